@@ -89,20 +89,76 @@ func (d *Decoder) Decode() (value cadence.Value, err error) {
 	return value, nil
 }
 
+// DecodeType returns a Cadence type decoded from its JSON-encoded representation.
+//
+// This function returns an error if the bytes represent JSON that is malformed
+// or does not conform to the JSON Cadence specification.
+func DecodeType(b []byte) (cadence.Type, error) {
+	r := bytes.NewReader(b)
+	dec := NewDecoder(r)
+
+	t, err := dec.DecodeType()
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// DecodeType reads JSON-encoded bytes from the io.Reader and decodes them to a
+// Cadence type.
+//
+// This function returns an error if the bytes represent JSON that is malformed
+// or does not conform to the JSON Cadence specification.
+func (d *Decoder) DecodeType() (t cadence.Type, err error) {
+	jsonMap := make(map[string]interface{})
+
+	err = d.dec.Decode(&jsonMap)
+	if err != nil {
+		return nil, fmt.Errorf("json-cdc: failed to decode valid JSON structure: %w", err)
+	}
+
+	// capture panics that occur during decoding
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr, isError := r.(error)
+			if !isError {
+				panic(r)
+			}
+
+			err = fmt.Errorf("failed to decode type: %w", panicErr)
+		}
+	}()
+
+	t = decodeTypeJSON(jsonMap)
+	return t, nil
+}
+
 const (
-	typeKey       = "type"
-	valueKey      = "value"
-	keyKey        = "key"
-	nameKey       = "name"
-	fieldsKey     = "fields"
-	idKey         = "id"
-	targetPathKey = "targetPath"
-	borrowTypeKey = "borrowType"
-	domainKey     = "domain"
-	identifierKey = "identifier"
-	staticTypeKey = "staticType"
-	addressKey    = "address"
-	pathKey       = "path"
+	typeKey                 = "type"
+	valueKey                = "value"
+	keyKey                  = "key"
+	nameKey                 = "name"
+	fieldsKey               = "fields"
+	idKey                   = "id"
+	targetPathKey           = "targetPath"
+	borrowTypeKey           = "borrowType"
+	borrowTypeDescriptorKey = "borrowTypeDescriptor"
+	domainKey               = "domain"
+	identifierKey           = "identifier"
+	staticTypeKey           = "staticType"
+	staticTypeDescriptorKey = "staticTypeDescriptor"
+	addressKey              = "address"
+	pathKey                 = "path"
+	kindKey                 = "kind"
+	typeIDKey               = "typeID"
+	initializersKey         = "initializers"
+	labelKey                = "label"
+	parametersKey           = "parameters"
+	returnKey               = "return"
+	authorizedKey           = "authorized"
+	restrictionsKey         = "restrictions"
+	sizeKey                 = "size"
 )
 
 var ErrInvalidJSONCadence = errors.New("invalid JSON Cadence structure")
@@ -173,6 +229,10 @@ func decodeJSON(v interface{}) cadence.Value {
 		return decodeFix64(valueJSON)
 	case ufix64TypeStr:
 		return decodeUFix64(valueJSON)
+	case fix128TypeStr:
+		return decodeFix128(valueJSON)
+	case ufix128TypeStr:
+		return decodeUFix128(valueJSON)
 	case arrayTypeStr:
 		return decodeArray(valueJSON)
 	case dictionaryTypeStr:
@@ -477,6 +537,24 @@ func decodeUFix64(valueJSON interface{}) cadence.UFix64 {
 	return v
 }
 
+func decodeFix128(valueJSON interface{}) cadence.Fix128 {
+	v, err := cadence.NewFix128(toString(valueJSON))
+	if err != nil {
+		// TODO: improve error message
+		panic(ErrInvalidJSONCadence)
+	}
+	return v
+}
+
+func decodeUFix128(valueJSON interface{}) cadence.UFix128 {
+	v, err := cadence.NewUFix128(toString(valueJSON))
+	if err != nil {
+		// TODO: improve error message
+		panic(ErrInvalidJSONCadence)
+	}
+	return v
+}
+
 func decodeValues(valueJSON interface{}) []cadence.Value {
 	v := toSlice(valueJSON)
 
@@ -656,7 +734,8 @@ func decodeTypeValue(valueJSON interface{}) cadence.TypeValue {
 	}
 
 	return cadence.TypeValue{
-		StaticType: staticType,
+		StaticType:           staticType,
+		StaticTypeDescriptor: decodeTypeDescriptor(obj, staticTypeDescriptorKey),
 	}
 }
 
@@ -670,10 +749,366 @@ func decodeCapability(valueJSON interface{}) cadence.Capability {
 	}
 
 	return cadence.Capability{
-		Path:       path,
-		Address:    decodeAddress(obj.Get(addressKey)),
-		BorrowType: obj.GetString(borrowTypeKey),
+		Path:                 path,
+		Address:              decodeAddress(obj.Get(addressKey)),
+		BorrowType:           obj.GetString(borrowTypeKey),
+		BorrowTypeDescriptor: decodeTypeDescriptor(obj, borrowTypeDescriptorKey),
+	}
+}
+
+// decodeTypeDescriptor decodes the full static type of a type value or borrow type of a capability.
+//
+// The field is optional, e.g. it is not included by older encoders,
+// so nil is returned if it is missing
+//
+func decodeTypeDescriptor(obj jsonObject, key string) cadence.Type {
+	typeDescriptorProperty, ok := obj[key]
+	if !ok || typeDescriptorProperty == nil {
+		return nil
+	}
+
+	return decodeTypeJSON(typeDescriptorProperty)
+}
+
+// Types
+
+var simpleTypes = func() map[string]cadence.Type {
+	types := []cadence.Type{
+		cadence.AnyType{},
+		cadence.AnyStructType{},
+		cadence.AnyResourceType{},
+		cadence.MetaType{},
+		cadence.VoidType{},
+		cadence.NeverType{},
+		cadence.BoolType{},
+		cadence.StringType{},
+		cadence.CharacterType{},
+		cadence.BytesType{},
+		cadence.AddressType{},
+		cadence.NumberType{},
+		cadence.SignedNumberType{},
+		cadence.IntegerType{},
+		cadence.SignedIntegerType{},
+		cadence.FixedPointType{},
+		cadence.SignedFixedPointType{},
+		cadence.IntType{},
+		cadence.Int8Type{},
+		cadence.Int16Type{},
+		cadence.Int32Type{},
+		cadence.Int64Type{},
+		cadence.Int128Type{},
+		cadence.Int256Type{},
+		cadence.UIntType{},
+		cadence.UInt8Type{},
+		cadence.UInt16Type{},
+		cadence.UInt32Type{},
+		cadence.UInt64Type{},
+		cadence.UInt128Type{},
+		cadence.UInt256Type{},
+		cadence.Word8Type{},
+		cadence.Word16Type{},
+		cadence.Word32Type{},
+		cadence.Word64Type{},
+		cadence.Fix64Type{},
+		cadence.UFix64Type{},
+		cadence.Fix128Type{},
+		cadence.UFix128Type{},
+		cadence.BlockType{},
+		cadence.PathType{},
+		cadence.CapabilityPathType{},
+		cadence.StoragePathType{},
+		cadence.PublicPathType{},
+		cadence.PrivatePathType{},
+	}
+
+	result := make(map[string]cadence.Type, len(types))
+	for _, ty := range types {
+		result[ty.ID()] = ty
+	}
+	return result
+}()
+
+// decodeTypeJSON decodes the given unmarshalled JSON representation of a type,
+// as produced by PrepareType.
+//
+// An empty string decodes to nil, as it represents a type which is not provided,
+// e.g. the borrow type of a capability
+func decodeTypeJSON(v interface{}) cadence.Type {
+	return decodeType(v, map[string]cadence.Type{})
+}
+
+// decodeType decodes the given JSON representation of a type.
+//
+// The given results are the nominal types which were already decoded, by type ID:
+// Nominal types are only fully represented the first time they occur,
+// and subsequent occurrences are represented by their type ID
+//
+func decodeType(v interface{}, results map[string]cadence.Type) cadence.Type {
+
+	if typeID, ok := v.(string); ok {
+		if typeID == "" {
+			return nil
+		}
+
+		result, ok := results[typeID]
+		if !ok {
+			panic(fmt.Errorf("%s. unknown type ID: `%s`", ErrInvalidJSONCadence, typeID))
+		}
+		return result
+	}
+
+	obj := toObject(v)
+
+	kind := obj.GetString(kindKey)
+
+	if result, ok := simpleTypes[kind]; ok {
+		return result
+	}
+
+	switch kind {
+	case optionalTypeStr:
+		return cadence.OptionalType{
+			Type: decodeType(obj.Get(typeKey), results),
+		}
+
+	case variableSizedArrayTypeStr:
+		return cadence.VariableSizedArrayType{
+			ElementType: decodeType(obj.Get(typeKey), results),
+		}
+
+	case constantSizedArrayTypeStr:
+		return cadence.ConstantSizedArrayType{
+			ElementType: decodeType(obj.Get(typeKey), results),
+			Size:        decodeTypeSize(obj.Get(sizeKey)),
+		}
+
+	case dictionaryTypeStr:
+		return cadence.DictionaryType{
+			KeyType:     decodeType(obj.Get(keyKey), results),
+			ElementType: decodeType(obj.Get(valueKey), results),
+		}
+
+	case structTypeStr,
+		resourceTypeStr,
+		eventTypeStr,
+		contractTypeStr,
+		enumTypeStr,
+		structInterfaceTypeStr,
+		resourceInterfaceTypeStr,
+		contractInterfaceTypeStr:
+
+		return decodeNominalType(kind, obj, results)
+
+	case functionTypeStr:
+		return cadence.Function{
+			Parameters: decodeParameterTypes(obj.Get(parametersKey), results),
+			ReturnType: decodeType(obj.Get(returnKey), results),
+		}.WithID(obj.GetString(typeIDKey))
+
+	case referenceTypeStr:
+		return cadence.ReferenceType{
+			Authorized: obj.GetBool(authorizedKey),
+			Type:       decodeType(obj.Get(typeKey), results),
+		}.WithID(decodeOptionalTypeID(obj))
+
+	case restrictedTypeStr:
+		restrictionsJSON := obj.GetSlice(restrictionsKey)
+
+		var restrictions []cadence.Type
+		if len(restrictionsJSON) > 0 {
+			restrictions = make([]cadence.Type, len(restrictionsJSON))
+			for i, restriction := range restrictionsJSON {
+				restrictions[i] = decodeType(restriction, results)
+			}
+		}
+
+		return cadence.RestrictedType{
+			Type:         decodeType(obj.Get(typeKey), results),
+			Restrictions: restrictions,
+		}.WithID(obj.GetString(typeIDKey))
+
+	case capabilityTypeStr:
+		return cadence.CapabilityType{
+			BorrowType: decodeType(obj.Get(typeKey), results),
+		}.WithID(decodeOptionalTypeID(obj))
+
+	default:
+		panic(fmt.Errorf("%s. unsupported type kind: `%s`", ErrInvalidJSONCadence, kind))
+	}
+}
+
+// decodeOptionalTypeID decodes the type ID of a reference or capability type.
+//
+// The type ID is omitted if it is empty
+//
+func decodeOptionalTypeID(obj jsonObject) string {
+	typeIDProperty, ok := obj[typeIDKey]
+	if !ok || typeIDProperty == nil {
+		return ""
+	}
+
+	return toString(typeIDProperty)
+}
+
+func decodeTypeSize(valueJSON interface{}) uint {
+	// JSON numbers are unmarshalled as float64
+	size, isNumber := valueJSON.(float64)
+	if !isNumber || size < 0 || size != float64(uint(size)) {
+		// TODO: improve error message
+		panic(ErrInvalidJSONCadence)
+	}
+
+	return uint(size)
+}
+
+func decodeNominalType(kind string, obj jsonObject, results map[string]cadence.Type) cadence.Type {
+
+	typeID := obj.GetString(typeIDKey)
+	location, qualifiedIdentifier, err := common.DecodeTypeID(typeID)
+
+	if err != nil ||
+		location == nil && sema.NativeCompositeTypes[typeID] == nil {
+
+		panic(fmt.Errorf("%s. invalid type ID: `%s`", ErrInvalidJSONCadence, typeID))
+	}
+
+	// The type is registered before its fields and initializers are decoded,
+	// as they may refer to the type itself
+
+	var result cadence.Type
+
+	switch kind {
+	case structTypeStr:
+		result = &cadence.StructType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}
+	case resourceTypeStr:
+		result = &cadence.ResourceType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}
+	case eventTypeStr:
+		result = &cadence.EventType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}
+	case contractTypeStr:
+		result = &cadence.ContractType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}
+	case enumTypeStr:
+		result = &cadence.EnumType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}
+	case structInterfaceTypeStr:
+		result = &cadence.StructInterfaceType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}
+	case resourceInterfaceTypeStr:
+		result = &cadence.ResourceInterfaceType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}
+	case contractInterfaceTypeStr:
+		result = &cadence.ContractInterfaceType{
+			Location:            location,
+			QualifiedIdentifier: qualifiedIdentifier,
+		}
+	default:
+		panic(fmt.Errorf("%s. unsupported type kind: `%s`", ErrInvalidJSONCadence, kind))
+	}
+
+	results[typeID] = result
+
+	fields := decodeFieldTypes(obj.Get(fieldsKey), results)
+	initializers := decodeInitializerTypes(obj.Get(initializersKey), results)
+
+	switch result := result.(type) {
+	case *cadence.StructType:
+		result.Fields = fields
+		result.Initializers = initializers
+	case *cadence.ResourceType:
+		result.Fields = fields
+		result.Initializers = initializers
+	case *cadence.EventType:
+		result.Fields = fields
+		// events have exactly one initializer
+		if len(initializers) > 0 {
+			result.Initializer = initializers[0]
+		}
+	case *cadence.ContractType:
+		result.Fields = fields
+		result.Initializers = initializers
+	case *cadence.EnumType:
+		result.RawType = decodeType(obj.Get(typeKey), results)
+		result.Fields = fields
+		result.Initializers = initializers
+	case *cadence.StructInterfaceType:
+		result.Fields = fields
+		result.Initializers = initializers
+	case *cadence.ResourceInterfaceType:
+		result.Fields = fields
+		result.Initializers = initializers
+	case *cadence.ContractInterfaceType:
+		result.Fields = fields
+		result.Initializers = initializers
+	}
+
+	return result
+}
+
+func decodeFieldTypes(valueJSON interface{}, results map[string]cadence.Type) []cadence.Field {
+	fieldsJSON := toSlice(valueJSON)
+	if len(fieldsJSON) == 0 {
+		return nil
+	}
+
+	fields := make([]cadence.Field, len(fieldsJSON))
+	for i, fieldJSON := range fieldsJSON {
+		obj := toObject(fieldJSON)
+
+		fields[i] = cadence.Field{
+			Identifier: obj.GetString(idKey),
+			Type:       decodeType(obj.Get(typeKey), results),
+		}
+	}
+	return fields
+}
+
+func decodeInitializerTypes(valueJSON interface{}, results map[string]cadence.Type) [][]cadence.Parameter {
+	initializersJSON := toSlice(valueJSON)
+	if len(initializersJSON) == 0 {
+		return nil
+	}
+
+	initializers := make([][]cadence.Parameter, len(initializersJSON))
+	for i, parametersJSON := range initializersJSON {
+		initializers[i] = decodeParameterTypes(parametersJSON, results)
+	}
+	return initializers
+}
+
+func decodeParameterTypes(valueJSON interface{}, results map[string]cadence.Type) []cadence.Parameter {
+	parametersJSON := toSlice(valueJSON)
+	if len(parametersJSON) == 0 {
+		return nil
+	}
+
+	parameters := make([]cadence.Parameter, len(parametersJSON))
+	for i, parameterJSON := range parametersJSON {
+		obj := toObject(parameterJSON)
+
+		parameters[i] = cadence.Parameter{
+			Label:      obj.GetString(labelKey),
+			Identifier: obj.GetString(idKey),
+			Type:       decodeType(obj.Get(typeKey), results),
+		}
 	}
+	return parameters
 }
 
 // JSON types
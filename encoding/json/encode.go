@@ -62,6 +62,31 @@ func MustEncode(value cadence.Value) []byte {
 	return b
 }
 
+// EncodeType returns the JSON-encoded representation of the given type.
+//
+// This function returns an error if the Cadence type cannot be represented as JSON.
+func EncodeType(t cadence.Type) ([]byte, error) {
+	var w bytes.Buffer
+	enc := NewEncoder(&w)
+
+	err := enc.EncodeType(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// MustEncodeType returns the JSON-encoded representation of the given type, or panics
+// if the type cannot be represented as JSON.
+func MustEncodeType(t cadence.Type) []byte {
+	b, err := EncodeType(t)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
 // NewEncoder initializes an Encoder that will write JSON-encoded bytes to the
 // given io.Writer.
 func NewEncoder(w io.Writer) *Encoder {
@@ -97,6 +122,35 @@ func (e *Encoder) Encode(value cadence.Value) (err error) {
 	return e.enc.Encode(&preparedValue)
 }
 
+// EncodeType writes the JSON-encoded representation of the given type to this
+// encoder's io.Writer.
+//
+// This function returns an error if the given type is not supported
+// by this encoder.
+func (e *Encoder) EncodeType(t cadence.Type) (err error) {
+	// capture panics that occur during struct preparation
+	defer func() {
+		if r := recover(); r != nil {
+			// don't recover Go errors
+			goErr, ok := r.(goRuntime.Error)
+			if ok {
+				panic(goErr)
+			}
+
+			panicErr, isError := r.(error)
+			if !isError {
+				panic(r)
+			}
+
+			err = fmt.Errorf("failed to encode type: %w", panicErr)
+		}
+	}()
+
+	preparedType := PrepareType(t)
+
+	return e.enc.Encode(&preparedType)
+}
+
 // JSON struct definitions
 
 type jsonValue interface{}
@@ -136,13 +190,82 @@ type jsonPathValue struct {
 }
 
 type jsonTypeValue struct {
-	StaticType string `json:"staticType"`
+	StaticType           string    `json:"staticType"`
+	StaticTypeDescriptor jsonValue `json:"staticTypeDescriptor,omitempty"`
 }
 
 type jsonCapabilityValue struct {
-	Path       jsonValue `json:"path"`
-	Address    string    `json:"address"`
-	BorrowType string    `json:"borrowType"`
+	Path                 jsonValue `json:"path"`
+	Address              string    `json:"address"`
+	BorrowType           string    `json:"borrowType"`
+	BorrowTypeDescriptor jsonValue `json:"borrowTypeDescriptor,omitempty"`
+}
+
+type jsonSimpleType struct {
+	Kind string `json:"kind"`
+}
+
+type jsonUnaryType struct {
+	Kind string    `json:"kind"`
+	Type jsonValue `json:"type"`
+}
+
+type jsonConstantSizedArrayType struct {
+	Kind string    `json:"kind"`
+	Type jsonValue `json:"type"`
+	Size uint      `json:"size"`
+}
+
+type jsonDictionaryType struct {
+	Kind      string    `json:"kind"`
+	KeyType   jsonValue `json:"key"`
+	ValueType jsonValue `json:"value"`
+}
+
+type jsonNominalType struct {
+	Kind         string                `json:"kind"`
+	Type         jsonValue             `json:"type"`
+	TypeID       string                `json:"typeID"`
+	Fields       []jsonFieldType       `json:"fields"`
+	Initializers [][]jsonParameterType `json:"initializers"`
+}
+
+type jsonFieldType struct {
+	ID   string    `json:"id"`
+	Type jsonValue `json:"type"`
+}
+
+type jsonParameterType struct {
+	Label string    `json:"label"`
+	ID    string    `json:"id"`
+	Type  jsonValue `json:"type"`
+}
+
+type jsonFunctionType struct {
+	Kind       string              `json:"kind"`
+	TypeID     string              `json:"typeID"`
+	Parameters []jsonParameterType `json:"parameters"`
+	Return     jsonValue           `json:"return"`
+}
+
+type jsonReferenceType struct {
+	Kind       string    `json:"kind"`
+	TypeID     string    `json:"typeID,omitempty"`
+	Authorized bool      `json:"authorized"`
+	Type       jsonValue `json:"type"`
+}
+
+type jsonRestrictedType struct {
+	Kind         string      `json:"kind"`
+	TypeID       string      `json:"typeID"`
+	Type         jsonValue   `json:"type"`
+	Restrictions []jsonValue `json:"restrictions"`
+}
+
+type jsonCapabilityType struct {
+	Kind   string    `json:"kind"`
+	TypeID string    `json:"typeID,omitempty"`
+	Type   jsonValue `json:"type"`
 }
 
 const (
@@ -171,6 +294,8 @@ const (
 	word64TypeStr     = "Word64"
 	fix64TypeStr      = "Fix64"
 	ufix64TypeStr     = "UFix64"
+	fix128TypeStr     = "Fix128"
+	ufix128TypeStr    = "UFix128"
 	arrayTypeStr      = "Array"
 	dictionaryTypeStr = "Dictionary"
 	structTypeStr     = "Struct"
@@ -238,6 +363,10 @@ func Prepare(v cadence.Value) jsonValue {
 		return prepareFix64(x)
 	case cadence.UFix64:
 		return prepareUFix64(x)
+	case cadence.Fix128:
+		return prepareFix128(x)
+	case cadence.UFix128:
+		return prepareUFix128(x)
 	case cadence.Array:
 		return prepareArray(x)
 	case cadence.Dictionary:
@@ -443,6 +572,20 @@ func prepareUFix64(v cadence.UFix64) jsonValue {
 	}
 }
 
+func prepareFix128(v cadence.Fix128) jsonValue {
+	return jsonValueObject{
+		Type:  fix128TypeStr,
+		Value: encodeFix128(v.Big()),
+	}
+}
+
+func prepareUFix128(v cadence.UFix128) jsonValue {
+	return jsonValueObject{
+		Type:  ufix128TypeStr,
+		Value: encodeFix128(v.Big()),
+	}
+}
+
 func prepareArray(v cadence.Array) jsonValue {
 	values := make([]jsonValue, len(v.Values))
 
@@ -554,7 +697,8 @@ func prepareTypeValue(x cadence.TypeValue) jsonValue {
 	return jsonValueObject{
 		Type: typeTypeStr,
 		Value: jsonTypeValue{
-			StaticType: x.StaticType,
+			StaticType:           x.StaticType,
+			StaticTypeDescriptor: prepareTypeDescriptor(x.StaticTypeDescriptor),
 		},
 	}
 }
@@ -563,13 +707,250 @@ func prepareCapability(x cadence.Capability) jsonValue {
 	return jsonValueObject{
 		Type: capabilityTypeStr,
 		Value: jsonCapabilityValue{
-			Path:       preparePath(x.Path),
-			Address:    encodeBytes(x.Address.Bytes()),
-			BorrowType: x.BorrowType,
+			Path:                 preparePath(x.Path),
+			Address:              encodeBytes(x.Address.Bytes()),
+			BorrowType:           x.BorrowType,
+			BorrowTypeDescriptor: prepareTypeDescriptor(x.BorrowTypeDescriptor),
 		},
 	}
 }
 
+// Type kinds which have no corresponding value kind
+
+const (
+	variableSizedArrayTypeStr = "VariableSizedArray"
+	constantSizedArrayTypeStr = "ConstantSizedArray"
+	structInterfaceTypeStr    = "StructInterface"
+	resourceInterfaceTypeStr  = "ResourceInterface"
+	contractInterfaceTypeStr  = "ContractInterface"
+	functionTypeStr           = "Function"
+	referenceTypeStr          = "Reference"
+	restrictedTypeStr         = "Restriction"
+)
+
+// PrepareType traverses the given type and constructs
+// a struct representation that can be marshalled to JSON.
+//
+// Nominal types, i.e. composite and interface types, are fully represented the first time they occur.
+// Subsequent occurrences, e.g. recursive occurrences in the type's fields,
+// are represented by their type ID
+func PrepareType(typ cadence.Type) jsonValue {
+	return prepareType(typ, map[string]struct{}{})
+}
+
+func prepareType(typ cadence.Type, results map[string]struct{}) jsonValue {
+
+	switch typ := typ.(type) {
+	case cadence.AnyType,
+		cadence.AnyStructType,
+		cadence.AnyResourceType,
+		cadence.MetaType,
+		cadence.VoidType,
+		cadence.NeverType,
+		cadence.BoolType,
+		cadence.StringType,
+		cadence.CharacterType,
+		cadence.BytesType,
+		cadence.AddressType,
+		cadence.NumberType,
+		cadence.SignedNumberType,
+		cadence.IntegerType,
+		cadence.SignedIntegerType,
+		cadence.FixedPointType,
+		cadence.SignedFixedPointType,
+		cadence.IntType,
+		cadence.Int8Type,
+		cadence.Int16Type,
+		cadence.Int32Type,
+		cadence.Int64Type,
+		cadence.Int128Type,
+		cadence.Int256Type,
+		cadence.UIntType,
+		cadence.UInt8Type,
+		cadence.UInt16Type,
+		cadence.UInt32Type,
+		cadence.UInt64Type,
+		cadence.UInt128Type,
+		cadence.UInt256Type,
+		cadence.Word8Type,
+		cadence.Word16Type,
+		cadence.Word32Type,
+		cadence.Word64Type,
+		cadence.Fix64Type,
+		cadence.UFix64Type,
+		cadence.Fix128Type,
+		cadence.UFix128Type,
+		cadence.BlockType,
+		cadence.PathType,
+		cadence.CapabilityPathType,
+		cadence.StoragePathType,
+		cadence.PublicPathType,
+		cadence.PrivatePathType:
+
+		return jsonSimpleType{
+			Kind: typ.ID(),
+		}
+
+	case cadence.OptionalType:
+		return jsonUnaryType{
+			Kind: optionalTypeStr,
+			Type: prepareType(typ.Type, results),
+		}
+
+	case cadence.VariableSizedArrayType:
+		return jsonUnaryType{
+			Kind: variableSizedArrayTypeStr,
+			Type: prepareType(typ.ElementType, results),
+		}
+
+	case cadence.ConstantSizedArrayType:
+		return jsonConstantSizedArrayType{
+			Kind: constantSizedArrayTypeStr,
+			Type: prepareType(typ.ElementType, results),
+			Size: typ.Size,
+		}
+
+	case cadence.DictionaryType:
+		return jsonDictionaryType{
+			Kind:      dictionaryTypeStr,
+			KeyType:   prepareType(typ.KeyType, results),
+			ValueType: prepareType(typ.ElementType, results),
+		}
+
+	case *cadence.StructType:
+		return prepareNominalType(structTypeStr, typ, nil, typ.Fields, typ.Initializers, results)
+
+	case *cadence.ResourceType:
+		return prepareNominalType(resourceTypeStr, typ, nil, typ.Fields, typ.Initializers, results)
+
+	case *cadence.EventType:
+		return prepareNominalType(eventTypeStr, typ, nil, typ.Fields, [][]cadence.Parameter{typ.Initializer}, results)
+
+	case *cadence.ContractType:
+		return prepareNominalType(contractTypeStr, typ, nil, typ.Fields, typ.Initializers, results)
+
+	case *cadence.EnumType:
+		return prepareNominalType(enumTypeStr, typ, typ.RawType, typ.Fields, typ.Initializers, results)
+
+	case *cadence.StructInterfaceType:
+		return prepareNominalType(structInterfaceTypeStr, typ, nil, typ.Fields, typ.Initializers, results)
+
+	case *cadence.ResourceInterfaceType:
+		return prepareNominalType(resourceInterfaceTypeStr, typ, nil, typ.Fields, typ.Initializers, results)
+
+	case *cadence.ContractInterfaceType:
+		return prepareNominalType(contractInterfaceTypeStr, typ, nil, typ.Fields, typ.Initializers, results)
+
+	case cadence.Function:
+		return jsonFunctionType{
+			Kind:       functionTypeStr,
+			TypeID:     typ.ID(),
+			Parameters: prepareParameterTypes(typ.Parameters, results),
+			Return:     prepareType(typ.ReturnType, results),
+		}
+
+	case cadence.ReferenceType:
+		return jsonReferenceType{
+			Kind:       referenceTypeStr,
+			TypeID:     typ.ID(),
+			Authorized: typ.Authorized,
+			Type:       prepareType(typ.Type, results),
+		}
+
+	case cadence.RestrictedType:
+		restrictions := make([]jsonValue, len(typ.Restrictions))
+		for i, restriction := range typ.Restrictions {
+			restrictions[i] = prepareType(restriction, results)
+		}
+
+		return jsonRestrictedType{
+			Kind:         restrictedTypeStr,
+			TypeID:       typ.ID(),
+			Type:         prepareType(typ.Type, results),
+			Restrictions: restrictions,
+		}
+
+	case cadence.CapabilityType:
+		return jsonCapabilityType{
+			Kind:   capabilityTypeStr,
+			TypeID: typ.ID(),
+			Type:   prepareOptionalType(typ.BorrowType, results),
+		}
+
+	default:
+		panic(fmt.Errorf("unsupported type: %T, %v", typ, typ))
+	}
+}
+
+// prepareOptionalType prepares the given type,
+// or returns an empty string if the type is not provided
+func prepareOptionalType(typ cadence.Type, results map[string]struct{}) jsonValue {
+	if typ == nil {
+		return ""
+	}
+	return prepareType(typ, results)
+}
+
+// prepareTypeDescriptor prepares the full static type of a type value or borrow type of a capability,
+// or returns nil if the type is not provided, so the field is omitted
+//
+func prepareTypeDescriptor(typ cadence.Type) jsonValue {
+	if typ == nil {
+		return nil
+	}
+	return prepareType(typ, map[string]struct{}{})
+}
+
+func prepareNominalType(
+	kind string,
+	typ cadence.Type,
+	rawType cadence.Type,
+	fields []cadence.Field,
+	initializers [][]cadence.Parameter,
+	results map[string]struct{},
+) jsonValue {
+
+	typeID := typ.ID()
+
+	if _, ok := results[typeID]; ok {
+		return typeID
+	}
+	results[typeID] = struct{}{}
+
+	fieldTypes := make([]jsonFieldType, len(fields))
+	for i, field := range fields {
+		fieldTypes[i] = jsonFieldType{
+			ID:   field.Identifier,
+			Type: prepareType(field.Type, results),
+		}
+	}
+
+	initializerTypes := make([][]jsonParameterType, len(initializers))
+	for i, parameters := range initializers {
+		initializerTypes[i] = prepareParameterTypes(parameters, results)
+	}
+
+	return jsonNominalType{
+		Kind:         kind,
+		Type:         prepareOptionalType(rawType, results),
+		TypeID:       typeID,
+		Fields:       fieldTypes,
+		Initializers: initializerTypes,
+	}
+}
+
+func prepareParameterTypes(parameters []cadence.Parameter, results map[string]struct{}) []jsonParameterType {
+	parameterTypes := make([]jsonParameterType, len(parameters))
+	for i, parameter := range parameters {
+		parameterTypes[i] = jsonParameterType{
+			Label: parameter.Label,
+			ID:    parameter.Identifier,
+			Type:  prepareType(parameter.Type, results),
+		}
+	}
+	return parameterTypes
+}
+
 func encodeBytes(v []byte) string {
 	return fmt.Sprintf("0x%x", v)
 }
@@ -620,3 +1001,26 @@ func encodeUFix64(v uint64) string {
 		fraction,
 	)
 }
+
+func encodeFix128(v *big.Int) string {
+	integer, fraction := new(big.Int).QuoRem(v, sema.Fix128FactorBig, new(big.Int))
+
+	negative := fraction.Sign() < 0
+
+	var builder strings.Builder
+
+	if negative {
+		fraction.Neg(fraction)
+		if integer.Sign() == 0 {
+			builder.WriteRune('-')
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf(
+		"%d.%024d",
+		integer,
+		fraction,
+	))
+
+	return builder.String()
+}
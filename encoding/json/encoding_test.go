@@ -538,6 +538,63 @@ func TestEncodeUFix64(t *testing.T) {
 	}...)
 }
 
+func TestEncodeFix128(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{
+			"Zero",
+			mustNewFix128("0.0"),
+			`{"type":"Fix128","value":"0.000000000000000000000000"}`,
+		},
+		{
+			"789.000000000000000000123010",
+			mustNewFix128("789.00000000000000000012301"),
+			`{"type":"Fix128","value":"789.000000000000000000123010"}`,
+		},
+		{
+			"-0.5",
+			mustNewFix128("-0.5"),
+			`{"type":"Fix128","value":"-0.500000000000000000000000"}`,
+		},
+	}...)
+}
+
+func TestEncodeUFix128(t *testing.T) {
+
+	t.Parallel()
+
+	testAllEncodeAndDecode(t, []encodeTest{
+		{
+			"Zero",
+			mustNewUFix128("0.0"),
+			`{"type":"UFix128","value":"0.000000000000000000000000"}`,
+		},
+		{
+			"1234.056",
+			mustNewUFix128("1234.056"),
+			`{"type":"UFix128","value":"1234.056000000000000000000000"}`,
+		},
+	}...)
+}
+
+func mustNewFix128(s string) cadence.Fix128 {
+	value, err := cadence.NewFix128(s)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+func mustNewUFix128(s string) cadence.UFix128 {
+	value, err := cadence.NewUFix128(s)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
 func TestEncodeArray(t *testing.T) {
 
 	t.Parallel()
@@ -1000,6 +1057,31 @@ func TestEncodeType(t *testing.T) {
 			`{"type":"Type","value":{"staticType":""}}`,
 		)
 	})
+
+	t.Run("with static type descriptor", func(t *testing.T) {
+
+		t.Parallel()
+
+		testEncodeAndDecode(
+			t,
+			cadence.TypeValue{
+				StaticType: "S.test.S",
+				StaticTypeDescriptor: &cadence.StructType{
+					Location:            utils.TestLocation,
+					QualifiedIdentifier: "S",
+					Fields: []cadence.Field{
+						{Identifier: "id", Type: cadence.UInt64Type{}},
+					},
+					Initializers: [][]cadence.Parameter{
+						{
+							{Label: "_", Identifier: "id", Type: cadence.UInt64Type{}},
+						},
+					},
+				},
+			},
+			`{"type":"Type","value":{"staticType":"S.test.S","staticTypeDescriptor":{"kind":"Struct","type":"","typeID":"S.test.S","fields":[{"id":"id","type":{"kind":"UInt64"}}],"initializers":[[{"label":"_","id":"id","type":{"kind":"UInt64"}}]]}}}`,
+		)
+	})
 }
 
 func TestEncodeCapability(t *testing.T) {
@@ -1017,6 +1099,416 @@ func TestEncodeCapability(t *testing.T) {
 	)
 }
 
+func TestEncodeCapabilityWithBorrowTypeDescriptor(t *testing.T) {
+
+	t.Parallel()
+
+	testEncodeAndDecode(
+		t,
+		cadence.Capability{
+			Path:       cadence.Path{Domain: "storage", Identifier: "foo"},
+			Address:    cadence.BytesToAddress([]byte{1, 2, 3, 4, 5}),
+			BorrowType: "&Int",
+			BorrowTypeDescriptor: cadence.ReferenceType{
+				Type: cadence.IntType{},
+			}.WithID("&Int"),
+		},
+		`{"type":"Capability","value":{"path":{"type":"Path","value":{"domain":"storage","identifier":"foo"}},"borrowType":"&Int","borrowTypeDescriptor":{"kind":"Reference","typeID":"&Int","authorized":false,"type":{"kind":"Int"}},"address":"0x0000000102030405"}}`,
+	)
+}
+
+func TestEncodeTypeDescriptors(t *testing.T) {
+
+	t.Parallel()
+
+	testEncodeType := func(t *testing.T, ty cadence.Type, expectedJSON string) {
+		actualJSON, err := json.EncodeType(ty)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, expectedJSON, string(actualJSON))
+
+		decodedType, err := json.DecodeType(actualJSON)
+		require.NoError(t, err)
+
+		assert.Equal(t, ty, decodedType)
+	}
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		testEncodeType(t, cadence.UFix64Type{}, `{"kind":"UFix64"}`)
+		testEncodeType(t, cadence.AnyResourceType{}, `{"kind":"AnyResource"}`)
+		testEncodeType(t, cadence.StoragePathType{}, `{"kind":"StoragePath"}`)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+
+		t.Parallel()
+
+		testEncodeType(t,
+			cadence.OptionalType{Type: cadence.StringType{}},
+			`{"kind":"Optional","type":{"kind":"String"}}`,
+		)
+	})
+
+	t.Run("arrays and dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		testEncodeType(t,
+			cadence.VariableSizedArrayType{ElementType: cadence.IntType{}},
+			`{"kind":"VariableSizedArray","type":{"kind":"Int"}}`,
+		)
+
+		testEncodeType(t,
+			cadence.ConstantSizedArrayType{Size: 3, ElementType: cadence.IntType{}},
+			`{"kind":"ConstantSizedArray","type":{"kind":"Int"},"size":3}`,
+		)
+
+		testEncodeType(t,
+			cadence.DictionaryType{KeyType: cadence.StringType{}, ElementType: cadence.AddressType{}},
+			`{"kind":"Dictionary","key":{"kind":"String"},"value":{"kind":"Address"}}`,
+		)
+	})
+
+	t.Run("composite", func(t *testing.T) {
+
+		t.Parallel()
+
+		testEncodeType(t,
+			&cadence.StructType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "S",
+				Fields: []cadence.Field{
+					{Identifier: "id", Type: cadence.UInt64Type{}},
+				},
+				Initializers: [][]cadence.Parameter{
+					{
+						{Label: "_", Identifier: "id", Type: cadence.UInt64Type{}},
+					},
+				},
+			},
+			`
+              {
+                "kind": "Struct",
+                "type": "",
+                "typeID": "S.test.S",
+                "fields": [
+                  {"id": "id", "type": {"kind": "UInt64"}}
+                ],
+                "initializers": [
+                  [
+                    {"label": "_", "id": "id", "type": {"kind": "UInt64"}}
+                  ]
+                ]
+              }
+            `,
+		)
+	})
+
+	t.Run("enum", func(t *testing.T) {
+
+		t.Parallel()
+
+		testEncodeType(t,
+			&cadence.EnumType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "E",
+				RawType:             cadence.UInt8Type{},
+				Fields: []cadence.Field{
+					{Identifier: "rawValue", Type: cadence.UInt8Type{}},
+				},
+			},
+			`
+              {
+                "kind": "Enum",
+                "type": {"kind": "UInt8"},
+                "typeID": "S.test.E",
+                "fields": [
+                  {"id": "rawValue", "type": {"kind": "UInt8"}}
+                ],
+                "initializers": []
+              }
+            `,
+		)
+	})
+
+	t.Run("recursive", func(t *testing.T) {
+
+		t.Parallel()
+
+		ty := &cadence.ResourceType{
+			Location:            utils.TestLocation,
+			QualifiedIdentifier: "Foo",
+			Fields: []cadence.Field{
+				{
+					Identifier: "foo",
+				},
+			},
+		}
+
+		ty.Fields[0].Type = cadence.OptionalType{
+			Type: ty,
+		}
+
+		testEncodeType(t,
+			ty,
+			`
+              {
+                "kind": "Resource",
+                "type": "",
+                "typeID": "S.test.Foo",
+                "fields": [
+                  {"id": "foo", "type": {"kind": "Optional", "type": "S.test.Foo"}}
+                ],
+                "initializers": []
+              }
+            `,
+		)
+	})
+
+	t.Run("function", func(t *testing.T) {
+
+		t.Parallel()
+
+		testEncodeType(t,
+			cadence.Function{
+				Parameters: []cadence.Parameter{
+					{Label: "to", Identifier: "address", Type: cadence.AddressType{}},
+				},
+				ReturnType: cadence.BoolType{},
+			}.WithID("((Address):Bool)"),
+			`
+              {
+                "kind": "Function",
+                "typeID": "((Address):Bool)",
+                "parameters": [
+                  {"label": "to", "id": "address", "type": {"kind": "Address"}}
+                ],
+                "return": {"kind": "Bool"}
+              }
+            `,
+		)
+	})
+
+	t.Run("reference, restricted, and capability", func(t *testing.T) {
+
+		t.Parallel()
+
+		interfaceType := &cadence.ResourceInterfaceType{
+			Location:            utils.TestLocation,
+			QualifiedIdentifier: "I",
+		}
+
+		restrictedType := cadence.RestrictedType{
+			Type:         cadence.AnyResourceType{},
+			Restrictions: []cadence.Type{interfaceType},
+		}.WithID("AnyResource{S.test.I}")
+
+		testEncodeType(t,
+			cadence.CapabilityType{
+				BorrowType: cadence.ReferenceType{
+					Authorized: true,
+					Type:       restrictedType,
+				}.WithID("auth &AnyResource{S.test.I}"),
+			}.WithID("Capability<auth &AnyResource{S.test.I}>"),
+			`
+              {
+                "kind": "Capability",
+                "typeID": "Capability<auth &AnyResource{S.test.I}>",
+                "type": {
+                  "kind": "Reference",
+                  "typeID": "auth &AnyResource{S.test.I}",
+                  "authorized": true,
+                  "type": {
+                    "kind": "Restriction",
+                    "typeID": "AnyResource{S.test.I}",
+                    "type": {"kind": "AnyResource"},
+                    "restrictions": [
+                      {
+                        "kind": "ResourceInterface",
+                        "type": "",
+                        "typeID": "S.test.I",
+                        "fields": [],
+                        "initializers": []
+                      }
+                    ]
+                  }
+                }
+              }
+            `,
+		)
+
+		testEncodeType(t,
+			cadence.CapabilityType{},
+			`{"kind":"Capability","type":""}`,
+		)
+	})
+
+	t.Run("event", func(t *testing.T) {
+
+		t.Parallel()
+
+		testEncodeType(t,
+			&cadence.EventType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "C.Deposited",
+				Fields: []cadence.Field{
+					{Identifier: "amount", Type: cadence.UFix64Type{}},
+					{Identifier: "to", Type: cadence.OptionalType{Type: cadence.AddressType{}}},
+				},
+				Initializer: []cadence.Parameter{
+					{Label: "amount", Identifier: "amount", Type: cadence.UFix64Type{}},
+					{Label: "to", Identifier: "to", Type: cadence.OptionalType{Type: cadence.AddressType{}}},
+				},
+			},
+			`
+              {
+                "kind": "Event",
+                "type": "",
+                "typeID": "S.test.C.Deposited",
+                "fields": [
+                  {"id": "amount", "type": {"kind": "UFix64"}},
+                  {"id": "to", "type": {"kind": "Optional", "type": {"kind": "Address"}}}
+                ],
+                "initializers": [
+                  [
+                    {"label": "amount", "id": "amount", "type": {"kind": "UFix64"}},
+                    {"label": "to", "id": "to", "type": {"kind": "Optional", "type": {"kind": "Address"}}}
+                  ]
+                ]
+              }
+            `,
+		)
+	})
+
+	t.Run("contract and interfaces", func(t *testing.T) {
+
+		t.Parallel()
+
+		providerType := &cadence.ResourceInterfaceType{
+			Location:            utils.TestLocation,
+			QualifiedIdentifier: "C.Provider",
+			Fields: []cadence.Field{
+				{Identifier: "balance", Type: cadence.UFix64Type{}},
+			},
+		}
+
+		testEncodeType(t,
+			&cadence.ContractType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "C",
+				Fields: []cadence.Field{
+					{
+						Identifier: "providers",
+						Type: cadence.VariableSizedArrayType{
+							ElementType: cadence.RestrictedType{
+								Type:         cadence.AnyResourceType{},
+								Restrictions: []cadence.Type{providerType},
+							}.WithID("AnyResource{S.test.C.Provider}"),
+						},
+					},
+					{
+						Identifier: "provider",
+						Type: cadence.RestrictedType{
+							Type:         cadence.AnyResourceType{},
+							Restrictions: []cadence.Type{providerType},
+						}.WithID("AnyResource{S.test.C.Provider}"),
+					},
+					{
+						Identifier: "info",
+						Type: &cadence.StructInterfaceType{
+							Location:            utils.TestLocation,
+							QualifiedIdentifier: "C.Info",
+						},
+					},
+				},
+			},
+			`
+              {
+                "kind": "Contract",
+                "type": "",
+                "typeID": "S.test.C",
+                "fields": [
+                  {
+                    "id": "providers",
+                    "type": {
+                      "kind": "VariableSizedArray",
+                      "type": {
+                        "kind": "Restriction",
+                        "typeID": "AnyResource{S.test.C.Provider}",
+                        "type": {"kind": "AnyResource"},
+                        "restrictions": [
+                          {
+                            "kind": "ResourceInterface",
+                            "type": "",
+                            "typeID": "S.test.C.Provider",
+                            "fields": [
+                              {"id": "balance", "type": {"kind": "UFix64"}}
+                            ],
+                            "initializers": []
+                          }
+                        ]
+                      }
+                    }
+                  },
+                  {
+                    "id": "provider",
+                    "type": {
+                      "kind": "Restriction",
+                      "typeID": "AnyResource{S.test.C.Provider}",
+                      "type": {"kind": "AnyResource"},
+                      "restrictions": ["S.test.C.Provider"]
+                    }
+                  },
+                  {
+                    "id": "info",
+                    "type": {
+                      "kind": "StructInterface",
+                      "type": "",
+                      "typeID": "S.test.C.Info",
+                      "fields": [],
+                      "initializers": []
+                    }
+                  }
+                ],
+                "initializers": []
+              }
+            `,
+		)
+	})
+
+	t.Run("decode invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		for _, invalidJSON := range []string{
+			// unknown kind
+			`{"kind":"Foo"}`,
+			// reference to a type which was not decoded before
+			`{"kind":"Optional","type":"S.test.Foo"}`,
+			// invalid type ID
+			`{"kind":"Struct","type":"","typeID":"Foo","fields":[],"initializers":[]}`,
+			// invalid size
+			`{"kind":"ConstantSizedArray","type":{"kind":"Int"},"size":-1}`,
+			// missing key
+			`{"kind":"Dictionary","key":{"kind":"String"}}`,
+		} {
+			_, err := json.DecodeType([]byte(invalidJSON))
+			assert.Error(t, err, invalidJSON)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := json.EncodeType(cadence.ResourcePointer{TypeName: "R"})
+		require.Error(t, err)
+	})
+}
+
 func TestDecodeFixedPoints(t *testing.T) {
 
 	t.Parallel()
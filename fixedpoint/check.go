@@ -54,8 +54,60 @@ const UFix64TypeMaxFractional = math.MaxUint64 % uint64(Fix64Factor)
 var UFix64TypeMinFractionalBig = new(big.Int).SetUint64(UFix64TypeMinFractional)
 var UFix64TypeMaxFractionalBig = new(big.Int).SetUint64(UFix64TypeMaxFractional)
 
+// Fix128
+
+const Fix128Scale uint = 24
+
+var Fix128FactorBig = new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(Fix128Scale)), nil)
+
+var Fix128TypeMinBig = func() *big.Int {
+	fix128TypeMin := big.NewInt(-1)
+	fix128TypeMin.Lsh(fix128TypeMin, 127)
+	return fix128TypeMin
+}()
+
+var Fix128TypeMaxBig = func() *big.Int {
+	fix128TypeMax := big.NewInt(1)
+	fix128TypeMax.Lsh(fix128TypeMax, 127)
+	fix128TypeMax.Sub(fix128TypeMax, big.NewInt(1))
+	return fix128TypeMax
+}()
+
+var Fix128TypeMinIntBig, Fix128TypeMinFractionalBig = new(big.Int).QuoRem(
+	Fix128TypeMinBig,
+	Fix128FactorBig,
+	new(big.Int),
+)
+
+var Fix128TypeMaxIntBig, Fix128TypeMaxFractionalBig = new(big.Int).QuoRem(
+	Fix128TypeMaxBig,
+	Fix128FactorBig,
+	new(big.Int),
+)
+
+// UFix128
+
+var UFix128TypeMinBig = new(big.Int)
+
+var UFix128TypeMaxBig = func() *big.Int {
+	ufix128TypeMax := big.NewInt(1)
+	ufix128TypeMax.Lsh(ufix128TypeMax, 128)
+	ufix128TypeMax.Sub(ufix128TypeMax, big.NewInt(1))
+	return ufix128TypeMax
+}()
+
+var UFix128TypeMinIntBig = new(big.Int)
+var UFix128TypeMinFractionalBig = new(big.Int)
+
+var UFix128TypeMaxIntBig, UFix128TypeMaxFractionalBig = new(big.Int).QuoRem(
+	UFix128TypeMaxBig,
+	Fix128FactorBig,
+	new(big.Int),
+)
+
 func init() {
 	Fix64TypeMinFractionalBig.Abs(Fix64TypeMinFractionalBig)
+	Fix128TypeMinFractionalBig.Abs(Fix128TypeMinFractionalBig)
 }
 
 func CheckRange(
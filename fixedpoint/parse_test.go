@@ -19,6 +19,7 @@
 package fixedpoint
 
 import (
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -57,3 +58,46 @@ func TestParseFixedPoint(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFix128(t *testing.T) {
+
+	t.Parallel()
+
+	assert.Equal(t,
+		"-170141183460469.231731687303715884105728",
+		fmt.Sprintf("%s.%s", Fix128TypeMinIntBig, Fix128TypeMinFractionalBig),
+	)
+
+	assert.Equal(t,
+		"170141183460469.231731687303715884105727",
+		fmt.Sprintf("%s.%s", Fix128TypeMaxIntBig, Fix128TypeMaxFractionalBig),
+	)
+
+	assert.Equal(t,
+		"340282366920938.463463374607431768211455",
+		fmt.Sprintf("%s.%s", UFix128TypeMaxIntBig, UFix128TypeMaxFractionalBig),
+	)
+
+	_, err := ParseFix128("170141183460469.231731687303715884105727")
+	assert.NoError(t, err)
+
+	_, err = ParseFix128("170141183460469.231731687303715884105728")
+	assert.Error(t, err)
+
+	_, err = ParseFix128("-170141183460469.231731687303715884105728")
+	assert.NoError(t, err)
+
+	_, err = ParseUFix128("340282366920938.463463374607431768211455")
+	assert.NoError(t, err)
+
+	_, err = ParseUFix128("340282366920938.463463374607431768211456")
+	assert.Error(t, err)
+
+	_, err = ParseUFix128("-1.0")
+	assert.Error(t, err)
+
+	// scale is limited to 24 fractional digits
+
+	_, err = ParseFix128("0.0000000000000000000000001")
+	assert.Error(t, err)
+}
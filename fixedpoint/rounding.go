@@ -0,0 +1,139 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixedpoint
+
+import (
+	"math/big"
+)
+
+// RoundingRule is the rule which is used to round the result
+// of a fixed-point operation which is not exactly representable
+//
+type RoundingRule uint8
+
+const (
+	// RoundTowardZero rounds to the nearest representable value
+	// whose magnitude is not greater than the exact result, i.e. it truncates.
+	// This is the rounding rule of the arithmetic operators
+	RoundTowardZero RoundingRule = iota
+	// RoundAwayFromZero rounds to the nearest representable value
+	// whose magnitude is not less than the exact result
+	RoundAwayFromZero
+	// RoundNearestHalfAway rounds to the nearest representable value,
+	// and if the exact result is halfway between two values,
+	// to the one with the greater magnitude
+	RoundNearestHalfAway
+	// RoundNearestHalfEven rounds to the nearest representable value,
+	// and if the exact result is halfway between two values,
+	// to the even one ("banker's rounding")
+	RoundNearestHalfEven
+)
+
+// RoundingRuleCount is the number of rounding rules
+//
+const RoundingRuleCount = RoundNearestHalfEven + 1
+
+func (r RoundingRule) String() string {
+	switch r {
+	case RoundTowardZero:
+		return "towardZero"
+	case RoundAwayFromZero:
+		return "awayFromZero"
+	case RoundNearestHalfAway:
+		return "nearestHalfAway"
+	case RoundNearestHalfEven:
+		return "nearestHalfEven"
+	}
+
+	panic("unknown rounding rule")
+}
+
+// DivRound returns the quotient numerator / denominator,
+// rounded using the given rounding rule.
+// The denominator must not be zero.
+//
+func DivRound(numerator, denominator *big.Int, rule RoundingRule) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	// The sign of the exact result
+
+	sign := numerator.Sign() * denominator.Sign()
+
+	roundAway := false
+
+	switch rule {
+	case RoundTowardZero:
+		roundAway = false
+
+	case RoundAwayFromZero:
+		roundAway = true
+
+	case RoundNearestHalfAway, RoundNearestHalfEven:
+		// Compare the remainder with half of the denominator,
+		// i.e. 2 * |remainder| with |denominator|
+
+		doubledRemainder := new(big.Int).Abs(remainder)
+		doubledRemainder.Lsh(doubledRemainder, 1)
+
+		switch doubledRemainder.Cmp(new(big.Int).Abs(denominator)) {
+		case -1:
+			roundAway = false
+		case 1:
+			roundAway = true
+		case 0:
+			if rule == RoundNearestHalfAway {
+				roundAway = true
+			} else {
+				roundAway = quotient.Bit(0) == 1
+			}
+		}
+
+	default:
+		panic("unknown rounding rule")
+	}
+
+	if roundAway {
+		quotient.Add(quotient, big.NewInt(int64(sign)))
+	}
+
+	return quotient
+}
+
+// MulRound returns the product of the given fixed-point numbers,
+// which have the given scale factor, i.e. a * b / factor,
+// rounded using the given rounding rule
+//
+func MulRound(a, b, factor *big.Int, rule RoundingRule) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	return DivRound(product, factor, rule)
+}
+
+// QuoRound returns the quotient of the given fixed-point numbers,
+// which have the given scale factor, i.e. a * factor / b,
+// rounded using the given rounding rule.
+// The divisor b must not be zero.
+//
+func QuoRound(a, b, factor *big.Int, rule RoundingRule) *big.Int {
+	scaled := new(big.Int).Mul(a, factor)
+	return DivRound(scaled, b, rule)
+}
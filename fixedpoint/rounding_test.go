@@ -0,0 +1,142 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fixedpoint
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDivRound(t *testing.T) {
+
+	t.Parallel()
+
+	type testCase struct {
+		numerator   int64
+		denominator int64
+		expected    [RoundingRuleCount]int64
+	}
+
+	for _, testCase := range []testCase{
+		// exact
+		{6, 3, [...]int64{2, 2, 2, 2}},
+		// 2.5
+		{5, 2, [...]int64{2, 3, 3, 2}},
+		// 3.5
+		{7, 2, [...]int64{3, 4, 4, 4}},
+		// -2.5
+		{-5, 2, [...]int64{-2, -3, -3, -2}},
+		// -2.5
+		{5, -2, [...]int64{-2, -3, -3, -2}},
+		// 2.333..
+		{7, 3, [...]int64{2, 3, 2, 2}},
+		// 2.666..
+		{8, 3, [...]int64{2, 3, 3, 3}},
+		// -2.666..
+		{-8, 3, [...]int64{-2, -3, -3, -3}},
+		// 0.25
+		{1, 4, [...]int64{0, 1, 0, 0}},
+	} {
+		for rule := RoundingRule(0); rule < RoundingRuleCount; rule++ {
+
+			testName := fmt.Sprintf(
+				"%d / %d, %s",
+				testCase.numerator,
+				testCase.denominator,
+				rule,
+			)
+
+			// NOTE: don't capture loop variables
+			testCase := testCase
+			rule := rule
+
+			t.Run(testName, func(t *testing.T) {
+
+				t.Parallel()
+
+				assert.Equal(t,
+					testCase.expected[rule],
+					DivRound(
+						big.NewInt(testCase.numerator),
+						big.NewInt(testCase.denominator),
+						rule,
+					).Int64(),
+				)
+			})
+		}
+	}
+}
+
+func TestMulRound(t *testing.T) {
+
+	t.Parallel()
+
+	factor := big.NewInt(100)
+
+	// 0.15 * 0.15 = 0.0225
+
+	assert.Equal(t,
+		int64(2),
+		MulRound(big.NewInt(15), big.NewInt(15), factor, RoundTowardZero).Int64(),
+	)
+
+	assert.Equal(t,
+		int64(3),
+		MulRound(big.NewInt(15), big.NewInt(15), factor, RoundAwayFromZero).Int64(),
+	)
+
+	assert.Equal(t,
+		int64(2),
+		MulRound(big.NewInt(15), big.NewInt(15), factor, RoundNearestHalfAway).Int64(),
+	)
+}
+
+func TestQuoRound(t *testing.T) {
+
+	t.Parallel()
+
+	factor := big.NewInt(100)
+
+	// 2.00 / 3.00 = 0.666..
+
+	assert.Equal(t,
+		int64(66),
+		QuoRound(big.NewInt(200), big.NewInt(300), factor, RoundTowardZero).Int64(),
+	)
+
+	assert.Equal(t,
+		int64(67),
+		QuoRound(big.NewInt(200), big.NewInt(300), factor, RoundNearestHalfEven).Int64(),
+	)
+
+	// -0.01 / 2.00 = -0.005
+
+	assert.Equal(t,
+		int64(0),
+		QuoRound(big.NewInt(-1), big.NewInt(200), factor, RoundNearestHalfEven).Int64(),
+	)
+
+	assert.Equal(t,
+		int64(-1),
+		QuoRound(big.NewInt(-1), big.NewInt(200), factor, RoundNearestHalfAway).Int64(),
+	)
+}
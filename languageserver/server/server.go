@@ -31,6 +31,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/analysis"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
@@ -566,11 +567,19 @@ func (s *Server) Hover(
 
 	var markup strings.Builder
 
-	_, _ = fmt.Fprintf(
-		&markup,
-		"**Type**\n\n```cadence\n%s\n```\n",
-		documentType(occurrence.Origin.Type),
-	)
+	if occurrence.Origin.DeclarationKind == common.DeclarationKindTypeAlias {
+		_, _ = fmt.Fprintf(
+			&markup,
+			"**Type alias** for\n\n```cadence\n%s\n```\n",
+			occurrence.Origin.Type.QualifiedString(),
+		)
+	} else {
+		_, _ = fmt.Fprintf(
+			&markup,
+			"**Type**\n\n```cadence\n%s\n```\n",
+			documentType(occurrence.Origin.Type),
+		)
+	}
 
 	docString := occurrence.Origin.DocString
 	if docString != "" {
@@ -1861,9 +1870,81 @@ func (s *Server) getDiagnostics(
 		diagnostics = append(diagnostics, diagnostic)
 	}
 
+	// Only analyze programs which type-check,
+	// as the elaboration of programs with errors is incomplete
+
+	if checkError == nil {
+		analysisDiagnostics, err := analysis.Run(
+			lintAnalyzers,
+			analysis.ProgramFromChecker(checker),
+		)
+		if err != nil {
+			conn.LogMessage(&protocol.LogMessageParams{
+				Type:    protocol.Error,
+				Message: fmt.Sprintf("analysis of %s failed: %s", string(uri), err),
+			})
+		}
+
+		for _, analysisDiagnostic := range analysisDiagnostics {
+			diagnostics = append(diagnostics, convertAnalysisDiagnostic(analysisDiagnostic))
+		}
+	}
+
 	return
 }
 
+// lintAnalyzers are the analyzers which are run on checked programs.
+//
+// The analyzers for unnecessary force-unwraps and redundant casts are not included,
+// as these problems are already reported as checker hints, which provide quick fixes.
+//
+var lintAnalyzers = []*analysis.Analyzer{
+	analysis.UnusedVariableAnalyzer,
+	analysis.UnusedImportAnalyzer,
+	analysis.ShadowingAnalyzer,
+	analysis.MissingDocStringAnalyzer,
+	analysis.SecurityAnalyzer,
+}
+
+// convertAnalysisDiagnostic converts a diagnostic reported by an analyzer
+// to a protocol diagnostic
+//
+func convertAnalysisDiagnostic(diagnostic analysis.Diagnostic) protocol.Diagnostic {
+	message := diagnostic.Message
+	if diagnostic.SecondaryMessage != "" {
+		message = fmt.Sprintf("%s. %s", message, diagnostic.SecondaryMessage)
+	}
+
+	var severity protocol.DiagnosticSeverity
+	switch diagnostic.Severity {
+	case analysis.SeverityError:
+		severity = protocol.SeverityError
+	case analysis.SeverityInfo:
+		severity = protocol.SeverityInformation
+	default:
+		severity = protocol.SeverityWarning
+	}
+
+	protocolDiagnostic := protocol.Diagnostic{
+		Message:  message,
+		Severity: severity,
+		Code:     diagnostic.Category,
+		Source:   "cadence-lint",
+		Range:    conversion.ASTToProtocolRange(diagnostic.StartPos, diagnostic.EndPos),
+	}
+
+	// Unused declarations are rendered faded out by clients
+
+	switch diagnostic.Category {
+	case analysis.UnusedVariableAnalyzer.Name,
+		analysis.UnusedImportAnalyzer.Name:
+
+		protocolDiagnostic.Tags = []protocol.DiagnosticTag{protocol.Unnecessary}
+	}
+
+	return protocolDiagnostic
+}
+
 // getDiagnosticsForParentError unpacks all child errors and converts each to
 // a diagnostic. Both parser and checker errors can be unpacked.
 //
@@ -3011,7 +3092,76 @@ func convertHint(
 				},
 			}
 		}
+
+	case *sema.MissingSwitchCasesHint:
+		codeActionsResolver = missingSwitchCasesCodeActionsResolver(diagnostic, hint, uri)
 	}
 
 	return diagnostic, codeActionsResolver
 }
+
+// missingSwitchCasesCodeActionsResolver returns a code action resolver
+// which inserts the missing cases of a non-exhaustive switch over an enum value
+// before the closing brace of the switch statement.
+//
+func missingSwitchCasesCodeActionsResolver(
+	diagnostic protocol.Diagnostic,
+	hint *sema.MissingSwitchCasesHint,
+	uri protocol.DocumentUri,
+) func() []*protocol.CodeAction {
+
+	return func() []*protocol.CodeAction {
+
+		statement := hint.Statement
+
+		// Prefer the enum expression used in the existing cases, e.g. `Color`,
+		// as the enum type might be nested and be referred to by a shorter name
+
+		enumExpression := hint.EnumType.QualifiedString()
+		for _, switchCase := range statement.Cases {
+			memberExpression, ok := switchCase.Expression.(*ast.MemberExpression)
+			if ok {
+				enumExpression = memberExpression.Expression.String()
+				break
+			}
+		}
+
+		insertionPos := statement.EndPos
+
+		caseIndentation := strings.Repeat(" ", indentationCount)
+		statementIndentation := strings.Repeat(" ", insertionPos.Column+2*indentationCount)
+		closingIndentation := strings.Repeat(" ", insertionPos.Column)
+
+		var builder strings.Builder
+
+		for _, missingCase := range hint.MissingCases {
+			builder.WriteString(caseIndentation)
+			builder.WriteString(fmt.Sprintf("case %s.%s:\n", enumExpression, missingCase))
+			builder.WriteString(statementIndentation)
+			builder.WriteString(fmt.Sprintf("panic(\"TODO: handle %s\")\n", missingCase))
+			builder.WriteString(closingIndentation)
+		}
+
+		textEdit := protocol.TextEdit{
+			Range: protocol.Range{
+				Start: conversion.ASTToProtocolPosition(insertionPos),
+				End:   conversion.ASTToProtocolPosition(insertionPos),
+			},
+			NewText: builder.String(),
+		}
+
+		return []*protocol.CodeAction{
+			{
+				Title:       "Add missing cases",
+				Kind:        protocol.QuickFix,
+				Diagnostics: []protocol.Diagnostic{diagnostic},
+				Edit: &protocol.WorkspaceEdit{
+					Changes: &map[string][]protocol.TextEdit{
+						string(uri): {textEdit},
+					},
+				},
+				IsPreferred: true,
+			},
+		}
+	}
+}
@@ -0,0 +1,881 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package cadence
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// Marshal converts the given Go value to a Cadence value of the given type.
+//
+// Cadence values are used as is. Pointers and interfaces are dereferenced.
+// Otherwise the Go value is converted depending on the Cadence type:
+//
+// Optionals are converted from nil (including nil slices and maps), or from the value for the inner type.
+// Bools and strings are converted from Go bools and strings.
+// Integers are converted from Go integers, `big.Int` and `*big.Int`, and must be in range of the type.
+// Fixed-point numbers are converted from decimal strings, e.g. "1.5", so they are not subject to rounding.
+// Addresses are converted from byte arrays of length 8, e.g. `common.Address`, or hex strings.
+// Paths are converted from strings, e.g. "/public/tokens", and must have the domain of the type.
+// Arrays are converted from Go slices or arrays, and dictionaries from Go maps.
+// Structs, resources, events, contracts, and enums are converted from Go structs,
+// which must have a field for every field of the Cadence type.
+// Enums are also converted from Go integers, the raw value of the enum.
+//
+// A Go struct field is used for the Cadence field with the name given by the `cadence` tag of the field,
+// e.g. `cadence:"totalSupply"`, or otherwise for the Cadence field with the same name, ignoring case.
+// Go struct fields with the tag `cadence:"-"` are ignored.
+//
+func Marshal(v interface{}, t Type) (Value, error) {
+	return marshal(reflect.ValueOf(v), t)
+}
+
+// Unmarshal converts the given Cadence value to a Go value, and stores it in the value pointed to by v.
+//
+// Cadence values are stored as is if the Go type allows it, e.g. `cadence.Value`, `interface{}`,
+// or `cadence.Address` for an address. Otherwise the Cadence value is converted depending on the Go type,
+// in the inverse way of Marshal:
+//
+// Pointers are allocated, and are set to nil for nil optionals.
+// Non-nil optionals are stored as their inner value.
+// Integers are stored in Go integers, if they do not overflow, `big.Int` and `*big.Int`.
+// Fixed-point numbers, addresses, and paths are stored in Go strings, as returned by their String function.
+// Addresses are also stored in byte arrays of length 8, e.g. `common.Address`.
+// Arrays are stored in Go slices or in Go arrays with the same length, and dictionaries in Go maps.
+// Structs, resources, events, contracts, and enums are stored in Go structs.
+// Every field of the Go struct must have a corresponding field in the Cadence value,
+// but the Cadence value may have fields which are not in the Go struct,
+// e.g. if a field was added to a contract type.
+// Enums are also stored in Go integers, as the raw value of the enum.
+//
+func Unmarshal(value Value, v interface{}) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return fmt.Errorf("cannot unmarshal into Go value of type %T: must be a non-nil pointer", v)
+	}
+
+	return unmarshal(value, target.Elem())
+}
+
+var valueType = reflect.TypeOf((*Value)(nil)).Elem()
+var bigIntType = reflect.TypeOf(big.Int{})
+
+func marshal(v reflect.Value, t Type) (Value, error) {
+
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			v = reflect.Value{}
+			break
+		}
+		v = v.Elem()
+	}
+
+	if v.IsValid() && v.Type().Implements(valueType) {
+		return v.Interface().(Value), nil
+	}
+
+	if optionalType, ok := t.(OptionalType); ok {
+		if !v.IsValid() ||
+			((v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil()) {
+
+			return NewOptional(nil), nil
+		}
+
+		value, err := marshal(v, optionalType.Type)
+		if err != nil {
+			return nil, err
+		}
+		return NewOptional(value), nil
+	}
+
+	if !v.IsValid() {
+		return nil, fmt.Errorf("cannot marshal nil to non-optional Cadence type %s", t.ID())
+	}
+
+	switch t := t.(type) {
+	case BoolType:
+		if v.Kind() == reflect.Bool {
+			return NewBool(v.Bool()), nil
+		}
+
+	case StringType:
+		if v.Kind() == reflect.String {
+			return NewString(v.String())
+		}
+
+	case AddressType:
+		switch {
+		case isAddressArray(v.Type()):
+			var address Address
+			reflect.Copy(reflect.ValueOf(address[:]), v)
+			return address, nil
+
+		case v.Kind() == reflect.String:
+			address, err := common.HexToAddress(v.String())
+			if err != nil {
+				return nil, err
+			}
+			return Address(address), nil
+		}
+
+	case IntType, Int8Type, Int16Type, Int32Type, Int64Type, Int128Type, Int256Type,
+		UIntType, UInt8Type, UInt16Type, UInt32Type, UInt64Type, UInt128Type, UInt256Type,
+		Word8Type, Word16Type, Word32Type, Word64Type:
+
+		integer, ok := goInteger(v)
+		if ok {
+			return marshalInteger(integer, t)
+		}
+
+	case Fix64Type:
+		if v.Kind() == reflect.String {
+			return NewFix64(v.String())
+		}
+
+	case UFix64Type:
+		if v.Kind() == reflect.String {
+			return NewUFix64(v.String())
+		}
+
+	case Fix128Type:
+		if v.Kind() == reflect.String {
+			return NewFix128(v.String())
+		}
+
+	case UFix128Type:
+		if v.Kind() == reflect.String {
+			return NewUFix128(v.String())
+		}
+
+	case PathType, CapabilityPathType, StoragePathType, PublicPathType, PrivatePathType:
+		if v.Kind() == reflect.String {
+			return marshalPath(v.String(), t)
+		}
+
+	case VariableSizedArrayType:
+		if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			values, err := marshalElements(v, t.ElementType)
+			if err != nil {
+				return nil, err
+			}
+			return NewArray(values).WithType(t), nil
+		}
+
+	case ConstantSizedArrayType:
+		if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			if uint(v.Len()) != t.Size {
+				return nil, fmt.Errorf(
+					"cannot marshal Go value with %d elements to Cadence type %s",
+					v.Len(),
+					t.ID(),
+				)
+			}
+			values, err := marshalElements(v, t.ElementType)
+			if err != nil {
+				return nil, err
+			}
+			return NewArray(values).WithType(t), nil
+		}
+
+	case DictionaryType:
+		if v.Kind() == reflect.Map {
+			return marshalDictionary(v, t)
+		}
+
+	case *EnumType:
+		if _, ok := goInteger(v); ok {
+			rawValue, err := marshal(v, t.RawType)
+			if err != nil {
+				return nil, err
+			}
+			return NewEnum([]Value{rawValue}).WithType(t), nil
+		}
+
+		if v.Kind() == reflect.Struct {
+			return marshalComposite(v, t)
+		}
+
+	case CompositeType:
+		if v.Kind() == reflect.Struct {
+			return marshalComposite(v, t)
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"cannot marshal Go value of type %s to Cadence type %s",
+		v.Type(),
+		t.ID(),
+	)
+}
+
+// isAddressArray returns true if the given Go type is a byte array with the length of an address
+//
+func isAddressArray(t reflect.Type) bool {
+	return t.Kind() == reflect.Array &&
+		t.Len() == AddressLength &&
+		t.Elem().Kind() == reflect.Uint8
+}
+
+// goInteger returns the value of the given Go integer, `big.Int`, or `*big.Int`
+//
+func goInteger(v reflect.Value) (*big.Int, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(v.Int()), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(v.Uint()), true
+
+	case reflect.Struct:
+		if v.Type() == bigIntType {
+			integer := v.Interface().(big.Int)
+			return new(big.Int).Set(&integer), true
+		}
+	}
+
+	return nil, false
+}
+
+func marshalInteger(integer *big.Int, t Type) (Value, error) {
+	switch t.(type) {
+	case IntType:
+		return NewIntFromBig(integer), nil
+
+	case Int8Type:
+		err := checkIntegerRange(integer, sema.Int8TypeMinInt, sema.Int8TypeMaxInt, t)
+		if err != nil {
+			return nil, err
+		}
+		return NewInt8(int8(integer.Int64())), nil
+
+	case Int16Type:
+		err := checkIntegerRange(integer, sema.Int16TypeMinInt, sema.Int16TypeMaxInt, t)
+		if err != nil {
+			return nil, err
+		}
+		return NewInt16(int16(integer.Int64())), nil
+
+	case Int32Type:
+		err := checkIntegerRange(integer, sema.Int32TypeMinInt, sema.Int32TypeMaxInt, t)
+		if err != nil {
+			return nil, err
+		}
+		return NewInt32(int32(integer.Int64())), nil
+
+	case Int64Type:
+		err := checkIntegerRange(integer, sema.Int64TypeMinInt, sema.Int64TypeMaxInt, t)
+		if err != nil {
+			return nil, err
+		}
+		return NewInt64(integer.Int64()), nil
+
+	case Int128Type:
+		return NewInt128FromBig(integer)
+
+	case Int256Type:
+		return NewInt256FromBig(integer)
+
+	case UIntType:
+		return NewUIntFromBig(integer)
+
+	case UInt8Type:
+		err := checkIntegerRange(integer, sema.UInt8TypeMinInt, sema.UInt8TypeMaxInt, t)
+		if err != nil {
+			return nil, err
+		}
+		return NewUInt8(uint8(integer.Uint64())), nil
+
+	case UInt16Type:
+		err := checkIntegerRange(integer, sema.UInt16TypeMinInt, sema.UInt16TypeMaxInt, t)
+		if err != nil {
+			return nil, err
+		}
+		return NewUInt16(uint16(integer.Uint64())), nil
+
+	case UInt32Type:
+		err := checkIntegerRange(integer, sema.UInt32TypeMinInt, sema.UInt32TypeMaxInt, t)
+		if err != nil {
+			return nil, err
+		}
+		return NewUInt32(uint32(integer.Uint64())), nil
+
+	case UInt64Type:
+		err := checkIntegerRange(integer, sema.UInt64TypeMinInt, sema.UInt64TypeMaxInt, t)
+		if err != nil {
+			return nil, err
+		}
+		return NewUInt64(integer.Uint64()), nil
+
+	case UInt128Type:
+		return NewUInt128FromBig(integer)
+
+	case UInt256Type:
+		return NewUInt256FromBig(integer)
+
+	case Word8Type:
+		err := checkIntegerRange(integer, sema.Word8TypeMinInt, sema.Word8TypeMaxInt, t)
+		if err != nil {
+			return nil, err
+		}
+		return NewWord8(uint8(integer.Uint64())), nil
+
+	case Word16Type:
+		err := checkIntegerRange(integer, sema.Word16TypeMinInt, sema.Word16TypeMaxInt, t)
+		if err != nil {
+			return nil, err
+		}
+		return NewWord16(uint16(integer.Uint64())), nil
+
+	case Word32Type:
+		err := checkIntegerRange(integer, sema.Word32TypeMinInt, sema.Word32TypeMaxInt, t)
+		if err != nil {
+			return nil, err
+		}
+		return NewWord32(uint32(integer.Uint64())), nil
+
+	case Word64Type:
+		err := checkIntegerRange(integer, sema.Word64TypeMinInt, sema.Word64TypeMaxInt, t)
+		if err != nil {
+			return nil, err
+		}
+		return NewWord64(integer.Uint64()), nil
+	}
+
+	panic(fmt.Errorf("unsupported integer type: %s", t.ID()))
+}
+
+func checkIntegerRange(integer *big.Int, min *big.Int, max *big.Int, t Type) error {
+	if integer.Cmp(min) < 0 {
+		return fmt.Errorf("value exceeds min of %s: %s", t.ID(), integer)
+	}
+	if integer.Cmp(max) > 0 {
+		return fmt.Errorf("value exceeds max of %s: %s", t.ID(), integer)
+	}
+	return nil
+}
+
+// marshalPath parses the given path, e.g. "/public/tokens",
+// and checks that it has a domain of the given path type
+//
+func marshalPath(path string, t Type) (Value, error) {
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) != 3 || parts[0] != "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid path: %s", path)
+	}
+
+	domain := common.PathDomainFromIdentifier(parts[1])
+
+	var valid bool
+	switch t.(type) {
+	case PathType:
+		valid = domain != common.PathDomainUnknown
+	case CapabilityPathType:
+		valid = domain == common.PathDomainPublic || domain == common.PathDomainPrivate
+	case StoragePathType:
+		valid = domain == common.PathDomainStorage
+	case PublicPathType:
+		valid = domain == common.PathDomainPublic
+	case PrivatePathType:
+		valid = domain == common.PathDomainPrivate
+	}
+
+	if !valid {
+		return nil, fmt.Errorf("invalid path for Cadence type %s: %s", t.ID(), path)
+	}
+
+	return Path{
+		Domain:     parts[1],
+		Identifier: parts[2],
+	}, nil
+}
+
+func marshalElements(v reflect.Value, elementType Type) ([]Value, error) {
+	values := make([]Value, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		value, err := marshal(v.Index(i), elementType)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func marshalDictionary(v reflect.Value, t DictionaryType) (Value, error) {
+	pairs := make([]KeyValuePair, 0, v.Len())
+
+	iterator := v.MapRange()
+	for iterator.Next() {
+		key, err := marshal(iterator.Key(), t.KeyType)
+		if err != nil {
+			return nil, fmt.Errorf("key %v: %w", iterator.Key(), err)
+		}
+
+		value, err := marshal(iterator.Value(), t.ElementType)
+		if err != nil {
+			return nil, fmt.Errorf("value for key %v: %w", iterator.Key(), err)
+		}
+
+		pairs = append(pairs, KeyValuePair{
+			Key:   key,
+			Value: value,
+		})
+	}
+
+	// Go maps are unordered, so sort the pairs to get a deterministic result
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Key.String() < pairs[j].Key.String()
+	})
+
+	return NewDictionary(pairs).WithType(t), nil
+}
+
+func marshalComposite(v reflect.Value, t CompositeType) (Value, error) {
+	structFields := goStructFieldsOf(v.Type())
+
+	fieldTypes := t.CompositeFields()
+	values := make([]Value, len(fieldTypes))
+
+	for i, fieldType := range fieldTypes {
+		structField := structFields.find(fieldType.Identifier)
+		if structField == nil {
+			return nil, fmt.Errorf(
+				"cannot marshal Go value of type %s to Cadence type %s: missing field `%s`",
+				v.Type(),
+				t.ID(),
+				fieldType.Identifier,
+			)
+		}
+
+		value, err := marshal(v.Field(structField.index), fieldType.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field `%s`: %w", fieldType.Identifier, err)
+		}
+		values[i] = value
+	}
+
+	switch t := t.(type) {
+	case *StructType:
+		return NewStruct(values).WithType(t), nil
+	case *ResourceType:
+		return NewResource(values).WithType(t), nil
+	case *EventType:
+		return NewEvent(values).WithType(t), nil
+	case *ContractType:
+		return NewContract(values).WithType(t), nil
+	case *EnumType:
+		return NewEnum(values).WithType(t), nil
+	}
+
+	panic(fmt.Errorf("unsupported composite type: %s", t.ID()))
+}
+
+func unmarshal(value Value, target reflect.Value) error {
+	if value == nil {
+		return fmt.Errorf("cannot unmarshal missing value into Go value of type %s", target.Type())
+	}
+
+	if reflect.TypeOf(value).AssignableTo(target.Type()) {
+		target.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	if target.Kind() == reflect.Ptr {
+		if optional, ok := value.(Optional); ok {
+			if optional.Value == nil {
+				target.Set(reflect.Zero(target.Type()))
+				return nil
+			}
+			value = optional.Value
+		}
+
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return unmarshal(value, target.Elem())
+	}
+
+	if optional, ok := value.(Optional); ok {
+		if optional.Value != nil {
+			return unmarshal(optional.Value, target)
+		}
+
+		switch target.Kind() {
+		case reflect.Slice, reflect.Map, reflect.Interface:
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+
+		return fmt.Errorf("cannot unmarshal nil into non-pointer Go value of type %s", target.Type())
+	}
+
+	switch value := value.(type) {
+	case Bool:
+		if target.Kind() == reflect.Bool {
+			target.SetBool(bool(value))
+			return nil
+		}
+
+	case String:
+		if target.Kind() == reflect.String {
+			target.SetString(string(value))
+			return nil
+		}
+
+	case Address:
+		if isAddressArray(target.Type()) {
+			reflect.Copy(target, reflect.ValueOf(value[:]))
+			return nil
+		}
+
+		if target.Kind() == reflect.String {
+			target.SetString(value.String())
+			return nil
+		}
+
+	case Fix64, UFix64, Fix128, UFix128, Path:
+		if target.Kind() == reflect.String {
+			target.SetString(value.String())
+			return nil
+		}
+
+	case Array:
+		return unmarshalArray(value, target)
+
+	case Dictionary:
+		if target.Kind() == reflect.Map {
+			return unmarshalDictionary(value, target)
+		}
+
+	case Enum:
+		if value.EnumType == nil {
+			break
+		}
+
+		if _, ok := goInteger(target); ok {
+			for i, field := range value.EnumType.Fields {
+				if field.Identifier == "rawValue" {
+					return unmarshal(value.Fields[i], target)
+				}
+			}
+		}
+
+		if target.Kind() == reflect.Struct {
+			return unmarshalComposite(value.EnumType, value.Fields, target)
+		}
+
+	case Struct:
+		if value.StructType != nil && target.Kind() == reflect.Struct {
+			return unmarshalComposite(value.StructType, value.Fields, target)
+		}
+
+	case Resource:
+		if value.ResourceType != nil && target.Kind() == reflect.Struct {
+			return unmarshalComposite(value.ResourceType, value.Fields, target)
+		}
+
+	case Event:
+		if value.EventType != nil && target.Kind() == reflect.Struct {
+			return unmarshalComposite(value.EventType, value.Fields, target)
+		}
+
+	case Contract:
+		if value.ContractType != nil && target.Kind() == reflect.Struct {
+			return unmarshalComposite(value.ContractType, value.Fields, target)
+		}
+
+	default:
+		if integer, ok := cadenceInteger(value); ok {
+			return unmarshalInteger(integer, value, target)
+		}
+	}
+
+	return unmarshalError(value, target)
+}
+
+func unmarshalError(value Value, target reflect.Value) error {
+	return fmt.Errorf(
+		"cannot unmarshal Cadence value of type %s into Go value of type %s",
+		valueTypeID(value),
+		target.Type(),
+	)
+}
+
+// valueTypeID returns the ID of the type of the given value,
+// or the name of the Go type if the value has no type
+//
+func valueTypeID(value Value) string {
+	t := value.Type()
+	if t == nil {
+		return fmt.Sprintf("%T", value)
+	}
+
+	typeValue := reflect.ValueOf(t)
+	if typeValue.Kind() == reflect.Ptr && typeValue.IsNil() {
+		return fmt.Sprintf("%T", value)
+	}
+
+	return t.ID()
+}
+
+// cadenceInteger returns the value of the given Cadence integer
+//
+func cadenceInteger(value Value) (*big.Int, bool) {
+	switch value := value.(type) {
+	case Int:
+		return value.Big(), true
+	case Int8:
+		return big.NewInt(int64(value)), true
+	case Int16:
+		return big.NewInt(int64(value)), true
+	case Int32:
+		return big.NewInt(int64(value)), true
+	case Int64:
+		return big.NewInt(int64(value)), true
+	case Int128:
+		return value.Big(), true
+	case Int256:
+		return value.Big(), true
+	case UInt:
+		return value.Big(), true
+	case UInt8:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case UInt16:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case UInt32:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case UInt64:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case UInt128:
+		return value.Big(), true
+	case UInt256:
+		return value.Big(), true
+	case Word8:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case Word16:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case Word32:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case Word64:
+		return new(big.Int).SetUint64(uint64(value)), true
+	}
+
+	return nil, false
+}
+
+func unmarshalInteger(integer *big.Int, value Value, target reflect.Value) error {
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !integer.IsInt64() || target.OverflowInt(integer.Int64()) {
+			return unmarshalOverflowError(value, target)
+		}
+		target.SetInt(integer.Int64())
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !integer.IsUint64() || target.OverflowUint(integer.Uint64()) {
+			return unmarshalOverflowError(value, target)
+		}
+		target.SetUint(integer.Uint64())
+		return nil
+
+	case reflect.Struct:
+		if target.Type() == bigIntType {
+			target.Set(reflect.ValueOf(*new(big.Int).Set(integer)))
+			return nil
+		}
+	}
+
+	return unmarshalError(value, target)
+}
+
+func unmarshalOverflowError(value Value, target reflect.Value) error {
+	return fmt.Errorf(
+		"cannot unmarshal Cadence value %s of type %s into Go value of type %s: value overflows",
+		value,
+		valueTypeID(value),
+		target.Type(),
+	)
+}
+
+func unmarshalArray(value Array, target reflect.Value) error {
+	switch target.Kind() {
+	case reflect.Slice:
+		target.Set(reflect.MakeSlice(target.Type(), len(value.Values), len(value.Values)))
+
+	case reflect.Array:
+		if target.Len() != len(value.Values) {
+			return fmt.Errorf(
+				"cannot unmarshal Cadence array with %d elements into Go value of type %s",
+				len(value.Values),
+				target.Type(),
+			)
+		}
+
+	default:
+		return unmarshalError(value, target)
+	}
+
+	for i, element := range value.Values {
+		err := unmarshal(element, target.Index(i))
+		if err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func unmarshalDictionary(value Dictionary, target reflect.Value) error {
+	targetType := target.Type()
+
+	result := reflect.MakeMapWithSize(targetType, len(value.Pairs))
+
+	for _, pair := range value.Pairs {
+		key := reflect.New(targetType.Key()).Elem()
+		err := unmarshal(pair.Key, key)
+		if err != nil {
+			return fmt.Errorf("key %s: %w", pair.Key, err)
+		}
+
+		element := reflect.New(targetType.Elem()).Elem()
+		err = unmarshal(pair.Value, element)
+		if err != nil {
+			return fmt.Errorf("value for key %s: %w", pair.Key, err)
+		}
+
+		result.SetMapIndex(key, element)
+	}
+
+	target.Set(result)
+	return nil
+}
+
+func unmarshalComposite(t CompositeType, values []Value, target reflect.Value) error {
+	fieldTypes := t.CompositeFields()
+	if len(fieldTypes) != len(values) {
+		return fmt.Errorf(
+			"cannot unmarshal Cadence value of type %s: type has %d fields, value has %d",
+			t.ID(),
+			len(fieldTypes),
+			len(values),
+		)
+	}
+
+	for _, structField := range goStructFieldsOf(target.Type()) {
+		index := -1
+		for i, fieldType := range fieldTypes {
+			if structField.matches(fieldType.Identifier) {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 {
+			return fmt.Errorf(
+				"cannot unmarshal Cadence value of type %s into Go value of type %s: missing field `%s`",
+				t.ID(),
+				target.Type(),
+				structField.name,
+			)
+		}
+
+		err := unmarshal(values[index], target.Field(structField.index))
+		if err != nil {
+			return fmt.Errorf("field `%s`: %w", fieldTypes[index].Identifier, err)
+		}
+	}
+
+	return nil
+}
+
+// goStructField is an exported field of a Go struct, which corresponds to a Cadence field
+//
+type goStructField struct {
+	index int
+	// name is the name given in the `cadence` tag of the field, or the name of the field
+	name   string
+	tagged bool
+}
+
+// matches returns true if the Go struct field corresponds to the Cadence field with the given name
+//
+func (f goStructField) matches(name string) bool {
+	if f.tagged {
+		return f.name == name
+	}
+	return strings.EqualFold(f.name, name)
+}
+
+type goStructFields []goStructField
+
+// find returns the Go struct field for the Cadence field with the given name, if any
+//
+func (fields goStructFields) find(name string) *goStructField {
+	for i, field := range fields {
+		if field.matches(name) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func goStructFieldsOf(t reflect.Type) goStructFields {
+	var fields goStructFields
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Ignore unexported fields
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		tagged := false
+
+		if tag, ok := field.Tag.Lookup("cadence"); ok {
+			tagName := strings.Split(tag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+				tagged = true
+			}
+		}
+
+		fields = append(fields, goStructField{
+			index:  i,
+			name:   name,
+			tagged: tagged,
+		})
+	}
+
+	return fields
+}
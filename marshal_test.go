@@ -0,0 +1,585 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package cadence
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func TestMarshalSimple(t *testing.T) {
+
+	t.Parallel()
+
+	type testCase struct {
+		value    interface{}
+		typ      Type
+		expected Value
+	}
+
+	ufix64, _ := NewUFix64("1.5")
+	fix64, _ := NewFix64("-2.25")
+	ufix128, _ := NewUFix128("128.01")
+	int256, _ := NewInt256FromBig(big.NewInt(-256))
+	uint128, _ := NewUInt128FromBig(big.NewInt(128))
+	uint64Value := uint64(64)
+
+	tests := map[string]testCase{
+		"Bool":                  {true, BoolType{}, NewBool(true)},
+		"String":                {"test", StringType{}, String("test")},
+		"Int from int":          {-1, IntType{}, NewInt(-1)},
+		"Int from *big.Int":     {big.NewInt(42), IntType{}, NewInt(42)},
+		"Int from big.Int":      {*big.NewInt(42), IntType{}, NewInt(42)},
+		"Int8":                  {int8(-8), Int8Type{}, NewInt8(-8)},
+		"Int64 from uint":       {uint(64), Int64Type{}, NewInt64(64)},
+		"Int256":                {-256, Int256Type{}, int256},
+		"UInt8 from int":        {8, UInt8Type{}, NewUInt8(8)},
+		"UInt64 from pointer":   {&uint64Value, UInt64Type{}, NewUInt64(64)},
+		"UInt128":               {big.NewInt(128), UInt128Type{}, uint128},
+		"Word16":                {16, Word16Type{}, NewWord16(16)},
+		"UFix64":                {"1.5", UFix64Type{}, ufix64},
+		"Fix64":                 {"-2.25", Fix64Type{}, fix64},
+		"UFix128":               {"128.01", UFix128Type{}, ufix128},
+		"Address from array":    {common.Address{0x1}, AddressType{}, Address{0x1}},
+		"Address from string":   {"0x0000000000000002", AddressType{}, BytesToAddress([]byte{0x2})},
+		"StoragePath":           {"/storage/tokens", StoragePathType{}, Path{Domain: "storage", Identifier: "tokens"}},
+		"CapabilityPath":        {"/private/tokens", CapabilityPathType{}, Path{Domain: "private", Identifier: "tokens"}},
+		"Cadence value":         {NewUInt8(1), UInt8Type{}, NewUInt8(1)},
+		"Cadence value pointer": {&ufix64, UFix64Type{}, ufix64},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Marshal(test.value, test.typ)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+
+	t.Parallel()
+
+	type testCase struct {
+		value    interface{}
+		typ      Type
+		expected string
+	}
+
+	tests := map[string]testCase{
+		"type mismatch": {
+			"1",
+			IntType{},
+			"cannot marshal Go value of type string to Cadence type Int",
+		},
+		"integer overflow": {
+			256,
+			UInt8Type{},
+			"value exceeds max of UInt8: 256",
+		},
+		"negative unsigned integer": {
+			-1,
+			UInt128Type{},
+			"invalid negative value for UInt: -1",
+		},
+		"invalid fixed-point number": {
+			"1",
+			UFix64Type{},
+			"missing decimal point",
+		},
+		"nil": {
+			nil,
+			StringType{},
+			"cannot marshal nil to non-optional Cadence type String",
+		},
+		"path domain": {
+			"/storage/tokens",
+			PublicPathType{},
+			"invalid path for Cadence type PublicPath: /storage/tokens",
+		},
+		"constant-sized array": {
+			[]int{1, 2},
+			ConstantSizedArrayType{Size: 3, ElementType: IntType{}},
+			"cannot marshal Go value with 2 elements to Cadence type [Int;3]",
+		},
+		"element": {
+			[]int{1, 300},
+			VariableSizedArrayType{ElementType: Int8Type{}},
+			"element 1: value exceeds max of Int8: 300",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Marshal(test.value, test.typ)
+			require.Error(t, err)
+
+			assert.Contains(t, err.Error(), test.expected)
+		})
+	}
+}
+
+func TestMarshalContainers(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("optional", func(t *testing.T) {
+
+		t.Parallel()
+
+		optionalType := OptionalType{Type: IntType{}}
+
+		var none *int
+		actual, err := Marshal(none, optionalType)
+		require.NoError(t, err)
+		assert.Equal(t, NewOptional(nil), actual)
+
+		one := 1
+		actual, err = Marshal(&one, optionalType)
+		require.NoError(t, err)
+		assert.Equal(t, NewOptional(NewInt(1)), actual)
+
+		var nilSlice []int
+		actual, err = Marshal(nilSlice, OptionalType{Type: VariableSizedArrayType{ElementType: IntType{}}})
+		require.NoError(t, err)
+		assert.Equal(t, NewOptional(nil), actual)
+	})
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		arrayType := ConstantSizedArrayType{Size: 2, ElementType: UInt8Type{}}
+
+		actual, err := Marshal([2]uint8{1, 2}, arrayType)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			NewArray([]Value{NewUInt8(1), NewUInt8(2)}).WithType(arrayType),
+			actual,
+		)
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		dictionaryType := DictionaryType{
+			KeyType:     StringType{},
+			ElementType: OptionalType{Type: UInt64Type{}},
+		}
+
+		two := uint64(2)
+
+		actual, err := Marshal(
+			map[string]*uint64{
+				"b": &two,
+				"a": nil,
+			},
+			dictionaryType,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			NewDictionary([]KeyValuePair{
+				{Key: String("a"), Value: NewOptional(nil)},
+				{Key: String("b"), Value: NewOptional(NewUInt64(2))},
+			}).WithType(dictionaryType),
+			actual,
+		)
+	})
+}
+
+type testToken struct {
+	ID      uint64          `cadence:"id"`
+	Name    string          // matched case-insensitively
+	Balance string          `cadence:"balance"`
+	Owner   *common.Address `cadence:"owner"`
+	Tags    map[string]int  `cadence:"tags"`
+	Color   uint8           `cadence:"color"`
+	Note    string          `cadence:"-"`
+	secret  string
+}
+
+var testColorType = &EnumType{
+	QualifiedIdentifier: "Color",
+	RawType:             UInt8Type{},
+	Fields: []Field{
+		{Identifier: "rawValue", Type: UInt8Type{}},
+	},
+}
+
+var testTokenType = &StructType{
+	Location:            common.IdentifierLocation("test"),
+	QualifiedIdentifier: "Token",
+	Fields: []Field{
+		{Identifier: "id", Type: UInt64Type{}},
+		{Identifier: "name", Type: StringType{}},
+		{Identifier: "balance", Type: UFix64Type{}},
+		{Identifier: "owner", Type: OptionalType{Type: AddressType{}}},
+		{Identifier: "tags", Type: DictionaryType{KeyType: StringType{}, ElementType: Int8Type{}}},
+		{Identifier: "color", Type: testColorType},
+	},
+}
+
+func testTokenValue() Struct {
+	balance, _ := NewUFix64("10.5")
+
+	return NewStruct([]Value{
+		NewUInt64(1),
+		String("Gold"),
+		balance,
+		NewOptional(Address{0x1}),
+		NewDictionary([]KeyValuePair{
+			{Key: String("rare"), Value: NewInt8(1)},
+		}).WithType(DictionaryType{KeyType: StringType{}, ElementType: Int8Type{}}),
+		NewEnum([]Value{NewUInt8(2)}).WithType(testColorType),
+	}).WithType(testTokenType)
+}
+
+func TestMarshalComposite(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		owner := common.Address{0x1}
+
+		actual, err := Marshal(
+			&testToken{
+				ID:      1,
+				Name:    "Gold",
+				Balance: "10.5",
+				Owner:   &owner,
+				Tags:    map[string]int{"rare": 1},
+				Color:   2,
+				Note:    "ignored",
+			},
+			testTokenType,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, testTokenValue(), actual)
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+
+		t.Parallel()
+
+		type token struct {
+			ID uint64 `cadence:"id"`
+		}
+
+		_, err := Marshal(token{ID: 1}, testTokenType)
+		require.EqualError(t, err,
+			"cannot marshal Go value of type cadence.token to Cadence type I.test.Token: missing field `name`",
+		)
+	})
+
+	t.Run("event", func(t *testing.T) {
+
+		t.Parallel()
+
+		eventType := &EventType{
+			QualifiedIdentifier: "Deposited",
+			Fields: []Field{
+				{Identifier: "amount", Type: UFix64Type{}},
+			},
+		}
+
+		actual, err := Marshal(
+			struct {
+				Amount string
+			}{
+				Amount: "1.0",
+			},
+			eventType,
+		)
+		require.NoError(t, err)
+
+		amount, _ := NewUFix64("1.0")
+
+		assert.Equal(t,
+			NewEvent([]Value{amount}).WithType(eventType),
+			actual,
+		)
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		var b bool
+		require.NoError(t, Unmarshal(NewBool(true), &b))
+		assert.True(t, b)
+
+		var s string
+		require.NoError(t, Unmarshal(String("test"), &s))
+		assert.Equal(t, "test", s)
+
+		var i int16
+		require.NoError(t, Unmarshal(NewInt(-16), &i))
+		assert.Equal(t, int16(-16), i)
+
+		var u uint64
+		require.NoError(t, Unmarshal(NewWord64(64), &u))
+		assert.Equal(t, uint64(64), u)
+
+		var bigInt *big.Int
+		uint256, _ := NewUInt256FromBig(big.NewInt(256))
+		require.NoError(t, Unmarshal(uint256, &bigInt))
+		assert.Equal(t, big.NewInt(256), bigInt)
+
+		var fixedPoint string
+		fix64, _ := NewFix64("-1.5")
+		require.NoError(t, Unmarshal(fix64, &fixedPoint))
+		assert.Equal(t, "-1.50000000", fixedPoint)
+
+		var ufix64 UFix64
+		value, _ := NewUFix64("1.5")
+		require.NoError(t, Unmarshal(value, &ufix64))
+		assert.Equal(t, value, ufix64)
+
+		var address common.Address
+		require.NoError(t, Unmarshal(Address{0x1}, &address))
+		assert.Equal(t, common.Address{0x1}, address)
+
+		var addressString string
+		require.NoError(t, Unmarshal(BytesToAddress([]byte{0x1}), &addressString))
+		assert.Equal(t, "0x1", addressString)
+
+		var path string
+		require.NoError(t, Unmarshal(Path{Domain: "public", Identifier: "tokens"}, &path))
+		assert.Equal(t, "/public/tokens", path)
+
+		var anyValue Value
+		require.NoError(t, Unmarshal(NewInt8(1), &anyValue))
+		assert.Equal(t, NewInt8(1), anyValue)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+
+		t.Parallel()
+
+		result := new(int)
+		require.NoError(t, Unmarshal(NewOptional(nil), &result))
+		assert.Nil(t, result)
+
+		require.NoError(t, Unmarshal(NewOptional(NewInt(1)), &result))
+		require.NotNil(t, result)
+		assert.Equal(t, 1, *result)
+
+		var i int
+		require.NoError(t, Unmarshal(NewOptional(NewInt(2)), &i))
+		assert.Equal(t, 2, i)
+
+		err := Unmarshal(NewOptional(nil), &i)
+		require.EqualError(t, err, "cannot unmarshal nil into non-pointer Go value of type int")
+	})
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		array := NewArray([]Value{NewUInt8(1), NewUInt8(2)})
+
+		var slice []uint8
+		require.NoError(t, Unmarshal(array, &slice))
+		assert.Equal(t, []uint8{1, 2}, slice)
+
+		var goArray [2]int
+		require.NoError(t, Unmarshal(array, &goArray))
+		assert.Equal(t, [2]int{1, 2}, goArray)
+
+		var shortArray [1]int
+		err := Unmarshal(array, &shortArray)
+		require.EqualError(t, err, "cannot unmarshal Cadence array with 2 elements into Go value of type [1]int")
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		dictionary := NewDictionary([]KeyValuePair{
+			{Key: String("a"), Value: NewOptional(nil)},
+			{Key: String("b"), Value: NewOptional(NewUInt64(2))},
+		})
+
+		var result map[string]*uint64
+		require.NoError(t, Unmarshal(dictionary, &result))
+
+		two := uint64(2)
+		assert.Equal(t, map[string]*uint64{"a": nil, "b": &two}, result)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		var token testToken
+		require.NoError(t, Unmarshal(testTokenValue(), &token))
+
+		owner := common.Address{0x1}
+
+		assert.Equal(t,
+			testToken{
+				ID:      1,
+				Name:    "Gold",
+				Balance: "10.50000000",
+				Owner:   &owner,
+				Tags:    map[string]int{"rare": 1},
+				Color:   2,
+			},
+			token,
+		)
+	})
+
+	t.Run("struct with added field", func(t *testing.T) {
+
+		t.Parallel()
+
+		type token struct {
+			ID   uint64 `cadence:"id"`
+			Name string `cadence:"name"`
+		}
+
+		value := NewStruct([]Value{
+			NewUInt64(1),
+			NewInt(42),
+			String("Gold"),
+		}).WithType(&StructType{
+			QualifiedIdentifier: "Token",
+			Fields: []Field{
+				{Identifier: "id", Type: UInt64Type{}},
+				{Identifier: "rank", Type: IntType{}},
+				{Identifier: "name", Type: StringType{}},
+			},
+		})
+
+		var result token
+		require.NoError(t, Unmarshal(value, &result))
+		assert.Equal(t, token{ID: 1, Name: "Gold"}, result)
+	})
+
+	t.Run("event", func(t *testing.T) {
+
+		t.Parallel()
+
+		type deposited struct {
+			Amount *big.Int `cadence:"amount"`
+			To     *string  `cadence:"to"`
+		}
+
+		value := NewEvent([]Value{
+			NewUInt(100),
+			NewOptional(BytesToAddress([]byte{0x2})),
+		}).WithType(&EventType{
+			QualifiedIdentifier: "Deposited",
+			Fields: []Field{
+				{Identifier: "amount", Type: UIntType{}},
+				{Identifier: "to", Type: OptionalType{Type: AddressType{}}},
+			},
+		})
+
+		var result deposited
+		require.NoError(t, Unmarshal(value, &result))
+
+		to := "0x2"
+		assert.Equal(t, deposited{Amount: big.NewInt(100), To: &to}, result)
+	})
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("non-pointer", func(t *testing.T) {
+
+		t.Parallel()
+
+		var i int
+		err := Unmarshal(NewInt(1), i)
+		require.EqualError(t, err, "cannot unmarshal into Go value of type int: must be a non-nil pointer")
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		var i int
+		err := Unmarshal(String("1"), &i)
+		require.EqualError(t, err, "cannot unmarshal Cadence value of type String into Go value of type int")
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		var i int8
+		err := Unmarshal(NewUInt64(300), &i)
+		require.EqualError(t, err,
+			"cannot unmarshal Cadence value 300 of type UInt64 into Go value of type int8: value overflows",
+		)
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+
+		t.Parallel()
+
+		type token struct {
+			Supply uint64 `cadence:"supply"`
+		}
+
+		var result token
+		err := Unmarshal(testTokenValue(), &result)
+		require.EqualError(t, err,
+			"cannot unmarshal Cadence value of type I.test.Token into Go value of type cadence.token: missing field `supply`",
+		)
+	})
+
+	t.Run("field", func(t *testing.T) {
+
+		t.Parallel()
+
+		type token struct {
+			Name int `cadence:"name"`
+		}
+
+		var result token
+		err := Unmarshal(testTokenValue(), &result)
+		require.EqualError(t, err,
+			"field `name`: cannot unmarshal Cadence value of type String into Go value of type int",
+		)
+	})
+}
@@ -0,0 +1,494 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package abi provides a description of the public interface of Cadence programs,
+// e.g. the types, functions, and events declared by contracts,
+// so clients can use the declarations without depending on the source code.
+//
+// The types of the declarations are represented as Cadence types,
+// which are encoded as JSON-Cadence types
+//
+package abi
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// ABI is the description of the public interface of a program
+//
+type ABI struct {
+	// Declarations are the composite and interface declarations of the program,
+	// e.g. contracts and contract interfaces
+	Declarations []*Declaration `json:"declarations"`
+	// Functions are the public global functions of the program, e.g. the `main` function of a script
+	Functions []*Function `json:"functions"`
+	// Transaction is the transaction declared by the program, if any
+	Transaction *Transaction `json:"transaction,omitempty"`
+}
+
+// Declaration is the description of a composite or interface declaration
+//
+type Declaration struct {
+	// Kind is the kind of the declared type, as used in JSON-Cadence types,
+	// e.g. `Resource` or `ContractInterface`
+	Kind       string `json:"kind"`
+	Identifier string `json:"identifier"`
+	TypeID     string `json:"typeID"`
+	DocString  string `json:"docString,omitempty"`
+	// Type is the declared type
+	Type cadence.Type `json:"-"`
+	// Conformances are the type IDs of the interfaces the declared composite type conforms to
+	Conformances []string `json:"conformances,omitempty"`
+	// RawType is the raw type of an enum
+	RawType cadence.Type `json:"rawType,omitempty"`
+	// Fields are all fields of the declared type, including non-public fields
+	// and built-in fields like `uuid`, as they are part of the values of the type
+	Fields []*Field `json:"fields"`
+	// Initializer are the parameters of the initializer of a composite,
+	// e.g. the parameters of an event
+	Initializer []*Parameter `json:"initializer,omitempty"`
+	// Functions are the public functions of the declaration
+	Functions []*Function `json:"functions"`
+	// Cases are the cases of an enum
+	Cases []*EnumCase `json:"cases,omitempty"`
+	// Declarations are the nested public composite and interface declarations, e.g. events
+	Declarations []*Declaration `json:"declarations,omitempty"`
+}
+
+func (d *Declaration) MarshalJSON() ([]byte, error) {
+	type Alias Declaration
+	return json.Marshal(&struct {
+		*Alias
+		RawType *typeJSON `json:"rawType,omitempty"`
+	}{
+		Alias:   (*Alias)(d),
+		RawType: optionalTypeJSON(d.RawType),
+	})
+}
+
+// Field is the description of a field
+//
+type Field struct {
+	Identifier string       `json:"id"`
+	Type       cadence.Type `json:"type"`
+	// Access is the access modifier of the field, e.g. `pub` or `access(contract)`
+	Access string `json:"access"`
+	// VariableKind is the kind of the field, `let` or `var`
+	VariableKind string `json:"variableKind"`
+	DocString    string `json:"docString,omitempty"`
+}
+
+func (f *Field) MarshalJSON() ([]byte, error) {
+	type Alias Field
+	return json.Marshal(&struct {
+		*Alias
+		Type typeJSON `json:"type"`
+	}{
+		Alias: (*Alias)(f),
+		Type:  typeJSON{f.Type},
+	})
+}
+
+// Function is the description of a function
+//
+type Function struct {
+	Identifier string       `json:"identifier"`
+	DocString  string       `json:"docString,omitempty"`
+	Parameters []*Parameter `json:"parameters"`
+	ReturnType cadence.Type `json:"return"`
+}
+
+func (f *Function) MarshalJSON() ([]byte, error) {
+	type Alias Function
+	return json.Marshal(&struct {
+		*Alias
+		ReturnType typeJSON `json:"return"`
+	}{
+		Alias:      (*Alias)(f),
+		ReturnType: typeJSON{f.ReturnType},
+	})
+}
+
+// Parameter is the description of a function or initializer parameter
+//
+type Parameter struct {
+	Label      string       `json:"label"`
+	Identifier string       `json:"id"`
+	Type       cadence.Type `json:"type"`
+}
+
+func (p *Parameter) MarshalJSON() ([]byte, error) {
+	type Alias Parameter
+	return json.Marshal(&struct {
+		*Alias
+		Type typeJSON `json:"type"`
+	}{
+		Alias: (*Alias)(p),
+		Type:  typeJSON{p.Type},
+	})
+}
+
+// EnumCase is the description of an enum case
+//
+type EnumCase struct {
+	Identifier string `json:"identifier"`
+	RawValue   uint64 `json:"rawValue"`
+	DocString  string `json:"docString,omitempty"`
+}
+
+// Transaction is the description of a transaction declaration
+//
+type Transaction struct {
+	DocString  string       `json:"docString,omitempty"`
+	Parameters []*Parameter `json:"parameters"`
+}
+
+// typeJSON is a type which is marshalled as a JSON-Cadence type
+//
+type typeJSON struct {
+	cadence.Type
+}
+
+func (t typeJSON) MarshalJSON() ([]byte, error) {
+	return jsoncdc.EncodeType(t.Type)
+}
+
+// optionalTypeJSON returns the given type as a type which is marshalled as a JSON-Cadence type,
+// or nil if no type is given
+//
+func optionalTypeJSON(ty cadence.Type) *typeJSON {
+	if ty == nil {
+		return nil
+	}
+	return &typeJSON{ty}
+}
+
+// FromChecker returns the ABI of the program checked by the given checker.
+//
+// The program must have been checked successfully
+//
+func FromChecker(checker *sema.Checker) *ABI {
+	exporter := &exporter{
+		elaboration: checker.Elaboration,
+		types:       map[sema.TypeID]cadence.Type{},
+	}
+	return exporter.exportProgram(checker.Program)
+}
+
+type exporter struct {
+	elaboration *sema.Elaboration
+	// types are the already exported types, shared between all exported types,
+	// so every type is only exported once
+	types map[sema.TypeID]cadence.Type
+}
+
+func (e *exporter) exportType(ty sema.Type) cadence.Type {
+	return runtime.ExportType(ty, e.types)
+}
+
+func (e *exporter) exportProgram(program *ast.Program) *ABI {
+	abi := &ABI{
+		// NOTE: allocate slices, so the result is `[]` in JSON, nil is serialized to `null`
+		Declarations: []*Declaration{},
+		Functions:    []*Function{},
+	}
+
+	for _, declaration := range program.Declarations() {
+		switch declaration := declaration.(type) {
+		case *ast.CompositeDeclaration:
+			if !isPublic(declaration.Access) {
+				continue
+			}
+			abi.Declarations = append(abi.Declarations, e.exportCompositeDeclaration(declaration))
+
+		case *ast.InterfaceDeclaration:
+			if !isPublic(declaration.Access) {
+				continue
+			}
+			abi.Declarations = append(abi.Declarations, e.exportInterfaceDeclaration(declaration))
+
+		case *ast.FunctionDeclaration:
+			if !isPublic(declaration.Access) {
+				continue
+			}
+			functionType := e.elaboration.FunctionDeclarationFunctionTypes[declaration]
+			abi.Functions = append(abi.Functions, e.exportFunction(declaration, functionType))
+
+		case *ast.TransactionDeclaration:
+			abi.Transaction = e.exportTransaction(declaration)
+		}
+	}
+
+	return abi
+}
+
+func (e *exporter) exportCompositeDeclaration(declaration *ast.CompositeDeclaration) *Declaration {
+	compositeType := e.elaboration.CompositeDeclarationTypes[declaration]
+
+	exportedType := e.exportType(compositeType)
+
+	result := e.newDeclaration(
+		declaration.DeclarationMembers(),
+		compositeType.Members,
+		compositeType.Fields,
+		compositeKindName(compositeType.Kind),
+		declaration.Identifier.Identifier,
+		compositeType.ID(),
+		declaration.DocString,
+		exportedType,
+	)
+
+	for _, conformance := range compositeType.ExplicitInterfaceConformances {
+		result.Conformances = append(result.Conformances, string(conformance.ID()))
+	}
+
+	initializer := e.exportParameters(compositeType.ConstructorParameters)
+
+	switch compositeType.Kind {
+	case common.CompositeKindEnum:
+		result.RawType = e.exportType(compositeType.EnumRawType)
+
+		result.Cases = []*EnumCase{}
+		for i, enumCase := range declaration.Members.EnumCases() {
+			result.Cases = append(result.Cases, &EnumCase{
+				Identifier: enumCase.Identifier.Identifier,
+				RawValue:   uint64(i),
+				DocString:  formatDocString(enumCase.DocString),
+			})
+		}
+
+	case common.CompositeKindEvent:
+		result.Initializer = initializer
+		if eventType, ok := exportedType.(*cadence.EventType); ok {
+			eventType.Initializer = exportedParameters(initializer)
+		}
+
+	default:
+		result.Initializer = initializer
+		setInitializers(exportedType, [][]cadence.Parameter{exportedParameters(initializer)})
+	}
+
+	return result
+}
+
+func (e *exporter) exportInterfaceDeclaration(declaration *ast.InterfaceDeclaration) *Declaration {
+	interfaceType := e.elaboration.InterfaceDeclarationTypes[declaration]
+
+	return e.newDeclaration(
+		declaration.DeclarationMembers(),
+		interfaceType.Members,
+		interfaceType.Fields,
+		compositeKindName(interfaceType.CompositeKind)+"Interface",
+		declaration.Identifier.Identifier,
+		interfaceType.ID(),
+		declaration.DocString,
+		e.exportType(interfaceType),
+	)
+}
+
+func (e *exporter) newDeclaration(
+	members *ast.Members,
+	memberTypes *sema.StringMemberOrderedMap,
+	fieldNames []string,
+	kind string,
+	identifier string,
+	typeID sema.TypeID,
+	docString string,
+	exportedType cadence.Type,
+) *Declaration {
+
+	result := &Declaration{
+		Kind:       kind,
+		Identifier: identifier,
+		TypeID:     string(typeID),
+		DocString:  formatDocString(docString),
+		Type:       exportedType,
+		Fields:     []*Field{},
+		Functions:  []*Function{},
+	}
+
+	// NOTE: use the fields of the type instead of the field declarations,
+	// as events have no field declarations, but fields for their parameters
+
+	for _, fieldName := range fieldNames {
+		member, ok := memberTypes.Get(fieldName)
+		if !ok || member.IgnoreInSerialization {
+			continue
+		}
+
+		result.Fields = append(result.Fields, &Field{
+			Identifier:   fieldName,
+			Type:         e.exportType(member.TypeAnnotation.Type),
+			Access:       member.Access.Keyword(),
+			VariableKind: member.VariableKind.Keyword(),
+			DocString:    formatDocString(member.DocString),
+		})
+	}
+
+	for _, function := range members.Functions() {
+		if !isPublic(function.Access) {
+			continue
+		}
+
+		member, ok := memberTypes.Get(function.Identifier.Identifier)
+		if !ok {
+			continue
+		}
+
+		functionType, ok := member.TypeAnnotation.Type.(*sema.FunctionType)
+		if !ok {
+			continue
+		}
+
+		result.Functions = append(result.Functions, e.exportFunction(function, functionType))
+	}
+
+	for _, nestedDeclaration := range members.Declarations() {
+		switch nestedDeclaration := nestedDeclaration.(type) {
+		case *ast.CompositeDeclaration:
+			if !isPublic(nestedDeclaration.Access) {
+				continue
+			}
+
+			result.Declarations = append(
+				result.Declarations,
+				e.exportCompositeDeclaration(nestedDeclaration),
+			)
+
+		case *ast.InterfaceDeclaration:
+			if !isPublic(nestedDeclaration.Access) {
+				continue
+			}
+
+			result.Declarations = append(
+				result.Declarations,
+				e.exportInterfaceDeclaration(nestedDeclaration),
+			)
+		}
+	}
+
+	return result
+}
+
+func (e *exporter) exportFunction(declaration *ast.FunctionDeclaration, functionType *sema.FunctionType) *Function {
+	return &Function{
+		Identifier: declaration.Identifier.Identifier,
+		DocString:  formatDocString(declaration.DocString),
+		Parameters: e.exportParameters(functionType.Parameters),
+		ReturnType: e.exportType(functionType.ReturnTypeAnnotation.Type),
+	}
+}
+
+func (e *exporter) exportTransaction(declaration *ast.TransactionDeclaration) *Transaction {
+	transactionType := e.elaboration.TransactionDeclarationTypes[declaration]
+
+	return &Transaction{
+		DocString:  formatDocString(declaration.DocString),
+		Parameters: e.exportParameters(transactionType.Parameters),
+	}
+}
+
+func (e *exporter) exportParameters(parameters []*sema.Parameter) []*Parameter {
+	// NOTE: allocate slice, so the result is `[]` in JSON, nil is serialized to `null`
+	result := make([]*Parameter, len(parameters))
+	for i, parameter := range parameters {
+		result[i] = &Parameter{
+			Label:      parameter.Label,
+			Identifier: parameter.Identifier,
+			Type:       e.exportType(parameter.TypeAnnotation.Type),
+		}
+	}
+	return result
+}
+
+func exportedParameters(parameters []*Parameter) []cadence.Parameter {
+	result := make([]cadence.Parameter, len(parameters))
+	for i, parameter := range parameters {
+		result[i] = cadence.Parameter{
+			Label:      parameter.Label,
+			Identifier: parameter.Identifier,
+			Type:       parameter.Type,
+		}
+	}
+	return result
+}
+
+// setInitializers sets the initializers of the given exported composite type,
+// which are not exported by runtime.ExportType
+//
+func setInitializers(ty cadence.Type, initializers [][]cadence.Parameter) {
+	switch ty := ty.(type) {
+	case *cadence.StructType:
+		ty.Initializers = initializers
+	case *cadence.ResourceType:
+		ty.Initializers = initializers
+	case *cadence.ContractType:
+		ty.Initializers = initializers
+	}
+}
+
+// formatDocString returns the given docstring without the space
+// which usually follows the docstring comment prefix (`///`) on each line
+//
+func formatDocString(docString string) string {
+	lines := strings.Split(docString, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// compositeKindName returns the name of the given composite kind,
+// as used for the kinds of JSON-Cadence types
+//
+func compositeKindName(kind common.CompositeKind) string {
+	switch kind {
+	case common.CompositeKindStructure:
+		return "Struct"
+	case common.CompositeKindResource:
+		return "Resource"
+	case common.CompositeKindContract:
+		return "Contract"
+	case common.CompositeKindEvent:
+		return "Event"
+	case common.CompositeKindEnum:
+		return "Enum"
+	default:
+		return kind.Name()
+	}
+}
+
+// isPublic returns true if declarations with the given access
+// are accessible outside of the program
+//
+func isPublic(access ast.Access) bool {
+	switch access {
+	case ast.AccessNotSpecified, ast.AccessPublic, ast.AccessPublicSettable:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,354 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package abi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/tests/checker"
+)
+
+func exportABI(t *testing.T, code string) *ABI {
+	c, err := checker.ParseAndCheck(t, code)
+	require.NoError(t, err)
+
+	return FromChecker(c)
+}
+
+func TestABIContract(t *testing.T) {
+
+	t.Parallel()
+
+	abi := exportABI(t, `
+      /// A token
+      pub contract Token {
+
+          /// The total supply
+          pub var totalSupply: UInt64
+
+          access(contract) let admins: [Address]
+
+          /// Emitted when tokens are withdrawn
+          pub event Withdrawn(amount: UInt64, from: Address?)
+
+          pub resource interface Receiver {
+              pub fun deposit(from: @Vault)
+          }
+
+          pub resource Vault: Receiver {
+              pub var balance: UInt64
+
+              init(balance: UInt64) {
+                  self.balance = balance
+              }
+
+              /// Withdraws the given amount
+              pub fun withdraw(amount: UInt64): @Vault {
+                  self.balance = self.balance - amount
+                  return <-create Vault(balance: amount)
+              }
+
+              pub fun deposit(from: @Vault) {
+                  self.balance = self.balance + from.balance
+                  destroy from
+              }
+
+              access(contract) fun reset() {
+                  self.balance = 0
+              }
+          }
+
+          pub fun createEmptyVault(): @Vault {
+              return <-create Vault(balance: 0)
+          }
+
+          init(_ initialSupply: UInt64) {
+              self.totalSupply = initialSupply
+              self.admins = []
+          }
+      }
+    `)
+
+	actual, err := json.Marshal(abi)
+	require.NoError(t, err)
+
+	vaultType := `
+      {
+        "kind": "Resource",
+        "type": "",
+        "typeID": "S.test.Token.Vault",
+        "fields": [
+          {"id": "uuid", "type": {"kind": "UInt64"}},
+          {"id": "balance", "type": {"kind": "UInt64"}}
+        ],
+        "initializers": [
+          [{"label": "", "id": "balance", "type": {"kind": "UInt64"}}]
+        ]
+      }
+    `
+
+	assert.JSONEq(t,
+		`
+          {
+            "declarations": [
+              {
+                "kind": "Contract",
+                "identifier": "Token",
+                "typeID": "S.test.Token",
+                "docString": "A token",
+                "fields": [
+                  {
+                    "id": "totalSupply",
+                    "type": {"kind": "UInt64"},
+                    "access": "pub",
+                    "variableKind": "var",
+                    "docString": "The total supply"
+                  },
+                  {
+                    "id": "admins",
+                    "type": {"kind": "VariableSizedArray", "type": {"kind": "Address"}},
+                    "access": "access(contract)",
+                    "variableKind": "let"
+                  }
+                ],
+                "initializer": [
+                  {"label": "_", "id": "initialSupply", "type": {"kind": "UInt64"}}
+                ],
+                "functions": [
+                  {
+                    "identifier": "createEmptyVault",
+                    "parameters": [],
+                    "return": `+vaultType+`
+                  }
+                ],
+                "declarations": [
+                  {
+                    "kind": "Event",
+                    "identifier": "Withdrawn",
+                    "typeID": "S.test.Token.Withdrawn",
+                    "docString": "Emitted when tokens are withdrawn",
+                    "fields": [
+                      {
+                        "id": "amount",
+                        "type": {"kind": "UInt64"},
+                        "access": "pub",
+                        "variableKind": "let"
+                      },
+                      {
+                        "id": "from",
+                        "type": {"kind": "Optional", "type": {"kind": "Address"}},
+                        "access": "pub",
+                        "variableKind": "let"
+                      }
+                    ],
+                    "initializer": [
+                      {"label": "", "id": "amount", "type": {"kind": "UInt64"}},
+                      {"label": "", "id": "from", "type": {"kind": "Optional", "type": {"kind": "Address"}}}
+                    ],
+                    "functions": []
+                  },
+                  {
+                    "kind": "ResourceInterface",
+                    "identifier": "Receiver",
+                    "typeID": "S.test.Token.Receiver",
+                    "fields": [
+                      {
+                        "id": "uuid",
+                        "type": {"kind": "UInt64"},
+                        "access": "pub",
+                        "variableKind": "let",
+                        "docString": "The automatically generated, unique ID of the resource"
+                      }
+                    ],
+                    "functions": [
+                      {
+                        "identifier": "deposit",
+                        "parameters": [
+                          {"label": "", "id": "from", "type": `+vaultType+`}
+                        ],
+                        "return": {"kind": "Void"}
+                      }
+                    ]
+                  },
+                  {
+                    "kind": "Resource",
+                    "identifier": "Vault",
+                    "typeID": "S.test.Token.Vault",
+                    "conformances": ["S.test.Token.Receiver"],
+                    "fields": [
+                      {
+                        "id": "uuid",
+                        "type": {"kind": "UInt64"},
+                        "access": "pub",
+                        "variableKind": "let",
+                        "docString": "The automatically generated, unique ID of the resource"
+                      },
+                      {
+                        "id": "balance",
+                        "type": {"kind": "UInt64"},
+                        "access": "pub",
+                        "variableKind": "var"
+                      }
+                    ],
+                    "initializer": [
+                      {"label": "", "id": "balance", "type": {"kind": "UInt64"}}
+                    ],
+                    "functions": [
+                      {
+                        "identifier": "withdraw",
+                        "docString": "Withdraws the given amount",
+                        "parameters": [
+                          {"label": "", "id": "amount", "type": {"kind": "UInt64"}}
+                        ],
+                        "return": `+vaultType+`
+                      },
+                      {
+                        "identifier": "deposit",
+                        "parameters": [
+                          {"label": "", "id": "from", "type": `+vaultType+`}
+                        ],
+                        "return": {"kind": "Void"}
+                      }
+                    ]
+                  }
+                ]
+              }
+            ],
+            "functions": []
+          }
+        `,
+		string(actual),
+	)
+}
+
+func TestABIEnum(t *testing.T) {
+
+	t.Parallel()
+
+	abi := exportABI(t, `
+      pub enum Direction: UInt8 {
+          /// Up
+          pub case up
+          pub case down
+      }
+    `)
+
+	require.Len(t, abi.Declarations, 1)
+
+	declaration := abi.Declarations[0]
+
+	assert.Equal(t, "Enum", declaration.Kind)
+	assert.Equal(t, cadence.UInt8Type{}, declaration.RawType)
+	assert.Equal(t,
+		[]*EnumCase{
+			{Identifier: "up", RawValue: 0, DocString: "Up"},
+			{Identifier: "down", RawValue: 1},
+		},
+		declaration.Cases,
+	)
+	assert.Nil(t, declaration.Initializer)
+
+	actual, err := json.Marshal(declaration)
+	require.NoError(t, err)
+
+	assert.JSONEq(t,
+		`
+          {
+            "kind": "Enum",
+            "identifier": "Direction",
+            "typeID": "S.test.Direction",
+            "rawType": {"kind": "UInt8"},
+            "fields": [
+              {
+                "id": "rawValue",
+                "type": {"kind": "UInt8"},
+                "access": "pub",
+                "variableKind": "let",
+                "docString": "The raw value of the enum case"
+              }
+            ],
+            "functions": [],
+            "cases": [
+              {"identifier": "up", "rawValue": 0, "docString": "Up"},
+              {"identifier": "down", "rawValue": 1}
+            ]
+          }
+        `,
+		string(actual),
+	)
+}
+
+func TestABIScript(t *testing.T) {
+
+	t.Parallel()
+
+	abi := exportABI(t, `
+      /// Returns the sum
+      pub fun main(a: Int, _ b: Int): Int {
+          return helper(a) + b
+      }
+
+      priv fun helper(_ x: Int): Int {
+          return x
+      }
+    `)
+
+	assert.Empty(t, abi.Declarations)
+	assert.Nil(t, abi.Transaction)
+	assert.Equal(t,
+		[]*Function{
+			{
+				Identifier: "main",
+				DocString:  "Returns the sum",
+				Parameters: []*Parameter{
+					{Label: "", Identifier: "a", Type: cadence.IntType{}},
+					{Label: "_", Identifier: "b", Type: cadence.IntType{}},
+				},
+				ReturnType: cadence.IntType{},
+			},
+		},
+		abi.Functions,
+	)
+}
+
+func TestABITransaction(t *testing.T) {
+
+	t.Parallel()
+
+	abi := exportABI(t, `
+      /// Transfers tokens
+      transaction(amount: UFix64, to: Address) {}
+    `)
+
+	assert.Equal(t,
+		&Transaction{
+			DocString: "Transfers tokens",
+			Parameters: []*Parameter{
+				{Identifier: "amount", Type: cadence.UFix64Type{}},
+				{Identifier: "to", Type: cadence.AddressType{}},
+			},
+		},
+		abi.Transaction,
+	)
+}
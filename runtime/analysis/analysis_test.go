@@ -0,0 +1,93 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/checker"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+const importedLocation = common.StringLocation("imported")
+
+// analyze checks the given code and runs the given analyzers on it.
+//
+// The code may import declarations from the given imported code,
+// using the location `imported`
+//
+func analyze(t *testing.T, code string, importedCode string, analyzers ...*Analyzer) []Diagnostic {
+
+	options := []sema.Option{
+		sema.WithPositionInfoEnabled(true),
+	}
+
+	if importedCode != "" {
+		importedChecker, err := checker.ParseAndCheckWithOptions(t,
+			importedCode,
+			checker.ParseAndCheckOptions{
+				Location: importedLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		options = append(options,
+			sema.WithImportHandler(
+				func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+					return sema.ElaborationImport{
+						Elaboration: importedChecker.Elaboration,
+					}, nil
+				},
+			),
+		)
+	}
+
+	c, err := checker.ParseAndCheckWithOptions(t,
+		code,
+		checker.ParseAndCheckOptions{
+			Options: options,
+		},
+	)
+	require.NoError(t, err)
+
+	diagnostics, err := Run(analyzers, ProgramFromChecker(c))
+	require.NoError(t, err)
+
+	for _, diagnostic := range diagnostics {
+		require.Equal(t, utils.TestLocation, diagnostic.Location)
+	}
+
+	return diagnostics
+}
+
+// diagnosticMessages returns the messages of the given diagnostics
+//
+func diagnosticMessages(diagnostics []Diagnostic) []string {
+	messages := make([]string, len(diagnostics))
+	for i, diagnostic := range diagnostics {
+		messages[i] = diagnostic.Message
+	}
+	return messages
+}
@@ -0,0 +1,119 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+// Package analysis provides a framework for static analyses of checked Cadence programs,
+// modelled on golang.org/x/tools/go/analysis.
+//
+// An analysis is described by an Analyzer. Its Run function is invoked with a Pass,
+// which provides the parsed program and the elaboration produced by the checker,
+// and reports diagnostics.
+//
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// Analyzer describes an analysis of a checked program
+//
+type Analyzer struct {
+	// Name is the name of the analyzer.
+	// It is used as the default category of the diagnostics the analyzer reports,
+	// and to select the analyzer, e.g. on the command line
+	Name string
+	// Doc is the documentation of the analyzer
+	Doc string
+	// Requires are the analyzers which must be run before this analyzer,
+	// and whose results are provided to it in Pass.ResultOf
+	Requires []*Analyzer
+	// Run runs the analyzer on the program of the given pass.
+	// The result is provided to the analyzers which require this analyzer
+	Run func(pass *Pass) (interface{}, error)
+}
+
+func (a *Analyzer) String() string {
+	return a.Name
+}
+
+// Program is a checked program which is analyzed
+//
+type Program struct {
+	Program     *ast.Program
+	Elaboration *sema.Elaboration
+	// Occurrences are the occurrences of declarations in the program.
+	// They are only available if the checker recorded position information,
+	// see sema.WithPositionInfoEnabled, and nil otherwise
+	Occurrences *sema.Occurrences
+	Location    common.Location
+}
+
+func ProgramFromChecker(checker *sema.Checker) *Program {
+	return &Program{
+		Program:     checker.Program,
+		Elaboration: checker.Elaboration,
+		Occurrences: checker.Occurrences,
+		Location:    checker.Location,
+	}
+}
+
+// Pass provides the information about a checked program to the Run function of an analyzer
+//
+type Pass struct {
+	Analyzer    *Analyzer
+	Program     *ast.Program
+	Elaboration *sema.Elaboration
+	// Occurrences are the occurrences of declarations in the program, if available
+	Occurrences *sema.Occurrences
+	Location    common.Location
+	// ResultOf contains the results of the required analyzers
+	ResultOf map[*Analyzer]interface{}
+	// Report reports the given diagnostic
+	Report func(Diagnostic)
+}
+
+// Reportf reports a diagnostic with the given range and formatted message
+//
+func (pass *Pass) Reportf(positioned ast.HasPosition, format string, args ...interface{}) {
+	pass.Report(
+		Diagnostic{
+			Location: pass.Location,
+			Category: pass.Analyzer.Name,
+			Message:  fmt.Sprintf(format, args...),
+			Range:    ast.NewRangeFromPositioned(positioned),
+		},
+	)
+}
+
+// Diagnostic is a finding of an analyzer
+//
+type Diagnostic struct {
+	Location common.Location
+	// Category is the category of the diagnostic,
+	// by default the name of the analyzer which reported it
+	Category string
+	Severity Severity
+	Message  string
+	// SecondaryMessage is an optional explanation of the problem
+	SecondaryMessage string
+	ast.Range
+}
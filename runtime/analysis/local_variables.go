@@ -0,0 +1,201 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// LocalVariable is a variable, constant, parameter, or function
+// which is declared in a function
+//
+type LocalVariable struct {
+	Identifier      ast.Identifier
+	DeclarationKind common.DeclarationKind
+	// IsRead is true if the value of the variable is read.
+	// Assigning to the variable is not a read
+	IsRead bool
+	// IsLoopVariable is true if the variable is declared by a for-in loop
+	IsLoopVariable bool
+	// Shadowed is the variable of an enclosing scope
+	// which is shadowed by this variable, if any
+	Shadowed *LocalVariable
+}
+
+// LocalVariablesAnalyzer resolves the identifiers in all functions of the program
+// to the local variables they refer to.
+//
+// The result is the list of all local variables (*LocalVariable), in declaration order.
+// Global declarations, members, and imported declarations are not local variables.
+//
+var LocalVariablesAnalyzer = &Analyzer{
+	Name: "local-variables",
+	Doc:  "resolves the local variables of functions",
+	Run: func(pass *Pass) (interface{}, error) {
+		resolver := &localVariableResolver{}
+		for _, declaration := range pass.Program.Declarations() {
+			resolver.visit(declaration)
+		}
+		return resolver.variables, nil
+	},
+}
+
+type localVariableResolver struct {
+	scopes    []map[string]*LocalVariable
+	variables []*LocalVariable
+}
+
+func (r *localVariableResolver) enterScope() {
+	r.scopes = append(r.scopes, map[string]*LocalVariable{})
+}
+
+func (r *localVariableResolver) leaveScope() {
+	r.scopes = r.scopes[:len(r.scopes)-1]
+}
+
+func (r *localVariableResolver) find(name string) *LocalVariable {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		variable, ok := r.scopes[i][name]
+		if ok {
+			return variable
+		}
+	}
+	return nil
+}
+
+func (r *localVariableResolver) declare(identifier ast.Identifier, kind common.DeclarationKind) *LocalVariable {
+
+	// Declarations outside of functions are not local
+
+	if len(r.scopes) == 0 {
+		return nil
+	}
+
+	name := identifier.Identifier
+	if name == "" || name == "_" {
+		return nil
+	}
+
+	variable := &LocalVariable{
+		Identifier:      identifier,
+		DeclarationKind: kind,
+		Shadowed:        r.find(name),
+	}
+
+	r.scopes[len(r.scopes)-1][name] = variable
+	r.variables = append(r.variables, variable)
+
+	return variable
+}
+
+func (r *localVariableResolver) declareLoopVariable(identifier ast.Identifier) {
+	variable := r.declare(identifier, common.DeclarationKindConstant)
+	if variable != nil {
+		variable.IsLoopVariable = true
+	}
+}
+
+func (r *localVariableResolver) visit(element ast.Element) {
+	switch element := element.(type) {
+	case *ast.FunctionDeclaration:
+		r.declare(element.Identifier, common.DeclarationKindFunction)
+		r.visitFunction(element.ParameterList, element.FunctionBlock)
+
+	case *ast.SpecialFunctionDeclaration:
+		r.visitFunction(
+			element.FunctionDeclaration.ParameterList,
+			element.FunctionDeclaration.FunctionBlock,
+		)
+
+	case *ast.FunctionExpression:
+		r.visitFunction(element.ParameterList, element.FunctionBlock)
+
+	case *ast.Block:
+		r.enterScope()
+		element.Walk(r.visit)
+		r.leaveScope()
+
+	case *ast.VariableDeclaration:
+		// The value is visited before the variable is declared,
+		// as it may refer to a shadowed variable with the same name
+		element.Walk(r.visit)
+		r.declare(element.Identifier, element.DeclarationKind())
+
+	case *ast.IfStatement:
+		// The variable declared in an optional binding
+		// is only in scope in the then-branch
+		if variableDeclaration, ok := element.Test.(*ast.VariableDeclaration); ok {
+			variableDeclaration.Walk(r.visit)
+			r.enterScope()
+			r.declare(variableDeclaration.Identifier, variableDeclaration.DeclarationKind())
+			r.visit(element.Then)
+			r.leaveScope()
+		} else {
+			r.visit(element.Test)
+			r.visit(element.Then)
+		}
+		if element.Else != nil {
+			r.visit(element.Else)
+		}
+
+	case *ast.ForStatement:
+		r.visit(element.Value)
+		r.enterScope()
+		if element.Index != nil {
+			r.declareLoopVariable(*element.Index)
+		}
+		r.declareLoopVariable(element.Identifier)
+		r.visit(element.Block)
+		r.leaveScope()
+
+	case *ast.AssignmentStatement:
+		// Assigning to a variable does not read it,
+		// but assigning to a member or element of it does
+		if _, ok := element.Target.(*ast.IdentifierExpression); !ok {
+			r.visit(element.Target)
+		}
+		r.visit(element.Value)
+
+	case *ast.IdentifierExpression:
+		variable := r.find(element.Identifier.Identifier)
+		if variable != nil {
+			variable.IsRead = true
+		}
+
+	default:
+		element.Walk(r.visit)
+	}
+}
+
+func (r *localVariableResolver) visitFunction(parameterList *ast.ParameterList, functionBlock *ast.FunctionBlock) {
+	r.enterScope()
+	defer r.leaveScope()
+
+	if parameterList != nil {
+		for _, parameter := range parameterList.Parameters {
+			r.declare(parameter.Identifier, common.DeclarationKindParameter)
+		}
+	}
+
+	if functionBlock != nil {
+		r.visit(functionBlock)
+	}
+}
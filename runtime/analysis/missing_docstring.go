@@ -0,0 +1,86 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// MissingDocStringAnalyzer reports public declarations which have no docstring:
+// composite and interface declarations, and their public fields, functions,
+// and nested declarations.
+//
+// Global functions and variables are not reported,
+// as they are usually the entry points of scripts.
+//
+var MissingDocStringAnalyzer = &Analyzer{
+	Name: "missing-docstring",
+	Doc:  "reports public declarations which have no docstring",
+	Run: func(pass *Pass) (interface{}, error) {
+
+		var check func(declaration ast.Declaration, isMember bool)
+		check = func(declaration ast.Declaration, isMember bool) {
+
+			members := declaration.DeclarationMembers()
+			if !isMember && members == nil {
+				return
+			}
+
+			identifier := declaration.DeclarationIdentifier()
+
+			if identifier != nil &&
+				isPublicAccess(declaration.DeclarationAccess()) &&
+				strings.TrimSpace(declaration.DeclarationDocString()) == "" {
+
+				pass.Reportf(
+					identifier,
+					"public %s `%s` has no docstring",
+					declaration.DeclarationKind().Name(),
+					identifier.Identifier,
+				)
+			}
+
+			if members == nil {
+				return
+			}
+
+			for _, member := range members.Declarations() {
+				check(member, true)
+			}
+		}
+
+		for _, declaration := range pass.Program.Declarations() {
+			check(declaration, false)
+		}
+
+		return nil, nil
+	},
+}
+
+func isPublicAccess(access ast.Access) bool {
+	switch access {
+	case ast.AccessPublic, ast.AccessPublicSettable:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingDocStringAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := analyze(t,
+		`
+          pub fun main() {}
+
+          /// S is documented
+          pub struct S {
+
+              pub let undocumentedField: Int
+
+              /// This field is documented
+              pub let documentedField: Int
+
+              let privateField: Int
+
+              pub fun undocumentedFunction() {}
+
+              /// This function is documented
+              pub(set) var documentedVariable: Int
+
+              init() {
+                  self.undocumentedField = 1
+                  self.documentedField = 2
+                  self.privateField = 3
+                  self.documentedVariable = 4
+              }
+          }
+
+          pub resource interface RI {
+              pub fun f()
+          }
+
+          struct P {
+              pub let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+        `,
+		"",
+		MissingDocStringAnalyzer,
+	)
+
+	assert.Equal(t,
+		[]string{
+			"public field `undocumentedField` has no docstring",
+			"public function `undocumentedFunction` has no docstring",
+			"public resource interface `RI` has no docstring",
+			"public function `f` has no docstring",
+			"public field `x` has no docstring",
+		},
+		diagnosticMessages(diagnostics),
+	)
+}
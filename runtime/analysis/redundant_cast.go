@@ -0,0 +1,113 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// RedundantCastAnalyzer reports casts which have no effect:
+// Static casts (`as`) to the type the value already has, or to the type which is already expected,
+// and failable casts (`as?`) and force casts (`as!`) which always succeed,
+// because the static type of the value is a subtype of the target type.
+//
+var RedundantCastAnalyzer = &Analyzer{
+	Name: "redundant-cast",
+	Doc:  "reports casts which have no effect",
+	Run: func(pass *Pass) (interface{}, error) {
+		elaboration := pass.Elaboration
+
+		ast.Inspect(pass.Program, func(element ast.Element) bool {
+			castingExpression, ok := element.(*ast.CastingExpression)
+			if !ok {
+				return true
+			}
+
+			valueType := elaboration.CastingStaticValueTypes[castingExpression]
+			targetType := elaboration.CastingTargetTypes[castingExpression]
+
+			if valueType == nil || targetType == nil ||
+				valueType.IsInvalidType() || targetType.IsInvalidType() {
+
+				return true
+			}
+
+			switch castingExpression.Operation {
+			case ast.OperationCast:
+				expectedType := elaboration.CastingExpectedTypes[castingExpression]
+
+				if (expectedType != nil && expectedType.Equal(targetType)) ||
+					(!isTypeInferredFromExpectedType(castingExpression.Expression) &&
+						valueType.Equal(targetType)) {
+
+					pass.Report(
+						Diagnostic{
+							Message: fmt.Sprintf("cast to `%s` is redundant", targetType),
+							Range:   ast.NewRangeFromPositioned(castingExpression.TypeAnnotation),
+						},
+					)
+				}
+
+			case ast.OperationFailableCast, ast.OperationForceCast:
+				if sema.IsSubType(valueType, targetType) {
+					pass.Report(
+						Diagnostic{
+							Message: fmt.Sprintf(
+								"%s cast ('%s') from `%s` to `%s` always succeeds",
+								castKind(castingExpression.Operation),
+								castingExpression.Operation.Symbol(),
+								valueType,
+								targetType,
+							),
+							SecondaryMessage: "consider using a static cast (`as`) or removing the cast",
+							Range:            ast.NewRangeFromPositioned(castingExpression),
+						},
+					)
+				}
+			}
+
+			return true
+		})
+
+		return nil, nil
+	},
+}
+
+// isTypeInferredFromExpectedType returns true if the type of the given expression
+// may depend on the expected type, e.g. the type of the integer literal `1` in `1 as UInt8`
+//
+func isTypeInferredFromExpectedType(expression ast.Expression) bool {
+	switch expression.(type) {
+	case *ast.IdentifierExpression, *ast.MemberExpression, *ast.IndexExpression:
+		return false
+	default:
+		return true
+	}
+}
+
+func castKind(operation ast.Operation) string {
+	if operation == ast.OperationFailableCast {
+		return "failable"
+	}
+	return "force"
+}
@@ -0,0 +1,83 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedundantCastAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("static cast", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              fun test(x: Int, y: Int8) {
+                  let a = x as Int
+                  let b: Int8 = 1 as Int8
+                  let c = 1 as Int8
+                  let d = y as Integer
+              }
+            `,
+			"",
+			RedundantCastAnalyzer,
+		)
+
+		assert.Equal(t,
+			[]string{
+				"cast to `Int` is redundant",
+				"cast to `Int8` is redundant",
+			},
+			diagnosticMessages(diagnostics),
+		)
+	})
+
+	t.Run("failable and force cast", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              fun test(x: Int, y: AnyStruct) {
+                  let a = x as? Int
+                  let b = x as! Integer
+                  let c = y as? Int
+                  let d = y as! Int
+              }
+            `,
+			"",
+			RedundantCastAnalyzer,
+		)
+
+		assert.Equal(t,
+			[]string{
+				"failable cast ('as?') from `Int` to `Int` always succeeds",
+				"force cast ('as!') from `Int` to `Integer` always succeeds",
+			},
+			diagnosticMessages(diagnostics),
+		)
+	})
+}
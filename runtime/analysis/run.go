@@ -0,0 +1,120 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Run runs the given analyzers and the analyzers they require
+// on the given checked program, and returns the reported diagnostics,
+// ordered by their position.
+//
+// Each analyzer is run at most once, even if it is required by multiple analyzers.
+//
+func Run(analyzers []*Analyzer, program *Program) ([]Diagnostic, error) {
+	runner := &runner{
+		program: program,
+		results: map[*Analyzer]interface{}{},
+		running: map[*Analyzer]bool{},
+	}
+
+	for _, analyzer := range analyzers {
+		_, err := runner.run(analyzer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	diagnostics := runner.diagnostics
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a := diagnostics[i].StartPos
+		b := diagnostics[j].StartPos
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	return diagnostics, nil
+}
+
+type runner struct {
+	program     *Program
+	results     map[*Analyzer]interface{}
+	running     map[*Analyzer]bool
+	diagnostics []Diagnostic
+}
+
+func (r *runner) run(analyzer *Analyzer) (interface{}, error) {
+	if result, ok := r.results[analyzer]; ok {
+		return result, nil
+	}
+
+	if r.running[analyzer] {
+		return nil, fmt.Errorf("cyclic requirement of analyzer %s", analyzer.Name)
+	}
+	r.running[analyzer] = true
+	defer delete(r.running, analyzer)
+
+	resultOf := make(map[*Analyzer]interface{}, len(analyzer.Requires))
+
+	for _, required := range analyzer.Requires {
+		result, err := r.run(required)
+		if err != nil {
+			return nil, err
+		}
+		resultOf[required] = result
+	}
+
+	program := r.program
+
+	pass := &Pass{
+		Analyzer:    analyzer,
+		Program:     program.Program,
+		Elaboration: program.Elaboration,
+		Occurrences: program.Occurrences,
+		Location:    program.Location,
+		ResultOf:    resultOf,
+		Report: func(diagnostic Diagnostic) {
+			if diagnostic.Location == nil {
+				diagnostic.Location = program.Location
+			}
+			if diagnostic.Category == "" {
+				diagnostic.Category = analyzer.Name
+			}
+			if diagnostic.Severity == SeverityUnspecified {
+				diagnostic.Severity = SeverityWarning
+			}
+			r.diagnostics = append(r.diagnostics, diagnostic)
+		},
+	}
+
+	result, err := analyzer.Run(pass)
+	if err != nil {
+		return nil, fmt.Errorf("analyzer %s failed: %w", analyzer.Name, err)
+	}
+
+	r.results[analyzer] = result
+
+	return result, nil
+}
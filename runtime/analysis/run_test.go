@@ -0,0 +1,141 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/tests/checker"
+)
+
+func TestRun(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("requirements", func(t *testing.T) {
+
+		t.Parallel()
+
+		var runs int
+
+		countAnalyzer := &Analyzer{
+			Name: "count",
+			Run: func(pass *Pass) (interface{}, error) {
+				runs++
+				return len(pass.Program.Declarations()), nil
+			},
+		}
+
+		reportAnalyzer := func(name string) *Analyzer {
+			return &Analyzer{
+				Name:     name,
+				Requires: []*Analyzer{countAnalyzer},
+				Run: func(pass *Pass) (interface{}, error) {
+					count := pass.ResultOf[countAnalyzer].(int)
+					pass.Reportf(pass.Program.Declarations()[0], "%d declarations", count)
+					return nil, nil
+				},
+			}
+		}
+
+		diagnostics := analyze(t,
+			`
+              let x = 1
+              let y = 2
+            `,
+			"",
+			reportAnalyzer("a"),
+			reportAnalyzer("b"),
+		)
+
+		assert.Equal(t, 1, runs)
+
+		require.Len(t, diagnostics, 2)
+
+		assert.Equal(t, "a", diagnostics[0].Category)
+		assert.Equal(t, "2 declarations", diagnostics[0].Message)
+		assert.Equal(t, "b", diagnostics[1].Category)
+		assert.Equal(t, "2 declarations", diagnostics[1].Message)
+	})
+
+	t.Run("diagnostics are sorted", func(t *testing.T) {
+
+		t.Parallel()
+
+		reverseAnalyzer := &Analyzer{
+			Name: "reverse",
+			Run: func(pass *Pass) (interface{}, error) {
+				declarations := pass.Program.Declarations()
+				for i := len(declarations) - 1; i >= 0; i-- {
+					declaration := declarations[i]
+					identifier := declaration.DeclarationIdentifier()
+					pass.Report(
+						Diagnostic{
+							Category: "custom",
+							Message:  identifier.Identifier,
+							Range:    ast.NewRangeFromPositioned(identifier),
+						},
+					)
+				}
+				return nil, nil
+			},
+		}
+
+		diagnostics := analyze(t,
+			`
+              let x = 1
+              let y = 2
+            `,
+			"",
+			reverseAnalyzer,
+		)
+
+		assert.Equal(t, []string{"x", "y"}, diagnosticMessages(diagnostics))
+		assert.Equal(t, "custom", diagnostics[0].Category)
+	})
+
+	t.Run("cyclic requirement", func(t *testing.T) {
+
+		t.Parallel()
+
+		a := &Analyzer{
+			Name: "a",
+			Run: func(_ *Pass) (interface{}, error) {
+				return nil, nil
+			},
+		}
+		b := &Analyzer{
+			Name:     "b",
+			Requires: []*Analyzer{a},
+			Run:      a.Run,
+		}
+		a.Requires = []*Analyzer{b}
+
+		c, err := checker.ParseAndCheck(t, `let x = 1`)
+		require.NoError(t, err)
+
+		_, err = Run([]*Analyzer{a}, ProgramFromChecker(c))
+		require.EqualError(t, err, "cyclic requirement of analyzer a")
+	})
+}
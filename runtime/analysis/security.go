@@ -0,0 +1,596 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+const (
+	SecurityCategoryPublicCapability      = "security/public-capability"
+	SecurityCategoryPublicField           = "security/public-field"
+	SecurityCategoryAuthReference         = "security/auth-reference"
+	SecurityCategoryAuthAccountParameter  = "security/auth-account-parameter"
+	SecurityCategoryUncheckedBorrowResult = "security/unchecked-borrow"
+)
+
+// withdrawFunctionNamePrefixes are the prefixes of the names of functions
+// which should not be callable by anyone, e.g. `withdraw` or `mintTokens`
+//
+var withdrawFunctionNamePrefixes = []string{
+	"withdraw",
+	"mint",
+}
+
+// SecurityAnalyzer reports common security problems related to capabilities and access control:
+//
+// Public capabilities which are linked to a type that exposes withdraw-style functions,
+// public fields of resource or collection type, which anyone can access or mutate,
+// authorized references returned from public functions,
+// public functions which have `AuthAccount` parameters,
+// and results of `borrow` which are not checked, so failures go unnoticed.
+//
+// The detection of unchecked borrow results stored in variables
+// requires the occurrences of the program.
+//
+var SecurityAnalyzer = &Analyzer{
+	Name: "security",
+	Doc:  "reports common capability and access control security problems",
+	Run: func(pass *Pass) (interface{}, error) {
+		analyzer := &securityAnalyzer{
+			pass:               pass,
+			parents:            map[ast.Element]ast.Element{},
+			identifierElements: map[ast.Position]*ast.IdentifierExpression{},
+		}
+		analyzer.analyze()
+		return nil, nil
+	},
+}
+
+type securityAnalyzer struct {
+	pass *Pass
+	// parents are the parent elements of all elements of the program
+	parents map[ast.Element]ast.Element
+	// identifierElements are the identifier expressions of the program,
+	// by their start position (without offset)
+	identifierElements map[ast.Position]*ast.IdentifierExpression
+	invocations        []*ast.InvocationExpression
+}
+
+func (a *securityAnalyzer) analyze() {
+	program := a.pass.Program
+
+	var stack []ast.Element
+
+	ast.Inspect(program, func(element ast.Element) bool {
+		if element == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+
+		if len(stack) > 0 {
+			a.parents[element] = stack[len(stack)-1]
+		}
+		stack = append(stack, element)
+
+		switch element := element.(type) {
+		case *ast.IdentifierExpression:
+			a.identifierElements[positionKey(element.Identifier.Pos)] = element
+
+		case *ast.InvocationExpression:
+			a.invocations = append(a.invocations, element)
+		}
+
+		return true
+	})
+
+	for _, declaration := range program.Declarations() {
+		a.checkDeclaration(declaration)
+	}
+
+	for _, invocation := range a.invocations {
+		a.checkInvocation(invocation)
+	}
+}
+
+func positionKey(position ast.Position) ast.Position {
+	return ast.Position{
+		Line:   position.Line,
+		Column: position.Column,
+	}
+}
+
+func (a *securityAnalyzer) report(
+	category string,
+	severity Severity,
+	positioned ast.HasPosition,
+	message string,
+	explanation string,
+) {
+	a.pass.Report(
+		Diagnostic{
+			Category:         category,
+			Severity:         severity,
+			Message:          message,
+			SecondaryMessage: explanation,
+			Range:            ast.NewRangeFromPositioned(positioned),
+		},
+	)
+}
+
+// checkDeclaration checks the fields and functions of the given declaration and its nested declarations.
+//
+// Global functions are not checked, as they can only be called by the program itself
+//
+func (a *securityAnalyzer) checkDeclaration(declaration ast.Declaration) {
+	elaboration := a.pass.Elaboration
+
+	switch declaration := declaration.(type) {
+	case *ast.CompositeDeclaration:
+		compositeType := elaboration.CompositeDeclarationTypes[declaration]
+		if compositeType != nil {
+			a.checkMembers(declaration.Members, compositeType.Members)
+		}
+
+	case *ast.InterfaceDeclaration:
+		interfaceType := elaboration.InterfaceDeclarationTypes[declaration]
+		if interfaceType != nil {
+			a.checkMembers(declaration.Members, interfaceType.Members)
+		}
+	}
+}
+
+func (a *securityAnalyzer) checkMembers(members *ast.Members, memberTypes *sema.StringMemberOrderedMap) {
+	for _, field := range members.Fields() {
+		member, ok := memberTypes.Get(field.Identifier.Identifier)
+		if !ok {
+			continue
+		}
+		a.checkField(field, member.TypeAnnotation.Type)
+	}
+
+	for _, function := range members.Functions() {
+		member, ok := memberTypes.Get(function.Identifier.Identifier)
+		if !ok {
+			continue
+		}
+		functionType, ok := member.TypeAnnotation.Type.(*sema.FunctionType)
+		if !ok {
+			continue
+		}
+		a.checkFunction(function, functionType)
+	}
+
+	for _, nestedDeclaration := range members.Declarations() {
+		switch nestedDeclaration.(type) {
+		case *ast.CompositeDeclaration, *ast.InterfaceDeclaration:
+			a.checkDeclaration(nestedDeclaration)
+		}
+	}
+}
+
+// checkField reports public fields of resource or collection type
+//
+func (a *securityAnalyzer) checkField(field *ast.FieldDeclaration, fieldType sema.Type) {
+	if !isPubliclyAccessible(field.Access) || fieldType == nil {
+		return
+	}
+
+	isResource := fieldType.IsResourceType()
+	isCollection := isCollectionType(fieldType)
+
+	if !isResource && !isCollection {
+		return
+	}
+
+	name := field.Identifier.Identifier
+
+	if field.Access == ast.AccessPublicSettable {
+		a.report(
+			SecurityCategoryPublicField,
+			SeverityError,
+			field.Identifier,
+			fmt.Sprintf(
+				"field `%s` of type `%s` is publicly settable",
+				name,
+				fieldType.QualifiedString(),
+			),
+			"anyone can replace the value of the field. "+
+				"Consider restricting the access of the field, e.g. to `access(contract)`, "+
+				"and providing functions which perform the necessary checks",
+		)
+		return
+	}
+
+	var explanation string
+	if isCollection {
+		explanation = "anyone can mutate the collection, e.g. using `append`, `insert`, or `remove`. " +
+			"Consider restricting the access of the field, " +
+			"and providing functions which only allow the intended modifications"
+	} else {
+		explanation = "anyone can access the resource and call its public functions. " +
+			"Consider restricting the access of the field, " +
+			"and providing functions which only allow the intended operations"
+	}
+
+	a.report(
+		SecurityCategoryPublicField,
+		SeverityWarning,
+		field.Identifier,
+		fmt.Sprintf(
+			"public field `%s` has %s type `%s`",
+			name,
+			resourceOrCollection(isResource),
+			fieldType.QualifiedString(),
+		),
+		explanation,
+	)
+}
+
+func resourceOrCollection(isResource bool) string {
+	if isResource {
+		return "resource"
+	}
+	return "collection"
+}
+
+// checkFunction reports public functions which return authorized references,
+// or which have `AuthAccount` parameters
+//
+func (a *securityAnalyzer) checkFunction(function *ast.FunctionDeclaration, functionType *sema.FunctionType) {
+	if !isPubliclyAccessible(function.Access) || functionType == nil {
+		return
+	}
+
+	name := function.Identifier.Identifier
+
+	returnType := functionType.ReturnTypeAnnotation.Type
+	if referenceType := unwrapReferenceType(returnType); referenceType != nil && referenceType.Authorized {
+
+		var positioned ast.HasPosition = function.Identifier
+		if function.ReturnTypeAnnotation != nil {
+			positioned = function.ReturnTypeAnnotation
+		}
+
+		a.report(
+			SecurityCategoryAuthReference,
+			SeverityWarning,
+			positioned,
+			fmt.Sprintf(
+				"public function `%s` returns authorized reference `%s`",
+				name,
+				returnType.QualifiedString(),
+			),
+			"an authorized reference can be downcast to the concrete type of the referenced value, "+
+				"which gives access to all of its public members, not just the ones of the reference type. "+
+				"Consider returning an unauthorized reference",
+		)
+	}
+
+	parameters := function.ParameterList.Parameters
+	for i, parameter := range functionType.Parameters {
+		if i >= len(parameters) || !isAuthAccountType(parameter.TypeAnnotation.Type) {
+			continue
+		}
+
+		a.report(
+			SecurityCategoryAuthAccountParameter,
+			SeverityError,
+			parameters[i].Identifier,
+			fmt.Sprintf(
+				"public function `%s` has parameter `%s` of type `%s`",
+				name,
+				parameter.Identifier,
+				parameter.TypeAnnotation.Type.QualifiedString(),
+			),
+			"callers must give the function full access to their account, "+
+				"including its storage, keys, and contracts. "+
+				"Consider requiring a capability or a reference to the specific value which is needed instead",
+		)
+	}
+}
+
+// checkInvocation reports links of public capabilities
+// to types which expose withdraw-style functions,
+// and unchecked results of `borrow`
+//
+func (a *securityAnalyzer) checkInvocation(invocation *ast.InvocationExpression) {
+	memberExpression, ok := invocation.InvokedExpression.(*ast.MemberExpression)
+	if !ok {
+		return
+	}
+
+	memberInfo, ok := a.pass.Elaboration.MemberExpressionMemberInfos[memberExpression]
+	if !ok || memberInfo.Member == nil {
+		return
+	}
+
+	member := memberInfo.Member
+
+	switch member.Identifier.Identifier {
+	case sema.AuthAccountLinkField:
+		if member.ContainerType == sema.AuthAccountType {
+			a.checkLink(invocation)
+		}
+
+	case sema.AuthAccountBorrowField:
+		switch member.ContainerType.(type) {
+		case *sema.CompositeType:
+			if member.ContainerType != sema.AuthAccountType {
+				return
+			}
+		case *sema.CapabilityType:
+			break
+		default:
+			return
+		}
+
+		a.checkBorrow(invocation)
+	}
+}
+
+func (a *securityAnalyzer) checkLink(invocation *ast.InvocationExpression) {
+	if len(invocation.Arguments) == 0 {
+		return
+	}
+
+	pathExpression, ok := invocation.Arguments[0].Expression.(*ast.PathExpression)
+	if !ok {
+		return
+	}
+
+	domain := common.PathDomainFromIdentifier(pathExpression.Domain.Identifier)
+	if domain != common.PathDomainPublic {
+		return
+	}
+
+	typeArguments := a.pass.Elaboration.InvocationExpressionTypeArguments[invocation]
+	if typeArguments == nil || typeArguments.Len() == 0 {
+		return
+	}
+
+	linkedType := typeArguments.Oldest().Value
+
+	for _, member := range exposedMembers(linkedType) {
+		if member.DeclarationKind != common.DeclarationKindFunction ||
+			!isPubliclyAccessible(member.Access) ||
+			!isWithdrawFunctionName(member.Identifier.Identifier) {
+
+			continue
+		}
+
+		a.report(
+			SecurityCategoryPublicCapability,
+			SeverityError,
+			invocation,
+			fmt.Sprintf(
+				"public capability of type `%s` exposes function `%s`",
+				linkedType.QualifiedString(),
+				member.Identifier.Identifier,
+			),
+			fmt.Sprintf(
+				"anyone can borrow a reference from the public capability and call `%s`. "+
+					"Consider linking the capability using a restricted type "+
+					"which only exposes the functions that are safe to be called by anyone",
+				member.Identifier.Identifier,
+			),
+		)
+	}
+}
+
+func (a *securityAnalyzer) checkBorrow(invocation *ast.InvocationExpression) {
+	if a.optionalUsage(invocation) != optionalUsageUnchecked {
+		return
+	}
+
+	a.report(
+		SecurityCategoryUncheckedBorrowResult,
+		SeverityWarning,
+		invocation,
+		"result of `borrow` is not checked",
+		"borrowing fails and returns `nil` if nothing is stored, or the stored value has a different type. "+
+			"Accessing the result using optional chaining silently skips the operation in this case. "+
+			"Consider checking the result, e.g. using optional binding (`if let`), "+
+			"or aborting with a message (`?? panic(\"...\")`)",
+	)
+}
+
+type optionalUsage int
+
+const (
+	// optionalUsageOther: the optional is used in another way, e.g. returned or passed to a function
+	optionalUsageOther optionalUsage = iota
+	// optionalUsageChecked: the optional is checked, e.g. compared to `nil`, bound, or forced
+	optionalUsageChecked
+	// optionalUsageUnchecked: the optional is only accessed using optional chaining, or discarded
+	optionalUsageUnchecked
+)
+
+// optionalUsage determines how the optional value of the given expression is used
+//
+func (a *securityAnalyzer) optionalUsage(expression ast.Expression) optionalUsage {
+	switch parent := a.parents[expression].(type) {
+	case *ast.ForceExpression:
+		return optionalUsageChecked
+
+	case *ast.BinaryExpression:
+		switch parent.Operation {
+		case ast.OperationNilCoalesce,
+			ast.OperationEqual,
+			ast.OperationNotEqual:
+
+			return optionalUsageChecked
+		}
+
+	case *ast.MemberExpression:
+		if parent.Optional {
+			return optionalUsageUnchecked
+		}
+
+	case *ast.ExpressionStatement:
+		return optionalUsageUnchecked
+
+	case *ast.VariableDeclaration:
+		if parent.Value != expression {
+			break
+		}
+		if parent.ParentIfStatement != nil {
+			return optionalUsageChecked
+		}
+		return a.variableUsage(parent)
+	}
+
+	return optionalUsageOther
+}
+
+// variableUsage determines how the optional value stored in the variable
+// declared by the given declaration is used, based on the occurrences of the variable.
+//
+// The value is unchecked if all uses of the variable are unchecked
+//
+func (a *securityAnalyzer) variableUsage(declaration *ast.VariableDeclaration) optionalUsage {
+	occurrences := a.pass.Occurrences
+	if occurrences == nil {
+		return optionalUsageOther
+	}
+
+	declarationPosition := declaration.Identifier.Pos
+
+	occurrence := occurrences.Find(sema.ASTToSemaPosition(declarationPosition))
+	if occurrence == nil || occurrence.Origin == nil {
+		return optionalUsageOther
+	}
+
+	result := optionalUsageUnchecked
+
+	for _, occurrenceRange := range occurrence.Origin.Occurrences {
+		position := positionKey(occurrenceRange.StartPos)
+		if position == positionKey(declarationPosition) {
+			continue
+		}
+
+		identifierExpression, ok := a.identifierElements[position]
+		if !ok {
+			return optionalUsageOther
+		}
+
+		// Assigning a new value to the variable is not a use
+
+		if assignment, ok := a.parents[identifierExpression].(*ast.AssignmentStatement); ok &&
+			assignment.Target == identifierExpression {
+
+			continue
+		}
+
+		switch a.optionalUsage(identifierExpression) {
+		case optionalUsageChecked:
+			return optionalUsageChecked
+		case optionalUsageOther:
+			result = optionalUsageOther
+		}
+	}
+
+	return result
+}
+
+func isPubliclyAccessible(access ast.Access) bool {
+	return access == ast.AccessNotSpecified ||
+		isPublicAccess(access)
+}
+
+func isWithdrawFunctionName(name string) bool {
+	name = strings.ToLower(name)
+	for _, prefix := range withdrawFunctionNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func unwrapOptionalType(ty sema.Type) sema.Type {
+	for {
+		optionalType, ok := ty.(*sema.OptionalType)
+		if !ok {
+			return ty
+		}
+		ty = optionalType.Type
+	}
+}
+
+func isCollectionType(ty sema.Type) bool {
+	switch unwrapOptionalType(ty).(type) {
+	case *sema.VariableSizedType, *sema.ConstantSizedType, *sema.DictionaryType:
+		return true
+	default:
+		return false
+	}
+}
+
+func unwrapReferenceType(ty sema.Type) *sema.ReferenceType {
+	referenceType, _ := unwrapOptionalType(ty).(*sema.ReferenceType)
+	return referenceType
+}
+
+func isAuthAccountType(ty sema.Type) bool {
+	ty = unwrapOptionalType(ty)
+	if referenceType, ok := ty.(*sema.ReferenceType); ok {
+		ty = referenceType.Type
+	}
+	return ty == sema.AuthAccountType
+}
+
+// exposedMembers returns the members which are available on a value of the given type,
+// e.g. the members of a reference's referenced type, or the members of a restricted type's restrictions
+//
+func exposedMembers(ty sema.Type) []*sema.Member {
+	var members []*sema.Member
+
+	addMembers := func(memberMap *sema.StringMemberOrderedMap) {
+		memberMap.Foreach(func(_ string, member *sema.Member) {
+			members = append(members, member)
+		})
+	}
+
+	switch ty := unwrapOptionalType(ty).(type) {
+	case *sema.ReferenceType:
+		return exposedMembers(ty.Type)
+
+	case *sema.RestrictedType:
+		if len(ty.Restrictions) == 0 {
+			return exposedMembers(ty.Type)
+		}
+		for _, restriction := range ty.Restrictions {
+			addMembers(restriction.Members)
+		}
+
+	case *sema.CompositeType:
+		addMembers(ty.Members)
+
+	case *sema.InterfaceType:
+		addMembers(ty.Members)
+	}
+
+	return members
+}
@@ -0,0 +1,401 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// securityFindings returns the categories, severities, and messages of the given diagnostics
+//
+func securityFindings(diagnostics []Diagnostic) [][3]string {
+	findings := make([][3]string, len(diagnostics))
+	for i, diagnostic := range diagnostics {
+		findings[i] = [3]string{
+			diagnostic.Category,
+			diagnostic.Severity.Name(),
+			diagnostic.Message,
+		}
+	}
+	return findings
+}
+
+func TestSecurityAnalyzerPublicCapability(t *testing.T) {
+
+	t.Parallel()
+
+	const vault = `
+      pub resource interface Receiver {
+          pub fun deposit(amount: Int)
+      }
+
+      pub resource Vault: Receiver {
+          pub var balance: Int
+
+          init() {
+              self.balance = 0
+          }
+
+          pub fun deposit(amount: Int) {
+              self.balance = self.balance + amount
+          }
+
+          pub fun withdraw(amount: Int): Int {
+              self.balance = self.balance - amount
+              return amount
+          }
+      }
+    `
+
+	t.Run("concrete type", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			vault+`
+              fun test(account: AuthAccount) {
+                  account.link<&Vault>(/public/vault, target: /storage/vault)
+              }
+            `,
+			"",
+			SecurityAnalyzer,
+		)
+
+		require.Equal(t,
+			[][3]string{
+				{
+					"security/public-capability",
+					"error",
+					"public capability of type `&Vault` exposes function `withdraw`",
+				},
+			},
+			securityFindings(diagnostics),
+		)
+	})
+
+	t.Run("unrestricted type", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			vault+`
+              fun test(account: AuthAccount) {
+                  account.link<&Vault{}>(/public/vault, target: /storage/vault)
+              }
+            `,
+			"",
+			SecurityAnalyzer,
+		)
+
+		require.Len(t, diagnostics, 1)
+		require.Equal(t, "security/public-capability", diagnostics[0].Category)
+	})
+
+	t.Run("restricted type", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			vault+`
+              fun test(account: AuthAccount) {
+                  account.link<&Vault{Receiver}>(/public/vault, target: /storage/vault)
+              }
+            `,
+			"",
+			SecurityAnalyzer,
+		)
+
+		require.Empty(t, diagnostics)
+	})
+
+	t.Run("private path", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			vault+`
+              fun test(account: AuthAccount) {
+                  account.link<&Vault>(/private/vault, target: /storage/vault)
+              }
+            `,
+			"",
+			SecurityAnalyzer,
+		)
+
+		require.Empty(t, diagnostics)
+	})
+}
+
+func TestSecurityAnalyzerPublicField(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := analyze(t,
+		`
+          pub resource R {}
+
+          pub contract C {
+              pub let ids: [UInt64]
+              pub(set) var names: {String: String}
+              pub var r: @R?
+              pub var count: Int
+              access(contract) let owners: [Address]
+
+              init() {
+                  self.ids = []
+                  self.names = {}
+                  self.r <- nil
+                  self.count = 0
+                  self.owners = []
+              }
+          }
+        `,
+		"",
+		SecurityAnalyzer,
+	)
+
+	require.Equal(t,
+		[][3]string{
+			{
+				"security/public-field",
+				"warning",
+				"public field `ids` has collection type `[UInt64]`",
+			},
+			{
+				"security/public-field",
+				"error",
+				"field `names` of type `{String: String}` is publicly settable",
+			},
+			{
+				"security/public-field",
+				"warning",
+				"public field `r` has resource type `R?`",
+			},
+		},
+		securityFindings(diagnostics),
+	)
+}
+
+func TestSecurityAnalyzerAuthReference(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := analyze(t,
+		`
+          pub resource R {}
+
+          pub resource Collection {
+              access(self) var r: @R
+
+              init() {
+                  self.r <- create R()
+              }
+
+              pub fun borrowAuth(): auth &R {
+                  return &self.r as auth &R
+              }
+
+              pub fun borrow(): &R {
+                  return &self.r as &R
+              }
+
+              access(contract) fun borrowAuthContract(): auth &R {
+                  return &self.r as auth &R
+              }
+
+              destroy() {
+                  destroy self.r
+              }
+          }
+        `,
+		"",
+		SecurityAnalyzer,
+	)
+
+	require.Equal(t,
+		[][3]string{
+			{
+				"security/auth-reference",
+				"warning",
+				"public function `borrowAuth` returns authorized reference `auth &R`",
+			},
+		},
+		securityFindings(diagnostics),
+	)
+}
+
+func TestSecurityAnalyzerAuthAccountParameter(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := analyze(t,
+		`
+          pub contract C {
+              pub fun setup(account: AuthAccount) {}
+
+              pub fun setupWithReference(account: &AuthAccount) {}
+
+              access(contract) fun setupInternal(account: AuthAccount) {}
+
+              pub fun read(account: PublicAccount) {}
+          }
+        `,
+		"",
+		SecurityAnalyzer,
+	)
+
+	require.Equal(t,
+		[][3]string{
+			{
+				"security/auth-account-parameter",
+				"error",
+				"public function `setup` has parameter `account` of type `AuthAccount`",
+			},
+			{
+				"security/auth-account-parameter",
+				"error",
+				"public function `setupWithReference` has parameter `account` of type `&AuthAccount`",
+			},
+		},
+		securityFindings(diagnostics),
+	)
+}
+
+func TestSecurityAnalyzerUncheckedBorrow(t *testing.T) {
+
+	t.Parallel()
+
+	const declarations = `
+      resource R {
+          fun use() {}
+      }
+    `
+
+	test := func(body string) []Diagnostic {
+		return analyze(t,
+			declarations+`
+              fun test(account: AuthAccount, capability: Capability, fallback: &R) {
+            `+body+`
+              }
+            `,
+			"",
+			SecurityAnalyzer,
+		)
+	}
+
+	t.Run("optional chaining", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := test(`
+            account.borrow<&R>(from: /storage/r)?.use()
+        `)
+
+		require.Equal(t,
+			[][3]string{
+				{
+					"security/unchecked-borrow",
+					"warning",
+					"result of `borrow` is not checked",
+				},
+			},
+			securityFindings(diagnostics),
+		)
+	})
+
+	t.Run("capability, optional chaining", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := test(`
+            capability.borrow<&R>()?.use()
+        `)
+
+		require.Len(t, diagnostics, 1)
+		require.Equal(t, "security/unchecked-borrow", diagnostics[0].Category)
+	})
+
+	t.Run("variable, optional chaining", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := test(`
+            let ref = account.borrow<&R>(from: /storage/r)
+            ref?.use()
+            ref?.use()
+        `)
+
+		require.Len(t, diagnostics, 1)
+		require.Equal(t, "security/unchecked-borrow", diagnostics[0].Category)
+	})
+
+	t.Run("variable, checked", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := test(`
+            let ref = account.borrow<&R>(from: /storage/r)
+            if ref == nil {
+                return
+            }
+            ref?.use()
+        `)
+
+		require.Empty(t, diagnostics)
+	})
+
+	t.Run("force", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := test(`
+            account.borrow<&R>(from: /storage/r)!.use()
+        `)
+
+		require.Empty(t, diagnostics)
+	})
+
+	t.Run("nil-coalescing", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := test(`
+            let ref = account.borrow<&R>(from: /storage/r) ?? fallback
+            ref.use()
+        `)
+
+		require.Empty(t, diagnostics)
+	})
+
+	t.Run("optional binding", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := test(`
+            if let ref = account.borrow<&R>(from: /storage/r) {
+                ref.use()
+            }
+        `)
+
+		require.Empty(t, diagnostics)
+	})
+}
@@ -0,0 +1,64 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// ShadowingAnalyzer reports local declarations which shadow
+// a variable, constant, parameter, or function of an enclosing scope
+// of the same function, or of an enclosing function
+//
+var ShadowingAnalyzer = &Analyzer{
+	Name:     "shadowing",
+	Doc:      "reports local declarations which shadow declarations of enclosing scopes",
+	Requires: []*Analyzer{LocalVariablesAnalyzer},
+	Run: func(pass *Pass) (interface{}, error) {
+		variables := pass.ResultOf[LocalVariablesAnalyzer].([]*LocalVariable)
+
+		for _, variable := range variables {
+			shadowed := variable.Shadowed
+			if shadowed == nil {
+				continue
+			}
+
+			pass.Report(
+				Diagnostic{
+					Message: fmt.Sprintf(
+						"declaration of %s `%s` shadows %s",
+						variable.DeclarationKind.Name(),
+						variable.Identifier.Identifier,
+						shadowed.DeclarationKind.Name(),
+					),
+					SecondaryMessage: fmt.Sprintf(
+						"shadowed declaration is at line %d",
+						shadowed.Identifier.Pos.Line,
+					),
+					Range: ast.NewRangeFromPositioned(variable.Identifier),
+				},
+			)
+		}
+
+		return nil, nil
+	},
+}
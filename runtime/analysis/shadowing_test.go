@@ -0,0 +1,129 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestShadowingAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("nested block", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              fun test(): Int {
+                  let x = 1
+                  if true {
+                      let x = 2
+                      return x
+                  }
+                  return x
+              }
+            `,
+			"",
+			ShadowingAnalyzer,
+		)
+
+		require.Equal(t,
+			[]Diagnostic{
+				{
+					Location:         utils.TestLocation,
+					Category:         "shadowing",
+					Severity:         SeverityWarning,
+					Message:          "declaration of constant `x` shadows constant",
+					SecondaryMessage: "shadowed declaration is at line 3",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 115, Line: 5, Column: 26},
+						EndPos:   ast.Position{Offset: 115, Line: 5, Column: 26},
+					},
+				},
+			},
+			diagnostics,
+		)
+	})
+
+	t.Run("nested block, optional binding, and function expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              fun test(y: Int?, z: Int) {
+                  let x = 1
+                  if true {
+                      let x = x
+                  }
+                  if let y = y {}
+                  let f = fun (z: Int) {}
+              }
+            `,
+			"",
+			ShadowingAnalyzer,
+		)
+
+		assert.Equal(t,
+			[]string{
+				"declaration of constant `x` shadows constant",
+				"declaration of constant `y` shadows parameter",
+				"declaration of parameter `z` shadows parameter",
+			},
+			diagnosticMessages(diagnostics),
+		)
+	})
+
+	t.Run("sibling scopes and globals", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              let x = 1
+
+              fun test() {
+                  if true {
+                      let y = 2
+                  } else {
+                      let y = 3
+                  }
+                  let x = 4
+              }
+
+              fun x2() {
+                  let y = 5
+              }
+            `,
+			"",
+			ShadowingAnalyzer,
+		)
+
+		assert.Empty(t, diagnostics)
+	})
+}
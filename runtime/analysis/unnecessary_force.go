@@ -0,0 +1,64 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// UnnecessaryForceAnalyzer reports force expressions (`x!`)
+// whose value is not optional, and so can never be nil
+//
+var UnnecessaryForceAnalyzer = &Analyzer{
+	Name: "unnecessary-force",
+	Doc:  "reports force-unwraps of non-optional values",
+	Run: func(pass *Pass) (interface{}, error) {
+		elaboration := pass.Elaboration
+
+		ast.Inspect(pass.Program, func(element ast.Element) bool {
+			forceExpression, ok := element.(*ast.ForceExpression)
+			if !ok {
+				return true
+			}
+
+			valueType, ok := elaboration.ForceExpressionValueTypes[forceExpression]
+			if !ok || valueType.IsInvalidType() {
+				return true
+			}
+
+			if _, ok := valueType.(*sema.OptionalType); ok {
+				return true
+			}
+
+			pass.Report(
+				Diagnostic{
+					Message:          "unnecessary force operator",
+					SecondaryMessage: "the value is not optional: consider removing the force operator",
+					Range:            ast.NewRangeFromPositioned(forceExpression),
+				},
+			)
+
+			return true
+		})
+
+		return nil, nil
+	},
+}
@@ -0,0 +1,61 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestUnnecessaryForceAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := analyze(t,
+		`
+          fun test(x: Int, y: Int?): Int {
+              return x! + y!
+          }
+        `,
+		"",
+		UnnecessaryForceAnalyzer,
+	)
+
+	require.Equal(t,
+		[]Diagnostic{
+			{
+				Location:         utils.TestLocation,
+				Category:         "unnecessary-force",
+				Severity:         SeverityWarning,
+				Message:          "unnecessary force operator",
+				SecondaryMessage: "the value is not optional: consider removing the force operator",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 65, Line: 3, Column: 21},
+					EndPos:   ast.Position{Offset: 66, Line: 3, Column: 22},
+				},
+			},
+		},
+		diagnostics,
+	)
+}
@@ -0,0 +1,201 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// UnusedImportAnalyzer reports imported declarations which are never referred to,
+// neither as a value nor as a type.
+//
+// Imports of all declarations of a location, e.g. `import "Foo"`, are not reported.
+//
+var UnusedImportAnalyzer = &Analyzer{
+	Name: "unused-import",
+	Doc:  "reports imported declarations which are never used",
+	Run: func(pass *Pass) (interface{}, error) {
+		program := pass.Program
+
+		referencedNames := referencedNames(program)
+
+		for _, declaration := range program.ImportDeclarations() {
+			for _, identifier := range declaration.Identifiers {
+				if _, ok := referencedNames[identifier.Identifier]; ok {
+					continue
+				}
+
+				pass.Reportf(
+					identifier,
+					"unused import `%s`",
+					identifier.Identifier,
+				)
+			}
+		}
+
+		return nil, nil
+	},
+}
+
+// referencedNames returns the names referred to in the given program,
+// in identifier expressions and in nominal types
+//
+func referencedNames(program *ast.Program) map[string]struct{} {
+	names := map[string]struct{}{}
+
+	var addType func(ty ast.Type)
+
+	addTypeAnnotation := func(typeAnnotation *ast.TypeAnnotation) {
+		if typeAnnotation != nil {
+			addType(typeAnnotation.Type)
+		}
+	}
+
+	addNominalType := func(nominalType *ast.NominalType) {
+		if nominalType != nil {
+			names[nominalType.Identifier.Identifier] = struct{}{}
+		}
+	}
+
+	addType = func(ty ast.Type) {
+		switch ty := ty.(type) {
+		case *ast.NominalType:
+			addNominalType(ty)
+
+		case *ast.OptionalType:
+			addType(ty.Type)
+
+		case *ast.VariableSizedType:
+			addType(ty.Type)
+
+		case *ast.ConstantSizedType:
+			addType(ty.Type)
+
+		case *ast.DictionaryType:
+			addType(ty.KeyType)
+			addType(ty.ValueType)
+
+		case *ast.FunctionType:
+			for _, parameterTypeAnnotation := range ty.ParameterTypeAnnotations {
+				addTypeAnnotation(parameterTypeAnnotation)
+			}
+			addTypeAnnotation(ty.ReturnTypeAnnotation)
+
+		case *ast.ReferenceType:
+			addType(ty.Type)
+
+		case *ast.RestrictedType:
+			if ty.Type != nil {
+				addType(ty.Type)
+			}
+			for _, restriction := range ty.Restrictions {
+				addNominalType(restriction)
+			}
+
+		case *ast.InstantiationType:
+			addType(ty.Type)
+			for _, typeArgument := range ty.TypeArguments {
+				addTypeAnnotation(typeArgument)
+			}
+		}
+	}
+
+	addFunction := func(
+		typeParameterList *ast.TypeParameterList,
+		parameterList *ast.ParameterList,
+		returnTypeAnnotation *ast.TypeAnnotation,
+	) {
+		if typeParameterList != nil {
+			for _, typeParameter := range typeParameterList.TypeParameters {
+				addTypeAnnotation(typeParameter.TypeBound)
+			}
+		}
+		if parameterList != nil {
+			for _, parameter := range parameterList.Parameters {
+				addTypeAnnotation(parameter.TypeAnnotation)
+			}
+		}
+		addTypeAnnotation(returnTypeAnnotation)
+	}
+
+	ast.Inspect(program, func(element ast.Element) bool {
+		switch element := element.(type) {
+		case *ast.IdentifierExpression:
+			names[element.Identifier.Identifier] = struct{}{}
+
+		case *ast.VariableDeclaration:
+			addTypeAnnotation(element.TypeAnnotation)
+
+		case *ast.FieldDeclaration:
+			addTypeAnnotation(element.TypeAnnotation)
+
+		case *ast.FunctionDeclaration:
+			addFunction(
+				element.TypeParameterList,
+				element.ParameterList,
+				element.ReturnTypeAnnotation,
+			)
+
+		case *ast.SpecialFunctionDeclaration:
+			addFunction(
+				element.FunctionDeclaration.TypeParameterList,
+				element.FunctionDeclaration.ParameterList,
+				element.FunctionDeclaration.ReturnTypeAnnotation,
+			)
+
+		case *ast.FunctionExpression:
+			addFunction(nil, element.ParameterList, element.ReturnTypeAnnotation)
+
+		case *ast.CompositeDeclaration:
+			if element.TypeParameterList != nil {
+				for _, typeParameter := range element.TypeParameterList.TypeParameters {
+					addTypeAnnotation(typeParameter.TypeBound)
+				}
+			}
+			for _, conformance := range element.Conformances {
+				addNominalType(conformance)
+			}
+
+		case *ast.ExtensionDeclaration:
+			addNominalType(element.ExtendedType)
+
+		case *ast.TypeAliasDeclaration:
+			addTypeAnnotation(element.TypeAnnotation)
+
+		case *ast.TransactionDeclaration:
+			addFunction(nil, element.ParameterList, nil)
+
+		case *ast.CastingExpression:
+			addTypeAnnotation(element.TypeAnnotation)
+
+		case *ast.ReferenceExpression:
+			addType(element.Type)
+
+		case *ast.InvocationExpression:
+			for _, typeArgument := range element.TypeArguments {
+				addTypeAnnotation(typeArgument)
+			}
+		}
+
+		return true
+	})
+
+	return names
+}
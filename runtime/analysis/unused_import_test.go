@@ -0,0 +1,115 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnusedImportAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	const importedCode = `
+      pub struct S {}
+
+      pub struct interface I {}
+
+      pub resource R {}
+
+      pub fun f(): Int {
+          return 1
+      }
+
+      pub let x = 1
+
+      pub let y = 2
+    `
+
+	t.Run("unused", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              import S, I, R, f, x, y from "imported"
+
+              pub fun test(): Int {
+                  return y
+              }
+            `,
+			importedCode,
+			UnusedImportAnalyzer,
+		)
+
+		assert.Equal(t,
+			[]string{
+				"unused import `S`",
+				"unused import `I`",
+				"unused import `R`",
+				"unused import `f`",
+				"unused import `x`",
+			},
+			diagnosticMessages(diagnostics),
+		)
+	})
+
+	t.Run("used as types and values", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              import S, I, R, f, x from "imported"
+
+              pub struct T: I {}
+
+              pub fun test(_ r: @R): [S] {
+                  pre {
+                      f() == x
+                  }
+                  destroy r
+                  return []
+              }
+            `,
+			importedCode,
+			UnusedImportAnalyzer,
+		)
+
+		assert.Empty(t, diagnostics)
+	})
+
+	t.Run("import of all declarations", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              import "imported"
+            `,
+			importedCode,
+			UnusedImportAnalyzer,
+		)
+
+		assert.Empty(t, diagnostics)
+	})
+}
@@ -0,0 +1,59 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// UnusedVariableAnalyzer reports local variables and constants which are never read.
+//
+// Parameters and the variables of for-in loops are not reported,
+// as there is no way to avoid declaring them.
+//
+var UnusedVariableAnalyzer = &Analyzer{
+	Name:     "unused-variable",
+	Doc:      "reports local variables and constants which are never read",
+	Requires: []*Analyzer{LocalVariablesAnalyzer},
+	Run: func(pass *Pass) (interface{}, error) {
+		variables := pass.ResultOf[LocalVariablesAnalyzer].([]*LocalVariable)
+
+		for _, variable := range variables {
+			if variable.IsRead || variable.IsLoopVariable {
+				continue
+			}
+
+			switch variable.DeclarationKind {
+			case common.DeclarationKindConstant,
+				common.DeclarationKindVariable,
+				common.DeclarationKindFunction:
+
+				pass.Reportf(
+					variable.Identifier,
+					"unused %s `%s`",
+					variable.DeclarationKind.Name(),
+					variable.Identifier.Identifier,
+				)
+			}
+		}
+
+		return nil, nil
+	},
+}
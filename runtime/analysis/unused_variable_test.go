@@ -0,0 +1,207 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestUnusedVariableAnalyzer(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("unused", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              fun test() {
+                  let x = 1
+                  var y = 2
+                  fun f() {}
+              }
+            `,
+			"",
+			UnusedVariableAnalyzer,
+		)
+
+		require.Equal(t,
+			[]Diagnostic{
+				{
+					Location: utils.TestLocation,
+					Category: "unused-variable",
+					Severity: SeverityWarning,
+					Message:  "unused constant `x`",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 50, Line: 3, Column: 22},
+						EndPos:   ast.Position{Offset: 50, Line: 3, Column: 22},
+					},
+				},
+				{
+					Location: utils.TestLocation,
+					Category: "unused-variable",
+					Severity: SeverityWarning,
+					Message:  "unused variable `y`",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 78, Line: 4, Column: 22},
+						EndPos:   ast.Position{Offset: 78, Line: 4, Column: 22},
+					},
+				},
+				{
+					Location: utils.TestLocation,
+					Category: "unused-variable",
+					Severity: SeverityWarning,
+					Message:  "unused function `f`",
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 106, Line: 5, Column: 22},
+						EndPos:   ast.Position{Offset: 106, Line: 5, Column: 22},
+					},
+				},
+			},
+			diagnostics,
+		)
+	})
+
+	t.Run("used", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              fun test(): Int {
+                  let x = 1
+                  let f = fun (): Int {
+                      return x
+                  }
+                  var y = 2
+                  if let z = 3 as Int? {
+                      y = z
+                  }
+                  return f() + y
+              }
+            `,
+			"",
+			UnusedVariableAnalyzer,
+		)
+
+		assert.Empty(t, diagnostics)
+	})
+
+	t.Run("only assigned", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              fun test() {
+                  var x = 1
+                  x = 2
+              }
+            `,
+			"",
+			UnusedVariableAnalyzer,
+		)
+
+		assert.Equal(t,
+			[]string{"unused variable `x`"},
+			diagnosticMessages(diagnostics),
+		)
+	})
+
+	t.Run("used in condition", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              fun test(): ((Int): Void) {
+                  let limit = 10
+                  return fun (n: Int) {
+                      pre {
+                          n < limit
+                      }
+                  }
+              }
+            `,
+			"",
+			UnusedVariableAnalyzer,
+		)
+
+		assert.Empty(t, diagnostics)
+	})
+
+	t.Run("shadowed variable used", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              fun test(): Int {
+                  let x = 1
+                  if true {
+                      let x = 2
+                      return x
+                  }
+                  return 0
+              }
+            `,
+			"",
+			UnusedVariableAnalyzer,
+		)
+
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "unused constant `x`", diagnostics[0].Message)
+		assert.Equal(t, 3, diagnostics[0].StartPos.Line)
+	})
+
+	t.Run("parameters, loop variables, and globals", func(t *testing.T) {
+
+		t.Parallel()
+
+		diagnostics := analyze(t,
+			`
+              let global = 1
+
+              fun test(a: Int, _ b: Int) {
+                  for i, x in [1, 2, 3] {}
+              }
+
+              struct S {
+                  let field: Int
+
+                  init(value: Int) {
+                      self.field = 1
+                  }
+              }
+            `,
+			"",
+			UnusedVariableAnalyzer,
+		)
+
+		assert.Empty(t, diagnostics)
+	})
+}
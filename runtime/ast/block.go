@@ -59,9 +59,11 @@ func (b *FunctionBlock) Accept(visitor Visitor) Repr {
 }
 
 func (b *FunctionBlock) Walk(walkChild func(Element)) {
-	// TODO: pre-conditions
-	walkChild(b.Block)
-	// TODO: post-conditions
+	b.PreConditions.walk(walkChild)
+	if b.Block != nil {
+		walkChild(b.Block)
+	}
+	b.PostConditions.walk(walkChild)
 }
 
 func (b *FunctionBlock) MarshalJSON() ([]byte, error) {
@@ -96,3 +98,17 @@ type Condition struct {
 // Conditions
 
 type Conditions []*Condition
+
+// walk walks the test and message expressions of the conditions, if any
+//
+func (c *Conditions) walk(walkChild func(Element)) {
+	if c == nil {
+		return
+	}
+	for _, condition := range *c {
+		walkChild(condition.Test)
+		if condition.Message != nil {
+			walkChild(condition.Message)
+		}
+	}
+}
@@ -29,12 +29,13 @@ import (
 // NOTE: For events, only an empty initializer is declared
 
 type CompositeDeclaration struct {
-	Access        Access
-	CompositeKind common.CompositeKind
-	Identifier    Identifier
-	Conformances  []*NominalType
-	Members       *Members
-	DocString     string
+	Access            Access
+	CompositeKind     common.CompositeKind
+	Identifier        Identifier
+	TypeParameterList *TypeParameterList `json:",omitempty"`
+	Conformances      []*NominalType
+	Members           *Members
+	DocString         string
 	Range
 }
 
@@ -162,6 +162,61 @@ func (e *StringExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// StringTemplateExpression is a string literal with interpolated expressions,
+// e.g. "Balance: \(balance)".
+//
+// Values contains the string parts around the expressions,
+// so it always has exactly one more element than Expressions.
+//
+type StringTemplateExpression struct {
+	Values      []string
+	Expressions []Expression
+	Range
+}
+
+func (*StringTemplateExpression) isExpression() {}
+
+func (*StringTemplateExpression) isIfStatementTest() {}
+
+func (e *StringTemplateExpression) Accept(visitor Visitor) Repr {
+	return e.AcceptExp(visitor)
+}
+
+func (e *StringTemplateExpression) Walk(walkChild func(Element)) {
+	walkExpressions(walkChild, e.Expressions)
+}
+
+func (e *StringTemplateExpression) AcceptExp(visitor ExpressionVisitor) Repr {
+	return visitor.VisitStringTemplateExpression(e)
+}
+
+func (e *StringTemplateExpression) String() string {
+	var builder strings.Builder
+	builder.WriteString("\"")
+	for i, value := range e.Values {
+		quoted := strconv.Quote(value)
+		builder.WriteString(quoted[1 : len(quoted)-1])
+		if i < len(e.Expressions) {
+			builder.WriteString("\\(")
+			builder.WriteString(e.Expressions[i].String())
+			builder.WriteString(")")
+		}
+	}
+	builder.WriteString("\"")
+	return builder.String()
+}
+
+func (e *StringTemplateExpression) MarshalJSON() ([]byte, error) {
+	type Alias StringTemplateExpression
+	return json.Marshal(&struct {
+		Type string
+		*Alias
+	}{
+		Type:  "StringTemplateExpression",
+		Alias: (*Alias)(e),
+	})
+}
+
 // IntegerExpression
 
 type IntegerExpression struct {
@@ -790,9 +845,70 @@ func (e *BinaryExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// RangeExpression
+
+type RangeExpression struct {
+	Start Expression
+	End   Expression
+	// Inclusive is true if the range includes the end, i.e. `start...end`,
+	// and false if the range excludes the end, i.e. `start..<end`
+	Inclusive bool
+}
+
+func (*RangeExpression) isExpression() {}
+
+func (*RangeExpression) isIfStatementTest() {}
+
+func (e *RangeExpression) Accept(visitor Visitor) Repr {
+	return e.AcceptExp(visitor)
+}
+
+func (e *RangeExpression) Walk(walkChild func(Element)) {
+	walkChild(e.Start)
+	walkChild(e.End)
+}
+
+func (e *RangeExpression) AcceptExp(visitor ExpressionVisitor) Repr {
+	return visitor.VisitRangeExpression(e)
+}
+
+func (e *RangeExpression) String() string {
+	operator := "..<"
+	if e.Inclusive {
+		operator = "..."
+	}
+
+	return fmt.Sprintf(
+		"(%s%s%s)",
+		e.Start, operator, e.End,
+	)
+}
+
+func (e *RangeExpression) StartPosition() Position {
+	return e.Start.StartPosition()
+}
+
+func (e *RangeExpression) EndPosition() Position {
+	return e.End.EndPosition()
+}
+
+func (e *RangeExpression) MarshalJSON() ([]byte, error) {
+	type Alias RangeExpression
+	return json.Marshal(&struct {
+		Type string
+		Range
+		*Alias
+	}{
+		Type:  "RangeExpression",
+		Range: NewRangeFromPositioned(e),
+		Alias: (*Alias)(e),
+	})
+}
+
 // FunctionExpression
 
 type FunctionExpression struct {
+	Purity               FunctionPurity `json:",omitempty"`
 	ParameterList        *ParameterList
 	ReturnTypeAnnotation *TypeAnnotation
 	FunctionBlock        *FunctionBlock
@@ -44,6 +44,10 @@ type StringExtractor interface {
 	ExtractString(extractor *ExpressionExtractor, expression *StringExpression) ExpressionExtraction
 }
 
+type StringTemplateExtractor interface {
+	ExtractStringTemplate(extractor *ExpressionExtractor, expression *StringTemplateExpression) ExpressionExtraction
+}
+
 type ArrayExtractor interface {
 	ExtractArray(extractor *ExpressionExtractor, expression *ArrayExpression) ExpressionExtraction
 }
@@ -80,6 +84,10 @@ type BinaryExtractor interface {
 	ExtractBinary(extractor *ExpressionExtractor, expression *BinaryExpression) ExpressionExtraction
 }
 
+type RangeExtractor interface {
+	ExtractRange(extractor *ExpressionExtractor, expression *RangeExpression) ExpressionExtraction
+}
+
 type FunctionExtractor interface {
 	ExtractFunction(extractor *ExpressionExtractor, expression *FunctionExpression) ExpressionExtraction
 }
@@ -109,28 +117,30 @@ type PathExtractor interface {
 }
 
 type ExpressionExtractor struct {
-	nextIdentifier       int
-	BoolExtractor        BoolExtractor
-	NilExtractor         NilExtractor
-	IntExtractor         IntExtractor
-	FixedPointExtractor  FixedPointExtractor
-	StringExtractor      StringExtractor
-	ArrayExtractor       ArrayExtractor
-	DictionaryExtractor  DictionaryExtractor
-	IdentifierExtractor  IdentifierExtractor
-	InvocationExtractor  InvocationExtractor
-	MemberExtractor      MemberExtractor
-	IndexExtractor       IndexExtractor
-	ConditionalExtractor ConditionalExtractor
-	UnaryExtractor       UnaryExtractor
-	BinaryExtractor      BinaryExtractor
-	FunctionExtractor    FunctionExtractor
-	CastingExtractor     CastingExtractor
-	CreateExtractor      CreateExtractor
-	DestroyExtractor     DestroyExtractor
-	ReferenceExtractor   ReferenceExtractor
-	ForceExtractor       ForceExtractor
-	PathExtractor        PathExtractor
+	nextIdentifier          int
+	BoolExtractor           BoolExtractor
+	NilExtractor            NilExtractor
+	IntExtractor            IntExtractor
+	FixedPointExtractor     FixedPointExtractor
+	StringExtractor         StringExtractor
+	StringTemplateExtractor StringTemplateExtractor
+	ArrayExtractor          ArrayExtractor
+	DictionaryExtractor     DictionaryExtractor
+	IdentifierExtractor     IdentifierExtractor
+	InvocationExtractor     InvocationExtractor
+	MemberExtractor         MemberExtractor
+	IndexExtractor          IndexExtractor
+	ConditionalExtractor    ConditionalExtractor
+	UnaryExtractor          UnaryExtractor
+	BinaryExtractor         BinaryExtractor
+	RangeExtractor          RangeExtractor
+	FunctionExtractor       FunctionExtractor
+	CastingExtractor        CastingExtractor
+	CreateExtractor         CreateExtractor
+	DestroyExtractor        DestroyExtractor
+	ReferenceExtractor      ReferenceExtractor
+	ForceExtractor          ForceExtractor
+	PathExtractor           PathExtractor
 }
 
 func (extractor *ExpressionExtractor) Extract(expression Expression) ExpressionExtraction {
@@ -268,6 +278,35 @@ func (extractor *ExpressionExtractor) ExtractString(expression *StringExpression
 	}
 }
 
+func (extractor *ExpressionExtractor) VisitStringTemplateExpression(expression *StringTemplateExpression) Repr {
+
+	// delegate to child extractor, if any,
+	// or call default implementation
+
+	if extractor.StringTemplateExtractor != nil {
+		return extractor.StringTemplateExtractor.ExtractStringTemplate(extractor, expression)
+	}
+	return extractor.ExtractStringTemplate(expression)
+}
+
+func (extractor *ExpressionExtractor) ExtractStringTemplate(expression *StringTemplateExpression) ExpressionExtraction {
+
+	// copy the expression
+	newExpression := *expression
+
+	// rewrite all interpolated expressions
+
+	rewrittenExpressions, extractedExpressions :=
+		extractor.VisitExpressions(expression.Expressions)
+
+	newExpression.Expressions = rewrittenExpressions
+
+	return ExpressionExtraction{
+		RewrittenExpression:  &newExpression,
+		ExtractedExpressions: extractedExpressions,
+	}
+}
+
 func (extractor *ExpressionExtractor) VisitArrayExpression(expression *ArrayExpression) Repr {
 
 	// delegate to child extractor, if any,
@@ -606,6 +645,39 @@ func (extractor *ExpressionExtractor) ExtractBinary(expression *BinaryExpression
 	}
 }
 
+func (extractor *ExpressionExtractor) VisitRangeExpression(expression *RangeExpression) Repr {
+
+	// delegate to child extractor, if any,
+	// or call default implementation
+
+	if extractor.RangeExtractor != nil {
+		return extractor.RangeExtractor.ExtractRange(extractor, expression)
+	}
+	return extractor.ExtractRange(expression)
+}
+
+func (extractor *ExpressionExtractor) ExtractRange(expression *RangeExpression) ExpressionExtraction {
+
+	// copy the expression
+	newExpression := *expression
+
+	// rewrite start and end sub-expression
+
+	rewrittenExpressions, extractedExpressions :=
+		extractor.VisitExpressions([]Expression{
+			newExpression.Start,
+			newExpression.End,
+		})
+
+	newExpression.Start = rewrittenExpressions[0]
+	newExpression.End = rewrittenExpressions[1]
+
+	return ExpressionExtraction{
+		RewrittenExpression:  &newExpression,
+		ExtractedExpressions: extractedExpressions,
+	}
+}
+
 func (extractor *ExpressionExtractor) VisitFunctionExpression(expression *FunctionExpression) Repr {
 
 	// delegate to child extractor, if any,
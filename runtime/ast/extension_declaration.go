@@ -0,0 +1,77 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"encoding/json"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// ExtensionDeclaration
+
+type ExtensionDeclaration struct {
+	Access       Access
+	Identifier   Identifier
+	ExtendedType *NominalType
+	Members      *Members
+	DocString    string
+	Range
+}
+
+func (d *ExtensionDeclaration) Accept(visitor Visitor) Repr {
+	return visitor.VisitExtensionDeclaration(d)
+}
+
+func (d *ExtensionDeclaration) Walk(walkChild func(Element)) {
+	walkDeclarations(walkChild, d.Members.declarations)
+}
+
+func (*ExtensionDeclaration) isDeclaration() {}
+
+func (d *ExtensionDeclaration) DeclarationIdentifier() *Identifier {
+	return &d.Identifier
+}
+
+func (d *ExtensionDeclaration) DeclarationKind() common.DeclarationKind {
+	return common.DeclarationKindExtension
+}
+
+func (d *ExtensionDeclaration) DeclarationAccess() Access {
+	return d.Access
+}
+
+func (d *ExtensionDeclaration) DeclarationMembers() *Members {
+	return d.Members
+}
+
+func (d *ExtensionDeclaration) DeclarationDocString() string {
+	return d.DocString
+}
+
+func (d *ExtensionDeclaration) MarshalJSON() ([]byte, error) {
+	type Alias ExtensionDeclaration
+	return json.Marshal(&struct {
+		Type string
+		*Alias
+	}{
+		Type:  "ExtensionDeclaration",
+		Alias: (*Alias)(d),
+	})
+}
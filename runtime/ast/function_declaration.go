@@ -26,7 +26,9 @@ import (
 
 type FunctionDeclaration struct {
 	Access               Access
+	Purity               FunctionPurity `json:",omitempty"`
 	Identifier           Identifier
+	TypeParameterList    *TypeParameterList `json:",omitempty"`
 	ParameterList        *ParameterList
 	ReturnTypeAnnotation *TypeAnnotation
 	FunctionBlock        *FunctionBlock
@@ -56,6 +56,10 @@ type memberIndices struct {
 	_composites []*CompositeDeclaration
 	// Use `EnumCases()` instead
 	_enumCases []*EnumCaseDeclaration
+	// Use `TypeAliases()` instead
+	_typeAliases []*TypeAliasDeclaration
+	// Use `Extensions()` instead
+	_extensions []*ExtensionDeclaration
 }
 
 func (i *memberIndices) FieldsByIdentifier(declarations []Declaration) map[string]*FieldDeclaration {
@@ -118,6 +122,16 @@ func (i *memberIndices) EnumCases(declarations []Declaration) []*EnumCaseDeclara
 	return i._enumCases
 }
 
+func (i *memberIndices) TypeAliases(declarations []Declaration) []*TypeAliasDeclaration {
+	i.once.Do(i.initializer(declarations))
+	return i._typeAliases
+}
+
+func (i *memberIndices) Extensions(declarations []Declaration) []*ExtensionDeclaration {
+	i.once.Do(i.initializer(declarations))
+	return i._extensions
+}
+
 func (i *memberIndices) initializer(declarations []Declaration) func() {
 	return func() {
 		i.init(declarations)
@@ -145,6 +159,10 @@ func (i *memberIndices) init(declarations []Declaration) {
 
 	i._enumCases = make([]*EnumCaseDeclaration, 0)
 
+	i._typeAliases = make([]*TypeAliasDeclaration, 0)
+
+	i._extensions = make([]*ExtensionDeclaration, 0)
+
 	for _, declaration := range declarations {
 		switch declaration := declaration.(type) {
 		case *FieldDeclaration:
@@ -175,6 +193,12 @@ func (i *memberIndices) init(declarations []Declaration) {
 
 		case *EnumCaseDeclaration:
 			i._enumCases = append(i._enumCases, declaration)
+
+		case *TypeAliasDeclaration:
+			i._typeAliases = append(i._typeAliases, declaration)
+
+		case *ExtensionDeclaration:
+			i._extensions = append(i._extensions, declaration)
 		}
 	}
 }
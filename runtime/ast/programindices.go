@@ -40,6 +40,10 @@ type programIndices struct {
 	_transactionDeclarations []*TransactionDeclaration
 	// Use `variableDeclarations()` instead
 	_variableDeclarations []*VariableDeclaration
+	// Use `typeAliasDeclarations()` instead
+	_typeAliasDeclarations []*TypeAliasDeclaration
+	// Use `extensionDeclarations()` instead
+	_extensionDeclarations []*ExtensionDeclaration
 }
 
 func (i *programIndices) pragmaDeclarations(declarations []Declaration) []*PragmaDeclaration {
@@ -77,6 +81,16 @@ func (i *programIndices) variableDeclarations(declarations []Declaration) []*Var
 	return i._variableDeclarations
 }
 
+func (i *programIndices) typeAliasDeclarations(declarations []Declaration) []*TypeAliasDeclaration {
+	i.once.Do(i.initializer(declarations))
+	return i._typeAliasDeclarations
+}
+
+func (i *programIndices) extensionDeclarations(declarations []Declaration) []*ExtensionDeclaration {
+	i.once.Do(i.initializer(declarations))
+	return i._extensionDeclarations
+}
+
 func (i *programIndices) initializer(declarations []Declaration) func() {
 	return func() {
 		i.init(declarations)
@@ -93,6 +107,8 @@ func (i *programIndices) init(declarations []Declaration) {
 	i._interfaceDeclarations = make([]*InterfaceDeclaration, 0)
 	i._functionDeclarations = make([]*FunctionDeclaration, 0)
 	i._transactionDeclarations = make([]*TransactionDeclaration, 0)
+	i._typeAliasDeclarations = make([]*TypeAliasDeclaration, 0)
+	i._extensionDeclarations = make([]*ExtensionDeclaration, 0)
 
 	for _, declaration := range declarations {
 
@@ -117,6 +133,12 @@ func (i *programIndices) init(declarations []Declaration) {
 
 		case *VariableDeclaration:
 			i._variableDeclarations = append(i._variableDeclarations, declaration)
+
+		case *TypeAliasDeclaration:
+			i._typeAliasDeclarations = append(i._typeAliasDeclarations, declaration)
+
+		case *ExtensionDeclaration:
+			i._extensionDeclarations = append(i._extensionDeclarations, declaration)
 		}
 	}
 }
@@ -208,6 +208,10 @@ func (s *WhileStatement) MarshalJSON() ([]byte, error) {
 // ForStatement
 
 type ForStatement struct {
+	// Index is the optional first identifier of the loop, if the loop declares two identifiers.
+	// It is the index of the element when iterating over an array or range,
+	// and the key of the entry when iterating over a dictionary
+	Index      *Identifier `json:",omitempty"`
 	Identifier Identifier
 	Value      Expression
 	Block      *Block
@@ -417,7 +421,10 @@ func (s *SwitchStatement) Accept(visitor Visitor) Repr {
 func (s *SwitchStatement) Walk(walkChild func(Element)) {
 	walkChild(s.Expression)
 	for _, switchCase := range s.Cases {
-		walkChild(switchCase.Expression)
+		// The expression of the default case is nil
+		if switchCase.Expression != nil {
+			walkChild(switchCase.Expression)
+		}
 		walkStatements(walkChild, switchCase.Statements)
 	}
 }
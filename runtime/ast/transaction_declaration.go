@@ -47,10 +47,11 @@ func (d *TransactionDeclaration) Walk(walkChild func(Element)) {
 	if d.Prepare != nil {
 		walkChild(d.Prepare)
 	}
+	d.PreConditions.walk(walkChild)
 	if d.Execute != nil {
 		walkChild(d.Execute)
 	}
-	// TODO: walk pre and post-conditions
+	d.PostConditions.walk(walkChild)
 }
 
 func (*TransactionDeclaration) isDeclaration() {}
@@ -0,0 +1,40 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+// TypeParameter is a type parameter of a generic function or composite declaration,
+// e.g. `T` in `fun first<T>(_ xs: [T]): T?`.
+//
+// The type bound is optional, e.g. `T: AnyResource`
+//
+type TypeParameter struct {
+	Identifier Identifier
+	TypeBound  *TypeAnnotation `json:",omitempty"`
+}
+
+type TypeParameterList struct {
+	TypeParameters []*TypeParameter
+	Range
+}
+
+// IsEmpty returns true if the list is nil or contains no type parameters
+//
+func (l *TypeParameterList) IsEmpty() bool {
+	return l == nil || len(l.TypeParameters) == 0
+}
@@ -74,8 +74,10 @@ type ExpressionVisitor interface {
 	VisitConditionalExpression(*ConditionalExpression) Repr
 	VisitUnaryExpression(*UnaryExpression) Repr
 	VisitBinaryExpression(*BinaryExpression) Repr
+	VisitRangeExpression(*RangeExpression) Repr
 	VisitFunctionExpression(*FunctionExpression) Repr
 	VisitStringExpression(*StringExpression) Repr
+	VisitStringTemplateExpression(*StringTemplateExpression) Repr
 	VisitCastingExpression(*CastingExpression) Repr
 	VisitCreateExpression(*CreateExpression) Repr
 	VisitDestroyExpression(*DestroyExpression) Repr
@@ -95,6 +97,8 @@ type Visitor interface {
 	VisitInterfaceDeclaration(*InterfaceDeclaration) Repr
 	VisitFieldDeclaration(*FieldDeclaration) Repr
 	VisitEnumCaseDeclaration(*EnumCaseDeclaration) Repr
+	VisitTypeAliasDeclaration(*TypeAliasDeclaration) Repr
+	VisitExtensionDeclaration(*ExtensionDeclaration) Repr
 	VisitPragmaDeclaration(*PragmaDeclaration) Repr
 	VisitImportDeclaration(*ImportDeclaration) Repr
 	VisitTransactionDeclaration(*TransactionDeclaration) Repr
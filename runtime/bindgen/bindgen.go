@@ -0,0 +1,644 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+// Package bindgen generates Go bindings for Cadence programs.
+//
+// The bindings consist of Go types for the structs, events, and enums declared by the programs,
+// which can be converted to and from Cadence values, and of functions which build the arguments
+// of scripts and transactions and decode the results of scripts.
+//
+// The values of composite types are decoded by field name, not by field index,
+// so decoding keeps working when fields are added to a composite type.
+//
+package bindgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/abi"
+)
+
+// Source is a Cadence program for which bindings are generated
+//
+type Source struct {
+	// Name is the file name of the program, e.g. `get_balance.cdc`.
+	// The names of the bindings for scripts and transactions are derived from it
+	Name string
+	// Code is the code of the program
+	Code string
+	// ABI is the description of the public interface of the program
+	ABI *abi.ABI
+}
+
+// Generate generates the Go bindings for the given programs,
+// and returns the code of a Go file for the package with the given name.
+//
+// The bindings for all programs of a package must be generated together,
+// as the generated file also declares helper functions, and the types used by multiple programs.
+//
+func Generate(packageName string, sources []*Source) (code []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr, ok := r.(error)
+			if !ok {
+				panicErr = fmt.Errorf("%v", r)
+			}
+			err = fmt.Errorf("failed to generate bindings: %w", panicErr)
+		}
+	}()
+
+	g := &generator{
+		bindings:     map[string]*compositeBinding{},
+		nominalTypes: map[string]*nominalType{},
+		converters:   map[string]*converter{},
+	}
+
+	for _, source := range sources {
+		g.registerDeclarations(source.ABI.Declarations)
+	}
+
+	var programs []*programBinding
+	for _, source := range sources {
+		program := g.programBinding(source)
+		if program != nil {
+			programs = append(programs, program)
+		}
+	}
+
+	// Generating the bindings of composites and the declarations of types
+	// might require further bindings and types, which are appended to the lists
+
+	for i := 0; i < len(g.bindingList); i++ {
+		g.generateCompositeBinding(g.bindingList[i])
+	}
+
+	for i := 0; i < len(g.nominalTypeList); i++ {
+		g.generateNominalType(g.nominalTypeList[i])
+	}
+
+	var buffer bytes.Buffer
+	err = fileTemplate.Execute(&buffer, &file{
+		PackageName:  packageName,
+		UsesBig:      g.usesBig,
+		UsesCommon:   g.usesCommon,
+		NominalTypes: g.nominalTypeList,
+		Bindings:     g.bindingList,
+		Programs:     programs,
+		Converters:   g.sortedConverters(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buffer.Bytes())
+}
+
+type generator struct {
+	// bindings are the bindings of composite types, by qualified identifier
+	bindings    map[string]*compositeBinding
+	bindingList []*compositeBinding
+	// nominalTypes are the composite and interface types which are declared as Go variables,
+	// by qualified identifier
+	nominalTypes    map[string]*nominalType
+	nominalTypeList []*nominalType
+	// converters are the converters between Go values and Cadence values, by name
+	converters map[string]*converter
+	usesBig    bool
+	usesCommon bool
+}
+
+func (g *generator) sortedConverters() []*converter {
+	names := make([]string, 0, len(g.converters))
+	for name := range g.converters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]*converter, len(names))
+	for i, name := range names {
+		result[i] = g.converters[name]
+	}
+	return result
+}
+
+// compositeBinding is the binding of a Cadence struct, event, or enum type
+//
+type compositeBinding struct {
+	QualifiedIdentifier string
+	GoName              string
+	// Kind is the kind of the composite value, i.e. `Struct`, `Event`, or `Enum`
+	Kind         string
+	Type         cadence.CompositeType
+	DocString    string
+	fieldDocs    map[string]string
+	Fields       []*fieldBinding
+	IsEnum       bool
+	RawGoType    string
+	Cases        []*enumCaseBinding
+	EncodeBody   string
+	DecodeBody   string
+	VariableName string
+}
+
+type fieldBinding struct {
+	Identifier string
+	GoName     string
+	DocString  string
+	Converter  *converter
+}
+
+type enumCaseBinding struct {
+	GoName    string
+	RawValue  uint64
+	DocString string
+}
+
+// nominalType is a composite or interface type which is declared as a Go variable
+//
+type nominalType struct {
+	QualifiedIdentifier string
+	VariableName        string
+	Type                cadence.Type
+	Declaration         string
+	Fields              string
+}
+
+// programBinding is the binding of a script or transaction
+//
+type programBinding struct {
+	GoName string
+	// Kind is the kind of the program, i.e. `script` or `transaction`
+	Kind         string
+	FileName     string
+	ConstantName string
+	Code         string
+	DocString    string
+	Parameters   []*parameterBinding
+	Result       *converter
+}
+
+type parameterBinding struct {
+	Identifier string
+	GoName     string
+	Converter  *converter
+}
+
+// registerDeclarations registers the bindings for the given declarations,
+// and their nested declarations, so declared types have their documentation and enum cases
+//
+func (g *generator) registerDeclarations(declarations []*abi.Declaration) {
+	for _, declaration := range declarations {
+		compositeType, ok := declaration.Type.(cadence.CompositeType)
+		if ok {
+			binding := g.compositeBinding(compositeType)
+			if binding != nil && binding.fieldDocs == nil {
+				binding.DocString = declaration.DocString
+
+				binding.fieldDocs = map[string]string{}
+				for _, field := range declaration.Fields {
+					binding.fieldDocs[field.Identifier] = field.DocString
+				}
+
+				for _, enumCase := range declaration.Cases {
+					binding.Cases = append(
+						binding.Cases,
+						&enumCaseBinding{
+							GoName:    binding.GoName + goIdentifier(enumCase.Identifier),
+							RawValue:  enumCase.RawValue,
+							DocString: enumCase.DocString,
+						},
+					)
+				}
+			}
+		}
+
+		g.registerDeclarations(declaration.Declarations)
+	}
+}
+
+// compositeBinding returns the binding for the given type,
+// if it is a struct, event, or enum type, and registers it if needed.
+// The first registered type of a qualified identifier is used
+//
+func (g *generator) compositeBinding(ty cadence.Type) *compositeBinding {
+	var kind string
+	switch ty.(type) {
+	case *cadence.StructType:
+		kind = "Struct"
+	case *cadence.EventType:
+		kind = "Event"
+	case *cadence.EnumType:
+		kind = "Enum"
+	default:
+		return nil
+	}
+
+	compositeType := ty.(cadence.CompositeType)
+	qualifiedIdentifier := compositeType.CompositeTypeQualifiedIdentifier()
+
+	if binding, ok := g.bindings[qualifiedIdentifier]; ok {
+		return binding
+	}
+
+	goName := goTypeName(qualifiedIdentifier)
+
+	binding := &compositeBinding{
+		QualifiedIdentifier: qualifiedIdentifier,
+		GoName:              goName,
+		Kind:                kind,
+		Type:                compositeType,
+		IsEnum:              kind == "Enum",
+	}
+
+	g.bindings[qualifiedIdentifier] = binding
+	g.bindingList = append(g.bindingList, binding)
+
+	return binding
+}
+
+func (g *generator) generateCompositeBinding(binding *compositeBinding) {
+	binding.VariableName = g.nominalType(binding.Type).VariableName
+
+	var fieldValues []string
+
+	for _, field := range binding.Type.CompositeFields() {
+		converter := g.converter(field.Type)
+
+		if binding.IsEnum {
+			if field.Identifier != "rawValue" {
+				panic(fmt.Errorf("unsupported field of enum %s: %s", binding.QualifiedIdentifier, field.Identifier))
+			}
+			binding.RawGoType = converter.GoType
+			fieldValues = append(fieldValues, fmt.Sprintf("%s(v)", converter.GoType))
+			continue
+		}
+
+		goName := goIdentifier(field.Identifier)
+		if goName == "ToCadence" {
+			goName += "_"
+		}
+
+		binding.Fields = append(
+			binding.Fields,
+			&fieldBinding{
+				Identifier: field.Identifier,
+				GoName:     goName,
+				DocString:  binding.fieldDocs[field.Identifier],
+				Converter:  converter,
+			},
+		)
+		fieldValues = append(fieldValues, "v."+goName)
+	}
+
+	binding.EncodeBody = g.compositeEncodeBody(binding, fieldValues)
+	binding.DecodeBody = g.compositeDecodeBody(binding)
+}
+
+func (g *generator) compositeEncodeBody(binding *compositeBinding, fieldValues []string) string {
+	var builder strings.Builder
+
+	if len(fieldValues) == 0 {
+		fmt.Fprintf(
+			&builder,
+			"return cadence.New%s([]cadence.Value{}).WithType(%s), nil",
+			binding.Kind,
+			binding.VariableName,
+		)
+		return builder.String()
+	}
+
+	fmt.Fprintf(&builder, "fields := make([]cadence.Value, %d)\n", len(fieldValues))
+	builder.WriteString("var err error\n")
+
+	for i, field := range binding.Type.CompositeFields() {
+		fmt.Fprintf(
+			&builder,
+			`fields[%d], err = encode%s(%s)
+if err != nil {
+	return nil, fmt.Errorf("field %s: %%w", err)
+}
+`,
+			i,
+			g.converter(field.Type).Name,
+			fieldValues[i],
+			quoteIdentifier(field.Identifier),
+		)
+	}
+
+	fmt.Fprintf(
+		&builder,
+		"return cadence.New%s(fields).WithType(%s), nil",
+		binding.Kind,
+		binding.VariableName,
+	)
+
+	return builder.String()
+}
+
+func (g *generator) compositeDecodeBody(binding *compositeBinding) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(
+		&builder,
+		`compositeValue, ok := value.(cadence.%[1]s)
+if !ok {
+	return result, unexpectedValueError(%[2]q, value)
+}
+var valueType cadence.CompositeType
+if compositeValue.%[1]sType != nil {
+	valueType = compositeValue.%[1]sType
+}
+fields, err := compositeFields(%[3]s, valueType, compositeValue.Fields)
+if err != nil {
+	return result, err
+}
+`,
+		binding.Kind,
+		binding.QualifiedIdentifier,
+		binding.VariableName,
+	)
+
+	if binding.IsEnum {
+		fmt.Fprintf(
+			&builder,
+			`rawValue, err := decode%s(fields["rawValue"])
+if err != nil {
+	return result, fmt.Errorf("field %s: %%w", err)
+}
+return %s(rawValue), nil`,
+			g.converter(binding.Type.(*cadence.EnumType).RawType).Name,
+			quoteIdentifier("rawValue"),
+			binding.GoName,
+		)
+		return builder.String()
+	}
+
+	fmt.Fprintf(&builder, "result = &%s{}\n", binding.GoName)
+
+	for _, field := range binding.Fields {
+		fmt.Fprintf(
+			&builder,
+			`result.%s, err = decode%s(fields[%q])
+if err != nil {
+	return nil, fmt.Errorf("field %s: %%w", err)
+}
+`,
+			field.GoName,
+			field.Converter.Name,
+			field.Identifier,
+			quoteIdentifier(field.Identifier),
+		)
+	}
+
+	builder.WriteString("return result, nil")
+
+	return builder.String()
+}
+
+// nominalType returns the Go variable declaration for the given composite or interface type,
+// and registers it if needed. The type of a binding is preferred
+//
+func (g *generator) nominalType(ty cadence.Type) *nominalType {
+	var qualifiedIdentifier string
+	switch ty := ty.(type) {
+	case cadence.CompositeType:
+		qualifiedIdentifier = ty.CompositeTypeQualifiedIdentifier()
+	case cadence.InterfaceType:
+		qualifiedIdentifier = ty.InterfaceTypeQualifiedIdentifier()
+	default:
+		panic(fmt.Errorf("unsupported nominal type: %s", ty.ID()))
+	}
+
+	if result, ok := g.nominalTypes[qualifiedIdentifier]; ok {
+		return result
+	}
+
+	if binding, ok := g.bindings[qualifiedIdentifier]; ok {
+		ty = binding.Type
+	}
+
+	result := &nominalType{
+		QualifiedIdentifier: qualifiedIdentifier,
+		VariableName:        goTypeName(qualifiedIdentifier) + "CadenceType",
+		Type:                ty,
+	}
+
+	g.nominalTypes[qualifiedIdentifier] = result
+	g.nominalTypeList = append(g.nominalTypeList, result)
+
+	return result
+}
+
+// generateNominalType generates the variable declaration of the given type.
+//
+// The fields are assigned separately, as they may refer to the type itself
+//
+func (g *generator) generateNominalType(nominalType *nominalType) {
+	var fields []cadence.Field
+	var rawType string
+
+	switch ty := nominalType.Type.(type) {
+	case cadence.CompositeType:
+		fields = ty.CompositeFields()
+		nominalType.Declaration = g.locationLiteral(ty.CompositeTypeLocation())
+		if enumType, ok := ty.(*cadence.EnumType); ok {
+			rawType = fmt.Sprintf("RawType: %s,\n", g.typeLiteral(enumType.RawType))
+		}
+	case cadence.InterfaceType:
+		fields = ty.InterfaceFields()
+		nominalType.Declaration = g.locationLiteral(ty.InterfaceTypeLocation())
+	}
+
+	nominalType.Declaration = fmt.Sprintf(
+		"&%s{\nLocation: %s,\nQualifiedIdentifier: %q,\n%s}",
+		strings.TrimPrefix(fmt.Sprintf("%T", nominalType.Type), "*"),
+		nominalType.Declaration,
+		nominalType.QualifiedIdentifier,
+		rawType,
+	)
+
+	var builder strings.Builder
+	builder.WriteString("[]cadence.Field{\n")
+	for _, field := range fields {
+		fmt.Fprintf(
+			&builder,
+			"{Identifier: %q, Type: %s},\n",
+			field.Identifier,
+			g.typeLiteral(field.Type),
+		)
+	}
+	builder.WriteString("}")
+
+	nominalType.Fields = builder.String()
+}
+
+// programBinding returns the binding for the script or transaction declared by the given program, if any
+//
+func (g *generator) programBinding(source *Source) *programBinding {
+	var kind string
+	var docString string
+	var parameters []*abi.Parameter
+	var result *converter
+
+	if source.ABI.Transaction != nil {
+		kind = "transaction"
+		docString = source.ABI.Transaction.DocString
+		parameters = source.ABI.Transaction.Parameters
+	} else {
+		for _, function := range source.ABI.Functions {
+			if function.Identifier != "main" {
+				continue
+			}
+
+			kind = "script"
+			docString = function.DocString
+			parameters = function.Parameters
+
+			if _, ok := function.ReturnType.(cadence.VoidType); !ok {
+				result = g.converter(function.ReturnType)
+			}
+			break
+		}
+	}
+
+	if kind == "" {
+		return nil
+	}
+
+	fileName := filepath.Base(source.Name)
+	goName := programName(fileName)
+
+	binding := &programBinding{
+		GoName:       goName,
+		Kind:         kind,
+		FileName:     fileName,
+		ConstantName: goName + goIdentifier(kind),
+		Code:         stringLiteral(source.Code),
+		DocString:    docString,
+		Result:       result,
+	}
+
+	for _, parameter := range parameters {
+		binding.Parameters = append(
+			binding.Parameters,
+			&parameterBinding{
+				Identifier: parameter.Identifier,
+				GoName:     parameterName(parameter.Identifier),
+				Converter:  g.converter(parameter.Type),
+			},
+		)
+	}
+
+	return binding
+}
+
+// goIdentifier returns the exported Go identifier for the given Cadence identifier
+//
+func goIdentifier(identifier string) string {
+	if identifier == "" {
+		return identifier
+	}
+
+	runes := []rune(identifier)
+	if !unicode.IsLetter(runes[0]) {
+		return "X" + identifier
+	}
+
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// goTypeName returns the name of the Go type for the given qualified identifier,
+// e.g. `TokenVault` for `Token.Vault`
+//
+func goTypeName(qualifiedIdentifier string) string {
+	var builder strings.Builder
+	for _, part := range strings.Split(qualifiedIdentifier, ".") {
+		builder.WriteString(goIdentifier(part))
+	}
+	return builder.String()
+}
+
+// programName returns the name of the bindings of a script or transaction with the given file name,
+// e.g. `GetBalance` for `get_balance.cdc`
+//
+func programName(fileName string) string {
+	name := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var builder strings.Builder
+	for _, part := range parts {
+		builder.WriteString(goIdentifier(part))
+	}
+	return goIdentifier(builder.String())
+}
+
+// reservedParameterNames are the names used in the generated functions,
+// which must not be shadowed by parameters
+//
+var reservedParameterNames = map[string]struct{}{
+	"arguments": {},
+	"err":       {},
+	"cadence":   {},
+	"common":    {},
+	"big":       {},
+	"fmt":       {},
+}
+
+// parameterName returns the Go parameter name for the given Cadence parameter name
+//
+func parameterName(identifier string) string {
+	_, reserved := reservedParameterNames[identifier]
+	if reserved ||
+		token.Lookup(identifier).IsKeyword() ||
+		types.Universe.Lookup(identifier) != nil {
+
+		return identifier + "_"
+	}
+	return identifier
+}
+
+// quoteIdentifier returns the given identifier in backticks, as used in error messages
+//
+func quoteIdentifier(identifier string) string {
+	return "`" + identifier + "`"
+}
+
+// stringLiteral returns the Go string literal for the given string,
+// a raw string literal if possible
+//
+func stringLiteral(s string) string {
+	if strings.ContainsAny(s, "`\r") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
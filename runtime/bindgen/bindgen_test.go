@@ -0,0 +1,144 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package bindgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/abi"
+	"github.com/onflow/cadence/runtime/tests/checker"
+)
+
+func generate(t *testing.T, name string, code string) string {
+	c, err := checker.ParseAndCheck(t, code)
+	require.NoError(t, err)
+
+	result, err := Generate(
+		"test",
+		[]*Source{
+			{
+				Name: name,
+				Code: code,
+				ABI:  abi.FromChecker(c),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	return string(result)
+}
+
+func TestGenerateNames(t *testing.T) {
+
+	t.Parallel()
+
+	assert.Equal(t, "TokenVault", goTypeName("Token.Vault"))
+	assert.Equal(t, "X_internal", goIdentifier("_internal"))
+
+	assert.Equal(t, "GetBalance", programName("get_balance.cdc"))
+	assert.Equal(t, "SetupAccount", programName("setup-account.cdc"))
+	assert.Equal(t, "X1Mint", programName("1_mint.cdc"))
+
+	assert.Equal(t, "amount", parameterName("amount"))
+	assert.Equal(t, "type_", parameterName("type"))
+	assert.Equal(t, "string_", parameterName("string"))
+	assert.Equal(t, "err_", parameterName("err"))
+}
+
+func TestGenerateScript(t *testing.T) {
+
+	t.Parallel()
+
+	code := generate(t, "scripts/get_values.cdc", `
+      pub fun main(
+          type: String,
+          err: Int?,
+          keys: {Int: String},
+          fix: Fix64,
+          paths: [StoragePath]
+      ): [{String: UInt64?}] {
+          return []
+      }
+    `)
+
+	assert.Contains(t, code, "const GetValuesScript = `\n      pub fun main(")
+
+	assert.Contains(t, code, `func GetValuesArguments(
+	type_ string,
+	err_ *big.Int,
+	keys cadence.Value,
+	fix cadence.Fix64,
+	paths []cadence.Path,
+) ([]cadence.Value, error) {`)
+
+	assert.Contains(t, code, "func DecodeGetValuesResult(value cadence.Value) ([]map[string]*uint64, error) {")
+}
+
+func TestGenerateTransaction(t *testing.T) {
+
+	t.Parallel()
+
+	code := generate(t, "setup.cdc", "transaction {\n  execute {\n    let name = \"`setup`\"\n  }\n}\n")
+
+	assert.Contains(t, code, "const SetupTransaction = \"transaction {\\n  execute {\\n    let name = \\\"`setup`\\\"\\n  }\\n}\\n\"")
+
+	assert.Contains(t, code, `func SetupArguments() ([]cadence.Value, error) {
+	return []cadence.Value{}, nil
+}`)
+
+	assert.NotContains(t, code, "DecodeSetupResult")
+}
+
+func TestGenerateContract(t *testing.T) {
+
+	t.Parallel()
+
+	code := generate(t, "test.cdc", `
+      pub contract C {
+
+          pub resource R {}
+
+          pub struct S {
+              pub let toCadence: Int
+              pub let resources: Capability<&[R]>?
+              access(self) let _secret: Bool
+
+              init() {
+                  self.toCadence = 1
+                  self.resources = nil
+                  self._secret = true
+              }
+          }
+      }
+    `)
+
+	assert.Contains(t, code, `type CS struct {
+	ToCadence_ *big.Int
+	Resources  *cadence.Capability
+	X_secret   bool
+}`)
+
+	assert.Contains(t, code, `var CRCadenceType = &cadence.ResourceType{`)
+
+	assert.NotContains(t, code, "type CR struct")
+}
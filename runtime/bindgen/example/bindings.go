@@ -0,0 +1,694 @@
+// Code generated by bindgen. DO NOT EDIT.
+
+package example
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// TokenColorCadenceType is the Cadence type `Token.Color`
+var TokenColorCadenceType = &cadence.EnumType{
+	Location:            common.AddressLocation{Address: common.Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}, Name: ""},
+	QualifiedIdentifier: "Token.Color",
+	RawType:             cadence.UInt8Type{},
+}
+
+// TokenInfoCadenceType is the Cadence type `Token.Info`
+var TokenInfoCadenceType = &cadence.StructType{
+	Location:            common.AddressLocation{Address: common.Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}, Name: ""},
+	QualifiedIdentifier: "Token.Info",
+}
+
+// TokenMintedCadenceType is the Cadence type `Token.Minted`
+var TokenMintedCadenceType = &cadence.EventType{
+	Location:            common.AddressLocation{Address: common.Address{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}, Name: ""},
+	QualifiedIdentifier: "Token.Minted",
+}
+
+func init() {
+	TokenColorCadenceType.Fields = []cadence.Field{
+		{Identifier: "rawValue", Type: cadence.UInt8Type{}},
+	}
+	TokenInfoCadenceType.Fields = []cadence.Field{
+		{Identifier: "id", Type: cadence.UInt64Type{}},
+		{Identifier: "name", Type: cadence.StringType{}},
+		{Identifier: "color", Type: TokenColorCadenceType},
+		{Identifier: "price", Type: cadence.UFix64Type{}},
+		{Identifier: "supply", Type: cadence.UInt256Type{}},
+		{Identifier: "tags", Type: cadence.DictionaryType{KeyType: cadence.StringType{}, ElementType: cadence.Int8Type{}}},
+		{Identifier: "owners", Type: cadence.VariableSizedArrayType{ElementType: cadence.AddressType{}}},
+		{Identifier: "parent", Type: cadence.OptionalType{Type: TokenInfoCadenceType}},
+	}
+	TokenMintedCadenceType.Fields = []cadence.Field{
+		{Identifier: "id", Type: cadence.UInt64Type{}},
+		{Identifier: "price", Type: cadence.UFix64Type{}},
+		{Identifier: "receiver", Type: cadence.OptionalType{Type: cadence.AddressType{}}},
+	}
+}
+
+// TokenColor is the Cadence enum `Token.Color`
+//
+// Color is the color of a token
+type TokenColor uint8
+
+const (
+	TokenColorRed   TokenColor = 0
+	TokenColorGreen TokenColor = 1
+	TokenColorBlue  TokenColor = 2
+)
+
+// ToCadence converts the value to a Cadence value
+func (v TokenColor) ToCadence() (cadence.Value, error) {
+	fields := make([]cadence.Value, 1)
+	var err error
+	fields[0], err = encodeUInt8(uint8(v))
+	if err != nil {
+		return nil, fmt.Errorf("field `rawValue`: %w", err)
+	}
+	return cadence.NewEnum(fields).WithType(TokenColorCadenceType), nil
+}
+
+// TokenColorFromCadence converts the given Cadence value to a TokenColor.
+// The fields of the value are decoded by name
+func TokenColorFromCadence(value cadence.Value) (result TokenColor, err error) {
+	compositeValue, ok := value.(cadence.Enum)
+	if !ok {
+		return result, unexpectedValueError("Token.Color", value)
+	}
+	var valueType cadence.CompositeType
+	if compositeValue.EnumType != nil {
+		valueType = compositeValue.EnumType
+	}
+	fields, err := compositeFields(TokenColorCadenceType, valueType, compositeValue.Fields)
+	if err != nil {
+		return result, err
+	}
+	rawValue, err := decodeUInt8(fields["rawValue"])
+	if err != nil {
+		return result, fmt.Errorf("field `rawValue`: %w", err)
+	}
+	return TokenColor(rawValue), nil
+}
+
+// TokenInfo is the Cadence struct `Token.Info`
+//
+// Info is the description of a token
+type TokenInfo struct {
+	// id is the unique identifier of the token
+	Id     uint64
+	Name   string
+	Color  TokenColor
+	Price  cadence.UFix64
+	Supply *big.Int
+	Tags   map[string]int8
+	Owners []cadence.Address
+	Parent *TokenInfo
+}
+
+// ToCadence converts the value to a Cadence value
+func (v *TokenInfo) ToCadence() (cadence.Value, error) {
+	fields := make([]cadence.Value, 8)
+	var err error
+	fields[0], err = encodeUInt64(v.Id)
+	if err != nil {
+		return nil, fmt.Errorf("field `id`: %w", err)
+	}
+	fields[1], err = encodeString(v.Name)
+	if err != nil {
+		return nil, fmt.Errorf("field `name`: %w", err)
+	}
+	fields[2], err = encodeTokenColor(v.Color)
+	if err != nil {
+		return nil, fmt.Errorf("field `color`: %w", err)
+	}
+	fields[3], err = encodeUFix64(v.Price)
+	if err != nil {
+		return nil, fmt.Errorf("field `price`: %w", err)
+	}
+	fields[4], err = encodeUInt256(v.Supply)
+	if err != nil {
+		return nil, fmt.Errorf("field `supply`: %w", err)
+	}
+	fields[5], err = encodeDictionaryOfStringToInt8(v.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("field `tags`: %w", err)
+	}
+	fields[6], err = encodeArrayOfAddress(v.Owners)
+	if err != nil {
+		return nil, fmt.Errorf("field `owners`: %w", err)
+	}
+	fields[7], err = encodeOptionalTokenInfo(v.Parent)
+	if err != nil {
+		return nil, fmt.Errorf("field `parent`: %w", err)
+	}
+	return cadence.NewStruct(fields).WithType(TokenInfoCadenceType), nil
+}
+
+// TokenInfoFromCadence converts the given Cadence value to a TokenInfo.
+// The fields of the value are decoded by name
+func TokenInfoFromCadence(value cadence.Value) (result *TokenInfo, err error) {
+	compositeValue, ok := value.(cadence.Struct)
+	if !ok {
+		return result, unexpectedValueError("Token.Info", value)
+	}
+	var valueType cadence.CompositeType
+	if compositeValue.StructType != nil {
+		valueType = compositeValue.StructType
+	}
+	fields, err := compositeFields(TokenInfoCadenceType, valueType, compositeValue.Fields)
+	if err != nil {
+		return result, err
+	}
+	result = &TokenInfo{}
+	result.Id, err = decodeUInt64(fields["id"])
+	if err != nil {
+		return nil, fmt.Errorf("field `id`: %w", err)
+	}
+	result.Name, err = decodeString(fields["name"])
+	if err != nil {
+		return nil, fmt.Errorf("field `name`: %w", err)
+	}
+	result.Color, err = decodeTokenColor(fields["color"])
+	if err != nil {
+		return nil, fmt.Errorf("field `color`: %w", err)
+	}
+	result.Price, err = decodeUFix64(fields["price"])
+	if err != nil {
+		return nil, fmt.Errorf("field `price`: %w", err)
+	}
+	result.Supply, err = decodeUInt256(fields["supply"])
+	if err != nil {
+		return nil, fmt.Errorf("field `supply`: %w", err)
+	}
+	result.Tags, err = decodeDictionaryOfStringToInt8(fields["tags"])
+	if err != nil {
+		return nil, fmt.Errorf("field `tags`: %w", err)
+	}
+	result.Owners, err = decodeArrayOfAddress(fields["owners"])
+	if err != nil {
+		return nil, fmt.Errorf("field `owners`: %w", err)
+	}
+	result.Parent, err = decodeOptionalTokenInfo(fields["parent"])
+	if err != nil {
+		return nil, fmt.Errorf("field `parent`: %w", err)
+	}
+	return result, nil
+}
+
+// TokenMinted is the Cadence event `Token.Minted`
+//
+// Minted is emitted when a token is minted
+type TokenMinted struct {
+	Id       uint64
+	Price    cadence.UFix64
+	Receiver *cadence.Address
+}
+
+// ToCadence converts the value to a Cadence value
+func (v *TokenMinted) ToCadence() (cadence.Value, error) {
+	fields := make([]cadence.Value, 3)
+	var err error
+	fields[0], err = encodeUInt64(v.Id)
+	if err != nil {
+		return nil, fmt.Errorf("field `id`: %w", err)
+	}
+	fields[1], err = encodeUFix64(v.Price)
+	if err != nil {
+		return nil, fmt.Errorf("field `price`: %w", err)
+	}
+	fields[2], err = encodeOptionalAddress(v.Receiver)
+	if err != nil {
+		return nil, fmt.Errorf("field `receiver`: %w", err)
+	}
+	return cadence.NewEvent(fields).WithType(TokenMintedCadenceType), nil
+}
+
+// TokenMintedFromCadence converts the given Cadence value to a TokenMinted.
+// The fields of the value are decoded by name
+func TokenMintedFromCadence(value cadence.Value) (result *TokenMinted, err error) {
+	compositeValue, ok := value.(cadence.Event)
+	if !ok {
+		return result, unexpectedValueError("Token.Minted", value)
+	}
+	var valueType cadence.CompositeType
+	if compositeValue.EventType != nil {
+		valueType = compositeValue.EventType
+	}
+	fields, err := compositeFields(TokenMintedCadenceType, valueType, compositeValue.Fields)
+	if err != nil {
+		return result, err
+	}
+	result = &TokenMinted{}
+	result.Id, err = decodeUInt64(fields["id"])
+	if err != nil {
+		return nil, fmt.Errorf("field `id`: %w", err)
+	}
+	result.Price, err = decodeUFix64(fields["price"])
+	if err != nil {
+		return nil, fmt.Errorf("field `price`: %w", err)
+	}
+	result.Receiver, err = decodeOptionalAddress(fields["receiver"])
+	if err != nil {
+		return nil, fmt.Errorf("field `receiver`: %w", err)
+	}
+	return result, nil
+}
+
+// GetInfoScript is the code of the script `get_info.cdc`
+//
+// Returns the information about the tokens with the given IDs
+const GetInfoScript = `import Token from "token.cdc"
+
+/// Returns the information about the tokens with the given IDs
+pub fun main(ids: [UInt64], color: Token.Color?, path: PublicPath): {UInt64: Token.Info} {
+    return {}
+}
+`
+
+// GetInfoArguments returns the arguments for the script `get_info.cdc`
+func GetInfoArguments(
+	ids []uint64,
+	color *TokenColor,
+	path cadence.Path,
+) ([]cadence.Value, error) {
+	arguments := make([]cadence.Value, 3)
+	var err error
+	arguments[0], err = encodeArrayOfUInt64(ids)
+	if err != nil {
+		return nil, fmt.Errorf("argument `ids`: %w", err)
+	}
+	arguments[1], err = encodeOptionalTokenColor(color)
+	if err != nil {
+		return nil, fmt.Errorf("argument `color`: %w", err)
+	}
+	arguments[2], err = encodePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("argument `path`: %w", err)
+	}
+	return arguments, nil
+}
+
+// DecodeGetInfoResult decodes the result of the script `get_info.cdc`
+func DecodeGetInfoResult(value cadence.Value) (map[uint64]*TokenInfo, error) {
+	return decodeDictionaryOfUInt64ToTokenInfo(value)
+}
+
+// TransferTransaction is the code of the transaction `transfer.cdc`
+const TransferTransaction = `import Token from "token.cdc"
+
+transaction(amount: UFix64, to: Address, info: Token.Info?) {
+
+    prepare(signer: AuthAccount) {}
+}
+`
+
+// TransferArguments returns the arguments for the transaction `transfer.cdc`
+func TransferArguments(
+	amount cadence.UFix64,
+	to cadence.Address,
+	info *TokenInfo,
+) ([]cadence.Value, error) {
+	arguments := make([]cadence.Value, 3)
+	var err error
+	arguments[0], err = encodeUFix64(amount)
+	if err != nil {
+		return nil, fmt.Errorf("argument `amount`: %w", err)
+	}
+	arguments[1], err = encodeAddress(to)
+	if err != nil {
+		return nil, fmt.Errorf("argument `to`: %w", err)
+	}
+	arguments[2], err = encodeOptionalTokenInfo(info)
+	if err != nil {
+		return nil, fmt.Errorf("argument `info`: %w", err)
+	}
+	return arguments, nil
+}
+
+func encodeAddress(v cadence.Address) (cadence.Value, error) {
+	return v, nil
+}
+
+func decodeAddress(value cadence.Value) (result cadence.Address, err error) {
+	v, ok := value.(cadence.Address)
+	if !ok {
+		return result, unexpectedValueError("Address", value)
+	}
+	return v, nil
+}
+
+func encodeArrayOfAddress(v []cadence.Address) (cadence.Value, error) {
+	values := make([]cadence.Value, len(v))
+	for i, element := range v {
+		value, err := encodeAddress(element)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		values[i] = value
+	}
+	return cadence.NewArray(values), nil
+}
+
+func decodeArrayOfAddress(value cadence.Value) (result []cadence.Address, err error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return result, unexpectedValueError("Array", value)
+	}
+	result = make([]cadence.Address, len(array.Values))
+	for i, element := range array.Values {
+		result[i], err = decodeAddress(element)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return result, nil
+}
+
+func encodeArrayOfUInt64(v []uint64) (cadence.Value, error) {
+	values := make([]cadence.Value, len(v))
+	for i, element := range v {
+		value, err := encodeUInt64(element)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		values[i] = value
+	}
+	return cadence.NewArray(values), nil
+}
+
+func decodeArrayOfUInt64(value cadence.Value) (result []uint64, err error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return result, unexpectedValueError("Array", value)
+	}
+	result = make([]uint64, len(array.Values))
+	for i, element := range array.Values {
+		result[i], err = decodeUInt64(element)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return result, nil
+}
+
+func encodeDictionaryOfStringToInt8(v map[string]int8) (cadence.Value, error) {
+	pairs := make([]cadence.KeyValuePair, 0, len(v))
+	for key, element := range v {
+		encodedKey, err := encodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %v: %w", key, err)
+		}
+		encodedValue, err := encodeInt8(element)
+		if err != nil {
+			return nil, fmt.Errorf("value for key %v: %w", key, err)
+		}
+		pairs = append(pairs, cadence.KeyValuePair{
+			Key:   encodedKey,
+			Value: encodedValue,
+		})
+	}
+	return cadence.NewDictionary(pairs), nil
+}
+
+func decodeDictionaryOfStringToInt8(value cadence.Value) (result map[string]int8, err error) {
+	dictionary, ok := value.(cadence.Dictionary)
+	if !ok {
+		return result, unexpectedValueError("Dictionary", value)
+	}
+	result = make(map[string]int8, len(dictionary.Pairs))
+	for _, pair := range dictionary.Pairs {
+		key, err := decodeString(pair.Key)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", pair.Key, err)
+		}
+		result[key], err = decodeInt8(pair.Value)
+		if err != nil {
+			return nil, fmt.Errorf("value for key %s: %w", pair.Key, err)
+		}
+	}
+	return result, nil
+}
+
+func encodeDictionaryOfUInt64ToTokenInfo(v map[uint64]*TokenInfo) (cadence.Value, error) {
+	pairs := make([]cadence.KeyValuePair, 0, len(v))
+	for key, element := range v {
+		encodedKey, err := encodeUInt64(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %v: %w", key, err)
+		}
+		encodedValue, err := encodeTokenInfo(element)
+		if err != nil {
+			return nil, fmt.Errorf("value for key %v: %w", key, err)
+		}
+		pairs = append(pairs, cadence.KeyValuePair{
+			Key:   encodedKey,
+			Value: encodedValue,
+		})
+	}
+	return cadence.NewDictionary(pairs), nil
+}
+
+func decodeDictionaryOfUInt64ToTokenInfo(value cadence.Value) (result map[uint64]*TokenInfo, err error) {
+	dictionary, ok := value.(cadence.Dictionary)
+	if !ok {
+		return result, unexpectedValueError("Dictionary", value)
+	}
+	result = make(map[uint64]*TokenInfo, len(dictionary.Pairs))
+	for _, pair := range dictionary.Pairs {
+		key, err := decodeUInt64(pair.Key)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", pair.Key, err)
+		}
+		result[key], err = decodeTokenInfo(pair.Value)
+		if err != nil {
+			return nil, fmt.Errorf("value for key %s: %w", pair.Key, err)
+		}
+	}
+	return result, nil
+}
+
+func encodeInt8(v int8) (cadence.Value, error) {
+	return cadence.NewInt8(v), nil
+}
+
+func decodeInt8(value cadence.Value) (result int8, err error) {
+	v, ok := value.(cadence.Int8)
+	if !ok {
+		return result, unexpectedValueError("Int8", value)
+	}
+	return int8(v), nil
+}
+
+func encodeOptionalAddress(v *cadence.Address) (cadence.Value, error) {
+	if v == nil {
+		return cadence.NewOptional(nil), nil
+	}
+	value, err := encodeAddress(*v)
+	if err != nil {
+		return nil, err
+	}
+	return cadence.NewOptional(value), nil
+}
+
+func decodeOptionalAddress(value cadence.Value) (result *cadence.Address, err error) {
+	optional, ok := value.(cadence.Optional)
+	if !ok {
+		return result, unexpectedValueError("Optional", value)
+	}
+	if optional.Value == nil {
+		return result, nil
+	}
+	v, err := decodeAddress(optional.Value)
+	if err != nil {
+		return result, err
+	}
+	return &v, nil
+}
+
+func encodeOptionalTokenColor(v *TokenColor) (cadence.Value, error) {
+	if v == nil {
+		return cadence.NewOptional(nil), nil
+	}
+	value, err := encodeTokenColor(*v)
+	if err != nil {
+		return nil, err
+	}
+	return cadence.NewOptional(value), nil
+}
+
+func decodeOptionalTokenColor(value cadence.Value) (result *TokenColor, err error) {
+	optional, ok := value.(cadence.Optional)
+	if !ok {
+		return result, unexpectedValueError("Optional", value)
+	}
+	if optional.Value == nil {
+		return result, nil
+	}
+	v, err := decodeTokenColor(optional.Value)
+	if err != nil {
+		return result, err
+	}
+	return &v, nil
+}
+
+func encodeOptionalTokenInfo(v *TokenInfo) (cadence.Value, error) {
+	if v == nil {
+		return cadence.NewOptional(nil), nil
+	}
+	value, err := encodeTokenInfo(v)
+	if err != nil {
+		return nil, err
+	}
+	return cadence.NewOptional(value), nil
+}
+
+func decodeOptionalTokenInfo(value cadence.Value) (result *TokenInfo, err error) {
+	optional, ok := value.(cadence.Optional)
+	if !ok {
+		return result, unexpectedValueError("Optional", value)
+	}
+	if optional.Value == nil {
+		return result, nil
+	}
+	return decodeTokenInfo(optional.Value)
+}
+
+func encodePath(v cadence.Path) (cadence.Value, error) {
+	return v, nil
+}
+
+func decodePath(value cadence.Value) (result cadence.Path, err error) {
+	v, ok := value.(cadence.Path)
+	if !ok {
+		return result, unexpectedValueError("Path", value)
+	}
+	return v, nil
+}
+
+func encodeString(v string) (cadence.Value, error) {
+	return cadence.NewString(v)
+}
+
+func decodeString(value cadence.Value) (result string, err error) {
+	v, ok := value.(cadence.String)
+	if !ok {
+		return result, unexpectedValueError("String", value)
+	}
+	return string(v), nil
+}
+
+func encodeTokenColor(v TokenColor) (cadence.Value, error) {
+	return v.ToCadence()
+}
+
+func decodeTokenColor(value cadence.Value) (result TokenColor, err error) {
+	return TokenColorFromCadence(value)
+}
+
+func encodeTokenInfo(v *TokenInfo) (cadence.Value, error) {
+	if v == nil {
+		return nil, fmt.Errorf("missing Token.Info value")
+	}
+	return v.ToCadence()
+}
+
+func decodeTokenInfo(value cadence.Value) (result *TokenInfo, err error) {
+	return TokenInfoFromCadence(value)
+}
+
+func encodeUFix64(v cadence.UFix64) (cadence.Value, error) {
+	return v, nil
+}
+
+func decodeUFix64(value cadence.Value) (result cadence.UFix64, err error) {
+	v, ok := value.(cadence.UFix64)
+	if !ok {
+		return result, unexpectedValueError("UFix64", value)
+	}
+	return v, nil
+}
+
+func encodeUInt256(v *big.Int) (cadence.Value, error) {
+	if v == nil {
+		return nil, fmt.Errorf("missing UInt256 value")
+	}
+	return cadence.NewUInt256FromBig(v)
+}
+
+func decodeUInt256(value cadence.Value) (result *big.Int, err error) {
+	v, ok := value.(cadence.UInt256)
+	if !ok {
+		return result, unexpectedValueError("UInt256", value)
+	}
+	return v.Big(), nil
+}
+
+func encodeUInt64(v uint64) (cadence.Value, error) {
+	return cadence.NewUInt64(v), nil
+}
+
+func decodeUInt64(value cadence.Value) (result uint64, err error) {
+	v, ok := value.(cadence.UInt64)
+	if !ok {
+		return result, unexpectedValueError("UInt64", value)
+	}
+	return uint64(v), nil
+}
+
+func encodeUInt8(v uint8) (cadence.Value, error) {
+	return cadence.NewUInt8(v), nil
+}
+
+func decodeUInt8(value cadence.Value) (result uint8, err error) {
+	v, ok := value.(cadence.UInt8)
+	if !ok {
+		return result, unexpectedValueError("UInt8", value)
+	}
+	return uint8(v), nil
+}
+
+// unexpectedValueError returns an error for a Cadence value which has not the expected type
+func unexpectedValueError(expected string, value cadence.Value) error {
+	if value == nil {
+		return fmt.Errorf("missing %s value", expected)
+	}
+	return fmt.Errorf("expected %s value, got %T", expected, value)
+}
+
+// compositeFields returns the fields of a composite value by name.
+// The names are taken from the type of the value, if any, otherwise from the expected type
+func compositeFields(
+	expectedType cadence.CompositeType,
+	valueType cadence.CompositeType,
+	values []cadence.Value,
+) (
+	map[string]cadence.Value,
+	error,
+) {
+	fieldTypes := expectedType.CompositeFields()
+
+	if valueType != nil {
+		if valueType.CompositeTypeQualifiedIdentifier() != expectedType.CompositeTypeQualifiedIdentifier() {
+			return nil, fmt.Errorf(
+				"expected %s value, got %s value",
+				expectedType.CompositeTypeQualifiedIdentifier(),
+				valueType.CompositeTypeQualifiedIdentifier(),
+			)
+		}
+		fieldTypes = valueType.CompositeFields()
+	}
+
+	if len(fieldTypes) != len(values) {
+		return nil, fmt.Errorf("expected %d fields, got %d", len(fieldTypes), len(values))
+	}
+
+	fields := make(map[string]cadence.Value, len(values))
+	for i, fieldType := range fieldTypes {
+		fields[fieldType.Identifier] = values[i]
+	}
+	return fields, nil
+}
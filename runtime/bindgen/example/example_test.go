@@ -0,0 +1,336 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package example
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+func TestGeneratedBindingsAreUpToDate(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("skipping generation of bindings in short mode")
+	}
+
+	expected, err := ioutil.ReadFile("bindings.go")
+	require.NoError(t, err)
+
+	actual, err := exec.Command(
+		"go", "run", "../../cmd/bindgen",
+		"-package", "example",
+		"-address", "0x1",
+		"token.cdc", "get_info.cdc", "transfer.cdc",
+	).Output()
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), string(actual), "bindings are out of date, run `go generate`")
+}
+
+func newTokenInfo() *TokenInfo {
+	return &TokenInfo{
+		Id:     1,
+		Name:   "Gold",
+		Color:  TokenColorGreen,
+		Price:  cadence.UFix64(150000000),
+		Supply: big.NewInt(1000),
+		Tags:   map[string]int8{"rare": 1},
+		Owners: []cadence.Address{{0x1}},
+		Parent: &TokenInfo{
+			Id:     2,
+			Name:   "Silver",
+			Color:  TokenColorBlue,
+			Supply: big.NewInt(0),
+			Tags:   map[string]int8{},
+			Owners: []cadence.Address{},
+		},
+	}
+}
+
+func TestTokenInfo(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+
+		t.Parallel()
+
+		info := newTokenInfo()
+
+		value, err := info.ToCadence()
+		require.NoError(t, err)
+
+		require.IsType(t, cadence.Struct{}, value)
+		assert.Equal(t, "A.0000000000000001.Token.Info", value.Type().ID())
+
+		decoded, err := TokenInfoFromCadence(value)
+		require.NoError(t, err)
+
+		assert.Equal(t, info, decoded)
+	})
+
+	t.Run("JSON round trip", func(t *testing.T) {
+
+		t.Parallel()
+
+		info := newTokenInfo()
+
+		value, err := info.ToCadence()
+		require.NoError(t, err)
+
+		encoded, err := jsoncdc.Encode(value)
+		require.NoError(t, err)
+
+		decodedValue, err := jsoncdc.Decode(encoded)
+		require.NoError(t, err)
+
+		decoded, err := TokenInfoFromCadence(decodedValue)
+		require.NoError(t, err)
+
+		assert.Equal(t, info, decoded)
+	})
+
+	t.Run("added field", func(t *testing.T) {
+
+		t.Parallel()
+
+		// The contract was updated and has a new field `rank` before the existing field `name`
+
+		fieldTypes := []cadence.Field{
+			{Identifier: "id", Type: cadence.UInt64Type{}},
+			{Identifier: "rank", Type: cadence.IntType{}},
+		}
+		fieldTypes = append(fieldTypes, TokenInfoCadenceType.Fields[1:]...)
+
+		fields := []cadence.Value{
+			cadence.NewUInt64(3),
+			cadence.NewInt(42),
+			cadence.String("Bronze"),
+			cadence.NewEnum([]cadence.Value{cadence.NewUInt8(0)}).WithType(TokenColorCadenceType),
+			cadence.UFix64(100000000),
+			cadence.NewUInt256(7),
+			cadence.NewDictionary([]cadence.KeyValuePair{}),
+			cadence.NewArray([]cadence.Value{}),
+			cadence.NewOptional(nil),
+		}
+
+		value := cadence.NewStruct(fields).WithType(&cadence.StructType{
+			Location:            TokenInfoCadenceType.Location,
+			QualifiedIdentifier: "Token.Info",
+			Fields:              fieldTypes,
+		})
+
+		decoded, err := TokenInfoFromCadence(value)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&TokenInfo{
+				Id:     3,
+				Name:   "Bronze",
+				Color:  TokenColorRed,
+				Price:  cadence.UFix64(100000000),
+				Supply: big.NewInt(7),
+				Tags:   map[string]int8{},
+				Owners: []cadence.Address{},
+			},
+			decoded,
+		)
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+
+		t.Parallel()
+
+		value := cadence.NewStruct([]cadence.Value{
+			cadence.NewUInt64(3),
+		}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Token.Info",
+			Fields: []cadence.Field{
+				{Identifier: "id", Type: cadence.UInt64Type{}},
+			},
+		})
+
+		_, err := TokenInfoFromCadence(value)
+		require.EqualError(t, err, "field `name`: missing String value")
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+
+		t.Parallel()
+
+		info := newTokenInfo()
+
+		value, err := info.ToCadence()
+		require.NoError(t, err)
+
+		structValue := value.(cadence.Struct)
+		structValue.Fields[0] = cadence.String("1")
+
+		_, err = TokenInfoFromCadence(structValue)
+		require.EqualError(t, err, "field `id`: expected UInt64 value, got cadence.String")
+	})
+
+	t.Run("other type", func(t *testing.T) {
+
+		t.Parallel()
+
+		value := cadence.NewStruct([]cadence.Value{}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Token.Other",
+		})
+
+		_, err := TokenInfoFromCadence(value)
+		require.EqualError(t, err, "expected Token.Info value, got Token.Other value")
+	})
+
+	t.Run("missing value", func(t *testing.T) {
+
+		t.Parallel()
+
+		info := newTokenInfo()
+		info.Supply = nil
+
+		_, err := info.ToCadence()
+		require.EqualError(t, err, "field `supply`: missing UInt256 value")
+	})
+}
+
+func TestTokenMinted(t *testing.T) {
+
+	t.Parallel()
+
+	receiver := cadence.Address{0x2}
+
+	minted := &TokenMinted{
+		Id:       1,
+		Price:    cadence.UFix64(100000000),
+		Receiver: &receiver,
+	}
+
+	value, err := minted.ToCadence()
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		cadence.NewEvent([]cadence.Value{
+			cadence.NewUInt64(1),
+			cadence.UFix64(100000000),
+			cadence.NewOptional(receiver),
+		}).WithType(TokenMintedCadenceType),
+		value,
+	)
+
+	decoded, err := TokenMintedFromCadence(value)
+	require.NoError(t, err)
+
+	assert.Equal(t, minted, decoded)
+}
+
+func TestTokenColor(t *testing.T) {
+
+	t.Parallel()
+
+	value, err := TokenColorBlue.ToCadence()
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		cadence.NewEnum([]cadence.Value{cadence.NewUInt8(2)}).WithType(TokenColorCadenceType),
+		value,
+	)
+
+	decoded, err := TokenColorFromCadence(value)
+	require.NoError(t, err)
+
+	assert.Equal(t, TokenColorBlue, decoded)
+}
+
+func TestGetInfo(t *testing.T) {
+
+	t.Parallel()
+
+	color := TokenColorRed
+
+	arguments, err := GetInfoArguments(
+		[]uint64{1, 2},
+		&color,
+		cadence.Path{Domain: "public", Identifier: "tokens"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]cadence.Value{
+			cadence.NewArray([]cadence.Value{
+				cadence.NewUInt64(1),
+				cadence.NewUInt64(2),
+			}),
+			cadence.NewOptional(
+				cadence.NewEnum([]cadence.Value{cadence.NewUInt8(0)}).WithType(TokenColorCadenceType),
+			),
+			cadence.Path{Domain: "public", Identifier: "tokens"},
+		},
+		arguments,
+	)
+
+	info := newTokenInfo()
+
+	infoValue, err := info.ToCadence()
+	require.NoError(t, err)
+
+	result, err := DecodeGetInfoResult(
+		cadence.NewDictionary([]cadence.KeyValuePair{
+			{Key: cadence.NewUInt64(1), Value: infoValue},
+		}),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[uint64]*TokenInfo{1: info}, result)
+
+	_, err = DecodeGetInfoResult(cadence.NewOptional(nil))
+	require.EqualError(t, err, "expected Dictionary value, got cadence.Optional")
+}
+
+func TestTransfer(t *testing.T) {
+
+	t.Parallel()
+
+	arguments, err := TransferArguments(
+		cadence.UFix64(100000000),
+		cadence.Address{0x2},
+		nil,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]cadence.Value{
+			cadence.UFix64(100000000),
+			cadence.Address{0x2},
+			cadence.NewOptional(nil),
+		},
+		arguments,
+	)
+
+	assert.Contains(t, TransferTransaction, "transaction(amount: UFix64, to: Address, info: Token.Info?)")
+}
@@ -0,0 +1,230 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package bindgen
+
+import (
+	"strings"
+	"text/template"
+)
+
+// file is the data of the generated file
+//
+type file struct {
+	PackageName  string
+	UsesBig      bool
+	UsesCommon   bool
+	NominalTypes []*nominalType
+	Bindings     []*compositeBinding
+	Programs     []*programBinding
+	Converters   []*converter
+}
+
+// comment returns the given docstring as the lines of a Go comment.
+//
+// The generated comments have no trailing empty line,
+// as it would be removed by the formatting of the generated code
+//
+func comment(docString string) string {
+	if docString == "" {
+		return ""
+	}
+
+	lines := strings.Split(docString, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = "//"
+		} else {
+			lines[i] = "// " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+var fileTemplate = template.Must(
+	template.New("file").
+		Funcs(template.FuncMap{
+			"comment": comment,
+		}).
+		Parse(fileTemplateText),
+)
+
+const fileTemplateText = `// Code generated by bindgen. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"fmt"
+{{- if .UsesBig}}
+	"math/big"
+{{- end}}
+
+	"github.com/onflow/cadence"
+{{- if .UsesCommon}}
+	"github.com/onflow/cadence/runtime/common"
+{{- end}}
+)
+{{range .NominalTypes}}
+// {{.VariableName}} is the Cadence type ` + "`{{.QualifiedIdentifier}}`" + `
+var {{.VariableName}} = {{.Declaration}}
+{{end}}
+{{- if .NominalTypes}}
+func init() {
+{{- range .NominalTypes}}
+	{{.VariableName}}.Fields = {{.Fields}}
+{{- end}}
+}
+{{end}}
+{{- range .Bindings}}
+{{- $binding := .}}
+{{- if .IsEnum}}
+// {{.GoName}} is the Cadence enum ` + "`{{.QualifiedIdentifier}}`" + `
+{{- if .DocString}}
+//
+{{comment .DocString}}
+{{- end}}
+type {{.GoName}} {{.RawGoType}}
+{{if .Cases}}
+const (
+{{- range .Cases}}
+{{- if .DocString}}
+	{{comment .DocString}}
+{{- end}}
+	{{.GoName}} {{$binding.GoName}} = {{.RawValue}}
+{{- end}}
+)
+{{end}}
+// ToCadence converts the value to a Cadence value
+func (v {{.GoName}}) ToCadence() (cadence.Value, error) {
+	{{.EncodeBody}}
+}
+{{- else}}
+// {{.GoName}} is the Cadence {{if eq .Kind "Event"}}event{{else}}struct{{end}} ` + "`{{.QualifiedIdentifier}}`" + `
+{{- if .DocString}}
+//
+{{comment .DocString}}
+{{- end}}
+type {{.GoName}} struct {
+{{- range .Fields}}
+{{- if .DocString}}
+	{{comment .DocString}}
+{{- end}}
+	{{.GoName}} {{.Converter.GoType}}
+{{- end}}
+}
+
+// ToCadence converts the value to a Cadence value
+func (v *{{.GoName}}) ToCadence() (cadence.Value, error) {
+	{{.EncodeBody}}
+}
+{{- end}}
+
+// {{.GoName}}FromCadence converts the given Cadence value to a {{.GoName}}.
+// The fields of the value are decoded by name
+func {{.GoName}}FromCadence(value cadence.Value) (result {{if not .IsEnum}}*{{end}}{{.GoName}}, err error) {
+	{{.DecodeBody}}
+}
+{{end}}
+{{- range .Programs}}
+// {{.ConstantName}} is the code of the {{.Kind}} ` + "`{{.FileName}}`" + `
+{{- if .DocString}}
+//
+{{comment .DocString}}
+{{- end}}
+const {{.ConstantName}} = {{.Code}}
+
+// {{.GoName}}Arguments returns the arguments for the {{.Kind}} ` + "`{{.FileName}}`" + `
+func {{.GoName}}Arguments(
+{{- range .Parameters}}
+	{{.GoName}} {{.Converter.GoType}},
+{{- end}}
+) ([]cadence.Value, error) {
+{{- if .Parameters}}
+	arguments := make([]cadence.Value, {{len .Parameters}})
+	var err error
+{{- range $index, $parameter := .Parameters}}
+	arguments[{{$index}}], err = encode{{.Converter.Name}}({{.GoName}})
+	if err != nil {
+		return nil, fmt.Errorf("argument ` + "`{{.Identifier}}`" + `: %w", err)
+	}
+{{- end}}
+	return arguments, nil
+{{- else}}
+	return []cadence.Value{}, nil
+{{- end}}
+}
+{{- if .Result}}
+
+// Decode{{.GoName}}Result decodes the result of the {{.Kind}} ` + "`{{.FileName}}`" + `
+func Decode{{.GoName}}Result(value cadence.Value) ({{.Result.GoType}}, error) {
+	return decode{{.Result.Name}}(value)
+}
+{{- end}}
+{{end}}
+{{- range .Converters}}
+func encode{{.Name}}(v {{.GoType}}) (cadence.Value, error) {
+	{{.Encode}}
+}
+
+func decode{{.Name}}(value cadence.Value) (result {{.GoType}}, err error) {
+	{{.Decode}}
+}
+{{end}}
+// unexpectedValueError returns an error for a Cadence value which has not the expected type
+func unexpectedValueError(expected string, value cadence.Value) error {
+	if value == nil {
+		return fmt.Errorf("missing %s value", expected)
+	}
+	return fmt.Errorf("expected %s value, got %T", expected, value)
+}
+
+// compositeFields returns the fields of a composite value by name.
+// The names are taken from the type of the value, if any, otherwise from the expected type
+func compositeFields(
+	expectedType cadence.CompositeType,
+	valueType cadence.CompositeType,
+	values []cadence.Value,
+) (
+	map[string]cadence.Value,
+	error,
+) {
+	fieldTypes := expectedType.CompositeFields()
+
+	if valueType != nil {
+		if valueType.CompositeTypeQualifiedIdentifier() != expectedType.CompositeTypeQualifiedIdentifier() {
+			return nil, fmt.Errorf(
+				"expected %s value, got %s value",
+				expectedType.CompositeTypeQualifiedIdentifier(),
+				valueType.CompositeTypeQualifiedIdentifier(),
+			)
+		}
+		fieldTypes = valueType.CompositeFields()
+	}
+
+	if len(fieldTypes) != len(values) {
+		return nil, fmt.Errorf("expected %d fields, got %d", len(fieldTypes), len(values))
+	}
+
+	fields := make(map[string]cadence.Value, len(values))
+	for i, fieldType := range fieldTypes {
+		fields[fieldType.Identifier] = values[i]
+	}
+	return fields, nil
+}
+`
@@ -0,0 +1,592 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bindgen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// converter is a pair of generated functions,
+// which convert between the Go representation of a Cadence type and Cadence values
+//
+type converter struct {
+	// Name is the name of the Cadence type, used for the names of the functions,
+	// e.g. `OptionalUInt64` for the functions `encodeOptionalUInt64` and `decodeOptionalUInt64`
+	Name string
+	// GoType is the Go type the Cadence type is represented as
+	GoType string
+	// Encode is the body of the function which converts the Go value `v` to a Cadence value
+	Encode string
+	// Decode is the body of the function which converts the Cadence value `value` to a Go value
+	Decode string
+}
+
+// bigIntConstructors are the constructors of the Cadence values for the integer types
+// which are represented as `*big.Int`
+//
+var bigIntConstructors = map[string]string{
+	"Int":     "NewIntFromBig",
+	"Int128":  "NewInt128FromBig",
+	"Int256":  "NewInt256FromBig",
+	"UInt":    "NewUIntFromBig",
+	"UInt128": "NewUInt128FromBig",
+	"UInt256": "NewUInt256FromBig",
+}
+
+// fixedSizeIntegerTypes are the Go types of the integer types which have a fixed size
+//
+var fixedSizeIntegerTypes = map[string]string{
+	"Int8":   "int8",
+	"Int16":  "int16",
+	"Int32":  "int32",
+	"Int64":  "int64",
+	"UInt8":  "uint8",
+	"UInt16": "uint16",
+	"UInt32": "uint32",
+	"UInt64": "uint64",
+	"Word8":  "uint8",
+	"Word16": "uint16",
+	"Word32": "uint32",
+	"Word64": "uint64",
+}
+
+// converter returns the converter for the given type,
+// and generates it if it was not generated yet
+//
+func (g *generator) converter(ty cadence.Type) *converter {
+	name := g.converterName(ty)
+
+	if result, ok := g.converters[name]; ok {
+		return result
+	}
+
+	result := &converter{
+		Name: name,
+	}
+
+	// NOTE: register the converter before generating the converters of nested types,
+	// so recursive types are supported
+
+	g.converters[name] = result
+
+	g.generateConverter(result, ty)
+
+	return result
+}
+
+// converterName returns the name of the converter for the given type.
+//
+// Types which are represented as the same Go type and are converted the same way have the same name
+//
+func (g *generator) converterName(ty cadence.Type) string {
+	switch ty := ty.(type) {
+	case cadence.OptionalType:
+		return "Optional" + g.converterName(ty.Type)
+
+	case cadence.VariableSizedArrayType:
+		return "ArrayOf" + g.converterName(ty.ElementType)
+
+	case cadence.ConstantSizedArrayType:
+		return "ArrayOf" + g.converterName(ty.ElementType)
+
+	case cadence.DictionaryType:
+		if !g.isComparable(ty.KeyType) {
+			return "Value"
+		}
+		return "DictionaryOf" + g.converterName(ty.KeyType) + "To" + g.converterName(ty.ElementType)
+
+	case cadence.CapabilityType:
+		return "Capability"
+	}
+
+	if binding := g.compositeBinding(ty); binding != nil {
+		return binding.GoName
+	}
+
+	id := ty.ID()
+
+	if _, ok := bigIntConstructors[id]; ok {
+		return id
+	}
+
+	if _, ok := fixedSizeIntegerTypes[id]; ok {
+		return id
+	}
+
+	switch ty.(type) {
+	case cadence.BoolType,
+		cadence.StringType,
+		cadence.AddressType,
+		cadence.Fix64Type,
+		cadence.UFix64Type,
+		cadence.Fix128Type,
+		cadence.UFix128Type,
+		cadence.MetaType:
+
+		return id
+
+	case cadence.PathType,
+		cadence.CapabilityPathType,
+		cadence.StoragePathType,
+		cadence.PublicPathType,
+		cadence.PrivatePathType:
+
+		return "Path"
+	}
+
+	return "Value"
+}
+
+// isComparable returns true if the Go type of the given type can be used as a map key
+//
+func (g *generator) isComparable(ty cadence.Type) bool {
+	switch ty.(type) {
+	case cadence.BoolType,
+		cadence.StringType,
+		cadence.AddressType,
+		cadence.Fix64Type,
+		cadence.UFix64Type,
+		cadence.PathType,
+		cadence.CapabilityPathType,
+		cadence.StoragePathType,
+		cadence.PublicPathType,
+		cadence.PrivatePathType:
+
+		return true
+
+	case *cadence.EnumType:
+		return g.compositeBinding(ty) != nil
+	}
+
+	_, ok := fixedSizeIntegerTypes[ty.ID()]
+	return ok
+}
+
+// isNillable returns true if the given Go type has the zero value `nil`
+//
+func isNillable(goType string) bool {
+	return strings.HasPrefix(goType, "*") ||
+		strings.HasPrefix(goType, "[]") ||
+		strings.HasPrefix(goType, "map[") ||
+		goType == "cadence.Value"
+}
+
+func (g *generator) generateConverter(result *converter, ty cadence.Type) {
+
+	switch ty := ty.(type) {
+	case cadence.OptionalType:
+		g.generateOptionalConverter(result, ty)
+		return
+
+	case cadence.VariableSizedArrayType:
+		g.generateArrayConverter(result, ty.ElementType)
+		return
+
+	case cadence.ConstantSizedArrayType:
+		g.generateArrayConverter(result, ty.ElementType)
+		return
+
+	case cadence.DictionaryType:
+		if g.isComparable(ty.KeyType) {
+			g.generateDictionaryConverter(result, ty)
+			return
+		}
+	}
+
+	switch result.Name {
+	case "Value":
+		result.GoType = "cadence.Value"
+		result.Encode = `if v == nil {
+	return nil, fmt.Errorf("missing value")
+}
+return v, nil`
+		result.Decode = `if value == nil {
+	return nil, fmt.Errorf("missing value")
+}
+return value, nil`
+		return
+
+	case "Bool":
+		result.GoType = "bool"
+		result.Encode = "return cadence.NewBool(v), nil"
+		result.Decode = assertionCode("Bool", "bool(v)")
+		return
+
+	case "String":
+		result.GoType = "string"
+		result.Encode = "return cadence.NewString(v)"
+		result.Decode = assertionCode("String", "string(v)")
+		return
+
+	case "Address", "Fix64", "UFix64", "Fix128", "UFix128", "Capability", "Path":
+		result.GoType = "cadence." + result.Name
+		result.Encode = "return v, nil"
+		result.Decode = assertionCode(result.Name, "v")
+		return
+
+	case "Type":
+		result.GoType = "cadence.TypeValue"
+		result.Encode = "return v, nil"
+		result.Decode = assertionCode("TypeValue", "v")
+		return
+	}
+
+	if goType, ok := fixedSizeIntegerTypes[result.Name]; ok {
+		result.GoType = goType
+		result.Encode = fmt.Sprintf("return cadence.New%s(v), nil", result.Name)
+		result.Decode = assertionCode(result.Name, goType+"(v)")
+		return
+	}
+
+	if constructor, ok := bigIntConstructors[result.Name]; ok {
+		g.usesBig = true
+
+		result.GoType = "*big.Int"
+		// Only the constructor of Int cannot fail, as Int is unbounded
+		returnStatement := fmt.Sprintf("return cadence.%s(v)", constructor)
+		if result.Name == "Int" {
+			returnStatement += ", nil"
+		}
+
+		result.Encode = fmt.Sprintf(
+			`if v == nil {
+	return nil, fmt.Errorf("missing %s value")
+}
+%s`,
+			result.Name,
+			returnStatement,
+		)
+		result.Decode = assertionCode(result.Name, "v.Big()")
+		return
+	}
+
+	binding := g.compositeBinding(ty)
+	if binding == nil {
+		panic(fmt.Errorf("unsupported type: %s", ty.ID()))
+	}
+
+	if binding.IsEnum {
+		result.GoType = binding.GoName
+		result.Encode = "return v.ToCadence()"
+	} else {
+		result.GoType = "*" + binding.GoName
+		result.Encode = fmt.Sprintf(
+			`if v == nil {
+	return nil, fmt.Errorf("missing %s value")
+}
+return v.ToCadence()`,
+			binding.QualifiedIdentifier,
+		)
+	}
+	result.Decode = fmt.Sprintf("return %sFromCadence(value)", binding.GoName)
+}
+
+// assertionCode returns the body of a decoding function,
+// which asserts the Cadence value has the given value type,
+// and returns the given Go expression for the asserted value `v`
+//
+func assertionCode(valueType string, expression string) string {
+	return fmt.Sprintf(
+		`v, ok := value.(cadence.%[1]s)
+if !ok {
+	return result, unexpectedValueError("%[1]s", value)
+}
+return %[2]s, nil`,
+		valueType,
+		expression,
+	)
+}
+
+func (g *generator) generateOptionalConverter(result *converter, ty cadence.OptionalType) {
+	inner := g.converter(ty.Type)
+
+	if isNillable(inner.GoType) {
+		result.GoType = inner.GoType
+		result.Encode = fmt.Sprintf(
+			`if v == nil {
+	return cadence.NewOptional(nil), nil
+}
+value, err := encode%s(v)
+if err != nil {
+	return nil, err
+}
+return cadence.NewOptional(value), nil`,
+			inner.Name,
+		)
+		result.Decode = fmt.Sprintf(
+			`optional, ok := value.(cadence.Optional)
+if !ok {
+	return result, unexpectedValueError("Optional", value)
+}
+if optional.Value == nil {
+	return result, nil
+}
+return decode%s(optional.Value)`,
+			inner.Name,
+		)
+		return
+	}
+
+	result.GoType = "*" + inner.GoType
+	result.Encode = fmt.Sprintf(
+		`if v == nil {
+	return cadence.NewOptional(nil), nil
+}
+value, err := encode%s(*v)
+if err != nil {
+	return nil, err
+}
+return cadence.NewOptional(value), nil`,
+		inner.Name,
+	)
+	result.Decode = fmt.Sprintf(
+		`optional, ok := value.(cadence.Optional)
+if !ok {
+	return result, unexpectedValueError("Optional", value)
+}
+if optional.Value == nil {
+	return result, nil
+}
+v, err := decode%s(optional.Value)
+if err != nil {
+	return result, err
+}
+return &v, nil`,
+		inner.Name,
+	)
+}
+
+func (g *generator) generateArrayConverter(result *converter, elementType cadence.Type) {
+	element := g.converter(elementType)
+
+	result.GoType = "[]" + element.GoType
+	result.Encode = fmt.Sprintf(
+		`values := make([]cadence.Value, len(v))
+for i, element := range v {
+	value, err := encode%s(element)
+	if err != nil {
+		return nil, fmt.Errorf("element %%d: %%w", i, err)
+	}
+	values[i] = value
+}
+return cadence.NewArray(values), nil`,
+		element.Name,
+	)
+	result.Decode = fmt.Sprintf(
+		`array, ok := value.(cadence.Array)
+if !ok {
+	return result, unexpectedValueError("Array", value)
+}
+result = make(%s, len(array.Values))
+for i, element := range array.Values {
+	result[i], err = decode%s(element)
+	if err != nil {
+		return nil, fmt.Errorf("element %%d: %%w", i, err)
+	}
+}
+return result, nil`,
+		result.GoType,
+		element.Name,
+	)
+}
+
+func (g *generator) generateDictionaryConverter(result *converter, ty cadence.DictionaryType) {
+	key := g.converter(ty.KeyType)
+	element := g.converter(ty.ElementType)
+
+	result.GoType = fmt.Sprintf("map[%s]%s", key.GoType, element.GoType)
+	result.Encode = fmt.Sprintf(
+		`pairs := make([]cadence.KeyValuePair, 0, len(v))
+for key, element := range v {
+	encodedKey, err := encode%[1]s(key)
+	if err != nil {
+		return nil, fmt.Errorf("key %%v: %%w", key, err)
+	}
+	encodedValue, err := encode%[2]s(element)
+	if err != nil {
+		return nil, fmt.Errorf("value for key %%v: %%w", key, err)
+	}
+	pairs = append(pairs, cadence.KeyValuePair{
+		Key:   encodedKey,
+		Value: encodedValue,
+	})
+}
+return cadence.NewDictionary(pairs), nil`,
+		key.Name,
+		element.Name,
+	)
+	result.Decode = fmt.Sprintf(
+		`dictionary, ok := value.(cadence.Dictionary)
+if !ok {
+	return result, unexpectedValueError("Dictionary", value)
+}
+result = make(%[1]s, len(dictionary.Pairs))
+for _, pair := range dictionary.Pairs {
+	key, err := decode%[2]s(pair.Key)
+	if err != nil {
+		return nil, fmt.Errorf("key %%s: %%w", pair.Key, err)
+	}
+	result[key], err = decode%[3]s(pair.Value)
+	if err != nil {
+		return nil, fmt.Errorf("value for key %%s: %%w", pair.Key, err)
+	}
+}
+return result, nil`,
+		result.GoType,
+		key.Name,
+		element.Name,
+	)
+}
+
+// typeLiteral returns the Go expression for the given Cadence type
+//
+func (g *generator) typeLiteral(ty cadence.Type) string {
+	switch ty := ty.(type) {
+	case cadence.OptionalType:
+		return fmt.Sprintf(
+			"cadence.OptionalType{Type: %s}",
+			g.typeLiteral(ty.Type),
+		)
+
+	case cadence.VariableSizedArrayType:
+		return fmt.Sprintf(
+			"cadence.VariableSizedArrayType{ElementType: %s}",
+			g.typeLiteral(ty.ElementType),
+		)
+
+	case cadence.ConstantSizedArrayType:
+		return fmt.Sprintf(
+			"cadence.ConstantSizedArrayType{Size: %d, ElementType: %s}",
+			ty.Size,
+			g.typeLiteral(ty.ElementType),
+		)
+
+	case cadence.DictionaryType:
+		return fmt.Sprintf(
+			"cadence.DictionaryType{KeyType: %s, ElementType: %s}",
+			g.typeLiteral(ty.KeyType),
+			g.typeLiteral(ty.ElementType),
+		)
+
+	case cadence.CapabilityType:
+		borrowType := "nil"
+		if ty.BorrowType != nil {
+			borrowType = g.typeLiteral(ty.BorrowType)
+		}
+		return fmt.Sprintf(
+			"cadence.CapabilityType{BorrowType: %s}.WithID(%q)",
+			borrowType,
+			ty.ID(),
+		)
+
+	case cadence.ReferenceType:
+		return fmt.Sprintf(
+			"cadence.ReferenceType{Authorized: %t, Type: %s}.WithID(%q)",
+			ty.Authorized,
+			g.typeLiteral(ty.Type),
+			ty.ID(),
+		)
+
+	case cadence.RestrictedType:
+		restrictions := make([]string, len(ty.Restrictions))
+		for i, restriction := range ty.Restrictions {
+			restrictions[i] = g.typeLiteral(restriction)
+		}
+		return fmt.Sprintf(
+			"cadence.RestrictedType{Type: %s, Restrictions: []cadence.Type{%s}}.WithID(%q)",
+			g.typeLiteral(ty.Type),
+			strings.Join(restrictions, ", "),
+			ty.ID(),
+		)
+
+	case cadence.Function:
+		parameters := make([]string, len(ty.Parameters))
+		for i, parameter := range ty.Parameters {
+			parameters[i] = g.parameterLiteral(parameter)
+		}
+		return fmt.Sprintf(
+			"cadence.Function{Parameters: []cadence.Parameter{%s}, ReturnType: %s}.WithID(%q)",
+			strings.Join(parameters, ", "),
+			g.typeLiteral(ty.ReturnType),
+			ty.ID(),
+		)
+
+	case *cadence.StructType,
+		*cadence.ResourceType,
+		*cadence.EventType,
+		*cadence.ContractType,
+		*cadence.EnumType,
+		*cadence.StructInterfaceType,
+		*cadence.ResourceInterfaceType,
+		*cadence.ContractInterfaceType:
+
+		return g.nominalType(ty).VariableName
+	}
+
+	// All other types have no fields
+	return fmt.Sprintf("%T{}", ty)
+}
+
+func (g *generator) parameterLiteral(parameter cadence.Parameter) string {
+	return fmt.Sprintf(
+		"{Label: %q, Identifier: %q, Type: %s}",
+		parameter.Label,
+		parameter.Identifier,
+		g.typeLiteral(parameter.Type),
+	)
+}
+
+// locationLiteral returns the Go expression for the given location
+//
+func (g *generator) locationLiteral(location common.Location) string {
+	switch location := location.(type) {
+	case nil:
+		return "nil"
+
+	case common.AddressLocation:
+		g.usesCommon = true
+
+		bytes := make([]string, len(location.Address))
+		for i, b := range location.Address {
+			bytes[i] = fmt.Sprintf("0x%x", b)
+		}
+		return fmt.Sprintf(
+			"common.AddressLocation{Address: common.Address{%s}, Name: %q}",
+			strings.Join(bytes, ", "),
+			location.Name,
+		)
+
+	case common.StringLocation:
+		g.usesCommon = true
+
+		return fmt.Sprintf("common.StringLocation(%q)", string(location))
+
+	case common.IdentifierLocation:
+		g.usesCommon = true
+
+		return fmt.Sprintf("common.IdentifierLocation(%q)", string(location))
+
+	default:
+		panic(fmt.Errorf("unsupported location: %s", location))
+	}
+}
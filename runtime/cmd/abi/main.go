@@ -0,0 +1,93 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// A utility program that type-checks a Cadence program
+// and prints a JSON description of its public interface (ABI),
+// e.g. the types, functions, and events declared by contracts
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+
+	"github.com/onflow/cadence/runtime/abi"
+	"github.com/onflow/cadence/runtime/cmd"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+var addressFlag = flag.String("address", "", "the address the program is deployed to, used for the type IDs")
+
+func main() {
+	flag.Parse()
+
+	args := flag.Args()
+
+	var path string
+	switch len(args) {
+	case 0:
+		break
+	case 1:
+		path = args[0]
+	default:
+		cmd.ExitWithError("expected at most one path")
+	}
+
+	var location common.Location = common.StringLocation(path)
+	if *addressFlag != "" {
+		address, err := common.HexToAddress(*addressFlag)
+		if err != nil {
+			cmd.ExitWithError(err.Error())
+		}
+		location = common.AddressLocation{
+			Address: address,
+		}
+	}
+
+	codes := map[common.LocationID]string{}
+
+	program, must := cmd.PrepareProgram(read(path), location, codes)
+
+	checker, must := cmd.PrepareChecker(program, location, codes, nil, must)
+
+	must(checker.Check())
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(abi.FromChecker(checker))
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+}
+
+func read(path string) string {
+	var data []byte
+	var err error
+	if len(path) == 0 {
+		data, err = ioutil.ReadAll(bufio.NewReader(os.Stdin))
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+	return string(data)
+}
@@ -0,0 +1,113 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+// A utility program that type-checks Cadence programs and generates Go bindings for them,
+// i.e. Go types for the declared structs, events, and enums,
+// and functions for the arguments and results of scripts and transactions
+
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+
+	"github.com/onflow/cadence/runtime/abi"
+	"github.com/onflow/cadence/runtime/bindgen"
+	"github.com/onflow/cadence/runtime/cmd"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+var packageFlag = flag.String("package", "bindings", "the name of the generated Go package")
+var outFlag = flag.String("out", "", "the path of the generated Go file, standard output if empty")
+var addressFlag = flag.String("address", "", "the address the contracts are deployed to, used for the type IDs")
+
+func main() {
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		cmd.ExitWithError("expected at least one path")
+	}
+
+	var address *common.Address
+	if *addressFlag != "" {
+		parsedAddress, err := common.HexToAddress(*addressFlag)
+		if err != nil {
+			cmd.ExitWithError(err.Error())
+		}
+		address = &parsedAddress
+	}
+
+	sources := make([]*bindgen.Source, len(paths))
+
+	for i, path := range paths {
+		sources[i] = prepareSource(path, address)
+	}
+
+	code, err := bindgen.Generate(*packageFlag, sources)
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+
+	if *outFlag == "" {
+		_, err = os.Stdout.Write(code)
+	} else {
+		err = ioutil.WriteFile(*outFlag, code, 0644)
+	}
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+}
+
+// prepareSource type-checks the program at the given path.
+//
+// If an address is given, programs which declare contracts are checked as deployed to the address
+//
+func prepareSource(path string, address *common.Address) *bindgen.Source {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+	code := string(data)
+
+	var location common.Location = common.StringLocation(path)
+
+	codes := map[common.LocationID]string{}
+
+	program, must := cmd.PrepareProgram(code, location, codes)
+
+	if address != nil &&
+		(len(program.CompositeDeclarations()) > 0 || len(program.InterfaceDeclarations()) > 0) {
+
+		location = common.AddressLocation{
+			Address: *address,
+		}
+	}
+
+	checker, must := cmd.PrepareChecker(program, location, codes, nil, must)
+
+	must(checker.Check())
+
+	return &bindgen.Source{
+		Name: path,
+		Code: code,
+		ABI:  abi.FromChecker(checker),
+	}
+}
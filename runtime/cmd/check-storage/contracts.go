@@ -0,0 +1,285 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+var valueDeclarations = append(
+	stdlib.FlowBuiltInFunctions(stdlib.DefaultFlowBuiltinImpls()),
+	stdlib.BuiltinFunctions...,
+).ToSemaValueDeclarations()
+
+var typeDeclarations = append(
+	stdlib.FlowBuiltInTypes,
+	stdlib.BuiltinTypes...,
+).ToTypeDeclarations()
+
+// contracts is the set of contracts deployed in a state dump.
+//
+// All contracts are type-checked with the checker,
+// and the resulting elaborations are used to look up the current declarations of types.
+//
+type contracts struct {
+	codes        map[common.LocationID]string
+	names        map[common.Address][]string
+	elaborations map[common.LocationID]*sema.Elaboration
+	errors       map[common.LocationID]error
+	checking     map[common.LocationID]bool
+	// enumCaseCounts is the number of cases of each enum type
+	enumCaseCounts map[sema.TypeID]int
+}
+
+func newContracts() *contracts {
+	return &contracts{
+		codes:          map[common.LocationID]string{},
+		names:          map[common.Address][]string{},
+		elaborations:   map[common.LocationID]*sema.Elaboration{},
+		errors:         map[common.LocationID]error{},
+		checking:       map[common.LocationID]bool{},
+		enumCaseCounts: map[sema.TypeID]int{},
+	}
+}
+
+// Add adds the code of the contract with the given name, deployed to the given address.
+// The contract is checked when Check is called
+//
+func (c *contracts) Add(address common.Address, name string, code string) {
+	location := common.AddressLocation{
+		Address: address,
+		Name:    name,
+	}
+	c.codes[location.ID()] = code
+	c.names[address] = append(c.names[address], name)
+}
+
+// Check type-checks all contracts.
+// Contracts that fail to check are recorded in Errors.
+// The elaboration of a contract is still used if it failed to check,
+// as most of its declarations are usually still valid
+//
+func (c *contracts) Check() {
+	for _, location := range c.Locations() {
+		_, _ = c.check(location)
+	}
+}
+
+// Locations returns the locations of all contracts, in a deterministic order
+//
+func (c *contracts) Locations() []common.AddressLocation {
+	var locations []common.AddressLocation
+	for address, names := range c.names {
+		for _, name := range names {
+			locations = append(locations, common.AddressLocation{
+				Address: address,
+				Name:    name,
+			})
+		}
+	}
+
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].ID() < locations[j].ID()
+	})
+
+	return locations
+}
+
+// Errors returns the checking errors of the contracts, by location
+//
+func (c *contracts) Errors() map[common.LocationID]error {
+	return c.errors
+}
+
+// Codes returns the codes of the contracts, by location
+//
+func (c *contracts) Codes() map[common.LocationID]string {
+	return c.codes
+}
+
+func (c *contracts) check(location common.AddressLocation) (*sema.Elaboration, error) {
+	locationID := location.ID()
+
+	if elaboration, ok := c.elaborations[locationID]; ok {
+		return elaboration, c.errors[locationID]
+	}
+
+	code, ok := c.codes[locationID]
+	if !ok {
+		return nil, fmt.Errorf("missing contract %s", location)
+	}
+
+	// NOTE: record failures, so contracts are only checked once,
+	// and the contracts can be read concurrently after Check
+
+	program, err := parser2.ParseProgram(code)
+	if err != nil {
+		c.elaborations[locationID] = nil
+		c.errors[locationID] = err
+		return nil, err
+	}
+
+	c.checking[locationID] = true
+	defer delete(c.checking, locationID)
+
+	checker, err := sema.NewChecker(
+		program,
+		location,
+		sema.WithPredeclaredValues(valueDeclarations),
+		sema.WithPredeclaredTypes(typeDeclarations),
+		sema.WithLocationHandler(c.resolveLocation),
+		sema.WithImportHandler(
+			func(checker *sema.Checker, importedLocation common.Location, importRange ast.Range) (sema.Import, error) {
+
+				if importedLocation == stdlib.CryptoChecker.Location {
+					return sema.ElaborationImport{
+						Elaboration: stdlib.CryptoChecker.Elaboration,
+					}, nil
+				}
+
+				addressLocation, ok := importedLocation.(common.AddressLocation)
+				if !ok {
+					return nil, fmt.Errorf("cannot import %s", importedLocation)
+				}
+
+				if c.checking[addressLocation.ID()] {
+					return nil, &sema.CyclicImportsError{
+						Location: importedLocation,
+						Range:    importRange,
+					}
+				}
+
+				elaboration, _ := c.check(addressLocation)
+				if elaboration == nil {
+					return nil, fmt.Errorf("cannot import %s", importedLocation)
+				}
+
+				return sema.ElaborationImport{
+					Elaboration: elaboration,
+				}, nil
+			},
+		),
+	)
+	if err != nil {
+		c.elaborations[locationID] = nil
+		c.errors[locationID] = err
+		return nil, err
+	}
+
+	err = checker.Check()
+	if err != nil {
+		c.errors[locationID] = err
+	}
+
+	elaboration := checker.Elaboration
+	c.elaborations[locationID] = elaboration
+
+	for declaration, compositeType := range elaboration.CompositeDeclarationTypes {
+		if compositeType.Kind != common.CompositeKindEnum {
+			continue
+		}
+		c.enumCaseCounts[compositeType.ID()] = len(declaration.Members.EnumCases())
+	}
+
+	return elaboration, err
+}
+
+// resolveLocation resolves imports of the form `import X from 0x1`
+// to the contract `X` deployed to address 0x1.
+// If no identifiers are imported, all contracts of the account are imported
+//
+func (c *contracts) resolveLocation(identifiers []ast.Identifier, location common.Location) ([]sema.ResolvedLocation, error) {
+
+	addressLocation, ok := location.(common.AddressLocation)
+	if !ok || addressLocation.Name != "" {
+		return []sema.ResolvedLocation{
+			{
+				Location:    location,
+				Identifiers: identifiers,
+			},
+		}, nil
+	}
+
+	if len(identifiers) == 0 {
+		for _, name := range c.names[addressLocation.Address] {
+			identifiers = append(identifiers, ast.Identifier{Identifier: name})
+		}
+	}
+
+	resolvedLocations := make([]sema.ResolvedLocation, len(identifiers))
+	for i, identifier := range identifiers {
+		resolvedLocations[i] = sema.ResolvedLocation{
+			Location: common.AddressLocation{
+				Address: addressLocation.Address,
+				Name:    identifier.Identifier,
+			},
+			Identifiers: []ast.Identifier{identifier},
+		}
+	}
+
+	return resolvedLocations, nil
+}
+
+// Elaboration returns the elaboration of the contract declaring the type
+// with the given location and qualified identifier.
+// It returns nil if the contract does not exist.
+//
+// Locations of old encodings might not include the contract name,
+// in which case the contract name is the first part of the qualified identifier
+//
+func (c *contracts) Elaboration(location common.Location, qualifiedIdentifier string) *sema.Elaboration {
+	if location == stdlib.CryptoChecker.Location {
+		return stdlib.CryptoChecker.Elaboration
+	}
+
+	addressLocation, ok := location.(common.AddressLocation)
+	if !ok {
+		return nil
+	}
+
+	if addressLocation.Name == "" {
+		addressLocation.Name = contractName(qualifiedIdentifier)
+	}
+
+	elaboration, _ := c.check(addressLocation)
+	return elaboration
+}
+
+// EnumCaseCount returns the number of cases of the enum type with the given ID
+//
+func (c *contracts) EnumCaseCount(typeID sema.TypeID) (count int, ok bool) {
+	count, ok = c.enumCaseCounts[typeID]
+	return
+}
+
+func contractName(qualifiedIdentifier string) string {
+	for i, r := range qualifiedIdentifier {
+		if r == '.' {
+			return qualifiedIdentifier[:i]
+		}
+	}
+	return qualifiedIdentifier
+}
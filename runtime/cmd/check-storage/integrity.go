@@ -0,0 +1,327 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// problem is an inconsistency between a stored value and the current declarations
+//
+type problem struct {
+	Owner   string `json:"owner"`
+	Key     string `json:"key"`
+	TypeID  string `json:"typeID"`
+	Message string `json:"message"`
+}
+
+// integrityChecker checks stored values against the declarations of the deployed contracts
+//
+type integrityChecker struct {
+	contracts *contracts
+}
+
+func newIntegrityChecker(contracts *contracts) *integrityChecker {
+	return &integrityChecker{
+		contracts: contracts,
+	}
+}
+
+// CheckValue checks the given stored value and all values it contains,
+// and returns all problems found.
+//
+// The segments of large containers are loaded while the value is walked.
+// If a segment cannot be loaded, the problem is reported,
+// and the rest of the value is not checked.
+//
+func (c *integrityChecker) CheckValue(owner common.Address, key string, value interpreter.Value) (problems []problem) {
+	walker := &integrityWalker{
+		checker: c,
+		owner:   owner.ShortHexWithPrefix(),
+		key:     strings.ReplaceAll(key, "\x1F", "/"),
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(error)
+			if !ok {
+				panic(r)
+			}
+
+			walker.report("", "failed to load segment: %s", err)
+		}
+
+		problems = walker.problems
+	}()
+
+	interpreter.WalkValue(walker, value)
+
+	return
+}
+
+type integrityWalker struct {
+	checker  *integrityChecker
+	owner    string
+	key      string
+	problems []problem
+}
+
+func (w *integrityWalker) report(typeID common.TypeID, format string, args ...interface{}) {
+	w.problems = append(w.problems, problem{
+		Owner:   w.owner,
+		Key:     w.key,
+		TypeID:  string(typeID),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (w *integrityWalker) WalkValue(value interpreter.Value) interpreter.ValueWalker {
+	switch value := value.(type) {
+	case nil:
+		return nil
+
+	case *interpreter.CompositeValue:
+		w.checkComposite(value)
+
+	case *interpreter.ArrayValue:
+		w.checkStaticType(value.StaticType())
+
+	case *interpreter.DictionaryValue:
+		w.checkStaticType(value.StaticType())
+
+	case interpreter.TypeValue:
+		w.checkStaticType(value.Type)
+
+	case interpreter.CapabilityValue:
+		w.checkStaticType(value.BorrowType)
+
+	case interpreter.LinkValue:
+		w.checkStaticType(value.Type)
+	}
+
+	return w
+}
+
+func (w *integrityWalker) checkComposite(value *interpreter.CompositeValue) {
+	typeID := value.TypeID()
+
+	compositeType := w.compositeType(value.Location(), value.QualifiedIdentifier(), typeID)
+	if compositeType == nil {
+		return
+	}
+
+	if compositeType.Kind != value.Kind() {
+		w.report(
+			typeID,
+			"stored %s, but declared as %s",
+			value.Kind().Name(),
+			compositeType.Kind.Name(),
+		)
+		return
+	}
+
+	// Compare the stored fields with the declared fields.
+	// Members which are not serialized, like the `owner` field of resources,
+	// are not expected to be stored
+
+	fields := value.Fields()
+
+	declaredFields := map[string]bool{}
+
+	for _, fieldName := range compositeType.Fields {
+		member, ok := compositeType.Members.Get(fieldName)
+		if ok && member.IgnoreInSerialization {
+			continue
+		}
+
+		declaredFields[fieldName] = true
+
+		if _, ok := fields.Get(fieldName); !ok {
+			w.report(typeID, "missing field `%s`", fieldName)
+		}
+	}
+
+	var undeclaredFields []string
+
+	fields.Foreach(func(fieldName string, _ interpreter.Value) {
+		if !declaredFields[fieldName] {
+			undeclaredFields = append(undeclaredFields, fieldName)
+		}
+	})
+
+	sort.Strings(undeclaredFields)
+
+	for _, fieldName := range undeclaredFields {
+		w.report(typeID, "field `%s` is no longer declared", fieldName)
+	}
+
+	if compositeType.Kind == common.CompositeKindEnum {
+		w.checkEnumCase(compositeType, fields)
+	}
+}
+
+// checkEnumCase checks that the raw value of the stored enum case refers to a declared case.
+// The raw values of enum cases are assigned sequentially, starting at zero
+//
+func (w *integrityWalker) checkEnumCase(compositeType *sema.CompositeType, fields *interpreter.StringValueOrderedMap) {
+	typeID := compositeType.ID()
+
+	rawValue, ok := fields.Get(sema.EnumRawValueFieldName)
+	if !ok {
+		// already reported as a missing field
+		return
+	}
+
+	numberValue, ok := rawValue.(interpreter.NumberValue)
+	if !ok {
+		w.report(typeID, "invalid raw value %s", rawValue)
+		return
+	}
+
+	caseCount, ok := w.checker.contracts.EnumCaseCount(typeID)
+	if !ok {
+		return
+	}
+
+	rawValueIndex := numberValue.ToInt()
+	if rawValueIndex < 0 || rawValueIndex >= caseCount {
+		w.report(
+			typeID,
+			"raw value %d does not refer to a case, enum has %d cases",
+			rawValueIndex,
+			caseCount,
+		)
+	}
+}
+
+func (w *integrityWalker) compositeType(
+	location common.Location,
+	qualifiedIdentifier string,
+	typeID common.TypeID,
+) *sema.CompositeType {
+
+	elaboration := w.elaboration(location, qualifiedIdentifier, typeID)
+	if elaboration == nil {
+		return nil
+	}
+
+	compositeType, ok := elaboration.CompositeTypes[typeID]
+	if !ok {
+		w.report(typeID, "composite type is no longer declared")
+		return nil
+	}
+
+	return compositeType
+}
+
+func (w *integrityWalker) interfaceType(
+	location common.Location,
+	qualifiedIdentifier string,
+	typeID common.TypeID,
+) *sema.InterfaceType {
+
+	elaboration := w.elaboration(location, qualifiedIdentifier, typeID)
+	if elaboration == nil {
+		return nil
+	}
+
+	interfaceType, ok := elaboration.InterfaceTypes[typeID]
+	if !ok {
+		w.report(typeID, "interface type is no longer declared")
+		return nil
+	}
+
+	return interfaceType
+}
+
+func (w *integrityWalker) elaboration(
+	location common.Location,
+	qualifiedIdentifier string,
+	typeID common.TypeID,
+) *sema.Elaboration {
+
+	if location == nil {
+		w.report(typeID, "type has no location")
+		return nil
+	}
+
+	elaboration := w.checker.contracts.Elaboration(location, qualifiedIdentifier)
+	if elaboration == nil {
+		w.report(typeID, "contract %s is not deployed", location.ID())
+		return nil
+	}
+
+	return elaboration
+}
+
+// checkStaticType checks that all composite and interface types
+// referred to by the given static type are still declared
+//
+func (w *integrityWalker) checkStaticType(staticType interpreter.StaticType) {
+	switch staticType := staticType.(type) {
+	case nil:
+		return
+
+	case interpreter.CompositeStaticType:
+		typeID := staticTypeID(staticType.Location, staticType.QualifiedIdentifier)
+		w.compositeType(staticType.Location, staticType.QualifiedIdentifier, typeID)
+
+	case interpreter.InterfaceStaticType:
+		typeID := staticTypeID(staticType.Location, staticType.QualifiedIdentifier)
+		w.interfaceType(staticType.Location, staticType.QualifiedIdentifier, typeID)
+
+	case interpreter.VariableSizedStaticType:
+		w.checkStaticType(staticType.Type)
+
+	case interpreter.ConstantSizedStaticType:
+		w.checkStaticType(staticType.Type)
+
+	case interpreter.DictionaryStaticType:
+		w.checkStaticType(staticType.KeyType)
+		w.checkStaticType(staticType.ValueType)
+
+	case interpreter.OptionalStaticType:
+		w.checkStaticType(staticType.Type)
+
+	case *interpreter.RestrictedStaticType:
+		w.checkStaticType(staticType.Type)
+		for _, restriction := range staticType.Restrictions {
+			w.checkStaticType(restriction)
+		}
+
+	case interpreter.ReferenceStaticType:
+		w.checkStaticType(staticType.Type)
+
+	case interpreter.CapabilityStaticType:
+		w.checkStaticType(staticType.BorrowType)
+	}
+}
+
+func staticTypeID(location common.Location, qualifiedIdentifier string) common.TypeID {
+	if location == nil {
+		return common.TypeID(qualifiedIdentifier)
+	}
+	return location.TypeID(qualifiedIdentifier)
+}
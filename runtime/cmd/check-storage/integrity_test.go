@@ -0,0 +1,276 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestIntegrityChecker(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.BytesToAddress([]byte{0x1})
+
+	location := common.AddressLocation{
+		Address: address,
+		Name:    "Test",
+	}
+
+	newContractsWithTest := func(t *testing.T) *contracts {
+		contracts := newContracts()
+
+		contracts.Add(address, "Base", `
+          pub contract Base {
+              pub resource interface Receiver {}
+          }
+        `)
+
+		contracts.Add(address, "Test", `
+          import Base from 0x1
+
+          pub contract Test {
+
+              pub enum Color: UInt8 {
+                  pub case red
+                  pub case green
+              }
+
+              pub resource R: Base.Receiver {
+                  pub let color: Color
+
+                  init(color: Color) {
+                      self.color = color
+                  }
+              }
+
+              pub struct S {}
+          }
+        `)
+
+		contracts.Check()
+		require.Empty(t, contracts.Errors())
+
+		return contracts
+	}
+
+	newComposite := func(qualifiedIdentifier string, kind common.CompositeKind, fields map[string]interpreter.Value) *interpreter.CompositeValue {
+		orderedFields := interpreter.NewStringValueOrderedMap()
+		for name, value := range fields {
+			orderedFields.Set(name, value)
+		}
+		return interpreter.NewCompositeValue(location, qualifiedIdentifier, kind, orderedFields, nil)
+	}
+
+	newColor := func(rawValue uint8) *interpreter.CompositeValue {
+		return newComposite("Test.Color", common.CompositeKindEnum, map[string]interpreter.Value{
+			"rawValue": interpreter.UInt8Value(rawValue),
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker := newIntegrityChecker(newContractsWithTest(t))
+
+		value := newComposite("Test.R", common.CompositeKindResource, map[string]interpreter.Value{
+			"uuid":  interpreter.UInt64Value(1),
+			"color": newColor(1),
+		})
+
+		problems := checker.CheckValue(address, "storage\x1Fr", value)
+		assert.Empty(t, problems)
+	})
+
+	t.Run("fields", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker := newIntegrityChecker(newContractsWithTest(t))
+
+		value := newComposite("Test.R", common.CompositeKindResource, map[string]interpreter.Value{
+			"uuid": interpreter.UInt64Value(1),
+			"size": interpreter.NewIntValueFromInt64(1),
+		})
+
+		problems := checker.CheckValue(address, "storage\x1Fr", value)
+		assert.Equal(t,
+			[]problem{
+				{
+					Owner:   "0x1",
+					Key:     "storage/r",
+					TypeID:  "A.0000000000000001.Test.R",
+					Message: "missing field `color`",
+				},
+				{
+					Owner:   "0x1",
+					Key:     "storage/r",
+					TypeID:  "A.0000000000000001.Test.R",
+					Message: "field `size` is no longer declared",
+				},
+			},
+			problems,
+		)
+	})
+
+	t.Run("enum case", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker := newIntegrityChecker(newContractsWithTest(t))
+
+		problems := checker.CheckValue(address, "storage\x1Fcolor", newColor(2))
+		require.Len(t, problems, 1)
+		assert.Equal(t, "raw value 2 does not refer to a case, enum has 2 cases", problems[0].Message)
+	})
+
+	t.Run("kind", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker := newIntegrityChecker(newContractsWithTest(t))
+
+		value := newComposite("Test.S", common.CompositeKindResource, nil)
+
+		problems := checker.CheckValue(address, "storage\x1Fs", value)
+		require.Len(t, problems, 1)
+		assert.Equal(t, "stored resource, but declared as structure", problems[0].Message)
+	})
+
+	t.Run("static types", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker := newIntegrityChecker(newContractsWithTest(t))
+
+		value := interpreter.NewArrayValueUnownedNonCopying(
+			interpreter.VariableSizedStaticType{
+				Type: &interpreter.RestrictedStaticType{
+					Type: interpreter.CompositeStaticType{
+						Location:            location,
+						QualifiedIdentifier: "Test.Removed",
+					},
+					Restrictions: []interpreter.InterfaceStaticType{
+						{
+							Location: common.AddressLocation{
+								Address: address,
+								Name:    "Base",
+							},
+							QualifiedIdentifier: "Base.Receiver",
+						},
+						{
+							Location: common.AddressLocation{
+								Address: address,
+								Name:    "Missing",
+							},
+							QualifiedIdentifier: "Missing.Receiver",
+						},
+					},
+				},
+			},
+		)
+
+		problems := checker.CheckValue(address, "storage\x1Farray", value)
+		assert.Equal(t,
+			[]problem{
+				{
+					Owner:   "0x1",
+					Key:     "storage/array",
+					TypeID:  "A.0000000000000001.Test.Removed",
+					Message: "composite type is no longer declared",
+				},
+				{
+					Owner:   "0x1",
+					Key:     "storage/array",
+					TypeID:  "A.0000000000000001.Missing.Receiver",
+					Message: "contract A.0000000000000001.Missing is not deployed",
+				},
+			},
+			problems,
+		)
+	})
+	t.Run("segmented", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker := newIntegrityChecker(newContractsWithTest(t))
+
+		const key = "storage\x1Farray"
+
+		// The invalid element is stored in a segment of the array
+
+		elements := make([]interpreter.Value, 5000)
+		for i := range elements {
+			elements[i] = interpreter.NewStringValue("element")
+		}
+		elements[4000] = newComposite("Test.S", common.CompositeKindResource, nil)
+
+		array := interpreter.NewArrayValueUnownedNonCopying(
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeAnyStruct,
+			},
+			elements...,
+		)
+
+		data, deferrals, err := interpreter.EncodeValue(array, []string{key}, true, nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, deferrals.Segments)
+
+		decode := func(segments segments) interpreter.Value {
+			value, err := interpreter.DecodeSegmentedValue(
+				data,
+				&address,
+				[]string{key},
+				interpreter.CurrentEncodingVersion,
+				nil,
+				segments.Load,
+			)
+			require.NoError(t, err)
+			return value
+		}
+
+		arraySegments := map[interpreter.SegmentID][]byte{}
+		for _, segment := range deferrals.Segments {
+			arraySegments[segment.ID] = segment.Data
+		}
+
+		problems := checker.CheckValue(
+			address,
+			key,
+			decode(segments{
+				storedValue{owner: address, key: key}: arraySegments,
+			}),
+		)
+		require.Len(t, problems, 1)
+		assert.Equal(t, "stored resource, but declared as structure", problems[0].Message)
+
+		// Missing segments are reported
+
+		problems = checker.CheckValue(address, key, decode(segments{}))
+		require.Len(t, problems, 1)
+		assert.Contains(t, problems[0].Message, "failed to load segment: missing segment")
+	})
+}
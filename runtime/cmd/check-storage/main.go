@@ -0,0 +1,310 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// A utility program that parses a state dump in JSON Lines format,
+// type-checks all contracts, and checks that all stored values
+// are consistent with the current declarations of the contracts
+
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/schollz/progressbar/v3"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/pretty"
+)
+
+var gzipFlag = flag.Bool("gzip", false, "set true if input file is gzipped")
+var jsonFlag = flag.Bool("json", false, "print the problems formatted as JSON")
+
+// contractCodeKeyPrefix is the prefix of the keys of registers storing contract code.
+// The rest of the key is the name of the contract
+//
+const contractCodeKeyPrefix = "code."
+
+type keyPart struct {
+	Value string
+}
+
+type key struct {
+	KeyParts []keyPart
+}
+
+type entry struct {
+	Value string
+	Key   key
+}
+
+func (e entry) owner() common.Address {
+	rawOwner, err := hex.DecodeString(e.Key.KeyParts[1].Value)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return common.BytesToAddress(rawOwner)
+}
+
+func (e entry) key() string {
+	rawKey, err := hex.DecodeString(e.Key.KeyParts[2].Value)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return string(rawKey)
+}
+
+func (e entry) data() []byte {
+	data, err := hex.DecodeString(e.Value)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return data
+}
+
+// readEntries reads all entries of the state dump at the given path,
+// and calls the given function for each entry
+//
+func readEntries(path string, gzipped bool, description string, f func(entry)) {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	bar := progressbar.NewOptions64(
+		stat.Size(),
+		progressbar.OptionSetDescription(description),
+		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionShowBytes(true),
+	)
+
+	progressReader := progressbar.NewReader(file, bar)
+
+	var inputReader io.Reader = &progressReader
+	if gzipped {
+		gzipReader, err := gzip.NewReader(inputReader)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer gzipReader.Close()
+		inputReader = gzipReader
+	}
+
+	decoder := json.NewDecoder(bufio.NewReader(inputReader))
+	for {
+		var e entry
+
+		err = decoder.Decode(&e)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			log.Fatal(err)
+		}
+
+		f(e)
+	}
+
+	_, _ = fmt.Fprintln(os.Stderr)
+}
+
+func worker(
+	checker *integrityChecker,
+	segments segments,
+	jobs <-chan entry,
+	results chan<- []problem,
+	wg *sync.WaitGroup,
+) {
+	defer wg.Done()
+
+	for e := range jobs {
+
+		// Segments of large containers are checked as part of the value they belong to,
+		// they are loaded when the value is walked
+		if interpreter.IsSegment(e.data()) {
+			continue
+		}
+
+		data, version := interpreter.StripMagic(e.data())
+		if version == 0 {
+			continue
+		}
+
+		owner := e.owner()
+		key := e.key()
+
+		var value interpreter.Value
+		var err error
+		if version <= 4 {
+			value, err = interpreter.DecodeValueV4(data, &owner, nil, version, nil)
+		} else {
+			value, err = interpreter.DecodeSegmentedValue(
+				data,
+				&owner,
+				[]string{key},
+				version,
+				nil,
+				segments.Load,
+			)
+		}
+		if err != nil {
+			log.Fatalf("failed to decode value: %s\n%s\n", err, e.Value)
+		}
+
+		problems := checker.CheckValue(owner, key, value)
+		if len(problems) > 0 {
+			results <- problems
+		}
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		panic("missing path argument")
+	}
+
+	path := args[0]
+
+	// Load and check all contracts,
+	// and load the segments of large containers
+
+	contracts := newContracts()
+	segments := segments{}
+
+	readEntries(path, *gzipFlag, "(loading contracts and segments)", func(e entry) {
+		key := e.key()
+
+		if segments.Add(e.owner(), key, e.data()) {
+			return
+		}
+
+		if !strings.HasPrefix(key, contractCodeKeyPrefix) {
+			return
+		}
+
+		name := strings.TrimPrefix(key, contractCodeKeyPrefix)
+		contracts.Add(e.owner(), name, string(e.data()))
+	})
+
+	contracts.Check()
+
+	for _, location := range contracts.Locations() {
+		err := contracts.Errors()[location.ID()]
+		if err == nil {
+			continue
+		}
+
+		log.Printf("contract %s failed to check:\n", location)
+
+		printErr := pretty.NewErrorPrettyPrinter(os.Stderr, !*jsonFlag).
+			PrettyPrintError(err, location, contracts.Codes())
+		if printErr != nil {
+			log.Printf("%s\n", err)
+		}
+	}
+
+	// Check all stored values.
+	// Contracts are only read concurrently from here on,
+	// as all contracts are already checked
+
+	checker := newIntegrityChecker(contracts)
+
+	jobs := make(chan entry)
+	results := make(chan []problem)
+
+	var wg sync.WaitGroup
+
+	workerCount := runtime.NumCPU()
+
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go worker(checker, segments, jobs, results, &wg)
+	}
+
+	var problems []problem
+
+	collected := make(chan struct{})
+
+	go func() {
+		for result := range results {
+			problems = append(problems, result...)
+		}
+		close(collected)
+	}()
+
+	readEntries(path, *gzipFlag, "(checking values)", func(e entry) {
+		jobs <- e
+	})
+
+	close(jobs)
+
+	wg.Wait()
+
+	close(results)
+
+	<-collected
+
+	if *jsonFlag {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(problems)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(writer, "owner\tkey\ttype\tproblem")
+		for _, p := range problems {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", p.Owner, p.Key, p.TypeID, p.Message)
+		}
+		err := writer.Flush()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	log.Printf("found %d problems\n", len(problems))
+
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
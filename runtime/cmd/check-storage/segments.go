@@ -0,0 +1,76 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// storedValue identifies a value stored in a register
+//
+type storedValue struct {
+	owner common.Address
+	key   string
+}
+
+// segments are the segments of the large containers in a state dump,
+// grouped by the stored value they belong to.
+//
+// Segments are only added while the dump is read,
+// after that they may be loaded concurrently
+//
+type segments map[storedValue]map[interpreter.SegmentID][]byte
+
+// Add adds the given register data if the register stores a segment,
+// and returns true if it does
+//
+func (s segments) Add(owner common.Address, registerKey string, data []byte) bool {
+	if !interpreter.IsSegment(data) {
+		return false
+	}
+
+	key, id, ok := runtime.ParseSegmentKey(registerKey)
+	if !ok {
+		return false
+	}
+
+	value := storedValue{
+		owner: owner,
+		key:   key,
+	}
+
+	valueSegments, ok := s[value]
+	if !ok {
+		valueSegments = map[interpreter.SegmentID][]byte{}
+		s[value] = valueSegments
+	}
+
+	valueSegments[id] = data
+
+	return true
+}
+
+// Load is an interpreter.SegmentLoader.
+// The data of a missing segment is nil, which is reported when the segment is decoded
+//
+func (s segments) Load(owner common.Address, key string, id interpreter.SegmentID) ([]byte, error) {
+	return s[storedValue{owner: owner, key: key}][id], nil
+}
@@ -82,13 +82,15 @@ var typeDeclarations = append(
 var checkers = map[common.LocationID]*sema.Checker{}
 
 // PrepareChecker prepares and initializes a checker with a given code as a string,
-// and a filename which is used for pretty-printing errors, if any
+// and a filename which is used for pretty-printing errors, if any.
+// The given options are applied in addition to the default options
 func PrepareChecker(
 	program *ast.Program,
 	location common.Location,
 	codes map[common.LocationID]string,
 	memberAccountAccess map[common.LocationID]map[common.LocationID]struct{},
 	must func(error),
+	options ...sema.Option,
 ) (*sema.Checker, func(error)) {
 	checker, err := sema.NewChecker(
 		program,
@@ -139,6 +141,10 @@ func PrepareChecker(
 	)
 	must(err)
 
+	for _, option := range options {
+		must(option(checker))
+	}
+
 	return checker, must
 }
 
@@ -68,6 +68,11 @@ func worker(jobs <-chan entry, wg *sync.WaitGroup, decoded *uint64) {
 			log.Fatal(err)
 		}
 
+		// Segments of large containers are decoded when the value they belong to is loaded
+		if interpreter.IsSegment(data) {
+			continue
+		}
+
 		var version uint16
 		data, version = interpreter.StripMagic(data)
 		if version == 0 {
@@ -95,6 +100,17 @@ func worker(jobs <-chan entry, wg *sync.WaitGroup, decoded *uint64) {
 		atomic.AddUint64(decoded, 1)
 
 		if *roundtripFlag {
+			// Values with segmented containers cannot be compared,
+			// as their segments are stored in separate registers
+			references, err := interpreter.SegmentReferences(data)
+			if err != nil {
+				log.Fatalf("failed to decode segment references: %s\n%s\n", err, e.Value)
+			}
+
+			if len(references) > 0 {
+				continue
+			}
+
 			reEncodeDecode(value, owner)
 		}
 	}
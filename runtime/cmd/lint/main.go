@@ -0,0 +1,315 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+// A utility program that type-checks Cadence programs
+// and runs static analyses on them, reporting the found problems
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime/debug"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/onflow/cadence/runtime/analysis"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/cmd"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/pretty"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+var jsonFlag = flag.Bool("json", false, "print the result formatted as JSON")
+var analyzersFlag = flag.String("analyzers", "", "comma-separated names of the analyzers to run (default: all)")
+var listFlag = flag.Bool("list", false, "list the available analyzers")
+
+func main() {
+	flag.Parse()
+
+	if *listFlag {
+		listAnalyzers()
+		return
+	}
+
+	analyzers, err := selectAnalyzers(*analyzersFlag)
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
+
+	args := flag.Args()
+	run(args, analyzers, *jsonFlag)
+}
+
+func listAnalyzers() {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, analyzer := range analysis.BuiltinAnalyzers {
+		_, err := fmt.Fprintf(writer, "%s\t%s\n", analyzer.Name, analyzer.Doc)
+		if err != nil {
+			panic(err)
+		}
+	}
+	err := writer.Flush()
+	if err != nil {
+		panic(err)
+	}
+}
+
+// selectAnalyzers returns the built-in analyzers with the given comma-separated names,
+// or all built-in analyzers if no names are given
+//
+func selectAnalyzers(names string) ([]*analysis.Analyzer, error) {
+	if names == "" {
+		return analysis.BuiltinAnalyzers, nil
+	}
+
+	analyzersByName := map[string]*analysis.Analyzer{}
+	for _, analyzer := range analysis.BuiltinAnalyzers {
+		analyzersByName[analyzer.Name] = analyzer
+	}
+
+	var analyzers []*analysis.Analyzer
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		analyzer, ok := analyzersByName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown analyzer: %s", name)
+		}
+		analyzers = append(analyzers, analyzer)
+	}
+
+	return analyzers, nil
+}
+
+type position struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func newPosition(pos ast.Position) position {
+	return position{
+		Offset: pos.Offset,
+		Line:   pos.Line,
+		Column: pos.Column,
+	}
+}
+
+type diagnostic struct {
+	Category         string   `json:"category"`
+	Severity         string   `json:"severity"`
+	Message          string   `json:"message"`
+	SecondaryMessage string   `json:"secondaryMessage,omitempty"`
+	StartPos         position `json:"startPos"`
+	EndPos           position `json:"endPos"`
+}
+
+type result struct {
+	Path        string       `json:"path"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+	Error       string       `json:"error,omitempty"`
+}
+
+type output interface {
+	Append(result)
+	End()
+}
+
+type jsonOutput struct {
+	results []result
+}
+
+func newJSONOutput(count int) *jsonOutput {
+	return &jsonOutput{
+		results: make([]result, 0, count),
+	}
+}
+
+func (j *jsonOutput) Append(r result) {
+	j.results = append(j.results, r)
+}
+
+func (j *jsonOutput) End() {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(j.results)
+	if err != nil {
+		panic(err)
+	}
+}
+
+type stdoutOutput struct{}
+
+func (s stdoutOutput) Append(r result) {
+	var err error
+
+	path := r.Path
+	if path == "" {
+		path = "<stdin>"
+	}
+
+	for _, diagnostic := range r.Diagnostics {
+		// Columns are printed starting at 1, like most editors expect
+		_, err = fmt.Printf(
+			"%s:%d:%d: %s: %s (%s)\n",
+			path,
+			diagnostic.StartPos.Line,
+			diagnostic.StartPos.Column+1,
+			diagnostic.Severity,
+			diagnostic.Message,
+			diagnostic.Category,
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		if diagnostic.SecondaryMessage != "" {
+			_, err = fmt.Printf("\t%s\n", diagnostic.SecondaryMessage)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	if len(r.Error) > 0 {
+		_, err = fmt.Printf("%s\n", r.Error)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (s stdoutOutput) End() {
+	// no-op
+}
+
+func run(paths []string, analyzers []*analysis.Analyzer, json bool) {
+	if len(paths) == 0 {
+		paths = []string{""}
+	}
+
+	var out output
+	if json {
+		out = newJSONOutput(len(paths))
+	} else {
+		out = stdoutOutput{}
+	}
+
+	useColor := !json
+
+	allSucceeded := true
+
+	for _, path := range paths {
+		res := runPath(path, analyzers, useColor)
+		if len(res.Diagnostics) > 0 || res.Error != "" {
+			allSucceeded = false
+		}
+		out.Append(res)
+	}
+
+	out.End()
+
+	if !allSucceeded {
+		os.Exit(1)
+	}
+}
+
+func runPath(path string, analyzers []*analysis.Analyzer, useColor bool) (res result) {
+	res = result{
+		Path:        path,
+		Diagnostics: []diagnostic{},
+	}
+
+	code := read(path)
+
+	codes := map[common.LocationID]string{}
+
+	location := common.StringLocation(path)
+
+	defer func() {
+		if r := recover(); r != nil {
+			res.Error = fmt.Sprintf("%s", debug.Stack())
+		}
+	}()
+
+	program, must := cmd.PrepareProgram(code, location, codes)
+
+	checker, _ := cmd.PrepareChecker(
+		program,
+		location,
+		codes,
+		nil,
+		must,
+		sema.WithPositionInfoEnabled(true),
+	)
+
+	err := checker.Check()
+	if err != nil {
+		var builder strings.Builder
+		printErr := pretty.NewErrorPrettyPrinter(&builder, useColor).
+			PrettyPrintError(err, location, codes)
+		if printErr != nil {
+			panic(printErr)
+		}
+		res.Error = builder.String()
+		return
+	}
+
+	diagnostics, err := analysis.Run(analyzers, analysis.ProgramFromChecker(checker))
+	if err != nil {
+		res.Error = err.Error()
+		return
+	}
+
+	for _, d := range diagnostics {
+		res.Diagnostics = append(
+			res.Diagnostics,
+			diagnostic{
+				Category:         d.Category,
+				Severity:         d.Severity.Name(),
+				Message:          d.Message,
+				SecondaryMessage: d.SecondaryMessage,
+				StartPos:         newPosition(d.StartPos),
+				EndPos:           newPosition(d.EndPos),
+			},
+		)
+	}
+
+	return
+}
+
+func read(path string) string {
+	var data []byte
+	var err error
+	if len(path) == 0 {
+		data, err = ioutil.ReadAll(bufio.NewReader(os.Stdin))
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,272 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// A utility program that parses a state dump in JSON Lines format
+// and reports the storage usage per account, domain, composite type, and contract
+
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/schollz/progressbar/v3"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+var gzipFlag = flag.Bool("gzip", false, "set true if input file is gzipped")
+var jsonFlag = flag.Bool("json", false, "print the report formatted as JSON")
+var topFlag = flag.Int("top", 10, "number of largest and deepest values to report")
+var rowsFlag = flag.Int("rows", 50, "maximum number of rows per table, 0 for no limit. ignored for JSON output")
+
+type keyPart struct {
+	Value string
+}
+
+type key struct {
+	KeyParts []keyPart
+}
+
+type entry struct {
+	Value string
+	Key   key
+}
+
+func (e entry) owner() common.Address {
+	rawOwner, err := hex.DecodeString(e.Key.KeyParts[1].Value)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return common.BytesToAddress(rawOwner)
+}
+
+func (e entry) key() string {
+	rawKey, err := hex.DecodeString(e.Key.KeyParts[2].Value)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return string(rawKey)
+}
+
+func (e entry) data() []byte {
+	data, err := hex.DecodeString(e.Value)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return data
+}
+
+// readEntries reads all entries of the state dump at the given path,
+// and calls the given function for each entry
+//
+func readEntries(path string, gzipped bool, description string, f func(entry)) {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	bar := progressbar.NewOptions64(
+		stat.Size(),
+		progressbar.OptionSetDescription(description),
+		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionShowBytes(true),
+	)
+
+	progressReader := progressbar.NewReader(file, bar)
+
+	var inputReader io.Reader = &progressReader
+	if gzipped {
+		gzipReader, err := gzip.NewReader(inputReader)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer gzipReader.Close()
+		inputReader = gzipReader
+	}
+
+	decoder := json.NewDecoder(bufio.NewReader(inputReader))
+	for {
+		var e entry
+
+		err = decoder.Decode(&e)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			log.Fatal(err)
+		}
+
+		f(e)
+	}
+
+	_, _ = fmt.Fprintln(os.Stderr)
+}
+
+func worker(segments *segments, jobs <-chan entry, results chan<- *measurement, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for e := range jobs {
+
+		data := e.data()
+
+		// Segments of large containers are measured as part of the value they belong to,
+		// they are loaded when the value is walked
+		if interpreter.IsSegment(data) {
+			continue
+		}
+
+		size := len(data)
+
+		var version uint16
+		data, version = interpreter.StripMagic(data)
+		if version == 0 {
+			continue
+		}
+
+		owner := e.owner()
+		key := e.key()
+
+		segmentSizes := map[interpreter.SegmentID]int{}
+
+		var value interpreter.Value
+		var err error
+		if version <= 4 {
+			value, err = interpreter.DecodeValueV4(data, &owner, nil, version, nil)
+		} else {
+			value, err = interpreter.DecodeSegmentedValue(
+				data,
+				&owner,
+				[]string{key},
+				version,
+				nil,
+				segments.Loader(segmentSizes),
+			)
+		}
+		if err != nil {
+			log.Fatalf("failed to decode value: %s\n%s\n", err, e.Value)
+		}
+
+		// Segments are loaded while measuring, loading a missing segment panics
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Fatalf("failed to load segments: %s\n%s\n", r, e.Value)
+				}
+			}()
+
+			m := measure(owner, key, size, value)
+			m.addSegments(segmentSizes)
+
+			results <- m
+		}()
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		panic("missing path argument")
+	}
+
+	path := args[0]
+
+	// Load the segments of large containers,
+	// so they can be measured as part of the value they belong to
+
+	segments := newSegments()
+
+	readEntries(path, *gzipFlag, "(loading segments)", func(e entry) {
+		segments.Add(e.owner(), e.key(), e.data())
+	})
+
+	jobs := make(chan entry)
+	results := make(chan *measurement)
+
+	var wg sync.WaitGroup
+
+	workerCount := runtime.NumCPU()
+
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go worker(segments, jobs, results, &wg)
+	}
+
+	agg := newAggregator(*topFlag)
+
+	aggregated := make(chan struct{})
+
+	go func() {
+		for m := range results {
+			agg.Add(m)
+		}
+		close(aggregated)
+	}()
+
+	readEntries(path, *gzipFlag, "(measuring values)", func(e entry) {
+		jobs <- e
+	})
+
+	close(jobs)
+
+	wg.Wait()
+
+	// Segments which are not referenced by any stored value
+	// still occupy registers, so measure them on their own
+
+	segments.Unloaded(func(owner common.Address, key string, _ interpreter.SegmentID, data []byte) {
+		results <- measure(owner, key, len(data), nil)
+	})
+
+	close(results)
+
+	<-aggregated
+
+	r := agg.Report()
+
+	var err error
+	if *jsonFlag {
+		err = r.WriteJSON(os.Stdout)
+	} else {
+		err = r.WriteTable(os.Stdout, *rowsFlag)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"sync"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// storedValue identifies a value stored in a register
+//
+type storedValue struct {
+	owner common.Address
+	key   string
+}
+
+// segments are the segments of the large containers in a state dump,
+// grouped by the stored value they belong to.
+//
+// Segments are only added while the dump is read,
+// after that they may be loaded concurrently.
+// Segments which were loaded are recorded,
+// so the segments which no stored value references can be measured separately
+//
+type segments struct {
+	data   map[storedValue]map[interpreter.SegmentID][]byte
+	mutex  sync.Mutex
+	loaded map[storedValue]map[interpreter.SegmentID]struct{}
+}
+
+func newSegments() *segments {
+	return &segments{
+		data:   map[storedValue]map[interpreter.SegmentID][]byte{},
+		loaded: map[storedValue]map[interpreter.SegmentID]struct{}{},
+	}
+}
+
+// Add adds the given register data if the register stores a segment,
+// and returns true if it does
+//
+func (s *segments) Add(owner common.Address, registerKey string, data []byte) bool {
+	if !interpreter.IsSegment(data) {
+		return false
+	}
+
+	key, id, ok := runtime.ParseSegmentKey(registerKey)
+	if !ok {
+		return false
+	}
+
+	value := storedValue{
+		owner: owner,
+		key:   key,
+	}
+
+	valueSegments, ok := s.data[value]
+	if !ok {
+		valueSegments = map[interpreter.SegmentID][]byte{}
+		s.data[value] = valueSegments
+	}
+
+	valueSegments[id] = data
+
+	return true
+}
+
+// Loader returns a segment loader for a single stored value,
+// which records the sizes of the loaded segments in the given map.
+//
+// The data of a missing segment is nil, which is reported when the segment is decoded
+//
+func (s *segments) Loader(sizes map[interpreter.SegmentID]int) interpreter.SegmentLoader {
+	return func(owner common.Address, key string, id interpreter.SegmentID) ([]byte, error) {
+		value := storedValue{
+			owner: owner,
+			key:   key,
+		}
+
+		data, ok := s.data[value][id]
+		if !ok {
+			return nil, nil
+		}
+
+		sizes[id] = len(data)
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		loadedSegments, ok := s.loaded[value]
+		if !ok {
+			loadedSegments = map[interpreter.SegmentID]struct{}{}
+			s.loaded[value] = loadedSegments
+		}
+		loadedSegments[id] = struct{}{}
+
+		return data, nil
+	}
+}
+
+// Unloaded calls the given function for each segment which was not loaded
+//
+func (s *segments) Unloaded(f func(owner common.Address, key string, id interpreter.SegmentID, data []byte)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for value, valueSegments := range s.data {
+		for id, data := range valueSegments {
+			if _, ok := s.loaded[value][id]; ok {
+				continue
+			}
+
+			f(value.owner, value.key, id, data)
+		}
+	}
+}
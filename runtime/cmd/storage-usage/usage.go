@@ -0,0 +1,438 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// keySeparator separates the parts of a storage key, e.g. the domain and the identifier.
+//
+// \x1F = Information Separator One
+//
+const keySeparator = "\x1F"
+
+// measurement is the usage of a single stored value, i.e. a single register
+//
+type measurement struct {
+	Owner common.Address
+	Key   string
+	// Size is the number of bytes of the encoded value, including the magic prefix,
+	// and including the segments of the large containers in the value
+	Size int
+	// Registers is the number of registers which store the value,
+	// i.e. the register of the value itself and the registers of its segments
+	Registers int
+	// Values is the number of values in the value graph, including the stored value itself
+	Values int
+	// Depth is the maximum nesting depth of the value graph.
+	// A value without children has depth 1
+	Depth int
+	// TypeID is the type ID of the stored value, if it is a composite
+	TypeID common.TypeID
+	// Composites is the number of composite values of each type in the value graph
+	Composites map[common.TypeID]int
+	// Contracts maps the type IDs in Composites to the contract that declares them
+	Contracts map[common.TypeID]string
+}
+
+// Domain returns the storage domain of the measured value, e.g. `storage`, `public`, or `contract`.
+// It is the first part of the key.
+//
+func (m *measurement) Domain() string {
+	return strings.SplitN(m.Key, keySeparator, 2)[0]
+}
+
+type measuringWalker struct {
+	measurement *measurement
+	depth       int
+}
+
+func (w measuringWalker) WalkValue(value interpreter.Value) interpreter.ValueWalker {
+	if value == nil {
+		return nil
+	}
+
+	m := w.measurement
+
+	m.Values++
+
+	depth := w.depth + 1
+	if depth > m.Depth {
+		m.Depth = depth
+	}
+
+	switch value := value.(type) {
+	case *interpreter.CompositeValue:
+		typeID := value.TypeID()
+		m.Composites[typeID]++
+		if _, ok := m.Contracts[typeID]; !ok {
+			m.Contracts[typeID] = contractOf(value.Location(), value.QualifiedIdentifier())
+		}
+	}
+
+	return measuringWalker{
+		measurement: m,
+		depth:       depth,
+	}
+}
+
+// contractOf returns the identifier of the contract that declares
+// the composite type with the given location and qualified identifier.
+//
+// The contract is the outermost declaration of the qualified identifier,
+// e.g. the contract of `A.0000000000000001.FlowToken.Vault` is `A.0000000000000001.FlowToken`.
+//
+func contractOf(location common.Location, qualifiedIdentifier string) string {
+	contractName := strings.SplitN(qualifiedIdentifier, ".", 2)[0]
+	if location == nil {
+		return contractName
+	}
+	return string(location.TypeID(contractName))
+}
+
+// measure walks the given value and returns its usage.
+// The size is the size of the value's encoding.
+//
+// The segments of large containers in the value are loaded when the value is walked,
+// their sizes must be added with addSegments
+//
+func measure(owner common.Address, key string, size int, value interpreter.Value) *measurement {
+	m := &measurement{
+		Owner:      owner,
+		Key:        key,
+		Size:       size,
+		Registers:  1,
+		Composites: map[common.TypeID]int{},
+		Contracts:  map[common.TypeID]string{},
+	}
+
+	if composite, ok := value.(*interpreter.CompositeValue); ok {
+		m.TypeID = composite.TypeID()
+	}
+
+	interpreter.WalkValue(measuringWalker{measurement: m}, value)
+
+	return m
+}
+
+// addSegments adds the segments with the given sizes,
+// which were loaded when the value was measured
+//
+func (m *measurement) addSegments(sizes map[interpreter.SegmentID]int) {
+	for _, size := range sizes {
+		m.Size += size
+		m.Registers++
+	}
+}
+
+// usage is the aggregated usage of a group of stored values
+//
+type usage struct {
+	Name string `json:"name"`
+	// Bytes is the total size of the stored values
+	Bytes int `json:"bytes"`
+	// Registers is the number of registers storing the values, including the segments of large containers
+	Registers int `json:"registers"`
+	// Values is the total number of values in the stored values
+	Values int `json:"values"`
+}
+
+// typeUsage is the aggregated usage of a composite type or a contract.
+//
+// Bytes and Registers only account for the stored values of which the composite is the root value,
+// as nested values are not stored separately.
+//
+type typeUsage struct {
+	usage
+	// Instances is the number of composite values, including nested values
+	Instances int `json:"instances"`
+}
+
+// valueInfo describes a single stored value
+//
+type valueInfo struct {
+	Owner  string `json:"owner"`
+	Key    string `json:"key"`
+	TypeID string `json:"typeID,omitempty"`
+	Bytes  int    `json:"bytes"`
+	Values int    `json:"values"`
+	Depth  int    `json:"depth"`
+}
+
+type report struct {
+	Total     usage       `json:"total"`
+	Accounts  []usage     `json:"accounts"`
+	Domains   []usage     `json:"domains"`
+	Types     []typeUsage `json:"types"`
+	Contracts []typeUsage `json:"contracts"`
+	Largest   []valueInfo `json:"largest"`
+	Deepest   []valueInfo `json:"deepest"`
+}
+
+// aggregator aggregates measurements into a report.
+// It is not safe for concurrent use.
+//
+type aggregator struct {
+	top       int
+	total     usage
+	accounts  map[common.Address]*usage
+	domains   map[string]*usage
+	types     map[common.TypeID]*typeUsage
+	contracts map[string]*typeUsage
+	largest   []valueInfo
+	deepest   []valueInfo
+}
+
+func newAggregator(top int) *aggregator {
+	return &aggregator{
+		top:       top,
+		total:     usage{Name: "total"},
+		accounts:  map[common.Address]*usage{},
+		domains:   map[string]*usage{},
+		types:     map[common.TypeID]*typeUsage{},
+		contracts: map[string]*typeUsage{},
+	}
+}
+
+func (u *usage) add(m *measurement) {
+	u.Bytes += m.Size
+	u.Registers += m.Registers
+	u.Values += m.Values
+}
+
+func (a *aggregator) Add(m *measurement) {
+
+	a.total.add(m)
+
+	accountUsage, ok := a.accounts[m.Owner]
+	if !ok {
+		accountUsage = &usage{Name: m.Owner.ShortHexWithPrefix()}
+		a.accounts[m.Owner] = accountUsage
+	}
+	accountUsage.add(m)
+
+	domain := m.Domain()
+	domainUsage, ok := a.domains[domain]
+	if !ok {
+		domainUsage = &usage{Name: domain}
+		a.domains[domain] = domainUsage
+	}
+	domainUsage.add(m)
+
+	// Count the instances of all composites in the value graph,
+	// but only attribute the stored value to the type and contract of the root value
+
+	for typeID, count := range m.Composites {
+		a.typeUsage(typeID).Instances += count
+		a.contractUsage(m.Contracts[typeID]).Instances += count
+	}
+
+	if m.TypeID != "" {
+		a.typeUsage(m.TypeID).add(m)
+		a.contractUsage(m.Contracts[m.TypeID]).add(m)
+	}
+
+	info := valueInfo{
+		Owner:  m.Owner.ShortHexWithPrefix(),
+		Key:    strings.ReplaceAll(m.Key, keySeparator, "/"),
+		TypeID: string(m.TypeID),
+		Bytes:  m.Size,
+		Values: m.Values,
+		Depth:  m.Depth,
+	}
+
+	a.largest = insertTop(a.largest, info, a.top, func(a, b valueInfo) bool {
+		return a.Bytes > b.Bytes
+	})
+
+	a.deepest = insertTop(a.deepest, info, a.top, func(a, b valueInfo) bool {
+		return a.Depth > b.Depth
+	})
+}
+
+func (a *aggregator) typeUsage(typeID common.TypeID) *typeUsage {
+	result, ok := a.types[typeID]
+	if !ok {
+		result = &typeUsage{usage: usage{Name: string(typeID)}}
+		a.types[typeID] = result
+	}
+	return result
+}
+
+func (a *aggregator) contractUsage(contract string) *typeUsage {
+	result, ok := a.contracts[contract]
+	if !ok {
+		result = &typeUsage{usage: usage{Name: contract}}
+		a.contracts[contract] = result
+	}
+	return result
+}
+
+// insertTop inserts the given info into the given list, which is sorted according to the given order,
+// and only keeps the top n entries
+//
+func insertTop(infos []valueInfo, info valueInfo, n int, before func(a, b valueInfo) bool) []valueInfo {
+	if n <= 0 {
+		return infos
+	}
+
+	index := sort.Search(len(infos), func(i int) bool {
+		return before(info, infos[i])
+	})
+
+	if index >= n {
+		return infos
+	}
+
+	infos = append(infos, valueInfo{})
+	copy(infos[index+1:], infos[index:])
+	infos[index] = info
+
+	if len(infos) > n {
+		infos = infos[:n]
+	}
+
+	return infos
+}
+
+// Report returns the aggregated report.
+// All groups are sorted by size in descending order
+//
+func (a *aggregator) Report() report {
+	r := report{
+		Total:   a.total,
+		Largest: a.largest,
+		Deepest: a.deepest,
+	}
+
+	for _, accountUsage := range a.accounts {
+		r.Accounts = append(r.Accounts, *accountUsage)
+	}
+	sortUsages(r.Accounts)
+
+	for _, domainUsage := range a.domains {
+		r.Domains = append(r.Domains, *domainUsage)
+	}
+	sortUsages(r.Domains)
+
+	for _, typeUsage := range a.types {
+		r.Types = append(r.Types, *typeUsage)
+	}
+	sortTypeUsages(r.Types)
+
+	for _, contractUsage := range a.contracts {
+		r.Contracts = append(r.Contracts, *contractUsage)
+	}
+	sortTypeUsages(r.Contracts)
+
+	return r
+}
+
+func usageBefore(a, b usage) bool {
+	if a.Bytes != b.Bytes {
+		return a.Bytes > b.Bytes
+	}
+	return a.Name < b.Name
+}
+
+func sortUsages(usages []usage) {
+	sort.Slice(usages, func(i, j int) bool {
+		return usageBefore(usages[i], usages[j])
+	})
+}
+
+func sortTypeUsages(usages []typeUsage) {
+	sort.Slice(usages, func(i, j int) bool {
+		a := usages[i]
+		b := usages[j]
+		if a.Bytes != b.Bytes {
+			return a.Bytes > b.Bytes
+		}
+		if a.Instances != b.Instances {
+			return a.Instances > b.Instances
+		}
+		return a.Name < b.Name
+	})
+}
+
+func (r report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteTable writes the report as tables.
+// Each group is limited to the given number of rows
+//
+func (r report) WriteTable(w io.Writer, rows int) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	limit := func(n int) int {
+		if rows > 0 && n > rows {
+			return rows
+		}
+		return n
+	}
+
+	writeUsages := func(title string, usages []usage) {
+		_, _ = fmt.Fprintf(writer, "%s\tbytes\tregisters\tvalues\t\n", title)
+		for _, u := range usages[:limit(len(usages))] {
+			_, _ = fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t\n", u.Name, u.Bytes, u.Registers, u.Values)
+		}
+		_, _ = fmt.Fprintln(writer, "\t\t\t\t")
+	}
+
+	writeTypeUsages := func(title string, usages []typeUsage) {
+		_, _ = fmt.Fprintf(writer, "%s\tbytes\tregisters\tvalues\tinstances\t\n", title)
+		for _, u := range usages[:limit(len(usages))] {
+			_, _ = fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t%d\t\n", u.Name, u.Bytes, u.Registers, u.Values, u.Instances)
+		}
+		_, _ = fmt.Fprintln(writer, "\t\t\t\t\t")
+	}
+
+	writeValueInfos := func(title string, infos []valueInfo) {
+		_, _ = fmt.Fprintf(writer, "%s\tkey\ttype\tbytes\tvalues\tdepth\t\n", title)
+		for _, info := range infos {
+			_, _ = fmt.Fprintf(writer,
+				"%s\t%s\t%s\t%d\t%d\t%d\t\n",
+				info.Owner, info.Key, info.TypeID, info.Bytes, info.Values, info.Depth,
+			)
+		}
+		_, _ = fmt.Fprintln(writer, "\t\t\t\t\t\t")
+	}
+
+	writeUsages("total", []usage{r.Total})
+	writeUsages("account", r.Accounts)
+	writeUsages("domain", r.Domains)
+	writeTypeUsages("type", r.Types)
+	writeTypeUsages("contract", r.Contracts)
+	writeValueInfos("largest", r.Largest)
+	writeValueInfos("deepest", r.Deepest)
+
+	return writer.Flush()
+}
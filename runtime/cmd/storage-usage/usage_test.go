@@ -0,0 +1,273 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+func newTestComposite(location common.Location, qualifiedIdentifier string, fields map[string]interpreter.Value) *interpreter.CompositeValue {
+	orderedFields := interpreter.NewStringValueOrderedMap()
+	for name, value := range fields {
+		orderedFields.Set(name, value)
+	}
+
+	return interpreter.NewCompositeValue(
+		location,
+		qualifiedIdentifier,
+		common.CompositeKindResource,
+		orderedFields,
+		nil,
+	)
+}
+
+func TestMeasure(t *testing.T) {
+
+	t.Parallel()
+
+	location := common.AddressLocation{
+		Address: common.BytesToAddress([]byte{0x1}),
+		Name:    "Test",
+	}
+
+	nft := newTestComposite(location, "Test.NFT", map[string]interpreter.Value{
+		"id": interpreter.NewIntValueFromInt64(1),
+	})
+
+	collection := newTestComposite(location, "Test.Collection", map[string]interpreter.Value{
+		"nfts": interpreter.NewArrayValueUnownedNonCopying(
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.CompositeStaticType{
+					Location:            location,
+					QualifiedIdentifier: "Test.NFT",
+				},
+			},
+			nft,
+		),
+	})
+
+	owner := common.BytesToAddress([]byte{0x2})
+
+	m := measure(owner, "storage\x1Fcollection", 42, collection)
+
+	assert.Equal(t, "storage", m.Domain())
+	assert.Equal(t, 42, m.Size)
+	// collection, array, NFT, ID
+	assert.Equal(t, 4, m.Values)
+	assert.Equal(t, 4, m.Depth)
+	assert.Equal(t, common.TypeID("A.0000000000000001.Test.Collection"), m.TypeID)
+	assert.Equal(t,
+		map[common.TypeID]int{
+			"A.0000000000000001.Test.Collection": 1,
+			"A.0000000000000001.Test.NFT":        1,
+		},
+		m.Composites,
+	)
+	assert.Equal(t,
+		map[common.TypeID]string{
+			"A.0000000000000001.Test.Collection": "A.0000000000000001.Test",
+			"A.0000000000000001.Test.NFT":        "A.0000000000000001.Test",
+		},
+		m.Contracts,
+	)
+}
+
+func TestMeasureSegmented(t *testing.T) {
+
+	t.Parallel()
+
+	elements := make([]interpreter.Value, 5000)
+	for i := range elements {
+		elements[i] = interpreter.NewStringValue("element")
+	}
+
+	array := interpreter.NewArrayValueUnownedNonCopying(
+		interpreter.VariableSizedStaticType{
+			Type: interpreter.PrimitiveStaticTypeString,
+		},
+		elements...,
+	)
+
+	const key = "storage\x1Farray"
+
+	data, deferrals, err := interpreter.EncodeValue(array, []string{key}, true, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, deferrals.Segments)
+
+	owner := common.BytesToAddress([]byte{0x2})
+
+	segments := newSegments()
+
+	// Segments are content-addressed, so equal segments are only stored once
+
+	segmentsSize := 0
+	segmentCount := 0
+	for _, segment := range deferrals.Segments {
+		registerKey := key + "\x1Fsegment\x1F" + segment.ID.String()
+		if _, ok := segments.data[storedValue{owner: owner, key: key}][segment.ID]; !ok {
+			segmentsSize += len(segment.Data)
+			segmentCount++
+		}
+		require.True(t, segments.Add(owner, registerKey, segment.Data))
+	}
+
+	// A segment which is not referenced by the value
+
+	unreferencedData := append([]byte{}, deferrals.Segments[0].Data...)
+	unreferencedData = append(unreferencedData, 0x0)
+	unreferencedID := interpreter.SegmentID{0x1}
+	require.True(t, segments.Add(owner, key+"\x1Fsegment\x1F"+unreferencedID.String(), unreferencedData))
+
+	segmentSizes := map[interpreter.SegmentID]int{}
+
+	value, err := interpreter.DecodeSegmentedValue(
+		data,
+		&owner,
+		[]string{key},
+		interpreter.CurrentEncodingVersion,
+		nil,
+		segments.Loader(segmentSizes),
+	)
+	require.NoError(t, err)
+
+	// The elements of the segmented array are walked,
+	// and the segments are measured as part of the array
+
+	m := measure(owner, key, len(data), value)
+	m.addSegments(segmentSizes)
+
+	assert.Equal(t, 5001, m.Values)
+	assert.Equal(t, 2, m.Depth)
+	assert.Equal(t, 1+segmentCount, m.Registers)
+	assert.Equal(t, len(data)+segmentsSize, m.Size)
+
+	var unloaded []interpreter.SegmentID
+	segments.Unloaded(func(_ common.Address, _ string, id interpreter.SegmentID, _ []byte) {
+		unloaded = append(unloaded, id)
+	})
+	assert.Equal(t, []interpreter.SegmentID{unreferencedID}, unloaded)
+}
+
+func TestAggregator(t *testing.T) {
+
+	t.Parallel()
+
+	location := common.AddressLocation{
+		Address: common.BytesToAddress([]byte{0x1}),
+		Name:    "Test",
+	}
+
+	owner1 := common.BytesToAddress([]byte{0x1})
+	owner2 := common.BytesToAddress([]byte{0x2})
+
+	agg := newAggregator(2)
+
+	agg.Add(measure(
+		owner1,
+		"contract\x1FTest",
+		100,
+		newTestComposite(location, "Test", nil),
+	))
+
+	agg.Add(measure(
+		owner2,
+		"storage\x1Fr1",
+		20,
+		newTestComposite(location, "Test.R", map[string]interpreter.Value{
+			"nested": newTestComposite(location, "Test.R", nil),
+		}),
+	))
+
+	agg.Add(measure(
+		owner2,
+		"storage\x1Fstring",
+		10,
+		interpreter.NewStringValue("test"),
+	))
+
+	r := agg.Report()
+
+	assert.Equal(t,
+		usage{Name: "total", Bytes: 130, Registers: 3, Values: 4},
+		r.Total,
+	)
+
+	assert.Equal(t,
+		[]usage{
+			{Name: "0x1", Bytes: 100, Registers: 1, Values: 1},
+			{Name: "0x2", Bytes: 30, Registers: 2, Values: 3},
+		},
+		r.Accounts,
+	)
+
+	assert.Equal(t,
+		[]usage{
+			{Name: "contract", Bytes: 100, Registers: 1, Values: 1},
+			{Name: "storage", Bytes: 30, Registers: 2, Values: 3},
+		},
+		r.Domains,
+	)
+
+	assert.Equal(t,
+		[]typeUsage{
+			{
+				usage:     usage{Name: "A.0000000000000001.Test", Bytes: 100, Registers: 1, Values: 1},
+				Instances: 1,
+			},
+			{
+				usage:     usage{Name: "A.0000000000000001.Test.R", Bytes: 20, Registers: 1, Values: 2},
+				Instances: 2,
+			},
+		},
+		r.Types,
+	)
+
+	assert.Equal(t,
+		[]typeUsage{
+			{
+				usage:     usage{Name: "A.0000000000000001.Test", Bytes: 120, Registers: 2, Values: 3},
+				Instances: 3,
+			},
+		},
+		r.Contracts,
+	)
+
+	require.Len(t, r.Largest, 2)
+	assert.Equal(t, "contract/Test", r.Largest[0].Key)
+	assert.Equal(t, "storage/r1", r.Largest[1].Key)
+
+	require.Len(t, r.Deepest, 2)
+	assert.Equal(t, "storage/r1", r.Deepest[0].Key)
+	assert.Equal(t, 2, r.Deepest[0].Depth)
+
+	var buffer bytes.Buffer
+	require.NoError(t, r.WriteTable(&buffer, 0))
+	assert.Contains(t, buffer.String(), "A.0000000000000001.Test.R")
+
+	buffer.Reset()
+	require.NoError(t, r.WriteJSON(&buffer))
+	assert.Contains(t, buffer.String(), `"instances": 2`)
+}
@@ -222,6 +222,11 @@ func (compiler *Compiler) VisitBinaryExpression(expression *ast.BinaryExpression
 	}
 }
 
+func (compiler *Compiler) VisitRangeExpression(_ *ast.RangeExpression) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func (compiler *Compiler) VisitFunctionExpression(_ *ast.FunctionExpression) ast.Repr {
 	// TODO
 	panic(errors.NewUnreachableError())
@@ -235,6 +240,11 @@ func (compiler *Compiler) VisitStringExpression(e *ast.StringExpression) ast.Rep
 	}
 }
 
+func (compiler *Compiler) VisitStringTemplateExpression(_ *ast.StringTemplateExpression) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func (compiler *Compiler) VisitCastingExpression(_ *ast.CastingExpression) ast.Repr {
 	// TODO
 	panic(errors.NewUnreachableError())
@@ -377,6 +387,16 @@ func (compiler *Compiler) VisitEnumCaseDeclaration(_ *ast.EnumCaseDeclaration) a
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitTypeAliasDeclaration(_ *ast.TypeAliasDeclaration) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
+func (compiler *Compiler) VisitExtensionDeclaration(_ *ast.ExtensionDeclaration) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func compileBinaryOperation(operation ast.Operation) ir.BinOp {
 	// TODO: add remaining operations
 	switch operation {
@@ -19,6 +19,8 @@
 package runtime
 
 import (
+	"context"
+
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 )
@@ -27,8 +29,12 @@ type Context struct {
 	Interface         Interface
 	Location          Location
 	PredeclaredValues []ValueDeclaration
-	codes             map[common.LocationID]string
-	programs          map[common.LocationID]*ast.Program
+	// ExecutionContext is the optional context of the execution.
+	// When it is cancelled, or its deadline is exceeded,
+	// the execution is aborted with an interpreter.ExecutionCancelledError
+	ExecutionContext context.Context
+	codes            map[common.LocationID]string
+	programs         map[common.LocationID]*ast.Program
 }
 
 func (c Context) SetCode(location common.Location, code string) {
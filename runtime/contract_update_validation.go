@@ -25,13 +25,26 @@ import (
 )
 
 type ContractUpdateValidator struct {
-	location     Location
-	contractName string
-	oldProgram   *ast.Program
-	newProgram   *ast.Program
-	rootDecl     ast.Declaration
-	currentDecl  ast.Declaration
-	errors       []error
+	location       Location
+	contractName   string
+	oldProgram     *ast.Program
+	newProgram     *ast.Program
+	rootDecl       ast.Declaration
+	currentDecl    ast.Declaration
+	oldTypeAliases typeAliases
+	newTypeAliases typeAliases
+	errors         []error
+}
+
+// typeAliases are the target types of the type aliases declared in a program,
+// by name
+//
+type typeAliases struct {
+	// topLevel are the type aliases declared at the top-level of the program,
+	// and in the root declaration
+	topLevel map[string]ast.Type
+	// nested are the type aliases declared in the root declaration
+	nested map[string]ast.Type
 }
 
 // ContractUpdateValidator should implement ast.TypeEqualityChecker
@@ -67,6 +80,9 @@ func (validator *ContractUpdateValidator) Validate() error {
 	}
 
 	validator.rootDecl = newRootDecl
+	validator.oldTypeAliases = getTypeAliases(validator.oldProgram, oldRootDecl)
+	validator.newTypeAliases = getTypeAliases(validator.newProgram, newRootDecl)
+
 	validator.checkDeclarationUpdatability(oldRootDecl, newRootDecl)
 
 	if validator.hasErrors() {
@@ -105,6 +121,24 @@ func getRootDeclaration(program *ast.Program) (ast.Declaration, error) {
 
 }
 
+func getTypeAliases(program *ast.Program, rootDeclaration ast.Declaration) typeAliases {
+	result := typeAliases{
+		topLevel: map[string]ast.Type{},
+		nested:   map[string]ast.Type{},
+	}
+
+	for _, declaration := range program.TypeAliasDeclarations() {
+		result.topLevel[declaration.Identifier.Identifier] = declaration.TypeAnnotation.Type
+	}
+
+	for _, declaration := range rootDeclaration.DeclarationMembers().TypeAliases() {
+		result.topLevel[declaration.Identifier.Identifier] = declaration.TypeAnnotation.Type
+		result.nested[declaration.Identifier.Identifier] = declaration.TypeAnnotation.Type
+	}
+
+	return result
+}
+
 func (validator *ContractUpdateValidator) hasErrors() bool {
 	return len(validator.errors) > 0
 }
@@ -141,6 +175,7 @@ func (validator *ContractUpdateValidator) checkDeclarationUpdatability(
 	if newDecl, ok := newDeclaration.(*ast.CompositeDeclaration); ok {
 		if oldDecl, ok := oldDeclaration.(*ast.CompositeDeclaration); ok {
 			validator.checkConformances(oldDecl, newDecl)
+			validator.checkTypeParameters(oldDecl, newDecl)
 		}
 	}
 }
@@ -293,7 +328,17 @@ func (validator *ContractUpdateValidator) checkEnumCases(oldDeclaration ast.Decl
 }
 
 func (validator *ContractUpdateValidator) CheckNominalTypeEquality(expected *ast.NominalType, found ast.Type) error {
-	foundNominalType, ok := found.(*ast.NominalType)
+
+	// An alias and its target are equal.
+	// If the expected type refers to a type alias of the old program,
+	// compare the target type of the type alias instead
+
+	expectedTargetType := validator.resolveTypeAlias(expected, validator.oldTypeAliases)
+	if expectedTargetType != expected {
+		return expectedTargetType.CheckEqual(found, validator)
+	}
+
+	foundNominalType, ok := validator.resolveNewTypeAlias(found).(*ast.NominalType)
 	if !ok {
 		return getTypeMismatchError(expected, found)
 	}
@@ -308,7 +353,7 @@ func (validator *ContractUpdateValidator) CheckNominalTypeEquality(expected *ast
 }
 
 func (validator *ContractUpdateValidator) CheckOptionalTypeEquality(expected *ast.OptionalType, found ast.Type) error {
-	foundOptionalType, ok := found.(*ast.OptionalType)
+	foundOptionalType, ok := validator.resolveNewTypeAlias(found).(*ast.OptionalType)
 	if !ok {
 		return getTypeMismatchError(expected, found)
 	}
@@ -317,7 +362,7 @@ func (validator *ContractUpdateValidator) CheckOptionalTypeEquality(expected *as
 }
 
 func (validator *ContractUpdateValidator) CheckVariableSizedTypeEquality(expected *ast.VariableSizedType, found ast.Type) error {
-	foundVarSizedType, ok := found.(*ast.VariableSizedType)
+	foundVarSizedType, ok := validator.resolveNewTypeAlias(found).(*ast.VariableSizedType)
 	if !ok {
 		return getTypeMismatchError(expected, found)
 	}
@@ -326,7 +371,7 @@ func (validator *ContractUpdateValidator) CheckVariableSizedTypeEquality(expecte
 }
 
 func (validator *ContractUpdateValidator) CheckConstantSizedTypeEquality(expected *ast.ConstantSizedType, found ast.Type) error {
-	foundConstSizedType, ok := found.(*ast.ConstantSizedType)
+	foundConstSizedType, ok := validator.resolveNewTypeAlias(found).(*ast.ConstantSizedType)
 	if !ok {
 		return getTypeMismatchError(expected, found)
 	}
@@ -342,7 +387,7 @@ func (validator *ContractUpdateValidator) CheckConstantSizedTypeEquality(expecte
 }
 
 func (validator *ContractUpdateValidator) CheckDictionaryTypeEquality(expected *ast.DictionaryType, found ast.Type) error {
-	foundDictionaryType, ok := found.(*ast.DictionaryType)
+	foundDictionaryType, ok := validator.resolveNewTypeAlias(found).(*ast.DictionaryType)
 	if !ok {
 		return getTypeMismatchError(expected, found)
 	}
@@ -356,7 +401,7 @@ func (validator *ContractUpdateValidator) CheckDictionaryTypeEquality(expected *
 }
 
 func (validator *ContractUpdateValidator) CheckRestrictedTypeEquality(expected *ast.RestrictedType, found ast.Type) error {
-	foundRestrictedType, ok := found.(*ast.RestrictedType)
+	foundRestrictedType, ok := validator.resolveNewTypeAlias(found).(*ast.RestrictedType)
 	if !ok {
 		return getTypeMismatchError(expected, found)
 	}
@@ -395,7 +440,7 @@ func (validator *ContractUpdateValidator) CheckRestrictedTypeEquality(expected *
 }
 
 func (validator *ContractUpdateValidator) CheckInstantiationTypeEquality(expected *ast.InstantiationType, found ast.Type) error {
-	foundInstType, ok := found.(*ast.InstantiationType)
+	foundInstType, ok := validator.resolveNewTypeAlias(found).(*ast.InstantiationType)
 	if !ok {
 		return getTypeMismatchError(expected, found)
 	}
@@ -417,7 +462,7 @@ func (validator *ContractUpdateValidator) CheckInstantiationTypeEquality(expecte
 }
 
 func (validator *ContractUpdateValidator) CheckFunctionTypeEquality(expected *ast.FunctionType, found ast.Type) error {
-	foundFuncType, ok := found.(*ast.FunctionType)
+	foundFuncType, ok := validator.resolveNewTypeAlias(found).(*ast.FunctionType)
 	if !ok || len(expected.ParameterTypeAnnotations) != len(foundFuncType.ParameterTypeAnnotations) {
 		return getTypeMismatchError(expected, found)
 	}
@@ -434,7 +479,7 @@ func (validator *ContractUpdateValidator) CheckFunctionTypeEquality(expected *as
 }
 
 func (validator *ContractUpdateValidator) CheckReferenceTypeEquality(expected *ast.ReferenceType, found ast.Type) error {
-	refType, ok := found.(*ast.ReferenceType)
+	refType, ok := validator.resolveNewTypeAlias(found).(*ast.ReferenceType)
 	if !ok {
 		return getTypeMismatchError(expected, found)
 	}
@@ -442,6 +487,59 @@ func (validator *ContractUpdateValidator) CheckReferenceTypeEquality(expected *a
 	return expected.Type.CheckEqual(refType.Type, validator)
 }
 
+// resolveNewTypeAlias returns the target type of the given type,
+// if it refers to a type alias of the new program
+//
+func (validator *ContractUpdateValidator) resolveNewTypeAlias(ty ast.Type) ast.Type {
+	return validator.resolveTypeAlias(ty, validator.newTypeAliases)
+}
+
+// resolveTypeAlias returns the target type of the given type,
+// if it refers to one of the given type aliases, directly or indirectly.
+// Otherwise, the given type is returned.
+//
+// A type alias may either be referred to by its name,
+// or, if it is declared in the root declaration, by its qualified name
+//
+func (validator *ContractUpdateValidator) resolveTypeAlias(ty ast.Type, typeAliases typeAliases) ast.Type {
+
+	// NOTE: the number of resolutions is bounded by the number of type aliases,
+	// to guard against cyclic type aliases
+
+	for i := 0; i <= len(typeAliases.topLevel); i++ {
+
+		nominalType, ok := ty.(*ast.NominalType)
+		if !ok {
+			return ty
+		}
+
+		var targetType ast.Type
+
+		switch len(nominalType.NestedIdentifiers) {
+		case 0:
+			targetType, ok = typeAliases.topLevel[nominalType.Identifier.Identifier]
+
+		case 1:
+			if nominalType.Identifier.Identifier != validator.rootDecl.DeclarationIdentifier().Identifier {
+				return ty
+			}
+
+			targetType, ok = typeAliases.nested[nominalType.NestedIdentifiers[0].Identifier]
+
+		default:
+			return ty
+		}
+
+		if !ok {
+			return ty
+		}
+
+		ty = targetType
+	}
+
+	return ty
+}
+
 func (validator *ContractUpdateValidator) checkNameEquality(expectedType *ast.NominalType, foundType *ast.NominalType) bool {
 	isExpectedQualifiedName := expectedType.IsQualifiedName()
 	isFoundQualifiedName := foundType.IsQualifiedName()
@@ -522,6 +620,96 @@ func (validator *ContractUpdateValidator) checkConformances(
 	}
 }
 
+// checkTypeParameters validates updating the type parameters of a generic composite declaration.
+// Stored values of the composite type have the type arguments for the type parameters,
+// so the updated declaration must have the same type parameters, in the same order,
+// with the same type bounds.
+//
+func (validator *ContractUpdateValidator) checkTypeParameters(
+	oldDecl *ast.CompositeDeclaration,
+	newDecl *ast.CompositeDeclaration,
+) {
+
+	var oldTypeParameters, newTypeParameters []*ast.TypeParameter
+	if oldDecl.TypeParameterList != nil {
+		oldTypeParameters = oldDecl.TypeParameterList.TypeParameters
+	}
+	if newDecl.TypeParameterList != nil {
+		newTypeParameters = newDecl.TypeParameterList.TypeParameters
+	}
+
+	if len(oldTypeParameters) != len(newTypeParameters) {
+		validator.report(&TypeParameterCountMismatchError{
+			DeclName: newDecl.Identifier.Identifier,
+			Expected: len(oldTypeParameters),
+			Found:    len(newTypeParameters),
+			Range:    ast.NewRangeFromPositioned(newDecl.Identifier),
+		})
+
+		// If the lengths are not the same, trying to match the type parameters
+		// may result in too many regression errors. hence return.
+		return
+	}
+
+	for index, oldTypeParameter := range oldTypeParameters {
+		newTypeParameter := newTypeParameters[index]
+
+		if oldTypeParameter.Identifier.Identifier != newTypeParameter.Identifier.Identifier {
+			validator.report(&TypeParameterMismatchError{
+				DeclName:     newDecl.Identifier.Identifier,
+				ExpectedName: oldTypeParameter.Identifier.Identifier,
+				FoundName:    newTypeParameter.Identifier.Identifier,
+				Range:        ast.NewRangeFromPositioned(newTypeParameter.Identifier),
+			})
+
+			continue
+		}
+
+		err := validator.checkTypeParameterBoundEquality(oldTypeParameter, newTypeParameter)
+		if err != nil {
+			validator.report(&TypeParameterMismatchError{
+				DeclName:     newDecl.Identifier.Identifier,
+				ExpectedName: oldTypeParameter.Identifier.Identifier,
+				FoundName:    newTypeParameter.Identifier.Identifier,
+				Err:          err,
+				Range:        ast.NewRangeFromPositioned(newTypeParameter.Identifier),
+			})
+		}
+	}
+}
+
+// checkTypeParameterBoundEquality checks that the type bounds of the given type parameters are equal.
+// A type parameter without a type bound is bounded by `AnyStruct`.
+//
+func (validator *ContractUpdateValidator) checkTypeParameterBoundEquality(
+	oldTypeParameter *ast.TypeParameter,
+	newTypeParameter *ast.TypeParameter,
+) error {
+
+	oldTypeBound := oldTypeParameter.TypeBound
+	newTypeBound := newTypeParameter.TypeBound
+
+	switch {
+	case oldTypeBound == nil && newTypeBound == nil:
+		return nil
+
+	case oldTypeBound == nil:
+		if !isAnyStructType(newTypeBound.Type) {
+			return getTypeMismatchError(anyStructNominalType(newTypeBound.StartPos), newTypeBound.Type)
+		}
+		return nil
+
+	case newTypeBound == nil:
+		if !isAnyStructType(oldTypeBound.Type) {
+			return getTypeMismatchError(oldTypeBound.Type, anyStructNominalType(newTypeParameter.Identifier.EndPosition()))
+		}
+		return nil
+
+	default:
+		return oldTypeBound.Type.CheckEqual(newTypeBound.Type, validator)
+	}
+}
+
 func (validator *ContractUpdateValidator) report(err error) {
 	if err == nil {
 		return
@@ -577,6 +765,25 @@ func isAnyStructOrAnyResourceType(astType ast.Type) bool {
 	}
 }
 
+func isAnyStructType(astType ast.Type) bool {
+	nominalType, ok := astType.(*ast.NominalType)
+	if !ok {
+		return false
+	}
+
+	return nominalType.Identifier.Identifier == sema.AnyStructType.Name &&
+		len(nominalType.NestedIdentifiers) == 0
+}
+
+func anyStructNominalType(pos ast.Position) *ast.NominalType {
+	return &ast.NominalType{
+		Identifier: ast.Identifier{
+			Identifier: sema.AnyStructType.Name,
+			Pos:        pos,
+		},
+	}
+}
+
 func containsEnumsInProgram(program *ast.Program) bool {
 	declaration, err := getRootDeclaration(program)
 
@@ -1714,6 +1714,280 @@ func TestContractUpdateValidation(t *testing.T) {
 
 		assert.NoError(t, err)
 	})
+
+	t.Run("replace type with type alias", func(t *testing.T) {
+
+		const oldCode = `
+			pub contract Test37 {
+
+				pub var a: Capability<&{TestInterface, OtherInterface}>?
+				pub var b: [TestStruct]
+				pub var c: {String: Test37.TestStruct}
+
+				init() {
+					self.a = nil
+					self.b = []
+					self.c = {}
+				}
+
+				pub struct TestStruct: TestInterface, OtherInterface {}
+
+				pub struct interface TestInterface {}
+
+				pub struct interface OtherInterface {}
+			}`
+
+		const newCode = `
+			pub typealias Structs = [Test37.TestStruct]
+
+			pub contract Test37 {
+
+				pub typealias TestReference = &{TestInterface, OtherInterface}
+
+				pub typealias Struct = TestStruct
+
+				pub var a: Capability<TestReference>?
+				pub var b: Structs
+				pub var c: {String: Test37.Struct}
+
+				init() {
+					self.a = nil
+					self.b = []
+					self.c = {}
+				}
+
+				pub struct TestStruct: TestInterface, OtherInterface {}
+
+				pub struct interface TestInterface {}
+
+				pub struct interface OtherInterface {}
+			}`
+
+		err := deployAndUpdate("Test37", oldCode, newCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("replace type alias with target type", func(t *testing.T) {
+
+		const oldCode = `
+			pub contract Test38 {
+
+				pub typealias Numbers = [Int]
+
+				pub var a: Numbers
+
+				init() {
+					self.a = []
+				}
+			}`
+
+		const newCode = `
+			pub contract Test38 {
+
+				pub var a: [Int]
+
+				init() {
+					self.a = []
+				}
+			}`
+
+		err := deployAndUpdate("Test38", oldCode, newCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("change type alias target type", func(t *testing.T) {
+
+		const oldCode = `
+			pub contract Test39 {
+
+				pub typealias Numbers = [Int]
+
+				pub var a: Numbers
+
+				init() {
+					self.a = []
+				}
+			}`
+
+		const newCode = `
+			pub contract Test39 {
+
+				pub typealias Numbers = [String]
+
+				pub var a: Numbers
+
+				init() {
+					self.a = []
+				}
+			}`
+
+		err := deployAndUpdate("Test39", oldCode, newCode)
+		require.Error(t, err)
+
+		cause := getErrorCause(t, err, "Test39")
+		assertFieldTypeMismatchError(t, cause, "Test39", "a", "Int", "String")
+	})
+
+	t.Run("unchanged type parameters", func(t *testing.T) {
+
+		const oldCode = `
+			pub contract Test40 {
+
+				pub struct Box<T: AnyStruct> {
+					pub let value: T
+
+					init(value: T) {
+						self.value = value
+					}
+				}
+
+				pub var box: Box<Int>
+
+				init() {
+					self.box = Box(value: 1)
+				}
+			}`
+
+		const newCode = `
+			pub contract Test40 {
+
+				pub struct Box<T> {
+					pub let value: T
+
+					init(value: T) {
+						self.value = value
+					}
+
+					pub fun get(): T {
+						return self.value
+					}
+				}
+
+				pub var box: Box<Int>
+
+				init() {
+					self.box = Box(value: 1)
+				}
+			}`
+
+		err := deployAndUpdate("Test40", oldCode, newCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("add type parameter", func(t *testing.T) {
+
+		const oldCode = `
+			pub contract Test41 {
+
+				pub struct Box<T> {
+					pub let value: T
+
+					init(value: T) {
+						self.value = value
+					}
+				}
+			}`
+
+		const newCode = `
+			pub contract Test41 {
+
+				pub struct Box<T, U> {
+					pub let value: T
+
+					init(value: T) {
+						self.value = value
+					}
+				}
+			}`
+
+		err := deployAndUpdate("Test41", oldCode, newCode)
+		require.Error(t, err)
+
+		cause := getErrorCause(t, err, "Test41")
+		require.IsType(t, &TypeParameterCountMismatchError{}, cause)
+		assert.Equal(t,
+			"type parameter count does not match in `Box`: expected 1, found 2",
+			cause.Error(),
+		)
+	})
+
+	t.Run("rename type parameter", func(t *testing.T) {
+
+		const oldCode = `
+			pub contract Test42 {
+
+				pub struct Box<T> {
+					pub let value: T
+
+					init(value: T) {
+						self.value = value
+					}
+				}
+			}`
+
+		const newCode = `
+			pub contract Test42 {
+
+				pub struct Box<U> {
+					pub let value: U
+
+					init(value: U) {
+						self.value = value
+					}
+				}
+			}`
+
+		err := deployAndUpdate("Test42", oldCode, newCode)
+		require.Error(t, err)
+
+		updateErr := getContractUpdateError(t, err)
+		childErrors := updateErr.ChildErrors()
+		require.Equal(t, 2, len(childErrors))
+
+		assertFieldTypeMismatchError(t, childErrors[0], "Box", "value", "T", "U")
+
+		require.IsType(t, &TypeParameterMismatchError{}, childErrors[1])
+		assert.Equal(t,
+			"type parameters do not match in `Box`: expected `T`, found `U`",
+			childErrors[1].Error(),
+		)
+	})
+
+	t.Run("change type parameter bound", func(t *testing.T) {
+
+		const oldCode = `
+			pub contract Test43 {
+
+				pub struct Box<T> {
+					pub let value: T
+
+					init(value: T) {
+						self.value = value
+					}
+				}
+			}`
+
+		const newCode = `
+			pub contract Test43 {
+
+				pub struct Box<T: Integer> {
+					pub let value: T
+
+					init(value: T) {
+						self.value = value
+					}
+				}
+			}`
+
+		err := deployAndUpdate("Test43", oldCode, newCode)
+		require.Error(t, err)
+
+		cause := getErrorCause(t, err, "Test43")
+		require.IsType(t, &TypeParameterMismatchError{}, cause)
+
+		typeParameterMismatchError := cause.(*TypeParameterMismatchError)
+		assert.IsType(t, &TypeMismatchError{}, typeParameterMismatchError.Err)
+	})
 }
 
 func assertDeclTypeChangeError(
@@ -114,6 +114,10 @@ func ExportType(t sema.Type, results map[sema.TypeID]cadence.Type) cadence.Type
 			return cadence.Fix64Type{}
 		case sema.UFix64Type:
 			return cadence.UFix64Type{}
+		case sema.Fix128Type:
+			return cadence.Fix128Type{}
+		case sema.UFix128Type:
+			return cadence.UFix128Type{}
 		case sema.PathType:
 			return cadence.PathType{}
 		case sema.StoragePathType:
@@ -20,6 +20,7 @@ package runtime
 
 import (
 	"fmt"
+	goRuntime "runtime"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/common"
@@ -110,6 +111,10 @@ func exportValueWithInterpreter(
 		return cadence.Fix64(v), nil
 	case interpreter.UFix64Value:
 		return cadence.UFix64(v), nil
+	case interpreter.Fix128Value:
+		return cadence.NewFix128FromBig(v.BigInt)
+	case interpreter.UFix128Value:
+		return cadence.NewUFix128FromBig(v.BigInt)
 	case *interpreter.CompositeValue:
 		return exportCompositeValue(v, inter, seenReferences)
 	case *interpreter.DictionaryValue:
@@ -306,28 +311,57 @@ func exportPathValue(v interpreter.PathValue) cadence.Path {
 
 func exportTypeValue(v interpreter.TypeValue, inter *interpreter.Interpreter) cadence.TypeValue {
 	var typeID string
+	var typeDescriptor cadence.Type
 	staticType := v.Type
 	if staticType != nil {
-		typeID = string(inter.ConvertStaticToSemaType(staticType).ID())
+		semaType := inter.ConvertStaticToSemaType(staticType)
+		typeID = string(semaType.ID())
+		typeDescriptor = exportTypeDescriptor(semaType)
 	}
 	return cadence.TypeValue{
-		StaticType: typeID,
+		StaticType:           typeID,
+		StaticTypeDescriptor: typeDescriptor,
 	}
 }
 
 func exportCapabilityValue(v interpreter.CapabilityValue, inter *interpreter.Interpreter) cadence.Capability {
 	var borrowType string
+	var borrowTypeDescriptor cadence.Type
 	if v.BorrowType != nil {
-		borrowType = string(inter.ConvertStaticToSemaType(v.BorrowType).ID())
+		semaType := inter.ConvertStaticToSemaType(v.BorrowType)
+		borrowType = string(semaType.ID())
+		borrowTypeDescriptor = exportTypeDescriptor(semaType)
 	}
 
 	return cadence.Capability{
-		Path:       exportPathValue(v.Path),
-		Address:    cadence.NewAddress(v.Address),
-		BorrowType: borrowType,
+		Path:                 exportPathValue(v.Path),
+		Address:              cadence.NewAddress(v.Address),
+		BorrowType:           borrowType,
+		BorrowTypeDescriptor: borrowTypeDescriptor,
 	}
 }
 
+// exportTypeDescriptor converts the given type to its corresponding Go representation.
+//
+// Not all types can be represented, e.g. range types, so nil is returned for those,
+// and only the type ID is exported
+//
+func exportTypeDescriptor(semaType sema.Type) (result cadence.Type) {
+	defer func() {
+		if r := recover(); r != nil {
+			// don't recover Go errors
+			goErr, ok := r.(goRuntime.Error)
+			if ok {
+				panic(goErr)
+			}
+
+			result = nil
+		}
+	}()
+
+	return ExportType(semaType, map[sema.TypeID]cadence.Type{})
+}
+
 // exportEvent converts a runtime event to its native Go representation.
 func exportEvent(event exportableEvent, seenReferences seenReferences) (cadence.Event, error) {
 	fields := make([]cadence.Value, len(event.Fields))
@@ -399,6 +433,10 @@ func importValue(inter *interpreter.Interpreter, value cadence.Value, expectedTy
 		return interpreter.Fix64Value(v), nil
 	case cadence.UFix64:
 		return interpreter.UFix64Value(v), nil
+	case cadence.Fix128:
+		return interpreter.NewFix128ValueFromBigInt(v.Value), nil
+	case cadence.UFix128:
+		return interpreter.NewUFix128ValueFromBigInt(v.Value), nil
 	case cadence.Path:
 		return importPathValue(v), nil
 	case cadence.Array:
@@ -665,7 +703,8 @@ func importCompositeValue(
 			// (e.g. it has host functions)
 			return importHashAlgorithm(fields)
 
-		case sema.SignatureAlgorithmType:
+		case sema.SignatureAlgorithmType,
+			sema.RoundingRuleType:
 			// continue in the normal path
 
 		default:
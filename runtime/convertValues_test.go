@@ -1079,7 +1079,8 @@ func TestExportTypeValue(t *testing.T) {
 
 		actual := exportValueFromScript(t, script)
 		expected := cadence.TypeValue{
-			StaticType: "Int",
+			StaticType:           "Int",
+			StaticTypeDescriptor: cadence.IntType{},
 		}
 
 		assert.Equal(t, expected, actual)
@@ -1100,6 +1101,11 @@ func TestExportTypeValue(t *testing.T) {
 		actual := exportValueFromScript(t, script)
 		expected := cadence.TypeValue{
 			StaticType: "S.test.S",
+			StaticTypeDescriptor: &cadence.StructType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "S",
+				Fields:              []cadence.Field{},
+			},
 		}
 
 		assert.Equal(t, expected, actual)
@@ -1122,6 +1128,26 @@ func TestExportTypeValue(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 
+	t.Run("with unsupported static type", func(t *testing.T) {
+
+		t.Parallel()
+
+		value := interpreter.TypeValue{
+			Type: interpreter.PrimitiveStaticTypeDeployedContract,
+		}
+		actual, err := exportValueWithInterpreter(value, nil, seenReferences{})
+		require.NoError(t, err)
+
+		// Only the type ID is exported, as the type cannot be represented
+
+		assert.Equal(t,
+			cadence.TypeValue{
+				StaticType: "DeployedContract",
+			},
+			actual,
+		)
+	})
+
 	t.Run("with restricted static type", func(t *testing.T) {
 
 		t.Parallel()
@@ -1167,6 +1193,20 @@ func TestExportTypeValue(t *testing.T) {
 		assert.Equal(t,
 			cadence.TypeValue{
 				StaticType: "S.test.S{S.test.SI}",
+				StaticTypeDescriptor: cadence.RestrictedType{
+					Type: &cadence.StructType{
+						Location:            utils.TestLocation,
+						QualifiedIdentifier: "S",
+						Fields:              []cadence.Field{},
+					},
+					Restrictions: []cadence.Type{
+						&cadence.StructInterfaceType{
+							Location:            utils.TestLocation,
+							QualifiedIdentifier: "SI",
+							Fields:              []cadence.Field{},
+						},
+					},
+				}.WithID("S.test.S{S.test.SI}"),
 			},
 			actual,
 		)
@@ -1197,8 +1237,9 @@ func TestExportCapabilityValue(t *testing.T) {
 				Domain:     "storage",
 				Identifier: "foo",
 			},
-			Address:    cadence.Address{0x1},
-			BorrowType: "Int",
+			Address:              cadence.Address{0x1},
+			BorrowType:           "Int",
+			BorrowTypeDescriptor: cadence.IntType{},
 		}
 
 		assert.Equal(t, expected, actual)
@@ -1244,6 +1285,11 @@ func TestExportCapabilityValue(t *testing.T) {
 			},
 			Address:    cadence.Address{0x1},
 			BorrowType: "S.test.S",
+			BorrowTypeDescriptor: &cadence.StructType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "S",
+				Fields:              []cadence.Field{},
+			},
 		}
 
 		assert.Equal(t, expected, actual)
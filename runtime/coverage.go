@@ -18,7 +18,11 @@
 
 package runtime
 
-import "github.com/onflow/cadence/runtime/common"
+import (
+	"sync"
+
+	"github.com/onflow/cadence/runtime/common"
+)
 
 // LocationCoverage records coverage information for a location
 //
@@ -36,13 +40,18 @@ func NewLocationCoverage() *LocationCoverage {
 	}
 }
 
-// CoverageReport is a collection of coverage per location
+// CoverageReport is a collection of coverage per location.
+// It is safe for concurrent use
 //
 type CoverageReport struct {
+	lock     sync.Mutex
 	Coverage map[common.LocationID]*LocationCoverage `json:"coverage"`
 }
 
 func (r *CoverageReport) AddLineHit(location common.Location, line int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
 	locationID := location.ID()
 	locationCoverage := r.Coverage[locationID]
 	if locationCoverage == nil {
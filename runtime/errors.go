@@ -437,6 +437,51 @@ func (e *ConformanceCountMismatchError) Error() string {
 	return fmt.Sprintf("conformances count does not match: expected %d, found %d", e.Expected, e.Found)
 }
 
+// TypeParameterCountMismatchError is reported during a contract update, when the type parameter count
+// of a generic composite declaration does not match the existing type parameter count.
+type TypeParameterCountMismatchError struct {
+	DeclName string
+	Expected int
+	Found    int
+	ast.Range
+}
+
+func (e *TypeParameterCountMismatchError) Error() string {
+	return fmt.Sprintf(
+		"type parameter count does not match in `%s`: expected %d, found %d",
+		e.DeclName,
+		e.Expected,
+		e.Found,
+	)
+}
+
+// TypeParameterMismatchError is reported during a contract update, when a type parameter
+// of a generic composite declaration does not match the existing type parameter,
+// i.e. when it is renamed, or its type bound is changed.
+type TypeParameterMismatchError struct {
+	DeclName     string
+	ExpectedName string
+	FoundName    string
+	Err          error
+	ast.Range
+}
+
+func (e *TypeParameterMismatchError) Error() string {
+	return fmt.Sprintf(
+		"type parameters do not match in `%s`: expected `%s`, found `%s`",
+		e.DeclName,
+		e.ExpectedName,
+		e.FoundName,
+	)
+}
+
+func (e *TypeParameterMismatchError) SecondaryError() string {
+	if e.Err == nil {
+		return "type parameters must not be renamed"
+	}
+	return e.Err.Error()
+}
+
 // EnumCaseMismatchError is reported during an enum update, when an updated enum case
 // does not match the existing enum case.
 type EnumCaseMismatchError struct {
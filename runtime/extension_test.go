@@ -0,0 +1,237 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestRuntimeExtensionOfImportedResource(t *testing.T) {
+
+	t.Parallel()
+
+	nftContract := `
+      pub contract NFTs {
+
+          pub resource NFT {
+              pub let id: UInt64
+
+              init(id: UInt64) {
+                  self.id = id
+              }
+          }
+
+          pub fun mint(id: UInt64): @NFT {
+              return <-create NFT(id: id)
+          }
+      }
+    `
+
+	viewsContract := `
+      import NFTs from 0x1
+
+      pub contract Views {
+
+          pub extension Display for NFTs.NFT {
+              pub fun name(): String {
+                  return "NFT #".concat(self.id.toString())
+              }
+          }
+      }
+    `
+
+	addTx := func(name, code string) []byte {
+		return []byte(
+			fmt.Sprintf(
+				`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.contracts.add(name: %[1]q, code: "%[2]s".decodeHex())
+                      }
+                  }
+                `,
+				name,
+				hex.EncodeToString([]byte(code)),
+			),
+		)
+	}
+
+	accountCodes := map[common.LocationID][]byte{}
+
+	var signer Address
+	var loggedMessages []string
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestStorage(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{signer}, nil
+		},
+		updateAccountContractCode: func(address Address, name string, code []byte) error {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			accountCodes[location.ID()] = code
+			return nil
+		},
+		getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			code = accountCodes[location.ID()]
+			return code, nil
+		},
+		resolveLocation: func(identifiers []ast.Identifier, location common.Location) (result []sema.ResolvedLocation, err error) {
+			for _, identifier := range identifiers {
+				result = append(result, sema.ResolvedLocation{
+					Location: common.AddressLocation{
+						Address: location.(common.AddressLocation).Address,
+						Name:    identifier.Identifier,
+					},
+					Identifiers: []ast.Identifier{
+						identifier,
+					},
+				})
+			}
+			return
+		},
+		emitEvent: func(event cadence.Event) error {
+			return nil
+		},
+		log: func(message string) {
+			loggedMessages = append(loggedMessages, message)
+		},
+	}
+
+	runtime := NewInterpreterRuntime()
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	for _, contract := range []struct {
+		address Address
+		name    string
+		code    string
+	}{
+		{common.BytesToAddress([]byte{0x1}), "NFTs", nftContract},
+		{common.BytesToAddress([]byte{0x2}), "Views", viewsContract},
+	} {
+		signer = contract.address
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: addTx(contract.name, contract.code),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	signer = common.BytesToAddress([]byte{0x3})
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              import NFTs from 0x1
+
+              transaction {
+                  prepare(signer: AuthAccount) {
+                      signer.save(<-NFTs.mint(id: 42), to: /storage/nft)
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	t.Run("without extension", func(t *testing.T) {
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  import NFTs from 0x1
+
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          let nft = signer.borrow<&NFTs.NFT>(from: /storage/nft)!
+                          log(nft.name())
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.Error(t, err)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, err, &checkerErr)
+
+		errs := checkerErr.Errors
+		require.Len(t, errs, 1)
+		assert.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+	})
+
+	t.Run("with extension", func(t *testing.T) {
+
+		loggedMessages = nil
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  import NFTs from 0x1
+                  import Views from 0x2
+
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          let nft = signer.borrow<&NFTs.NFT>(from: /storage/nft)!
+                          log(nft.name())
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{`"NFT #42"`}, loggedMessages)
+	})
+}
@@ -42,6 +42,8 @@ type Interface interface {
 	// it may NOT return something different or nothing (!) after SetProgram was called.
 	//
 	// This is not a caching function!
+	// The runtime already caches imported programs, across executions,
+	// so the host environment does not have to implement a cache.
 	//
 	GetProgram(Location) (*interpreter.Program, error)
 	// SetProgram sets the program for the given location.
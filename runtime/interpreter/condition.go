@@ -0,0 +1,101 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+// conditionState is the state of the evaluation of conditions and view functions.
+//
+// It is shared by the interpreters of all programs,
+// as a condition or view function may call functions of imported programs
+//
+type conditionState struct {
+	// depth is the number of conditions which are currently evaluated
+	depth int
+	// viewFunctionDepth is the number of view functions which are currently invoked
+	viewFunctionDepth int
+}
+
+// withCondition calls the given function while conditions are evaluated
+//
+func (interpreter *Interpreter) withCondition(f func()) {
+	interpreter.conditionState.depth++
+	defer func() {
+		interpreter.conditionState.depth--
+	}()
+
+	f()
+}
+
+// withViewFunction calls the given function while a view function is invoked
+//
+func (interpreter *Interpreter) withViewFunction(f func()) {
+	interpreter.conditionState.viewFunctionDepth++
+	defer func() {
+		interpreter.conditionState.viewFunctionDepth--
+	}()
+
+	f()
+}
+
+// InCondition returns true if a condition is currently evaluated
+//
+func (interpreter *Interpreter) InCondition() bool {
+	return interpreter.conditionState.depth > 0
+}
+
+// InViewFunction returns true if a view function is currently invoked
+//
+func (interpreter *Interpreter) InViewFunction() bool {
+	return interpreter.conditionState.viewFunctionDepth > 0
+}
+
+// ExpectNoSideEffects panics if a condition is currently evaluated,
+// or if a view function is currently invoked.
+//
+// The checker rejects conditions and view functions which have side effects,
+// but host functions are implemented outside of the checked program,
+// and view functions may be invoked by the host, e.g. a contract function,
+// so their side effects are rejected when they are performed
+//
+func (interpreter *Interpreter) ExpectNoSideEffects(operation string, getLocationRange func() LocationRange) {
+	switch {
+	case interpreter.InCondition():
+		panic(SideEffectInConditionError{
+			Operation:     operation,
+			LocationRange: getLocationRange(),
+		})
+
+	case interpreter.InViewFunction():
+		panic(SideEffectInViewFunctionError{
+			Operation:     operation,
+			LocationRange: getLocationRange(),
+		})
+	}
+}
+
+// statementLocationRange returns the location range of the statement which is currently evaluated
+//
+func (interpreter *Interpreter) statementLocationRange() LocationRange {
+	if interpreter.statement == nil {
+		return LocationRange{
+			Location: interpreter.Location,
+		}
+	}
+
+	return locationRangeGetter(interpreter.Location, interpreter.statement)()
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/fxamacker/cbor/v2"
 
+	"github.com/onflow/cadence/fixedpoint"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/common/orderedmap"
 	"github.com/onflow/cadence/runtime/sema"
@@ -44,6 +45,7 @@ type DecoderV5 struct {
 	version        uint16
 	decodeCallback DecodingCallback
 	isByteDecoder  bool
+	segmentLoader  SegmentLoader
 }
 
 // maxInt is math.MaxInt32 or math.MaxInt64 depending on arch.
@@ -93,6 +95,38 @@ func DecodeValue(
 	return v, nil
 }
 
+// DecodeSegmentedValue returns a value decoded from its CBOR-encoded representation,
+// like DecodeValue, which might contain segmented containers.
+//
+// The given segment loader is used to load the segments of segmented containers on demand.
+// The first element of the given path must be the key the value is stored under
+//
+func DecodeSegmentedValue(
+	data []byte,
+	owner *common.Address,
+	path []string,
+	version uint16,
+	decodeCallback DecodingCallback,
+	segmentLoader SegmentLoader,
+) (
+	Value,
+	error,
+) {
+	decoder, err := NewByteDecoder(data, owner, version, decodeCallback)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder.segmentLoader = segmentLoader
+
+	v, err := decoder.Decode(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
 // NewDecoder initializes a DecoderV5 that will decode CBOR-encoded bytes from the
 // given io.Reader.
 //
@@ -218,6 +252,12 @@ func (d *DecoderV5) decodeValue(path []string) (Value, error) {
 		case cborTagArrayValue:
 			value, err = d.decodeArray(path, true)
 
+		case cborTagSegmentedArrayValue:
+			value, err = d.decodeSegmentedArray(path)
+
+		case cborTagSegmentedDictionaryValue:
+			value, err = d.decodeSegmentedDictionary(path)
+
 		// Int*
 
 		case cborTagIntValue:
@@ -283,11 +323,17 @@ func (d *DecoderV5) decodeValue(path []string) (Value, error) {
 		case cborTagFix64Value:
 			value, err = d.decodeFix64()
 
+		case cborTagFix128Value:
+			value, err = d.decodeFix128()
+
 		// UFix*
 
 		case cborTagUFix64Value:
 			value, err = d.decodeUFix64()
 
+		case cborTagUFix128Value:
+			value, err = d.decodeUFix128()
+
 		// Storage
 
 		case cborTagPathValue:
@@ -391,7 +437,41 @@ func (d *DecoderV5) decodeArray(path []string, deferDecoding bool) (*ArrayValue,
 	valuePath := make([]string, len(path))
 	copy(valuePath, path)
 
-	return NewDeferredArrayValue(valuePath, content, d.owner, d.decodeCallback, d.version), nil
+	array := NewDeferredArrayValue(valuePath, content, d.owner, d.decodeCallback, d.version)
+	array.segmentLoader = d.segmentLoader
+	return array, nil
+}
+
+func (d *DecoderV5) decodeSegmentedArray(path []string) (*ArrayValue, error) {
+	array, err := d.decodeArray(path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	array.segments = &arraySegments{
+		origin: d.segmentOrigin(path),
+	}
+
+	return array, nil
+}
+
+// segmentOrigin returns the origin of the segments of a segmented container
+// decoded at the given path, i.e. the stored value the segments belong to
+//
+func (d *DecoderV5) segmentOrigin(path []string) segmentOrigin {
+	origin := segmentOrigin{
+		loader: d.segmentLoader,
+	}
+
+	if d.owner != nil {
+		origin.owner = *d.owner
+	}
+
+	if len(path) > 0 {
+		origin.key = path[0]
+	}
+
+	return origin
 }
 
 func (d *DecoderV5) decodeArrayValueHead(valuePath []string) error {
@@ -485,14 +565,28 @@ func (d *DecoderV5) decodeDictionary(path []string) (*DictionaryValue, error) {
 	valuePath := make([]string, len(path))
 	copy(valuePath, path)
 
-	return NewDeferredDictionaryValue(
-			valuePath,
-			content,
-			d.owner,
-			d.decodeCallback,
-			d.version,
-		),
-		nil
+	dictionary := NewDeferredDictionaryValue(
+		valuePath,
+		content,
+		d.owner,
+		d.decodeCallback,
+		d.version,
+	)
+	dictionary.segmentLoader = d.segmentLoader
+	return dictionary, nil
+}
+
+func (d *DecoderV5) decodeSegmentedDictionary(path []string) (*DictionaryValue, error) {
+	dictionary, err := d.decodeDictionary(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dictionary.segments = &dictionarySegments{
+		origin: d.segmentOrigin(path),
+	}
+
+	return dictionary, nil
 }
 
 func (d *DecoderV5) decodeLocation() (common.Location, error) {
@@ -622,7 +716,9 @@ func (d *DecoderV5) decodeComposite(path []string) (*CompositeValue, error) {
 	valuePath := make([]string, len(path))
 	copy(valuePath, path)
 
-	return NewDeferredCompositeValue(valuePath, content, d.owner, d.decodeCallback, d.version), nil
+	composite := NewDeferredCompositeValue(valuePath, content, d.owner, d.decodeCallback, d.version)
+	composite.segmentLoader = d.segmentLoader
+	return composite, nil
 }
 
 func (d *DecoderV5) decodeInt() (IntValue, error) {
@@ -953,6 +1049,49 @@ func (d *DecoderV5) decodeUFix64() (UFix64Value, error) {
 	return UFix64Value(value), nil
 }
 
+func (d *DecoderV5) decodeFix128() (Fix128Value, error) {
+	bigInt, err := d.decoder.DecodeBigInt()
+	if err != nil {
+		if e, ok := err.(*cbor.WrongTypeError); ok {
+			return Fix128Value{}, fmt.Errorf("invalid Fix128 encoding: %s", e.ActualType.String())
+		}
+		return Fix128Value{}, err
+	}
+
+	min := fixedpoint.Fix128TypeMinBig
+	if bigInt.Cmp(min) < 0 {
+		return Fix128Value{}, fmt.Errorf("invalid Fix128: got %s, expected min %s", bigInt, min)
+	}
+
+	max := fixedpoint.Fix128TypeMaxBig
+	if bigInt.Cmp(max) > 0 {
+		return Fix128Value{}, fmt.Errorf("invalid Fix128: got %s, expected max %s", bigInt, max)
+	}
+
+	return NewFix128ValueFromBigInt(bigInt), nil
+}
+
+func (d *DecoderV5) decodeUFix128() (UFix128Value, error) {
+	bigInt, err := d.decoder.DecodeBigInt()
+	if err != nil {
+		if e, ok := err.(*cbor.WrongTypeError); ok {
+			return UFix128Value{}, fmt.Errorf("invalid UFix128 encoding: %s", e.ActualType.String())
+		}
+		return UFix128Value{}, err
+	}
+
+	if bigInt.Sign() < 0 {
+		return UFix128Value{}, fmt.Errorf("invalid UFix128: got %s, expected positive", bigInt)
+	}
+
+	max := fixedpoint.UFix128TypeMaxBig
+	if bigInt.Cmp(max) > 0 {
+		return UFix128Value{}, fmt.Errorf("invalid UFix128: got %s, expected max %s", bigInt, max)
+	}
+
+	return NewUFix128ValueFromBigInt(bigInt), nil
+}
+
 func (d *DecoderV5) decodeSome(path []string) (*SomeValue, error) {
 	value, err := d.decodeValue(path)
 	if err != nil {
@@ -1215,6 +1354,9 @@ func (d *DecoderV5) decodeStaticType() (StaticType, error) {
 	case cborTagCapabilityStaticType:
 		return d.decodeCapabilityStaticType()
 
+	case cborTagRangeStaticType:
+		return d.decodeRangeStaticType()
+
 	default:
 		return nil, fmt.Errorf("invalid static type encoding tag: %d", number)
 	}
@@ -1257,7 +1399,11 @@ func (d *DecoderV5) decodeCompositeStaticType() (StaticType, error) {
 		return nil, err
 	}
 
-	if size != expectedLength {
+	// The type arguments are optional
+
+	if size != expectedLength &&
+		size != encodedCompositeStaticTypeWithTypeArgumentsLength {
+
 		return nil, fmt.Errorf("invalid composite static type encoding: expected [%d]interface{}, got [%d]interface{}",
 			expectedLength,
 			size,
@@ -1282,12 +1428,47 @@ func (d *DecoderV5) decodeCompositeStaticType() (StaticType, error) {
 		return nil, err
 	}
 
+	// Decode type arguments at array index encodedCompositeStaticTypeTypeArgumentsFieldKeyV5, if any
+	var typeArguments []StaticType
+	if size == encodedCompositeStaticTypeWithTypeArgumentsLength {
+		typeArguments, err = d.decodeStaticTypes()
+		if err != nil {
+			return nil, fmt.Errorf("invalid composite static type type arguments encoding: %w", err)
+		}
+	}
+
 	return CompositeStaticType{
 		Location:            location,
 		QualifiedIdentifier: qualifiedIdentifier,
+		TypeArguments:       typeArguments,
 	}, nil
 }
 
+// decodeStaticTypes decodes an array of static types
+//
+func (d *DecoderV5) decodeStaticTypes() ([]StaticType, error) {
+	size, err := d.decoder.DecodeArrayHead()
+	if err != nil {
+		if e, ok := err.(*cbor.WrongTypeError); ok {
+			return nil, fmt.Errorf(
+				"invalid static types encoding: expected []interface{}, got %s",
+				e.ActualType.String(),
+			)
+		}
+		return nil, err
+	}
+
+	types := make([]StaticType, size)
+	for i := 0; i < int(size); i++ {
+		types[i], err = d.decodeStaticType()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return types, nil
+}
+
 func (d *DecoderV5) decodeInterfaceStaticType() (InterfaceStaticType, error) {
 	const expectedLength = encodedInterfaceStaticTypeLength
 
@@ -1609,6 +1790,24 @@ func (d *DecoderV5) decodeCapabilityStaticType() (StaticType, error) {
 	}, nil
 }
 
+func (d *DecoderV5) decodeRangeStaticType() (StaticType, error) {
+	var elementStaticType StaticType
+
+	// Optional element type can be CBOR nil.
+	err := d.decoder.DecodeNil()
+	if _, ok := err.(*cbor.WrongTypeError); ok {
+		elementStaticType, err = d.decodeStaticType()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid range static type element type encoding: %w", err)
+	}
+
+	return RangeStaticType{
+		ElementType: elementStaticType,
+	}, nil
+}
+
 // decodeCompositeMetaInfo decodes the meta info from the byte content and updates the composite value.
 // Meta info includes:
 //    - location
@@ -1642,7 +1841,11 @@ func decodeCompositeMetaInfo(v *CompositeValue, content []byte) error {
 		return err
 	}
 
-	if size != expectedLength {
+	// The type arguments are optional
+
+	if size != expectedLength &&
+		size != encodedCompositeValueWithTypeArgumentsLength {
+
 		return fmt.Errorf("invalid composite encoding (@ %s): expected [%d]interface{}, got [%d]interface{}",
 			strings.Join(v.valuePath, "."),
 			expectedLength,
@@ -1715,9 +1918,25 @@ func decodeCompositeMetaInfo(v *CompositeValue, content []byte) error {
 		return err
 	}
 
+	// Type arguments
+
+	// Decode type arguments at array index encodedCompositeValueTypeArgumentsFieldKeyV5, if any
+	var typeArguments []StaticType
+	if size == encodedCompositeValueWithTypeArgumentsLength {
+		typeArguments, err = d.decodeStaticTypes()
+		if err != nil {
+			return fmt.Errorf(
+				"invalid composite type arguments encoding (@ %s): %w",
+				strings.Join(v.valuePath, "."),
+				err,
+			)
+		}
+	}
+
 	v.location = location
 	v.qualifiedIdentifier = qualifiedIdentifier
 	v.kind = kind
+	v.typeArguments = typeArguments
 	v.fieldsContent = fieldsContent
 
 	return nil
@@ -1725,6 +1944,10 @@ func decodeCompositeMetaInfo(v *CompositeValue, content []byte) error {
 
 // decodeCompositeFields decodes fields from the byte content and updates the composite value.
 //
+// The field values are not decoded yet, only their raw content is extracted,
+// so each field value can be decoded individually when it is first accessed,
+// see decodeCompositeField
+//
 func decodeCompositeFields(v *CompositeValue, content []byte) error {
 	if v.encodingVersion < 5 {
 		return decodeCompositeFieldsV4(v, content)
@@ -1757,12 +1980,7 @@ func decodeCompositeFields(v *CompositeValue, content []byte) error {
 	}
 
 	fields := NewStringValueOrderedMap()
-
-	// Pre-allocate and reuse valuePath.
-	//nolint:gocritic
-	valuePath := append(v.valuePath, "")
-
-	lastValuePathIndex := len(v.valuePath)
+	fieldContents := make(map[string][]byte, fieldsSize/2)
 
 	for i := 0; i < int(fieldsSize); i += 2 {
 
@@ -1782,9 +2000,7 @@ func decodeCompositeFields(v *CompositeValue, content []byte) error {
 
 		// field value
 
-		valuePath[lastValuePathIndex] = fieldName
-
-		decodedValue, err := d.decodeValue(valuePath)
+		fieldContent, err := d.decoder.DecodeRawBytesZeroCopy()
 		if err != nil {
 			return fmt.Errorf(
 				"invalid composite field value encoding (@ %s, %s): %w",
@@ -1794,14 +2010,48 @@ func decodeCompositeFields(v *CompositeValue, content []byte) error {
 			)
 		}
 
-		fields.Set(fieldName, decodedValue)
+		// The field value is not decoded yet
+		fields.Set(fieldName, nil)
+		fieldContents[fieldName] = fieldContent
 	}
 
 	v.fields = fields
 
+	if len(fieldContents) > 0 {
+		v.fieldContents = fieldContents
+	}
+
 	return nil
 }
 
+// decodeCompositeField decodes the value of the field with the given name
+// from the raw content of the field value.
+//
+func decodeCompositeField(v *CompositeValue, fieldName string, content []byte) (Value, error) {
+	d, err := NewByteDecoder(content, v.Owner, v.encodingVersion, v.decodeCallback)
+	if err != nil {
+		return nil, err
+	}
+
+	d.segmentLoader = v.segmentLoader
+
+	valuePath := make([]string, len(v.valuePath), len(v.valuePath)+1)
+	copy(valuePath, v.valuePath)
+	valuePath = append(valuePath, fieldName)
+
+	decodedValue, err := d.decodeValue(valuePath)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"invalid composite field value encoding (@ %s, %s): %w",
+			strings.Join(v.valuePath, "."),
+			fieldName,
+			err,
+		)
+	}
+
+	return decodedValue, nil
+}
+
 func decodeArrayMetaInfo(array *ArrayValue, content []byte) error {
 	if array.encodingVersion < 5 {
 		// In encoding version 4, no meta info was available for arrays.
@@ -1863,25 +2113,89 @@ func decodeArrayMetaInfo(array *ArrayValue, content []byte) error {
 	return nil
 }
 
+// decodeArrayElements decodes the elements from the byte content and updates the array value.
+//
+// The elements are not decoded yet, only their raw content is extracted,
+// so each element can be decoded individually when it is first accessed,
+// see decodeArrayElement
+//
 func decodeArrayElements(array *ArrayValue, elementContent []byte) error {
 	if array.encodingVersion < 5 {
 		return decodeArrayElementsV4(array, elementContent)
 	}
 
+	if array.segments != nil {
+		return decodeArraySegments(array, elementContent)
+	}
+
 	d, err := NewByteDecoder(elementContent, array.Owner, array.encodingVersion, array.decodeCallback)
 	if err != nil {
 		return err
 	}
 
-	elements, err := d.decodeArrayElements(array.valuePath)
+	size, err := d.decoder.DecodeArrayHead()
 	if err != nil {
+		if e, ok := err.(*cbor.WrongTypeError); ok {
+			return fmt.Errorf("invalid array encoding (@ %s): expected []interface{}, got %s",
+				strings.Join(array.valuePath, "."),
+				e.ActualType.String(),
+			)
+		}
 		return err
 	}
 
-	array.values = elements
+	elementContents := make([][]byte, size)
+
+	for i := 0; i < int(size); i++ {
+		elementContents[i], err = d.decoder.DecodeRawBytesZeroCopy()
+		if err != nil {
+			return fmt.Errorf(
+				"invalid array element encoding (@ %s, %d): %w",
+				strings.Join(array.valuePath, "."),
+				i,
+				err,
+			)
+		}
+	}
+
+	// The elements are not decoded yet
+	array.values = make([]Value, size)
+
+	if size > 0 {
+		array.elementContents = elementContents
+	}
+
 	return nil
 }
 
+// decodeArrayElement decodes the element at the given index
+// from the raw content of the element.
+//
+func decodeArrayElement(array *ArrayValue, index int, content []byte) (Value, error) {
+	d, err := NewByteDecoder(content, array.Owner, array.encodingVersion, array.decodeCallback)
+	if err != nil {
+		return nil, err
+	}
+
+	d.segmentLoader = array.segmentLoader
+
+	valuePath := make([]string, len(array.valuePath), len(array.valuePath)+1)
+	copy(valuePath, array.valuePath)
+	valuePath = append(valuePath, strconv.Itoa(index))
+
+	decodedValue, err := d.decodeValue(valuePath)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"invalid array element encoding (@ %s, %d): %w",
+			strings.Join(array.valuePath, "."),
+			index,
+			err,
+		)
+	}
+
+	return decodedValue, nil
+}
+
 func decodeDictionaryMetaInfo(v *DictionaryValue, content []byte) error {
 	if v.encodingVersion < 5 {
 		// In encoding version 4, no meta info was available for dictionaries.
@@ -1895,7 +2209,10 @@ func decodeDictionaryMetaInfo(v *DictionaryValue, content []byte) error {
 		return err
 	}
 
-	const expectedLength = encodedDictionaryValueLength
+	expectedLength := uint64(encodedDictionaryValueLength)
+	if v.segments != nil {
+		expectedLength = encodedSegmentedDictionaryValueLength
+	}
 
 	size, err := d.decoder.DecodeArrayHead()
 
@@ -1933,6 +2250,10 @@ func decodeDictionaryMetaInfo(v *DictionaryValue, content []byte) error {
 		)
 	}
 
+	if v.segments != nil {
+		return decodeSegmentedDictionaryMetaInfo(d, v, dictionaryStaticType)
+	}
+
 	// Lazily decode keys
 
 	var keysContent []byte
@@ -1988,11 +2309,17 @@ func decodeDictionaryEntries(v *DictionaryValue, content []byte) error {
 		return decodeDictionaryEntriesV4(v, content)
 	}
 
+	if v.segments != nil {
+		return decodeDictionaryBuckets(v, content)
+	}
+
 	d, err := NewByteDecoder(content, v.Owner, v.encodingVersion, v.decodeCallback)
 	if err != nil {
 		return err
 	}
 
+	d.segmentLoader = v.segmentLoader
+
 	// Decode keys at array index encodedDictionaryValueKeysFieldKeyV5
 	//nolint:gocritic
 	keysPath := append(v.valuePath, dictionaryKeyPathPrefix)
@@ -2120,3 +2447,290 @@ func decodeDictionaryEntries(v *DictionaryValue, content []byte) error {
 
 	return nil
 }
+
+// decodeArraySegments decodes the segment references of a segmented array
+// from the byte content and updates the array value.
+//
+// The segments are not loaded yet, they are loaded when an element
+// of the segment is first accessed, see decodeArraySegment
+//
+func decodeArraySegments(array *ArrayValue, content []byte) error {
+	decoder := decMode.NewByteStreamDecoder(content)
+
+	segments, err := decodeArraySegmentReferences(decoder)
+	if err != nil {
+		return fmt.Errorf(
+			"invalid array segments encoding (@ %s): %w",
+			strings.Join(array.valuePath, "."),
+			err,
+		)
+	}
+
+	count := 0
+	for _, segment := range segments {
+		count += segment.count
+	}
+
+	array.segments.list = segments
+
+	// The elements are not decoded yet
+	array.values = make([]Value, count)
+
+	if count > 0 {
+		array.elementContents = make([][]byte, count)
+	}
+
+	return nil
+}
+
+// decodeArraySegment loads the given segment of the array,
+// which contains the elements starting at the given index,
+// and extracts the raw content of the elements.
+//
+func decodeArraySegment(array *ArrayValue, segment *arraySegment, start int) error {
+	content, size, err := array.segments.origin.loadContent(segment.id)
+	if err != nil {
+		return err
+	}
+
+	decoder := decMode.NewByteStreamDecoder(content)
+
+	count, err := decoder.DecodeArrayHead()
+	if err != nil {
+		return fmt.Errorf(
+			"invalid array segment encoding (@ %s, %s): %w",
+			strings.Join(array.valuePath, "."),
+			segment.id,
+			err,
+		)
+	}
+
+	if int(count) != segment.count {
+		return fmt.Errorf(
+			"invalid array segment encoding (@ %s, %s): expected %d elements, got %d",
+			strings.Join(array.valuePath, "."),
+			segment.id,
+			segment.count,
+			count,
+		)
+	}
+
+	for i := start; i < start+segment.count; i++ {
+		elementContent, err := decoder.DecodeRawBytesZeroCopy()
+		if err != nil {
+			return fmt.Errorf(
+				"invalid array element encoding (@ %s, %d): %w",
+				strings.Join(array.valuePath, "."),
+				i,
+				err,
+			)
+		}
+
+		// Elements which were already decoded are kept
+		if array.values[i] == nil {
+			array.elementContents[i] = elementContent
+		}
+	}
+
+	segment.loaded = true
+	segment.size = size
+
+	return nil
+}
+
+func decodeSegmentedDictionaryMetaInfo(
+	d *DecoderV5,
+	v *DictionaryValue,
+	dictionaryStaticType DictionaryStaticType,
+) error {
+
+	deferred, err := d.decoder.DecodeBool()
+	if err != nil {
+		return fmt.Errorf(
+			"invalid dictionary deferral encoding (@ %s): %w",
+			strings.Join(v.valuePath, "."),
+			err,
+		)
+	}
+
+	// Lazily decode buckets
+
+	var bucketsContent []byte
+	if d.isByteDecoder {
+		// Use the zero-copy method if available, for better performance.
+		bucketsContent, err = d.decoder.DecodeRawBytesZeroCopy()
+	} else {
+		bucketsContent, err = d.decoder.DecodeRawBytes()
+	}
+
+	if err != nil {
+		return fmt.Errorf(
+			"invalid dictionary buckets encoding (@ %s): %w",
+			strings.Join(v.valuePath, "."),
+			err,
+		)
+	}
+
+	v.segments.deferred = deferred
+	v.entriesContent = bucketsContent
+	v.Type = dictionaryStaticType
+
+	return nil
+}
+
+// decodeDictionaryBuckets decodes the bucket references of a segmented dictionary
+// from the byte content and updates the dictionary value.
+//
+// The buckets are not loaded yet, they are loaded when an entry
+// of the bucket is first accessed, see decodeDictionaryBucket
+//
+func decodeDictionaryBuckets(v *DictionaryValue, content []byte) error {
+	decoder := decMode.NewByteStreamDecoder(content)
+
+	buckets, err := decodeDictionaryBucketReferences(decoder)
+	if err != nil {
+		return fmt.Errorf(
+			"invalid dictionary buckets encoding (@ %s): %w",
+			strings.Join(v.valuePath, "."),
+			err,
+		)
+	}
+
+	count := 0
+	for _, bucket := range buckets {
+		count += bucket.count
+	}
+
+	v.segments.buckets = buckets
+	v.segments.count = count
+
+	// The keys are only available once all buckets are loaded
+
+	v.keys = nil
+	v.entries = NewStringValueOrderedMap()
+
+	if v.segments.deferred {
+		v.deferredKeys = orderedmap.NewStringStructOrderedMap()
+		v.deferredStorageKeyBase = joinPathElements(
+			joinPath(v.valuePath),
+			dictionaryValuePathPrefix,
+		)
+	} else {
+		v.deferredOwner = nil
+	}
+
+	return nil
+}
+
+// decodeDictionaryBucket loads the given bucket of the dictionary,
+// and decodes its entries.
+//
+// If the values of the dictionary are deferred, the keys are added to the deferred keys
+//
+func decodeDictionaryBucket(v *DictionaryValue, bucket *dictionaryBucket) error {
+	content, size, err := v.segments.origin.loadContent(bucket.id)
+	if err != nil {
+		return err
+	}
+
+	d, err := NewByteDecoder(content, v.Owner, v.encodingVersion, v.decodeCallback)
+	if err != nil {
+		return err
+	}
+
+	d.segmentLoader = v.segmentLoader
+
+	itemCount, err := d.decoder.DecodeArrayHead()
+	if err != nil {
+		return fmt.Errorf(
+			"invalid dictionary bucket encoding (@ %s, %s): %w",
+			strings.Join(v.valuePath, "."),
+			bucket.id,
+			err,
+		)
+	}
+
+	deferred := v.segments.deferred
+
+	expectedItemCount := bucket.count
+	if !deferred {
+		expectedItemCount *= 2
+	}
+
+	if int(itemCount) != expectedItemCount {
+		return fmt.Errorf(
+			"invalid dictionary bucket encoding (@ %s, %s): expected %d items, got %d",
+			strings.Join(v.valuePath, "."),
+			bucket.id,
+			expectedItemCount,
+			itemCount,
+		)
+	}
+
+	keys := make([]Value, bucket.count)
+
+	// Pre-allocate and reuse keyPath and valuePath.
+	keyPath := make([]string, len(v.valuePath), len(v.valuePath)+2)
+	copy(keyPath, v.valuePath)
+	keyPath = append(keyPath, dictionaryKeyPathPrefix, "")
+
+	valuePath := make([]string, len(v.valuePath), len(v.valuePath)+2)
+	copy(valuePath, v.valuePath)
+	valuePath = append(valuePath, dictionaryValuePathPrefix, "")
+
+	lastPathIndex := len(v.valuePath) + 1
+
+	for i := range keys {
+		keyPath[lastPathIndex] = strconv.Itoa(i)
+
+		keyValue, err := d.decodeValue(keyPath)
+		if err != nil {
+			return fmt.Errorf(
+				"invalid dictionary key encoding (@ %s, %s, %d): %w",
+				strings.Join(v.valuePath, "."),
+				bucket.id,
+				i,
+				err,
+			)
+		}
+
+		keyStringValue, ok := keyValue.(HasKeyString)
+		if !ok {
+			return fmt.Errorf(
+				"invalid dictionary key encoding (@ %s, %s, %d): %T",
+				strings.Join(v.valuePath, "."),
+				bucket.id,
+				i,
+				keyValue,
+			)
+		}
+
+		keyString := keyStringValue.KeyString()
+		keys[i] = keyValue
+
+		if deferred {
+			v.deferredKeys.Set(keyString, struct{}{})
+			continue
+		}
+
+		valuePath[lastPathIndex] = keyString
+
+		decodedValue, err := d.decodeValue(valuePath)
+		if err != nil {
+			return fmt.Errorf(
+				"invalid dictionary value encoding (@ %s, %s): %w",
+				strings.Join(v.valuePath, "."),
+				keyString,
+				err,
+			)
+		}
+
+		v.entries.Set(keyString, decodedValue)
+	}
+
+	bucket.keys = keys
+	bucket.loaded = true
+	bucket.size = size
+
+	return nil
+}
@@ -433,6 +433,140 @@ func TestCompositeDeferredDecoding(t *testing.T) {
 		assert.Nil(t, compositeValue.content)
 		assert.NotNil(t, compositeValue.fieldsContent)
 	})
+
+	t.Run("Single field access", func(t *testing.T) {
+		t.Parallel()
+
+		value := newTestLargeCompositeValue(0)
+
+		encoded, _, err := EncodeValue(value, nil, true, nil)
+		require.NoError(t, err)
+
+		var decodedPaths [][]string
+		callback := func(_ interface{}, path []string) {
+			valuePath := make([]string, len(path))
+			copy(valuePath, path)
+			decodedPaths = append(decodedPaths, valuePath)
+		}
+
+		decoded, err := DecodeValue(encoded, &testOwner, []string{}, CurrentEncodingVersion, callback)
+		require.NoError(t, err)
+
+		require.IsType(t, &CompositeValue{}, decoded)
+		compositeValue := decoded.(*CompositeValue)
+
+		fieldValue := compositeValue.GetField("lname")
+		assert.Equal(t, NewStringValue("Doe"), fieldValue)
+
+		// Only the accessed field must be decoded
+		assert.Equal(t,
+			[][]string{
+				{},
+				{"lname"},
+			},
+			decodedPaths,
+		)
+
+		assert.Nil(t, compositeValue.fieldsContent)
+		assert.Len(t, compositeValue.fieldContents, 4)
+
+		// The other fields must not be loaded
+		for pair := compositeValue.fields.Oldest(); pair != nil; pair = pair.Next() {
+			if pair.Key == "lname" {
+				continue
+			}
+			assert.Nil(t, pair.Value)
+		}
+
+		assert.False(t, compositeValue.IsModified())
+
+		// Re-encoding must produce the same encoding
+		reEncoded, _, err := EncodeValue(compositeValue, []string{}, true, nil)
+		require.NoError(t, err)
+		assert.Equal(t, encoded, reEncoded)
+
+		// Loading all fields must decode the remaining fields
+		fields := compositeValue.Fields()
+		assert.Equal(t, 5, fields.Len())
+		assert.Len(t, decodedPaths, 6)
+		assert.Nil(t, compositeValue.fieldContents)
+
+		fieldValue, ok := fields.Get("fname")
+		assert.True(t, ok)
+		assert.Equal(t, NewStringValue("John0"), fieldValue)
+	})
+
+	t.Run("Single field update", func(t *testing.T) {
+		t.Parallel()
+
+		value := newTestLargeCompositeValue(0)
+
+		encoded, _, err := EncodeValue(value, nil, true, nil)
+		require.NoError(t, err)
+
+		decoded, err := DecodeValue(encoded, &testOwner, nil, CurrentEncodingVersion, nil)
+		require.NoError(t, err)
+
+		require.IsType(t, &CompositeValue{}, decoded)
+		compositeValue := decoded.(*CompositeValue)
+
+		newValue := NewStringValue("green")
+		compositeValue.SetMember(nil, nil, "status", newValue)
+
+		// The other fields must not be loaded
+		for pair := compositeValue.fields.Oldest(); pair != nil; pair = pair.Next() {
+			if pair.Key == "status" {
+				assert.Equal(t, newValue, pair.Value)
+				continue
+			}
+			assert.Nil(t, pair.Value)
+		}
+
+		assert.True(t, compositeValue.IsModified())
+
+		// Re-encoding must produce the same encoding as the updated original value
+
+		value.SetMember(nil, nil, "status", newValue)
+
+		expected, _, err := EncodeValue(value, nil, true, nil)
+		require.NoError(t, err)
+
+		reEncoded, _, err := EncodeValue(compositeValue, nil, true, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, reEncoded)
+	})
+
+	t.Run("Copy partially loaded", func(t *testing.T) {
+		t.Parallel()
+
+		value := newTestLargeCompositeValue(0)
+
+		encoded, _, err := EncodeValue(value, nil, true, nil)
+		require.NoError(t, err)
+
+		decoded, err := DecodeValue(encoded, &testOwner, nil, CurrentEncodingVersion, nil)
+		require.NoError(t, err)
+
+		require.IsType(t, &CompositeValue{}, decoded)
+		compositeValue := decoded.(*CompositeValue)
+
+		compositeValue.GetField("age")
+
+		copied := compositeValue.Copy()
+		require.IsType(t, &CompositeValue{}, copied)
+		copiedComposite := copied.(*CompositeValue)
+
+		assert.Nil(t, copiedComposite.Owner)
+
+		// Loading the fields of the copy must not affect the original
+		copiedComposite.SetMember(nil, nil, "lname", NewStringValue("Roe"))
+		copiedComposite.Fields()
+
+		assert.Equal(t, NewStringValue("Doe"), compositeValue.GetField("lname"))
+		assert.Equal(t, NewStringValue("Roe"), copiedComposite.GetField("lname"))
+		assert.Equal(t, NewStringValue("John0"), copiedComposite.GetField("fname"))
+	})
 }
 
 func BenchmarkCompositeDeferredDecoding(b *testing.B) {
@@ -699,6 +833,119 @@ func TestArrayDeferredDecoding(t *testing.T) {
 		assert.NotNil(t, decodedArray.content)
 	})
 
+	t.Run("Single element access", func(t *testing.T) {
+		t.Parallel()
+
+		array := newTestArrayValue(10)
+
+		encoded, _, err := EncodeValue(array, nil, true, nil)
+		require.NoError(t, err)
+
+		var decodedPaths [][]string
+		callback := func(_ interface{}, path []string) {
+			valuePath := make([]string, len(path))
+			copy(valuePath, path)
+			decodedPaths = append(decodedPaths, valuePath)
+		}
+
+		decoded, err := DecodeValue(encoded, &testOwner, []string{}, CurrentEncodingVersion, callback)
+		require.NoError(t, err)
+
+		require.IsType(t, &ArrayValue{}, decoded)
+		decodedArray := decoded.(*ArrayValue)
+
+		assert.Equal(t, 10, decodedArray.Count())
+
+		element := decodedArray.Get(nil, nil, NewIntValueFromInt64(3))
+		require.IsType(t, &CompositeValue{}, element)
+
+		// Only the accessed element must be decoded
+		assert.Equal(t,
+			[][]string{
+				{},
+				{"3"},
+			},
+			decodedPaths,
+		)
+
+		for i, value := range decodedArray.values {
+			if i == 3 {
+				assert.Same(t, element, value)
+				continue
+			}
+			assert.Nil(t, value)
+		}
+
+		assert.False(t, decodedArray.IsModified())
+
+		// Re-encoding must produce the same encoding
+		reEncoded, _, err := EncodeValue(decodedArray, []string{}, true, nil)
+		require.NoError(t, err)
+		assert.Equal(t, encoded, reEncoded)
+
+		// Loading all elements must decode the remaining elements
+		elements := decodedArray.Elements()
+		assert.Len(t, elements, 10)
+		assert.Len(t, decodedPaths, 11)
+		assert.Nil(t, decodedArray.elementContents)
+	})
+
+	t.Run("Single element update", func(t *testing.T) {
+		t.Parallel()
+
+		inter, err := NewInterpreter(nil, utils.TestLocation)
+		require.NoError(t, err)
+
+		newArray := func() *ArrayValue {
+			return NewArrayValueUnownedNonCopying(
+				VariableSizedStaticType{
+					Type: PrimitiveStaticTypeString,
+				},
+				NewStringValue("a"),
+				NewStringValue("b"),
+				NewStringValue("c"),
+			)
+		}
+
+		encoded, _, err := EncodeValue(newArray(), nil, true, nil)
+		require.NoError(t, err)
+
+		decoded, err := DecodeValue(encoded, &testOwner, nil, CurrentEncodingVersion, nil)
+		require.NoError(t, err)
+
+		require.IsType(t, &ArrayValue{}, decoded)
+		decodedArray := decoded.(*ArrayValue)
+
+		newElement := NewStringValue("x")
+
+		decodedArray.SetIndex(inter, nil, 1, newElement)
+
+		newElement2 := NewStringValue("y")
+
+		decodedArray.Append(inter, nil, newElement2)
+
+		assert.Nil(t, decodedArray.values[0])
+		assert.Same(t, newElement, decodedArray.values[1])
+		assert.Nil(t, decodedArray.values[2])
+		assert.Same(t, newElement2, decodedArray.values[3])
+
+		assert.True(t, decodedArray.IsModified())
+
+		// Re-encoding must produce the same encoding as the updated original value
+
+		array := newArray()
+		array.SetIndex(inter, nil, 1, newElement)
+		array.Append(inter, nil, newElement2)
+
+		expected, _, err := EncodeValue(array, nil, true, nil)
+		require.NoError(t, err)
+
+		reEncoded, _, err := EncodeValue(decodedArray, nil, true, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, reEncoded)
+	})
+
 	t.Run("Decode with V4", func(t *testing.T) {
 		t.Parallel()
 
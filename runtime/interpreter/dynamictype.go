@@ -78,6 +78,8 @@ func (BoolDynamicType) IsImportable() bool {
 // ArrayDynamicType
 
 type ArrayDynamicType struct {
+	// ElementTypes is nil for segmented arrays,
+	// the elements conform to the static type
 	ElementTypes []DynamicType
 	StaticType   ArrayStaticType
 }
@@ -126,6 +128,8 @@ type DictionaryStaticTypeEntry struct {
 }
 
 type DictionaryDynamicType struct {
+	// EntryTypes is nil for segmented dictionaries,
+	// the entries conform to the static type
 	EntryTypes []DictionaryStaticTypeEntry
 	StaticType DictionaryStaticType
 }
@@ -284,6 +288,18 @@ func (CapabilityDynamicType) IsImportable() bool {
 	return false
 }
 
+// RangeDynamicType
+
+type RangeDynamicType struct {
+	ElementType sema.Type
+}
+
+func (RangeDynamicType) IsDynamicType() {}
+
+func (RangeDynamicType) IsImportable() bool {
+	return false
+}
+
 // DeployedContractDynamicType
 
 type DeployedContractDynamicType struct{}
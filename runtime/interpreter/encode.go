@@ -68,8 +68,8 @@ const (
 	cborTagCompositeValue
 	cborTagTypeValue
 	cborTagArrayValue
-	_
-	_
+	cborTagSegmentedArrayValue
+	cborTagSegmentedDictionaryValue
 	_
 	_
 	_
@@ -122,7 +122,7 @@ const (
 	_ // future: Fix16
 	_ // future: Fix32
 	cborTagFix64Value
-	_ // future: Fix128
+	cborTagFix128Value
 	_ // future: Fix256
 	_
 
@@ -132,7 +132,7 @@ const (
 	_ // future: UFix16
 	_ // future: UFix32
 	cborTagUFix64Value
-	_ // future: UFix128
+	cborTagUFix128Value
 	_ // future: UFix256
 	_
 
@@ -172,6 +172,7 @@ const (
 	cborTagReferenceStaticType
 	cborTagRestrictedStaticType
 	cborTagCapabilityStaticType
+	cborTagRangeStaticType
 )
 
 type EncodingDeferralMove struct {
@@ -186,9 +187,25 @@ type EncodingDeferralValue struct {
 	Value Value
 }
 
+// EncodingDeferralSegment is a segment of a segmented container,
+// which must be stored separately, under the storage key of the stored value
+// that references it.
+//
+type EncodingDeferralSegment struct {
+	ID   SegmentID
+	Data []byte
+}
+
 type EncodingDeferrals struct {
-	Values []EncodingDeferralValue
-	Moves  []EncodingDeferralMove
+	Values   []EncodingDeferralValue
+	Moves    []EncodingDeferralMove
+	Segments []EncodingDeferralSegment
+}
+
+func (d *EncodingDeferrals) append(other *EncodingDeferrals) {
+	d.Values = append(d.Values, other.Values...)
+	d.Moves = append(d.Moves, other.Moves...)
+	d.Segments = append(d.Segments, other.Segments...)
 }
 
 type EncodingPrepareCallback func(value Value, path []string)
@@ -199,6 +216,13 @@ type EncoderV5 struct {
 	enc             *cbor.StreamEncoder
 	deferred        bool
 	prepareCallback EncodingPrepareCallback
+	// rootKey is the storage key of the encoded value
+	rootKey string
+	// inSegment is true if the encoder encodes the content of a segment.
+	// Segments cannot reference other segments
+	inSegment bool
+	// segmentReferences is true if the encoded data references segments
+	segmentReferences bool
 }
 
 // EncodeValue returns the CBOR-encoded representation of the given value.
@@ -214,6 +238,10 @@ type EncoderV5 struct {
 // which have not been encoded, and which values need to be moved
 // from a previous storage key to another storage key.
 //
+// Large containers are only encoded as segmented containers if the deferred flag is true.
+// The deferrals result then also contains the segments which need to be stored
+// under the storage key given as the first element of the path.
+//
 func EncodeValue(value Value, path []string, deferred bool, prepareCallback EncodingPrepareCallback) (
 	encoded []byte,
 	deferrals *EncodingDeferrals,
@@ -225,6 +253,10 @@ func EncodeValue(value Value, path []string, deferred bool, prepareCallback Enco
 		return nil, nil, err
 	}
 
+	if len(path) > 0 {
+		enc.rootKey = path[0]
+	}
+
 	deferrals = &EncodingDeferrals{}
 
 	err = enc.Encode(value, path, deferrals)
@@ -268,6 +300,90 @@ func NewEncoder(w io.Writer, deferred bool, prepareCallback EncodingPrepareCallb
 	}, nil
 }
 
+// canReferenceSegments returns true if the encoded data may reference segments:
+// Only stored values, i.e. values encoded with deferrals, can reference segments,
+// and segments cannot reference other segments.
+//
+func (e *EncoderV5) canReferenceSegments() bool {
+	return e.deferred && !e.inSegment
+}
+
+// canDumpRaw returns true if the given raw content of a value which is not loaded
+// can be encoded as it is.
+//
+// Raw content which might reference segments is not dumped,
+// as the segments might belong to another stored value:
+// The value must be loaded up to the segmented containers,
+// which determine if the referenced segments can be kept.
+//
+func (e *EncoderV5) canDumpRaw(content []byte) bool {
+	return !mayContainSegmentReferences(content)
+}
+
+// encodeRaw encodes the given raw content of a value which is not loaded, with the given tag number.
+//
+func (e *EncoderV5) encodeRaw(tag byte, content []byte) error {
+	err := e.enc.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, tag,
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.enc.EncodeRawBytes(content)
+}
+
+// encodeItems encodes the given number of items individually, using the given function.
+// The items are encoded in a segment if inSegment is true.
+//
+// The references result is true if any of the encoded items references segments.
+//
+func (e *EncoderV5) encodeItems(
+	count int,
+	inSegment bool,
+	encodeItem func(enc *EncoderV5, index int) error,
+) (
+	items [][]byte,
+	references bool,
+	err error,
+) {
+	var w bytes.Buffer
+
+	enc := &EncoderV5{
+		enc:             encMode.NewStreamEncoder(&w),
+		deferred:        e.deferred,
+		prepareCallback: e.prepareCallback,
+		rootKey:         e.rootKey,
+		inSegment:       e.inSegment || inSegment,
+	}
+
+	offsets := make([]int, count+1)
+
+	for i := 0; i < count; i++ {
+		err = encodeItem(enc, i)
+		if err != nil {
+			return nil, false, err
+		}
+
+		err = enc.enc.Flush()
+		if err != nil {
+			return nil, false, err
+		}
+
+		offsets[i+1] = w.Len()
+	}
+
+	data := w.Bytes()
+
+	items = make([][]byte, count)
+	for i := range items {
+		items[i] = data[offsets[i]:offsets[i+1]:offsets[i+1]]
+	}
+
+	return items, enc.segmentReferences, nil
+}
+
 // Encode writes the CBOR-encoded representation of the given value to this
 // encoder's io.Writer.
 //
@@ -367,6 +483,12 @@ func (e *EncoderV5) Encode(
 	case UFix64Value:
 		return e.encodeUFix64(v)
 
+	case Fix128Value:
+		return e.encodeFix128(v)
+
+	case UFix128Value:
+		return e.encodeUFix128(v)
+
 	// String
 
 	case *StringValue:
@@ -758,6 +880,38 @@ func (e *EncoderV5) encodeUFix64(v UFix64Value) error {
 	return e.enc.EncodeUint64(uint64(v))
 }
 
+// encodeFix128 encodes Fix128Value as
+// cbor.Tag{
+//		Number:  cborTagFix128Value,
+//		Content: *big.Int(v.BigInt),
+// }
+func (e *EncoderV5) encodeFix128(v Fix128Value) error {
+	err := e.enc.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, cborTagFix128Value,
+	})
+	if err != nil {
+		return err
+	}
+	return e.enc.EncodeBigInt(v.BigInt)
+}
+
+// encodeUFix128 encodes UFix128Value as
+// cbor.Tag{
+//		Number:  cborTagUFix128Value,
+//		Content: *big.Int(v.BigInt),
+// }
+func (e *EncoderV5) encodeUFix128(v UFix128Value) error {
+	err := e.enc.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, cborTagUFix128Value,
+	})
+	if err != nil {
+		return err
+	}
+	return e.enc.EncodeBigInt(v.BigInt)
+}
+
 // \x1F = Information Separator One
 //
 const pathSeparator = "\x1F"
@@ -785,6 +939,30 @@ const (
 	encodedArrayValueLength = 2
 )
 
+// NOTE: NEVER change, only add/increment; ensure uint64
+const (
+	// encodedSegmentedArrayValueStaticTypeFieldKeyV5 uint64 = 0
+	// encodedSegmentedArrayValueSegmentsFieldKeyV5   uint64 = 1
+
+	// !!! *WARNING* !!!
+	//
+	// encodedSegmentedArrayValueLength MUST be updated when new element is added.
+	// It is used to verify encoded segmented array length during decoding.
+	encodedSegmentedArrayValueLength = 2
+)
+
+// NOTE: NEVER change, only add/increment; ensure uint64
+const (
+	// encodedArraySegmentReferenceCountFieldKeyV5 uint64 = 0
+	// encodedArraySegmentReferenceIDFieldKeyV5    uint64 = 1
+
+	// !!! *WARNING* !!!
+	//
+	// encodedArraySegmentReferenceLength MUST be updated when new element is added.
+	// It is used to verify encoded array segment reference length during decoding.
+	encodedArraySegmentReferenceLength = 2
+)
+
 // encodeArray encodes ArrayValue as
 // cbor.Tag{
 //     Number: cborTagArrayValue,
@@ -793,32 +971,74 @@ const (
 //         encodedArrayValueElementsFieldKeyV5:   []interface{}(v.Elements),
 //     },
 // }
+//
+// Large arrays are encoded as segmented arrays, see encodeSegmentedArray.
+//
 func (e *EncoderV5) encodeArray(
 	v *ArrayValue,
 	path []string,
 	deferrals *EncodingDeferrals,
 ) error {
 
-	// Encode tag number and array head
-	err := e.enc.EncodeRawBytes([]byte{
-		// tag number
-		0xd8, cborTagArrayValue,
-	})
-	if err != nil {
-		return err
+	if v.segments != nil {
+		if e.canReferenceSegments() {
+			return e.encodeSegmentedArray(v, path, deferrals)
+		}
+
+		// Segments can only be referenced from stored values,
+		// so the array is encoded inline.
+		v.ensureElementsLoaded()
 	}
 
+	// If the array is not loaded, dump the raw content as it is.
 	if v.content != nil {
-		err := e.enc.EncodeRawBytes(v.content)
-		if err != nil {
-			return err
+		if e.canDumpRaw(v.content) {
+			return e.encodeRaw(cborTagArrayValue, v.content)
 		}
 
-		return nil
+		v.ensureMetaInfoLoaded()
 	}
 
-	// Encode array head
-	err = e.enc.EncodeRawBytes([]byte{
+	if v.elementsContent != nil && !e.canDumpRaw(v.elementsContent) {
+		v.ensureElementsIndexed()
+	}
+
+	if v.elementsContent != nil || !e.canReferenceSegments() {
+		return e.encodeInlineArray(v, path, deferrals)
+	}
+
+	// Encode the elements separately, so the array can be encoded as a segmented array
+	// if it is large, and its elements do not reference segments themselves.
+
+	elements, references, err := e.encodeArrayElements(v, path, deferrals, 0, len(v.values), false)
+	if err != nil {
+		return err
+	}
+
+	if !references && encodedItemsSize(elements) > segmentationThreshold {
+		segments := e.newArraySegments(elements, deferrals)
+		return e.writeSegmentedArray(v.StaticType(), segments)
+	}
+
+	if references {
+		e.segmentReferences = true
+	}
+
+	return e.writeArray(v.StaticType(), elements)
+}
+
+// encodeInlineArray encodes the array with all its elements.
+//
+func (e *EncoderV5) encodeInlineArray(
+	v *ArrayValue,
+	path []string,
+	deferrals *EncodingDeferrals,
+) error {
+
+	// Encode tag number and array head
+	err := e.enc.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, cborTagArrayValue,
 		// array, 2 items follow
 		0x82,
 	})
@@ -834,8 +1054,14 @@ func (e *EncoderV5) encodeArray(
 
 	// Encode elements (as array) at array index encodedArrayValueElementsFieldKeyV5
 
-	elements := v.Elements()
-	err = e.enc.EncodeArrayHead(uint64(len(elements)))
+	// If the elements are not loaded, dump the raw elements content as it is.
+	if v.elementsContent != nil {
+		return e.enc.EncodeRawBytes(v.elementsContent)
+	}
+
+	// NOTE: do not load the elements, elements which are not loaded yet,
+	// i.e. nil, are encoded from their raw content.
+	err = e.enc.EncodeArrayHead(uint64(len(v.values)))
 	if err != nil {
 		return err
 	}
@@ -846,10 +1072,8 @@ func (e *EncoderV5) encodeArray(
 
 	lastValuePathIndex := len(path)
 
-	for i, value := range elements {
-		valuePath[lastValuePathIndex] = strconv.Itoa(i)
-
-		err := e.Encode(value, valuePath, deferrals)
+	for i := range v.values {
+		err := e.encodeArrayElement(v, i, valuePath, lastValuePathIndex, deferrals)
 		if err != nil {
 			return err
 		}
@@ -858,169 +1082,1032 @@ func (e *EncoderV5) encodeArray(
 	return nil
 }
 
-// NOTE: NEVER change, only add/increment; ensure uint64
-const (
-	// encodedDictionaryValueTypeFieldKeyV5    uint64 = 0
-	// encodedDictionaryValueKeysFieldKeyV5    uint64 = 1
-	// encodedDictionaryValueEntriesFieldKeyV5 uint64 = 2
+// encodeArrayElement encodes the element at the given index.
+// If the element is not loaded, the raw content is dumped as it is, if possible.
+//
+func (e *EncoderV5) encodeArrayElement(
+	v *ArrayValue,
+	index int,
+	valuePath []string,
+	lastValuePathIndex int,
+	deferrals *EncodingDeferrals,
+) error {
+	value := v.values[index]
 
-	// !!! *WARNING* !!!
-	//
-	// encodedDictionaryValueLength MUST be updated when new element is added.
-	// It is used to verify encoded dictionaries length during decoding.
-	encodedDictionaryValueLength = 3
-)
+	if value == nil {
+		content := v.elementContents[index]
+		if e.canDumpRaw(content) {
+			return e.enc.EncodeRawBytes(content)
+		}
 
-const dictionaryKeyPathPrefix = "k"
-const dictionaryValuePathPrefix = "v"
+		value = v.element(index)
+	}
 
-// encodeDictionaryValue encodes DictionaryValue as
-// cbor.Tag{
-//			Number: cborTagDictionaryValue,
-//			Content: cborArray{
-// 				encodedDictionaryValueTypeFieldKeyV5:    []interface{}(type),
-//				encodedDictionaryValueKeysFieldKeyV5:    []interface{}(keys),
-//				encodedDictionaryValueEntriesFieldKeyV5: []interface{}(entries),
-//			},
-// }
-func (e *EncoderV5) encodeDictionaryValue(
-	v *DictionaryValue,
+	valuePath[lastValuePathIndex] = strconv.Itoa(index)
+
+	return e.Encode(value, valuePath, deferrals)
+}
+
+// encodeArrayElements encodes the elements in the given index range individually.
+//
+func (e *EncoderV5) encodeArrayElements(
+	v *ArrayValue,
 	path []string,
 	deferrals *EncodingDeferrals,
-) error {
+	from, to int,
+	inSegment bool,
+) (
+	elements [][]byte,
+	references bool,
+	err error,
+) {
+	// Pre-allocate and reuse valuePath.
+	//nolint:gocritic
+	valuePath := append(path, "")
 
-	// Encode CBOR tag number
+	lastValuePathIndex := len(path)
+
+	return e.encodeItems(
+		to-from,
+		inSegment,
+		func(enc *EncoderV5, i int) error {
+			return enc.encodeArrayElement(v, from+i, valuePath, lastValuePathIndex, deferrals)
+		},
+	)
+}
+
+// writeArray writes an array with the given encoded elements.
+//
+func (e *EncoderV5) writeArray(staticType StaticType, elements [][]byte) error {
+
+	// Encode tag number and array head
 	err := e.enc.EncodeRawBytes([]byte{
 		// tag number
-		0xd8, cborTagDictionaryValue,
+		0xd8, cborTagArrayValue,
+		// array, 2 items follow
+		0x82,
 	})
 	if err != nil {
 		return err
 	}
 
-	if v.content != nil {
-		err := e.enc.EncodeRawBytes(v.content)
-		if err != nil {
-			return err
-		}
-
-		return nil
-	}
-
-	// Encode array head
-	err = e.enc.EncodeRawBytes([]byte{
-		// array, 3 items follow
-		0x83,
-	})
+	// Encode array static type at array index encodedArrayValueStaticTypeFieldKeyV5
+	err = e.encodeStaticType(staticType)
 	if err != nil {
 		return err
 	}
 
-	//nolint:gocritic
-	keysPath := append(path, dictionaryKeyPathPrefix)
-
-	// (1) Encode dictionary static type at array index encodedDictionaryValueTypeFieldKeyV5
-	err = e.encodeStaticType(v.StaticType())
+	// Encode elements (as array) at array index encodedArrayValueElementsFieldKeyV5
+	err = e.enc.EncodeArrayHead(uint64(len(elements)))
 	if err != nil {
 		return err
 	}
 
-	// (2) Encode keys (as array) at array index encodedDictionaryValueKeysFieldKeyV5
-	err = e.encodeArray(v.Keys(), keysPath, deferrals)
-	if err != nil {
-		return err
+	for _, element := range elements {
+		err = e.enc.EncodeRawBytes(element)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Deferring the encoding of values is only supported if all
-	// values are resources: resource typed dictionaries are moved
+	return nil
+}
 
-	deferred := e.deferred
-	if deferred {
+// encodeSegmentedArray encodes an array which was decoded from a segmented array
+// as a segmented array.
+//
+// Segments which are not modified are referenced as they are,
+// only modified segments are encoded again.
+// If the array became small, it is encoded inline again.
+//
+func (e *EncoderV5) encodeSegmentedArray(
+	v *ArrayValue,
+	path []string,
+	deferrals *EncodingDeferrals,
+) error {
 
-		// Iterating over the map in a non-deterministic way is OK,
-		// we only determine check if all values are resources.
+	segments := v.segments
+	originMatches := segments.origin.matches(v.Owner, e.rootKey)
 
-		for pair := v.Entries().Oldest(); pair != nil; pair = pair.Next() {
-			compositeValue, ok := pair.Value.(*CompositeValue)
-			if !ok || compositeValue.Kind() != common.CompositeKindResource {
-				deferred = false
-				break
-			}
+	// If the array is not loaded, and it is encoded into the stored value
+	// which its segments belong to, dump the raw content as it is.
+
+	if v.content != nil {
+		if originMatches {
+			e.segmentReferences = true
+			return e.encodeRaw(cborTagSegmentedArrayValue, v.content)
 		}
-	}
 
-	// entries is empty if encoding of values is deferred,
-	// otherwise entries size is the same as keys size.
-	keys := v.Keys().Elements()
-	entriesLength := len(keys)
-	if deferred {
-		entriesLength = 0
+		v.ensureMetaInfoLoaded()
 	}
 
-	// (3) Encode values (as array) at array index encodedDictionaryValueEntriesFieldKeyV5
-	err = e.enc.EncodeArrayHead(uint64(entriesLength))
-	if err != nil {
-		return err
-	}
+	v.ensureElementsIndexed()
 
-	// Pre-allocate and reuse valuePath.
-	//nolint:gocritic
-	valuePath := append(path, dictionaryValuePathPrefix, "")
+	// Segments are encoded with separate deferrals,
+	// as the array might be encoded inline instead
 
-	lastValuePathIndex := len(path) + 1
+	segmentDeferrals := &EncodingDeferrals{}
 
-	for _, keyValue := range keys {
-		key := dictionaryKey(keyValue)
-		entryValue, _ := v.Entries().Get(key)
-		valuePath[lastValuePathIndex] = key
+	var references []*arraySegment
+	size := 0
+	loaded := true
 
-		if deferred {
+	list := segments.list
+	start := 0
 
-			var isDeferred bool
-			if v.deferredKeys != nil {
-				_, isDeferred = v.deferredKeys.Get(key)
-			}
+	for i := 0; i < len(list); {
+		segment := list[i]
 
-			// If the value is not deferred, i.e. it is in memory,
-			// then it must be stored under a separate storage key
-			// in the owner's storage.
+		if !arraySegmentNeedsEncoding(v, segment, start, originMatches) {
 
-			if !isDeferred {
-				deferrals.Values = append(deferrals.Values,
-					EncodingDeferralValue{
-						Key:   joinPath(valuePath),
-						Value: entryValue,
+			// The segment belongs to another stored value, copy it
+
+			if !originMatches {
+				data, err := segments.origin.loadData(segment.id)
+				if err != nil {
+					return err
+				}
+
+				segmentDeferrals.Segments = append(segmentDeferrals.Segments,
+					EncodingDeferralSegment{
+						ID:   segment.id,
+						Data: data,
 					},
 				)
-			} else {
+			}
 
-				// If the value is deferred, and the deferred value
-				// is stored in another account's storage,
-				// it must be moved.
+			references = append(references, segment)
+			size += segment.size
+			loaded = loaded && segment.loaded
 
-				deferredOwner := *v.deferredOwner
-				owner := *v.Owner
+			start += segment.count
+			i++
+			continue
+		}
 
-				if deferredOwner != owner {
+		// Encode the consecutive segments which need to be encoded again,
+		// and split the elements into segments again
 
-					deferredStorageKey := joinPathElements(v.deferredStorageKeyBase, key)
+		end := start
+		for ; i < len(list) && arraySegmentNeedsEncoding(v, list[i], end, originMatches); i++ {
+			end += list[i].count
+		}
 
-					deferrals.Moves = append(deferrals.Moves,
-						EncodingDeferralMove{
-							DeferredOwner:      deferredOwner,
-							DeferredStorageKey: deferredStorageKey,
-							NewOwner:           owner,
-							NewStorageKey:      joinPath(valuePath),
-						},
-					)
-				}
-			}
+		elements, _, err := e.encodeArrayElements(v, path, segmentDeferrals, start, end, true)
+		if err != nil {
+			return err
+		}
+
+		for _, newSegment := range e.newArraySegments(elements, segmentDeferrals) {
+			references = append(references, newSegment)
+			size += newSegment.size
+		}
+
+		start = end
+	}
+
+	if loaded && size < inlineThreshold {
+		return e.encodeInlineArray(v, path, deferrals)
+	}
+
+	deferrals.append(segmentDeferrals)
+
+	return e.writeSegmentedArray(v.StaticType(), references)
+}
+
+// arraySegmentNeedsEncoding returns true if the given segment of the array,
+// which contains the elements starting at the given index, must be encoded again:
+// Modified segments, segments which contain modified elements,
+// and loaded segments which belong to another stored value.
+//
+func arraySegmentNeedsEncoding(v *ArrayValue, segment *arraySegment, start int, originMatches bool) bool {
+	if segment.modified {
+		return true
+	}
+
+	if !segment.loaded {
+		return false
+	}
+
+	if !originMatches {
+		return true
+	}
+
+	for _, value := range v.values[start : start+segment.count] {
+		// Elements which are not loaded are not modified
+		if value != nil && value.IsModified() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newArraySegments splits the given encoded elements into segments,
+// adds the segments to the deferrals, and returns references to them.
+//
+func (e *EncoderV5) newArraySegments(elements [][]byte, deferrals *EncodingDeferrals) []*arraySegment {
+	var segments []*arraySegment
+
+	start := 0
+	for _, count := range splitArraySegments(elements) {
+		data := newSegmentData(count, elements[start:start+count])
+		id := newSegmentID(data)
+
+		deferrals.Segments = append(deferrals.Segments,
+			EncodingDeferralSegment{
+				ID:   id,
+				Data: data,
+			},
+		)
+
+		segments = append(segments, &arraySegment{
+			id:    id,
+			count: count,
+			size:  len(data),
+		})
+
+		start += count
+	}
+
+	return segments
+}
+
+// writeSegmentedArray writes a segmented array with the given segments as
+// cbor.Tag{
+//     Number: cborTagSegmentedArrayValue,
+//     Content: cborArray{
+//         encodedSegmentedArrayValueStaticTypeFieldKeyV5: []interface{}(v.type),
+//         encodedSegmentedArrayValueSegmentsFieldKeyV5:   []interface{}(segments),
+//     },
+// }
+//
+// Each segment is encoded as
+// cborArray{
+//     encodedArraySegmentReferenceCountFieldKeyV5: uint64(count),
+//     encodedArraySegmentReferenceIDFieldKeyV5:    []byte(id),
+// }
+//
+func (e *EncoderV5) writeSegmentedArray(staticType StaticType, segments []*arraySegment) error {
+
+	e.segmentReferences = true
+
+	// Encode tag number and array head
+	err := e.enc.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, cborTagSegmentedArrayValue,
+		// array, 2 items follow
+		0x82,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Encode array static type at array index encodedSegmentedArrayValueStaticTypeFieldKeyV5
+	err = e.encodeStaticType(staticType)
+	if err != nil {
+		return err
+	}
+
+	// Encode segments (as array) at array index encodedSegmentedArrayValueSegmentsFieldKeyV5
+	err = e.enc.EncodeArrayHead(uint64(len(segments)))
+	if err != nil {
+		return err
+	}
+
+	for _, segment := range segments {
+		err = e.enc.EncodeRawBytes([]byte{
+			// array, 2 items follow
+			0x82,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeUint64(uint64(segment.count))
+		if err != nil {
+			return err
+		}
+
+		// NOTE: copy, the segment is referenced
+		id := segment.id
+		err = e.enc.EncodeBytes(id[:])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NOTE: NEVER change, only add/increment; ensure uint64
+const (
+	// encodedDictionaryValueTypeFieldKeyV5    uint64 = 0
+	// encodedDictionaryValueKeysFieldKeyV5    uint64 = 1
+	// encodedDictionaryValueEntriesFieldKeyV5 uint64 = 2
+
+	// !!! *WARNING* !!!
+	//
+	// encodedDictionaryValueLength MUST be updated when new element is added.
+	// It is used to verify encoded dictionaries length during decoding.
+	encodedDictionaryValueLength = 3
+)
+
+// NOTE: NEVER change, only add/increment; ensure uint64
+const (
+	// encodedSegmentedDictionaryValueTypeFieldKeyV5     uint64 = 0
+	// encodedSegmentedDictionaryValueDeferredFieldKeyV5 uint64 = 1
+	// encodedSegmentedDictionaryValueBucketsFieldKeyV5  uint64 = 2
+
+	// !!! *WARNING* !!!
+	//
+	// encodedSegmentedDictionaryValueLength MUST be updated when new element is added.
+	// It is used to verify encoded segmented dictionaries length during decoding.
+	encodedSegmentedDictionaryValueLength = 3
+)
+
+// NOTE: NEVER change, only add/increment; ensure uint64
+const (
+	// encodedDictionaryBucketReferenceDepthFieldKeyV5  uint64 = 0
+	// encodedDictionaryBucketReferencePrefixFieldKeyV5 uint64 = 1
+	// encodedDictionaryBucketReferenceCountFieldKeyV5  uint64 = 2
+	// encodedDictionaryBucketReferenceIDFieldKeyV5     uint64 = 3
+
+	// !!! *WARNING* !!!
+	//
+	// encodedDictionaryBucketReferenceLength MUST be updated when new element is added.
+	// It is used to verify encoded dictionary bucket reference length during decoding.
+	encodedDictionaryBucketReferenceLength = 4
+)
+
+const dictionaryKeyPathPrefix = "k"
+const dictionaryValuePathPrefix = "v"
+
+// encodeDictionaryValue encodes DictionaryValue as
+// cbor.Tag{
+//			Number: cborTagDictionaryValue,
+//			Content: cborArray{
+// 				encodedDictionaryValueTypeFieldKeyV5:    []interface{}(type),
+//				encodedDictionaryValueKeysFieldKeyV5:    []interface{}(keys),
+//				encodedDictionaryValueEntriesFieldKeyV5: []interface{}(entries),
+//			},
+// }
+//
+// Large dictionaries are encoded as segmented dictionaries, see encodeSegmentedDictionary.
+//
+func (e *EncoderV5) encodeDictionaryValue(
+	v *DictionaryValue,
+	path []string,
+	deferrals *EncodingDeferrals,
+) error {
+
+	if v.segments != nil {
+		if e.canReferenceSegments() {
+			return e.encodeSegmentedDictionary(v, path, deferrals)
+		}
+
+		// Segments can only be referenced from stored values,
+		// so the dictionary is encoded inline.
+		v.ensureLoaded()
+	}
+
+	// If the dictionary is not loaded, dump the raw content as it is.
+	if v.content != nil && e.canDumpRaw(v.content) {
+		return e.encodeRaw(cborTagDictionaryValue, v.content)
+	}
+
+	if !e.canReferenceSegments() {
+		return e.encodeInlineDictionary(v, path, deferrals)
+	}
+
+	// Encode the keys and values separately, so the dictionary can be encoded
+	// as a segmented dictionary if it is large, and its entries do not reference segments themselves.
+
+	keys := v.Keys().Elements()
+
+	keyItems, keyReferences, err := e.encodeDictionaryKeys(keys, path, deferrals, false)
+	if err != nil {
+		return err
+	}
+
+	var valueItems [][]byte
+	var valueReferences bool
+
+	deferred := e.deferDictionaryValues(v)
+	if deferred {
+		e.encodeDictionaryDeferrals(v, keys, path, deferrals)
+	} else {
+		valueItems, valueReferences, err = e.encodeDictionaryValues(v, keys, path, deferrals, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	references := keyReferences || valueReferences
+
+	size := encodedItemsSize(keyItems) + encodedItemsSize(valueItems)
+
+	if !references && size > segmentationThreshold {
+		entries := newBucketEntries(keys, keyItems, valueItems)
+		buckets := e.newDictionaryBuckets(0, 0, entries, deferred, deferrals)
+		return e.writeSegmentedDictionary(v.StaticType(), deferred, buckets)
+	}
+
+	if references {
+		e.segmentReferences = true
+	}
+
+	return e.writeDictionary(v, keyItems, valueItems)
+}
+
+// deferDictionaryValues returns true if the encoding of the values of the dictionary should be deferred.
+//
+// Deferring the encoding of values is only supported if all
+// values are resources: resource typed dictionaries are moved
+//
+func (e *EncoderV5) deferDictionaryValues(v *DictionaryValue) bool {
+	if !e.deferred {
+		return false
+	}
+
+	// Iterating over the map in a non-deterministic way is OK,
+	// we only determine check if all values are resources.
+
+	for pair := v.Entries().Oldest(); pair != nil; pair = pair.Next() {
+		compositeValue, ok := pair.Value.(*CompositeValue)
+		if !ok || compositeValue.Kind() != common.CompositeKindResource {
+			return false
+		}
+	}
+
+	return true
+}
+
+// encodeInlineDictionary encodes the dictionary with all its keys and values.
+//
+func (e *EncoderV5) encodeInlineDictionary(
+	v *DictionaryValue,
+	path []string,
+	deferrals *EncodingDeferrals,
+) error {
+
+	// Encode CBOR tag number and array head
+	err := e.enc.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, cborTagDictionaryValue,
+		// array, 3 items follow
+		0x83,
+	})
+	if err != nil {
+		return err
+	}
+
+	//nolint:gocritic
+	keysPath := append(path, dictionaryKeyPathPrefix)
+
+	// (1) Encode dictionary static type at array index encodedDictionaryValueTypeFieldKeyV5
+	err = e.encodeStaticType(v.StaticType())
+	if err != nil {
+		return err
+	}
+
+	// (2) Encode keys (as array) at array index encodedDictionaryValueKeysFieldKeyV5
+	err = e.encodeInlineArray(v.Keys(), keysPath, deferrals)
+	if err != nil {
+		return err
+	}
+
+	deferred := e.deferDictionaryValues(v)
+
+	// entries is empty if encoding of values is deferred,
+	// otherwise entries size is the same as keys size.
+	keys := v.Keys().Elements()
+	entriesLength := len(keys)
+	if deferred {
+		entriesLength = 0
+	}
+
+	// (3) Encode values (as array) at array index encodedDictionaryValueEntriesFieldKeyV5
+	err = e.enc.EncodeArrayHead(uint64(entriesLength))
+	if err != nil {
+		return err
+	}
+
+	if deferred {
+		e.encodeDictionaryDeferrals(v, keys, path, deferrals)
+		return nil
+	}
+
+	// Pre-allocate and reuse valuePath.
+	//nolint:gocritic
+	valuePath := append(path, dictionaryValuePathPrefix, "")
+
+	lastValuePathIndex := len(path) + 1
+
+	for _, keyValue := range keys {
+		key := dictionaryKey(keyValue)
+		entryValue, _ := v.Entries().Get(key)
+		valuePath[lastValuePathIndex] = key
+
+		// Encode value as element in values array
+		err = e.Encode(entryValue, valuePath, deferrals)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeDictionaryDeferrals adds the values of the dictionary with the given keys to the deferrals:
+// Values which are in memory must be stored under a separate storage key in the owner's storage,
+// and values which are not loaded must be moved if the owner changed.
+//
+func (e *EncoderV5) encodeDictionaryDeferrals(
+	v *DictionaryValue,
+	keys []Value,
+	path []string,
+	deferrals *EncodingDeferrals,
+) {
+	// Pre-allocate and reuse valuePath.
+	//nolint:gocritic
+	valuePath := append(path, dictionaryValuePathPrefix, "")
+
+	lastValuePathIndex := len(path) + 1
+
+	for _, keyValue := range keys {
+		key := dictionaryKey(keyValue)
+		entryValue, _ := v.entries.Get(key)
+		valuePath[lastValuePathIndex] = key
+
+		var isDeferred bool
+		if v.deferredKeys != nil {
+			_, isDeferred = v.deferredKeys.Get(key)
+		}
+
+		// If the value is not deferred, i.e. it is in memory,
+		// then it must be stored under a separate storage key
+		// in the owner's storage.
+
+		if !isDeferred {
+			deferrals.Values = append(deferrals.Values,
+				EncodingDeferralValue{
+					Key:   joinPath(valuePath),
+					Value: entryValue,
+				},
+			)
 		} else {
-			// Encode value as element in values array
-			err = e.Encode(entryValue, valuePath, deferrals)
-			if err != nil {
-				return err
+
+			// If the value is deferred, and the deferred value
+			// is stored in another account's storage,
+			// it must be moved.
+
+			deferredOwner := *v.deferredOwner
+			owner := *v.Owner
+
+			if deferredOwner != owner {
+
+				deferredStorageKey := joinPathElements(v.deferredStorageKeyBase, key)
+
+				deferrals.Moves = append(deferrals.Moves,
+					EncodingDeferralMove{
+						DeferredOwner:      deferredOwner,
+						DeferredStorageKey: deferredStorageKey,
+						NewOwner:           owner,
+						NewStorageKey:      joinPath(valuePath),
+					},
+				)
+			}
+		}
+	}
+}
+
+// encodeDictionaryKeys encodes the given keys individually.
+//
+func (e *EncoderV5) encodeDictionaryKeys(
+	keys []Value,
+	path []string,
+	deferrals *EncodingDeferrals,
+	inSegment bool,
+) (
+	items [][]byte,
+	references bool,
+	err error,
+) {
+	// Pre-allocate and reuse keyPath.
+	//nolint:gocritic
+	keyPath := append(path, dictionaryKeyPathPrefix, "")
+
+	lastKeyPathIndex := len(path) + 1
+
+	return e.encodeItems(
+		len(keys),
+		inSegment,
+		func(enc *EncoderV5, i int) error {
+			keyPath[lastKeyPathIndex] = strconv.Itoa(i)
+			return enc.Encode(keys[i], keyPath, deferrals)
+		},
+	)
+}
+
+// encodeDictionaryValues encodes the values for the given keys individually.
+//
+func (e *EncoderV5) encodeDictionaryValues(
+	v *DictionaryValue,
+	keys []Value,
+	path []string,
+	deferrals *EncodingDeferrals,
+	inSegment bool,
+) (
+	items [][]byte,
+	references bool,
+	err error,
+) {
+	// Pre-allocate and reuse valuePath.
+	//nolint:gocritic
+	valuePath := append(path, dictionaryValuePathPrefix, "")
+
+	lastValuePathIndex := len(path) + 1
+
+	return e.encodeItems(
+		len(keys),
+		inSegment,
+		func(enc *EncoderV5, i int) error {
+			key := dictionaryKey(keys[i])
+			entryValue, _ := v.entries.Get(key)
+			valuePath[lastValuePathIndex] = key
+			return enc.Encode(entryValue, valuePath, deferrals)
+		},
+	)
+}
+
+// writeDictionary writes the dictionary with the given encoded keys and values.
+// The values are empty if the encoding of the values is deferred.
+//
+func (e *EncoderV5) writeDictionary(v *DictionaryValue, keys [][]byte, values [][]byte) error {
+
+	// Encode CBOR tag number and array head
+	err := e.enc.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, cborTagDictionaryValue,
+		// array, 3 items follow
+		0x83,
+	})
+	if err != nil {
+		return err
+	}
+
+	// (1) Encode dictionary static type at array index encodedDictionaryValueTypeFieldKeyV5
+	err = e.encodeStaticType(v.StaticType())
+	if err != nil {
+		return err
+	}
+
+	// (2) Encode keys (as array) at array index encodedDictionaryValueKeysFieldKeyV5
+	err = e.writeArray(v.Keys().StaticType(), keys)
+	if err != nil {
+		return err
+	}
+
+	// (3) Encode values (as array) at array index encodedDictionaryValueEntriesFieldKeyV5
+	err = e.enc.EncodeArrayHead(uint64(len(values)))
+	if err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		err = e.enc.EncodeRawBytes(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeSegmentedDictionary encodes a dictionary which was decoded from a segmented dictionary
+// as a segmented dictionary.
+//
+// Buckets which are not modified are referenced as they are,
+// only modified buckets are encoded again, and split if they got too large.
+// If the dictionary became small, it is encoded inline again.
+//
+func (e *EncoderV5) encodeSegmentedDictionary(
+	v *DictionaryValue,
+	path []string,
+	deferrals *EncodingDeferrals,
+) error {
+
+	segments := v.segments
+	originMatches := segments.origin.matches(v.Owner, e.rootKey)
+
+	// If the dictionary is not loaded, and it is encoded into the stored value
+	// which its segments belong to, dump the raw content as it is.
+
+	if v.content != nil {
+		if originMatches {
+			e.segmentReferences = true
+			return e.encodeRaw(cborTagSegmentedDictionaryValue, v.content)
+		}
+
+		v.ensureMetaInfoLoaded()
+	}
+
+	v.ensureEntriesIndexed()
+
+	deferred := segments.deferred
+
+	// The deferred values of all buckets might have to be moved
+	if deferred && !originMatches {
+		v.ensureLoaded()
+	}
+
+	// Buckets are encoded with separate deferrals,
+	// as the dictionary might be encoded inline instead
+
+	segmentDeferrals := &EncodingDeferrals{}
+
+	var references []*dictionaryBucket
+	size := 0
+	loaded := true
+
+	for _, bucket := range segments.buckets {
+
+		if !dictionaryBucketNeedsEncoding(v, bucket, originMatches) {
+
+			// The bucket belongs to another stored value, copy it
+
+			if !originMatches && bucket.count > 0 {
+				data, err := segments.origin.loadData(bucket.id)
+				if err != nil {
+					return err
+				}
+
+				segmentDeferrals.Segments = append(segmentDeferrals.Segments,
+					EncodingDeferralSegment{
+						ID:   bucket.id,
+						Data: data,
+					},
+				)
+			}
+
+			references = append(references, bucket)
+			size += bucket.size
+			loaded = loaded && bucket.loaded
+
+			continue
+		}
+
+		entries, err := e.encodeDictionaryBucketEntries(v, bucket, path, segmentDeferrals)
+		if err != nil {
+			return err
+		}
+
+		newBuckets := e.newDictionaryBuckets(bucket.depth, bucket.prefix, entries, deferred, segmentDeferrals)
+		for _, newBucket := range newBuckets {
+			references = append(references, newBucket)
+			size += newBucket.size
+		}
+	}
+
+	if loaded && size < inlineThreshold {
+		v.ensureLoaded()
+		return e.encodeInlineDictionary(v, path, deferrals)
+	}
+
+	if deferred {
+		var keys []Value
+		for _, bucket := range segments.buckets {
+			keys = append(keys, bucket.keys...)
+		}
+
+		e.encodeDictionaryDeferrals(v, keys, path, deferrals)
+	}
+
+	deferrals.append(segmentDeferrals)
+
+	return e.writeSegmentedDictionary(v.StaticType(), deferred, references)
+}
+
+// dictionaryBucketNeedsEncoding returns true if the given bucket of the dictionary must be encoded again:
+// Modified buckets, buckets which contain modified values,
+// and loaded buckets which belong to another stored value.
+//
+func dictionaryBucketNeedsEncoding(v *DictionaryValue, bucket *dictionaryBucket, originMatches bool) bool {
+	if bucket.modified {
+		return true
+	}
+
+	if !bucket.loaded {
+		return false
+	}
+
+	if !originMatches {
+		return true
+	}
+
+	// Deferred values are not encoded in the bucket
+	if v.segments.deferred {
+		return false
+	}
+
+	for _, keyValue := range bucket.keys {
+		value, _ := v.entries.Get(dictionaryKey(keyValue))
+		if value != nil && value.IsModified() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// encodeDictionaryBucketEntries encodes the entries of the given bucket of the dictionary.
+//
+func (e *EncoderV5) encodeDictionaryBucketEntries(
+	v *DictionaryValue,
+	bucket *dictionaryBucket,
+	path []string,
+	deferrals *EncodingDeferrals,
+) ([]bucketEntry, error) {
+
+	keys := bucket.keys
+
+	keyItems, _, err := e.encodeDictionaryKeys(keys, path, deferrals, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var valueItems [][]byte
+	if !v.segments.deferred {
+		valueItems, _, err = e.encodeDictionaryValues(v, keys, path, deferrals, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newBucketEntries(keys, keyItems, valueItems), nil
+}
+
+// newBucketEntries returns the bucket entries for the given keys and encoded keys and values.
+// The values are empty if the encoding of the values is deferred.
+//
+func newBucketEntries(keys []Value, keyItems [][]byte, valueItems [][]byte) []bucketEntry {
+	entries := make([]bucketEntry, len(keys))
+
+	for i, keyValue := range keys {
+		data := keyItems[i]
+		if valueItems != nil {
+			data = append(append([]byte{}, data...), valueItems[i]...)
+		}
+
+		entries[i] = bucketEntry{
+			hash: segmentKeyHash(dictionaryKey(keyValue)),
+			data: data,
+		}
+	}
+
+	return entries
+}
+
+// newDictionaryBuckets splits the given entries of the bucket with the given depth and prefix
+// into buckets, adds the buckets to the deferrals, and returns references to them.
+//
+func (e *EncoderV5) newDictionaryBuckets(
+	depth uint,
+	prefix uint64,
+	entries []bucketEntry,
+	deferred bool,
+	deferrals *EncodingDeferrals,
+) []*dictionaryBucket {
+
+	var buckets []*dictionaryBucket
+
+	for _, split := range splitDictionaryBucket(depth, prefix, entries) {
+
+		bucket := &dictionaryBucket{
+			depth:  split.depth,
+			prefix: split.prefix,
+			count:  len(split.entries),
+		}
+
+		// Empty buckets have no segment
+		if bucket.count > 0 {
+			items := make([][]byte, len(split.entries))
+			for i, entry := range split.entries {
+				items[i] = entry.data
+			}
+
+			itemCount := bucket.count
+			if !deferred {
+				itemCount *= 2
 			}
+
+			data := newSegmentData(itemCount, items)
+			bucket.id = newSegmentID(data)
+			bucket.size = len(data)
+
+			deferrals.Segments = append(deferrals.Segments,
+				EncodingDeferralSegment{
+					ID:   bucket.id,
+					Data: data,
+				},
+			)
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets
+}
+
+// writeSegmentedDictionary writes a segmented dictionary with the given buckets as
+// cbor.Tag{
+//			Number: cborTagSegmentedDictionaryValue,
+//			Content: cborArray{
+// 				encodedSegmentedDictionaryValueTypeFieldKeyV5:     []interface{}(type),
+// 				encodedSegmentedDictionaryValueDeferredFieldKeyV5: bool(deferred),
+// 				encodedSegmentedDictionaryValueBucketsFieldKeyV5:  []interface{}(buckets),
+//			},
+// }
+//
+// Each bucket is encoded as
+// cborArray{
+//     encodedDictionaryBucketReferenceDepthFieldKeyV5:  uint64(depth),
+//     encodedDictionaryBucketReferencePrefixFieldKeyV5: uint64(prefix),
+//     encodedDictionaryBucketReferenceCountFieldKeyV5:  uint64(count),
+//     encodedDictionaryBucketReferenceIDFieldKeyV5:     []byte(id), or nil if the bucket is empty
+// }
+//
+func (e *EncoderV5) writeSegmentedDictionary(
+	staticType StaticType,
+	deferred bool,
+	buckets []*dictionaryBucket,
+) error {
+
+	e.segmentReferences = true
+
+	// Encode CBOR tag number and array head
+	err := e.enc.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, cborTagSegmentedDictionaryValue,
+		// array, 3 items follow
+		0x83,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Encode dictionary static type at array index encodedSegmentedDictionaryValueTypeFieldKeyV5
+	err = e.encodeStaticType(staticType)
+	if err != nil {
+		return err
+	}
+
+	// Encode deferral at array index encodedSegmentedDictionaryValueDeferredFieldKeyV5
+	err = e.enc.EncodeBool(deferred)
+	if err != nil {
+		return err
+	}
+
+	// Encode buckets (as array) at array index encodedSegmentedDictionaryValueBucketsFieldKeyV5
+	err = e.enc.EncodeArrayHead(uint64(len(buckets)))
+	if err != nil {
+		return err
+	}
+
+	for _, bucket := range buckets {
+		err = e.enc.EncodeRawBytes([]byte{
+			// array, 4 items follow
+			0x84,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeUint64(uint64(bucket.depth))
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeUint64(bucket.prefix)
+		if err != nil {
+			return err
+		}
+
+		err = e.enc.EncodeUint64(uint64(bucket.count))
+		if err != nil {
+			return err
+		}
+
+		if bucket.count == 0 {
+			err = e.enc.EncodeNil()
+		} else {
+			// NOTE: copy, the bucket is referenced
+			id := bucket.id
+			err = e.enc.EncodeBytes(id[:])
+		}
+		if err != nil {
+			return err
 		}
 	}
 
@@ -1033,12 +2120,19 @@ const (
 	// encodedCompositeValueKindFieldKeyV5                uint64 = 1
 	// encodedCompositeValueFieldsFieldKeyV5              uint64 = 2
 	// encodedCompositeValueQualifiedIdentifierFieldKeyV5 uint64 = 3
+	// encodedCompositeValueTypeArgumentsFieldKeyV5       uint64 = 4
 
 	// !!! *WARNING* !!!
 	//
 	// encodedCompositeValueLength MUST be updated when new element is added.
 	// It is used to verify encoded composites length during decoding.
 	encodedCompositeValueLength = 4
+
+	// encodedCompositeValueWithTypeArgumentsLength is the length
+	// of encoded values of generic composite types.
+	// The type arguments are optional, so the encoding of
+	// values of non-generic composite types is unchanged.
+	encodedCompositeValueWithTypeArgumentsLength = 5
 )
 
 // encodeCompositeValue encodes CompositeValue as
@@ -1049,6 +2143,7 @@ const (
 //			encodedCompositeValueKindFieldKeyV5:                uint(v.Kind),
 //			encodedCompositeValueFieldsFieldKeyV5:              []interface{}(fields),
 //			encodedCompositeValueQualifiedIdentifierFieldKeyV5: string(v.QualifiedIdentifier),
+//			encodedCompositeValueTypeArgumentsFieldKeyV5:       []StaticType(v.TypeArguments), (optional)
 //		},
 // }
 func (e *EncoderV5) encodeCompositeValue(
@@ -1069,18 +2164,34 @@ func (e *EncoderV5) encodeCompositeValue(
 
 	// If the value is not loaded, dump the raw content as it is.
 	if v.content != nil {
-		err = e.enc.EncodeRawBytes(v.content)
-		if err != nil {
-			return err
+		if e.canDumpRaw(v.content) {
+			err = e.enc.EncodeRawBytes(v.content)
+			if err != nil {
+				return err
+			}
+
+			return nil
 		}
 
-		return nil
+		v.ensureMetaInfoLoaded()
 	}
 
+	typeArguments := v.TypeArguments()
+	hasTypeArguments := len(typeArguments) > 0
+
 	// Encode array head
-	err = e.enc.EncodeRawBytes([]byte{
+
+	arrayHead := byte(
 		// array, 4 items follow
 		0x84,
+	)
+	if hasTypeArguments {
+		// array, 5 items follow
+		arrayHead = 0x85
+	}
+
+	err = e.enc.EncodeRawBytes([]byte{
+		arrayHead,
 	})
 	if err != nil {
 		return err
@@ -1100,6 +2211,10 @@ func (e *EncoderV5) encodeCompositeValue(
 
 	// Encode fields (as array) at array index encodedCompositeValueFieldsFieldKeyV5
 
+	if v.fieldsContent != nil && !e.canDumpRaw(v.fieldsContent) {
+		v.ensureFieldsIndexed()
+	}
+
 	// If the fields are not loaded, dump the raw fields content as it is.
 	if v.fieldsContent != nil {
 		err := e.enc.EncodeRawBytes(v.fieldsContent)
@@ -1107,7 +2222,9 @@ func (e *EncoderV5) encodeCompositeValue(
 			return err
 		}
 	} else {
-		fields := v.Fields()
+		// NOTE: do not load the fields, field values which are not loaded yet,
+		// i.e. nil, are encoded from their raw content.
+		fields := v.fields
 		err = e.enc.EncodeArrayHead(uint64(fields.Len() * 2))
 		if err != nil {
 			return err
@@ -1130,6 +2247,20 @@ func (e *EncoderV5) encodeCompositeValue(
 
 			value := pair.Value
 
+			// If the field value is not loaded, dump the raw content as it is.
+			if value == nil {
+				content := v.fieldContents[fieldName]
+				if e.canDumpRaw(content) {
+					err = e.enc.EncodeRawBytes(content)
+					if err != nil {
+						return err
+					}
+					continue
+				}
+
+				value, _ = v.getField(fieldName)
+			}
+
 			valuePath[lastValuePathIndex] = fieldName
 
 			// Encode value as fields array element
@@ -1146,6 +2277,14 @@ func (e *EncoderV5) encodeCompositeValue(
 		return err
 	}
 
+	if hasTypeArguments {
+		// Encode type arguments at array index encodedCompositeValueTypeArgumentsFieldKeyV5
+		err = e.encodeStaticTypes(typeArguments)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1277,6 +2416,22 @@ func (e *EncoderV5) encodeCapabilityValue(v CapabilityValue) error {
 	return e.encodeStaticType(v.BorrowType)
 }
 
+// encodeRangeStaticType encodes RangeStaticType as
+// cbor.Tag{
+//		Number:  cborTagRangeStaticType,
+//		Content: StaticType(v.ElementType),
+// }
+func (e *EncoderV5) encodeRangeStaticType(v RangeStaticType) error {
+	err := e.enc.EncodeRawBytes([]byte{
+		// tag number
+		0xd8, cborTagRangeStaticType,
+	})
+	if err != nil {
+		return err
+	}
+	return e.encodeStaticType(v.ElementType)
+}
+
 // NOTE: NEVER change, only add/increment; ensure uint64
 const (
 	// encodedAddressLocationAddressFieldKeyV5 uint64 = 0
@@ -1429,6 +2584,9 @@ func (e *EncoderV5) encodeStaticType(t StaticType) error {
 	case CapabilityStaticType:
 		return e.encodeCapabilityStaticType(v)
 
+	case RangeStaticType:
+		return e.encodeRangeStaticType(v)
+
 	default:
 		return fmt.Errorf("unsupported static type: %T", t)
 	}
@@ -1470,12 +2628,19 @@ func (e *EncoderV5) encodeOptionalStaticType(v OptionalStaticType) error {
 const (
 	// encodedCompositeStaticTypeLocationFieldKeyV5            uint64 = 0
 	// encodedCompositeStaticTypeQualifiedIdentifierFieldKeyV5 uint64 = 1
+	// encodedCompositeStaticTypeTypeArgumentsFieldKeyV5       uint64 = 2
 
 	// !!! *WARNING* !!!
 	//
 	// encodedCompositeStaticTypeLength MUST be updated when new element is added.
 	// It is used to verify encoded composite static type length during decoding.
 	encodedCompositeStaticTypeLength = 2
+
+	// encodedCompositeStaticTypeWithTypeArgumentsLength is the length
+	// of encoded composite static types which have type arguments.
+	// The type arguments are optional, so the encoding of
+	// non-generic composite static types is unchanged.
+	encodedCompositeStaticTypeWithTypeArgumentsLength = 3
 )
 
 // encodeCompositeStaticType encodes CompositeStaticType as
@@ -1484,15 +2649,27 @@ const (
 // 			Content: cborArray{
 //				encodedCompositeStaticTypeLocationFieldKeyV5:            Location(v.Location),
 //				encodedCompositeStaticTypeQualifiedIdentifierFieldKeyV5: string(v.QualifiedIdentifier),
+//				encodedCompositeStaticTypeTypeArgumentsFieldKeyV5:       []StaticType(v.TypeArguments), (optional)
 //		},
 // }
 func (e *EncoderV5) encodeCompositeStaticType(v CompositeStaticType) error {
+	hasTypeArguments := len(v.TypeArguments) > 0
+
 	// Encode tag number and array head
+
+	arrayHead := byte(
+		// array, 2 items follow
+		0x82,
+	)
+	if hasTypeArguments {
+		// array, 3 items follow
+		arrayHead = 0x83
+	}
+
 	err := e.enc.EncodeRawBytes([]byte{
 		// tag number
 		0xd8, cborTagCompositeStaticType,
-		// array, 2 items follow
-		0x82,
+		arrayHead,
 	})
 	if err != nil {
 		return err
@@ -1505,7 +2682,35 @@ func (e *EncoderV5) encodeCompositeStaticType(v CompositeStaticType) error {
 	}
 
 	// Encode qualified identifier at array index encodedCompositeStaticTypeQualifiedIdentifierFieldKeyV5
-	return e.enc.EncodeString(v.QualifiedIdentifier)
+	err = e.enc.EncodeString(v.QualifiedIdentifier)
+	if err != nil {
+		return err
+	}
+
+	if hasTypeArguments {
+		// Encode type arguments at array index encodedCompositeStaticTypeTypeArgumentsFieldKeyV5
+		return e.encodeStaticTypes(v.TypeArguments)
+	}
+
+	return nil
+}
+
+// encodeStaticTypes encodes the given static types as an array
+//
+func (e *EncoderV5) encodeStaticTypes(types []StaticType) error {
+	err := e.enc.EncodeArrayHead(uint64(len(types)))
+	if err != nil {
+		return err
+	}
+
+	for _, ty := range types {
+		err = e.encodeStaticType(ty)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // NOTE: NEVER change, only add/increment; ensure uint64
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/fixedpoint"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/common/orderedmap"
 	"github.com/onflow/cadence/runtime/sema"
@@ -567,6 +568,63 @@ func TestEncodeDecodeComposite(t *testing.T) {
 		)
 	})
 
+	t.Run("empty structure, type arguments", func(t *testing.T) {
+		expected := NewCompositeValue(
+			utils.TestLocation,
+			"TestStruct",
+			common.CompositeKindStructure,
+			NewStringValueOrderedMap(),
+			nil,
+		)
+		expected.typeArguments = []StaticType{
+			PrimitiveStaticTypeInt,
+			PrimitiveStaticTypeString,
+		}
+		expected.modified = false
+
+		encoded := []byte{
+			// tag
+			0xd8, cborTagCompositeValue,
+			// array, 5 items follow
+			0x85,
+
+			// tag
+			0xd8, cborTagStringLocation,
+			// UTF-8 string, length 4
+			0x64,
+			// t, e, s, t
+			0x74, 0x65, 0x73, 0x74,
+
+			// positive integer 1
+			0x1,
+
+			// array, 0 items follow
+			0x80,
+
+			// UTF-8 string, length 10
+			0x6a,
+			0x54, 0x65, 0x73, 0x74, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+
+			// array, 2 items follow
+			0x82,
+			// tag
+			0xd8, cborTagPrimitiveStaticType,
+			// positive integer 36
+			0x18, 0x24,
+			// tag
+			0xd8, cborTagPrimitiveStaticType,
+			// positive integer 8
+			0x8,
+		}
+
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value:   expected,
+				encoded: encoded,
+			},
+		)
+	})
+
 	t.Run("empty, address location", func(t *testing.T) {
 		expected := NewCompositeValue(
 			common.AddressLocation{
@@ -2548,6 +2606,179 @@ func TestEncodeDecodeUFix64Value(t *testing.T) {
 	})
 }
 
+func TestEncodeDecodeFix128Value(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("zero", func(t *testing.T) {
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value: NewFix128ValueFromBigInt(big.NewInt(0)),
+				encoded: []byte{
+					0xd8, cborTagFix128Value,
+					// positive bignum
+					0xc2,
+					// byte string, length 0
+					0x40,
+				},
+			},
+		)
+	})
+
+	t.Run("positive", func(t *testing.T) {
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value: NewFix128ValueFromBigInt(big.NewInt(42)),
+				encoded: []byte{
+					0xd8, cborTagFix128Value,
+					// positive bignum
+					0xc2,
+					// byte string, length 1
+					0x41,
+					0x2a,
+				},
+			},
+		)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value: NewFix128ValueFromBigInt(big.NewInt(-42)),
+				encoded: []byte{
+					0xd8, cborTagFix128Value,
+					// negative bignum
+					0xc3,
+					// byte string, length 1
+					0x41,
+					0x29,
+				},
+			},
+		)
+	})
+
+	t.Run("max", func(t *testing.T) {
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value: NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMaxBig),
+				encoded: []byte{
+					0xd8, cborTagFix128Value,
+					// positive bignum
+					0xc2,
+					// byte string, length 16
+					0x50,
+					0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+					0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				},
+			},
+		)
+	})
+
+	t.Run("> max", func(t *testing.T) {
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				encoded: []byte{
+					0xd8, cborTagFix128Value,
+					// positive bignum
+					0xc2,
+					// byte string, length 16
+					0x50,
+					0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				},
+				invalid: true,
+			},
+		)
+	})
+}
+
+func TestEncodeDecodeUFix128Value(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("zero", func(t *testing.T) {
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value: NewUFix128ValueFromBigInt(big.NewInt(0)),
+				encoded: []byte{
+					0xd8, cborTagUFix128Value,
+					// positive bignum
+					0xc2,
+					// byte string, length 0
+					0x40,
+				},
+			},
+		)
+	})
+
+	t.Run("positive", func(t *testing.T) {
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value: NewUFix128ValueFromBigInt(big.NewInt(42)),
+				encoded: []byte{
+					0xd8, cborTagUFix128Value,
+					// positive bignum
+					0xc2,
+					// byte string, length 1
+					0x41,
+					0x2a,
+				},
+			},
+		)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				encoded: []byte{
+					0xd8, cborTagUFix128Value,
+					// negative bignum
+					0xc3,
+					// byte string, length 1
+					0x41,
+					0x29,
+				},
+				invalid: true,
+			},
+		)
+	})
+
+	t.Run("max", func(t *testing.T) {
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value: NewUFix128ValueFromBigInt(fixedpoint.UFix128TypeMaxBig),
+				encoded: []byte{
+					0xd8, cborTagUFix128Value,
+					// positive bignum
+					0xc2,
+					// byte string, length 16
+					0x50,
+					0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+					0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				},
+			},
+		)
+	})
+
+	t.Run("> max", func(t *testing.T) {
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				encoded: []byte{
+					0xd8, cborTagUFix128Value,
+					// positive bignum
+					0xc2,
+					// byte string, length 17
+					0x51,
+					0x01,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				},
+				invalid: true,
+			},
+		)
+	})
+}
+
 func TestEncodeDecodeAddressValue(t *testing.T) {
 
 	t.Parallel()
@@ -3017,6 +3248,50 @@ func TestEncodeDecodeLinkValue(t *testing.T) {
 		)
 	})
 
+	t.Run("composite, struct, type arguments", func(t *testing.T) {
+		value := LinkValue{
+			TargetPath: publicPathValue,
+			Type: CompositeStaticType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "SimpleStruct",
+				TypeArguments: []StaticType{
+					PrimitiveStaticTypeString,
+				},
+			},
+		}
+
+		//nolint:gocritic
+		encoded := append(
+			expectedLinkEncodingPrefix[:],
+			// tag
+			0xd8, cborTagCompositeStaticType,
+			// array, 3 items follow
+			0x83,
+			// tag
+			0xd8, cborTagStringLocation,
+			// UTF-8 string, length 4
+			0x64,
+			// t, e, s, t
+			0x74, 0x65, 0x73, 0x74,
+			// UTF-8 string, length 12
+			0x6c,
+			// SimpleStruct
+			0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+			// array, 1 items follow
+			0x81,
+			// tag
+			0xd8, cborTagPrimitiveStaticType,
+			0x8,
+		)
+
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value:   value,
+				encoded: encoded,
+			},
+		)
+	})
+
 	t.Run("interface, struct, qualified identifier", func(t *testing.T) {
 		value := LinkValue{
 			TargetPath: publicPathValue,
@@ -3590,6 +3865,34 @@ func TestEncodeDecodeTypeValue(t *testing.T) {
 		)
 	})
 
+	t.Run("range, Int", func(t *testing.T) {
+		value := TypeValue{
+			Type: RangeStaticType{
+				ElementType: PrimitiveStaticTypeInt,
+			},
+		}
+
+		encoded := []byte{
+			// tag
+			0xd8, cborTagTypeValue,
+			// array, 1 items follow
+			0x81,
+			// tag
+			0xd8, cborTagRangeStaticType,
+			// tag
+			0xd8, cborTagPrimitiveStaticType,
+			// positive integer 36
+			0x18, 0x24,
+		}
+
+		testEncodeDecode(t,
+			encodeDecodeTest{
+				value:   value,
+				encoded: encoded,
+			},
+		)
+	})
+
 	t.Run("without static type", func(t *testing.T) {
 		value := TypeValue{
 			Type: nil,
@@ -163,6 +163,28 @@ func (e ConditionError) Error() string {
 	return fmt.Sprintf("%s failed: %s", e.ConditionKind.Name(), e.Message)
 }
 
+// SideEffectInConditionError
+
+type SideEffectInConditionError struct {
+	Operation string
+	LocationRange
+}
+
+func (e SideEffectInConditionError) Error() string {
+	return fmt.Sprintf("invalid side effect in condition: %s", e.Operation)
+}
+
+// SideEffectInViewFunctionError
+
+type SideEffectInViewFunctionError struct {
+	Operation string
+	LocationRange
+}
+
+func (e SideEffectInViewFunctionError) Error() string {
+	return fmt.Sprintf("invalid side effect in view function: %s", e.Operation)
+}
+
 // RedeclarationError
 
 type RedeclarationError struct {
@@ -207,6 +229,14 @@ func (e DivisionByZeroError) Error() string {
 	return "division by zero"
 }
 
+// NegativeSquareRootError
+
+type NegativeSquareRootError struct{}
+
+func (e NegativeSquareRootError) Error() string {
+	return "square root of negative number"
+}
+
 // DestroyedCompositeError
 
 type DestroyedCompositeError struct {
@@ -350,6 +380,34 @@ func (e ArrayIndexOutOfBoundsError) Error() string {
 	)
 }
 
+// ArraySliceIndicesError
+//
+type ArraySliceIndicesError struct {
+	FromIndex int
+	UpToIndex int
+	Size      int
+	LocationRange
+}
+
+func (e ArraySliceIndicesError) Error() string {
+	return fmt.Sprintf(
+		"invalid array slice indices: %d to %d, but size is %d",
+		e.FromIndex,
+		e.UpToIndex,
+		e.Size,
+	)
+}
+
+// RangeStepZeroError
+//
+type RangeStepZeroError struct {
+	LocationRange
+}
+
+func (e RangeStepZeroError) Error() string {
+	return "invalid range step: the step must not be zero"
+}
+
 // StringIndexOutOfBoundsError
 //
 type StringIndexOutOfBoundsError struct {
@@ -496,3 +554,19 @@ func (e ContainerMutationError) Error() string {
 		e.ExpectedType.QualifiedString(),
 	)
 }
+
+// ExecutionCancelledError is reported when the execution is aborted,
+// because the context of the execution was cancelled, or its deadline was exceeded.
+// Err is the error of the context, i.e. context.Canceled or context.DeadlineExceeded
+//
+type ExecutionCancelledError struct {
+	Err error
+}
+
+func (e ExecutionCancelledError) Unwrap() error {
+	return e.Err
+}
+
+func (e ExecutionCancelledError) Error() string {
+	return fmt.Sprintf("execution cancelled: %s", e.Err)
+}
@@ -19,11 +19,15 @@
 package interpreter
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math"
 	goRuntime "runtime"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/onflow/cadence/fixedpoint"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
@@ -202,6 +206,13 @@ type CompositeTypeCode struct {
 	DestructorFunction FunctionValue
 }
 
+// ExtensionCode contains the "prepared" / "callable" "code"
+// for the functions of an extension.
+//
+type ExtensionCode struct {
+	Functions map[string]FunctionValue
+}
+
 type FunctionWrapper = func(inner FunctionValue) FunctionValue
 
 // WrapperCode contains the "prepared" / "callable" "code"
@@ -217,12 +228,13 @@ type WrapperCode struct {
 }
 
 // TypeCodes is the value which stores the "prepared" / "callable" "code"
-// of all composite types, interface types, and type requirements.
+// of all composite types, interface types, type requirements, and extensions.
 //
 type TypeCodes struct {
 	CompositeCodes       map[sema.TypeID]CompositeTypeCode
 	InterfaceCodes       map[sema.TypeID]WrapperCode
 	TypeRequirementCodes map[sema.TypeID]WrapperCode
+	ExtensionCodes       map[sema.TypeID]ExtensionCode
 }
 
 func (c TypeCodes) Merge(codes TypeCodes) {
@@ -241,6 +253,10 @@ func (c TypeCodes) Merge(codes TypeCodes) {
 	for typeID, code := range codes.TypeRequirementCodes { //nolint:maprangecheck
 		c.TypeRequirementCodes[typeID] = code
 	}
+
+	for typeID, code := range codes.ExtensionCodes { //nolint:maprangecheck
+		c.ExtensionCodes[typeID] = code
+	}
 }
 
 type Interpreter struct {
@@ -252,6 +268,7 @@ type Interpreter struct {
 	Globals                        GlobalVariables
 	allInterpreters                map[common.LocationID]*Interpreter
 	typeCodes                      TypeCodes
+	conditionState                 *conditionState
 	Transactions                   []*HostFunctionValue
 	onEventEmitted                 OnEventEmittedFunc
 	onStatement                    OnStatementFunc
@@ -272,6 +289,7 @@ type Interpreter struct {
 	ExitHandler                    ExitHandlerFunc
 	interpreted                    bool
 	statement                      ast.Statement
+	ctx                            context.Context
 }
 
 type Option func(*Interpreter) error
@@ -326,6 +344,20 @@ func WithOnInvokedFunctionReturnHandler(handler OnInvokedFunctionReturnFunc) Opt
 	}
 }
 
+// WithContext returns an interpreter option which sets
+// the given context as the context of the execution.
+//
+// The context is checked before each statement, loop iteration, and function invocation.
+// When it is cancelled, or its deadline is exceeded,
+// the execution is aborted with an ExecutionCancelledError.
+//
+func WithContext(ctx context.Context) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetContext(ctx)
+		return nil
+	}
+}
+
 // WithPredeclaredValues returns an interpreter option which declares
 // the given the predeclared values.
 //
@@ -477,6 +509,15 @@ func WithAllInterpreters(allInterpreters map[common.LocationID]*Interpreter) Opt
 	}
 }
 
+// withConditionState returns an interpreter option which sets the condition state.
+//
+func withConditionState(state *conditionState) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.conditionState = state
+		return nil
+	}
+}
+
 // withTypeCodes returns an interpreter option which sets the type codes.
 //
 func withTypeCodes(typeCodes TypeCodes) Option {
@@ -514,7 +555,9 @@ func NewInterpreter(program *Program, location common.Location, options ...Optio
 			CompositeCodes:       map[sema.TypeID]CompositeTypeCode{},
 			InterfaceCodes:       map[sema.TypeID]WrapperCode{},
 			TypeRequirementCodes: map[sema.TypeID]WrapperCode{},
+			ExtensionCodes:       map[sema.TypeID]ExtensionCode{},
 		}),
+		withConditionState(&conditionState{}),
 	}
 
 	for _, option := range defaultOptions {
@@ -546,6 +589,12 @@ func (interpreter *Interpreter) SetOnStatementHandler(function OnStatementFunc)
 	interpreter.onStatement = function
 }
 
+// SetContext sets the context of the execution.
+//
+func (interpreter *Interpreter) SetContext(ctx context.Context) {
+	interpreter.ctx = ctx
+}
+
 // SetOnLoopIterationHandler sets the function that is triggered when a loop iteration is about to be executed.
 //
 func (interpreter *Interpreter) SetOnLoopIterationHandler(function OnLoopIterationFunc) {
@@ -917,6 +966,10 @@ func (interpreter *Interpreter) VisitProgram(program *ast.Program) ast.Repr {
 		interpreter.visitGlobalDeclaration(declaration)
 	}
 
+	for _, declaration := range program.ExtensionDeclarations() {
+		interpreter.visitGlobalDeclaration(declaration)
+	}
+
 	for _, declaration := range program.FunctionDeclarations() {
 		interpreter.visitGlobalDeclaration(declaration)
 	}
@@ -1074,7 +1127,12 @@ func (interpreter *Interpreter) visitFunctionBody(
 	interpreter.activations.PushNewWithCurrent()
 	defer interpreter.activations.Pop()
 
-	result := interpreter.visitStatements(beforeStatements)
+	// The `before` expressions are part of the post-conditions
+
+	var result controlReturn
+	interpreter.withCondition(func() {
+		result = interpreter.visitStatements(beforeStatements)
+	})
 	if ret, ok := result.(functionReturn); ok {
 		return ret.Value
 	}
@@ -1120,9 +1178,15 @@ func (interpreter *Interpreter) visitFunctionBody(
 }
 
 func (interpreter *Interpreter) visitConditions(conditions []*ast.Condition) {
-	for _, condition := range conditions {
-		interpreter.visitCondition(condition)
+	if len(conditions) == 0 {
+		return
 	}
+
+	interpreter.withCondition(func() {
+		for _, condition := range conditions {
+			interpreter.visitCondition(condition)
+		}
+	})
 }
 
 func (interpreter *Interpreter) visitCondition(condition *ast.Condition) {
@@ -1321,6 +1385,10 @@ func (interpreter *Interpreter) declareNonEnumCompositeValue(
 			memberIdentifier := nestedCompositeDeclaration.Identifier.Identifier
 			nestedVariables.Set(memberIdentifier, nestedVariable)
 		}
+
+		for _, nestedExtensionDeclaration := range declaration.Members.Extensions() {
+			interpreter.declareExtension(nestedExtensionDeclaration, lexicalScope)
+		}
 	})()
 
 	compositeType := interpreter.Program.Elaboration.CompositeDeclarationTypes[declaration]
@@ -1479,6 +1547,16 @@ func (interpreter *Interpreter) declareNonEnumCompositeValue(
 				modified: true,
 			}
 
+			// Instantiate the generic composite type, if any,
+			// with the type arguments of the constructor invocation
+
+			if len(compositeType.TypeParameters) > 0 {
+				value.typeArguments = interpreter.compositeTypeArguments(
+					compositeType,
+					invocation.TypeParameterTypes,
+				)
+			}
+
 			invocation.Self = value
 
 			if declaration.CompositeKind == common.CompositeKindContract {
@@ -1527,6 +1605,30 @@ func (interpreter *Interpreter) declareNonEnumCompositeValue(
 	return lexicalScope, variable
 }
 
+// compositeTypeArguments returns the static types of the type arguments
+// for the type parameters of the given generic composite type
+//
+func (interpreter *Interpreter) compositeTypeArguments(
+	compositeType *sema.CompositeType,
+	typeParameterTypes *sema.TypeParameterTypeOrderedMap,
+) []StaticType {
+
+	typeArguments := make([]StaticType, len(compositeType.TypeParameters))
+
+	for i, typeParameter := range compositeType.TypeParameters {
+		var ty sema.Type
+		if typeParameterTypes != nil {
+			ty, _ = typeParameterTypes.Get(typeParameter)
+		}
+		if ty == nil {
+			ty = typeParameter.TypeBound
+		}
+		typeArguments[i] = ConvertSemaToStaticType(ty)
+	}
+
+	return typeArguments
+}
+
 func (interpreter *Interpreter) declareEnumConstructor(
 	declaration *ast.CompositeDeclaration,
 	lexicalScope *VariableActivation,
@@ -1812,6 +1914,108 @@ func (interpreter *Interpreter) VisitEnumCaseDeclaration(_ *ast.EnumCaseDeclarat
 	panic(errors.NewUnreachableError())
 }
 
+func (interpreter *Interpreter) VisitTypeAliasDeclaration(_ *ast.TypeAliasDeclaration) ast.Repr {
+	// type aliases are resolved by the checker,
+	// static types always refer to the target types
+	return nil
+}
+
+// NOTE: only called for top-level extension declarations
+func (interpreter *Interpreter) VisitExtensionDeclaration(declaration *ast.ExtensionDeclaration) ast.Repr {
+
+	// lexical scope: variables in functions are bound to what is visible at declaration time
+	lexicalScope := interpreter.activations.CurrentOrNew()
+
+	interpreter.declareExtension(declaration, lexicalScope)
+
+	return nil
+}
+
+// declareExtension prepares the functions of the extension declaration.
+//
+// The functions are not bound to a value of the extended type yet:
+// when a member of the extension is accessed, the function is bound
+// to the accessed value (see `getExtensionMember`).
+//
+func (interpreter *Interpreter) declareExtension(
+	declaration *ast.ExtensionDeclaration,
+	lexicalScope *VariableActivation,
+) {
+	extensionType := interpreter.Program.Elaboration.ExtensionDeclarationTypes[declaration]
+
+	functions := map[string]FunctionValue{}
+
+	for _, functionDeclaration := range declaration.Members.Functions() {
+		name := functionDeclaration.Identifier.Identifier
+		functions[name] =
+			interpreter.compositeFunction(
+				functionDeclaration,
+				lexicalScope,
+			)
+	}
+
+	interpreter.typeCodes.ExtensionCodes[extensionType.ID()] = ExtensionCode{
+		Functions: functions,
+	}
+}
+
+// getExtensionMember returns the function with the given name of the given extension,
+// bound to the given value of the extended type, or a reference to it
+//
+func (interpreter *Interpreter) getExtensionMember(
+	self Value,
+	extensionType *sema.ExtensionType,
+	getLocationRange func() LocationRange,
+	name string,
+) Value {
+
+	switch reference := self.(type) {
+	case *EphemeralReferenceValue:
+		referencedValue := reference.ReferencedValue()
+		if referencedValue == nil {
+			panic(DereferenceError{
+				LocationRange: getLocationRange(),
+			})
+		}
+		self = *referencedValue
+
+	case *StorageReferenceValue:
+		referencedValue := reference.ReferencedValue(interpreter)
+		if referencedValue == nil {
+			panic(DereferenceError{
+				LocationRange: getLocationRange(),
+			})
+		}
+		self = *referencedValue
+	}
+
+	compositeValue, ok := self.(*CompositeValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	compositeValue.checkStatus(getLocationRange)
+
+	typeID := extensionType.ID()
+
+	code, ok := interpreter.typeCodes.ExtensionCodes[typeID]
+	if !ok {
+		// The extension is declared in a program that was not interpreted yet
+		interpreter.EnsureLoaded(extensionType.Location)
+		code = interpreter.typeCodes.ExtensionCodes[typeID]
+	}
+
+	function, ok := code.Functions[name]
+	if !ok {
+		return nil
+	}
+
+	return BoundFunctionValue{
+		Self:     compositeValue,
+		Function: function,
+	}
+}
+
 func (interpreter *Interpreter) checkValueTransferTargetType(value Value, targetType sema.Type) bool {
 
 	if targetType == nil {
@@ -1854,6 +2058,9 @@ func (interpreter *Interpreter) copyAndConvert(
 	getLocationRange func() LocationRange,
 ) Value {
 
+	valueType = interpreter.substituteTypeArguments(valueType)
+	targetType = interpreter.substituteTypeArguments(targetType)
+
 	result := interpreter.convertAndBox(value.Copy(), valueType, targetType)
 
 	if !interpreter.checkValueTransferTargetType(result, targetType) {
@@ -1988,6 +2195,16 @@ func (interpreter *Interpreter) convert(value Value, valueType, targetType sema.
 		if !valueType.Equal(unwrappedTargetType) {
 			return ConvertUFix64(value)
 		}
+
+	case sema.Fix128Type:
+		if !valueType.Equal(unwrappedTargetType) {
+			return ConvertFix128(value)
+		}
+
+	case sema.UFix128Type:
+		if !valueType.Equal(unwrappedTargetType) {
+			return ConvertUFix128(value)
+		}
 	}
 
 	switch unwrappedTargetType.(type) {
@@ -2332,6 +2549,7 @@ func (interpreter *Interpreter) NewSubInterpreter(
 		WithPredeclaredValues(interpreter.PredeclaredValues),
 		WithOnEventEmittedHandler(interpreter.onEventEmitted),
 		WithOnStatementHandler(interpreter.onStatement),
+		WithContext(interpreter.ctx),
 		WithOnLoopIterationHandler(interpreter.onLoopIteration),
 		WithOnFunctionInvocationHandler(interpreter.onFunctionInvocation),
 		WithOnInvokedFunctionReturnHandler(interpreter.onInvokedFunctionReturn),
@@ -2344,6 +2562,7 @@ func (interpreter *Interpreter) NewSubInterpreter(
 		WithUUIDHandler(interpreter.uuidHandler),
 		WithAllInterpreters(interpreter.allInterpreters),
 		withTypeCodes(interpreter.typeCodes),
+		withConditionState(interpreter.conditionState),
 		WithAccountHandlerFunc(interpreter.accountHandler),
 		WithPublicKeyValidationHandler(interpreter.PublicKeyValidationHandler),
 		WithSignatureVerificationHandler(interpreter.SignatureVerificationHandler),
@@ -2369,6 +2588,8 @@ func (interpreter *Interpreter) ReadStored(storageAddress common.Address, key st
 }
 
 func (interpreter *Interpreter) writeStored(storageAddress common.Address, key string, value OptionalValue) {
+	interpreter.ExpectNoSideEffects("write to storage", interpreter.statementLocationRange)
+
 	value.SetOwner(&storageAddress)
 
 	interpreter.storageWriteHandler(interpreter, storageAddress, key, value)
@@ -2541,6 +2762,22 @@ var converterDeclarations = []valueConverterDeclaration{
 		min: UFix64Value(0),
 		max: UFix64Value(math.MaxUint64),
 	},
+	{
+		name: sema.Fix128TypeName,
+		convert: func(value Value) Value {
+			return ConvertFix128(value)
+		},
+		min: NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMinBig),
+		max: NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMaxBig),
+	},
+	{
+		name: sema.UFix128TypeName,
+		convert: func(value Value) Value {
+			return ConvertUFix128(value)
+		},
+		min: NewUFix128ValueFromBigInt(fixedpoint.UFix128TypeMinBig),
+		max: NewUFix128ValueFromBigInt(fixedpoint.UFix128TypeMaxBig),
+	},
 	{
 		name: "Address",
 		convert: func(value Value) Value {
@@ -2694,6 +2931,7 @@ var stringFunction = func() Value {
 		NewHostFunctionValue(
 			func(invocation Invocation) Value {
 				argument := invocation.Arguments[0].(*ArrayValue)
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, argument.Count())
 				bytes, _ := ByteArrayValueToByteSlice(argument)
 				return NewStringValue(hex.EncodeToString(bytes))
 			},
@@ -2701,6 +2939,58 @@ var stringFunction = func() Value {
 		),
 	)
 
+	addMember(
+		sema.StringTypeJoinFunctionName,
+		NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				stringsArray := invocation.Arguments[0].(*ArrayValue)
+				separator := invocation.Arguments[1].(*StringValue)
+
+				elements := stringsArray.Elements()
+
+				// The size of the result is the total size of all elements and separators
+
+				size := 0
+				if len(elements) > 0 {
+					size = (len(elements) - 1) * len(separator.Str)
+				}
+
+				strs := make([]string, len(elements))
+				for i, element := range elements {
+					invocation.Interpreter.checkCancellation()
+
+					strs[i] = element.(*StringValue).Str
+					size += len(strs[i])
+				}
+
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, size)
+
+				return NewStringValue(strings.Join(strs, separator.Str))
+			},
+			sema.StringTypeJoinFunctionType,
+		),
+	)
+
+	addMember(
+		sema.StringTypeFromUTF8FunctionName,
+		NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				argument := invocation.Arguments[0].(*ArrayValue)
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, argument.Count())
+				bytes, _ := ByteArrayValueToByteSlice(argument)
+
+				if !utf8.Valid(bytes) {
+					return NilValue{}
+				}
+
+				return NewSomeValueOwningNonCopying(
+					NewStringValue(string(bytes)),
+				)
+			},
+			sema.StringTypeFromUTF8FunctionType,
+		),
+	)
+
 	return functionValue
 }()
 
@@ -2782,7 +3072,9 @@ func (interpreter *Interpreter) IsSubType(subType DynamicType, superType sema.Ty
 				return false
 			}
 
-			if typedSuperType.Size != int64(len(typedSubType.ElementTypes)) {
+			if typedSubType.ElementTypes != nil &&
+				typedSuperType.Size != int64(len(typedSubType.ElementTypes)) {
+
 				return false
 			}
 
@@ -2910,6 +3202,18 @@ func (interpreter *Interpreter) IsSubType(subType DynamicType, superType sema.Ty
 
 		return superType == sema.AnyStructType
 
+	case RangeDynamicType:
+		if _, ok := superType.(*sema.RangeType); ok {
+			return sema.IsSubType(
+				&sema.RangeType{
+					ElementType: typedSubType.ElementType,
+				},
+				superType,
+			)
+		}
+
+		return superType == sema.AnyStructType
+
 	case PublicPathDynamicType:
 		switch superType {
 		case sema.PublicPathType, sema.CapabilityPathType, sema.PathType, sema.AnyStructType:
@@ -3475,7 +3779,27 @@ func (interpreter *Interpreter) getInterfaceType(location common.Location, quali
 	return ty
 }
 
+// checkCancellation aborts the execution with an ExecutionCancelledError
+// if the context of the execution is cancelled
+//
+func (interpreter *Interpreter) checkCancellation() {
+	if interpreter.ctx == nil {
+		return
+	}
+
+	select {
+	case <-interpreter.ctx.Done():
+		panic(ExecutionCancelledError{
+			Err: interpreter.ctx.Err(),
+		})
+	default:
+		return
+	}
+}
+
 func (interpreter *Interpreter) reportLoopIteration(pos ast.HasPosition) {
+	interpreter.checkCancellation()
+
 	if interpreter.onLoopIteration == nil {
 		return
 	}
@@ -3484,7 +3808,27 @@ func (interpreter *Interpreter) reportLoopIteration(pos ast.HasPosition) {
 	interpreter.onLoopIteration(interpreter, line)
 }
 
+// reportStringOperation reports the computation of a native string operation,
+// which processes input of the given size in bytes, as one loop iteration per byte.
+//
+// The operation must be reported before it is performed,
+// so the computation is limited before the work is done
+//
+func (interpreter *Interpreter) reportStringOperation(getLocationRange func() LocationRange, size int) {
+	if size == 0 {
+		return
+	}
+
+	locationRange := getLocationRange()
+
+	for i := 0; i < size; i++ {
+		interpreter.reportLoopIteration(locationRange)
+	}
+}
+
 func (interpreter *Interpreter) reportFunctionInvocation(line int) {
+	interpreter.checkCancellation()
+
 	if interpreter.onFunctionInvocation == nil {
 		return
 	}
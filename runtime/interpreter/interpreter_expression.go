@@ -20,6 +20,7 @@ package interpreter
 
 import (
 	"math/big"
+	"strings"
 
 	"github.com/onflow/cadence/fixedpoint"
 	"github.com/onflow/cadence/runtime/ast"
@@ -100,7 +101,18 @@ func (interpreter *Interpreter) memberExpressionGetterSetter(memberExpression *a
 func (interpreter *Interpreter) VisitIdentifierExpression(expression *ast.IdentifierExpression) ast.Repr {
 	name := expression.Identifier.Identifier
 	variable := interpreter.findVariable(name)
-	return variable.GetValue()
+	value := variable.GetValue()
+
+	// If the checker narrowed the optional type of the variable,
+	// the value is known to be non-nil, so unwrap it
+
+	if _, ok := interpreter.Program.Elaboration.NarrowedIdentifierExpressionTypes[expression]; ok {
+		if someValue, ok := value.(*SomeValue); ok {
+			return someValue.Value
+		}
+	}
+
+	return value
 }
 
 func (interpreter *Interpreter) evalExpression(expression ast.Expression) Value {
@@ -217,15 +229,24 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 		// interpret the left-hand side
 		left := interpreter.evalExpression(expression.Left)
 
+		resultType := interpreter.Program.Elaboration.BinaryExpressionResultTypes[expression]
+
 		// only evaluate right-hand side if left-hand side is nil
 		if some, ok := left.(*SomeValue); ok {
-			return some.Value
+			leftType := interpreter.Program.Elaboration.BinaryExpressionLeftTypes[expression]
+
+			var leftInnerType sema.Type
+			if leftOptionalType, ok := leftType.(*sema.OptionalType); ok {
+				leftInnerType = leftOptionalType.Type
+			}
+
+			// NOTE: the result type might be an optional, e.g. if the right-hand side is nil
+			return interpreter.convertAndBox(some.Value, leftInnerType, resultType)
 		}
 
 		value := interpreter.evalExpression(expression.Right)
 
 		rightType := interpreter.Program.Elaboration.BinaryExpressionRightTypes[expression]
-		resultType := interpreter.Program.Elaboration.BinaryExpressionResultTypes[expression]
 
 		// NOTE: important to convert both any and optional
 		return interpreter.convertAndBox(value, rightType, resultType)
@@ -238,6 +259,24 @@ func (interpreter *Interpreter) VisitBinaryExpression(expression *ast.BinaryExpr
 	})
 }
 
+func (interpreter *Interpreter) VisitRangeExpression(expression *ast.RangeExpression) ast.Repr {
+	start := interpreter.evalExpression(expression.Start).(IntegerValue)
+	end := interpreter.evalExpression(expression.End).(IntegerValue)
+
+	rangeType := interpreter.Program.Elaboration.RangeExpressionTypes[expression]
+	elementType := interpreter.substituteTypeArguments(rangeType.EffectiveElementType())
+
+	step := interpreter.convert(NewIntValueFromInt64(1), sema.IntType, elementType).(IntegerValue)
+
+	return RangeValue{
+		Start:       start,
+		End:         end,
+		Step:        step,
+		Inclusive:   expression.Inclusive,
+		ElementType: ConvertSemaToStaticType(elementType),
+	}
+}
+
 func (interpreter *Interpreter) testEqual(left, right Value) BoolValue {
 	left = interpreter.unbox(left)
 	right = interpreter.unbox(right)
@@ -350,23 +389,29 @@ func NewIntValue(value *big.Int, intSubType sema.Type) Value {
 }
 
 func (interpreter *Interpreter) VisitFixedPointExpression(expression *ast.FixedPointExpression) ast.Repr {
-	// TODO: adjust once/if we support more fixed point types
-
 	fixedPointSubType := interpreter.Program.Elaboration.FixedPointExpression[expression]
 
-	value := fixedpoint.ConvertToFixedPointBigInt(
-		expression.Negative,
-		expression.UnsignedInteger,
-		expression.Fractional,
-		expression.Scale,
-		sema.Fix64Scale,
-	)
+	convert := func(targetScale uint) *big.Int {
+		return fixedpoint.ConvertToFixedPointBigInt(
+			expression.Negative,
+			expression.UnsignedInteger,
+			expression.Fractional,
+			expression.Scale,
+			targetScale,
+		)
+	}
+
 	switch fixedPointSubType {
 	case sema.Fix64Type, sema.SignedFixedPointType:
-		return Fix64Value(value.Int64())
+		return Fix64Value(convert(sema.Fix64Scale).Int64())
 	case sema.UFix64Type:
-		return UFix64Value(value.Uint64())
+		return UFix64Value(convert(sema.Fix64Scale).Uint64())
+	case sema.Fix128Type:
+		return NewFix128ValueFromBigInt(convert(sema.Fix128Scale))
+	case sema.UFix128Type:
+		return NewUFix128ValueFromBigInt(convert(sema.Fix128Scale))
 	case sema.FixedPointType:
+		value := convert(sema.Fix64Scale)
 		if expression.Negative {
 			return Fix64Value(value.Int64())
 		} else {
@@ -381,11 +426,42 @@ func (interpreter *Interpreter) VisitStringExpression(expression *ast.StringExpr
 	return NewStringValue(expression.Value)
 }
 
+func (interpreter *Interpreter) VisitStringTemplateExpression(expression *ast.StringTemplateExpression) ast.Repr {
+	parts := make([]string, 0, len(expression.Values)+len(expression.Expressions))
+	size := 0
+
+	for i, value := range expression.Values {
+		parts = append(parts, value)
+		size += len(value)
+
+		if i >= len(expression.Expressions) {
+			break
+		}
+
+		var part string
+		switch value := interpreter.evalExpression(expression.Expressions[i]).(type) {
+		case *StringValue:
+			part = value.Str
+		default:
+			part = value.String()
+		}
+
+		parts = append(parts, part)
+		size += len(part)
+	}
+
+	getLocationRange := locationRangeGetter(interpreter.Location, expression)
+	interpreter.reportStringOperation(getLocationRange, size)
+
+	return NewStringValue(strings.Join(parts, ""))
+}
+
 func (interpreter *Interpreter) VisitArrayExpression(expression *ast.ArrayExpression) ast.Repr {
 	values := interpreter.visitExpressionsNonCopying(expression.Values)
 
 	argumentTypes := interpreter.Program.Elaboration.ArrayExpressionArgumentTypes[expression]
 	arrayType := interpreter.Program.Elaboration.ArrayExpressionArrayType[expression]
+	arrayType = interpreter.substituteTypeArguments(arrayType).(sema.ArrayType)
 	elementType := arrayType.ElementType(false)
 
 	copies := make([]Value, len(values))
@@ -406,6 +482,7 @@ func (interpreter *Interpreter) VisitDictionaryExpression(expression *ast.Dictio
 
 	entryTypes := interpreter.Program.Elaboration.DictionaryExpressionEntryTypes[expression]
 	dictionaryType := interpreter.Program.Elaboration.DictionaryExpressionType[expression]
+	dictionaryType = interpreter.substituteTypeArguments(dictionaryType).(*sema.DictionaryType)
 
 	dictionaryStaticType := ConvertSemaDictionaryTypeToStaticDictionaryType(dictionaryType)
 
@@ -466,7 +543,22 @@ func (interpreter *Interpreter) VisitMemberExpression(expression *ast.MemberExpr
 		getLocationRange,
 	)
 
-	resultValue := interpreter.getMember(self, getLocationRange, identifier)
+	// If the member is declared by an extension of the accessed value's type,
+	// get the member from the extension
+
+	var extensionType *sema.ExtensionType
+	member := interpreter.Program.Elaboration.MemberExpressionMemberInfos[expression].Member
+	if member != nil {
+		extensionType, _ = member.ContainerType.(*sema.ExtensionType)
+	}
+
+	var resultValue Value
+	if extensionType != nil {
+		resultValue = interpreter.getExtensionMember(self, extensionType, getLocationRange, identifier)
+	} else {
+		resultValue = interpreter.getMember(self, getLocationRange, identifier)
+	}
+
 	if resultValue == nil {
 		panic(MissingMemberValueError{
 			Name:          identifier,
@@ -525,11 +617,21 @@ func (interpreter *Interpreter) VisitIndexExpression(expression *ast.IndexExpres
 }
 
 func (interpreter *Interpreter) VisitConditionalExpression(expression *ast.ConditionalExpression) ast.Repr {
+	elaboration := interpreter.Program.Elaboration
+	resultType := elaboration.ConditionalExpressionResultTypes[expression]
+
+	// NOTE: the result type is the common super type of both branches,
+	// so the value of the taken branch might need to be boxed
+
 	value := interpreter.evalExpression(expression.Test).(BoolValue)
 	if value {
-		return interpreter.evalExpression(expression.Then)
+		thenValue := interpreter.evalExpression(expression.Then)
+		thenType := elaboration.ConditionalExpressionThenTypes[expression]
+		return interpreter.convertAndBox(thenValue, thenType, resultType)
 	} else {
-		return interpreter.evalExpression(expression.Else)
+		elseValue := interpreter.evalExpression(expression.Else)
+		elseType := elaboration.ConditionalExpressionElseTypes[expression]
+		return interpreter.convertAndBox(elseValue, elseType, resultType)
 	}
 }
 
@@ -573,8 +675,9 @@ func (interpreter *Interpreter) VisitInvocationExpression(invocationExpression *
 
 	receiverType :=
 		interpreter.Program.Elaboration.InvocationExpressionReceiverTypes[invocationExpression]
-	typeParameterTypes :=
-		interpreter.Program.Elaboration.InvocationExpressionTypeArguments[invocationExpression]
+	typeParameterTypes := interpreter.substituteTypeParameterTypes(
+		interpreter.Program.Elaboration.InvocationExpressionTypeArguments[invocationExpression],
+	)
 	argumentTypes :=
 		interpreter.Program.Elaboration.InvocationExpressionArgumentTypes[invocationExpression]
 	parameterTypes :=
@@ -678,6 +781,7 @@ func (interpreter *Interpreter) VisitCastingExpression(expression *ast.CastingEx
 	value := interpreter.evalExpression(expression.Expression)
 
 	expectedType := interpreter.Program.Elaboration.CastingTargetTypes[expression]
+	expectedType = interpreter.substituteTypeArguments(expectedType)
 
 	switch expression.Operation {
 	case ast.OperationFailableCast, ast.OperationForceCast:
@@ -733,6 +837,7 @@ func (interpreter *Interpreter) VisitDestroyExpression(expression *ast.DestroyEx
 func (interpreter *Interpreter) VisitReferenceExpression(referenceExpression *ast.ReferenceExpression) ast.Repr {
 
 	borrowType := interpreter.Program.Elaboration.ReferenceExpressionBorrowTypes[referenceExpression]
+	borrowType = interpreter.substituteTypeArguments(borrowType).(*sema.ReferenceType)
 
 	result := interpreter.evalExpression(referenceExpression.Expression)
 
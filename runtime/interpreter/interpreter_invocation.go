@@ -103,10 +103,27 @@ func (interpreter *Interpreter) invokeInterpretedFunction(
 	invocation Invocation,
 ) Value {
 
+	// Conditions and view functions may call interface requirements
+	// which are not declared as view functions, see sema.NonViewInterfaceFunctionCallHint,
+	// so reject calls of implementations which are not view functions.
+	//
+	// Initializers are not called by conditions and view functions,
+	// but they might be invoked when a contract is loaded
+
+	if !function.Type.IsView() && !function.Type.IsConstructor {
+		interpreter.ExpectNoSideEffects("call of non-view function", invocation.GetLocationRange)
+	}
+
 	// Start a new activation record.
 	// Lexical scope: use the function declaration's activation record,
 	// not the current one (which would be dynamic scope)
-	interpreter.activations.PushNewWithParent(function.Activation)
+	activation := interpreter.activations.PushNewWithParent(function.Activation)
+
+	// Bind the type parameters of the function and of the composite of `self`, if any
+
+	activation.SetTypeArguments(
+		interpreter.invocationTypeArguments(invocation),
+	)
 
 	// Make `self` available, if any
 	if invocation.Self != nil {
@@ -116,6 +133,99 @@ func (interpreter *Interpreter) invokeInterpretedFunction(
 	return interpreter.invokeInterpretedFunctionActivated(function, invocation.Arguments)
 }
 
+// invocationTypeArguments returns the type arguments for the given invocation,
+// i.e. the type arguments of the invoked generic function,
+// and the type arguments of the generic composite value `self`.
+// It returns nil if there are no type arguments.
+//
+func (interpreter *Interpreter) invocationTypeArguments(invocation Invocation) *sema.TypeParameterTypeOrderedMap {
+	var typeArguments *sema.TypeParameterTypeOrderedMap
+
+	if invocation.TypeParameterTypes != nil &&
+		invocation.TypeParameterTypes.Len() > 0 {
+
+		typeArguments = sema.NewTypeParameterTypeOrderedMap()
+		invocation.TypeParameterTypes.Foreach(func(typeParameter *sema.TypeParameter, ty sema.Type) {
+			typeArguments.Set(typeParameter, ty)
+		})
+	}
+
+	self := invocation.Self
+	if self == nil {
+		return typeArguments
+	}
+
+	selfTypeArguments := self.TypeArguments()
+	if len(selfTypeArguments) == 0 {
+		return typeArguments
+	}
+
+	compositeType := interpreter.getCompositeType(self.Location(), self.QualifiedIdentifier())
+
+	if typeArguments == nil {
+		typeArguments = sema.NewTypeParameterTypeOrderedMap()
+	}
+
+	for i, typeParameter := range compositeType.TypeParameters {
+		if i >= len(selfTypeArguments) {
+			break
+		}
+		typeArguments.Set(
+			typeParameter,
+			interpreter.ConvertStaticToSemaType(selfTypeArguments[i]),
+		)
+	}
+
+	return typeArguments
+}
+
+// substituteTypeArguments returns the given type,
+// with the type parameters in scope replaced by their type arguments.
+// Types of the elaboration may refer to type parameters of generic declarations,
+// which are only bound at run-time, when the generic declaration is used.
+//
+func (interpreter *Interpreter) substituteTypeArguments(ty sema.Type) sema.Type {
+	if ty == nil {
+		return nil
+	}
+
+	current := interpreter.activations.Current()
+	if current == nil {
+		return ty
+	}
+
+	typeArguments := current.TypeArguments()
+	if typeArguments == nil {
+		return ty
+	}
+
+	resolvedType := ty.Resolve(typeArguments)
+	if resolvedType == nil {
+		return ty
+	}
+
+	return resolvedType
+}
+
+// substituteTypeParameterTypes returns the given type parameter types,
+// with the type parameters in scope replaced by their type arguments,
+// see substituteTypeArguments
+//
+func (interpreter *Interpreter) substituteTypeParameterTypes(
+	typeParameterTypes *sema.TypeParameterTypeOrderedMap,
+) *sema.TypeParameterTypeOrderedMap {
+
+	if typeParameterTypes == nil {
+		return nil
+	}
+
+	result := sema.NewTypeParameterTypeOrderedMap()
+	typeParameterTypes.Foreach(func(typeParameter *sema.TypeParameter, ty sema.Type) {
+		result.Set(typeParameter, interpreter.substituteTypeArguments(ty))
+	})
+	return result
+}
+
 // NOTE: assumes the function's activation (or an extension of it) is pushed!
 //
 func (interpreter *Interpreter) invokeInterpretedFunctionActivated(
@@ -128,15 +238,31 @@ func (interpreter *Interpreter) invokeInterpretedFunctionActivated(
 		interpreter.bindParameterArguments(function.ParameterList, arguments)
 	}
 
-	return interpreter.visitFunctionBody(
-		function.BeforeStatements,
-		function.PreConditions,
-		func() controlReturn {
-			return interpreter.visitStatements(function.Statements)
-		},
-		function.PostConditions,
-		function.Type.ReturnTypeAnnotation.Type,
-	)
+	visitFunctionBody := func() Value {
+		return interpreter.visitFunctionBody(
+			function.BeforeStatements,
+			function.PreConditions,
+			func() controlReturn {
+				return interpreter.visitStatements(function.Statements)
+			},
+			function.PostConditions,
+			function.Type.ReturnTypeAnnotation.Type,
+		)
+	}
+
+	// View functions, declared or inferred, are checked to have no side effects,
+	// but they may call host functions which have side effects,
+	// so reject those side effects, see ExpectNoSideEffects
+
+	if !function.Type.IsView() {
+		return visitFunctionBody()
+	}
+
+	var result Value
+	interpreter.withViewFunction(func() {
+		result = visitFunctionBody()
+	})
+	return result
 }
 
 // bindParameterArguments binds the argument values to the given parameters
@@ -34,6 +34,8 @@ func (interpreter *Interpreter) evalStatement(statement ast.Statement) interface
 
 	interpreter.statement = statement
 
+	interpreter.checkCancellation()
+
 	if interpreter.onStatement != nil {
 		interpreter.onStatement(interpreter, statement)
 	}
@@ -241,34 +243,94 @@ func (interpreter *Interpreter) VisitForStatement(statement *ast.ForStatement) a
 	interpreter.activations.PushNewWithCurrent()
 	defer interpreter.activations.Pop()
 
+	var indexVariable *Variable
+	if statement.Index != nil {
+		indexVariable = interpreter.declareVariable(
+			statement.Index.Identifier,
+			nil,
+		)
+	}
+
 	variable := interpreter.declareVariable(
 		statement.Identifier.Identifier,
 		nil,
 	)
 
-	values := interpreter.evalExpression(statement.Value).(*ArrayValue).Elements()[:]
+	var result ast.Repr
 
-	for _, value := range values {
+	// runIteration binds the loop variables and evaluates the body of the loop.
+	// It returns false if the loop should be stopped
+
+	runIteration := func(index Value, value Value) (resume bool) {
 
 		interpreter.reportLoopIteration(statement)
 
+		if indexVariable != nil {
+			indexVariable.SetValue(index)
+		}
 		variable.SetValue(value)
 
-		result := statement.Block.Accept(interpreter)
+		blockResult := statement.Block.Accept(interpreter)
 
-		switch result.(type) {
+		switch blockResult.(type) {
 		case controlBreak:
-			return nil
+			return false
 
 		case controlContinue:
 			// NO-OP
 
 		case functionReturn:
-			return result
+			result = blockResult
+			return false
 		}
+
+		return true
 	}
 
-	return nil
+	switch value := interpreter.evalExpression(statement.Value).(type) {
+	case *ArrayValue:
+		values := value.Elements()[:]
+
+		for i, element := range values {
+			if !runIteration(NewIntValueFromInt64(int64(i)), element) {
+				break
+			}
+		}
+
+	case RangeValue:
+		index := 0
+
+		value.ForEach(func(element IntegerValue) bool {
+			resume := runIteration(NewIntValueFromInt64(int64(index)), element)
+			index++
+			return resume
+		})
+
+	case *DictionaryValue:
+		getLocationRange := locationRangeGetter(interpreter.Location, statement)
+
+		// A single loop variable is bound to the key,
+		// two loop variables are bound to the key and the value
+
+		for _, key := range value.Keys().Elements() {
+			var resume bool
+			if indexVariable != nil {
+				entryValue := value.Get(interpreter, getLocationRange, key).(*SomeValue).Value
+				resume = runIteration(key, entryValue)
+			} else {
+				resume = runIteration(nil, key)
+			}
+
+			if !resume {
+				break
+			}
+		}
+
+	default:
+		panic(errors.NewUnreachableError())
+	}
+
+	return result
 }
 
 func (interpreter *Interpreter) VisitEmitStatement(statement *ast.EmitStatement) ast.Repr {
@@ -65,3 +65,31 @@ func PrependMagic(unprefixedData []byte, version uint16) (result []byte) {
 	copy(result[fullPrefixLength:], unprefixedData)
 	return result
 }
+
+// SegmentMagic is the prefix that is added to all segments of segmented containers.
+//
+// Segments are stored separately from the values which reference them,
+// so the prefix differs from Magic, which allows tools to tell them apart.
+//
+var SegmentMagic = []byte{0x0, 0xCA, 0xDF}
+
+// IsSegment tests whether the given data begins with the segment magic prefix.
+//
+func IsSegment(data []byte) bool {
+	return bytes.HasPrefix(data, SegmentMagic) && len(data) >= fullPrefixLength
+}
+
+// stripSegmentMagic returns the given segment data with the segment magic prefix and version removed.
+//
+// If the data doesn't start with SegmentMagic, the data is returned unchanged
+// and the version is 0.
+//
+func stripSegmentMagic(data []byte) (trimmed []byte, version uint16) {
+	if !IsSegment(data) {
+		return data, 0
+	}
+
+	version = binary.BigEndian.Uint16(data[MagicLength:fullPrefixLength])
+
+	return data[fullPrefixLength:], version
+}
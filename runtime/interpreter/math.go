@@ -0,0 +1,307 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/onflow/cadence/fixedpoint"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// The math functions of number values are implemented on the raw big integer representation
+// of the values, i.e. the integer value for integers, and the scaled value for fixed-point numbers.
+//
+// All computations are performed with arbitrary precision, and are then checked against
+// the bounds of the type, so the results are deterministic and independent of the
+// underlying representation.
+
+var maxUint64Big = new(big.Int).SetUint64(math.MaxUint64)
+
+// numberValueRawBigInt returns the raw big integer representation of the given number value.
+// The result must not be mutated.
+//
+func numberValueRawBigInt(value NumberValue) *big.Int {
+	switch value := value.(type) {
+	case IntValue:
+		return value.BigInt
+	case Int8Value:
+		return big.NewInt(int64(value))
+	case Int16Value:
+		return big.NewInt(int64(value))
+	case Int32Value:
+		return big.NewInt(int64(value))
+	case Int64Value:
+		return big.NewInt(int64(value))
+	case Int128Value:
+		return value.BigInt
+	case Int256Value:
+		return value.BigInt
+	case UIntValue:
+		return value.BigInt
+	case UInt8Value:
+		return new(big.Int).SetUint64(uint64(value))
+	case UInt16Value:
+		return new(big.Int).SetUint64(uint64(value))
+	case UInt32Value:
+		return new(big.Int).SetUint64(uint64(value))
+	case UInt64Value:
+		return new(big.Int).SetUint64(uint64(value))
+	case UInt128Value:
+		return value.BigInt
+	case UInt256Value:
+		return value.BigInt
+	case Word8Value:
+		return new(big.Int).SetUint64(uint64(value))
+	case Word16Value:
+		return new(big.Int).SetUint64(uint64(value))
+	case Word32Value:
+		return new(big.Int).SetUint64(uint64(value))
+	case Word64Value:
+		return new(big.Int).SetUint64(uint64(value))
+	case Fix64Value:
+		return big.NewInt(int64(value))
+	case UFix64Value:
+		return new(big.Int).SetUint64(uint64(value))
+	case Fix128Value:
+		return value.BigInt
+	case UFix128Value:
+		return value.BigInt
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+// newNumberValueFromRawBigInt returns a number value of the given type
+// for the given raw big integer representation.
+// The value must be in the range of the type.
+//
+func newNumberValueFromRawBigInt(ty sema.Type, value *big.Int) NumberValue {
+	switch ty {
+	case sema.IntType:
+		return NewIntValueFromBigInt(value)
+	case sema.Int8Type:
+		return Int8Value(value.Int64())
+	case sema.Int16Type:
+		return Int16Value(value.Int64())
+	case sema.Int32Type:
+		return Int32Value(value.Int64())
+	case sema.Int64Type:
+		return Int64Value(value.Int64())
+	case sema.Int128Type:
+		return NewInt128ValueFromBigInt(value)
+	case sema.Int256Type:
+		return NewInt256ValueFromBigInt(value)
+	case sema.UIntType:
+		return NewUIntValueFromBigInt(value)
+	case sema.UInt8Type:
+		return UInt8Value(value.Uint64())
+	case sema.UInt16Type:
+		return UInt16Value(value.Uint64())
+	case sema.UInt32Type:
+		return UInt32Value(value.Uint64())
+	case sema.UInt64Type:
+		return UInt64Value(value.Uint64())
+	case sema.UInt128Type:
+		return NewUInt128ValueFromBigInt(value)
+	case sema.UInt256Type:
+		return NewUInt256ValueFromBigInt(value)
+	case sema.Word8Type:
+		return Word8Value(value.Uint64())
+	case sema.Word16Type:
+		return Word16Value(value.Uint64())
+	case sema.Word32Type:
+		return Word32Value(value.Uint64())
+	case sema.Word64Type:
+		return Word64Value(value.Uint64())
+	case sema.Fix64Type:
+		return Fix64Value(value.Int64())
+	case sema.UFix64Type:
+		return UFix64Value(value.Uint64())
+	case sema.Fix128Type:
+		return NewFix128ValueFromBigInt(value)
+	case sema.UFix128Type:
+		return NewUFix128ValueFromBigInt(value)
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+// numberTypeRawBounds returns the bounds of the raw big integer representation
+// of values of the given number type. The bounds are nil if the type is unbounded.
+//
+func numberTypeRawBounds(ty sema.Type) (min, max *big.Int) {
+	switch ty {
+	case sema.Fix64Type:
+		return minInt64Big, maxInt64Big
+	case sema.UFix64Type:
+		return big.NewInt(0), maxUint64Big
+	case sema.Fix128Type:
+		return fixedpoint.Fix128TypeMinBig, fixedpoint.Fix128TypeMaxBig
+	case sema.UFix128Type:
+		return fixedpoint.UFix128TypeMinBig, fixedpoint.UFix128TypeMaxBig
+	}
+
+	rangedType := ty.(sema.IntegerRangedType)
+	return rangedType.MinInt(), rangedType.MaxInt()
+}
+
+// numberTypeFactor returns the scale factor of the given number type,
+// or nil if the type is an integer type.
+//
+func numberTypeFactor(ty sema.Type) *big.Int {
+	switch ty {
+	case sema.Fix64Type, sema.UFix64Type:
+		return sema.Fix64FactorBig
+	case sema.Fix128Type, sema.UFix128Type:
+		return sema.Fix128FactorBig
+	}
+
+	return nil
+}
+
+func isWordType(ty sema.Type) bool {
+	switch ty {
+	case sema.Word8Type, sema.Word16Type, sema.Word32Type, sema.Word64Type:
+		return true
+	}
+
+	return false
+}
+
+// checkNumberTypeRange checks that the given raw result is in the range of the given type.
+// If saturating is true, the result is clamped to the bounds,
+// otherwise an overflow or underflow error is raised.
+//
+func checkNumberTypeRange(ty sema.Type, result *big.Int, saturating bool) NumberValue {
+	min, max := numberTypeRawBounds(ty)
+
+	if min != nil && result.Cmp(min) < 0 {
+		if saturating {
+			return newNumberValueFromRawBigInt(ty, min)
+		}
+		panic(UnderflowError{})
+	}
+
+	if max != nil && result.Cmp(max) > 0 {
+		if saturating {
+			return newNumberValueFromRawBigInt(ty, max)
+		}
+		panic(OverflowError{})
+	}
+
+	return newNumberValueFromRawBigInt(ty, result)
+}
+
+// numberValuePow returns the given integer value raised to the power of the given exponent.
+// Word types wrap around, all other types either check for overflow or saturate.
+//
+func numberValuePow(ty sema.Type, value NumberValue, exponent UInt8Value, saturating bool) NumberValue {
+	base := numberValueRawBigInt(value)
+
+	var modulus *big.Int
+	if isWordType(ty) {
+		_, max := numberTypeRawBounds(ty)
+		modulus = new(big.Int).Add(max, big.NewInt(1))
+	}
+
+	result := new(big.Int).Exp(base, big.NewInt(int64(exponent)), modulus)
+
+	return checkNumberTypeRange(ty, result, saturating)
+}
+
+// numberValueSqrt returns the square root of the given number value, rounded toward zero
+//
+func numberValueSqrt(ty sema.Type, value NumberValue) NumberValue {
+	radicand := numberValueRawBigInt(value)
+
+	if radicand.Sign() < 0 {
+		panic(NegativeSquareRootError{})
+	}
+
+	// The square root of a fixed-point number with factor f and raw value r is
+	// sqrt(r / f) * f = sqrt(r * f)
+
+	factor := numberTypeFactor(ty)
+	if factor != nil {
+		radicand = new(big.Int).Mul(radicand, factor)
+	}
+
+	result := new(big.Int).Sqrt(radicand)
+
+	return checkNumberTypeRange(ty, result, false)
+}
+
+// numberValueAbs returns the absolute value of the given number value
+//
+func numberValueAbs(ty sema.Type, value NumberValue) NumberValue {
+	result := new(big.Int).Abs(numberValueRawBigInt(value))
+
+	return checkNumberTypeRange(ty, result, false)
+}
+
+// numberValueMin returns the lesser of the given number values
+//
+func numberValueMin(value, other NumberValue) NumberValue {
+	if numberValueRawBigInt(other).Cmp(numberValueRawBigInt(value)) < 0 {
+		return other
+	}
+	return value
+}
+
+// numberValueMax returns the greater of the given number values
+//
+func numberValueMax(value, other NumberValue) NumberValue {
+	if numberValueRawBigInt(other).Cmp(numberValueRawBigInt(value)) > 0 {
+		return other
+	}
+	return value
+}
+
+// numberValueMulDiv returns value * multiplier / divisor, rounded using the given rounding rule.
+// The intermediate product is computed with arbitrary precision.
+//
+func numberValueMulDiv(
+	ty sema.Type,
+	value NumberValue,
+	multiplier NumberValue,
+	divisor NumberValue,
+	rule fixedpoint.RoundingRule,
+) NumberValue {
+
+	c := numberValueRawBigInt(divisor)
+	if c.Sign() == 0 {
+		panic(DivisionByZeroError{})
+	}
+
+	// For fixed-point numbers with factor f and raw values a, b, and c,
+	// the raw result is ((a / f) * (b / f) / (c / f)) * f = a * b / c,
+	// i.e. the same as for integers
+
+	product := new(big.Int).Mul(
+		numberValueRawBigInt(value),
+		numberValueRawBigInt(multiplier),
+	)
+
+	result := fixedpoint.DivRound(product, c, rule)
+
+	return checkNumberTypeRange(ty, result, false)
+}
@@ -0,0 +1,264 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// RangeValue is the value of a range expression, e.g. `0..<10`.
+//
+// The range starts at Start and advances by Step,
+// until it reaches End, which is only included if Inclusive is true.
+// A range with a positive step whose start is greater than the end is empty,
+// as is a range with a negative step whose start is less than the end.
+//
+type RangeValue struct {
+	Start       IntegerValue
+	End         IntegerValue
+	Step        IntegerValue
+	Inclusive   bool
+	ElementType StaticType
+}
+
+func (RangeValue) IsValue() {}
+
+func (v RangeValue) Accept(interpreter *Interpreter, visitor Visitor) {
+	visitor.VisitRangeValue(interpreter, v)
+}
+
+func (v RangeValue) Walk(walkChild func(Value)) {
+	walkChild(v.Start)
+	walkChild(v.End)
+	walkChild(v.Step)
+}
+
+func (v RangeValue) DynamicType(inter *Interpreter, _ SeenReferences) DynamicType {
+	return RangeDynamicType{
+		ElementType: inter.ConvertStaticToSemaType(v.ElementType),
+	}
+}
+
+func (v RangeValue) StaticType() StaticType {
+	return RangeStaticType{
+		ElementType: v.ElementType,
+	}
+}
+
+func (v RangeValue) Copy() Value {
+	return v
+}
+
+func (RangeValue) GetOwner() *common.Address {
+	// value is never owned
+	return nil
+}
+
+func (RangeValue) SetOwner(_ *common.Address) {
+	// NO-OP: value cannot be owned
+}
+
+func (RangeValue) IsModified() bool {
+	return false
+}
+
+func (RangeValue) SetModified(_ bool) {
+	// NO-OP
+}
+
+func (v RangeValue) Destroy(_ *Interpreter, _ func() LocationRange) {
+	// NO-OP
+}
+
+func (v RangeValue) String() string {
+	return v.RecursiveString(SeenReferences{})
+}
+
+func (v RangeValue) RecursiveString(seenReferences SeenReferences) string {
+	operator := "..<"
+	if v.Inclusive {
+		operator = "..."
+	}
+
+	result := fmt.Sprintf(
+		"%s%s%s",
+		v.Start.RecursiveString(seenReferences),
+		operator,
+		v.End.RecursiveString(seenReferences),
+	)
+
+	if integerValueToBigInt(v.Step).Cmp(big.NewInt(1)) != 0 {
+		result = fmt.Sprintf(
+			"(%s).stride(by: %s)",
+			result,
+			v.Step.RecursiveString(seenReferences),
+		)
+	}
+
+	return result
+}
+
+func (v RangeValue) GetMember(inter *Interpreter, _ func() LocationRange, name string) Value {
+	switch name {
+	case "start":
+		return v.Start
+
+	case "end":
+		return v.End
+
+	case "step":
+		return v.Step
+
+	case "isInclusive":
+		return BoolValue(v.Inclusive)
+
+	case "contains":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				element := invocation.Arguments[0].(IntegerValue)
+				return BoolValue(v.Contains(element))
+			},
+			sema.RangeContainsFunctionType(
+				inter.ConvertStaticToSemaType(v.ElementType),
+			),
+		)
+
+	case "stride":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				step := invocation.Arguments[0].(IntegerValue)
+				return v.Stride(step, invocation.GetLocationRange)
+			},
+			sema.RangeStrideFunctionType(
+				inter.ConvertStaticToSemaType(v.StaticType()).(*sema.RangeType),
+			),
+		)
+	}
+
+	return nil
+}
+
+func (RangeValue) SetMember(_ *Interpreter, _ func() LocationRange, _ string, _ Value) {
+	panic(errors.NewUnreachableError())
+}
+
+func (v RangeValue) ConformsToDynamicType(_ *Interpreter, dynamicType DynamicType, _ TypeConformanceResults) bool {
+	_, ok := dynamicType.(RangeDynamicType)
+	return ok
+}
+
+func (RangeValue) IsStorable() bool {
+	return false
+}
+
+// Stride returns a new range with the same bounds and the given step.
+//
+func (v RangeValue) Stride(step IntegerValue, getLocationRange func() LocationRange) RangeValue {
+	if integerValueToBigInt(step).Sign() == 0 {
+		panic(RangeStepZeroError{
+			LocationRange: getLocationRange(),
+		})
+	}
+
+	v.Step = step
+	return v
+}
+
+// Contains returns true if the given element is an element of the range.
+//
+func (v RangeValue) Contains(element IntegerValue) bool {
+	start := integerValueToBigInt(v.Start)
+	step := integerValueToBigInt(v.Step)
+	value := integerValueToBigInt(element)
+
+	if !v.isBeforeEnd(value, step) {
+		return false
+	}
+
+	// The element must be at or after the start, in the direction of the step,
+	// and the distance to the start must be a multiple of the step
+
+	distance := new(big.Int).Sub(value, start)
+	if distance.Sign() != 0 && distance.Sign() != step.Sign() {
+		return false
+	}
+
+	return new(big.Int).Rem(distance, step).Sign() == 0
+}
+
+// ForEach calls the given function for each element of the range, in order,
+// until the function returns false.
+//
+// The bounds are checked using arbitrary precision arithmetic before advancing,
+// so iterating up to the maximum or minimum value of the element type does not overflow.
+//
+func (v RangeValue) ForEach(f func(element IntegerValue) (resume bool)) {
+	step := integerValueToBigInt(v.Step)
+	current := integerValueToBigInt(v.Start)
+
+	element := v.Start
+
+	for v.isBeforeEnd(current, step) {
+		if !f(element) {
+			return
+		}
+
+		current = new(big.Int).Add(current, step)
+		if !v.isBeforeEnd(current, step) {
+			return
+		}
+
+		element = element.Plus(v.Step).(IntegerValue)
+	}
+}
+
+// isBeforeEnd returns true if the given value has not passed the end of the range,
+// in the direction of the given step.
+//
+func (v RangeValue) isBeforeEnd(value *big.Int, step *big.Int) bool {
+	comparison := value.Cmp(integerValueToBigInt(v.End))
+	if comparison == 0 {
+		return v.Inclusive
+	}
+	if step.Sign() < 0 {
+		return comparison > 0
+	}
+	return comparison < 0
+}
+
+func integerValueToBigInt(value IntegerValue) *big.Int {
+	switch value := value.(type) {
+	case BigNumberValue:
+		return value.ToBigInt()
+
+	case UInt64Value:
+		return new(big.Int).SetUint64(uint64(value))
+
+	case Word64Value:
+		return new(big.Int).SetUint64(uint64(value))
+
+	default:
+		return big.NewInt(int64(value.ToInt()))
+	}
+}
@@ -0,0 +1,702 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// Segmented containers
+//
+// The elements of large arrays and the entries of large dictionaries are not encoded
+// into the stored value which contains the container, but into segments,
+// which are stored separately. The stored value only contains references to the segments.
+//
+// Segments are loaded on demand, e.g. when an element of an array is accessed,
+// only the segment containing the element is loaded, and when an entry of a dictionary
+// is accessed, only the bucket containing the entry is loaded.
+//
+// Segments are identified by the hash of their data, so when a segmented container is modified,
+// only the segments containing modified elements or entries are encoded and written again.
+//
+// Array segments are split at content-defined boundaries, so inserting or removing an element
+// only changes the segment containing the element.
+// Dictionary entries are distributed into buckets by the hash of the key,
+// and buckets are split when they get too large.
+//
+// Containers migrate transparently: Containers are segmented when their encoding exceeds
+// the segmentation threshold, and are encoded inline again when they shrink.
+
+const (
+	// segmentationThreshold is the size of the encoded elements or entries of a container,
+	// above which the container is encoded as a segmented container
+	segmentationThreshold = 32 * 1024
+
+	// inlineThreshold is the size of the segments of a segmented container,
+	// below which the container is encoded inline again
+	inlineThreshold = 16 * 1024
+
+	// minSegmentSize is the minimum size of an array segment,
+	// unless it contains the last elements of the array
+	minSegmentSize = 2 * 1024
+
+	// targetSegmentSize is the average size of an array segment, above the minimum size
+	targetSegmentSize = 8 * 1024
+
+	// maxSegmentSize is the maximum size of an array segment, unless a single element exceeds it.
+	// Dictionary buckets are split when they exceed it
+	maxSegmentSize = 16 * 1024
+
+	// maxBucketDepth is the maximum number of bits of the key hash which determine a bucket
+	maxBucketDepth = 64
+
+	segmentIDLength = 16
+)
+
+// SegmentID identifies a segment of a segmented container.
+// It is the hash of the data of the segment
+//
+type SegmentID [segmentIDLength]byte
+
+func newSegmentID(data []byte) (id SegmentID) {
+	hash := sha256.Sum256(data)
+	copy(id[:], hash[:segmentIDLength])
+	return
+}
+
+func (id SegmentID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SegmentLoader returns the data of the segment with the given ID,
+// which belongs to the value stored under the given key in the given account.
+//
+// The data is the data of the segment as it was returned in EncodingDeferrals.Segments,
+// i.e. including the segment magic prefix
+//
+type SegmentLoader func(owner common.Address, key string, id SegmentID) ([]byte, error)
+
+// segmentOrigin is the stored value which the segments of a segmented container belong to
+//
+type segmentOrigin struct {
+	owner  common.Address
+	key    string
+	loader SegmentLoader
+}
+
+// matches returns true if the segments belong to the value stored under the given key in the given account
+//
+func (o segmentOrigin) matches(owner *common.Address, key string) bool {
+	return owner != nil && *owner == o.owner && key == o.key
+}
+
+// loadData returns the data of the segment with the given ID
+//
+func (o segmentOrigin) loadData(id SegmentID) ([]byte, error) {
+	if o.loader == nil {
+		return nil, fmt.Errorf("cannot load segment %s of value %s: no segment loader", id, o.key)
+	}
+
+	data, err := o.loader(o.owner, o.key, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsSegment(data) {
+		return nil, fmt.Errorf("missing segment %s of value %s in account %s", id, o.key, o.owner)
+	}
+
+	return data, nil
+}
+
+// loadContent returns the content of the segment with the given ID,
+// i.e. the data without the segment magic prefix
+//
+func (o segmentOrigin) loadContent(id SegmentID) (content []byte, size int, err error) {
+	data, err := o.loadData(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	content, version := stripSegmentMagic(data)
+	if version < 5 {
+		return nil, 0, fmt.Errorf("invalid segment %s of value %s: unsupported version %d", id, o.key, version)
+	}
+
+	return content, len(data), nil
+}
+
+// arraySegment is a segment of the elements of a segmented array
+//
+type arraySegment struct {
+	id SegmentID
+	// count is the number of elements in the segment
+	count int
+	// size is the size of the data of the segment.
+	// Only available for loaded segments
+	size int
+	// loaded is true if the raw content of the elements of the segment is available
+	loaded bool
+	// modified is true if elements of the segment were replaced, inserted, or removed
+	modified bool
+}
+
+// arraySegments are the segments of a segmented array, in the order of the elements
+//
+type arraySegments struct {
+	origin segmentOrigin
+	list   []*arraySegment
+}
+
+func (s *arraySegments) copy() *arraySegments {
+	if s == nil {
+		return nil
+	}
+
+	list := make([]*arraySegment, len(s.list))
+	for i, segment := range s.list {
+		segmentCopy := *segment
+		list[i] = &segmentCopy
+	}
+
+	return &arraySegments{
+		origin: s.origin,
+		list:   list,
+	}
+}
+
+// segmentAt returns the position of the segment which contains the element at the given index,
+// and the index of the first element of the segment.
+//
+// If the index is the number of elements, the last segment is returned.
+// If there are no segments, the position is -1
+//
+func (s *arraySegments) segmentAt(index int) (position int, start int) {
+	for i, segment := range s.list {
+		if index < start+segment.count {
+			return i, start
+		}
+		start += segment.count
+	}
+
+	if len(s.list) == 0 {
+		return -1, 0
+	}
+
+	last := len(s.list) - 1
+	return last, start - s.list[last].count
+}
+
+// splitArraySegments splits the given encoded elements into segments,
+// and returns the number of elements of each segment.
+//
+// Boundaries are determined by the content of the elements, instead of their index,
+// so inserting or removing an element only affects the segment containing the element
+//
+func splitArraySegments(elements [][]byte) (counts []int) {
+	count := 0
+	size := 0
+
+	for _, element := range elements {
+		if count > 0 && size+len(element) > maxSegmentSize {
+			counts = append(counts, count)
+			count = 0
+			size = 0
+		}
+
+		count++
+		size += len(element)
+
+		if size >= minSegmentSize && isSegmentBoundary(element) {
+			counts = append(counts, count)
+			count = 0
+			size = 0
+		}
+	}
+
+	if count > 0 {
+		counts = append(counts, count)
+	}
+
+	return counts
+}
+
+// isSegmentBoundary returns true if a segment should end after the given encoded element.
+// Larger elements are more likely to end a segment, so segments have about the target size
+//
+func isSegmentBoundary(element []byte) bool {
+	hash := fnv.New64a()
+	_, _ = hash.Write(element)
+	return hash.Sum64()%targetSegmentSize < uint64(len(element))
+}
+
+// dictionaryBucket is a segment of the entries of a segmented dictionary.
+//
+// A bucket contains the entries whose key hashes have the bucket's prefix
+// in their lowest depth bits
+//
+type dictionaryBucket struct {
+	id     SegmentID
+	depth  uint
+	prefix uint64
+	// count is the number of entries in the bucket
+	count int
+	// size is the size of the data of the bucket.
+	// Only available for loaded buckets
+	size int
+	// loaded is true if the entries of the bucket are available
+	loaded bool
+	// modified is true if entries of the bucket were inserted, updated, or removed
+	modified bool
+	// keys are the keys of the entries of the bucket.
+	// Only available for loaded buckets
+	keys []Value
+}
+
+func (b *dictionaryBucket) contains(hash uint64) bool {
+	if b.depth == 0 {
+		return true
+	}
+	mask := uint64(1)<<b.depth - 1
+	return hash&mask == b.prefix
+}
+
+// dictionarySegments are the buckets of a segmented dictionary
+//
+type dictionarySegments struct {
+	origin segmentOrigin
+	// deferred is true if the values of the dictionary are stored separately,
+	// and the buckets only contain the keys
+	deferred bool
+	buckets  []*dictionaryBucket
+	// count is the number of entries
+	count int
+}
+
+func (s *dictionarySegments) copy() *dictionarySegments {
+	if s == nil {
+		return nil
+	}
+
+	buckets := make([]*dictionaryBucket, len(s.buckets))
+	for i, bucket := range s.buckets {
+		bucketCopy := *bucket
+		bucketCopy.keys = append([]Value(nil), bucket.keys...)
+		buckets[i] = &bucketCopy
+	}
+
+	return &dictionarySegments{
+		origin:   s.origin,
+		deferred: s.deferred,
+		buckets:  buckets,
+		count:    s.count,
+	}
+}
+
+// bucket returns the bucket which contains the entry with the given key
+//
+func (s *dictionarySegments) bucket(key string) *dictionaryBucket {
+	hash := segmentKeyHash(key)
+	for _, bucket := range s.buckets {
+		if bucket.contains(hash) {
+			return bucket
+		}
+	}
+	return nil
+}
+
+func segmentKeyHash(key string) uint64 {
+	hash := sha256.Sum256([]byte(key))
+	return binary.LittleEndian.Uint64(hash[:8])
+}
+
+// bucketEntry is an encoded entry of a dictionary bucket:
+// The encoded key, followed by the encoded value, if the values are not deferred
+//
+type bucketEntry struct {
+	hash uint64
+	data []byte
+}
+
+type splitBucket struct {
+	depth   uint
+	prefix  uint64
+	entries []bucketEntry
+}
+
+// splitDictionaryBucket splits the entries of the bucket with the given depth and prefix
+// into buckets which do not exceed the maximum segment size, if possible
+//
+func splitDictionaryBucket(depth uint, prefix uint64, entries []bucketEntry) []splitBucket {
+	if depth >= maxBucketDepth || bucketEntriesSize(entries) <= maxSegmentSize {
+		return []splitBucket{
+			{
+				depth:   depth,
+				prefix:  prefix,
+				entries: entries,
+			},
+		}
+	}
+
+	var low, high []bucketEntry
+	bit := uint64(1) << depth
+	for _, entry := range entries {
+		if entry.hash&bit == 0 {
+			low = append(low, entry)
+		} else {
+			high = append(high, entry)
+		}
+	}
+
+	return append(
+		splitDictionaryBucket(depth+1, prefix, low),
+		splitDictionaryBucket(depth+1, prefix|bit, high)...,
+	)
+}
+
+func bucketEntriesSize(entries []bucketEntry) (size int) {
+	for _, entry := range entries {
+		size += len(entry.data)
+	}
+	return
+}
+
+func encodedItemsSize(items [][]byte) (size int) {
+	for _, item := range items {
+		size += len(item)
+	}
+	return
+}
+
+// newSegmentData returns the data of a segment which contains the given encoded items,
+// i.e. the segment magic prefix, followed by a CBOR array of the items
+//
+func newSegmentData(itemCount int, items [][]byte) []byte {
+	var buffer bytes.Buffer
+	buffer.Write(SegmentMagic)
+	var version [VersionEncodingLength]byte
+	binary.BigEndian.PutUint16(version[:], CurrentEncodingVersion)
+	buffer.Write(version[:])
+	buffer.Write(cborArrayHead(uint64(itemCount)))
+	for _, item := range items {
+		buffer.Write(item)
+	}
+	return buffer.Bytes()
+}
+
+// cborArrayHead returns the head of a CBOR array with the given number of items
+//
+func cborArrayHead(count uint64) []byte {
+	const majorTypeArray = 0x80
+
+	switch {
+	case count < 24:
+		return []byte{majorTypeArray | byte(count)}
+	case count <= 0xff:
+		return []byte{majorTypeArray | 24, byte(count)}
+	case count <= 0xffff:
+		head := []byte{majorTypeArray | 25, 0, 0}
+		binary.BigEndian.PutUint16(head[1:], uint16(count))
+		return head
+	case count <= 0xffffffff:
+		head := []byte{majorTypeArray | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(head[1:], uint32(count))
+		return head
+	default:
+		head := []byte{majorTypeArray | 27, 0, 0, 0, 0, 0, 0, 0, 0}
+		binary.BigEndian.PutUint64(head[1:], count)
+		return head
+	}
+}
+
+// mayContainSegmentReferences returns true if the given encoded data
+// may contain segmented containers, i.e. may reference segments
+//
+func mayContainSegmentReferences(data []byte) bool {
+	return bytes.Contains(data, []byte{0xd8, cborTagSegmentedArrayValue}) ||
+		bytes.Contains(data, []byte{0xd8, cborTagSegmentedDictionaryValue})
+}
+
+// SegmentReferences returns the IDs of the segments which are referenced
+// by the segmented containers in the given encoded value, in order of occurrence.
+//
+// The given data must not have the magic prefix
+//
+func SegmentReferences(data []byte) ([]SegmentID, error) {
+	if !mayContainSegmentReferences(data) {
+		return nil, nil
+	}
+
+	var ids []SegmentID
+	seen := map[SegmentID]struct{}{}
+
+	add := func(id SegmentID) {
+		if _, ok := seen[id]; ok {
+			return
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	decoder := decMode.NewByteStreamDecoder(data)
+
+	var walk func() error
+	walk = func() error {
+		dataType, err := decoder.NextType()
+		if err != nil {
+			return err
+		}
+
+		switch dataType {
+		case cbor.TagType:
+			number, err := decoder.DecodeTagNumber()
+			if err != nil {
+				return err
+			}
+
+			switch number {
+			case cborTagSegmentedArrayValue:
+				segments, err := decodeSegmentedArrayContent(decoder)
+				if err != nil {
+					return err
+				}
+				for _, segment := range segments {
+					add(segment.id)
+				}
+				return nil
+
+			case cborTagSegmentedDictionaryValue:
+				_, buckets, err := decodeSegmentedDictionaryContent(decoder)
+				if err != nil {
+					return err
+				}
+				for _, bucket := range buckets {
+					if bucket.count > 0 {
+						add(bucket.id)
+					}
+				}
+				return nil
+
+			default:
+				return walk()
+			}
+
+		case cbor.ArrayType:
+			size, err := decoder.DecodeArrayHead()
+			if err != nil {
+				return err
+			}
+			for i := uint64(0); i < size; i++ {
+				err = walk()
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+
+		default:
+			return decoder.Skip()
+		}
+	}
+
+	err := walk()
+	if err != nil {
+		return nil, fmt.Errorf("invalid segment references: %w", err)
+	}
+
+	return ids, nil
+}
+
+// decodeSegmentedArrayContent decodes the content of a segmented array,
+// and returns the segment references
+//
+func decodeSegmentedArrayContent(decoder *cbor.StreamDecoder) ([]*arraySegment, error) {
+	size, err := decoder.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+	if size != encodedSegmentedArrayValueLength {
+		return nil, fmt.Errorf("invalid segmented array encoding: expected %d elements, got %d",
+			encodedSegmentedArrayValueLength, size)
+	}
+
+	// Skip the static type
+	err = decoder.Skip()
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArraySegmentReferences(decoder)
+}
+
+// decodeSegmentedDictionaryContent decodes the content of a segmented dictionary,
+// and returns the bucket references
+//
+func decodeSegmentedDictionaryContent(decoder *cbor.StreamDecoder) (deferred bool, buckets []*dictionaryBucket, err error) {
+	size, err := decoder.DecodeArrayHead()
+	if err != nil {
+		return false, nil, err
+	}
+	if size != encodedSegmentedDictionaryValueLength {
+		return false, nil, fmt.Errorf("invalid segmented dictionary encoding: expected %d elements, got %d",
+			encodedSegmentedDictionaryValueLength, size)
+	}
+
+	// Skip the static type
+	err = decoder.Skip()
+	if err != nil {
+		return false, nil, err
+	}
+
+	deferred, err = decoder.DecodeBool()
+	if err != nil {
+		return false, nil, err
+	}
+
+	buckets, err = decodeDictionaryBucketReferences(decoder)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return deferred, buckets, nil
+}
+
+// decodeArraySegmentReferences decodes the references to the segments of a segmented array
+//
+func decodeArraySegmentReferences(decoder *cbor.StreamDecoder) ([]*arraySegment, error) {
+	size, err := decoder.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]*arraySegment, size)
+
+	for i := range segments {
+		referenceSize, err := decoder.DecodeArrayHead()
+		if err != nil {
+			return nil, err
+		}
+		if referenceSize != encodedArraySegmentReferenceLength {
+			return nil, fmt.Errorf("invalid array segment reference: expected %d elements, got %d",
+				encodedArraySegmentReferenceLength, referenceSize)
+		}
+
+		count, err := decoder.DecodeUint64()
+		if err != nil {
+			return nil, err
+		}
+
+		id, err := decodeSegmentID(decoder)
+		if err != nil {
+			return nil, err
+		}
+
+		segments[i] = &arraySegment{
+			id:    id,
+			count: int(count),
+		}
+	}
+
+	return segments, nil
+}
+
+// decodeDictionaryBucketReferences decodes the references to the buckets of a segmented dictionary
+//
+func decodeDictionaryBucketReferences(decoder *cbor.StreamDecoder) ([]*dictionaryBucket, error) {
+	size, err := decoder.DecodeArrayHead()
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]*dictionaryBucket, size)
+
+	for i := range buckets {
+		referenceSize, err := decoder.DecodeArrayHead()
+		if err != nil {
+			return nil, err
+		}
+		if referenceSize != encodedDictionaryBucketReferenceLength {
+			return nil, fmt.Errorf("invalid dictionary bucket reference: expected %d elements, got %d",
+				encodedDictionaryBucketReferenceLength, referenceSize)
+		}
+
+		depth, err := decoder.DecodeUint64()
+		if err != nil {
+			return nil, err
+		}
+		if depth > maxBucketDepth {
+			return nil, fmt.Errorf("invalid dictionary bucket reference: invalid depth %d", depth)
+		}
+
+		prefix, err := decoder.DecodeUint64()
+		if err != nil {
+			return nil, err
+		}
+
+		count, err := decoder.DecodeUint64()
+		if err != nil {
+			return nil, err
+		}
+
+		bucket := &dictionaryBucket{
+			depth:  uint(depth),
+			prefix: prefix,
+			count:  int(count),
+		}
+
+		// Empty buckets have no segment
+		if count == 0 {
+			err = decoder.DecodeNil()
+			if err != nil {
+				return nil, err
+			}
+			bucket.loaded = true
+		} else {
+			bucket.id, err = decodeSegmentID(decoder)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		buckets[i] = bucket
+	}
+
+	return buckets, nil
+}
+
+func decodeSegmentID(decoder *cbor.StreamDecoder) (id SegmentID, err error) {
+	data, err := decoder.DecodeBytes()
+	if err != nil {
+		return id, err
+	}
+	if len(data) != segmentIDLength {
+		return id, fmt.Errorf("invalid segment ID length: %d", len(data))
+	}
+	copy(id[:], data)
+	return id, nil
+}
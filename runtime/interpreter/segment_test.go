@@ -0,0 +1,558 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+type testSegmentStore struct {
+	segments map[SegmentID][]byte
+	loads    int
+}
+
+func newTestSegmentStore() *testSegmentStore {
+	return &testSegmentStore{
+		segments: map[SegmentID][]byte{},
+	}
+}
+
+func (s *testSegmentStore) add(deferrals *EncodingDeferrals) {
+	for _, segment := range deferrals.Segments {
+		s.segments[segment.ID] = segment.Data
+	}
+}
+
+func (s *testSegmentStore) load(_ common.Address, _ string, id SegmentID) ([]byte, error) {
+	s.loads++
+
+	data, ok := s.segments[id]
+	if !ok {
+		return nil, fmt.Errorf("missing segment %s", id)
+	}
+	return data, nil
+}
+
+func (s *testSegmentStore) encode(t *testing.T, value Value, key string) []byte {
+	encoded, deferrals, err := EncodeValue(value, []string{key}, true, nil)
+	require.NoError(t, err)
+
+	s.add(deferrals)
+
+	return encoded
+}
+
+func (s *testSegmentStore) decode(t *testing.T, encoded []byte, key string) Value {
+	decoded, err := DecodeSegmentedValue(
+		encoded,
+		&testOwner,
+		[]string{key},
+		CurrentEncodingVersion,
+		nil,
+		s.load,
+	)
+	require.NoError(t, err)
+
+	return decoded
+}
+
+func newTestSegmentedArrayValue(size int) *ArrayValue {
+	values := make([]Value, size)
+
+	for i := 0; i < size; i++ {
+		values[i] = NewStringValue(fmt.Sprintf("%s%d", strings.Repeat("x", 32), i))
+	}
+
+	return NewArrayValueUnownedNonCopying(
+		VariableSizedStaticType{
+			Type: PrimitiveStaticTypeString,
+		},
+		values...,
+	)
+}
+
+func segmentIDSet(ids []SegmentID) map[SegmentID]struct{} {
+	set := map[SegmentID]struct{}{}
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+func TestSegmentedArray(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("small array", func(t *testing.T) {
+		t.Parallel()
+
+		encoded, deferrals, err := EncodeValue(newTestSegmentedArrayValue(10), []string{"v"}, true, nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, deferrals.Segments)
+
+		references, err := SegmentReferences(encoded)
+		require.NoError(t, err)
+		assert.Empty(t, references)
+	})
+
+	t.Run("round trip, lazy loading", func(t *testing.T) {
+		t.Parallel()
+
+		store := newTestSegmentStore()
+
+		array := newTestSegmentedArrayValue(2000)
+
+		encoded, deferrals, err := EncodeValue(array, []string{"v"}, true, nil)
+		require.NoError(t, err)
+
+		store.add(deferrals)
+
+		assert.Less(t, len(encoded), segmentationThreshold)
+		require.Greater(t, len(deferrals.Segments), 1)
+
+		references, err := SegmentReferences(encoded)
+		require.NoError(t, err)
+		assert.Len(t, references, len(deferrals.Segments))
+
+		for _, segment := range deferrals.Segments {
+			assert.True(t, IsSegment(segment.Data))
+			assert.LessOrEqual(t, len(segment.Data), maxSegmentSize+fullPrefixLength+9)
+		}
+
+		decoded := store.decode(t, encoded, "v")
+		require.IsType(t, &ArrayValue{}, decoded)
+		decodedArray := decoded.(*ArrayValue)
+
+		assert.True(t, decodedArray.IsSegmented())
+		assert.Equal(t, 2000, decodedArray.Count())
+		assert.Equal(t, 0, store.loads)
+
+		// Only the segment containing the element is loaded
+
+		assert.Equal(t, array.values[1234], decodedArray.Get(nil, nil, NewIntValueFromInt64(1234)))
+		assert.Equal(t, 1, store.loads)
+
+		assert.Equal(t, array.values[1234], decodedArray.Get(nil, nil, NewIntValueFromInt64(1234)))
+		assert.Equal(t, 1, store.loads)
+
+		// All segments are loaded
+
+		assert.Equal(t, array.Elements(), decodedArray.Elements())
+		assert.Equal(t, len(references), store.loads)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		store := newTestSegmentStore()
+
+		array := newTestSegmentedArrayValue(2000)
+
+		encoded := store.encode(t, array, "v")
+
+		references, err := SegmentReferences(encoded)
+		require.NoError(t, err)
+
+		decodedArray := store.decode(t, encoded, "v").(*ArrayValue)
+
+		newElement := NewStringValue("new")
+		decodedArray.SetIndex(inter, nil, 1234, newElement)
+		array.SetIndex(inter, nil, 1234, newElement)
+
+		assert.Equal(t, 1, store.loads)
+
+		// Only the modified segment is encoded again
+
+		reEncoded, deferrals, err := EncodeValue(decodedArray, []string{"v"}, true, nil)
+		require.NoError(t, err)
+
+		store.add(deferrals)
+
+		assert.Equal(t, 1, store.loads)
+		assert.NotEmpty(t, deferrals.Segments)
+		assert.LessOrEqual(t, len(deferrals.Segments), 2)
+
+		newReferences, err := SegmentReferences(reEncoded)
+		require.NoError(t, err)
+
+		previousReferences := segmentIDSet(references)
+
+		kept := 0
+		for _, id := range newReferences {
+			if _, ok := previousReferences[id]; ok {
+				kept++
+			}
+		}
+		assert.Equal(t, len(newReferences)-len(deferrals.Segments), kept)
+
+		reDecodedArray := store.decode(t, reEncoded, "v").(*ArrayValue)
+
+		assert.Equal(t, array.Elements(), reDecodedArray.Elements())
+	})
+
+	t.Run("insert and remove", func(t *testing.T) {
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		store := newTestSegmentStore()
+
+		array := newTestSegmentedArrayValue(2000)
+
+		encoded := store.encode(t, array, "v")
+
+		decodedArray := store.decode(t, encoded, "v").(*ArrayValue)
+
+		for _, a := range []*ArrayValue{array, decodedArray} {
+			a.Insert(inter, nil, 10, NewStringValue("inserted"))
+			a.Append(inter, nil, NewStringValue("appended"))
+			a.Remove(1500, nil)
+			a.RemoveFirst(nil)
+		}
+
+		reEncoded := store.encode(t, decodedArray, "v")
+
+		reDecodedArray := store.decode(t, reEncoded, "v").(*ArrayValue)
+
+		assert.Equal(t, array.Count(), reDecodedArray.Count())
+		assert.Equal(t, array.Elements(), reDecodedArray.Elements())
+	})
+
+	t.Run("shrink", func(t *testing.T) {
+		t.Parallel()
+
+		store := newTestSegmentStore()
+
+		encoded := store.encode(t, newTestSegmentedArrayValue(1000), "v")
+
+		decodedArray := store.decode(t, encoded, "v").(*ArrayValue)
+
+		for decodedArray.Count() > 100 {
+			decodedArray.RemoveLast(nil)
+		}
+
+		// A small array is encoded inline again
+
+		reEncoded, deferrals, err := EncodeValue(decodedArray, []string{"v"}, true, nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, deferrals.Segments)
+
+		references, err := SegmentReferences(reEncoded)
+		require.NoError(t, err)
+		assert.Empty(t, references)
+
+		reDecoded, err := DecodeValue(reEncoded, &testOwner, []string{"v"}, CurrentEncodingVersion, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			newTestSegmentedArrayValue(100).Elements(),
+			reDecoded.(*ArrayValue).Elements(),
+		)
+	})
+
+	t.Run("copy to other key", func(t *testing.T) {
+		t.Parallel()
+
+		store := newTestSegmentStore()
+
+		array := newTestSegmentedArrayValue(2000)
+
+		encoded := store.encode(t, array, "v")
+
+		references, err := SegmentReferences(encoded)
+		require.NoError(t, err)
+
+		decodedArray := store.decode(t, encoded, "v").(*ArrayValue)
+
+		// The segments of the value are stored under its key,
+		// so they must be copied, but are not decoded
+
+		copied, deferrals, err := EncodeValue(decodedArray, []string{"w"}, true, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, len(references), store.loads)
+
+		copiedReferences, err := SegmentReferences(copied)
+		require.NoError(t, err)
+		assert.Equal(t, references, copiedReferences)
+
+		var segmentIDs []SegmentID
+		for _, segment := range deferrals.Segments {
+			segmentIDs = append(segmentIDs, segment.ID)
+		}
+		assert.Equal(t, references, segmentIDs)
+	})
+
+	t.Run("not deferred", func(t *testing.T) {
+		t.Parallel()
+
+		store := newTestSegmentStore()
+
+		array := newTestSegmentedArrayValue(2000)
+
+		encoded := store.encode(t, array, "v")
+
+		decodedArray := store.decode(t, encoded, "v").(*ArrayValue)
+
+		// Segments can only be referenced by stored values
+
+		inline, deferrals, err := EncodeValue(decodedArray, []string{"v"}, false, nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, deferrals.Segments)
+
+		references, err := SegmentReferences(inline)
+		require.NoError(t, err)
+		assert.Empty(t, references)
+
+		decoded, err := DecodeValue(inline, &testOwner, []string{"v"}, CurrentEncodingVersion, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, array.Elements(), decoded.(*ArrayValue).Elements())
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		store := newTestSegmentStore()
+
+		newArray := func() *ArrayValue {
+			return NewArrayValueUnownedNonCopying(
+				VariableSizedStaticType{
+					Type: VariableSizedStaticType{
+						Type: PrimitiveStaticTypeString,
+					},
+				},
+				newTestSegmentedArrayValue(1000),
+				newTestSegmentedArrayValue(1000),
+				newTestSegmentedArrayValue(10),
+			)
+		}
+
+		array := newArray()
+
+		encoded := store.encode(t, array, "v")
+
+		// The nested arrays are segmented, the outer array is not
+
+		assert.Less(t, len(encoded), segmentationThreshold)
+
+		decodedArray := store.decode(t, encoded, "v").(*ArrayValue)
+		assert.False(t, decodedArray.IsSegmented())
+
+		first := decodedArray.Get(nil, nil, NewIntValueFromInt64(0)).(*ArrayValue)
+		assert.True(t, first.IsSegmented())
+
+		second := decodedArray.Get(nil, nil, NewIntValueFromInt64(1)).(*ArrayValue)
+		second.SetIndex(inter, nil, 0, NewStringValue("new"))
+
+		array.Get(nil, nil, NewIntValueFromInt64(1)).(*ArrayValue).
+			SetIndex(inter, nil, 0, NewStringValue("new"))
+
+		// The unmodified nested array is kept as it is
+
+		reEncoded := store.encode(t, decodedArray, "v")
+		assert.Equal(t, 1, store.loads)
+
+		reDecodedArray := store.decode(t, reEncoded, "v").(*ArrayValue)
+
+		for i := 0; i < 3; i++ {
+			index := NewIntValueFromInt64(int64(i))
+			assert.Equal(t,
+				array.Get(nil, nil, index).(*ArrayValue).Elements(),
+				reDecodedArray.Get(nil, nil, index).(*ArrayValue).Elements(),
+			)
+		}
+	})
+}
+
+func TestSegmentedDictionary(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("round trip, lazy loading", func(t *testing.T) {
+		t.Parallel()
+
+		store := newTestSegmentStore()
+
+		dictionary := newTestDictionaryValue(t, 3000)
+
+		encoded, deferrals, err := EncodeValue(dictionary, []string{"v"}, true, nil)
+		require.NoError(t, err)
+
+		store.add(deferrals)
+
+		assert.Less(t, len(encoded), segmentationThreshold)
+		require.Greater(t, len(deferrals.Segments), 1)
+
+		references, err := SegmentReferences(encoded)
+		require.NoError(t, err)
+		assert.Len(t, references, len(deferrals.Segments))
+
+		decoded := store.decode(t, encoded, "v")
+		require.IsType(t, &DictionaryValue{}, decoded)
+		decodedDictionary := decoded.(*DictionaryValue)
+
+		assert.True(t, decodedDictionary.IsSegmented())
+		assert.Equal(t, 3000, decodedDictionary.Count())
+		assert.Equal(t, 0, store.loads)
+
+		// Only the bucket containing the key is loaded
+
+		assert.Equal(t,
+			NewSomeValueOwningNonCopying(NewStringValue("value1234")),
+			decodedDictionary.Get(nil, nil, NewStringValue("key1234")),
+		)
+		assert.Equal(t, 1, store.loads)
+
+		assert.Equal(t,
+			NilValue{},
+			decodedDictionary.Get(nil, nil, NewStringValue("unknown")),
+		)
+		assert.LessOrEqual(t, store.loads, 2)
+
+		// All buckets are loaded
+
+		assert.Equal(t, 3000, decodedDictionary.Keys().Count())
+		assert.Equal(t, len(references), store.loads)
+
+		for i := 0; i < 3000; i++ {
+			assert.Equal(t,
+				NewSomeValueOwningNonCopying(NewStringValue(fmt.Sprintf("value%d", i))),
+				decodedDictionary.Get(nil, nil, NewStringValue(fmt.Sprintf("key%d", i))),
+			)
+		}
+	})
+
+	t.Run("insert and remove", func(t *testing.T) {
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		store := newTestSegmentStore()
+
+		encoded := store.encode(t, newTestDictionaryValue(t, 3000), "v")
+
+		references, err := SegmentReferences(encoded)
+		require.NoError(t, err)
+
+		decodedDictionary := store.decode(t, encoded, "v").(*DictionaryValue)
+
+		decodedDictionary.Insert(inter, nil, NewStringValue("new"), NewStringValue("value"))
+		assert.Equal(t, 1, store.loads)
+
+		decodedDictionary.Remove(inter, nil, NewStringValue("key42"))
+		assert.LessOrEqual(t, store.loads, 2)
+
+		loads := store.loads
+
+		// Only the modified buckets are encoded again
+
+		reEncoded, deferrals, err := EncodeValue(decodedDictionary, []string{"v"}, true, nil)
+		require.NoError(t, err)
+
+		store.add(deferrals)
+
+		assert.Equal(t, loads, store.loads)
+		assert.NotEmpty(t, deferrals.Segments)
+		assert.LessOrEqual(t, len(deferrals.Segments), 4)
+
+		newReferences, err := SegmentReferences(reEncoded)
+		require.NoError(t, err)
+
+		previousReferences := segmentIDSet(references)
+
+		kept := 0
+		for _, id := range newReferences {
+			if _, ok := previousReferences[id]; ok {
+				kept++
+			}
+		}
+		assert.Equal(t, len(newReferences)-len(deferrals.Segments), kept)
+
+		reDecodedDictionary := store.decode(t, reEncoded, "v").(*DictionaryValue)
+
+		assert.Equal(t, 3000, reDecodedDictionary.Count())
+
+		assert.Equal(t,
+			NewSomeValueOwningNonCopying(NewStringValue("value")),
+			reDecodedDictionary.Get(nil, nil, NewStringValue("new")),
+		)
+		assert.Equal(t,
+			NilValue{},
+			reDecodedDictionary.Get(nil, nil, NewStringValue("key42")),
+		)
+		assert.Equal(t,
+			NewSomeValueOwningNonCopying(NewStringValue("value43")),
+			reDecodedDictionary.Get(nil, nil, NewStringValue("key43")),
+		)
+	})
+
+	t.Run("shrink", func(t *testing.T) {
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		store := newTestSegmentStore()
+
+		encoded := store.encode(t, newTestDictionaryValue(t, 2000), "v")
+
+		decodedDictionary := store.decode(t, encoded, "v").(*DictionaryValue)
+
+		for i := 100; i < 2000; i++ {
+			decodedDictionary.Remove(inter, nil, NewStringValue(fmt.Sprintf("key%d", i)))
+		}
+
+		// A small dictionary is encoded inline again
+
+		reEncoded, deferrals, err := EncodeValue(decodedDictionary, []string{"v"}, true, nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, deferrals.Segments)
+
+		references, err := SegmentReferences(reEncoded)
+		require.NoError(t, err)
+		assert.Empty(t, references)
+
+		reDecoded, err := DecodeValue(reEncoded, &testOwner, []string{"v"}, CurrentEncodingVersion, nil)
+		require.NoError(t, err)
+
+		reDecodedDictionary := reDecoded.(*DictionaryValue)
+		assert.Equal(t, 100, reDecodedDictionary.Count())
+
+		assert.Equal(t,
+			NewSomeValueOwningNonCopying(NewStringValue("value99")),
+			reDecodedDictionary.Get(nil, nil, NewStringValue("key99")),
+		)
+	})
+}
@@ -45,15 +45,29 @@ type StaticType interface {
 type CompositeStaticType struct {
 	Location            common.Location
 	QualifiedIdentifier string
+	// TypeArguments are the type arguments of an instantiation of a generic composite type
+	TypeArguments []StaticType
 }
 
 func (CompositeStaticType) isStaticType() {}
 
 func (t CompositeStaticType) String() string {
+	var result string
 	if t.Location == nil {
-		return t.QualifiedIdentifier
+		result = t.QualifiedIdentifier
+	} else {
+		result = string(t.Location.TypeID(t.QualifiedIdentifier))
 	}
-	return string(t.Location.TypeID(t.QualifiedIdentifier))
+
+	if len(t.TypeArguments) > 0 {
+		typeArguments := make([]string, len(t.TypeArguments))
+		for i, typeArgument := range t.TypeArguments {
+			typeArguments[i] = typeArgument.String()
+		}
+		result = fmt.Sprintf("%s<%s>", result, strings.Join(typeArguments, ", "))
+	}
+
+	return result
 }
 
 func (t CompositeStaticType) Equal(other StaticType) bool {
@@ -62,6 +76,16 @@ func (t CompositeStaticType) Equal(other StaticType) bool {
 		return false
 	}
 
+	if len(otherCompositeType.TypeArguments) != len(t.TypeArguments) {
+		return false
+	}
+
+	for i, typeArgument := range t.TypeArguments {
+		if !typeArgument.Equal(otherCompositeType.TypeArguments[i]) {
+			return false
+		}
+	}
+
 	return common.LocationsMatch(otherCompositeType.Location, t.Location) &&
 		otherCompositeType.QualifiedIdentifier == t.QualifiedIdentifier
 }
@@ -303,6 +327,37 @@ func (t CapabilityStaticType) Equal(other StaticType) bool {
 	return t.BorrowType.Equal(otherCapabilityType.BorrowType)
 }
 
+// RangeStaticType
+
+type RangeStaticType struct {
+	ElementType StaticType
+}
+
+func (RangeStaticType) isStaticType() {}
+
+func (t RangeStaticType) String() string {
+	if t.ElementType != nil {
+		return fmt.Sprintf("Range<%s>", t.ElementType)
+	}
+	return "Range"
+}
+
+func (t RangeStaticType) Equal(other StaticType) bool {
+	otherRangeType, ok := other.(RangeStaticType)
+	if !ok {
+		return false
+	}
+
+	// The element types must either be both nil,
+	// or they must be equal
+
+	if t.ElementType == nil {
+		return otherRangeType.ElementType == nil
+	}
+
+	return t.ElementType.Equal(otherRangeType.ElementType)
+}
+
 // Conversion
 
 func ConvertSemaToStaticType(t sema.Type) StaticType {
@@ -313,6 +368,18 @@ func ConvertSemaToStaticType(t sema.Type) StaticType {
 			QualifiedIdentifier: t.QualifiedIdentifier(),
 		}
 
+	case *sema.InstantiatedCompositeType:
+		typeArguments := make([]StaticType, len(t.TypeArguments()))
+		for i, typeArgument := range t.TypeArguments() {
+			typeArguments[i] = ConvertSemaToStaticType(typeArgument)
+		}
+
+		return CompositeStaticType{
+			Location:            t.CompositeType.Location,
+			QualifiedIdentifier: t.CompositeType.QualifiedIdentifier(),
+			TypeArguments:       typeArguments,
+		}
+
 	case *sema.InterfaceType:
 		return ConvertSemaInterfaceTypeToStaticInterfaceType(t)
 
@@ -349,6 +416,13 @@ func ConvertSemaToStaticType(t sema.Type) StaticType {
 		}
 		return result
 
+	case *sema.RangeType:
+		result := RangeStaticType{}
+		if t.ElementType != nil {
+			result.ElementType = ConvertSemaToStaticType(t.ElementType)
+		}
+		return result
+
 	case *sema.FunctionType:
 		return FunctionStaticType{
 			Type: t,
@@ -408,7 +482,17 @@ func ConvertStaticToSemaType(
 ) sema.Type {
 	switch t := typ.(type) {
 	case CompositeStaticType:
-		return getComposite(t.Location, t.QualifiedIdentifier)
+		compositeType := getComposite(t.Location, t.QualifiedIdentifier)
+		if len(t.TypeArguments) == 0 {
+			return compositeType
+		}
+
+		typeArguments := make([]sema.Type, len(t.TypeArguments))
+		for i, typeArgument := range t.TypeArguments {
+			typeArguments[i] = ConvertStaticToSemaType(typeArgument, getInterface, getComposite)
+		}
+
+		return sema.NewInstantiatedCompositeType(compositeType, typeArguments)
 
 	case InterfaceStaticType:
 		return getInterface(t.Location, t.QualifiedIdentifier)
@@ -463,6 +547,16 @@ func ConvertStaticToSemaType(
 			BorrowType: borrowType,
 		}
 
+	case RangeStaticType:
+		var elementType sema.Type
+		if t.ElementType != nil {
+			elementType = ConvertStaticToSemaType(t.ElementType, getInterface, getComposite)
+		}
+
+		return &sema.RangeType{
+			ElementType: elementType,
+		}
+
 	case FunctionStaticType:
 		return t.Type
 
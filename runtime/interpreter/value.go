@@ -25,12 +25,16 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/rivo/uniseg"
 	"golang.org/x/text/unicode/norm"
 
+	"github.com/onflow/cadence/fixedpoint"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/common/orderedmap"
 	"github.com/onflow/cadence/runtime/errors"
@@ -528,7 +532,9 @@ func (v *StringValue) checkBoundsInclusiveLength(index int, getLocationRange fun
 	}
 }
 
-func (v *StringValue) Get(_ *Interpreter, getLocationRange func() LocationRange, key Value) Value {
+func (v *StringValue) Get(interpreter *Interpreter, getLocationRange func() LocationRange, key Value) Value {
+	interpreter.reportStringOperation(getLocationRange, len(v.Str))
+
 	index := key.(NumberValue).ToInt()
 	v.checkBounds(index, getLocationRange)
 
@@ -547,19 +553,22 @@ func (v *StringValue) Set(_ *Interpreter, _ func() LocationRange, _ Value, _ Val
 	panic(errors.NewUnreachableError())
 }
 
-func (v *StringValue) GetMember(_ *Interpreter, _ func() LocationRange, name string) Value {
+func (v *StringValue) GetMember(interpreter *Interpreter, getLocationRange func() LocationRange, name string) Value {
 	switch name {
 	case "length":
+		interpreter.reportStringOperation(getLocationRange, len(v.Str))
 		length := v.Length()
 		return NewIntValueFromInt64(int64(length))
 
 	case "utf8":
+		interpreter.reportStringOperation(getLocationRange, len(v.Str))
 		return ByteSliceToByteArrayValue([]byte(v.Str))
 
 	case "concat":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
-				otherValue := invocation.Arguments[0].(ConcatenatableValue)
+				otherValue := invocation.Arguments[0].(*StringValue)
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str)+len(otherValue.Str))
 				return v.Concat(otherValue)
 			},
 			sema.StringTypeConcatFunctionType,
@@ -570,6 +579,7 @@ func (v *StringValue) GetMember(_ *Interpreter, _ func() LocationRange, name str
 			func(invocation Invocation) Value {
 				from := invocation.Arguments[0].(IntValue)
 				to := invocation.Arguments[1].(IntValue)
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str))
 				return v.Slice(from, to, invocation.GetLocationRange)
 			},
 			sema.StringTypeSliceFunctionType,
@@ -578,6 +588,7 @@ func (v *StringValue) GetMember(_ *Interpreter, _ func() LocationRange, name str
 	case "decodeHex":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str))
 				return v.DecodeHex()
 			},
 			sema.StringTypeDecodeHexFunctionType,
@@ -586,10 +597,133 @@ func (v *StringValue) GetMember(_ *Interpreter, _ func() LocationRange, name str
 	case "toLower":
 		return NewHostFunctionValue(
 			func(invocation Invocation) Value {
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str))
 				return v.ToLower()
 			},
 			sema.StringTypeToLowerFunctionType,
 		)
+
+	case "toUpper":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str))
+				return v.ToUpper()
+			},
+			sema.StringTypeToUpperFunctionType,
+		)
+
+	case "split":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				separator := invocation.Arguments[0].(*StringValue)
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str)+len(separator.Str))
+				return v.Split(invocation.Interpreter, separator)
+			},
+			sema.StringTypeSplitFunctionType,
+		)
+
+	case "contains":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other := invocation.Arguments[0].(*StringValue)
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str)+len(other.Str))
+				return BoolValue(v.Contains(invocation.Interpreter, other))
+			},
+			sema.StringTypeContainsFunctionType,
+		)
+
+	case "index":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other := invocation.Arguments[0].(*StringValue)
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str)+len(other.Str))
+				return v.IndexOf(invocation.Interpreter, other)
+			},
+			sema.StringTypeIndexFunctionType,
+		)
+
+	case "replaceAll":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				original := invocation.Arguments[0].(*StringValue)
+				replacement := invocation.Arguments[1].(*StringValue)
+				invocation.Interpreter.reportStringOperation(
+					invocation.GetLocationRange,
+					len(v.Str)+len(original.Str)+len(replacement.Str),
+				)
+				return v.ReplaceAll(invocation.Interpreter, original, replacement)
+			},
+			sema.StringTypeReplaceAllFunctionType,
+		)
+
+	case "trim":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str))
+				return v.Trim(invocation.Interpreter)
+			},
+			sema.StringTypeTrimFunctionType,
+		)
+
+	case "hasPrefix":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				prefix := invocation.Arguments[0].(*StringValue)
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str)+len(prefix.Str))
+				return BoolValue(v.HasPrefix(invocation.Interpreter, prefix))
+			},
+			sema.StringTypeHasPrefixFunctionType,
+		)
+
+	case "hasSuffix":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				suffix := invocation.Arguments[0].(*StringValue)
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str)+len(suffix.Str))
+				return BoolValue(v.HasSuffix(invocation.Interpreter, suffix))
+			},
+			sema.StringTypeHasSuffixFunctionType,
+		)
+
+	case "characters":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str))
+				return v.Characters(invocation.Interpreter)
+			},
+			sema.StringTypeCharactersFunctionType,
+		)
+
+	case "toUTF8":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				invocation.Interpreter.reportStringOperation(invocation.GetLocationRange, len(v.Str))
+				return ByteSliceToByteArrayValue([]byte(v.Str))
+			},
+			sema.StringTypeToUTF8FunctionType,
+		)
+
+	// Character members.
+	// Characters are represented as string values
+
+	case sema.ToStringFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return NewStringValue(v.Str)
+			},
+			sema.ToStringFunctionType,
+		)
+
+	case "isWhitespace":
+		return BoolValue(v.isWhitespace())
+
+	case "isLetter":
+		r, _ := utf8.DecodeRuneInString(v.Str)
+		return BoolValue(unicode.IsLetter(r))
+
+	case "isDigit":
+		r, _ := utf8.DecodeRuneInString(v.Str)
+		return BoolValue(unicode.IsDigit(r))
 	}
 
 	return nil
@@ -613,6 +747,235 @@ func (v *StringValue) ToLower() *StringValue {
 	return NewStringValue(strings.ToLower(v.Str))
 }
 
+func (v *StringValue) ToUpper() *StringValue {
+	return NewStringValue(strings.ToUpper(v.Str))
+}
+
+var CharacterArrayStaticType = ConvertSemaArrayTypeToStaticArrayType(sema.CharacterArrayType)
+
+// Characters returns an array of the characters (grapheme clusters) of this string
+//
+func (v *StringValue) Characters(inter *Interpreter) *ArrayValue {
+	values := make([]Value, 0, v.Length())
+
+	v.prepareGraphemes()
+
+	for v.graphemes.Next() {
+		inter.checkCancellation()
+
+		values = append(values, NewStringValue(v.graphemes.Str()))
+	}
+
+	return NewArrayValueUnownedNonCopying(CharacterArrayStaticType, values...)
+}
+
+// graphemeBoundaries returns the byte offsets of the start of each character (grapheme cluster)
+// of this string, followed by the length of the string
+//
+func (v *StringValue) graphemeBoundaries(inter *Interpreter) []int {
+	boundaries := make([]int, 0, len(v.Str)+1)
+
+	v.prepareGraphemes()
+
+	for v.graphemes.Next() {
+		inter.checkCancellation()
+
+		start, _ := v.graphemes.Positions()
+		boundaries = append(boundaries, start)
+	}
+
+	return append(boundaries, len(v.Str))
+}
+
+// isGraphemeBoundary returns true if the given byte offset is in the given sorted boundaries
+//
+func isGraphemeBoundary(boundaries []int, offset int) bool {
+	index := sort.SearchInts(boundaries, offset)
+	return index < len(boundaries) && boundaries[index] == offset
+}
+
+// occurrences returns the byte offsets of the non-overlapping occurrences of the given string.
+//
+// Only occurrences which start and end on character (grapheme cluster) boundaries are considered,
+// e.g. the string "e\u{301}" does not contain the string "e".
+//
+// The given string must not be empty. If limit is positive, at most limit occurrences are returned
+//
+func (v *StringValue) occurrences(inter *Interpreter, other string, limit int) []int {
+	var result []int
+
+	boundaries := v.graphemeBoundaries(inter)
+
+	// The last boundary is the end of the string,
+	// an occurrence can't start there
+
+	for i := 0; i < len(boundaries)-1; {
+		inter.checkCancellation()
+
+		start := boundaries[i]
+		end := start + len(other)
+
+		if !strings.HasPrefix(v.Str[start:], other) ||
+			!isGraphemeBoundary(boundaries, end) {
+
+			i++
+			continue
+		}
+
+		result = append(result, start)
+		if limit > 0 && len(result) == limit {
+			break
+		}
+
+		// Continue after the occurrence
+
+		for i < len(boundaries)-1 && boundaries[i] < end {
+			i++
+		}
+	}
+
+	return result
+}
+
+func (v *StringValue) Split(inter *Interpreter, separator *StringValue) *ArrayValue {
+
+	if separator.Str == "" {
+		characters := v.Characters(inter)
+		return NewArrayValueUnownedNonCopying(
+			StringArrayStaticType,
+			characters.Elements()...,
+		)
+	}
+
+	occurrences := v.occurrences(inter, separator.Str, 0)
+
+	values := make([]Value, 0, len(occurrences)+1)
+
+	start := 0
+	for _, occurrence := range occurrences {
+		values = append(values, NewStringValue(v.Str[start:occurrence]))
+		start = occurrence + len(separator.Str)
+	}
+	values = append(values, NewStringValue(v.Str[start:]))
+
+	return NewArrayValueUnownedNonCopying(StringArrayStaticType, values...)
+}
+
+var StringArrayStaticType = ConvertSemaArrayTypeToStaticArrayType(sema.StringArrayType)
+
+func (v *StringValue) Contains(inter *Interpreter, other *StringValue) bool {
+	if other.Str == "" {
+		return true
+	}
+
+	return len(v.occurrences(inter, other.Str, 1)) > 0
+}
+
+// IndexOf returns the index of the first character of the first occurrence of the given string,
+// or nil if the string does not contain it
+//
+func (v *StringValue) IndexOf(inter *Interpreter, other *StringValue) OptionalValue {
+	if other.Str == "" {
+		return NewSomeValueOwningNonCopying(NewIntValueFromInt64(0))
+	}
+
+	occurrences := v.occurrences(inter, other.Str, 1)
+	if len(occurrences) == 0 {
+		return NilValue{}
+	}
+
+	// Convert the byte offset to a character index
+
+	boundaries := v.graphemeBoundaries(inter)
+	index := sort.SearchInts(boundaries, occurrences[0])
+	return NewSomeValueOwningNonCopying(NewIntValueFromInt64(int64(index)))
+}
+
+func (v *StringValue) ReplaceAll(inter *Interpreter, original *StringValue, replacement *StringValue) *StringValue {
+	if original.Str == "" {
+		return NewStringValue(v.Str)
+	}
+
+	var sb strings.Builder
+
+	start := 0
+	for _, occurrence := range v.occurrences(inter, original.Str, 0) {
+		sb.WriteString(v.Str[start:occurrence])
+		sb.WriteString(replacement.Str)
+		start = occurrence + len(original.Str)
+	}
+	sb.WriteString(v.Str[start:])
+
+	return NewStringValue(sb.String())
+}
+
+// Trim returns the string without leading and trailing characters
+// which only consist of whitespace
+//
+func (v *StringValue) Trim(inter *Interpreter) *StringValue {
+	start := -1
+	end := 0
+
+	v.prepareGraphemes()
+
+	for v.graphemes.Next() {
+		inter.checkCancellation()
+
+		if isWhitespace(v.graphemes.Str()) {
+			continue
+		}
+
+		characterStart, characterEnd := v.graphemes.Positions()
+		if start < 0 {
+			start = characterStart
+		}
+		end = characterEnd
+	}
+
+	if start < 0 {
+		return NewStringValue("")
+	}
+
+	return NewStringValue(v.Str[start:end])
+}
+
+func (v *StringValue) HasPrefix(inter *Interpreter, prefix *StringValue) bool {
+	if !strings.HasPrefix(v.Str, prefix.Str) {
+		return false
+	}
+
+	return isGraphemeBoundary(v.graphemeBoundaries(inter), len(prefix.Str))
+}
+
+func (v *StringValue) HasSuffix(inter *Interpreter, suffix *StringValue) bool {
+	if !strings.HasSuffix(v.Str, suffix.Str) {
+		return false
+	}
+
+	return isGraphemeBoundary(v.graphemeBoundaries(inter), len(v.Str)-len(suffix.Str))
+}
+
+func (v *StringValue) isWhitespace() bool {
+	return isWhitespace(v.Str)
+}
+
+// isWhitespace returns true if the given string is not empty
+// and all its code points are whitespace
+//
+func isWhitespace(str string) bool {
+	if str == "" {
+		return false
+	}
+
+	for _, r := range str {
+		if !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (*StringValue) IsStorable() bool {
 	return true
 }
@@ -663,6 +1026,11 @@ type ArrayValue struct {
 	// Only available for decoded arrays who's elements are not loaded yet.
 	elementsContent []byte
 
+	// Raw content cache for individual elements, indexed like `values`.
+	// Only available for decoded arrays who's elements are not all loaded yet.
+	// An element in `values` is nil until it is decoded.
+	elementContents [][]byte
+
 	// Value's path to be used during decoding.
 	// Only available for decoded values who's elements are not loaded yet.
 	valuePath []string
@@ -674,6 +1042,14 @@ type ArrayValue struct {
 	// Encoding version of the raw content of the elements of this value.
 	// Only available for decoded values who's elements are not loaded yet.
 	encodingVersion uint16
+
+	// Loader for the segments of segmented containers in the raw content of this value.
+	// Only available for decoded values who's elements are not loaded yet.
+	segmentLoader SegmentLoader
+
+	// Segments of the elements, see segment.go.
+	// Only available for decoded arrays which were encoded as segmented arrays.
+	segments *arraySegments
 }
 
 func NewArrayValueUnownedNonCopying(arrayType ArrayStaticType, values ...Value) *ArrayValue {
@@ -736,6 +1112,15 @@ func (v *ArrayValue) Walk(walkChild func(Value)) {
 }
 
 func (v *ArrayValue) DynamicType(interpreter *Interpreter, seenReferences SeenReferences) DynamicType {
+
+	// The elements of segmented arrays were stored,
+	// so they conform to the static type of the array, and are not loaded
+	if v.IsSegmented() {
+		return &ArrayDynamicType{
+			StaticType: v.StaticType().(ArrayStaticType),
+		}
+	}
+
 	elements := v.Elements()
 	elementTypes := make([]DynamicType, len(elements))
 
@@ -767,15 +1152,43 @@ func (v *ArrayValue) Copy() Value {
 			valuePath:       v.valuePath,
 			decodeCallback:  v.decodeCallback,
 			encodingVersion: v.encodingVersion,
+			segmentLoader:   v.segmentLoader,
+			segments:        v.segments.copy(),
 		}
 
 		return value
 	}
 
+	// Only copy the loaded elements.
+	// Elements which are not loaded yet are copied as raw-content.
+
+	v.ensureElementsIndexed()
+
 	copies := make([]Value, len(v.values))
 	for i, value := range v.values {
-		copies[i] = value.Copy()
+		if value != nil {
+			copies[i] = value.Copy()
+		}
+	}
+
+	if v.elementContents != nil {
+		elementContents := make([][]byte, len(v.elementContents))
+		copy(elementContents, v.elementContents)
+
+		return &ArrayValue{
+			Type:            v.Type,
+			values:          copies,
+			modified:        true,
+			Owner:           nil,
+			elementContents: elementContents,
+			valuePath:       v.valuePath,
+			decodeCallback:  v.decodeCallback,
+			encodingVersion: v.encodingVersion,
+			segmentLoader:   v.segmentLoader,
+			segments:        v.segments.copy(),
+		}
 	}
+
 	return NewArrayValueUnownedNonCopying(v.Type, copies...)
 }
 
@@ -788,8 +1201,28 @@ func (v *ArrayValue) SetOwner(owner *common.Address) {
 		return
 	}
 
+	// If the owner does not change, the elements do not have to be loaded
+	if v.Owner != nil && owner != nil && *v.Owner == *owner {
+		v.Owner = owner
+		return
+	}
+
 	v.Owner = owner
 
+	// The elements of segmented arrays which are not loaded yet
+	// are decoded with the new owner when they are loaded
+	if v.segments != nil {
+		v.ensureElementsIndexed()
+
+		for _, value := range v.values {
+			if value != nil {
+				value.SetOwner(owner)
+			}
+		}
+
+		return
+	}
+
 	for _, value := range v.Elements() {
 		value.SetOwner(owner)
 	}
@@ -801,12 +1234,13 @@ func (v *ArrayValue) IsModified() bool {
 	}
 
 	// If the elements are not loaded, that implies they are not modified.
-	if v.content != nil {
+	if v.content != nil || v.elementsContent != nil {
 		return false
 	}
 
 	for _, value := range v.values {
-		if value.IsModified() {
+		// Elements which are not loaded are not modified
+		if value != nil && value.IsModified() {
 			return true
 		}
 	}
@@ -827,7 +1261,7 @@ func (v *ArrayValue) Destroy(interpreter *Interpreter, getLocationRange func() L
 func (v *ArrayValue) Concat(other ConcatenatableValue) Value {
 	otherArray := other.(*ArrayValue)
 	newArray := v.Copy().(*ArrayValue)
-	newArray.values = append(newArray.Elements(), otherArray.Elements()...)
+	newArray.insertElements(newArray.Count(), otherArray.Elements())
 	return newArray
 }
 
@@ -836,7 +1270,31 @@ func (v *ArrayValue) Get(_ *Interpreter, getLocationRange func() LocationRange,
 
 	v.checkBounds(index, getLocationRange)
 
-	return v.Elements()[index]
+	return v.element(index)
+}
+
+// element returns the element at the given index.
+// Only the element is loaded, the other elements are not loaded.
+//
+func (v *ArrayValue) element(index int) Value {
+	v.ensureElementsIndexed()
+
+	value := v.values[index]
+	if value != nil {
+		return value
+	}
+
+	v.ensureSegmentLoaded(index)
+
+	value, err := decodeArrayElement(v, index, v.elementContents[index])
+	if err != nil {
+		panic(err)
+	}
+
+	v.values[index] = value
+	v.elementContents[index] = nil
+
+	return value
 }
 
 func (v *ArrayValue) Set(inter *Interpreter, getLocationRange func() LocationRange, key Value, value Value) {
@@ -855,8 +1313,14 @@ func (v *ArrayValue) SetIndex(inter *Interpreter, getLocationRange func() Locati
 	v.modified = true
 	value.SetOwner(v.Owner)
 
-	v.ensureElementsLoaded()
+	v.ensureElementsIndexed()
+	v.updateSegment(index, 0)
 	v.values[index] = value
+
+	// The raw content of the previous element is no longer needed
+	if v.elementContents != nil {
+		v.elementContents[index] = nil
+	}
 }
 
 func (v *ArrayValue) checkBounds(index int, getLocationRange func() LocationRange) {
@@ -896,8 +1360,7 @@ func (v *ArrayValue) Append(inter *Interpreter, getLocationRange func() Location
 
 	element.SetOwner(v.Owner)
 
-	v.ensureElementsLoaded()
-	v.values = append(v.values, element)
+	v.insertElements(v.Count(), []Value{element})
 }
 
 func (v *ArrayValue) AppendAll(inter *Interpreter, getLocationRange func() LocationRange, other AllAppendableValue) {
@@ -917,8 +1380,7 @@ func (v *ArrayValue) AppendAll(inter *Interpreter, getLocationRange func() Locat
 		element.SetOwner(v.Owner)
 	}
 
-	v.ensureElementsLoaded()
-	v.values = append(v.values, otherElements...)
+	v.insertElements(v.Count(), otherElements)
 }
 
 func (v *ArrayValue) Insert(inter *Interpreter, getLocationRange func() LocationRange, index int, element Value) {
@@ -942,49 +1404,86 @@ func (v *ArrayValue) Insert(inter *Interpreter, getLocationRange func() Location
 
 	element.SetOwner(v.Owner)
 
-	elements := v.Elements()
+	v.insertElements(index, []Value{element})
+}
+
+// insertElements inserts the given elements at the given index.
+// Only the segment containing the index is loaded, the other elements are not loaded.
+//
+func (v *ArrayValue) insertElements(index int, elements []Value) {
+	v.ensureElementsIndexed()
+	v.updateSegment(index, len(elements))
+
+	if index == len(v.values) {
+		v.values = append(v.values, elements...)
+		if v.elementContents != nil {
+			v.elementContents = append(v.elementContents, make([][]byte, len(elements))...)
+		}
+		return
+	}
 
 	//nolint:gocritic
 	v.values = append(
-		elements[:index],
+		v.values[:index],
 		append(
-			[]Value{element},
-			elements[index:]...,
+			append([]Value{}, elements...),
+			v.values[index:]...,
 		)...,
 	)
+
+	if v.elementContents != nil {
+		//nolint:gocritic
+		v.elementContents = append(
+			v.elementContents[:index],
+			append(
+				make([][]byte, len(elements)),
+				v.elementContents[index:]...,
+			)...,
+		)
+	}
 }
 
-// TODO: unset owner?
-func (v *ArrayValue) Remove(index int, getLocationRange func() LocationRange) Value {
-	v.checkBounds(index, getLocationRange)
+// removeElement removes the element at the given index and returns it.
+// Only the segment containing the element is loaded, the other elements are not loaded.
+//
+func (v *ArrayValue) removeElement(index int) Value {
+	result := v.element(index)
 
-	v.modified = true
+	v.updateSegment(index, -1)
 
-	elements := v.Elements()
-	result := elements[index]
-
-	lastIndex := len(elements) - 1
-	copy(elements[index:], elements[index+1:])
+	lastIndex := len(v.values) - 1
+	copy(v.values[index:], v.values[index+1:])
 
 	// avoid memory leaks by explicitly setting value to nil
-	elements[lastIndex] = nil
+	v.values[lastIndex] = nil
+
+	v.values = v.values[:lastIndex]
 
-	v.values = elements[:lastIndex]
+	if v.elementContents != nil {
+		copy(v.elementContents[index:], v.elementContents[index+1:])
+		v.elementContents[lastIndex] = nil
+		v.elementContents = v.elementContents[:lastIndex]
+	}
 
 	return result
 }
 
+// TODO: unset owner?
+func (v *ArrayValue) Remove(index int, getLocationRange func() LocationRange) Value {
+	v.checkBounds(index, getLocationRange)
+
+	v.modified = true
+
+	return v.removeElement(index)
+}
+
 // TODO: unset owner?
 func (v *ArrayValue) RemoveFirst(getLocationRange func() LocationRange) Value {
 	v.checkBounds(0, getLocationRange)
 
 	v.modified = true
 
-	elements := v.Elements()
-
-	var firstElement Value
-	firstElement, v.values = elements[0], elements[1:]
-	return firstElement
+	return v.removeElement(0)
 }
 
 // TODO: unset owner?
@@ -994,11 +1493,7 @@ func (v *ArrayValue) RemoveLast(getLocationRange func() LocationRange) Value {
 
 	v.modified = true
 
-	elements := v.Elements()
-	var lastElement Value
-
-	lastElement, v.values = elements[lastIndex], elements[:lastIndex]
-	return lastElement
+	return v.removeElement(lastIndex)
 }
 
 func (v *ArrayValue) Contains(needleValue Value) BoolValue {
@@ -1116,17 +1611,362 @@ func (v *ArrayValue) GetMember(inter *Interpreter, getLocationRange func() Locat
 			),
 		)
 
+	case "map":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.Map(invocation)
+			},
+			sema.ArrayMapFunctionType(
+				inter.ConvertStaticToSemaType(
+					v.StaticType().(ArrayStaticType).ElementType(),
+				),
+			),
+		)
+
+	case "filter":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.Filter(invocation)
+			},
+			sema.ArrayFilterFunctionType(
+				inter.ConvertStaticToSemaType(
+					v.StaticType().(ArrayStaticType).ElementType(),
+				),
+			),
+		)
+
+	case "reduce":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.Reduce(invocation)
+			},
+			sema.ArrayReduceFunctionType(
+				inter.ConvertStaticToSemaType(
+					v.StaticType().(ArrayStaticType).ElementType(),
+				),
+			),
+		)
+
+	case "firstIndex":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.FirstIndex(invocation)
+			},
+			sema.ArrayFirstIndexFunctionType(
+				inter.ConvertStaticToSemaType(
+					v.StaticType().(ArrayStaticType).ElementType(),
+				),
+			),
+		)
+
+	case "reverse":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.Reverse(invocation.Interpreter, invocation.GetLocationRange)
+			},
+			sema.ArrayReverseFunctionType(
+				inter.ConvertStaticToSemaType(v.StaticType()),
+			),
+		)
+
+	case "slice":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				from := invocation.Arguments[0].(IntValue).ToInt()
+				upTo := invocation.Arguments[1].(IntValue).ToInt()
+				return v.Slice(invocation.Interpreter, invocation.GetLocationRange, from, upTo)
+			},
+			sema.ArraySliceFunctionType(
+				inter.ConvertStaticToSemaType(
+					v.StaticType().(ArrayStaticType).ElementType(),
+				),
+			),
+		)
+
+	case "sort":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				v.Sort(invocation)
+				return VoidValue{}
+			},
+			sema.ArraySortFunctionType(
+				inter.ConvertStaticToSemaType(
+					v.StaticType().(ArrayStaticType).ElementType(),
+				),
+			),
+		)
 	}
 
 	return nil
 }
 
+// Map returns a new array which contains the results of invoking
+// the transform function, the first argument of the given invocation, on each element
+//
+func (v *ArrayValue) Map(invocation Invocation) *ArrayValue {
+	inter := invocation.Interpreter
+
+	transformFunction := invocation.Arguments[0].(FunctionValue)
+	transformFunctionType := invocation.ArgumentTypes[0].(*sema.FunctionType)
+	resultType := transformFunctionType.ReturnTypeAnnotation.Type
+
+	elementType := v.semaElementType(inter)
+	locationRange := invocation.GetLocationRange()
+
+	elements := v.Elements()
+	results := make([]Value, len(elements))
+
+	for i, element := range elements {
+		inter.reportLoopIteration(locationRange)
+
+		results[i] = invokeFunctionArgument(
+			invocation,
+			transformFunction,
+			transformFunctionType,
+			[]Value{element},
+			[]sema.Type{elementType},
+		)
+	}
+
+	return NewArrayValueUnownedNonCopying(
+		VariableSizedStaticType{
+			Type: ConvertSemaToStaticType(resultType),
+		},
+		results...,
+	)
+}
+
+// Filter returns a new variable-sized array which contains copies of the elements
+// for which the predicate function, the first argument of the given invocation, returns true
+//
+func (v *ArrayValue) Filter(invocation Invocation) *ArrayValue {
+	inter := invocation.Interpreter
+
+	predicateFunction := invocation.Arguments[0].(FunctionValue)
+	predicateFunctionType := invocation.ArgumentTypes[0].(*sema.FunctionType)
+
+	elementType := v.semaElementType(inter)
+	locationRange := invocation.GetLocationRange()
+
+	var results []Value
+
+	for _, element := range v.Elements() {
+		inter.reportLoopIteration(locationRange)
+
+		include := invokeFunctionArgument(
+			invocation,
+			predicateFunction,
+			predicateFunctionType,
+			[]Value{element},
+			[]sema.Type{elementType},
+		).(BoolValue)
+
+		if include {
+			results = append(results, element.Copy())
+		}
+	}
+
+	return NewArrayValueUnownedNonCopying(
+		VariableSizedStaticType{
+			Type: v.StaticType().(ArrayStaticType).ElementType(),
+		},
+		results...,
+	)
+}
+
+// Reduce combines the elements, from first to last, by invoking the combine function,
+// the second argument of the given invocation, with the accumulated value and the element.
+// The accumulated value starts with the initial value, the first argument of the given invocation
+//
+func (v *ArrayValue) Reduce(invocation Invocation) Value {
+	inter := invocation.Interpreter
+
+	accumulator := invocation.Arguments[0]
+	combineFunction := invocation.Arguments[1].(FunctionValue)
+	combineFunctionType := invocation.ArgumentTypes[1].(*sema.FunctionType)
+	accumulatorType := combineFunctionType.ReturnTypeAnnotation.Type
+
+	elementType := v.semaElementType(inter)
+	locationRange := invocation.GetLocationRange()
+
+	for _, element := range v.Elements() {
+		inter.reportLoopIteration(locationRange)
+
+		accumulator = invokeFunctionArgument(
+			invocation,
+			combineFunction,
+			combineFunctionType,
+			[]Value{accumulator, element},
+			[]sema.Type{accumulatorType, elementType},
+		)
+	}
+
+	return accumulator
+}
+
+// FirstIndex returns the index of the first element for which the predicate function,
+// the first argument of the given invocation, returns true, or nil if there is no such element
+//
+func (v *ArrayValue) FirstIndex(invocation Invocation) OptionalValue {
+	inter := invocation.Interpreter
+
+	predicateFunction := invocation.Arguments[0].(FunctionValue)
+	predicateFunctionType := invocation.ArgumentTypes[0].(*sema.FunctionType)
+
+	elementType := v.semaElementType(inter)
+	locationRange := invocation.GetLocationRange()
+
+	for i, element := range v.Elements() {
+		inter.reportLoopIteration(locationRange)
+
+		found := invokeFunctionArgument(
+			invocation,
+			predicateFunction,
+			predicateFunctionType,
+			[]Value{element},
+			[]sema.Type{elementType},
+		).(BoolValue)
+
+		if found {
+			return NewSomeValueOwningNonCopying(NewIntValueFromInt64(int64(i)))
+		}
+	}
+
+	return NilValue{}
+}
+
+// Reverse returns a new array of the same type, which contains copies of the elements in reverse order
+//
+func (v *ArrayValue) Reverse(inter *Interpreter, getLocationRange func() LocationRange) *ArrayValue {
+	elements := v.Elements()
+	count := len(elements)
+
+	locationRange := getLocationRange()
+
+	results := make([]Value, count)
+	for i, element := range elements {
+		inter.reportLoopIteration(locationRange)
+
+		results[count-1-i] = element.Copy()
+	}
+
+	return NewArrayValueUnownedNonCopying(v.StaticType().(ArrayStaticType), results...)
+}
+
+// Slice returns a new variable-sized array which contains copies of the elements
+// starting at the given from-index, up to, but not including, the given upTo-index
+//
+func (v *ArrayValue) Slice(inter *Interpreter, getLocationRange func() LocationRange, from int, upTo int) *ArrayValue {
+	elements := v.Elements()
+	count := len(elements)
+
+	if from < 0 || from > upTo || upTo > count {
+		panic(ArraySliceIndicesError{
+			FromIndex:     from,
+			UpToIndex:     upTo,
+			Size:          count,
+			LocationRange: getLocationRange(),
+		})
+	}
+
+	locationRange := getLocationRange()
+
+	results := make([]Value, 0, upTo-from)
+	for _, element := range elements[from:upTo] {
+		inter.reportLoopIteration(locationRange)
+
+		results = append(results, element.Copy())
+	}
+
+	return NewArrayValueUnownedNonCopying(
+		VariableSizedStaticType{
+			Type: v.StaticType().(ArrayStaticType).ElementType(),
+		},
+		results...,
+	)
+}
+
+// Sort sorts the elements in place, using the comparison function,
+// the first argument of the given invocation, which returns true
+// if the first element should be ordered before the second element.
+// The sort is stable
+//
+func (v *ArrayValue) Sort(invocation Invocation) {
+	inter := invocation.Interpreter
+
+	lessFunction := invocation.Arguments[0].(FunctionValue)
+	lessFunctionType := invocation.ArgumentTypes[0].(*sema.FunctionType)
+
+	elementType := v.semaElementType(inter)
+	argumentTypes := []sema.Type{elementType, elementType}
+
+	locationRange := invocation.GetLocationRange()
+
+	elements := v.Elements()
+
+	sort.SliceStable(elements, func(i, j int) bool {
+		inter.reportLoopIteration(locationRange)
+
+		return bool(invokeFunctionArgument(
+			invocation,
+			lessFunction,
+			lessFunctionType,
+			[]Value{elements[i], elements[j]},
+			argumentTypes,
+		).(BoolValue))
+	})
+
+	v.modified = true
+
+	// All segments are affected by sorting
+	if v.segments != nil {
+		for _, segment := range v.segments.list {
+			segment.modified = true
+		}
+	}
+}
+
+func (v *ArrayValue) semaElementType(inter *Interpreter) sema.Type {
+	return inter.ConvertStaticToSemaType(
+		v.StaticType().(ArrayStaticType).ElementType(),
+	)
+}
+
+// invokeFunctionArgument invokes the given function, which was passed as an argument
+// to the host function invocation, with the given arguments.
+// The arguments are copied and converted to the parameter types of the function
+//
+func invokeFunctionArgument(
+	invocation Invocation,
+	function FunctionValue,
+	functionType *sema.FunctionType,
+	arguments []Value,
+	argumentTypes []sema.Type,
+) Value {
+	parameterTypes := make([]sema.Type, len(functionType.Parameters))
+	for i, parameter := range functionType.Parameters {
+		parameterTypes[i] = parameter.TypeAnnotation.Type
+	}
+
+	return invocation.Interpreter.invokeFunctionValue(
+		function,
+		nil,
+		arguments,
+		nil,
+		argumentTypes,
+		parameterTypes,
+		nil,
+		invocation.GetLocationRange(),
+	)
+}
+
 func (v *ArrayValue) SetMember(_ *Interpreter, _ func() LocationRange, _ string, _ Value) {
 	panic(errors.NewUnreachableError())
 }
 
 func (v *ArrayValue) Count() int {
-	return len(v.Elements())
+	v.ensureElementsIndexed()
+	return len(v.values)
 }
 
 func (v *ArrayValue) ConformsToDynamicType(
@@ -1154,17 +1994,16 @@ func (v *ArrayValue) ConformsToDynamicType(
 
 func (v *ArrayValue) IsStorable() bool {
 
-	// TODO: only check decoded values
-	//   and assume still encoded values are storable?
-
 	// If the elements are not loaded, that implies they were read from storage.
 	// Hence they are storable.
-	if v.content != nil {
+	if v.content != nil || v.elementsContent != nil {
 		return true
 	}
 
-	for _, value := range v.Elements() {
-		if !value.IsStorable() {
+	for _, value := range v.values {
+		// Elements which are not loaded were read from storage,
+		// hence they are storable
+		if value != nil && !value.IsStorable() {
 			return false
 		}
 	}
@@ -1233,8 +2072,11 @@ func (v *ArrayValue) ensureMetaInfoLoaded() {
 	v.content = nil
 }
 
-// Ensures the elements of this array value are loaded.
-func (v *ArrayValue) ensureElementsLoaded() {
+// ensureElementsIndexed ensures the raw content of each element of this array value is cached,
+// so elements can be decoded individually.
+// If the elements are already indexed, then calling this function won't have any effect.
+//
+func (v *ArrayValue) ensureElementsIndexed() {
 	v.ensureMetaInfoLoaded()
 
 	if v.elementsContent == nil {
@@ -1248,9 +2090,123 @@ func (v *ArrayValue) ensureElementsLoaded() {
 
 	// Reset the cache
 	v.elementsContent = nil
+
+	if v.elementContents == nil {
+		v.resetDecodingState()
+	}
+}
+
+// Ensures the elements of this array value are loaded.
+func (v *ArrayValue) ensureElementsLoaded() {
+	v.ensureElementsIndexed()
+
+	if v.elementContents == nil {
+		return
+	}
+
+	if v.segments != nil {
+		start := 0
+		for _, segment := range v.segments.list {
+			v.loadSegment(segment, start)
+			start += segment.count
+		}
+	}
+
+	for index, value := range v.values {
+		if value != nil {
+			continue
+		}
+
+		value, err := decodeArrayElement(v, index, v.elementContents[index])
+		if err != nil {
+			panic(err)
+		}
+
+		v.values[index] = value
+	}
+
+	// Reset the cache
+	v.elementContents = nil
+	v.resetDecodingState()
+}
+
+func (v *ArrayValue) resetDecodingState() {
 	v.valuePath = nil
 	v.decodeCallback = nil
 	v.encodingVersion = 0
+	v.segmentLoader = nil
+}
+
+// IsSegmented returns true if the elements of this array value are stored in segments,
+// i.e. if the array was decoded from a segmented array.
+//
+func (v *ArrayValue) IsSegmented() bool {
+	return v.segments != nil
+}
+
+// ensureSegmentLoaded ensures the segment which contains the element at the given index is loaded.
+//
+func (v *ArrayValue) ensureSegmentLoaded(index int) {
+	if v.segments == nil {
+		return
+	}
+
+	position, start := v.segments.segmentAt(index)
+	if position < 0 {
+		return
+	}
+
+	v.loadSegment(v.segments.list[position], start)
+}
+
+// loadSegment loads the given segment, which contains the elements starting at the given index.
+// If the segment is already loaded, then calling this function won't have any effect.
+//
+func (v *ArrayValue) loadSegment(segment *arraySegment, start int) {
+	if segment.loaded {
+		return
+	}
+
+	err := decodeArraySegment(v, segment, start)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// updateSegment updates the segment which contains the element at the given index,
+// when the number of elements of the segment changes by the given delta,
+// or when the element is replaced.
+//
+// The segment is loaded, as it has to be encoded again.
+//
+func (v *ArrayValue) updateSegment(index int, delta int) {
+	if v.segments == nil {
+		return
+	}
+
+	position, start := v.segments.segmentAt(index)
+
+	// If all elements were removed, add a new segment for the inserted elements
+	if position < 0 {
+		v.segments.list = append(v.segments.list, &arraySegment{
+			count:    delta,
+			loaded:   true,
+			modified: true,
+		})
+		return
+	}
+
+	segment := v.segments.list[position]
+
+	v.loadSegment(segment, start)
+
+	segment.count += delta
+	segment.modified = true
+
+	if segment.count == 0 {
+		list := v.segments.list
+		v.segments.list = append(list[:position:position], list[position+1:]...)
+	}
 }
 
 // NumberValue
@@ -1349,13 +2305,148 @@ func getNumberValueMember(v NumberValue, name string, typ sema.Type) Value {
 				),
 			},
 		)
-	}
 
-	return nil
-}
+	case sema.FixedPointTypeMultiplyWithRoundingFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other := invocation.Arguments[0].(NumberValue)
+				rule := roundingRuleValue(invocation.Arguments[1])
+				return v.(FixedPointValue).MulWithRounding(other, rule)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
 
-type IntegerValue interface {
-	NumberValue
+	case sema.FixedPointTypeDivideWithRoundingFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other := invocation.Arguments[0].(NumberValue)
+				rule := roundingRuleValue(invocation.Arguments[1])
+				return v.(FixedPointValue).DivWithRounding(other, rule)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
+
+	case sema.NumericTypePowFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				exponent := invocation.Arguments[0].(UInt8Value)
+				return numberValuePow(typ, v, exponent, false)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
+
+	case sema.NumericTypeSaturatingPowFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				exponent := invocation.Arguments[0].(UInt8Value)
+				return numberValuePow(typ, v, exponent, true)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
+
+	case sema.NumericTypeSqrtFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return numberValueSqrt(typ, v)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
+
+	case sema.NumericTypeAbsFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return numberValueAbs(typ, v)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
+
+	case sema.NumericTypeMinFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other := invocation.Arguments[0].(NumberValue)
+				return numberValueMin(v, other)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
+
+	case sema.NumericTypeMaxFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				other := invocation.Arguments[0].(NumberValue)
+				return numberValueMax(v, other)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
+
+	case sema.NumericTypeMulDivFunctionName:
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				multiplier := invocation.Arguments[0].(NumberValue)
+				divisor := invocation.Arguments[1].(NumberValue)
+				rule := roundingRuleValue(invocation.Arguments[2])
+				return numberValueMulDiv(typ, v, multiplier, divisor, rule)
+			},
+			&sema.FunctionType{
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					typ,
+				),
+			},
+		)
+	}
+
+	return nil
+}
+
+// roundingRuleValue returns the rounding rule for the given `RoundingRule` enum case
+//
+func roundingRuleValue(value Value) fixedpoint.RoundingRule {
+	rawValue, ok := value.(*CompositeValue).Fields().Get(sema.EnumRawValueFieldName)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	rule := fixedpoint.RoundingRule(rawValue.(UInt8Value))
+	if rule >= fixedpoint.RoundingRuleCount {
+		panic(errors.NewUnreachableError())
+	}
+
+	return rule
+}
+
+type IntegerValue interface {
+	NumberValue
 	BitwiseOr(other IntegerValue) IntegerValue
 	BitwiseXor(other IntegerValue) IntegerValue
 	BitwiseAnd(other IntegerValue) IntegerValue
@@ -1363,6 +2454,15 @@ type IntegerValue interface {
 	BitwiseRightShift(other IntegerValue) IntegerValue
 }
 
+// FixedPointValue is a fixed-point number value
+// which supports arithmetic with explicit rounding
+//
+type FixedPointValue interface {
+	NumberValue
+	MulWithRounding(other NumberValue, rule fixedpoint.RoundingRule) NumberValue
+	DivWithRounding(other NumberValue, rule fixedpoint.RoundingRule) NumberValue
+}
+
 // BigNumberValue.
 // Implemented by values with an integer value outside the range of int64
 //
@@ -6353,6 +7453,23 @@ func (v Fix64Value) Mul(other NumberValue) NumberValue {
 	return Fix64Value(result.Int64())
 }
 
+func (v Fix64Value) MulWithRounding(other NumberValue, rule fixedpoint.RoundingRule) NumberValue {
+	o := other.(Fix64Value)
+
+	a := new(big.Int).SetInt64(int64(v))
+	b := new(big.Int).SetInt64(int64(o))
+
+	result := fixedpoint.MulRound(a, b, sema.Fix64FactorBig, rule)
+
+	if result.Cmp(minInt64Big) < 0 {
+		panic(UnderflowError{})
+	} else if result.Cmp(maxInt64Big) > 0 {
+		panic(OverflowError{})
+	}
+
+	return Fix64Value(result.Int64())
+}
+
 func (v Fix64Value) SaturatingMul(other NumberValue) NumberValue {
 	o := other.(Fix64Value)
 
@@ -6389,6 +7506,27 @@ func (v Fix64Value) Div(other NumberValue) NumberValue {
 	return Fix64Value(result.Int64())
 }
 
+func (v Fix64Value) DivWithRounding(other NumberValue, rule fixedpoint.RoundingRule) NumberValue {
+	o := other.(Fix64Value)
+
+	if o == 0 {
+		panic(DivisionByZeroError{})
+	}
+
+	a := new(big.Int).SetInt64(int64(v))
+	b := new(big.Int).SetInt64(int64(o))
+
+	result := fixedpoint.QuoRound(a, b, sema.Fix64FactorBig, rule)
+
+	if result.Cmp(minInt64Big) < 0 {
+		panic(UnderflowError{})
+	} else if result.Cmp(maxInt64Big) > 0 {
+		panic(OverflowError{})
+	}
+
+	return Fix64Value(result.Int64())
+}
+
 func (v Fix64Value) SaturatingDiv(other NumberValue) NumberValue {
 	o := other.(Fix64Value)
 
@@ -6450,6 +7588,12 @@ func ConvertFix64(value Value) Fix64Value {
 		}
 		return Fix64Value(value)
 
+	case Fix128Value:
+		return convertFix128ToFix64(value.BigInt)
+
+	case UFix128Value:
+		return convertFix128ToFix64(value.BigInt)
+
 	case BigNumberValue:
 		v := value.ToBigInt()
 
@@ -6474,6 +7618,21 @@ func ConvertFix64(value Value) Fix64Value {
 	}
 }
 
+// convertFix128ToFix64 converts the given scaled 128-bit fixed-point value to Fix64.
+// Fractional digits which are not representable are truncated
+//
+func convertFix128ToFix64(value *big.Int) Fix64Value {
+	res := new(big.Int).Quo(value, fix64ToFix128FactorBig)
+
+	if res.Cmp(minInt64Big) < 0 {
+		panic(UnderflowError{})
+	} else if res.Cmp(maxInt64Big) > 0 {
+		panic(OverflowError{})
+	}
+
+	return Fix64Value(res.Int64())
+}
+
 func (v Fix64Value) GetMember(_ *Interpreter, _ func() LocationRange, name string) Value {
 	return getNumberValueMember(v, name, sema.Fix64Type)
 }
@@ -6621,6 +7780,21 @@ func (v UFix64Value) Mul(other NumberValue) NumberValue {
 	return UFix64Value(result.Uint64())
 }
 
+func (v UFix64Value) MulWithRounding(other NumberValue, rule fixedpoint.RoundingRule) NumberValue {
+	o := other.(UFix64Value)
+
+	a := new(big.Int).SetUint64(uint64(v))
+	b := new(big.Int).SetUint64(uint64(o))
+
+	result := fixedpoint.MulRound(a, b, sema.Fix64FactorBig, rule)
+
+	if !result.IsUint64() {
+		panic(OverflowError{})
+	}
+
+	return UFix64Value(result.Uint64())
+}
+
 func (v UFix64Value) SaturatingMul(other NumberValue) NumberValue {
 	o := other.(UFix64Value)
 
@@ -6649,6 +7823,25 @@ func (v UFix64Value) Div(other NumberValue) NumberValue {
 	return UFix64Value(result.Uint64())
 }
 
+func (v UFix64Value) DivWithRounding(other NumberValue, rule fixedpoint.RoundingRule) NumberValue {
+	o := other.(UFix64Value)
+
+	if o == 0 {
+		panic(DivisionByZeroError{})
+	}
+
+	a := new(big.Int).SetUint64(uint64(v))
+	b := new(big.Int).SetUint64(uint64(o))
+
+	result := fixedpoint.QuoRound(a, b, sema.Fix64FactorBig, rule)
+
+	if !result.IsUint64() {
+		panic(OverflowError{})
+	}
+
+	return UFix64Value(result.Uint64())
+}
+
 func (v UFix64Value) SaturatingDiv(other NumberValue) NumberValue {
 	return v.Div(other)
 }
@@ -6696,6 +7889,12 @@ func ConvertUFix64(value Value) UFix64Value {
 		}
 		return UFix64Value(value)
 
+	case Fix128Value:
+		return convertFix128ToUFix64(value.BigInt)
+
+	case UFix128Value:
+		return convertFix128ToUFix64(value.BigInt)
+
 	case BigNumberValue:
 		v := value.ToBigInt()
 
@@ -6727,6 +7926,23 @@ func ConvertUFix64(value Value) UFix64Value {
 	}
 }
 
+// convertFix128ToUFix64 converts the given scaled 128-bit fixed-point value to UFix64.
+// Fractional digits which are not representable are truncated
+//
+func convertFix128ToUFix64(value *big.Int) UFix64Value {
+	if value.Sign() < 0 {
+		panic(UnderflowError{})
+	}
+
+	res := new(big.Int).Quo(value, fix64ToFix128FactorBig)
+
+	if !res.IsUint64() {
+		panic(OverflowError{})
+	}
+
+	return UFix64Value(res.Uint64())
+}
+
 func (v UFix64Value) GetMember(_ *Interpreter, _ func() LocationRange, name string) Value {
 	return getNumberValueMember(v, name, sema.UFix64Type)
 }
@@ -6741,12 +7957,552 @@ func (v UFix64Value) ToBigEndianBytes() []byte {
 	return b
 }
 
-func (v UFix64Value) ConformsToDynamicType(_ *Interpreter, dynamicType DynamicType, _ TypeConformanceResults) bool {
+func (v UFix64Value) ConformsToDynamicType(_ *Interpreter, dynamicType DynamicType, _ TypeConformanceResults) bool {
+	numberType, ok := dynamicType.(NumberDynamicType)
+	return ok && sema.UFix64Type.Equal(numberType.StaticType)
+}
+
+func (UFix64Value) IsStorable() bool {
+	return true
+}
+
+// Fix128Value
+//
+type Fix128Value struct {
+	BigInt *big.Int
+}
+
+func NewFix128ValueFromBigInt(value *big.Int) Fix128Value {
+	return Fix128Value{BigInt: value}
+}
+
+func NewFix128ValueWithInteger(integer *big.Int) Fix128Value {
+
+	if integer.Cmp(sema.Fix128TypeMinIntBig) < 0 {
+		panic(UnderflowError{})
+	}
+
+	if integer.Cmp(sema.Fix128TypeMaxIntBig) > 0 {
+		panic(OverflowError{})
+	}
+
+	return Fix128Value{new(big.Int).Mul(integer, sema.Fix128FactorBig)}
+}
+
+func (Fix128Value) IsValue() {}
+
+func (v Fix128Value) Accept(interpreter *Interpreter, visitor Visitor) {
+	visitor.VisitFix128Value(interpreter, v)
+}
+
+func (Fix128Value) Walk(_ func(Value)) {
+	// NO-OP
+}
+
+var fix128DynamicType DynamicType = NumberDynamicType{sema.Fix128Type}
+
+func (Fix128Value) DynamicType(_ *Interpreter, _ SeenReferences) DynamicType {
+	return fix128DynamicType
+}
+
+func (Fix128Value) StaticType() StaticType {
+	return PrimitiveStaticTypeFix128
+}
+
+func (v Fix128Value) Copy() Value {
+	return Fix128Value{BigInt: new(big.Int).Set(v.BigInt)}
+}
+
+func (Fix128Value) GetOwner() *common.Address {
+	// value is never owned
+	return nil
+}
+
+func (Fix128Value) SetOwner(_ *common.Address) {
+	// NO-OP: value cannot be owned
+}
+
+func (Fix128Value) IsModified() bool {
+	return false
+}
+
+func (Fix128Value) SetModified(_ bool) {
+	// NO-OP
+}
+
+func (v Fix128Value) String() string {
+	return format.Fix128(v.BigInt)
+}
+
+func (v Fix128Value) RecursiveString(_ SeenReferences) string {
+	return v.String()
+}
+
+func (v Fix128Value) KeyString() string {
+	return v.String()
+}
+
+func (v Fix128Value) ToInt() int {
+	// TODO: handle overflow
+	return int(v.ToBigInt().Int64())
+}
+
+// ToBigInt returns the integer part of the value
+//
+func (v Fix128Value) ToBigInt() *big.Int {
+	return new(big.Int).Quo(v.BigInt, sema.Fix128FactorBig)
+}
+
+func (v Fix128Value) Negate() NumberValue {
+	// INT32-C
+	if v.BigInt.Cmp(fixedpoint.Fix128TypeMinBig) == 0 {
+		panic(OverflowError{})
+	}
+	return Fix128Value{new(big.Int).Neg(v.BigInt)}
+}
+
+func checkFix128Range(value *big.Int) Fix128Value {
+	if value.Cmp(fixedpoint.Fix128TypeMinBig) < 0 {
+		panic(UnderflowError{})
+	} else if value.Cmp(fixedpoint.Fix128TypeMaxBig) > 0 {
+		panic(OverflowError{})
+	}
+	return Fix128Value{value}
+}
+
+func saturateFix128Range(value *big.Int) Fix128Value {
+	if value.Cmp(fixedpoint.Fix128TypeMinBig) < 0 {
+		return Fix128Value{fixedpoint.Fix128TypeMinBig}
+	} else if value.Cmp(fixedpoint.Fix128TypeMaxBig) > 0 {
+		return Fix128Value{fixedpoint.Fix128TypeMaxBig}
+	}
+	return Fix128Value{value}
+}
+
+func (v Fix128Value) Plus(other NumberValue) NumberValue {
+	o := other.(Fix128Value)
+	// Given that this value is backed by an arbitrary size integer,
+	// we can just add and check the range of the result.
+	res := new(big.Int).Add(v.BigInt, o.BigInt)
+	return checkFix128Range(res)
+}
+
+func (v Fix128Value) SaturatingPlus(other NumberValue) NumberValue {
+	o := other.(Fix128Value)
+	res := new(big.Int).Add(v.BigInt, o.BigInt)
+	return saturateFix128Range(res)
+}
+
+func (v Fix128Value) Minus(other NumberValue) NumberValue {
+	o := other.(Fix128Value)
+	// Given that this value is backed by an arbitrary size integer,
+	// we can just subtract and check the range of the result.
+	res := new(big.Int).Sub(v.BigInt, o.BigInt)
+	return checkFix128Range(res)
+}
+
+func (v Fix128Value) SaturatingMinus(other NumberValue) NumberValue {
+	o := other.(Fix128Value)
+	res := new(big.Int).Sub(v.BigInt, o.BigInt)
+	return saturateFix128Range(res)
+}
+
+func (v Fix128Value) Mul(other NumberValue) NumberValue {
+	return v.MulWithRounding(other, fixedpoint.RoundTowardZero)
+}
+
+func (v Fix128Value) MulWithRounding(other NumberValue, rule fixedpoint.RoundingRule) NumberValue {
+	o := other.(Fix128Value)
+	res := fixedpoint.MulRound(v.BigInt, o.BigInt, sema.Fix128FactorBig, rule)
+	return checkFix128Range(res)
+}
+
+func (v Fix128Value) SaturatingMul(other NumberValue) NumberValue {
+	o := other.(Fix128Value)
+	res := fixedpoint.MulRound(v.BigInt, o.BigInt, sema.Fix128FactorBig, fixedpoint.RoundTowardZero)
+	return saturateFix128Range(res)
+}
+
+func (v Fix128Value) Div(other NumberValue) NumberValue {
+	return v.DivWithRounding(other, fixedpoint.RoundTowardZero)
+}
+
+func (v Fix128Value) DivWithRounding(other NumberValue, rule fixedpoint.RoundingRule) NumberValue {
+	o := other.(Fix128Value)
+	if o.BigInt.Sign() == 0 {
+		panic(DivisionByZeroError{})
+	}
+	res := fixedpoint.QuoRound(v.BigInt, o.BigInt, sema.Fix128FactorBig, rule)
+	return checkFix128Range(res)
+}
+
+func (v Fix128Value) SaturatingDiv(other NumberValue) NumberValue {
+	o := other.(Fix128Value)
+	if o.BigInt.Sign() == 0 {
+		panic(DivisionByZeroError{})
+	}
+	res := fixedpoint.QuoRound(v.BigInt, o.BigInt, sema.Fix128FactorBig, fixedpoint.RoundTowardZero)
+	return saturateFix128Range(res)
+}
+
+func (v Fix128Value) Mod(other NumberValue) NumberValue {
+	o := other.(Fix128Value)
+	// Both values have the same scale,
+	// so the remainder of the scaled values is the scaled remainder
+	if o.BigInt.Sign() == 0 {
+		panic(DivisionByZeroError{})
+	}
+	res := new(big.Int).Rem(v.BigInt, o.BigInt)
+	return Fix128Value{res}
+}
+
+func (v Fix128Value) Less(other NumberValue) BoolValue {
+	cmp := v.BigInt.Cmp(other.(Fix128Value).BigInt)
+	return cmp == -1
+}
+
+func (v Fix128Value) LessEqual(other NumberValue) BoolValue {
+	cmp := v.BigInt.Cmp(other.(Fix128Value).BigInt)
+	return cmp <= 0
+}
+
+func (v Fix128Value) Greater(other NumberValue) BoolValue {
+	cmp := v.BigInt.Cmp(other.(Fix128Value).BigInt)
+	return cmp == 1
+}
+
+func (v Fix128Value) GreaterEqual(other NumberValue) BoolValue {
+	cmp := v.BigInt.Cmp(other.(Fix128Value).BigInt)
+	return cmp >= 0
+}
+
+func (v Fix128Value) Equal(other Value, _ *Interpreter, _ bool) bool {
+	otherFix128, ok := other.(Fix128Value)
+	if !ok {
+		return false
+	}
+	cmp := v.BigInt.Cmp(otherFix128.BigInt)
+	return cmp == 0
+}
+
+// fix64ToFix128FactorBig is the factor between the scales of Fix64 and Fix128
+//
+var fix64ToFix128FactorBig = new(big.Int).Quo(sema.Fix128FactorBig, sema.Fix64FactorBig)
+
+func ConvertFix128(value Value) Fix128Value {
+	switch value := value.(type) {
+	case Fix128Value:
+		return value
+
+	case UFix128Value:
+		if value.BigInt.Cmp(fixedpoint.Fix128TypeMaxBig) > 0 {
+			panic(OverflowError{})
+		}
+		return Fix128Value{new(big.Int).Set(value.BigInt)}
+
+	case Fix64Value:
+		res := new(big.Int).SetInt64(int64(value))
+		return Fix128Value{res.Mul(res, fix64ToFix128FactorBig)}
+
+	case UFix64Value:
+		res := new(big.Int).SetUint64(uint64(value))
+		return Fix128Value{res.Mul(res, fix64ToFix128FactorBig)}
+
+	case BigNumberValue:
+		return NewFix128ValueWithInteger(value.ToBigInt())
+
+	case NumberValue:
+		v := value.ToInt()
+		return NewFix128ValueWithInteger(big.NewInt(int64(v)))
+
+	default:
+		panic(fmt.Sprintf("can't convert to Fix128: %s", value))
+	}
+}
+
+func (v Fix128Value) GetMember(_ *Interpreter, _ func() LocationRange, name string) Value {
+	return getNumberValueMember(v, name, sema.Fix128Type)
+}
+
+func (Fix128Value) SetMember(_ *Interpreter, _ func() LocationRange, _ string, _ Value) {
+	panic(errors.NewUnreachableError())
+}
+
+func (v Fix128Value) ToBigEndianBytes() []byte {
+	return SignedBigIntToBigEndianBytes(v.BigInt)
+}
+
+func (v Fix128Value) ConformsToDynamicType(_ *Interpreter, dynamicType DynamicType, _ TypeConformanceResults) bool {
+	numberType, ok := dynamicType.(NumberDynamicType)
+	return ok && sema.Fix128Type.Equal(numberType.StaticType)
+}
+
+func (Fix128Value) IsStorable() bool {
+	return true
+}
+
+// UFix128Value
+//
+type UFix128Value struct {
+	BigInt *big.Int
+}
+
+func NewUFix128ValueFromBigInt(value *big.Int) UFix128Value {
+	return UFix128Value{BigInt: value}
+}
+
+func NewUFix128ValueWithInteger(integer *big.Int) UFix128Value {
+
+	if integer.Sign() < 0 {
+		panic(UnderflowError{})
+	}
+
+	if integer.Cmp(sema.UFix128TypeMaxIntBig) > 0 {
+		panic(OverflowError{})
+	}
+
+	return UFix128Value{new(big.Int).Mul(integer, sema.Fix128FactorBig)}
+}
+
+func (UFix128Value) IsValue() {}
+
+func (v UFix128Value) Accept(interpreter *Interpreter, visitor Visitor) {
+	visitor.VisitUFix128Value(interpreter, v)
+}
+
+func (UFix128Value) Walk(_ func(Value)) {
+	// NO-OP
+}
+
+var ufix128DynamicType DynamicType = NumberDynamicType{sema.UFix128Type}
+
+func (UFix128Value) DynamicType(_ *Interpreter, _ SeenReferences) DynamicType {
+	return ufix128DynamicType
+}
+
+func (UFix128Value) StaticType() StaticType {
+	return PrimitiveStaticTypeUFix128
+}
+
+func (v UFix128Value) Copy() Value {
+	return UFix128Value{BigInt: new(big.Int).Set(v.BigInt)}
+}
+
+func (UFix128Value) GetOwner() *common.Address {
+	// value is never owned
+	return nil
+}
+
+func (UFix128Value) SetOwner(_ *common.Address) {
+	// NO-OP: value cannot be owned
+}
+
+func (UFix128Value) IsModified() bool {
+	return false
+}
+
+func (UFix128Value) SetModified(_ bool) {
+	// NO-OP
+}
+
+func (v UFix128Value) String() string {
+	return format.UFix128(v.BigInt)
+}
+
+func (v UFix128Value) RecursiveString(_ SeenReferences) string {
+	return v.String()
+}
+
+func (v UFix128Value) KeyString() string {
+	return v.String()
+}
+
+func (v UFix128Value) ToInt() int {
+	// TODO: handle overflow
+	return int(v.ToBigInt().Int64())
+}
+
+// ToBigInt returns the integer part of the value
+//
+func (v UFix128Value) ToBigInt() *big.Int {
+	return new(big.Int).Quo(v.BigInt, sema.Fix128FactorBig)
+}
+
+func (v UFix128Value) Negate() NumberValue {
+	panic(errors.NewUnreachableError())
+}
+
+func checkUFix128Range(value *big.Int) UFix128Value {
+	if value.Sign() < 0 {
+		panic(UnderflowError{})
+	} else if value.Cmp(fixedpoint.UFix128TypeMaxBig) > 0 {
+		panic(OverflowError{})
+	}
+	return UFix128Value{value}
+}
+
+func saturateUFix128Range(value *big.Int) UFix128Value {
+	if value.Sign() < 0 {
+		return UFix128Value{new(big.Int)}
+	} else if value.Cmp(fixedpoint.UFix128TypeMaxBig) > 0 {
+		return UFix128Value{fixedpoint.UFix128TypeMaxBig}
+	}
+	return UFix128Value{value}
+}
+
+func (v UFix128Value) Plus(other NumberValue) NumberValue {
+	o := other.(UFix128Value)
+	// Given that this value is backed by an arbitrary size integer,
+	// we can just add and check the range of the result.
+	res := new(big.Int).Add(v.BigInt, o.BigInt)
+	return checkUFix128Range(res)
+}
+
+func (v UFix128Value) SaturatingPlus(other NumberValue) NumberValue {
+	o := other.(UFix128Value)
+	res := new(big.Int).Add(v.BigInt, o.BigInt)
+	return saturateUFix128Range(res)
+}
+
+func (v UFix128Value) Minus(other NumberValue) NumberValue {
+	o := other.(UFix128Value)
+	// Given that this value is backed by an arbitrary size integer,
+	// we can just subtract and check the range of the result.
+	res := new(big.Int).Sub(v.BigInt, o.BigInt)
+	return checkUFix128Range(res)
+}
+
+func (v UFix128Value) SaturatingMinus(other NumberValue) NumberValue {
+	o := other.(UFix128Value)
+	res := new(big.Int).Sub(v.BigInt, o.BigInt)
+	return saturateUFix128Range(res)
+}
+
+func (v UFix128Value) Mul(other NumberValue) NumberValue {
+	return v.MulWithRounding(other, fixedpoint.RoundTowardZero)
+}
+
+func (v UFix128Value) MulWithRounding(other NumberValue, rule fixedpoint.RoundingRule) NumberValue {
+	o := other.(UFix128Value)
+	res := fixedpoint.MulRound(v.BigInt, o.BigInt, sema.Fix128FactorBig, rule)
+	return checkUFix128Range(res)
+}
+
+func (v UFix128Value) SaturatingMul(other NumberValue) NumberValue {
+	o := other.(UFix128Value)
+	res := fixedpoint.MulRound(v.BigInt, o.BigInt, sema.Fix128FactorBig, fixedpoint.RoundTowardZero)
+	return saturateUFix128Range(res)
+}
+
+func (v UFix128Value) Div(other NumberValue) NumberValue {
+	return v.DivWithRounding(other, fixedpoint.RoundTowardZero)
+}
+
+func (v UFix128Value) DivWithRounding(other NumberValue, rule fixedpoint.RoundingRule) NumberValue {
+	o := other.(UFix128Value)
+	if o.BigInt.Sign() == 0 {
+		panic(DivisionByZeroError{})
+	}
+	res := fixedpoint.QuoRound(v.BigInt, o.BigInt, sema.Fix128FactorBig, rule)
+	return checkUFix128Range(res)
+}
+
+func (v UFix128Value) SaturatingDiv(other NumberValue) NumberValue {
+	return v.Div(other)
+}
+
+func (v UFix128Value) Mod(other NumberValue) NumberValue {
+	o := other.(UFix128Value)
+	// Both values have the same scale,
+	// so the remainder of the scaled values is the scaled remainder
+	if o.BigInt.Sign() == 0 {
+		panic(DivisionByZeroError{})
+	}
+	res := new(big.Int).Rem(v.BigInt, o.BigInt)
+	return UFix128Value{res}
+}
+
+func (v UFix128Value) Less(other NumberValue) BoolValue {
+	cmp := v.BigInt.Cmp(other.(UFix128Value).BigInt)
+	return cmp == -1
+}
+
+func (v UFix128Value) LessEqual(other NumberValue) BoolValue {
+	cmp := v.BigInt.Cmp(other.(UFix128Value).BigInt)
+	return cmp <= 0
+}
+
+func (v UFix128Value) Greater(other NumberValue) BoolValue {
+	cmp := v.BigInt.Cmp(other.(UFix128Value).BigInt)
+	return cmp == 1
+}
+
+func (v UFix128Value) GreaterEqual(other NumberValue) BoolValue {
+	cmp := v.BigInt.Cmp(other.(UFix128Value).BigInt)
+	return cmp >= 0
+}
+
+func (v UFix128Value) Equal(other Value, _ *Interpreter, _ bool) bool {
+	otherUFix128, ok := other.(UFix128Value)
+	if !ok {
+		return false
+	}
+	cmp := v.BigInt.Cmp(otherUFix128.BigInt)
+	return cmp == 0
+}
+
+func ConvertUFix128(value Value) UFix128Value {
+	switch value := value.(type) {
+	case UFix128Value:
+		return value
+
+	case Fix128Value:
+		if value.BigInt.Sign() < 0 {
+			panic(UnderflowError{})
+		}
+		return UFix128Value{new(big.Int).Set(value.BigInt)}
+
+	case Fix64Value:
+		if value < 0 {
+			panic(UnderflowError{})
+		}
+		res := new(big.Int).SetInt64(int64(value))
+		return UFix128Value{res.Mul(res, fix64ToFix128FactorBig)}
+
+	case UFix64Value:
+		res := new(big.Int).SetUint64(uint64(value))
+		return UFix128Value{res.Mul(res, fix64ToFix128FactorBig)}
+
+	case BigNumberValue:
+		return NewUFix128ValueWithInteger(value.ToBigInt())
+
+	case NumberValue:
+		v := value.ToInt()
+		return NewUFix128ValueWithInteger(big.NewInt(int64(v)))
+
+	default:
+		panic(fmt.Sprintf("can't convert to UFix128: %s", value))
+	}
+}
+
+func (v UFix128Value) GetMember(_ *Interpreter, _ func() LocationRange, name string) Value {
+	return getNumberValueMember(v, name, sema.UFix128Type)
+}
+
+func (UFix128Value) SetMember(_ *Interpreter, _ func() LocationRange, _ string, _ Value) {
+	panic(errors.NewUnreachableError())
+}
+
+func (v UFix128Value) ToBigEndianBytes() []byte {
+	return UnsignedBigIntToBigEndianBytes(v.BigInt)
+}
+
+func (v UFix128Value) ConformsToDynamicType(_ *Interpreter, dynamicType DynamicType, _ TypeConformanceResults) bool {
 	numberType, ok := dynamicType.(NumberDynamicType)
-	return ok && sema.UFix64Type.Equal(numberType.StaticType)
+	return ok && sema.UFix128Type.Equal(numberType.StaticType)
 }
 
-func (UFix64Value) IsStorable() bool {
+func (UFix128Value) IsStorable() bool {
 	return true
 }
 
@@ -6758,6 +8514,8 @@ type CompositeValue struct {
 	qualifiedIdentifier string
 	kind                common.CompositeKind
 	fields              *StringValueOrderedMap
+	// typeArguments are the type arguments of a value of a generic composite type
+	typeArguments []StaticType
 
 	InjectedFields  *StringValueOrderedMap
 	ComputedFields  *StringComputedFieldOrderedMap
@@ -6778,6 +8536,11 @@ type CompositeValue struct {
 	// Only available for decoded values who's fields are not loaded yet.
 	fieldsContent []byte
 
+	// Raw content cache for the values of individual fields, by field name.
+	// Only available for decoded values who's field values are not all loaded yet.
+	// The value of a field in `fields` is nil until it is decoded.
+	fieldContents map[string][]byte
+
 	// Value's path to be used during decoding.
 	// Only available for decoded values that are not loaded yet.
 	valuePath []string
@@ -6789,6 +8552,10 @@ type CompositeValue struct {
 	// Encoding version of the raw content and raw fieldsContent of this value.
 	// Only available for decoded values who's fields are not loaded yet.
 	encodingVersion uint16
+
+	// Loader for the segments of segmented containers in the raw content of this value.
+	// Only available for decoded values who's fields are not loaded yet.
+	segmentLoader SegmentLoader
 }
 
 type ComputedField func(*Interpreter) Value
@@ -6878,7 +8645,12 @@ func (v *CompositeValue) Walk(walkChild func(Value)) {
 }
 
 func (v *CompositeValue) DynamicType(interpreter *Interpreter, _ SeenReferences) DynamicType {
-	staticType := interpreter.getCompositeType(v.Location(), v.QualifiedIdentifier())
+	var staticType sema.Type
+	if len(v.TypeArguments()) > 0 {
+		staticType = interpreter.ConvertStaticToSemaType(v.StaticType())
+	} else {
+		staticType = interpreter.getCompositeType(v.Location(), v.QualifiedIdentifier())
+	}
 	return CompositeDynamicType{
 		StaticType: staticType,
 	}
@@ -6888,6 +8660,7 @@ func (v *CompositeValue) StaticType() StaticType {
 	return CompositeStaticType{
 		Location:            v.Location(),
 		QualifiedIdentifier: v.QualifiedIdentifier(),
+		TypeArguments:       v.TypeArguments(),
 	}
 }
 
@@ -6909,6 +8682,7 @@ func (v *CompositeValue) Copy() Value {
 			qualifiedIdentifier: v.QualifiedIdentifier(),
 			kind:                v.Kind(),
 			fields:              nil,
+			typeArguments:       v.TypeArguments(),
 			InjectedFields:      v.InjectedFields,
 			ComputedFields:      v.ComputedFields,
 			NestedVariables:     v.NestedVariables,
@@ -6924,14 +8698,31 @@ func (v *CompositeValue) Copy() Value {
 			valuePath:       v.valuePath,
 			decodeCallback:  v.decodeCallback,
 			encodingVersion: v.encodingVersion,
+			segmentLoader:   v.segmentLoader,
 		}
 	}
 
+	// Only copy the loaded field values.
+	// Field values which are not loaded yet are copied as raw-content.
+
+	v.ensureFieldsIndexed()
+
 	newFields := NewStringValueOrderedMap()
-	v.Fields().Foreach(func(fieldName string, value Value) {
-		newFields.Set(fieldName, value.Copy())
+	v.fields.Foreach(func(fieldName string, value Value) {
+		if value != nil {
+			value = value.Copy()
+		}
+		newFields.Set(fieldName, value)
 	})
 
+	var fieldContents map[string][]byte
+	if v.fieldContents != nil {
+		fieldContents = make(map[string][]byte, len(v.fieldContents))
+		for fieldName, content := range v.fieldContents {
+			fieldContents[fieldName] = content
+		}
+	}
+
 	// NOTE: not copying functions or destructor – they are linked in
 
 	return &CompositeValue{
@@ -6939,6 +8730,7 @@ func (v *CompositeValue) Copy() Value {
 		qualifiedIdentifier: v.QualifiedIdentifier(),
 		kind:                v.Kind(),
 		fields:              newFields,
+		typeArguments:       v.TypeArguments(),
 		InjectedFields:      v.InjectedFields,
 		ComputedFields:      v.ComputedFields,
 		NestedVariables:     v.NestedVariables,
@@ -6951,9 +8743,11 @@ func (v *CompositeValue) Copy() Value {
 		stringer:        v.stringer,
 		content:         v.content,
 		fieldsContent:   v.fieldsContent,
+		fieldContents:   fieldContents,
 		valuePath:       v.valuePath,
 		decodeCallback:  v.decodeCallback,
 		encodingVersion: v.encodingVersion,
+		segmentLoader:   v.segmentLoader,
 	}
 }
 
@@ -6975,6 +8769,12 @@ func (v *CompositeValue) SetOwner(owner *common.Address) {
 		return
 	}
 
+	// If the owner does not change, the fields do not have to be loaded
+	if v.Owner != nil && owner != nil && *v.Owner == *owner {
+		v.Owner = owner
+		return
+	}
+
 	v.Owner = owner
 
 	v.Fields().Foreach(func(_ string, value Value) {
@@ -7009,7 +8809,8 @@ func (v *CompositeValue) IsModified() bool {
 	}
 
 	for pair := v.fields.Oldest(); pair != nil; pair = pair.Next() {
-		if pair.Value.IsModified() {
+		// Field values which are not loaded are not modified
+		if pair.Value != nil && pair.Value.IsModified() {
 			return true
 		}
 	}
@@ -7030,7 +8831,7 @@ func (v *CompositeValue) GetMember(interpreter *Interpreter, getLocationRange fu
 		return v.OwnerValue(interpreter)
 	}
 
-	value, ok := v.Fields().Get(name)
+	value, ok := v.getField(name)
 	if ok {
 		return value
 	}
@@ -7125,7 +8926,14 @@ func (v *CompositeValue) SetMember(_ *Interpreter, getLocationRange func() Locat
 
 	value.SetOwner(v.Owner)
 
-	v.Fields().Set(name, value)
+	v.ensureFieldsIndexed()
+
+	v.fields.Set(name, value)
+
+	// The raw content of the previous field value is no longer needed
+	if v.fieldContents != nil {
+		delete(v.fieldContents, name)
+	}
 }
 
 func (v *CompositeValue) String() string {
@@ -7162,10 +8970,34 @@ func formatComposite(typeId string, fields *StringValueOrderedMap, seenReference
 }
 
 func (v *CompositeValue) GetField(name string) Value {
-	value, _ := v.Fields().Get(name)
+	value, _ := v.getField(name)
 	return value
 }
 
+// getField returns the value of the field with the given name.
+// Only the value of the field is loaded, the other fields are not loaded.
+//
+func (v *CompositeValue) getField(name string) (Value, bool) {
+	v.ensureFieldsIndexed()
+
+	value, ok := v.fields.Get(name)
+	if !ok || value != nil {
+		return value, ok
+	}
+
+	content := v.fieldContents[name]
+
+	value, err := decodeCompositeField(v, name, content)
+	if err != nil {
+		panic(err)
+	}
+
+	v.fields.Set(name, value)
+	delete(v.fieldContents, name)
+
+	return value, true
+}
+
 func (v *CompositeValue) Equal(other Value, interpreter *Interpreter, loadDeferred bool) bool {
 	otherComposite, ok := other.(*CompositeValue)
 	if !ok {
@@ -7202,7 +9034,7 @@ func (v *CompositeValue) Equal(other Value, interpreter *Interpreter, loadDeferr
 
 func (v *CompositeValue) KeyString() string {
 	if v.Kind() == common.CompositeKindEnum {
-		rawValue, _ := v.Fields().Get(sema.EnumRawValueFieldName)
+		rawValue := v.GetField(sema.EnumRawValueFieldName)
 		return rawValue.String()
 	}
 
@@ -7307,9 +9139,6 @@ func (v *CompositeValue) IsStorable() bool {
 	// If this composite value has a field which is non-storable,
 	// then the composite value is not storable.
 
-	// TODO: only check decoded fields
-	//   and assume still encoded fields are storable?
-
 	// If the fields are not loaded, that implies they were read from storage.
 	// Hence they are storable.
 	if v.fieldsContent != nil {
@@ -7317,7 +9146,9 @@ func (v *CompositeValue) IsStorable() bool {
 	}
 
 	for pair := v.fields.Oldest(); pair != nil; pair = pair.Next() {
-		if !pair.Value.IsStorable() {
+		// Field values which are not loaded were read from storage,
+		// hence they are storable
+		if pair.Value != nil && !pair.Value.IsStorable() {
 			return false
 		}
 	}
@@ -7345,6 +9176,14 @@ func (v *CompositeValue) Kind() common.CompositeKind {
 	return v.kind
 }
 
+// TypeArguments returns the type arguments of a value of a generic composite type,
+// or nil if the composite type is not generic
+//
+func (v *CompositeValue) TypeArguments() []StaticType {
+	v.ensureMetaInfoLoaded()
+	return v.typeArguments
+}
+
 // ensureMetaInfoLoaded ensures loading the meta information of this composite value.
 // If the meta info is already loaded, then calling this function won't have any effect.
 // Otherwise, the values are decoded form the cached raw-content.
@@ -7353,6 +9192,7 @@ func (v *CompositeValue) Kind() common.CompositeKind {
 //    - location
 //    - qualifiedIdentifier
 //    - kind
+//    - typeArguments
 //
 func (v *CompositeValue) ensureMetaInfoLoaded() {
 	if v.content == nil {
@@ -7368,11 +9208,12 @@ func (v *CompositeValue) ensureMetaInfoLoaded() {
 	v.content = nil
 }
 
-// ensureFieldsLoaded ensures loading the fields of this composite value.
-// If the fields are already loaded, then calling this function won't have any effect.
-// Otherwise, the fields are decoded form the cached raw-fields-content.
+// ensureFieldsIndexed ensures loading the names of the fields of this composite value.
+// If the field names are already loaded, then calling this function won't have any effect.
+// Otherwise, the field names are decoded form the cached raw-fields-content,
+// and the raw content of each field value is cached, so field values can be decoded individually.
 //
-func (v *CompositeValue) ensureFieldsLoaded() {
+func (v *CompositeValue) ensureFieldsIndexed() {
 	// First ensure the fields content is extracted out.
 	v.ensureMetaInfoLoaded()
 
@@ -7385,12 +9226,52 @@ func (v *CompositeValue) ensureFieldsLoaded() {
 		panic(err)
 	}
 
-	// Path and the fields-content are no longer needed.
+	// The fields-content is no longer needed.
 	// Reset the cache and free-up the memory.
-	v.valuePath = nil
 	v.fieldsContent = nil
+
+	if v.fieldContents == nil {
+		v.resetDecodingState()
+	}
+}
+
+// ensureFieldsLoaded ensures loading the fields of this composite value.
+// If the fields are already loaded, then calling this function won't have any effect.
+// Otherwise, the fields are decoded form the cached raw-fields-content.
+//
+func (v *CompositeValue) ensureFieldsLoaded() {
+	v.ensureFieldsIndexed()
+
+	if v.fieldContents == nil {
+		return
+	}
+
+	for pair := v.fields.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value != nil {
+			continue
+		}
+
+		fieldName := pair.Key
+
+		value, err := decodeCompositeField(v, fieldName, v.fieldContents[fieldName])
+		if err != nil {
+			panic(err)
+		}
+
+		pair.Value = value
+	}
+
+	// Path and the field contents are no longer needed.
+	// Reset the cache and free-up the memory.
+	v.fieldContents = nil
+	v.resetDecodingState()
+}
+
+func (v *CompositeValue) resetDecodingState() {
+	v.valuePath = nil
 	v.decodeCallback = nil
 	v.encodingVersion = 0
+	v.segmentLoader = nil
 }
 
 func NewEnumCaseValue(
@@ -7460,6 +9341,17 @@ type DictionaryValue struct {
 	// Encoding version of the raw content of the entries of this value.
 	// Only available for decoded values who's entries are not loaded yet.
 	encodingVersion uint16
+
+	// Loader for the segments of segmented containers in the raw content of this value.
+	// Only available for decoded values who's entries are not loaded yet.
+	segmentLoader SegmentLoader
+
+	// Buckets of the entries, see segment.go.
+	// Only available for decoded dictionaries which were encoded as segmented dictionaries.
+	//
+	// The keys of a segmented dictionary are only available once all buckets are loaded,
+	// until then, the entries contain the entries of the loaded buckets.
+	segments *dictionarySegments
 }
 
 func NewDictionaryValueUnownedNonCopying(
@@ -7570,6 +9462,15 @@ func (v *DictionaryValue) Walk(walkChild func(Value)) {
 }
 
 func (v *DictionaryValue) DynamicType(interpreter *Interpreter, seenReferences SeenReferences) DynamicType {
+
+	// The entries of segmented dictionaries were stored,
+	// so they conform to the static type of the dictionary, and are not loaded
+	if v.IsSegmented() {
+		return &DictionaryDynamicType{
+			StaticType: v.StaticType().(DictionaryStaticType),
+		}
+	}
+
 	keys := v.Keys().Elements()
 	entryTypes := make([]DictionaryStaticTypeEntry, len(keys))
 
@@ -7612,6 +9513,38 @@ func (v *DictionaryValue) Copy() Value {
 			valuePath:       v.valuePath,
 			decodeCallback:  v.decodeCallback,
 			encodingVersion: v.encodingVersion,
+			segmentLoader:   v.segmentLoader,
+			segments:        v.segments.copy(),
+		}
+	}
+
+	v.ensureEntriesIndexed()
+
+	// Only copy the loaded entries of segmented dictionaries.
+	// Buckets which are not loaded yet are copied as they are.
+
+	if v.keys == nil {
+		newEntries := NewStringValueOrderedMap()
+
+		v.entries.Foreach(func(key string, value Value) {
+			newEntries.Set(key, value.Copy())
+		})
+
+		return &DictionaryValue{
+			Type:                   v.Type,
+			entries:                newEntries,
+			deferredOwner:          v.deferredOwner,
+			deferredKeys:           v.deferredKeys,
+			deferredStorageKeyBase: v.deferredStorageKeyBase,
+			prevDeferredKeys:       v.prevDeferredKeys,
+			// NOTE: new value has no owner
+			Owner:           nil,
+			modified:        true,
+			valuePath:       v.valuePath,
+			decodeCallback:  v.decodeCallback,
+			encodingVersion: v.encodingVersion,
+			segmentLoader:   v.segmentLoader,
+			segments:        v.segments.copy(),
 		}
 	}
 
@@ -7650,6 +9583,36 @@ func (v *DictionaryValue) SetOwner(owner *common.Address) {
 		return
 	}
 
+	// If the owner does not change, the entries do not have to be loaded
+	if v.Owner != nil && owner != nil && *v.Owner == *owner {
+		v.Owner = owner
+		return
+	}
+
+	// The entries of segmented dictionaries which are not loaded yet
+	// are decoded with the new owner when they are loaded
+	if v.segments != nil {
+		v.ensureEntriesIndexed()
+
+		v.Owner = owner
+
+		for _, bucket := range v.segments.buckets {
+			for _, keyValue := range bucket.keys {
+				keyValue.SetOwner(owner)
+			}
+		}
+
+		if v.keys != nil {
+			v.keys.Owner = owner
+		}
+
+		v.entries.Foreach(func(_ string, value Value) {
+			value.SetOwner(owner)
+		})
+
+		return
+	}
+
 	v.ensureLoaded()
 
 	v.Owner = owner
@@ -7667,11 +9630,12 @@ func (v *DictionaryValue) IsModified() bool {
 	}
 
 	// If the keys/entries are not loaded, that implies they are not modified.
-	if v.content != nil {
+	if v.content != nil || v.entriesContent != nil {
 		return false
 	}
 
-	if v.keys.IsModified() {
+	// The keys of segmented dictionaries might not be available yet
+	if v.keys != nil && v.keys.IsModified() {
 		return true
 	}
 
@@ -7713,9 +9677,10 @@ func (v *DictionaryValue) Destroy(inter *Interpreter, getLocationRange func() Lo
 }
 
 func (v *DictionaryValue) ContainsKey(keyValue Value) BoolValue {
-	v.ensureLoaded()
-
 	key := dictionaryKey(keyValue)
+
+	v.ensureEntryLoaded(key)
+
 	_, ok := v.entries.Get(key)
 	if ok {
 		return true
@@ -7732,7 +9697,7 @@ func (v *DictionaryValue) ContainsKey(keyValue Value) BoolValue {
 func (v *DictionaryValue) Get(inter *Interpreter, _ func() LocationRange, keyValue Value) Value {
 	key := dictionaryKey(keyValue)
 
-	v.ensureLoaded()
+	v.ensureEntryLoaded(key)
 
 	value, ok := v.entries.Get(key)
 	if ok {
@@ -7912,18 +9877,169 @@ func (v *DictionaryValue) GetMember(interpreter *Interpreter, getLocationRange f
 			),
 		)
 
+	case "forEachKey":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				v.ForEachKey(invocation)
+				return VoidValue{}
+			},
+			sema.DictionaryForEachKeyFunctionType(
+				interpreter.ConvertStaticToSemaType(v.StaticType()).(*sema.DictionaryType),
+			),
+		)
+
+	case "filter":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.Filter(invocation)
+			},
+			sema.DictionaryFilterFunctionType(
+				interpreter.ConvertStaticToSemaType(v.StaticType()).(*sema.DictionaryType),
+			),
+		)
+
+	case "mapValues":
+		return NewHostFunctionValue(
+			func(invocation Invocation) Value {
+				return v.MapValues(invocation)
+			},
+			sema.DictionaryMapValuesFunctionType(
+				interpreter.ConvertStaticToSemaType(v.StaticType()).(*sema.DictionaryType),
+			),
+		)
 	}
 
 	return nil
 }
 
+// ForEachKey invokes the function, the first argument of the given invocation, for each key, in order.
+// Iteration stops early if the function returns false
+//
+func (v *DictionaryValue) ForEachKey(invocation Invocation) {
+	inter := invocation.Interpreter
+
+	function := invocation.Arguments[0].(FunctionValue)
+	functionType := invocation.ArgumentTypes[0].(*sema.FunctionType)
+
+	dictionaryType := v.StaticType().(DictionaryStaticType)
+	keyType := inter.ConvertStaticToSemaType(dictionaryType.KeyType)
+
+	locationRange := invocation.GetLocationRange()
+
+	for _, key := range v.Keys().Elements() {
+		inter.reportLoopIteration(locationRange)
+
+		shouldContinue := invokeFunctionArgument(
+			invocation,
+			function,
+			functionType,
+			[]Value{key},
+			[]sema.Type{keyType},
+		).(BoolValue)
+
+		if !shouldContinue {
+			return
+		}
+	}
+}
+
+// Filter returns a new dictionary which contains copies of the entries
+// for which the predicate function, the first argument of the given invocation, returns true
+//
+func (v *DictionaryValue) Filter(invocation Invocation) *DictionaryValue {
+	inter := invocation.Interpreter
+
+	predicateFunction := invocation.Arguments[0].(FunctionValue)
+	predicateFunctionType := invocation.ArgumentTypes[0].(*sema.FunctionType)
+
+	dictionaryType := v.StaticType().(DictionaryStaticType)
+	keyType := inter.ConvertStaticToSemaType(dictionaryType.KeyType)
+	valueType := inter.ConvertStaticToSemaType(dictionaryType.ValueType)
+
+	getLocationRange := invocation.GetLocationRange
+	locationRange := getLocationRange()
+
+	var keysAndValues []Value
+
+	for _, key := range v.Keys().Elements() {
+		inter.reportLoopIteration(locationRange)
+
+		value := v.Get(inter, getLocationRange, key).(*SomeValue).Value
+
+		include := invokeFunctionArgument(
+			invocation,
+			predicateFunction,
+			predicateFunctionType,
+			[]Value{key, value},
+			[]sema.Type{keyType, valueType},
+		).(BoolValue)
+
+		if include {
+			keysAndValues = append(keysAndValues, key.Copy(), value.Copy())
+		}
+	}
+
+	return NewDictionaryValueUnownedNonCopying(inter, dictionaryType, keysAndValues...)
+}
+
+// MapValues returns a new dictionary which contains copies of the keys, and the results of invoking
+// the transform function, the first argument of the given invocation, on the corresponding values
+//
+func (v *DictionaryValue) MapValues(invocation Invocation) *DictionaryValue {
+	inter := invocation.Interpreter
+
+	transformFunction := invocation.Arguments[0].(FunctionValue)
+	transformFunctionType := invocation.ArgumentTypes[0].(*sema.FunctionType)
+	resultType := transformFunctionType.ReturnTypeAnnotation.Type
+
+	dictionaryType := v.StaticType().(DictionaryStaticType)
+	valueType := inter.ConvertStaticToSemaType(dictionaryType.ValueType)
+
+	getLocationRange := invocation.GetLocationRange
+	locationRange := getLocationRange()
+
+	keys := v.Keys().Elements()
+	keysAndValues := make([]Value, 0, len(keys)*2)
+
+	for _, key := range keys {
+		inter.reportLoopIteration(locationRange)
+
+		value := v.Get(inter, getLocationRange, key).(*SomeValue).Value
+
+		result := invokeFunctionArgument(
+			invocation,
+			transformFunction,
+			transformFunctionType,
+			[]Value{value},
+			[]sema.Type{valueType},
+		)
+
+		keysAndValues = append(keysAndValues, key.Copy(), result)
+	}
+
+	return NewDictionaryValueUnownedNonCopying(
+		inter,
+		DictionaryStaticType{
+			KeyType:   dictionaryType.KeyType,
+			ValueType: ConvertSemaToStaticType(resultType),
+		},
+		keysAndValues...,
+	)
+}
+
 func (v *DictionaryValue) SetMember(_ *Interpreter, _ func() LocationRange, _ string, _ Value) {
 	// Dictionaries have no settable members (fields / functions)
 	panic(errors.NewUnreachableError())
 }
 
 func (v *DictionaryValue) Count() int {
-	return v.Keys().Count()
+	v.ensureEntriesIndexed()
+
+	if v.segments != nil {
+		return v.segments.count
+	}
+
+	return v.keys.Count()
 }
 
 // TODO: unset owner?
@@ -7949,10 +10065,19 @@ func (v *DictionaryValue) Remove(inter *Interpreter, getLocationRange func() Loc
 	switch value := value.(type) {
 	case *SomeValue:
 
-		v.ensureLoaded()
+		bucket := v.ensureEntryLoaded(key)
 
 		v.entries.Delete(key)
 
+		if bucket != nil {
+			v.removeBucketKey(bucket, key)
+
+			// The keys of segmented dictionaries might not be available yet
+			if v.keys == nil {
+				return value
+			}
+		}
+
 		// TODO: optimize linear scan
 		for index, keyValue := range v.keys.Elements() {
 			if dictionaryKey(keyValue) == key {
@@ -7982,13 +10107,13 @@ func (v *DictionaryValue) Insert(inter *Interpreter, locationRangeGetter func()
 
 	v.modified = true
 
-	v.ensureLoaded()
+	key := dictionaryKey(keyValue)
+
+	bucket := v.ensureEntryLoaded(key)
 
 	// Don't use `Entries` here: the value might be deferred and needs to be loaded
 	existingValue := v.Get(inter, locationRangeGetter, keyValue)
 
-	key := dictionaryKey(keyValue)
-
 	value.SetOwner(v.Owner)
 
 	// Mark the inserted value itself modified.
@@ -8000,11 +10125,26 @@ func (v *DictionaryValue) Insert(inter *Interpreter, locationRangeGetter func()
 
 	v.entries.Set(key, value)
 
+	if bucket != nil {
+		bucket.modified = true
+	}
+
 	switch existingValue := existingValue.(type) {
 	case *SomeValue:
 		return existingValue
 
 	case NilValue:
+		if bucket != nil {
+			bucket.keys = append(bucket.keys, keyValue)
+			bucket.count++
+			v.segments.count++
+
+			// The keys of segmented dictionaries might not be available yet
+			if v.keys == nil {
+				return existingValue
+			}
+		}
+
 		v.keys.Append(inter, locationRangeGetter, keyValue)
 		return existingValue
 
@@ -8036,13 +10176,18 @@ func (v *DictionaryValue) IsStorable() bool {
 
 	// If the keys/entries are not loaded, that implies they were read from storage.
 	// Hence they are storable.
-	if v.content != nil {
+	if v.content != nil || v.entriesContent != nil {
 		return true
 	}
 
-	for _, keyValue := range v.keys.Elements() {
-		if !keyValue.IsStorable() {
-			return false
+	// The keys of segmented dictionaries might not be available yet.
+	// Keys of buckets which are not loaded were read from storage,
+	// hence they are storable
+	if v.keys != nil {
+		for _, keyValue := range v.keys.Elements() {
+			if !keyValue.IsStorable() {
+				return false
+			}
 		}
 	}
 
@@ -8170,8 +10315,12 @@ func (v *DictionaryValue) ensureMetaInfoLoaded() {
 	v.content = nil
 }
 
-// ensureLoaded ensures the entries of this dictionary value are loaded.
-func (v *DictionaryValue) ensureLoaded() {
+// ensureEntriesIndexed ensures the entries of this dictionary value are decoded,
+// or, if the dictionary is segmented, its buckets are indexed,
+// so buckets can be loaded individually.
+// If the entries are already indexed, then calling this function won't have any effect.
+//
+func (v *DictionaryValue) ensureEntriesIndexed() {
 	v.ensureMetaInfoLoaded()
 
 	if v.entriesContent == nil {
@@ -8185,9 +10334,103 @@ func (v *DictionaryValue) ensureLoaded() {
 
 	// Reset the cache
 	v.entriesContent = nil
+
+	// The buckets of segmented dictionaries are decoded when they are loaded
+	if v.segments == nil {
+		v.resetDecodingState()
+	}
+}
+
+// ensureEntryLoaded ensures the entry with the given key is loaded, if it exists.
+// For segmented dictionaries, only the bucket which contains the key is loaded,
+// and returned.
+//
+func (v *DictionaryValue) ensureEntryLoaded(key string) *dictionaryBucket {
+	v.ensureEntriesIndexed()
+
+	if v.segments == nil {
+		return nil
+	}
+
+	bucket := v.segments.bucket(key)
+	v.loadBucket(bucket)
+	return bucket
+}
+
+// ensureLoaded ensures the entries of this dictionary value are loaded.
+func (v *DictionaryValue) ensureLoaded() {
+	v.ensureEntriesIndexed()
+
+	if v.keys != nil {
+		return
+	}
+
+	// The dictionary is segmented and not all buckets are loaded yet
+
+	var keys []Value
+	for _, bucket := range v.segments.buckets {
+		v.loadBucket(bucket)
+		keys = append(keys, bucket.keys...)
+	}
+
+	if keys == nil {
+		keys = make([]Value, 0)
+	}
+
+	v.keys = &ArrayValue{
+		Type: VariableSizedStaticType{
+			Type: v.Type.KeyType,
+		},
+		values: keys,
+		Owner:  v.Owner,
+	}
+
+	v.resetDecodingState()
+}
+
+func (v *DictionaryValue) resetDecodingState() {
 	v.valuePath = nil
 	v.decodeCallback = nil
 	v.encodingVersion = 0
+	v.segmentLoader = nil
+}
+
+// loadBucket loads the given bucket of this segmented dictionary value.
+// If the bucket is already loaded, then calling this function won't have any effect.
+//
+func (v *DictionaryValue) loadBucket(bucket *dictionaryBucket) {
+	if bucket.loaded {
+		return
+	}
+
+	err := decodeDictionaryBucket(v, bucket)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// removeBucketKey removes the given key from the given bucket of this segmented dictionary value.
+//
+func (v *DictionaryValue) removeBucketKey(bucket *dictionaryBucket, key string) {
+	for index, keyValue := range bucket.keys {
+		if dictionaryKey(keyValue) == key {
+			bucket.keys = append(bucket.keys[:index:index], bucket.keys[index+1:]...)
+			bucket.count--
+			bucket.modified = true
+			v.segments.count--
+			return
+		}
+	}
+
+	// Should never occur, the key should have been found
+	panic(errors.NewUnreachableError())
+}
+
+// IsSegmented returns true if the entries of this dictionary value are stored in segments,
+// i.e. if the dictionary was decoded from a segmented dictionary.
+//
+func (v *DictionaryValue) IsSegmented() bool {
+	return v.segments != nil
 }
 
 // OptionalValue
@@ -22,6 +22,10 @@
 
 package interpreter
 
+import (
+	"github.com/onflow/cadence/runtime/sema"
+)
+
 // A VariableActivation is a map of strings to values.
 // It can be used to represent an active scope in a program,
 // i.e. it can be used as a symbol table during semantic analysis,
@@ -31,6 +35,9 @@ type VariableActivation struct {
 	entries map[string]*Variable
 	Depth   int
 	Parent  *VariableActivation
+	// typeArguments are the type arguments of the invocation
+	// of a generic function, if any
+	typeArguments *sema.TypeParameterTypeOrderedMap
 }
 
 func NewVariableActivation(parent *VariableActivation) *VariableActivation {
@@ -76,6 +83,42 @@ func (a *VariableActivation) Set(name string, value *Variable) {
 	a.entries[name] = value
 }
 
+// SetTypeArguments sets the type arguments of the activation,
+// i.e. the types which the type parameters of a generic function
+// are bound to during its invocation.
+//
+func (a *VariableActivation) SetTypeArguments(typeArguments *sema.TypeParameterTypeOrderedMap) {
+	a.typeArguments = typeArguments
+}
+
+// TypeArguments returns the type arguments which are in scope in the activation,
+// i.e. the type arguments of the activation and all its parents.
+// Type arguments of inner activations shadow the ones of outer activations.
+// It returns nil if no type arguments are in scope.
+//
+func (a *VariableActivation) TypeArguments() *sema.TypeParameterTypeOrderedMap {
+	var result *sema.TypeParameterTypeOrderedMap
+
+	for current := a; current != nil; current = current.Parent {
+		if current.typeArguments == nil {
+			continue
+		}
+
+		if result == nil {
+			result = sema.NewTypeParameterTypeOrderedMap()
+		}
+
+		for pair := current.typeArguments.Oldest(); pair != nil; pair = pair.Next() {
+			if _, ok := result.Get(pair.Key); ok {
+				continue
+			}
+			result.Set(pair.Key, pair.Value)
+		}
+	}
+
+	return result
+}
+
 // Activations is a stack of activation records.
 // Each entry represents a new activation record.
 //
@@ -45,6 +45,8 @@ type Visitor interface {
 	VisitWord64Value(interpreter *Interpreter, value Word64Value)
 	VisitFix64Value(interpreter *Interpreter, value Fix64Value)
 	VisitUFix64Value(interpreter *Interpreter, value UFix64Value)
+	VisitFix128Value(interpreter *Interpreter, value Fix128Value)
+	VisitUFix128Value(interpreter *Interpreter, value UFix128Value)
 	VisitCompositeValue(interpreter *Interpreter, value *CompositeValue) bool
 	VisitDictionaryValue(interpreter *Interpreter, value *DictionaryValue) bool
 	VisitNilValue(interpreter *Interpreter, value NilValue)
@@ -55,6 +57,7 @@ type Visitor interface {
 	VisitPathValue(interpreter *Interpreter, value PathValue)
 	VisitCapabilityValue(interpreter *Interpreter, value CapabilityValue)
 	VisitLinkValue(interpreter *Interpreter, value LinkValue)
+	VisitRangeValue(interpreter *Interpreter, value RangeValue)
 	VisitInterpretedFunctionValue(interpreter *Interpreter, value *InterpretedFunctionValue)
 	VisitHostFunctionValue(interpreter *Interpreter, value *HostFunctionValue)
 	VisitBoundFunctionValue(interpreter *Interpreter, value BoundFunctionValue)
@@ -88,6 +91,8 @@ type EmptyVisitor struct {
 	Word64ValueVisitor              func(interpreter *Interpreter, value Word64Value)
 	Fix64ValueVisitor               func(interpreter *Interpreter, value Fix64Value)
 	UFix64ValueVisitor              func(interpreter *Interpreter, value UFix64Value)
+	Fix128ValueVisitor              func(interpreter *Interpreter, value Fix128Value)
+	UFix128ValueVisitor             func(interpreter *Interpreter, value UFix128Value)
 	CompositeValueVisitor           func(interpreter *Interpreter, value *CompositeValue) bool
 	DictionaryValueVisitor          func(interpreter *Interpreter, value *DictionaryValue) bool
 	NilValueVisitor                 func(interpreter *Interpreter, value NilValue)
@@ -98,6 +103,7 @@ type EmptyVisitor struct {
 	PathValueVisitor                func(interpreter *Interpreter, value PathValue)
 	CapabilityValueVisitor          func(interpreter *Interpreter, value CapabilityValue)
 	LinkValueVisitor                func(interpreter *Interpreter, value LinkValue)
+	RangeValueVisitor               func(interpreter *Interpreter, value RangeValue)
 	InterpretedFunctionValueVisitor func(interpreter *Interpreter, value *InterpretedFunctionValue)
 	HostFunctionValueVisitor        func(interpreter *Interpreter, value *HostFunctionValue)
 	BoundFunctionValueVisitor       func(interpreter *Interpreter, value BoundFunctionValue)
@@ -288,6 +294,20 @@ func (v EmptyVisitor) VisitUFix64Value(interpreter *Interpreter, value UFix64Val
 	v.UFix64ValueVisitor(interpreter, value)
 }
 
+func (v EmptyVisitor) VisitFix128Value(interpreter *Interpreter, value Fix128Value) {
+	if v.Fix128ValueVisitor == nil {
+		return
+	}
+	v.Fix128ValueVisitor(interpreter, value)
+}
+
+func (v EmptyVisitor) VisitUFix128Value(interpreter *Interpreter, value UFix128Value) {
+	if v.UFix128ValueVisitor == nil {
+		return
+	}
+	v.UFix128ValueVisitor(interpreter, value)
+}
+
 func (v EmptyVisitor) VisitCompositeValue(interpreter *Interpreter, value *CompositeValue) bool {
 	if v.CompositeValueVisitor == nil {
 		return true
@@ -358,6 +378,13 @@ func (v EmptyVisitor) VisitLinkValue(interpreter *Interpreter, value LinkValue)
 	v.LinkValueVisitor(interpreter, value)
 }
 
+func (v EmptyVisitor) VisitRangeValue(interpreter *Interpreter, value RangeValue) {
+	if v.RangeValueVisitor == nil {
+		return
+	}
+	v.RangeValueVisitor(interpreter, value)
+}
+
 func (v EmptyVisitor) VisitInterpretedFunctionValue(interpreter *Interpreter, value *InterpretedFunctionValue) {
 	if v.InterpretedFunctionValueVisitor == nil {
 		return
@@ -20,6 +20,7 @@ package runtime
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/fixedpoint"
@@ -202,21 +203,25 @@ func fixedPointLiteralValue(expression ast.Expression, ty sema.Type) (cadence.Va
 		return nil, InvalidLiteralError
 	}
 
-	// TODO: adjust once/if we support more fixed point types
-
-	value := fixedpoint.ConvertToFixedPointBigInt(
-		fixedPointExpression.Negative,
-		fixedPointExpression.UnsignedInteger,
-		fixedPointExpression.Fractional,
-		fixedPointExpression.Scale,
-		sema.Fix64Scale,
-	)
+	convert := func(targetScale uint) *big.Int {
+		return fixedpoint.ConvertToFixedPointBigInt(
+			fixedPointExpression.Negative,
+			fixedPointExpression.UnsignedInteger,
+			fixedPointExpression.Fractional,
+			fixedPointExpression.Scale,
+			targetScale,
+		)
+	}
 
 	switch ty {
 	case sema.Fix64Type, sema.FixedPointType, sema.SignedFixedPointType:
-		return cadence.Fix64(value.Int64()), nil
+		return cadence.Fix64(convert(sema.Fix64Scale).Int64()), nil
 	case sema.UFix64Type:
-		return cadence.UFix64(value.Uint64()), nil
+		return cadence.UFix64(convert(sema.Fix64Scale).Uint64()), nil
+	case sema.Fix128Type:
+		return cadence.NewFix128FromBig(convert(sema.Fix128Scale))
+	case sema.UFix128Type:
+		return cadence.NewUFix128FromBig(convert(sema.Fix128Scale))
 	}
 
 	return nil, UnsupportedLiteralError
@@ -62,6 +62,9 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 	access := ast.AccessNotSpecified
 	var accessPos *ast.Position
 
+	purity := ast.FunctionPurityUnspecified
+	var purityPos *ast.Position
+
 	for {
 		p.skipSpaceAndComments(true)
 
@@ -74,7 +77,20 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 				return parseVariableDeclaration(p, access, accessPos, docString)
 
 			case keywordFun:
-				return parseFunctionDeclaration(p, false, access, accessPos, docString)
+				return parseFunctionDeclaration(p, false, access, accessPos, purity, purityPos, docString)
+
+			case keywordView:
+				// `view` is not a reserved keyword,
+				// so it is only a modifier if it is followed by `fun`
+				if !isFunKeywordAhead(p, true) {
+					return nil
+				}
+				pos := p.current.StartPos
+				purityPos = &pos
+				purity = ast.FunctionPurityView
+				// Skip the `view` keyword
+				p.next()
+				continue
 
 			case keywordImport:
 				return parseImportDeclaration(p)
@@ -85,6 +101,12 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 			case keywordStruct, keywordResource, keywordContract, keywordEnum:
 				return parseCompositeOrInterfaceDeclaration(p, access, accessPos, docString)
 
+			case keywordTypeAlias:
+				return parseTypeAliasDeclaration(p, access, accessPos, docString)
+
+			case keywordExtension:
+				return parseExtensionDeclaration(p, access, accessPos, docString)
+
 			case KeywordTransaction:
 				if access != ast.AccessNotSpecified {
 					panic(fmt.Errorf("invalid access modifier for transaction"))
@@ -815,6 +837,8 @@ func parseCompositeOrInterfaceDeclaration(
 		}
 	}
 
+	typeParameterList := parseTypeParameterList(p)
+
 	p.skipSpaceAndComments(true)
 
 	var conformances []*ast.NominalType
@@ -855,6 +879,10 @@ func parseCompositeOrInterfaceDeclaration(
 			panic(fmt.Errorf("unexpected conformances"))
 		}
 
+		if typeParameterList != nil {
+			panic(fmt.Errorf("unexpected type parameters for interface"))
+		}
+
 		return &ast.InterfaceDeclaration{
 			Access:        access,
 			CompositeKind: compositeKind,
@@ -865,13 +893,14 @@ func parseCompositeOrInterfaceDeclaration(
 		}
 	} else {
 		return &ast.CompositeDeclaration{
-			Access:        access,
-			CompositeKind: compositeKind,
-			Identifier:    identifier,
-			Conformances:  conformances,
-			Members:       members,
-			DocString:     docString,
-			Range:         declarationRange,
+			Access:            access,
+			CompositeKind:     compositeKind,
+			Identifier:        identifier,
+			TypeParameterList: typeParameterList,
+			Conformances:      conformances,
+			Members:           members,
+			DocString:         docString,
+			Range:             declarationRange,
 		}
 	}
 }
@@ -921,6 +950,8 @@ func parseMembersAndNestedDeclarations(p *parser, endTokenType lexer.TokenType)
 //                               | compositeDeclaration
 //                               | eventDeclaration
 //                               | enumCase
+//                               | typeAliasDeclaration
+//                               | extensionDeclaration
 //
 func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration {
 
@@ -929,6 +960,9 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 	access := ast.AccessNotSpecified
 	var accessPos *ast.Position
 
+	purity := ast.FunctionPurityUnspecified
+	var purityPos *ast.Position
+
 	var previousIdentifierToken *lexer.Token
 
 	for {
@@ -936,6 +970,23 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 
 		switch p.current.Type {
 		case lexer.TokenIdentifier:
+
+			// `view` is not a reserved keyword,
+			// so it is only a modifier if it is followed by `fun`
+
+			if purity == ast.FunctionPurityUnspecified &&
+				previousIdentifierToken == nil &&
+				p.current.Value == keywordView &&
+				isFunKeywordAhead(p, true) {
+
+				pos := p.current.StartPos
+				purityPos = &pos
+				purity = ast.FunctionPurityView
+				// Skip the `view` keyword
+				p.next()
+				continue
+			}
+
 			switch p.current.Value {
 			case keywordLet, keywordVar:
 				return parseFieldWithVariableKind(p, access, accessPos, docString)
@@ -944,7 +995,15 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 				return parseEnumCase(p, access, accessPos, docString)
 
 			case keywordFun:
-				return parseFunctionDeclaration(p, functionBlockIsOptional, access, accessPos, docString)
+				return parseFunctionDeclaration(
+					p,
+					functionBlockIsOptional,
+					access,
+					accessPos,
+					purity,
+					purityPos,
+					docString,
+				)
 
 			case keywordEvent:
 				return parseEventDeclaration(p, access, accessPos, docString)
@@ -952,6 +1011,12 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 			case keywordStruct, keywordResource, keywordContract, keywordEnum:
 				return parseCompositeOrInterfaceDeclaration(p, access, accessPos, docString)
 
+			case keywordTypeAlias:
+				return parseTypeAliasDeclaration(p, access, accessPos, docString)
+
+			case keywordExtension:
+				return parseExtensionDeclaration(p, access, accessPos, docString)
+
 			case keywordPriv, keywordPub, keywordAccess:
 				if access != ast.AccessNotSpecified {
 					panic(fmt.Errorf("unexpected access modifier"))
@@ -1116,3 +1181,129 @@ func parseEnumCase(
 		StartPos:   startPos,
 	}
 }
+
+// parseTypeAliasDeclaration parses a type alias declaration.
+//
+//     typeAliasDeclaration : 'typealias' identifier '=' typeAnnotation
+//
+func parseTypeAliasDeclaration(
+	p *parser,
+	access ast.Access,
+	accessPos *ast.Position,
+	docString string,
+) *ast.TypeAliasDeclaration {
+
+	startPos := p.current.StartPos
+	if accessPos != nil {
+		startPos = *accessPos
+	}
+
+	// Skip the `typealias` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	if !p.current.Is(lexer.TokenIdentifier) {
+		panic(fmt.Errorf(
+			"expected identifier after start of type alias declaration, got %s",
+			p.current.Type,
+		))
+	}
+
+	identifier := tokenToIdentifier(p.current)
+
+	// Skip the identifier
+	p.next()
+	p.skipSpaceAndComments(true)
+
+	p.mustOne(lexer.TokenEqual)
+	p.skipSpaceAndComments(true)
+
+	typeAnnotation := parseTypeAnnotation(p)
+
+	return &ast.TypeAliasDeclaration{
+		Access:         access,
+		Identifier:     identifier,
+		TypeAnnotation: typeAnnotation,
+		DocString:      docString,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   typeAnnotation.EndPosition(),
+		},
+	}
+}
+
+// parseExtensionDeclaration parses an extension declaration.
+//
+//     extensionDeclaration : 'extension' identifier 'for' nominalType
+//                            '{' membersAndNestedDeclarations '}'
+//
+func parseExtensionDeclaration(
+	p *parser,
+	access ast.Access,
+	accessPos *ast.Position,
+	docString string,
+) *ast.ExtensionDeclaration {
+
+	startPos := p.current.StartPos
+	if accessPos != nil {
+		startPos = *accessPos
+	}
+
+	// Skip the `extension` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	if !p.current.Is(lexer.TokenIdentifier) {
+		panic(fmt.Errorf(
+			"expected identifier after start of extension declaration, got %s",
+			p.current.Type,
+		))
+	}
+
+	identifier := tokenToIdentifier(p.current)
+
+	// Skip the identifier
+	p.next()
+	p.skipSpaceAndComments(true)
+
+	if !p.current.IsString(lexer.TokenIdentifier, keywordFor) {
+		panic(fmt.Errorf(
+			"expected keyword %q, got %s",
+			keywordFor,
+			p.current.Type,
+		))
+	}
+
+	// Skip the `for` keyword
+	p.next()
+	p.skipSpaceAndComments(true)
+
+	ty := parseType(p, lowestBindingPower)
+
+	extendedType, ok := ty.(*ast.NominalType)
+	if !ok {
+		panic(fmt.Errorf("expected nominal type, got %s", ty))
+	}
+
+	p.skipSpaceAndComments(true)
+
+	p.mustOne(lexer.TokenBraceOpen)
+
+	members := parseMembersAndNestedDeclarations(p, lexer.TokenBraceClose)
+
+	p.skipSpaceAndComments(true)
+
+	endToken := p.mustOne(lexer.TokenBraceClose)
+
+	return &ast.ExtensionDeclaration{
+		Access:       access,
+		Identifier:   identifier,
+		ExtendedType: extendedType,
+		Members:      members,
+		DocString:    docString,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   endToken.EndPos,
+		},
+	}
+}
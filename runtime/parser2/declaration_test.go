@@ -23,6 +23,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/runtime/ast"
@@ -933,6 +934,218 @@ func TestParseFunctionDeclaration(t *testing.T) {
 			result,
 		)
 	})
+	t.Run("with type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("fun foo<T, U: AnyResource>() {}")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.FunctionDeclaration{
+					Identifier: ast.Identifier{
+						Identifier: "foo",
+						Pos:        ast.Position{Line: 1, Column: 4, Offset: 4},
+					},
+					TypeParameterList: &ast.TypeParameterList{
+						TypeParameters: []*ast.TypeParameter{
+							{
+								Identifier: ast.Identifier{
+									Identifier: "T",
+									Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
+								},
+							},
+							{
+								Identifier: ast.Identifier{
+									Identifier: "U",
+									Pos:        ast.Position{Line: 1, Column: 11, Offset: 11},
+								},
+								TypeBound: &ast.TypeAnnotation{
+									Type: &ast.NominalType{
+										Identifier: ast.Identifier{
+											Identifier: "AnyResource",
+											Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+										},
+									},
+									StartPos: ast.Position{Line: 1, Column: 14, Offset: 14},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+							EndPos:   ast.Position{Line: 1, Column: 25, Offset: 25},
+						},
+					},
+					ParameterList: &ast.ParameterList{
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 26, Offset: 26},
+							EndPos:   ast.Position{Line: 1, Column: 27, Offset: 27},
+						},
+					},
+					ReturnTypeAnnotation: &ast.TypeAnnotation{
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Pos: ast.Position{Line: 1, Column: 27, Offset: 27},
+							},
+						},
+						StartPos: ast.Position{Line: 1, Column: 27, Offset: 27},
+					},
+					FunctionBlock: &ast.FunctionBlock{
+						Block: &ast.Block{
+							Range: ast.Range{
+								StartPos: ast.Position{Line: 1, Column: 29, Offset: 29},
+								EndPos:   ast.Position{Line: 1, Column: 30, Offset: 30},
+							},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("with type parameters, missing closing angle bracket", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("fun foo<T() {}")
+		require.NotEmpty(t, errs)
+	})
+}
+
+func TestParseViewFunctionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("pub view", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("pub view fun foo () { }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.FunctionDeclaration{
+					Access: ast.AccessPublic,
+					Purity: ast.FunctionPurityView,
+					Identifier: ast.Identifier{
+						Identifier: "foo",
+						Pos:        ast.Position{Line: 1, Column: 13, Offset: 13},
+					},
+					ParameterList: &ast.ParameterList{
+						Parameters: nil,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 17, Offset: 17},
+							EndPos:   ast.Position{Line: 1, Column: 18, Offset: 18},
+						},
+					},
+					ReturnTypeAnnotation: &ast.TypeAnnotation{
+						IsResource: false,
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Identifier: "",
+								Pos:        ast.Position{Line: 1, Column: 18, Offset: 18},
+							},
+						},
+						StartPos: ast.Position{Line: 1, Column: 18, Offset: 18},
+					},
+					FunctionBlock: &ast.FunctionBlock{
+						Block: &ast.Block{
+							Range: ast.Range{
+								StartPos: ast.Position{Line: 1, Column: 20, Offset: 20},
+								EndPos:   ast.Position{Line: 1, Column: 22, Offset: 22},
+							},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("view, without access", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("view fun foo () { }")
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		functionDeclaration := result[0].(*ast.FunctionDeclaration)
+
+		assert.Equal(t, ast.FunctionPurityView, functionDeclaration.Purity)
+		assert.Equal(t, ast.AccessNotSpecified, functionDeclaration.Access)
+		assert.Equal(t,
+			ast.Position{Line: 1, Column: 0, Offset: 0},
+			functionDeclaration.StartPos,
+		)
+	})
+
+	t.Run("view, composite member", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(`
+          struct S {
+              let view: Int
+
+              pub view fun foo() {}
+
+              view fun bar() {}
+          }
+        `)
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		members := result[0].(*ast.CompositeDeclaration).Members
+
+		fields := members.Fields()
+		require.Len(t, fields, 1)
+		assert.Equal(t, "view", fields[0].Identifier.Identifier)
+
+		functions := members.Functions()
+		require.Len(t, functions, 2)
+		assert.Equal(t, ast.FunctionPurityView, functions[0].Purity)
+		assert.Equal(t, ast.FunctionPurityView, functions[1].Purity)
+	})
+
+	t.Run("view, local function", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(`
+          fun test() {
+              view fun foo() {}
+              let view = 1
+          }
+        `)
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		statements := result[0].(*ast.FunctionDeclaration).FunctionBlock.Block.Statements
+		require.Len(t, statements, 2)
+
+		assert.Equal(t,
+			ast.FunctionPurityView,
+			statements[0].(*ast.FunctionDeclaration).Purity,
+		)
+		assert.Equal(t,
+			"view",
+			statements[1].(*ast.VariableDeclaration).Identifier.Identifier,
+		)
+	})
+
+	t.Run("view, not followed by fun", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("view let x = 1")
+		require.NotEmpty(t, errs)
+	})
 }
 
 func TestParseAccess(t *testing.T) {
@@ -1935,6 +2148,46 @@ func TestParseCompositeDeclaration(t *testing.T) {
 			result,
 		)
 	})
+	t.Run("struct, type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(" pub struct S<T> { }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.CompositeDeclaration{
+					Access:        ast.AccessPublic,
+					CompositeKind: common.CompositeKindStructure,
+					Identifier: ast.Identifier{
+						Identifier: "S",
+						Pos:        ast.Position{Line: 1, Column: 12, Offset: 12},
+					},
+					TypeParameterList: &ast.TypeParameterList{
+						TypeParameters: []*ast.TypeParameter{
+							{
+								Identifier: ast.Identifier{
+									Identifier: "T",
+									Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 13, Offset: 13},
+							EndPos:   ast.Position{Line: 1, Column: 15, Offset: 15},
+						},
+					},
+					Members: &ast.Members{},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 19, Offset: 19},
+					},
+				},
+			},
+			result,
+		)
+	})
 }
 
 func TestParseInterfaceDeclaration(t *testing.T) {
@@ -4855,3 +5108,195 @@ func TestParsePreconditionWithUnaryNegation(t *testing.T) {
 		result.Declarations(),
 	)
 }
+
+func TestParseTypeAliasDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("top-level", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("pub typealias R = &{A, B}")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.TypeAliasDeclaration{
+					Access: ast.AccessPublic,
+					Identifier: ast.Identifier{
+						Identifier: "R",
+						Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+					},
+					TypeAnnotation: &ast.TypeAnnotation{
+						IsResource: false,
+						Type: &ast.ReferenceType{
+							Type: &ast.RestrictedType{
+								Restrictions: []*ast.NominalType{
+									{
+										Identifier: ast.Identifier{
+											Identifier: "A",
+											Pos:        ast.Position{Line: 1, Column: 20, Offset: 20},
+										},
+									},
+									{
+										Identifier: ast.Identifier{
+											Identifier: "B",
+											Pos:        ast.Position{Line: 1, Column: 23, Offset: 23},
+										},
+									},
+								},
+								Range: ast.Range{
+									StartPos: ast.Position{Line: 1, Column: 19, Offset: 19},
+									EndPos:   ast.Position{Line: 1, Column: 24, Offset: 24},
+								},
+							},
+							StartPos: ast.Position{Line: 1, Column: 18, Offset: 18},
+						},
+						StartPos: ast.Position{Line: 1, Column: 18, Offset: 18},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 24, Offset: 24},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("nested, resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := ParseProgram(`
+          contract C {
+              /// doc
+              typealias V = @Vault
+          }
+        `)
+		require.NoError(t, err)
+
+		typeAliases := result.CompositeDeclarations()[0].Members.TypeAliases()
+		require.Len(t, typeAliases, 1)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.TypeAliasDeclaration{
+				Access: ast.AccessNotSpecified,
+				Identifier: ast.Identifier{
+					Identifier: "V",
+					Pos:        ast.Position{Line: 4, Column: 24, Offset: 70},
+				},
+				TypeAnnotation: &ast.TypeAnnotation{
+					IsResource: true,
+					Type: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "Vault",
+							Pos:        ast.Position{Line: 4, Column: 29, Offset: 75},
+						},
+					},
+					StartPos: ast.Position{Line: 4, Column: 28, Offset: 74},
+				},
+				DocString: " doc",
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 4, Column: 14, Offset: 60},
+					EndPos:   ast.Position{Line: 4, Column: 33, Offset: 79},
+				},
+			},
+			typeAliases[0],
+		)
+	})
+
+	t.Run("missing equal sign", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("typealias R Int")
+		require.NotEmpty(t, errs)
+	})
+}
+
+func TestParseExtensionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("pub extension E for C.R {}")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.ExtensionDeclaration{
+					Access: ast.AccessPublic,
+					Identifier: ast.Identifier{
+						Identifier: "E",
+						Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+					},
+					ExtendedType: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "C",
+							Pos:        ast.Position{Line: 1, Column: 20, Offset: 20},
+						},
+						NestedIdentifiers: []ast.Identifier{
+							{
+								Identifier: "R",
+								Pos:        ast.Position{Line: 1, Column: 22, Offset: 22},
+							},
+						},
+					},
+					Members: ast.NewMembers(nil),
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 25, Offset: 25},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("nested, with functions", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := ParseProgram(`
+          contract C {
+              /// doc
+              pub extension E for R {
+                  pub fun foo() {}
+                  fun bar(): Int { return 1 }
+              }
+          }
+        `)
+		require.NoError(t, err)
+
+		extensions := result.CompositeDeclarations()[0].Members.Extensions()
+		require.Len(t, extensions, 1)
+
+		extension := extensions[0]
+
+		require.Equal(t, "E", extension.Identifier.Identifier)
+		require.Equal(t, "R", extension.ExtendedType.Identifier.Identifier)
+		require.Equal(t, " doc", extension.DocString)
+		require.Len(t, extension.Members.Functions(), 2)
+	})
+
+	t.Run("missing for", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("extension E R {}")
+		require.NotEmpty(t, errs)
+	})
+
+	t.Run("non-nominal type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("extension E for [R] {}")
+		require.NotEmpty(t, errs)
+	})
+}
@@ -34,6 +34,7 @@ const (
 	exprLeftBindingPowerLogicalOr
 	exprLeftBindingPowerLogicalAnd
 	exprLeftBindingPowerComparison
+	exprLeftBindingPowerRange
 	exprLeftBindingPowerNilCoalescing
 	exprLeftBindingPowerBitwiseOr
 	exprLeftBindingPowerBitwiseXor
@@ -275,6 +276,9 @@ func init() {
 		operation:        ast.OperationNotEqual,
 	})
 
+	defineRangeExpression(lexer.TokenDotDotLess, false)
+	defineRangeExpression(lexer.TokenDotDotDot, true)
+
 	defineExpr(binaryExpr{
 		tokenType:        lexer.TokenDoubleQuestionMark,
 		leftBindingPower: exprLeftBindingPowerNilCoalescing,
@@ -419,15 +423,8 @@ func init() {
 	})
 
 	defineExpr(literalExpr{
-		tokenType: lexer.TokenString,
-		nullDenotation: func(p *parser, token lexer.Token) ast.Expression {
-			parsedString, errs := parseStringLiteral(token.Value.(string))
-			p.report(errs...)
-			return &ast.StringExpression{
-				Value: parsedString,
-				Range: token.Range,
-			}
-		},
+		tokenType:      lexer.TokenString,
+		nullDenotation: parseStringExpression,
 	})
 
 	defineExpr(prefixExpr{
@@ -766,6 +763,25 @@ func defineIdentifierExpression() {
 			case keywordFun:
 				return parseFunctionExpression(p, token)
 
+			case keywordView:
+				// `view` is not a reserved keyword,
+				// so it only introduces a view function expression if it is followed by `fun`
+				if !isFunKeywordAhead(p, false) {
+					return &ast.IdentifierExpression{
+						Identifier: tokenToIdentifier(token),
+					}
+				}
+
+				p.skipSpaceAndComments(true)
+				funToken := p.current
+				// Skip the `fun` keyword
+				p.next()
+
+				functionExpression := parseFunctionExpression(p, funToken)
+				functionExpression.Purity = ast.FunctionPurityView
+				functionExpression.StartPos = token.StartPos
+				return functionExpression
+
 			default:
 				return &ast.IdentifierExpression{
 					Identifier: tokenToIdentifier(token),
@@ -788,6 +804,20 @@ func parseFunctionExpression(p *parser, token lexer.Token) *ast.FunctionExpressi
 	}
 }
 
+func defineRangeExpression(tokenType lexer.TokenType, inclusive bool) {
+	defineExpr(infixExpr{
+		tokenType:        tokenType,
+		leftBindingPower: exprLeftBindingPowerRange,
+		leftDenotation: func(left, right ast.Expression) ast.Expression {
+			return &ast.RangeExpression{
+				Start:     left,
+				End:       right,
+				Inclusive: inclusive,
+			}
+		},
+	})
+}
+
 func defineCastingExpression() {
 
 	setExprIdentifierLeftBindingPower(keywordAs, exprLeftBindingPowerCasting)
@@ -1304,9 +1334,216 @@ func applyExprLeftDenotation(p *parser, token lexer.Token, left ast.Expression)
 	return leftDenotation(p, token, left)
 }
 
+// parseStringExpression parses a string literal token into a string expression,
+// or into a string template expression, if the literal contains string interpolations
+//
+func parseStringExpression(p *parser, token lexer.Token) ast.Expression {
+	literal := token.Value.(string)
+
+	if !strings.Contains(literal, "\\(") {
+		parsedString, errs := parseStringLiteral(literal)
+		p.report(errs...)
+		return &ast.StringExpression{
+			Value: parsedString,
+			Range: token.Range,
+		}
+	}
+
+	content, errs := stringLiteralContent(literal)
+	p.report(errs...)
+
+	// The content starts after the start quote
+
+	contentOffset := token.StartPos.Offset + 1
+	contentColumn := token.StartPos.Column + 1
+
+	segments, interpolations, errs := splitStringTemplate(content)
+	p.report(errs...)
+
+	parseSegment := func(segment string) string {
+		parsedSegment, errs := parseStringLiteralContent(segment)
+		p.report(errs...)
+		return parsedSegment
+	}
+
+	values := []string{parseSegment(segments[0])}
+	var expressions []ast.Expression
+
+	for i, interpolation := range interpolations {
+
+		// Lex and parse the interpolated expression from the original input,
+		// so the positions of the expression are the positions in the original input.
+		// String literals can't span multiple lines,
+		// so the expression is on the same line as the string literal
+
+		startPos := ast.Position{
+			Offset: contentOffset + interpolation.start,
+			Line:   token.StartPos.Line,
+			Column: contentColumn + utf8.RuneCountInString(content[:interpolation.start]),
+		}
+
+		input := p.tokens.Input()[:contentOffset+interpolation.end]
+
+		result, errs := ParseTokenStream(
+			lexer.LexFrom(input, startPos),
+			func(p *parser) interface{} {
+				return parseExpression(p, lowestBindingPower)
+			},
+		)
+		p.report(errs...)
+
+		value := parseSegment(segments[i+1])
+
+		expression, ok := result.(ast.Expression)
+		if !ok {
+			// The expression is invalid and the error was reported,
+			// merge the surrounding segments
+			values[len(values)-1] += value
+			continue
+		}
+
+		expressions = append(expressions, expression)
+		values = append(values, value)
+	}
+
+	return &ast.StringTemplateExpression{
+		Values:      values,
+		Expressions: expressions,
+		Range:       token.Range,
+	}
+}
+
+// stringInterpolation is the range of an interpolated expression in the content of a string literal,
+// excluding the surrounding `\(` and `)`
+//
+type stringInterpolation struct {
+	start int
+	end   int
+}
+
+// splitStringTemplate splits the content of a string literal, excluding start and end quotes,
+// into the segments around the string interpolations, and the string interpolations.
+// The segments are not parsed, i.e. they still contain escape sequences.
+//
+// There is always exactly one more segment than interpolations.
+//
+func splitStringTemplate(content string) (segments []string, interpolations []stringInterpolation, errs []error) {
+	segmentStart := 0
+
+	length := len(content)
+
+	for index := 0; index < length; index++ {
+		if content[index] != '\\' {
+			continue
+		}
+
+		if index+1 >= length || content[index+1] != '(' {
+			// Skip the escaped character, which is handled when parsing the segment
+			index++
+			continue
+		}
+
+		segments = append(segments, content[segmentStart:index])
+
+		start := index + 2
+		end := stringInterpolationEnd(content, start)
+		if end < 0 {
+			errs = append(errs, fmt.Errorf("incomplete string interpolation: missing ')'"))
+			end = length
+		}
+
+		interpolations = append(interpolations, stringInterpolation{
+			start: start,
+			end:   end,
+		})
+
+		index = end
+		segmentStart = end + 1
+	}
+
+	if segmentStart > length {
+		segmentStart = length
+	}
+	segments = append(segments, content[segmentStart:])
+
+	return
+}
+
+// stringInterpolationEnd returns the offset of the closing parenthesis
+// of the string interpolation which starts at the given offset, or -1 if it is not terminated.
+//
+// The interpolated expression may contain nested parentheses and nested string literals.
+//
+func stringInterpolationEnd(s string, start int) int {
+	depth := 1
+	for index := start; index < len(s); index++ {
+		switch s[index] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return index
+			}
+		case '"':
+			end := nestedStringLiteralEnd(s, index+1)
+			if end < 0 {
+				return -1
+			}
+			index = end
+		}
+	}
+	return -1
+}
+
+// nestedStringLiteralEnd returns the offset of the end quote of the string literal
+// which starts at the given offset, after the start quote, or -1 if it is not terminated.
+//
+func nestedStringLiteralEnd(s string, start int) int {
+	for index := start; index < len(s); index++ {
+		switch s[index] {
+		case '\\':
+			if index+1 < len(s) && s[index+1] == '(' {
+				end := stringInterpolationEnd(s, index+2)
+				if end < 0 {
+					return -1
+				}
+				index = end
+			} else {
+				index++
+			}
+		case '"':
+			return index
+		}
+	}
+	return -1
+}
+
 // parseStringLiteral parses a whole string literal, including start and end quotes
 //
 func parseStringLiteral(literal string) (result string, errs []error) {
+	content, errs := stringLiteralContent(literal)
+
+	var innerErrs []error
+	result, innerErrs = parseStringLiteralContent(content)
+
+	// NOTE: report the errors of the content before the error for a missing end quote
+
+	if len(errs) > 0 && errs[len(errs)-1] == errMissingStringLiteralEnd {
+		innerErrs = append(innerErrs, errMissingStringLiteralEnd)
+		errs = errs[:len(errs)-1]
+	}
+
+	errs = append(errs, innerErrs...)
+
+	return
+}
+
+var errMissingStringLiteralEnd = fmt.Errorf("invalid end of string literal: missing '\"'")
+
+// stringLiteralContent returns the contents of a whole string literal, excluding start and end quotes
+//
+func stringLiteralContent(literal string) (content string, errs []error) {
 	report := func(err error) {
 		errs = append(errs, err)
 	}
@@ -1338,12 +1575,10 @@ func parseStringLiteral(literal string) (result string, errs []error) {
 		missingEnd = true
 	}
 
-	var innerErrs []error
-	result, innerErrs = parseStringLiteralContent(literal[1:endOffset])
-	errs = append(errs, innerErrs...)
+	content = literal[1:endOffset]
 
 	if missingEnd {
-		report(fmt.Errorf("invalid end of string literal: missing '\"'"))
+		report(errMissingStringLiteralEnd)
 	}
 
 	return
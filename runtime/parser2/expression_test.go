@@ -207,6 +207,95 @@ func TestParseSimpleInfixExpression(t *testing.T) {
 	})
 }
 
+func TestParseRangeExpression(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("exclusive, with arithmetic", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("0..<n - 1")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.RangeExpression{
+				Start: &ast.IntegerExpression{
+					Value: big.NewInt(0),
+					Base:  10,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 0, Offset: 0},
+					},
+				},
+				End: &ast.BinaryExpression{
+					Operation: ast.OperationMinus,
+					Left: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "n",
+							Pos:        ast.Position{Line: 1, Column: 4, Offset: 4},
+						},
+					},
+					Right: &ast.IntegerExpression{
+						Value: big.NewInt(1),
+						Base:  10,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+							EndPos:   ast.Position{Line: 1, Column: 8, Offset: 8},
+						},
+					},
+				},
+				Inclusive: false,
+			},
+			result,
+		)
+	})
+
+	t.Run("inclusive, in comparison", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("a...b == c")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.BinaryExpression{
+				Operation: ast.OperationEqual,
+				Left: &ast.RangeExpression{
+					Start: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "a",
+							Pos:        ast.Position{Line: 1, Column: 0, Offset: 0},
+						},
+					},
+					End: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "b",
+							Pos:        ast.Position{Line: 1, Column: 4, Offset: 4},
+						},
+					},
+					Inclusive: true,
+				},
+				Right: &ast.IdentifierExpression{
+					Identifier: ast.Identifier{
+						Identifier: "c",
+						Pos:        ast.Position{Line: 1, Column: 9, Offset: 9},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("invalid operator", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseExpression("a..b")
+		require.NotEmpty(t, errs)
+	})
+}
+
 func TestParseAdvancedExpression(t *testing.T) {
 
 	t.Parallel()
@@ -4323,6 +4412,70 @@ func TestParseMultiplicativeExpression(t *testing.T) {
 	)
 }
 
+func TestParseViewFunctionExpression(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("view", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("view fun () { }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.FunctionExpression{
+				Purity: ast.FunctionPurityView,
+				ParameterList: &ast.ParameterList{
+					Parameters: nil,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+						EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+					},
+				},
+				ReturnTypeAnnotation: &ast.TypeAnnotation{
+					IsResource: false,
+					Type: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "",
+							Pos:        ast.Position{Line: 1, Column: 10, Offset: 10},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+				},
+				FunctionBlock: &ast.FunctionBlock{
+					Block: &ast.Block{
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 12, Offset: 12},
+							EndPos:   ast.Position{Line: 1, Column: 14, Offset: 14},
+						},
+					},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			result,
+		)
+	})
+
+	t.Run("view as identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("view")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.IdentifierExpression{
+				Identifier: ast.Identifier{
+					Identifier: "view",
+					Pos:        ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+}
+
 func TestParseFunctionExpressionAndReturn(t *testing.T) {
 
 	t.Parallel()
@@ -4841,6 +4994,157 @@ func TestParseStringWithUnicode(t *testing.T) {
 	utils.AssertEqualWithDiff(t, expected, actual)
 }
 
+func TestParseStringTemplate(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid, single interpolation", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"a \(b) c"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringTemplateExpression{
+				Values: []string{"a ", " c"},
+				Expressions: []ast.Expression{
+					&ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "b",
+							Pos:        ast.Position{Offset: 5, Line: 1, Column: 5},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+					EndPos:   ast.Position{Offset: 9, Line: 1, Column: 9},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("valid, multiple interpolations, escapes", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"\t\(1)\\(\(x.y)"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringTemplateExpression{
+				Values: []string{"\t", "\\(", ""},
+				Expressions: []ast.Expression{
+					&ast.IntegerExpression{
+						Value: big.NewInt(1),
+						Base:  10,
+						Range: ast.Range{
+							StartPos: ast.Position{Offset: 5, Line: 1, Column: 5},
+							EndPos:   ast.Position{Offset: 5, Line: 1, Column: 5},
+						},
+					},
+					&ast.MemberExpression{
+						Expression: &ast.IdentifierExpression{
+							Identifier: ast.Identifier{
+								Identifier: "x",
+								Pos:        ast.Position{Offset: 12, Line: 1, Column: 12},
+							},
+						},
+						AccessPos: ast.Position{Offset: 13, Line: 1, Column: 13},
+						Identifier: ast.Identifier{
+							Identifier: "y",
+							Pos:        ast.Position{Offset: 14, Line: 1, Column: 14},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+					EndPos:   ast.Position{Offset: 16, Line: 1, Column: 16},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("valid, nested string", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"\(f(")"))"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringTemplateExpression{
+				Values: []string{"", ""},
+				Expressions: []ast.Expression{
+					&ast.InvocationExpression{
+						InvokedExpression: &ast.IdentifierExpression{
+							Identifier: ast.Identifier{
+								Identifier: "f",
+								Pos:        ast.Position{Offset: 3, Line: 1, Column: 3},
+							},
+						},
+						Arguments: []*ast.Argument{
+							{
+								Expression: &ast.StringExpression{
+									Value: ")",
+									Range: ast.Range{
+										StartPos: ast.Position{Offset: 5, Line: 1, Column: 5},
+										EndPos:   ast.Position{Offset: 7, Line: 1, Column: 7},
+									},
+								},
+								TrailingSeparatorPos: ast.Position{Offset: 8, Line: 1, Column: 8},
+							},
+						},
+						ArgumentsStartPos: ast.Position{Offset: 4, Line: 1, Column: 4},
+						EndPos:            ast.Position{Offset: 8, Line: 1, Column: 8},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+					EndPos:   ast.Position{Offset: 10, Line: 1, Column: 10},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("invalid, missing end of interpolation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseExpression(`"a \(b"`)
+		require.NotEmpty(t, errs)
+
+		assert.Equal(t,
+			"incomplete string interpolation: missing ')'",
+			errs[0].Error(),
+		)
+	})
+
+	t.Run("invalid, invalid expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"a \() b"`)
+		require.Len(t, errs, 1)
+
+		require.IsType(t, &SyntaxError{}, errs[0])
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringTemplateExpression{
+				Values: []string{"a  b"},
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+					EndPos:   ast.Position{Offset: 8, Line: 1, Column: 8},
+				},
+			},
+			result,
+		)
+	})
+}
+
 func TestParseNilCoalescing(t *testing.T) {
 
 	t.Parallel()
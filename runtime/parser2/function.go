@@ -162,17 +162,128 @@ func parseParameter(p *parser) *ast.Parameter {
 	}
 }
 
+// parseTypeParameterList parses an optional type parameter list,
+// e.g. `<T, U: AnyResource>`.
+// Returns nil if the current token is not the start of a type parameter list.
+//
+func parseTypeParameterList(p *parser) *ast.TypeParameterList {
+	var typeParameters []*ast.TypeParameter
+
+	p.skipSpaceAndComments(true)
+
+	if !p.current.Is(lexer.TokenLess) {
+		return nil
+	}
+
+	startPos := p.current.StartPos
+	// Skip the opening angle bracket
+	p.next()
+
+	var endPos ast.Position
+
+	expectTypeParameter := true
+
+	atEnd := false
+	for !atEnd {
+		p.skipSpaceAndComments(true)
+		switch p.current.Type {
+		case lexer.TokenIdentifier:
+			if !expectTypeParameter {
+				panic(fmt.Errorf(
+					"expected comma or end of type parameter list, got %s",
+					p.current.Type,
+				))
+			}
+			typeParameter := parseTypeParameter(p)
+			typeParameters = append(typeParameters, typeParameter)
+			expectTypeParameter = false
+
+		case lexer.TokenComma:
+			if expectTypeParameter {
+				panic(fmt.Errorf(
+					"expected type parameter or end of type parameter list, got %s",
+					p.current.Type,
+				))
+			}
+			// Skip the comma
+			p.next()
+			expectTypeParameter = true
+
+		case lexer.TokenGreater:
+			endPos = p.current.EndPos
+			// Skip the closing angle bracket
+			p.next()
+			atEnd = true
+
+		case lexer.TokenEOF:
+			panic(fmt.Errorf(
+				"missing %s at end of type parameter list",
+				lexer.TokenGreater,
+			))
+
+		default:
+			if expectTypeParameter {
+				panic(fmt.Errorf(
+					"expected type parameter or end of type parameter list, got %s",
+					p.current.Type,
+				))
+			} else {
+				panic(fmt.Errorf(
+					"expected comma or end of type parameter list, got %s",
+					p.current.Type,
+				))
+			}
+		}
+	}
+
+	return &ast.TypeParameterList{
+		TypeParameters: typeParameters,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   endPos,
+		},
+	}
+}
+
+func parseTypeParameter(p *parser) *ast.TypeParameter {
+	identifier := tokenToIdentifier(p.current)
+	// Skip the identifier
+	p.next()
+
+	// The type bound is optional
+
+	var typeBound *ast.TypeAnnotation
+
+	p.skipSpaceAndComments(true)
+	if p.current.Is(lexer.TokenColon) {
+		// Skip the colon
+		p.next()
+		p.skipSpaceAndComments(true)
+
+		typeBound = parseTypeAnnotation(p)
+	}
+
+	return &ast.TypeParameter{
+		Identifier: identifier,
+		TypeBound:  typeBound,
+	}
+}
+
 func parseFunctionDeclaration(
 	p *parser,
 	functionBlockIsOptional bool,
 	access ast.Access,
 	accessPos *ast.Position,
+	purity ast.FunctionPurity,
+	purityPos *ast.Position,
 	docString string,
 ) *ast.FunctionDeclaration {
 
 	startPos := p.current.StartPos
 	if accessPos != nil {
 		startPos = *accessPos
+	} else if purityPos != nil {
+		startPos = *purityPos
 	}
 
 	// Skip the `fun` keyword
@@ -191,12 +302,16 @@ func parseFunctionDeclaration(
 	// Skip the identifier
 	p.next()
 
+	typeParameterList := parseTypeParameterList(p)
+
 	parameterList, returnTypeAnnotation, functionBlock :=
 		parseFunctionParameterListAndRest(p, functionBlockIsOptional)
 
 	return &ast.FunctionDeclaration{
 		Access:               access,
+		Purity:               purity,
 		Identifier:           identifier,
+		TypeParameterList:    typeParameterList,
 		ParameterList:        parameterList,
 		ReturnTypeAnnotation: returnTypeAnnotation,
 		FunctionBlock:        functionBlock,
@@ -245,3 +360,19 @@ func parseFunctionParameterListAndRest(
 	}
 	return
 }
+
+// isFunKeywordAhead checks whether the next token which is not a space or comment
+// is the `fun` keyword, optionally skipping the current token first.
+// No tokens are consumed.
+//
+func isFunKeywordAhead(p *parser, skipCurrent bool) bool {
+	p.startBuffering()
+	defer p.replayBuffered()
+
+	if skipCurrent {
+		p.next()
+	}
+	p.skipSpaceAndComments(true)
+
+	return p.current.IsString(lexer.TokenIdentifier, keywordFun)
+}
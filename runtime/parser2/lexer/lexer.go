@@ -114,14 +114,32 @@ func (l *lexer) Input() string {
 }
 
 func Lex(input string) TokenStream {
+	return LexFrom(
+		input,
+		ast.Position{
+			Offset: 0,
+			Line:   1,
+			Column: 0,
+		},
+	)
+}
+
+// LexFrom is like Lex, but starts scanning the given input at the given position,
+// e.g. to scan the expressions of a string interpolation in the original input
+//
+func LexFrom(input string, pos ast.Position) TokenStream {
 	ctx, cancelLexer := context.WithCancel(context.Background())
 	l := &lexer{
-		ctx:           ctx,
-		cancelLexer:   cancelLexer,
-		input:         input,
-		startPos:      position{line: 1},
-		endOffset:     0,
-		prevEndOffset: 0,
+		ctx:         ctx,
+		cancelLexer: cancelLexer,
+		input:       input,
+		startPos: position{
+			line:   pos.Line,
+			column: pos.Column,
+		},
+		startOffset:   pos.Offset,
+		endOffset:     pos.Offset,
+		prevEndOffset: pos.Offset,
 		current:       EOF,
 		prev:          EOF,
 		tokens:        make(chan Token),
@@ -193,6 +211,19 @@ func (l *lexer) next() rune {
 	return r
 }
 
+// peek decodes the next rune (UTF8 character) from the input string,
+// without advancing.
+//
+// It returns EOF if it reaches the end of the file,
+// otherwise returns the next rune.
+func (l *lexer) peek() rune {
+	if l.endOffset >= len(l.input) {
+		return EOF
+	}
+	r, _ := utf8.DecodeRuneInString(l.input[l.endOffset:])
+	return r
+}
+
 // backupOne steps back one rune.
 // Can be called only once per call of next.
 func (l *lexer) backupOne() {
@@ -360,25 +391,62 @@ func (l *lexer) acceptWhile(f func(rune) bool) {
 	}
 }
 
-func (l *lexer) scanString(quote rune) {
+// scanString scans the remainder of a string literal, including string interpolations.
+// It returns true if the string is terminated by the given quote.
+//
+func (l *lexer) scanString(quote rune) bool {
 	r := l.next()
 	for r != quote {
 		switch r {
 		case '\n', EOF:
 			// NOTE: invalid end of string handled by parser
 			l.backupOne()
-			return
+			return false
 		case '\\':
 			r = l.next()
 			switch r {
 			case '\n', EOF:
 				// NOTE: invalid end of string handled by parser
 				l.backupOne()
-				return
+				return false
+			case '(':
+				if !l.scanStringInterpolation() {
+					return false
+				}
 			}
 		}
 		r = l.next()
 	}
+	return true
+}
+
+// scanStringInterpolation scans the remainder of a string interpolation,
+// i.e. the interpolated expression and the closing parenthesis.
+// The expression may contain nested parentheses and nested string literals.
+// It returns true if the interpolation is terminated by a closing parenthesis.
+//
+func (l *lexer) scanStringInterpolation() bool {
+	depth := 1
+	for {
+		r := l.next()
+		switch r {
+		case '\n', EOF:
+			// NOTE: invalid end of string handled by parser
+			l.backupOne()
+			return false
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return true
+			}
+		case '"':
+			if !l.scanString('"') {
+				return false
+			}
+		}
+	}
 }
 
 func (l *lexer) scanBinaryRemainder() {
@@ -405,7 +473,9 @@ func (l *lexer) scanHexadecimalRemainder() {
 func (l *lexer) scanDecimalOrFixedPointRemainder() TokenType {
 	l.acceptWhile(isDecimalDigitOrUnderscore)
 	r := l.next()
-	if r == '.' {
+	// The dot might be the start of a range operator,
+	// e.g. `1..<10`, and not of the fractional part
+	if r == '.' && l.peek() != '.' {
 		l.scanFixedPointRemainder()
 		return TokenFixedPointNumberLiteral
 	} else {
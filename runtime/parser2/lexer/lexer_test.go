@@ -442,6 +442,75 @@ func TestLexBasic(t *testing.T) {
 		)
 	})
 
+	t.Run("ranges", func(t *testing.T) {
+		testLex(t,
+			"0..<10 1...2",
+			[]Token{
+				{
+					Type:  TokenDecimalIntegerLiteral,
+					Value: "0",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 0, Offset: 0},
+					},
+				},
+				{
+					Type: TokenDotDotLess,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 3, Offset: 3},
+					},
+				},
+				{
+					Type:  TokenDecimalIntegerLiteral,
+					Value: "10",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 4, Offset: 4},
+						EndPos:   ast.Position{Line: 1, Column: 5, Offset: 5},
+					},
+				},
+				{
+					Type:  TokenSpace,
+					Value: Space{" ", false},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 6, Offset: 6},
+						EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+					},
+				},
+				{
+					Type:  TokenDecimalIntegerLiteral,
+					Value: "1",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+						EndPos:   ast.Position{Line: 1, Column: 7, Offset: 7},
+					},
+				},
+				{
+					Type: TokenDotDotDot,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+						EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+					},
+				},
+				{
+					Type:  TokenDecimalIntegerLiteral,
+					Value: "2",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 11, Offset: 11},
+						EndPos:   ast.Position{Line: 1, Column: 11, Offset: 11},
+					},
+				},
+				{
+					Type: TokenEOF,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 12, Offset: 12},
+						EndPos:   ast.Position{Line: 1, Column: 12, Offset: 12},
+					},
+				},
+			},
+		)
+	})
+
 	t.Run("identifier", func(t *testing.T) {
 		testLex(t,
 			"test",
@@ -898,6 +967,52 @@ func TestLexString(t *testing.T) {
 			},
 		)
 	})
+
+	t.Run("valid, with interpolation", func(t *testing.T) {
+		testLex(t,
+			`"a \(b("c)")) d"`,
+			[]Token{
+				{
+					Type:  TokenString,
+					Value: `"a \(b("c)")) d"`,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 15, Offset: 15},
+					},
+				},
+				{
+					Type: TokenEOF,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 16, Offset: 16},
+						EndPos:   ast.Position{Line: 1, Column: 16, Offset: 16},
+					},
+				},
+			},
+		)
+	})
+
+	t.Run("invalid, missing end of interpolation", func(t *testing.T) {
+		testLex(t,
+			`"a \(b"`,
+			[]Token{
+				{
+					Type:  TokenString,
+					Value: `"a \(b"`,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+					},
+				},
+				{
+					Type: TokenEOF,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+						EndPos:   ast.Position{Line: 1, Column: 7, Offset: 7},
+					},
+				},
+			},
+		)
+	})
 }
 
 func TestLexBlockComment(t *testing.T) {
@@ -66,7 +66,20 @@ func rootState(l *lexer) stateFn {
 		case ':':
 			l.emitType(TokenColon)
 		case '.':
-			l.emitType(TokenDot)
+			if l.acceptOne('.') {
+				r = l.next()
+				switch r {
+				case '.':
+					l.emitType(TokenDotDotDot)
+				case '<':
+					l.emitType(TokenDotDotLess)
+				default:
+					l.backupOne()
+					return l.error(fmt.Errorf("invalid range operator: expected '...' or '..<'"))
+				}
+			} else {
+				l.emitType(TokenDot)
+			}
 		case '=':
 			if l.acceptOne('=') {
 				l.emitType(TokenEqualEqual)
@@ -215,8 +228,15 @@ func numberState(l *lexer) stateFn {
 			l.emitValue(tokenType)
 
 		case '.':
-			l.scanFixedPointRemainder()
-			l.emitValue(TokenFixedPointNumberLiteral)
+			// The dot might be the start of a range operator,
+			// e.g. `0..<10`, and not of the fractional part
+			if l.peek() == '.' {
+				l.backupOne()
+				l.emitValue(TokenDecimalIntegerLiteral)
+			} else {
+				l.scanFixedPointRemainder()
+				l.emitValue(TokenFixedPointNumberLiteral)
+			}
 
 		case EOF:
 			l.backupOne()
@@ -81,6 +81,8 @@ const (
 	TokenAsExclamationMark
 	TokenAsQuestionMark
 	TokenPragma
+	TokenDotDotDot
+	TokenDotDotLess
 	// NOTE: not an actual token, must be last item
 	TokenMax
 )
@@ -202,6 +204,10 @@ func (t TokenType) String() string {
 		return `'as?'`
 	case TokenPragma:
 		return `'#'`
+	case TokenDotDotDot:
+		return `'...'`
+	case TokenDotDotLess:
+		return `'..<'`
 	default:
 		panic(errors.NewUnreachableError())
 	}
@@ -98,7 +98,17 @@ func parseStatement(p *parser) ast.Statement {
 		case keywordFun:
 			// The `fun` keyword is ambiguous: it either introduces a function expression
 			// or a function declaration, depending on if an identifier follows, or not.
-			return parseFunctionDeclarationOrFunctionExpressionStatement(p)
+			return parseFunctionDeclarationOrFunctionExpressionStatement(p, ast.FunctionPurityUnspecified, nil)
+		case keywordView:
+			// `view` is not a reserved keyword,
+			// so it is only a modifier if it is followed by `fun`
+			if isFunKeywordAhead(p, true) {
+				purityPos := p.current.StartPos
+				// Skip the `view` keyword
+				p.next()
+				p.skipSpaceAndComments(true)
+				return parseFunctionDeclarationOrFunctionExpressionStatement(p, ast.FunctionPurityView, &purityPos)
+			}
 		}
 	}
 
@@ -148,9 +158,16 @@ func parseStatement(p *parser) ast.Statement {
 	}
 }
 
-func parseFunctionDeclarationOrFunctionExpressionStatement(p *parser) ast.Statement {
+func parseFunctionDeclarationOrFunctionExpressionStatement(
+	p *parser,
+	purity ast.FunctionPurity,
+	purityPos *ast.Position,
+) ast.Statement {
 
 	startPos := p.current.StartPos
+	if purityPos != nil {
+		startPos = *purityPos
+	}
 
 	// Skip the `fun` keyword
 	p.next()
@@ -167,6 +184,7 @@ func parseFunctionDeclarationOrFunctionExpressionStatement(p *parser) ast.Statem
 
 		return &ast.FunctionDeclaration{
 			Access:               ast.AccessNotSpecified,
+			Purity:               purity,
 			Identifier:           identifier,
 			ParameterList:        parameterList,
 			ReturnTypeAnnotation: returnTypeAnnotation,
@@ -179,6 +197,7 @@ func parseFunctionDeclarationOrFunctionExpressionStatement(p *parser) ast.Statem
 
 		return &ast.ExpressionStatement{
 			Expression: &ast.FunctionExpression{
+				Purity:               purity,
 				ParameterList:        parameterList,
 				ReturnTypeAnnotation: returnTypeAnnotation,
 				FunctionBlock:        functionBlock,
@@ -348,6 +367,22 @@ func parseForStatement(p *parser) *ast.ForStatement {
 
 	p.skipSpaceAndComments(true)
 
+	// If there is a comma, the first identifier is the index (or key),
+	// and the second identifier is the element (or value)
+
+	var index *ast.Identifier
+
+	if p.current.Is(lexer.TokenComma) {
+		p.next()
+		p.skipSpaceAndComments(true)
+
+		firstIdentifier := identifier
+		index = &firstIdentifier
+		identifier = mustIdentifier(p)
+
+		p.skipSpaceAndComments(true)
+	}
+
 	if !p.current.IsString(lexer.TokenIdentifier, keywordIn) {
 		p.report(fmt.Errorf(
 			"expected keyword %q, got %s",
@@ -363,6 +398,7 @@ func parseForStatement(p *parser) *ast.ForStatement {
 	block := parseBlock(p)
 
 	return &ast.ForStatement{
+		Index:      index,
 		Identifier: identifier,
 		Block:      block,
 		Value:      expression,
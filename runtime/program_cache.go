@@ -0,0 +1,230 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+type programCacheKey struct {
+	location common.LocationID
+	codeHash [sha256.Size]byte
+}
+
+func newProgramCacheKey(location common.Location, code []byte) programCacheKey {
+	return programCacheKey{
+		location: location.ID(),
+		codeHash: sha256.Sum256(code),
+	}
+}
+
+// programDependency is a program which a cached program imports, directly or indirectly,
+// identified by its location and the hash of its code at the time the cached program was checked
+//
+type programDependency struct {
+	location common.Location
+	codeHash [sha256.Size]byte
+}
+
+func newProgramDependency(location common.Location, code []byte) programDependency {
+	return programDependency{
+		location: location,
+		codeHash: sha256.Sum256(code),
+	}
+}
+
+// DefaultProgramCacheSize is the default maximum number of programs in the program cache
+//
+const DefaultProgramCacheSize = 1000
+
+type cachedProgram struct {
+	key          programCacheKey
+	program      *interpreter.Program
+	dependencies []programDependency
+}
+
+// programCache is a cache of parsed and checked programs, keyed by location and code hash.
+// It is safe for concurrent use.
+//
+// Cached programs are shared between executions, and must not be modified.
+//
+// The elaboration of a program refers to the types of its imports,
+// so a cached program is only valid as long as the code of its imports is unchanged.
+// The cache records the code hashes of all imports of a program, its dependencies,
+// which must be compared against the current code of the imports when the program is used.
+//
+// When the code at a location is changed by the runtime, the cached programs of the location,
+// and the cached programs which (indirectly) import the location, are invalidated eagerly.
+//
+// The cache holds at most a given number of programs.
+// When the cache is full, the least recently used program is evicted
+//
+type programCache struct {
+	lock     sync.Mutex
+	size     int
+	programs map[programCacheKey]*list.Element
+	// recency is the list of cached programs, the most recently used program first.
+	// The values of the elements are cachedProgram values
+	recency *list.List
+}
+
+// newProgramCache returns a new program cache which holds at most the given number of programs.
+// A size of 0 or less disables caching
+//
+func newProgramCache(size int) *programCache {
+	return &programCache{
+		size:     size,
+		programs: map[programCacheKey]*list.Element{},
+		recency:  list.New(),
+	}
+}
+
+// SetSize sets the maximum number of cached programs,
+// and evicts the least recently used programs which exceed the new size
+//
+func (c *programCache) SetSize(size int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.size = size
+	c.evict()
+}
+
+// Get returns the cached program for the given code at the given location,
+// and the dependencies of the program, or nil if there is no cached program
+//
+func (c *programCache) Get(location common.Location, code []byte) (*interpreter.Program, []programDependency) {
+	key := newProgramCacheKey(location, code)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	element, ok := c.programs[key]
+	if !ok {
+		return nil, nil
+	}
+
+	c.recency.MoveToFront(element)
+
+	cached := element.Value.(cachedProgram)
+	return cached.program, cached.dependencies
+}
+
+// Set caches the program for the given code at the given location,
+// together with the dependencies of the program
+//
+func (c *programCache) Set(
+	location common.Location,
+	code []byte,
+	program *interpreter.Program,
+	dependencies []programDependency,
+) {
+	key := newProgramCacheKey(location, code)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	cached := cachedProgram{
+		key:          key,
+		program:      program,
+		dependencies: dependencies,
+	}
+
+	if element, ok := c.programs[key]; ok {
+		element.Value = cached
+		c.recency.MoveToFront(element)
+		return
+	}
+
+	c.programs[key] = c.recency.PushFront(cached)
+	c.evict()
+}
+
+// evict removes the least recently used programs until the cache does not exceed its size.
+// The lock must be held
+//
+func (c *programCache) evict() {
+	for c.recency.Len() > 0 && c.recency.Len() > c.size {
+		c.remove(c.recency.Back())
+	}
+}
+
+// remove removes the given cached program element.
+// The lock must be held
+//
+func (c *programCache) remove(element *list.Element) {
+	cached := c.recency.Remove(element).(cachedProgram)
+	delete(c.programs, cached.key)
+}
+
+// Invalidate removes all cached programs of the given location,
+// and all cached programs which import the location, directly or indirectly
+//
+func (c *programCache) Invalidate(location common.Location) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	invalidated := map[common.LocationID]bool{
+		location.ID(): true,
+	}
+
+	for {
+		var invalidatedImporter bool
+
+		for key, element := range c.programs {
+			if invalidated[key.location] {
+				c.remove(element)
+				continue
+			}
+
+			cached := element.Value.(cachedProgram)
+			if !importsAny(cached.program, invalidated) {
+				continue
+			}
+
+			invalidated[key.location] = true
+			invalidatedImporter = true
+			c.remove(element)
+		}
+
+		// Importers of newly invalidated locations have to be invalidated, too
+
+		if !invalidatedImporter {
+			break
+		}
+	}
+}
+
+// importsAny returns true if the given program imports any of the given locations
+//
+func importsAny(program *interpreter.Program, locations map[common.LocationID]bool) bool {
+	for _, resolvedLocations := range program.Elaboration.ImportDeclarationsResolvedLocations {
+		for _, resolvedLocation := range resolvedLocations {
+			if locations[resolvedLocation.Location.ID()] {
+				return true
+			}
+		}
+	}
+	return false
+}
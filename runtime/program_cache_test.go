@@ -0,0 +1,501 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/checker"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestProgramCacheInvalidation(t *testing.T) {
+
+	t.Parallel()
+
+	newProgram := func(t *testing.T, code string, imports ...common.Location) *interpreter.Program {
+		program, err := parser2.ParseProgram(code)
+		require.NoError(t, err)
+
+		elaboration := sema.NewElaboration()
+		for i, declaration := range program.ImportDeclarations() {
+			elaboration.ImportDeclarationsResolvedLocations[declaration] = []sema.ResolvedLocation{
+				{Location: imports[i]},
+			}
+		}
+
+		return &interpreter.Program{
+			Program:     program,
+			Elaboration: elaboration,
+		}
+	}
+
+	address := common.BytesToAddress([]byte{0x1})
+
+	locationA := common.AddressLocation{Address: address, Name: "A"}
+	locationB := common.AddressLocation{Address: address, Name: "B"}
+	locationC := common.AddressLocation{Address: address, Name: "C"}
+	locationD := common.AddressLocation{Address: address, Name: "D"}
+
+	codeA := []byte(`pub contract A {}`)
+	codeB := []byte(`import A from 0x1`)
+	codeC := []byte(`import B from 0x1`)
+	codeD := []byte(`pub contract D {}`)
+
+	cache := newProgramCache(DefaultProgramCacheSize)
+
+	getProgram := func(location common.Location, code []byte) *interpreter.Program {
+		program, _ := cache.Get(location, code)
+		return program
+	}
+
+	dependencyA := newProgramDependency(locationA, codeA)
+	dependencyB := newProgramDependency(locationB, codeB)
+
+	programA := newProgram(t, string(codeA))
+	cache.Set(locationA, codeA, programA, nil)
+	cache.Set(locationB, codeB, newProgram(t, string(codeB), locationA), []programDependency{dependencyA})
+	cache.Set(locationC, codeC, newProgram(t, string(codeC), locationB), []programDependency{dependencyB, dependencyA})
+	cache.Set(locationD, codeD, newProgram(t, string(codeD)), nil)
+
+	assert.Same(t, programA, getProgram(locationA, codeA))
+
+	_, dependencies := cache.Get(locationC, codeC)
+	assert.Equal(t, []programDependency{dependencyB, dependencyA}, dependencies)
+
+	// Different code at the same location is not cached
+
+	assert.Nil(t, getProgram(locationA, []byte(`pub contract A { pub let x: Int }`)))
+
+	// Invalidating A invalidates its direct and indirect importers,
+	// but no other programs
+
+	cache.Invalidate(locationA)
+
+	assert.Nil(t, getProgram(locationA, codeA))
+	assert.Nil(t, getProgram(locationB, codeB))
+	assert.Nil(t, getProgram(locationC, codeC))
+	assert.NotNil(t, getProgram(locationD, codeD))
+}
+
+func TestProgramCacheEviction(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.BytesToAddress([]byte{0x1})
+
+	newEntry := func(name string) (common.Location, []byte, *interpreter.Program) {
+		location := common.AddressLocation{Address: address, Name: name}
+		code := []byte(fmt.Sprintf(`pub contract %s {}`, name))
+		return location, code, &interpreter.Program{}
+	}
+
+	locationA, codeA, programA := newEntry("A")
+	locationB, codeB, programB := newEntry("B")
+	locationC, codeC, programC := newEntry("C")
+
+	cache := newProgramCache(2)
+
+	getProgram := func(location common.Location, code []byte) *interpreter.Program {
+		program, _ := cache.Get(location, code)
+		return program
+	}
+
+	cache.Set(locationA, codeA, programA, nil)
+	cache.Set(locationB, codeB, programB, nil)
+
+	// Using A makes B the least recently used program,
+	// so it is evicted when C is cached
+
+	assert.Same(t, programA, getProgram(locationA, codeA))
+
+	cache.Set(locationC, codeC, programC, nil)
+
+	assert.Same(t, programA, getProgram(locationA, codeA))
+	assert.Nil(t, getProgram(locationB, codeB))
+	assert.Same(t, programC, getProgram(locationC, codeC))
+
+	// Shrinking the cache evicts the least recently used programs
+
+	cache.SetSize(1)
+
+	assert.Nil(t, getProgram(locationA, codeA))
+	assert.Same(t, programC, getProgram(locationC, codeC))
+
+	// A size of 0 disables caching
+
+	cache.SetSize(0)
+
+	cache.Set(locationA, codeA, programA, nil)
+
+	assert.Nil(t, getProgram(locationA, codeA))
+	assert.Nil(t, getProgram(locationC, codeC))
+}
+
+func TestRuntimeProgramCache(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := NewInterpreterRuntime()
+
+	address := common.BytesToAddress([]byte{0x1})
+
+	contract := func(answer int) []byte {
+		return []byte(fmt.Sprintf(
+			`
+              pub contract Foo {
+                  pub fun answer(): Int {
+                      return %d
+                  }
+              }
+            `,
+			answer,
+		))
+	}
+
+	script := []byte(`
+      import Foo from 0x1
+
+      pub fun main(): Int {
+          return Foo.answer()
+      }
+    `)
+
+	var contractCodeLock sync.RWMutex
+	contractCode := map[string][]byte{}
+
+	storage := newTestStorage(nil, nil)
+
+	var parseCount int64
+
+	// The host environment does not cache programs
+
+	newRuntimeInterface := func() *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: storage,
+			getProgram: func(_ Location) (*interpreter.Program, error) {
+				return nil, nil
+			},
+			setProgram: func(_ Location, _ *interpreter.Program) error {
+				return nil
+			},
+			resolveLocation: singleIdentifierLocationResolver(t),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+				contractCodeLock.RLock()
+				defer contractCodeLock.RUnlock()
+
+				return contractCode[name], nil
+			},
+			updateAccountContractCode: func(_ Address, name string, code []byte) error {
+				contractCodeLock.Lock()
+				defer contractCodeLock.Unlock()
+
+				contractCode[name] = code
+				return nil
+			},
+			emitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+			programParsed: func(location common.Location, _ time.Duration) {
+				if location == (common.AddressLocation{Address: address, Name: "Foo"}) {
+					atomic.AddInt64(&parseCount, 1)
+				}
+			},
+		}
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: utils.DeploymentTransaction("Foo", contract(1)),
+		},
+		Context{
+			Interface: newRuntimeInterface(),
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	executeScript := func() (cadence.Value, error) {
+		return runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: newRuntimeInterface(),
+				Location:  common.ScriptLocation{},
+			},
+		)
+	}
+
+	// The imported contract is only parsed and checked once
+
+	atomic.StoreInt64(&parseCount, 0)
+
+	result, err := executeScript()
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewInt(1), result)
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&parseCount))
+
+	// Execute the script in parallel, using the cached program
+
+	const concurrency = 16
+
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+
+			result, err := executeScript()
+			if assert.NoError(t, err) {
+				assert.Equal(t, cadence.NewInt(1), result)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&parseCount))
+
+	// Updating the contract invalidates the cached program
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(fmt.Sprintf(
+				`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.contracts.update__experimental(name: "Foo", code: "%s".decodeHex())
+                      }
+                  }
+                `,
+				hex.EncodeToString(contract(2)),
+			)),
+		},
+		Context{
+			Interface: newRuntimeInterface(),
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	atomic.StoreInt64(&parseCount, 0)
+
+	result, err = executeScript()
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewInt(2), result)
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&parseCount))
+}
+
+func TestRuntimeProgramCacheChangedImport(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := NewInterpreterRuntime()
+
+	address := common.BytesToAddress([]byte{0x1})
+
+	fooContract := func(returnType string, answer string) []byte {
+		return []byte(fmt.Sprintf(
+			`
+              pub contract Foo {
+                  pub fun answer(): %s {
+                      return %s
+                  }
+              }
+            `,
+			returnType,
+			answer,
+		))
+	}
+
+	barContract := []byte(`
+      import Foo from 0x1
+
+      pub contract Bar {
+          pub fun answer(): Int {
+              return Foo.answer()
+          }
+      }
+    `)
+
+	script := []byte(`
+      import Bar from 0x1
+
+      pub fun main(): Int {
+          return Bar.answer()
+      }
+    `)
+
+	var contractCodeLock sync.RWMutex
+	contractCode := map[string][]byte{}
+
+	storage := newTestStorage(nil, nil)
+
+	var barParseCount int64
+
+	// The host environment does not cache programs
+
+	newRuntimeInterface := func() *testRuntimeInterface {
+		return &testRuntimeInterface{
+			storage: storage,
+			getProgram: func(_ Location) (*interpreter.Program, error) {
+				return nil, nil
+			},
+			setProgram: func(_ Location, _ *interpreter.Program) error {
+				return nil
+			},
+			resolveLocation: singleIdentifierLocationResolver(t),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			getAccountContractCode: func(_ Address, name string) ([]byte, error) {
+				contractCodeLock.RLock()
+				defer contractCodeLock.RUnlock()
+
+				return contractCode[name], nil
+			},
+			updateAccountContractCode: func(_ Address, name string, code []byte) error {
+				contractCodeLock.Lock()
+				defer contractCodeLock.Unlock()
+
+				contractCode[name] = code
+				return nil
+			},
+			emitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+			programParsed: func(location common.Location, _ time.Duration) {
+				if location == (common.AddressLocation{Address: address, Name: "Bar"}) {
+					atomic.AddInt64(&barParseCount, 1)
+				}
+			},
+		}
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	for _, contract := range []struct {
+		name string
+		code []byte
+	}{
+		{"Foo", fooContract("Int", "1")},
+		{"Bar", barContract},
+	} {
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: utils.DeploymentTransaction(contract.name, contract.code),
+			},
+			Context{
+				Interface: newRuntimeInterface(),
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	executeScript := func() (cadence.Value, error) {
+		return runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: newRuntimeInterface(),
+				Location:  common.ScriptLocation{},
+			},
+		)
+	}
+
+	// Changes the code of the imported contract directly,
+	// without going through the runtime, e.g. like another runtime would
+
+	setFooContract := func(code []byte) {
+		contractCodeLock.Lock()
+		defer contractCodeLock.Unlock()
+
+		contractCode["Foo"] = code
+	}
+
+	atomic.StoreInt64(&barParseCount, 0)
+
+	result, err := executeScript()
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewInt(1), result)
+
+	result, err = executeScript()
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewInt(1), result)
+
+	// The importing contract is only parsed and checked once
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&barParseCount))
+
+	// Changing the imported contract requires the importing contract to be checked again
+
+	setFooContract(fooContract("Int", "2"))
+
+	atomic.StoreInt64(&barParseCount, 0)
+
+	result, err = executeScript()
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewInt(2), result)
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&barParseCount))
+
+	// The importing contract is invalid for the changed imported contract
+
+	setFooContract(fooContract("String", `"3"`))
+
+	_, err = executeScript()
+	require.Error(t, err)
+
+	var checkerErr *sema.CheckerError
+	require.ErrorAs(t, err, &checkerErr)
+
+	errs := checker.ExpectCheckerErrors(t, checkerErr, 2)
+	require.IsType(t, &sema.ImportedProgramError{}, errs[0])
+	assert.IsType(t, &sema.NotDeclaredError{}, errs[1])
+
+	var importedCheckerErr *sema.CheckerError
+	require.ErrorAs(t, errs[0].(*sema.ImportedProgramError).Err, &importedCheckerErr)
+
+	importedErrs := checker.ExpectCheckerErrors(t, importedCheckerErr, 1)
+	assert.IsType(t, &sema.TypeMismatchError{}, importedErrs[0])
+}
@@ -353,6 +353,13 @@ func TestScriptParameterTypeValidation(t *testing.T) {
 					},
 				).WithType(SignAlgoType)
 
+			case sema.RoundingRuleType:
+				value = cadence.NewEnum(
+					[]cadence.Value{
+						cadence.NewUInt8(0),
+					},
+				).WithType(ExportedBuiltinType(sema.RoundingRuleType).(*cadence.EnumType))
+
 			case sema.PublicKeyType:
 				value = cadence.NewStruct(
 					[]cadence.Value{
@@ -834,6 +841,13 @@ func TestTransactionParameterTypeValidation(t *testing.T) {
 					},
 				).WithType(SignAlgoType)
 
+			case sema.RoundingRuleType:
+				value = cadence.NewEnum(
+					[]cadence.Value{
+						cadence.NewUInt8(0),
+					},
+				).WithType(ExportedBuiltinType(sema.RoundingRuleType).(*cadence.EnumType))
+
 			case sema.PublicKeyType:
 				value = cadence.NewStruct(
 					[]cadence.Value{
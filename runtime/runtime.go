@@ -19,6 +19,7 @@
 package runtime
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"math"
@@ -85,6 +86,12 @@ type Runtime interface {
 	//
 	SetContractUpdateValidationEnabled(enabled bool)
 
+	// SetProgramCacheSize configures the maximum number of parsed and checked programs
+	// which are cached across executions. A size of 0 or less disables caching.
+	// The default is DefaultProgramCacheSize.
+	//
+	SetProgramCacheSize(size int)
+
 	// ReadStored reads the value stored at the given path
 	//
 	ReadStored(address common.Address, path cadence.Path, context Context) (cadence.Value, error)
@@ -105,6 +112,8 @@ var validTopLevelDeclarationsInTransaction = []common.DeclarationKind{
 	common.DeclarationKindImport,
 	common.DeclarationKindFunction,
 	common.DeclarationKindTransaction,
+	common.DeclarationKindTypeAlias,
+	common.DeclarationKindExtension,
 }
 
 var validTopLevelDeclarationsInAccountCode = []common.DeclarationKind{
@@ -112,6 +121,7 @@ var validTopLevelDeclarationsInAccountCode = []common.DeclarationKind{
 	common.DeclarationKindImport,
 	common.DeclarationKindContract,
 	common.DeclarationKindContractInterface,
+	common.DeclarationKindTypeAlias,
 }
 
 func validTopLevelDeclarations(location common.Location) []common.DeclarationKind {
@@ -144,9 +154,14 @@ func reportMetric(
 }
 
 // interpreterRuntime is a interpreter-based version of the Flow runtime.
+//
+// The runtime is safe for concurrent use, e.g. multiple scripts may be executed in parallel.
+//
 type interpreterRuntime struct {
 	coverageReport                  *CoverageReport
 	contractUpdateValidationEnabled bool
+	// programs is the cache of imported programs, shared by all executions
+	programs *programCache
 }
 
 type Option func(Runtime)
@@ -160,9 +175,20 @@ func WithContractUpdateValidationEnabled(enabled bool) Option {
 	}
 }
 
+// WithProgramCacheSize returns a runtime option
+// that configures the maximum number of cached programs.
+//
+func WithProgramCacheSize(size int) Option {
+	return func(runtime Runtime) {
+		runtime.SetProgramCacheSize(size)
+	}
+}
+
 // NewInterpreterRuntime returns a interpreter-based version of the Flow runtime.
 func NewInterpreterRuntime(options ...Option) Runtime {
-	runtime := &interpreterRuntime{}
+	runtime := &interpreterRuntime{
+		programs: newProgramCache(DefaultProgramCacheSize),
+	}
 	for _, option := range options {
 		option(runtime)
 	}
@@ -177,6 +203,10 @@ func (r *interpreterRuntime) SetContractUpdateValidationEnabled(enabled bool) {
 	r.contractUpdateValidationEnabled = enabled
 }
 
+func (r *interpreterRuntime) SetProgramCacheSize(size int) {
+	r.programs.SetSize(size)
+}
+
 func (r *interpreterRuntime) ExecuteScript(script Script, context Context) (cadence.Value, error) {
 	context.InitializeCodesAndPrograms()
 
@@ -1063,6 +1093,7 @@ func (r *interpreterRuntime) newInterpreter(
 		interpreter.WithOnStatementHandler(
 			r.onStatementHandler(),
 		),
+		interpreter.WithContext(context.ExecutionContext),
 		interpreter.WithAccountHandlerFunc(
 			func(address interpreter.AddressValue) *interpreter.CompositeValue {
 				return r.getPublicAccount(address, context.Interface, runtimeStorage)
@@ -1192,17 +1223,68 @@ func (r *interpreterRuntime) getProgram(
 			return nil, err
 		}
 
-		program, err = r.parseAndCheckProgram(
-			code,
-			context,
-			functions,
-			values,
-			checkerOptions,
-			true,
-			checkedImports,
-		)
-		if err != nil {
-			return nil, err
+		// Programs are only shared between executions
+		// if they are checked in the same environment
+
+		cacheable := len(context.PredeclaredValues) == 0 &&
+			len(checkerOptions) == 0
+
+		if cacheable {
+			var dependencies []programDependency
+			program, dependencies = r.programs.Get(context.Location, code)
+
+			// The cached program is only valid if the code of its imports is unchanged.
+			// The imports might have been changed without invalidating the cache,
+			// e.g. by another runtime, or at a different block height
+
+			if program != nil {
+				var unchanged bool
+				unchanged, err = r.programDependenciesUnchanged(context, dependencies)
+				if err != nil {
+					return nil, err
+				}
+
+				if !unchanged {
+					program = nil
+				}
+			}
+		}
+
+		if program != nil {
+			context.SetCode(context.Location, string(code))
+
+			wrapPanic(func() {
+				err = context.Interface.SetProgram(context.Location, program)
+			})
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			program, err = r.parseAndCheckProgram(
+				code,
+				context,
+				functions,
+				values,
+				checkerOptions,
+				true,
+				checkedImports,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			if cacheable {
+				var dependencies []programDependency
+				var known bool
+				dependencies, known, err = r.programDependencies(context, program)
+				if err != nil {
+					return nil, err
+				}
+
+				if known {
+					r.programs.Set(context.Location, code, program, dependencies)
+				}
+			}
 		}
 	}
 
@@ -1211,6 +1293,82 @@ func (r *interpreterRuntime) getProgram(
 	return program, nil
 }
 
+// programDependencies returns the locations and code hashes of all programs
+// which the given program imports, directly or indirectly.
+//
+// The dependencies of an imported program are only known if the imported program is cached.
+// If the dependencies of any imported program are unknown, known is false
+//
+func (r *interpreterRuntime) programDependencies(
+	context Context,
+	program *interpreter.Program,
+) (
+	dependencies []programDependency,
+	known bool,
+	err error,
+) {
+	seen := map[common.LocationID]bool{}
+
+	for _, resolvedLocations := range program.Elaboration.ImportDeclarationsResolvedLocations {
+		for _, resolvedLocation := range resolvedLocations {
+			location := resolvedLocation.Location
+
+			if location == stdlib.CryptoChecker.Location || seen[location.ID()] {
+				continue
+			}
+			seen[location.ID()] = true
+
+			var code []byte
+			code, err = r.getCode(context.WithLocation(location))
+			if err != nil {
+				return nil, false, err
+			}
+
+			importedProgram, importedDependencies := r.programs.Get(location, code)
+			if importedProgram == nil {
+				return nil, false, nil
+			}
+
+			dependencies = append(dependencies, newProgramDependency(location, code))
+
+			for _, dependency := range importedDependencies {
+				if seen[dependency.location.ID()] {
+					continue
+				}
+				seen[dependency.location.ID()] = true
+
+				dependencies = append(dependencies, dependency)
+			}
+		}
+	}
+
+	return dependencies, true, nil
+}
+
+// programDependenciesUnchanged returns true if the current code of all given dependencies
+// is the same as the code the dependent program was checked with
+//
+func (r *interpreterRuntime) programDependenciesUnchanged(
+	context Context,
+	dependencies []programDependency,
+) (
+	bool,
+	error,
+) {
+	for _, dependency := range dependencies {
+		code, err := r.getCode(context.WithLocation(dependency.location))
+		if err != nil {
+			return false, err
+		}
+
+		if sha256.Sum256(code) != dependency.codeHash {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func (r *interpreterRuntime) injectedCompositeFieldsHandler(
 	context Context,
 	runtimeStorage *runtimeStorage,
@@ -1479,6 +1637,8 @@ func (r *interpreterRuntime) newCreateAccountFunction(
 ) interpreter.HostFunction {
 	return func(invocation interpreter.Invocation) interpreter.Value {
 
+		invocation.Interpreter.ExpectNoSideEffects("account creation", invocation.GetLocationRange)
+
 		payer := invocation.Arguments[0].(*interpreter.CompositeValue)
 
 		if payer.QualifiedIdentifier() != sema.AuthAccountType.QualifiedIdentifier() {
@@ -1569,8 +1729,10 @@ func storageUsedGetFunction(
 	return func(inter *interpreter.Interpreter) interpreter.UInt64Value {
 
 		// NOTE: flush the cached values, so the host environment
-		// can properly calculate the amount of storage used by the account
-		err := runtimeStorage.writeCached(inter)
+		// can properly calculate the amount of storage used by the account.
+		// Segments which are no longer referenced are only removed at the end of the transaction,
+		// as values which are not stored, e.g. loaded values, might still reference them
+		err := runtimeStorage.writeValues(inter)
 		if err != nil {
 			panic(err)
 		}
@@ -1607,6 +1769,8 @@ func (r *interpreterRuntime) newAddPublicKeyFunction(
 ) *interpreter.HostFunctionValue {
 	return interpreter.NewHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
+			invocation.Interpreter.ExpectNoSideEffects("addition of public key", invocation.GetLocationRange)
+
 			publicKeyValue := invocation.Arguments[0].(*interpreter.ArrayValue)
 
 			publicKey, err := interpreter.ByteArrayValueToByteSlice(publicKeyValue)
@@ -1642,6 +1806,8 @@ func (r *interpreterRuntime) newRemovePublicKeyFunction(
 ) *interpreter.HostFunctionValue {
 	return interpreter.NewHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
+			invocation.Interpreter.ExpectNoSideEffects("removal of public key", invocation.GetLocationRange)
+
 			index := invocation.Arguments[0].(interpreter.IntValue)
 
 			var publicKey []byte
@@ -2065,6 +2231,8 @@ func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
 	return interpreter.NewHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
 
+			invocation.Interpreter.ExpectNoSideEffects("change of contract", invocation.GetLocationRange)
+
 			const requiredArgumentCount = 2
 
 			nameValue := invocation.Arguments[0].(*interpreter.StringValue)
@@ -2197,6 +2365,13 @@ func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
 			var contractInterfaceTypes []*sema.InterfaceType
 
 			program.Elaboration.GlobalTypes.Foreach(func(_ string, variable *sema.Variable) {
+
+				// Type aliases refer to other types, they do not declare them
+
+				if variable.DeclarationKind == common.DeclarationKindTypeAlias {
+					return
+				}
+
 				switch ty := variable.Type.(type) {
 				case *sema.CompositeType:
 					if ty.Kind == common.CompositeKindContract {
@@ -2411,6 +2586,13 @@ func (r *interpreterRuntime) updateAccountContractCode(
 		return err
 	}
 
+	// The cached programs of the contract, and of its importers, are outdated
+
+	r.programs.Invalidate(common.AddressLocation{
+		Address: address,
+		Name:    name,
+	})
+
 	if createContract {
 		// NOTE: the contract recording delays the write
 		// until the end of the execution of the program
@@ -2470,6 +2652,8 @@ func (r *interpreterRuntime) newAuthAccountContractsRemoveFunction(
 	return interpreter.NewHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
 
+			invocation.Interpreter.ExpectNoSideEffects("removal of contract", invocation.GetLocationRange)
+
 			nameValue := invocation.Arguments[0].(*interpreter.StringValue)
 
 			address := addressValue.ToAddress()
@@ -2517,6 +2701,11 @@ func (r *interpreterRuntime) newAuthAccountContractsRemoveFunction(
 					panic(err)
 				}
 
+				r.programs.Invalidate(common.AddressLocation{
+					Address: address,
+					Name:    nameArgument,
+				})
+
 				// NOTE: the contract recording function delays the write
 				// until the end of the execution of the program
 
@@ -2698,6 +2887,8 @@ func (r *interpreterRuntime) newAccountKeysAddFunction(
 ) *interpreter.HostFunctionValue {
 	return interpreter.NewHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
+			invocation.Interpreter.ExpectNoSideEffects("addition of account key", invocation.GetLocationRange)
+
 			publicKeyValue := invocation.Arguments[0].(*interpreter.CompositeValue)
 
 			publicKey, err := NewPublicKeyFromValue(publicKeyValue)
@@ -2778,6 +2969,8 @@ func (r *interpreterRuntime) newAccountKeysRevokeFunction(
 ) *interpreter.HostFunctionValue {
 	return interpreter.NewHostFunctionValue(
 		func(invocation interpreter.Invocation) interpreter.Value {
+			invocation.Interpreter.ExpectNoSideEffects("revocation of account key", invocation.GetLocationRange)
+
 			indexValue := invocation.Arguments[0].(interpreter.IntValue)
 			index := indexValue.ToInt()
 			address := addressValue.ToAddress()
@@ -51,6 +51,7 @@ type runtimeStorage struct {
 	runtimeInterface Interface
 	cache            Cache
 	contractUpdates  ContractUpdates
+	segments         *segmentStorage
 }
 
 func newRuntimeStorage(runtimeInterface Interface) *runtimeStorage {
@@ -58,6 +59,7 @@ func newRuntimeStorage(runtimeInterface Interface) *runtimeStorage {
 		runtimeInterface: runtimeInterface,
 		cache:            Cache{},
 		contractUpdates:  ContractUpdates{},
+		segments:         newSegmentStorage(runtimeInterface),
 	}
 }
 
@@ -101,6 +103,8 @@ func (s *runtimeStorage) valueExists(
 			MustWrite: false,
 			Value:     nil,
 		}
+
+		s.segments.notStored(fullKey)
 	}
 
 	return exists
@@ -138,11 +142,7 @@ func (s *runtimeStorage) readValue(
 	// Cache miss: Load and deserialize the stored value (if any)
 	// through the runtime interface
 
-	var storedData []byte
-	var err error
-	wrapPanic(func() {
-		storedData, err = s.runtimeInterface.GetValue(address[:], []byte(key))
-	})
+	storedData, err := s.segments.read(fullKey)
 	if err != nil {
 		panic(err)
 	}
@@ -162,12 +162,13 @@ func (s *runtimeStorage) readValue(
 
 	reportMetric(
 		func() {
-			storedValue, err = interpreter.DecodeValue(
+			storedValue, err = interpreter.DecodeSegmentedValue(
 				storedData,
 				&address,
 				[]string{key},
 				version,
 				nil,
+				s.segments.load,
 			)
 		},
 		s.runtimeInterface,
@@ -244,9 +245,24 @@ type writeItem struct {
 	value      interpreter.Value
 }
 
-// writeCached serializes/saves all values in the cache in storage (through the runtime interface).
+// writeCached serializes/saves all values in the cache in storage (through the runtime interface),
+// and removes the segments which are no longer referenced.
 //
 func (s *runtimeStorage) writeCached(inter *interpreter.Interpreter) error {
+	err := s.writeValues(inter)
+	if err != nil {
+		return err
+	}
+
+	return s.segments.removeUnreferenced()
+}
+
+// writeValues serializes/saves all values in the cache in storage (through the runtime interface).
+//
+// Segments which are no longer referenced are not removed,
+// as values which are not written yet might still reference them.
+//
+func (s *runtimeStorage) writeValues(inter *interpreter.Interpreter) error {
 
 	var items []writeItem
 
@@ -451,18 +467,13 @@ func (s *runtimeStorage) processEncodedItem(item writeItem, encoded *encodedResu
 	}
 
 	var newData []byte
+	var segments []interpreter.EncodingDeferralSegment
 	if encoded != nil && len(encoded.newData) > 0 {
 		newData = interpreter.PrependMagic(encoded.newData, interpreter.CurrentEncodingVersion)
+		segments = encoded.deferrals.Segments
 	}
 
-	var err error
-	wrapPanic(func() {
-		err = s.runtimeInterface.SetValue(
-			item.storageKey.Address[:],
-			[]byte(item.storageKey.Key),
-			newData,
-		)
-	})
+	err := s.segments.write(item.storageKey, newData, segments)
 	if err != nil {
 		return nil, err
 	}
@@ -498,18 +509,17 @@ func (s *runtimeStorage) move(
 	oldOwner common.Address, oldKey string,
 	newOwner common.Address, newKey string,
 ) {
-	data, err := s.runtimeInterface.GetValue(oldOwner[:], []byte(oldKey))
-	if err != nil {
-		panic(err)
-	}
-
-	err = s.runtimeInterface.SetValue(oldOwner[:], []byte(oldKey), nil)
-	if err != nil {
-		panic(err)
-	}
-
 	// NOTE: not prefix with magic, as data is moved, so might already have it
-	err = s.runtimeInterface.SetValue(newOwner[:], []byte(newKey), data)
+	err := s.segments.move(
+		StorageKey{
+			Address: oldOwner,
+			Key:     oldKey,
+		},
+		StorageKey{
+			Address: newOwner,
+			Key:     newKey,
+		},
+	)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,425 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// The elements of large arrays and the entries of large dictionaries are stored in segments,
+// see interpreter.SegmentID. The segments of a stored value are stored in separate registers,
+// under keys derived from the key of the value and the ID of the segment.
+//
+// Segments are content-addressed, so a segment which is referenced multiple times,
+// e.g. by a value and its copy, is only stored once. Segments are only written when
+// they are not stored yet, and segments which are no longer referenced by the value
+// are removed after all values are written.
+
+// segmentKeySeparator separates the key of the value from the ID of the segment
+//
+// \x1F = Information Separator One
+//
+const segmentKeySeparator = "\x1Fsegment\x1F"
+
+func segmentKey(key string, id interpreter.SegmentID) string {
+	return key + segmentKeySeparator + id.String()
+}
+
+// ParseSegmentKey returns the key of the value which the segment stored under the given key belongs to,
+// and the ID of the segment. It returns false if the given key is not the key of a segment.
+//
+// Tools which process the registers of a state dump can use it
+// to provide the segments of stored values, see interpreter.SegmentLoader
+//
+func ParseSegmentKey(key string) (valueKey string, id interpreter.SegmentID, ok bool) {
+	index := strings.LastIndex(key, segmentKeySeparator)
+	if index < 0 {
+		return "", id, false
+	}
+
+	rawID, err := hex.DecodeString(key[index+len(segmentKeySeparator):])
+	if err != nil || len(rawID) != len(id) {
+		return "", id, false
+	}
+
+	copy(id[:], rawID)
+
+	return key[:index], id, true
+}
+
+// storedSegments are the segments of the value stored under a key
+//
+type storedSegments struct {
+	// data is the stored data of the value,
+	// until the segments it references are determined
+	data []byte
+	// stored are the segments which are stored under the key
+	stored map[interpreter.SegmentID]struct{}
+	// referenced are the segments which are referenced by the stored value
+	referenced map[interpreter.SegmentID]struct{}
+}
+
+func newStoredSegments(data []byte) *storedSegments {
+	return &storedSegments{
+		data: data,
+	}
+}
+
+// resolve determines the segments referenced by the stored data, if not determined yet
+//
+func (s *storedSegments) resolve() error {
+	if s.stored != nil {
+		return nil
+	}
+
+	data, _ := interpreter.StripMagic(s.data)
+
+	ids, err := interpreter.SegmentReferences(data)
+	if err != nil {
+		return err
+	}
+
+	s.data = nil
+	s.stored = segmentIDSet(ids)
+	s.referenced = segmentIDSet(ids)
+
+	return nil
+}
+
+func segmentIDSet(ids []interpreter.SegmentID) map[interpreter.SegmentID]struct{} {
+	set := make(map[interpreter.SegmentID]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+// segmentStorage stores values and their segments through the runtime interface,
+// and keeps track of the segments stored for each value
+//
+type segmentStorage struct {
+	runtimeInterface Interface
+	// mutex synchronizes the loading of segments,
+	// which may happen concurrently while values are encoded
+	mutex  sync.Mutex
+	values map[StorageKey]*storedSegments
+}
+
+func newSegmentStorage(runtimeInterface Interface) *segmentStorage {
+	return &segmentStorage{
+		runtimeInterface: runtimeInterface,
+		values:           map[StorageKey]*storedSegments{},
+	}
+}
+
+func (s *segmentStorage) getValue(storageKey StorageKey) (data []byte, err error) {
+	wrapPanic(func() {
+		data, err = s.runtimeInterface.GetValue(storageKey.Address[:], []byte(storageKey.Key))
+	})
+	return
+}
+
+func (s *segmentStorage) setValue(storageKey StorageKey, data []byte) (err error) {
+	wrapPanic(func() {
+		err = s.runtimeInterface.SetValue(storageKey.Address[:], []byte(storageKey.Key), data)
+	})
+	return
+}
+
+// read reads the data of the value stored under the given key.
+// The segments of the value are not read, they are loaded when they are needed, see load.
+//
+func (s *segmentStorage) read(storageKey StorageKey) ([]byte, error) {
+	data, err := s.getValue(storageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.values[storageKey]; !ok {
+		s.values[storageKey] = newStoredSegments(data)
+	}
+
+	return data, nil
+}
+
+// notStored records that no value is stored under the given key
+//
+func (s *segmentStorage) notStored(storageKey StorageKey) {
+	if _, ok := s.values[storageKey]; !ok {
+		s.values[storageKey] = newStoredSegments(nil)
+	}
+}
+
+// load is the interpreter.SegmentLoader for values read from storage.
+// It reads the segment with the given ID of the value stored under the given key.
+//
+func (s *segmentStorage) load(owner common.Address, key string, id interpreter.SegmentID) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.getValue(StorageKey{
+		Address: owner,
+		Key:     segmentKey(key, id),
+	})
+}
+
+// state returns the segments of the value stored under the given key.
+// If the value was not read before, the given stored data is assumed.
+//
+func (s *segmentStorage) state(storageKey StorageKey, storedData []byte) (*storedSegments, error) {
+	state, ok := s.values[storageKey]
+	if !ok {
+		state = newStoredSegments(storedData)
+		s.values[storageKey] = state
+	}
+
+	err := state.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// write writes the given data of a value under the given key,
+// and the given segments which are referenced by the data and not stored yet.
+//
+// Writing nil data removes the value.
+// Segments which are no longer referenced are removed by removeUnreferenced.
+//
+func (s *segmentStorage) write(
+	storageKey StorageKey,
+	data []byte,
+	segments []interpreter.EncodingDeferralSegment,
+) error {
+
+	// Stored values are always read before they are overwritten,
+	// e.g. saving a value requires the path to be empty,
+	// so a value which was not read is a new value, and has no stored segments.
+	//
+	// However, removed values might not have been read,
+	// e.g. the deferred values of a destroyed dictionary,
+	// so they are read to determine the segments which have to be removed.
+
+	var storedData []byte
+	if _, ok := s.values[storageKey]; !ok && data == nil {
+		var err error
+		storedData, err = s.getValue(storageKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	state, err := s.state(storageKey, storedData)
+	if err != nil {
+		return err
+	}
+
+	content, _ := interpreter.StripMagic(data)
+
+	ids, err := interpreter.SegmentReferences(content)
+	if err != nil {
+		return err
+	}
+
+	referenced := segmentIDSet(ids)
+
+	for _, segment := range segments {
+		if _, ok := referenced[segment.ID]; !ok {
+			continue
+		}
+
+		if _, ok := state.stored[segment.ID]; ok {
+			continue
+		}
+
+		err = s.setValue(
+			StorageKey{
+				Address: storageKey.Address,
+				Key:     segmentKey(storageKey.Key, segment.ID),
+			},
+			segment.Data,
+		)
+		if err != nil {
+			return err
+		}
+
+		state.stored[segment.ID] = struct{}{}
+	}
+
+	for _, id := range ids {
+		if _, ok := state.stored[id]; !ok {
+			return fmt.Errorf("missing segment %s of value %s", id, storageKey.Key)
+		}
+	}
+
+	err = s.setValue(storageKey, data)
+	if err != nil {
+		return err
+	}
+
+	state.referenced = referenced
+
+	return nil
+}
+
+// move moves the value stored under the old key to the new key, including all its segments.
+// The data is moved as-is, it is not decoded
+//
+func (s *segmentStorage) move(oldStorageKey StorageKey, newStorageKey StorageKey) error {
+	data, err := s.getValue(oldStorageKey)
+	if err != nil {
+		return err
+	}
+
+	oldState, err := s.state(oldStorageKey, data)
+	if err != nil {
+		return err
+	}
+
+	// The value is moved to a new key, see write
+	newState, err := s.state(newStorageKey, nil)
+	if err != nil {
+		return err
+	}
+
+	err = s.setValue(oldStorageKey, nil)
+	if err != nil {
+		return err
+	}
+
+	err = s.setValue(newStorageKey, data)
+	if err != nil {
+		return err
+	}
+
+	// Copy the segments in a deterministic order
+
+	content, _ := interpreter.StripMagic(data)
+
+	ids, err := interpreter.SegmentReferences(content)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, ok := newState.stored[id]; ok {
+			continue
+		}
+
+		segment, err := s.getValue(StorageKey{
+			Address: oldStorageKey.Address,
+			Key:     segmentKey(oldStorageKey.Key, id),
+		})
+		if err != nil {
+			return err
+		}
+
+		err = s.setValue(
+			StorageKey{
+				Address: newStorageKey.Address,
+				Key:     segmentKey(newStorageKey.Key, id),
+			},
+			segment,
+		)
+		if err != nil {
+			return err
+		}
+
+		newState.stored[id] = struct{}{}
+	}
+
+	// The segments of the old key are removed by removeUnreferenced
+
+	oldState.referenced = map[interpreter.SegmentID]struct{}{}
+	newState.referenced = segmentIDSet(ids)
+
+	return nil
+}
+
+// removeUnreferenced removes all segments which are stored, but no longer referenced.
+//
+// Segments must only be removed once all values are written:
+// Values which were moved to another key still reference the segments of the old key
+// until they are written.
+//
+func (s *segmentStorage) removeUnreferenced() error {
+
+	type unreferencedSegment struct {
+		storageKey StorageKey
+		id         interpreter.SegmentID
+	}
+
+	var unreferenced []unreferencedSegment
+
+	for storageKey, state := range s.values { //nolint:maprangecheck
+		for id := range state.stored { //nolint:maprangecheck
+			if _, ok := state.referenced[id]; ok {
+				continue
+			}
+
+			unreferenced = append(unreferenced, unreferencedSegment{
+				storageKey: storageKey,
+				id:         id,
+			})
+
+			delete(state.stored, id)
+		}
+	}
+
+	// Remove the segments in a deterministic order
+
+	sort.Slice(unreferenced, func(i, j int) bool {
+		a := unreferenced[i]
+		b := unreferenced[j]
+
+		if c := bytes.Compare(a.storageKey.Address[:], b.storageKey.Address[:]); c != 0 {
+			return c < 0
+		}
+
+		if a.storageKey.Key != b.storageKey.Key {
+			return a.storageKey.Key < b.storageKey.Key
+		}
+
+		return bytes.Compare(a.id[:], b.id[:]) < 0
+	})
+
+	for _, segment := range unreferenced {
+		err := s.setValue(
+			StorageKey{
+				Address: segment.storageKey.Address,
+				Key:     segmentKey(segment.storageKey.Key, segment.id),
+			},
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
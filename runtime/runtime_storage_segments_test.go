@@ -0,0 +1,231 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestRuntimeStorageSegments(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := NewInterpreterRuntime()
+
+	var reads []string
+	var writes []testWrite
+
+	storage := newTestStorage(
+		func(owner, key, value []byte) {
+			reads = append(reads, string(key))
+		},
+		func(owner, key, value []byte) {
+			writes = append(writes, testWrite{
+				owner: owner,
+				key:   key,
+				value: value,
+			})
+		},
+	)
+
+	signer := common.BytesToAddress([]byte{0x42})
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: storage,
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{signer}, nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	executeTransaction := func(code string) {
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	segmentKeys := func(key string) (keys []string) {
+		for storageKey, value := range storage.storedValues {
+			if strings.Contains(storageKey, key+segmentKeySeparator) && len(value) > 0 {
+				keys = append(keys, storageKey)
+			}
+		}
+		return
+	}
+
+	segmentReads := func() (count int) {
+		for _, key := range reads {
+			if strings.Contains(key, segmentKeySeparator) {
+				count++
+			}
+		}
+		return
+	}
+
+	segmentWrites := func() (count int) {
+		for _, write := range writes {
+			if strings.Contains(string(write.key), segmentKeySeparator) {
+				count++
+			}
+		}
+		return
+	}
+
+	// Store a large dictionary, which exceeds the segmentation threshold
+
+	executeTransaction(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              let dict: {Int: String} = {}
+              var i = 0
+              while i < 5000 {
+                  dict[i] = "value ".concat(i.toString())
+                  i = i + 1
+              }
+              signer.save(dict, to: /storage/dict)
+          }
+      }
+    `)
+
+	initialSegmentKeys := segmentKeys("storage\x1Fdict")
+	require.Greater(t, len(initialSegmentKeys), 2)
+
+	// Reading an entry only reads the segment containing it
+
+	reads = nil
+
+	executeTransaction(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              let dict = signer.borrow<&{Int: String}>(from: /storage/dict)!
+              assert(dict[2500] == "value 2500")
+          }
+      }
+    `)
+
+	assert.Equal(t, 1, segmentReads())
+
+	// Updating an entry only reads and writes the segment containing it
+
+	reads = nil
+	writes = nil
+
+	executeTransaction(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              let dict = signer.load<{Int: String}>(from: /storage/dict)!
+              dict[2500] = "updated"
+              signer.save(dict, to: /storage/dict)
+          }
+      }
+    `)
+
+	assert.Equal(t, 1, segmentReads())
+
+	// The updated segment is written, and the previous segment is removed
+	assert.Equal(t, 2, segmentWrites())
+
+	assert.Len(t, segmentKeys("storage\x1Fdict"), len(initialSegmentKeys))
+
+	executeTransaction(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              let dict = signer.borrow<&{Int: String}>(from: /storage/dict)!
+              assert(dict[0] == "value 0")
+              assert(dict[2500] == "updated")
+              assert(dict[4999] == "value 4999")
+              assert(dict.length == 5000)
+          }
+      }
+    `)
+
+	// Moving the value to another path moves its segments
+
+	executeTransaction(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              let dict = signer.load<{Int: String}>(from: /storage/dict)!
+              signer.save(dict, to: /storage/other)
+          }
+      }
+    `)
+
+	assert.Empty(t, segmentKeys("storage\x1Fdict"))
+	assert.Len(t, segmentKeys("storage\x1Fother"), len(initialSegmentKeys))
+
+	executeTransaction(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              let dict = signer.borrow<&{Int: String}>(from: /storage/other)!
+              assert(dict[2500] == "updated")
+              assert(dict[4999] == "value 4999")
+          }
+      }
+    `)
+
+	// Shrink the value below the threshold: the value is stored inline again,
+	// and all segments are removed
+
+	executeTransaction(`
+      transaction {
+          prepare(signer: AuthAccount) {
+              let dict = signer.load<{Int: String}>(from: /storage/other)!
+              signer.save({1: dict[1]!}, to: /storage/other)
+          }
+      }
+    `)
+
+	assert.Empty(t, segmentKeys("storage\x1Fother"))
+}
+
+func TestParseSegmentKey(t *testing.T) {
+
+	t.Parallel()
+
+	id := interpreter.SegmentID{0x1, 0x2, 0xFF}
+
+	valueKey, parsedID, ok := ParseSegmentKey(segmentKey("storage\x1Fdict", id))
+	require.True(t, ok)
+	assert.Equal(t, "storage\x1Fdict", valueKey)
+	assert.Equal(t, id, parsedID)
+
+	for _, key := range []string{
+		"storage\x1Fdict",
+		"storage\x1Fdict" + segmentKeySeparator + "invalid",
+		"storage\x1Fdict" + segmentKeySeparator + "0102",
+	} {
+		_, _, ok = ParseSegmentKey(key)
+		assert.False(t, ok, key)
+	}
+}
@@ -20,6 +20,7 @@ package runtime
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
@@ -5155,6 +5156,164 @@ func TestRuntimeComputationLimit(t *testing.T) {
 	}
 }
 
+func TestRuntimeExecutionCancellation(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := NewInterpreterRuntime()
+
+	newRuntimeInterface := func() *testRuntimeInterface {
+		return &testRuntimeInterface{
+			getSigningAccounts: func() ([]Address, error) {
+				return nil, nil
+			},
+		}
+	}
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  pub fun main() {
+                      while true {}
+                  }
+                `),
+			},
+			Context{
+				Interface:        newRuntimeInterface(),
+				Location:         common.ScriptLocation{},
+				ExecutionContext: ctx,
+			},
+		)
+		require.Error(t, err)
+
+		var cancelledErr interpreter.ExecutionCancelledError
+		require.ErrorAs(t, err, &cancelledErr)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("cancelled, recursion", func(t *testing.T) {
+
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		runtimeInterface := newRuntimeInterface()
+		runtimeInterface.log = func(_ string) {
+			cancel()
+		}
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  pub fun count(_ n: Int): Int {
+                      if n == 10 {
+                          log("cancel")
+                      }
+                      return count(n + 1)
+                  }
+
+                  transaction {
+                      prepare() {
+                          count(0)
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface:        runtimeInterface,
+				Location:         utils.TestLocation,
+				ExecutionContext: ctx,
+			},
+		)
+		require.Error(t, err)
+
+		var cancelledErr interpreter.ExecutionCancelledError
+		require.ErrorAs(t, err, &cancelledErr)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("cancelled, map", func(t *testing.T) {
+
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var loggedMessages []string
+
+		runtimeInterface := newRuntimeInterface()
+		runtimeInterface.log = func(message string) {
+			loggedMessages = append(loggedMessages, message)
+			cancel()
+		}
+
+		// The transform function is the native `log` function,
+		// so only the native loop of `map` can observe the cancellation
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  pub fun main() {
+                      let xs = [1, 2, 3, 4, 5, 6, 7, 8, 9, 10]
+                      xs.map(log)
+                  }
+                `),
+			},
+			Context{
+				Interface:        runtimeInterface,
+				Location:         common.ScriptLocation{},
+				ExecutionContext: ctx,
+			},
+		)
+		require.Error(t, err)
+
+		var cancelledErr interpreter.ExecutionCancelledError
+		require.ErrorAs(t, err, &cancelledErr)
+
+		assert.ErrorIs(t, err, context.Canceled)
+
+		assert.Equal(t, []string{"1"}, loggedMessages)
+	})
+
+	t.Run("not cancelled", func(t *testing.T) {
+
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  pub fun main(): Int {
+                      var i = 0
+                      while i < 10 {
+                          i = i + 1
+                      }
+                      return i
+                  }
+                `),
+			},
+			Context{
+				Interface:        newRuntimeInterface(),
+				Location:         common.ScriptLocation{},
+				ExecutionContext: ctx,
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(10), result)
+	})
+}
+
 func TestRuntimeMetrics(t *testing.T) {
 
 	t.Parallel()
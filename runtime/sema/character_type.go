@@ -18,6 +18,11 @@
 
 package sema
 
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
 // CharacterType represents the character type
 //
 var CharacterType = &SimpleType{
@@ -31,3 +36,125 @@ var CharacterType = &SimpleType{
 	ExternallyReturnable: true,
 	Importable:           true,
 }
+
+func init() {
+	CharacterType.Members = func(t *SimpleType) map[string]MemberResolver {
+		return map[string]MemberResolver{
+			ToStringFunctionName: {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						ToStringFunctionType,
+						characterTypeToStringFunctionDocString,
+					)
+				},
+			},
+			"utf8": {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						ByteArrayType,
+						characterTypeUtf8FieldDocString,
+					)
+				},
+			},
+			"toLower": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						CharacterTypeToLowerFunctionType,
+						characterTypeToLowerFunctionDocString,
+					)
+				},
+			},
+			"toUpper": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						CharacterTypeToUpperFunctionType,
+						characterTypeToUpperFunctionDocString,
+					)
+				},
+			},
+			"isWhitespace": {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						BoolType,
+						characterTypeIsWhitespaceFieldDocString,
+					)
+				},
+			},
+			"isLetter": {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						BoolType,
+						characterTypeIsLetterFieldDocString,
+					)
+				},
+			},
+			"isDigit": {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						BoolType,
+						characterTypeIsDigitFieldDocString,
+					)
+				},
+			},
+		}
+	}
+}
+
+const characterTypeToStringFunctionDocString = `
+Returns the string consisting of the character
+`
+
+const characterTypeUtf8FieldDocString = `
+The byte array of the UTF-8 encoding
+`
+
+var CharacterTypeToLowerFunctionType = &FunctionType{
+	Purity:               ast.FunctionPurityView,
+	ReturnTypeAnnotation: NewTypeAnnotation(CharacterType),
+}
+
+const characterTypeToLowerFunctionDocString = `
+Returns the character with upper case letters replaced with lowercase
+`
+
+var CharacterTypeToUpperFunctionType = &FunctionType{
+	Purity:               ast.FunctionPurityView,
+	ReturnTypeAnnotation: NewTypeAnnotation(CharacterType),
+}
+
+const characterTypeToUpperFunctionDocString = `
+Returns the character with lower case letters replaced with uppercase
+`
+
+const characterTypeIsWhitespaceFieldDocString = `
+Is true if the character only consists of whitespace
+`
+
+const characterTypeIsLetterFieldDocString = `
+Is true if the character is a letter
+`
+
+const characterTypeIsDigitFieldDocString = `
+Is true if the character is a decimal digit
+`
@@ -64,17 +64,26 @@ func (checker *Checker) VisitArrayExpression(expression *ast.ArrayExpression) as
 	argumentTypes := make([]Type, len(expression.Values))
 
 	for i, value := range expression.Values {
-		valueType := checker.VisitExpression(value, elementType)
+
+		// If the element type is inferred, the element type inferred so far
+		// is at most used as a hint for the element, see inferredTypeHint
+
+		var valueType Type
+		if inferType {
+			valueType = checker.VisitExpressionWithForceType(value, inferredTypeHint(value, elementType), false)
+		} else {
+			valueType = checker.VisitExpression(value, elementType)
+		}
 
 		argumentTypes[i] = valueType
 
 		checker.checkVariableMove(value)
 		checker.checkResourceMoveOperation(value, valueType)
 
-		// infer element type from first element
-		// TODO: find common super type?
-		if elementType == nil {
-			elementType = valueType
+		// infer element type from the common super type of all elements
+
+		if inferType {
+			elementType = checker.inferCommonSuperType(elementType, valueType, value)
 		}
 	}
 
@@ -89,9 +98,57 @@ func (checker *Checker) VisitArrayExpression(expression *ast.ArrayExpression) as
 		resultType = &VariableSizedType{
 			Type: elementType,
 		}
+
+		for _, value := range expression.Values {
+			checker.inferNestedLiteralType(value, elementType)
+		}
 	}
 
 	checker.Elaboration.ArrayExpressionArrayType[expression] = resultType
 
 	return resultType
 }
+
+// inferNestedLiteralType sets the type of the given element of an array or dictionary literal,
+// if it is an array or dictionary literal itself, to the type inferred for all elements,
+// and does so recursively for the elements of the nested literal.
+//
+// The nested literal is checked before the type of all elements is known,
+// so this ensures its values have the inferred type,
+// e.g. the element of `[1]` in `[[1], [nil]]` is an optional.
+//
+func (checker *Checker) inferNestedLiteralType(expression ast.Expression, inferredType Type) {
+
+	inferredType = UnwrapOptionalType(inferredType)
+
+	switch expression := expression.(type) {
+	case *ast.ArrayExpression:
+		arrayType, ok := inferredType.(*VariableSizedType)
+		if !ok {
+			return
+		}
+
+		if _, ok := checker.Elaboration.ArrayExpressionArrayType[expression].(*VariableSizedType); !ok {
+			return
+		}
+
+		checker.Elaboration.ArrayExpressionArrayType[expression] = arrayType
+
+		for _, value := range expression.Values {
+			checker.inferNestedLiteralType(value, arrayType.Type)
+		}
+
+	case *ast.DictionaryExpression:
+		dictionaryType, ok := inferredType.(*DictionaryType)
+		if !ok {
+			return
+		}
+
+		checker.Elaboration.DictionaryExpressionType[expression] = dictionaryType
+
+		for _, entry := range expression.Entries {
+			checker.inferNestedLiteralType(entry.Key, dictionaryType.KeyType)
+			checker.inferNestedLiteralType(entry.Value, dictionaryType.ValueType)
+		}
+	}
+}
@@ -46,6 +46,8 @@ func (checker *Checker) checkAssignment(
 
 	targetType = checker.visitAssignmentValueType(target)
 
+	checker.checkAssignmentPurity(target)
+
 	valueType = checker.VisitExpression(value, targetType)
 
 	// NOTE: Visiting the `value` checks the compatibility between value and target types.
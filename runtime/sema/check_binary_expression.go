@@ -55,7 +55,17 @@ func (checker *Checker) VisitBinaryExpression(expression *ast.BinaryExpression)
 	// Visit the expression, with contextually expected type. Use the expected type
 	// only for inferring wherever possible, but do not check for compatibility.
 	// Compatibility is checked separately for each operand kind.
-	leftType := checker.VisitExpressionWithForceType(expression.Left, expectedType, false)
+	// The left-hand side of a nil-coalescing expression and the operands of a comparison with `nil`
+	// are expected to be optional, so the types of variables are not narrowed
+
+	var leftType Type
+	if operationKind == BinaryOperationKindNilCoalescing ||
+		(operationKind == BinaryOperationKindEquality && isNilExpression(expression.Right)) {
+
+		leftType = checker.visitExpressionWithoutNarrowing(expression.Left, expectedType, false)
+	} else {
+		leftType = checker.VisitExpressionWithForceType(expression.Left, expectedType, false)
+	}
 
 	leftIsInvalid := leftType.IsInvalidType()
 
@@ -111,7 +121,12 @@ func (checker *Checker) VisitBinaryExpression(expression *ast.BinaryExpression)
 			expectedType = leftType
 		}
 
-		rightType := checker.VisitExpressionWithForceType(expression.Right, expectedType, false)
+		var rightType Type
+		if operationKind == BinaryOperationKindEquality && isNilExpression(expression.Left) {
+			rightType = checker.visitExpressionWithoutNarrowing(expression.Right, expectedType, false)
+		} else {
+			rightType = checker.VisitExpressionWithForceType(expression.Right, expectedType, false)
+		}
 
 		rightIsInvalid := rightType.IsInvalidType()
 
@@ -146,14 +161,28 @@ func (checker *Checker) VisitBinaryExpression(expression *ast.BinaryExpression)
 		// That means that resource invalidation and returns
 		// are not definite, but only potential.
 
+		// The right-hand side of a boolean logic expression is only evaluated
+		// if the left-hand side is true (`&&`) or false (`||`),
+		// so the narrowings of the left-hand side hold for the right-hand side
+
+		var rightNarrowings narrowings
+		switch operation {
+		case ast.OperationAnd:
+			rightNarrowings, _ = checker.conditionNarrowings(expression.Left)
+		case ast.OperationOr:
+			_, rightNarrowings = checker.conditionNarrowings(expression.Left)
+		}
+
 		rightType := checker.checkPotentiallyUnevaluated(func() Type {
-			var expectedType Type
-			if !leftIsInvalid {
-				if optionalLeftType, ok := leftType.(*OptionalType); ok {
-					expectedType = optionalLeftType.Type
+			return checker.checkWithNarrowings(rightNarrowings, func() Type {
+				var expectedType Type
+				if !leftIsInvalid {
+					if optionalLeftType, ok := leftType.(*OptionalType); ok {
+						expectedType = optionalLeftType.Type
+					}
 				}
-			}
-			return checker.VisitExpressionWithForceType(expression.Right, expectedType, false)
+				return checker.VisitExpressionWithForceType(expression.Right, expectedType, false)
+			})
 		})
 
 		rightIsInvalid := rightType.IsInvalidType()
@@ -176,6 +205,7 @@ func (checker *Checker) VisitBinaryExpression(expression *ast.BinaryExpression)
 			)
 
 			checker.Elaboration.BinaryExpressionResultTypes[expression] = resultType
+			checker.Elaboration.BinaryExpressionLeftTypes[expression] = leftType
 			checker.Elaboration.BinaryExpressionRightTypes[expression] = rightType
 
 			return resultType
@@ -408,7 +438,6 @@ func (checker *Checker) checkBinaryExpressionNilCoalescing(
 	if leftInner == NeverType {
 		return rightType
 	}
-	canNarrow := false
 
 	if !rightIsInvalid {
 
@@ -421,7 +450,12 @@ func (checker *Checker) checkBinaryExpressionNilCoalescing(
 			)
 		}
 
-		if !IsSubType(rightType, leftOptional) {
+		// The result type is the common super type
+		// of the left-hand side's inner type and the right-hand side's type
+
+		resultType := LeastCommonSuperType(leftInner, rightType)
+
+		if resultType == nil {
 
 			checker.report(
 				&InvalidBinaryOperandError{
@@ -433,12 +467,14 @@ func (checker *Checker) checkBinaryExpressionNilCoalescing(
 				},
 			)
 		} else {
-			canNarrow = IsSubType(rightType, leftInner)
+			return resultType
 		}
 	}
 
-	if !canNarrow {
-		return leftOptional
-	}
-	return leftInner
+	return leftOptional
+}
+
+func isNilExpression(expression ast.Expression) bool {
+	_, ok := expression.(*ast.NilExpression)
+	return ok
 }
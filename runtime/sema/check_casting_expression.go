@@ -34,6 +34,10 @@ func (checker *Checker) VisitCastingExpression(expression *ast.CastingExpression
 
 	checker.Elaboration.CastingTargetTypes[expression] = rightHandType
 
+	if checker.expectedType != nil {
+		checker.Elaboration.CastingExpectedTypes[expression] = checker.expectedType
+	}
+
 	// visit the expression
 
 	leftHandExpression := expression.Expression
@@ -74,8 +74,27 @@ func (checker *Checker) visitCompositeDeclaration(declaration *ast.CompositeDecl
 		defer checker.leaveValueScope(declaration.EndPosition, false)
 	}
 
+	checker.declareTypeParameters(
+		compositeType.TypeParameters,
+		declaration.TypeParameterList,
+		true,
+	)
+
 	checker.declareCompositeNestedTypes(declaration, kind, true)
 
+	checker.declareNestedTypeAliases(
+		declaration.Members.TypeAliases(),
+		compositeType,
+		compositeType.Kind,
+		declaration.DeclarationKind(),
+	)
+
+	checker.declareNestedExtensions(
+		declaration.Members.Extensions(),
+		compositeType,
+		declaration.DeclarationKind(),
+	)
+
 	var initializationInfo *InitializationInfo
 
 	if kind == ContainerKindComposite {
@@ -197,6 +216,14 @@ func (checker *Checker) visitCompositeDeclaration(declaration *ast.CompositeDecl
 	for _, nestedComposite := range declaration.Members.Composites() {
 		nestedComposite.Accept(checker)
 	}
+
+	for _, typeAlias := range declaration.Members.TypeAliases() {
+		typeAlias.Accept(checker)
+	}
+
+	for _, extension := range declaration.Members.Extensions() {
+		extension.Accept(checker)
+	}
 }
 
 // declareCompositeNestedTypes declares the types nested in a composite,
@@ -417,6 +444,7 @@ func (checker *Checker) declareCompositeType(declaration *ast.CompositeDeclarati
 		Kind:        declaration.CompositeKind,
 		Identifier:  identifier.Identifier,
 		nestedTypes: NewStringTypeOrderedMap(),
+		typeAliases: NewStringTypeOrderedMap(),
 		Members:     NewStringMemberOrderedMap(),
 	}
 
@@ -437,10 +465,32 @@ func (checker *Checker) declareCompositeType(declaration *ast.CompositeDeclarati
 		)
 	}
 
+	// Resolve type parameters.
+	//
+	// Only structures and resources may be generic
+
+	if !declaration.TypeParameterList.IsEmpty() {
+		switch declaration.CompositeKind {
+		case common.CompositeKindStructure,
+			common.CompositeKindResource:
+
+			compositeType.TypeParameters = checker.typeParameters(declaration.TypeParameterList)
+
+		default:
+			checker.report(
+				&InvalidTypeParametersError{
+					CompositeKind: declaration.CompositeKind,
+					Range:         ast.NewRangeFromPositioned(declaration.TypeParameterList),
+				},
+			)
+		}
+	}
+
 	// Resolve conformances
 
 	if declaration.CompositeKind == common.CompositeKindEnum {
 		compositeType.EnumRawType = checker.enumRawType(declaration)
+		compositeType.EnumCases = enumCaseNames(declaration)
 	} else {
 		compositeType.ExplicitInterfaceConformances =
 			checker.explicitInterfaceConformances(declaration, compositeType)
@@ -511,8 +561,32 @@ func (checker *Checker) declareCompositeMembersAndValue(
 		checker.enterValueScope()
 		defer checker.leaveValueScope(declaration.EndPosition, false)
 
+		checker.declareTypeParameters(
+			compositeType.TypeParameters,
+			declaration.TypeParameterList,
+			false,
+		)
+
 		checker.declareCompositeNestedTypes(declaration, kind, false)
 
+		// NOTE: declare type aliases after nested types, as type aliases may refer to them,
+		// and before members, as members may refer to type aliases
+
+		checker.declareNestedTypeAliases(
+			declaration.Members.TypeAliases(),
+			compositeType,
+			compositeType.Kind,
+			declaration.DeclarationKind(),
+		)
+
+		// NOTE: declare extensions after type aliases, as extensions may refer to them
+
+		checker.declareNestedExtensions(
+			declaration.Members.Extensions(),
+			compositeType,
+			declaration.DeclarationKind(),
+		)
+
 		// NOTE: determine initializer parameter types while nested types are in scope,
 		// and after declaring nested types as the initializer may use nested type in parameters
 
@@ -799,6 +873,7 @@ func (checker *Checker) declareEnumConstructor(
 
 func EnumConstructorType(compositeType *CompositeType) *FunctionType {
 	return &FunctionType{
+		Purity:        ast.FunctionPurityView,
 		IsConstructor: true,
 		Parameters: []*Parameter{
 			{
@@ -899,6 +974,28 @@ func (checker *Checker) explicitInterfaceConformances(
 	return interfaceTypes
 }
 
+// enumCaseNames returns the names of the cases of the given enum declaration,
+// in declaration order. Duplicate cases are reported separately
+// and only included once.
+//
+func enumCaseNames(declaration *ast.CompositeDeclaration) []string {
+	enumCases := declaration.Members.EnumCases()
+
+	caseNames := make([]string, 0, len(enumCases))
+	seen := make(map[string]struct{}, len(enumCases))
+
+	for _, enumCase := range enumCases {
+		caseName := enumCase.Identifier.Identifier
+		if _, ok := seen[caseName]; ok {
+			continue
+		}
+		seen[caseName] = struct{}{}
+		caseNames = append(caseNames, caseName)
+	}
+
+	return caseNames
+}
+
 func (checker *Checker) enumRawType(declaration *ast.CompositeDeclaration) Type {
 
 	conformanceCount := len(declaration.Conformances)
@@ -1035,7 +1132,10 @@ func (checker *Checker) checkCompositeConformance(
 					InterfaceMember: interfaceMember,
 				},
 			)
+			return
 		}
+
+		checker.checkViewFunctionConformance(compositeMember, interfaceMember, interfaceType)
 	})
 
 	// Determine missing nested composite type definitions
@@ -1290,6 +1390,30 @@ func (checker *Checker) compositeConstructorType(
 		ReturnTypeAnnotation: NewTypeAnnotation(compositeType),
 	}
 
+	// Events are only initialized with the arguments,
+	// so their constructors have no side effects
+
+	if compositeType.Kind == common.CompositeKindEvent {
+		constructorFunctionType.Purity = ast.FunctionPurityView
+	}
+
+	// The constructor of a generic composite is generic,
+	// and returns the composite type instantiated with the type arguments
+
+	if len(compositeType.TypeParameters) > 0 {
+		typeArguments := make([]Type, len(compositeType.TypeParameters))
+		for i, typeParameter := range compositeType.TypeParameters {
+			typeArguments[i] = &GenericType{
+				TypeParameter: typeParameter,
+			}
+		}
+
+		constructorFunctionType.TypeParameters = compositeType.TypeParameters
+		constructorFunctionType.ReturnTypeAnnotation = NewTypeAnnotation(
+			NewInstantiatedCompositeType(compositeType, typeArguments),
+		)
+	}
+
 	// TODO: support multiple overloaded initializers
 
 	initializers := compositeDeclaration.Members.Initializers()
@@ -1452,7 +1576,24 @@ func (checker *Checker) defaultMembersAndOrigins(
 
 		identifier := function.Identifier.Identifier
 
-		functionType := checker.functionType(function.ParameterList, function.ReturnTypeAnnotation)
+		functionType := checker.functionType(
+			function.Purity,
+			function.TypeParameterList,
+			function.ParameterList,
+			function.ReturnTypeAnnotation,
+		)
+
+		// The type parameters of a generic function must be the same
+		// for the member and the function body, which is checked later,
+		// so record the function type for the declaration
+
+		if len(functionType.TypeParameters) > 0 {
+			checker.Elaboration.FunctionDeclarationFunctionTypes[function] = functionType
+		}
+
+		// The purity inferred for the function body must also apply to the member
+
+		checker.memberFunctionTypes[function] = functionType
 
 		argumentLabels := function.ParameterList.EffectiveArgumentLabels()
 
@@ -1778,6 +1919,7 @@ func (checker *Checker) checkCompositeFunctions(
 					mustExit:          true,
 					declareFunction:   false,
 					checkResourceLoss: true,
+					inferPurity:       true,
 				},
 			)
 		}()
@@ -68,19 +68,26 @@ func (checker *Checker) VisitConditionalExpression(expression *ast.ConditionalEx
 		panic(errors.NewUnreachableError())
 	}
 
-	// TODO: improve
-	resultType := thenType
+	// The result type is the common super type of both branches
 
-	if !IsSubType(elseType, resultType) {
+	resultType := LeastCommonSuperType(thenType, elseType)
+
+	if resultType == nil {
 		checker.report(
 			&TypeMismatchError{
-				ExpectedType: resultType,
+				ExpectedType: thenType,
 				ActualType:   elseType,
 				Range:        ast.NewRangeFromPositioned(expression.Else),
 			},
 		)
+
+		resultType = thenType
 	}
 
+	checker.Elaboration.ConditionalExpressionThenTypes[expression] = thenType
+	checker.Elaboration.ConditionalExpressionElseTypes[expression] = elseType
+	checker.Elaboration.ConditionalExpressionResultTypes[expression] = resultType
+
 	return resultType
 }
 
@@ -88,6 +95,10 @@ func (checker *Checker) VisitConditionalExpression(expression *ast.ConditionalEx
 // The test expression must be a boolean.
 // The "then" and "else" elements may be expressions, in which case their types are returned.
 //
+// Optional variables which are compared to `nil` in the test expression
+// are narrowed in the branches, and if one branch definitely returns or halts,
+// the narrowings of the other branch also hold after the conditional.
+//
 func (checker *Checker) visitConditional(
 	test ast.Expression,
 	thenElement ast.Element,
@@ -98,22 +109,55 @@ func (checker *Checker) visitConditional(
 
 	checker.VisitExpression(test, BoolType)
 
-	return checker.checkConditionalBranches(
+	thenNarrowings, elseNarrowings := checker.conditionNarrowings(test)
+
+	var thenExited, elseExited bool
+
+	thenType, elseType = checker.checkConditionalBranches(
 		func() Type {
-			thenResult := thenElement.Accept(checker)
-			if thenResult == nil {
-				return nil
-			}
-			return thenResult.(Type)
+			defer func() {
+				thenExited = checker.definitelyExited()
+			}()
+
+			return checker.checkWithNarrowings(thenNarrowings, func() Type {
+				thenResult := thenElement.Accept(checker)
+				if thenResult == nil {
+					return nil
+				}
+				return thenResult.(Type)
+			})
 		},
 		func() Type {
-			elseResult := elseElement.Accept(checker)
-			if elseResult == nil {
-				return nil
-			}
-			return elseResult.(Type)
+			defer func() {
+				elseExited = checker.definitelyExited()
+			}()
+
+			return checker.checkWithNarrowings(elseNarrowings, func() Type {
+				elseResult := elseElement.Accept(checker)
+				if elseResult == nil {
+					return nil
+				}
+				return elseResult.(Type)
+			})
 		},
 	)
+
+	if thenExited && !elseExited {
+		checker.narrow(elseNarrowings)
+	} else if elseExited && !thenExited {
+		checker.narrow(thenNarrowings)
+	}
+
+	return
+}
+
+// definitelyExited returns true if the current function
+// definitely returned or halted
+//
+func (checker *Checker) definitelyExited() bool {
+	returnInfo := checker.functionActivations.Current().ReturnInfo
+	return returnInfo.DefinitelyReturned ||
+		returnInfo.DefinitelyHalted
 }
 
 // checkConditionalBranches checks two conditional branches.
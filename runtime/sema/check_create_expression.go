@@ -30,6 +30,8 @@ func (checker *Checker) VisitCreateExpression(expression *ast.CreateExpression)
 		checker.inCreate = inCreate
 	}()
 
+	checker.recordImpureOperation("creation of resource", expression)
+
 	// TODO: maybe check that invoked expression is a composite constructor
 
 	invocation := expression.InvocationExpression
@@ -42,7 +44,7 @@ func (checker *Checker) VisitCreateExpression(expression *ast.CreateExpression)
 
 	// Check that the created expression is a resource
 
-	compositeType, isCompositeType := ty.(*CompositeType)
+	compositeType, isCompositeType := compositeOrInstantiatedCompositeType(ty)
 
 	// NOTE: not using `isResourceType`,
 	// as only direct resource types can be constructed
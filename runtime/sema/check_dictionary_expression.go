@@ -32,7 +32,10 @@ func (checker *Checker) VisitDictionaryExpression(expression *ast.DictionaryExpr
 
 	expectedType := UnwrapOptionalType(checker.expectedType)
 
+	inferType := true
+
 	if expectedMapType, ok := expectedType.(*DictionaryType); ok {
+		inferType = false
 		keyType = expectedMapType.KeyType
 		valueType = expectedMapType.ValueType
 	}
@@ -43,11 +46,21 @@ func (checker *Checker) VisitDictionaryExpression(expression *ast.DictionaryExpr
 		// NOTE: important to check move after each type check,
 		// not combined after both type checks!
 
-		entryKeyType := checker.VisitExpression(entry.Key, keyType)
+		// If the key and value types are inferred, the types inferred so far
+		// are at most used as hints for the entry, see inferredTypeHint
+
+		expectedKeyType := keyType
+		expectedValueType := valueType
+		if inferType {
+			expectedKeyType = inferredTypeHint(entry.Key, keyType)
+			expectedValueType = inferredTypeHint(entry.Value, valueType)
+		}
+
+		entryKeyType := checker.VisitExpressionWithForceType(entry.Key, expectedKeyType, !inferType)
 		checker.checkVariableMove(entry.Key)
 		checker.checkResourceMoveOperation(entry.Key, entryKeyType)
 
-		entryValueType := checker.VisitExpression(entry.Value, valueType)
+		entryValueType := checker.VisitExpressionWithForceType(entry.Value, expectedValueType, !inferType)
 		checker.checkVariableMove(entry.Value)
 		checker.checkResourceMoveOperation(entry.Value, entryValueType)
 
@@ -56,16 +69,11 @@ func (checker *Checker) VisitDictionaryExpression(expression *ast.DictionaryExpr
 			ValueType: entryValueType,
 		}
 
-		// infer key type from first entry's key
-		// TODO: find common super type?
-		if keyType == nil {
-			keyType = entryKeyType
-		}
+		// infer key and value types from the common super types of all entries
 
-		// infer value type from first entry's value
-		// TODO: find common super type?
-		if valueType == nil {
-			valueType = entryValueType
+		if inferType {
+			keyType = checker.inferCommonSuperType(keyType, entryKeyType, entry.Key)
+			valueType = checker.inferCommonSuperType(valueType, entryValueType, entry.Value)
 		}
 	}
 
@@ -91,6 +99,13 @@ func (checker *Checker) VisitDictionaryExpression(expression *ast.DictionaryExpr
 		ValueType: valueType,
 	}
 
+	if inferType {
+		for _, entry := range expression.Entries {
+			checker.inferNestedLiteralType(entry.Key, keyType)
+			checker.inferNestedLiteralType(entry.Value, valueType)
+		}
+	}
+
 	checker.Elaboration.DictionaryExpressionEntryTypes[expression] = entryTypes
 	checker.Elaboration.DictionaryExpressionType[expression] = dictionaryType
 
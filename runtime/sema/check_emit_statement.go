@@ -24,6 +24,8 @@ import (
 )
 
 func (checker *Checker) VisitEmitStatement(statement *ast.EmitStatement) ast.Repr {
+	checker.recordImpureOperation("emit of event", statement)
+
 	invocation := statement.InvocationExpression
 
 	ty := checker.checkInvocationExpression(invocation)
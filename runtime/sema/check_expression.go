@@ -32,6 +32,16 @@ func (checker *Checker) VisitIdentifierExpression(expression *ast.IdentifierExpr
 
 	valueType := variable.Type
 
+	// The type of the variable might be narrowed, e.g. inside `if x != nil { ... }`
+
+	if !checker.ignoreNarrowing {
+		narrowedType, ok := checker.valueActivations.NarrowedType(variable)
+		if ok {
+			valueType = narrowedType
+			checker.Elaboration.NarrowedIdentifierExpressionTypes[expression] = narrowedType
+		}
+	}
+
 	if valueType.IsResourceType() {
 		checker.checkResourceVariableCapturingInFunction(variable, identifier)
 		checker.checkResourceUseAfterInvalidation(variable, identifier)
@@ -230,6 +240,39 @@ func (checker *Checker) VisitStringExpression(expression *ast.StringExpression)
 	return StringType
 }
 
+func (checker *Checker) VisitStringTemplateExpression(expression *ast.StringTemplateExpression) ast.Repr {
+	for _, valueExpression := range expression.Expressions {
+		valueType := checker.VisitExpression(valueExpression, nil)
+
+		if !valueType.IsInvalidType() &&
+			!IsValidStringInterpolationType(valueType) {
+
+			checker.report(
+				&InvalidStringInterpolationTypeError{
+					Type:  valueType,
+					Range: ast.NewRangeFromPositioned(valueExpression),
+				},
+			)
+		}
+	}
+
+	return StringType
+}
+
+// IsValidStringInterpolationType returns true if values of the given type
+// can be interpolated into a string, i.e. if they have a string representation
+//
+func IsValidStringInterpolationType(ty Type) bool {
+	switch ty {
+	case StringType, CharacterType, BoolType:
+		return true
+	}
+
+	return IsSubType(ty, NumberType) ||
+		IsSubType(ty, &AddressType{}) ||
+		IsSubType(ty, PathType)
+}
+
 func (checker *Checker) VisitIndexExpression(expression *ast.IndexExpression) ast.Repr {
 	return checker.visitIndexExpression(expression, false)
 }
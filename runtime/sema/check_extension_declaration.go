@@ -0,0 +1,369 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// VisitExtensionDeclaration checks a previously declared extension declaration.
+//
+// NOTE: This function assumes that the extension was previously declared
+// using `declareExtensionDeclaration`.
+//
+func (checker *Checker) VisitExtensionDeclaration(declaration *ast.ExtensionDeclaration) ast.Repr {
+
+	extensionType := checker.Elaboration.ExtensionDeclarationTypes[declaration]
+	if extensionType == nil {
+		panic(errors.NewUnreachableError())
+	}
+
+	// The functions of the extension may access the private members of the extension,
+	// but only the accessible members of the extended type
+
+	checker.containerTypes[extensionType] = true
+	defer func() {
+		checker.containerTypes[extensionType] = false
+	}()
+
+	checker.checkDeclarationAccessModifier(
+		declaration.Access,
+		declaration.DeclarationKind(),
+		declaration.StartPos,
+		true,
+	)
+
+	checker.checkNestedIdentifiers(declaration.Members)
+
+	checker.checkExtensionMemberDeclarations(declaration.Members)
+
+	extendedType := extensionType.ExtendedType
+	if extendedType == nil {
+		return nil
+	}
+
+	// Extensions may not redeclare or shadow members of the extended type,
+	// as it would be ambiguous which member is accessed
+
+	extendedMembers := extendedType.GetMembers()
+
+	for _, function := range declaration.Members.Functions() {
+		name := function.Identifier.Identifier
+		if _, ok := extendedMembers[name]; !ok {
+			continue
+		}
+
+		checker.report(
+			&ExtensionMemberConflictError{
+				Name:         name,
+				ExtendedType: extendedType,
+				Range:        ast.NewRangeFromPositioned(function.Identifier),
+			},
+		)
+	}
+
+	// Inside the functions, `self` is the extended value
+
+	checker.checkCompositeFunctions(
+		declaration.Members.Functions(),
+		extendedType,
+		declaration.DocString,
+	)
+
+	return nil
+}
+
+// checkExtensionMemberDeclarations reports all member declarations
+// which are not function declarations.
+//
+// Extensions cannot declare fields, as the storage of values is fixed by the extended type,
+// and neither initializers, destructors, nor nested declarations.
+//
+func (checker *Checker) checkExtensionMemberDeclarations(members *ast.Members) {
+	for _, declaration := range members.Declarations() {
+		if _, ok := declaration.(*ast.FunctionDeclaration); ok {
+			continue
+		}
+
+		checker.report(
+			&InvalidExtensionMemberError{
+				DeclarationKind: declaration.DeclarationKind(),
+				Range:           ast.NewRangeFromPositioned(declaration),
+			},
+		)
+	}
+}
+
+// declareExtensionDeclaration declares the extension in the current scope,
+// and makes its members available on values of the extended type.
+//
+// The extension type is only determined the first time the extension is declared.
+// A nested extension is declared again when the containing declaration is checked,
+// but errors are only reported once.
+//
+func (checker *Checker) declareExtensionDeclaration(
+	declaration *ast.ExtensionDeclaration,
+	containerType Type,
+) *ExtensionType {
+
+	extensionType, declared := checker.Elaboration.ExtensionDeclarationTypes[declaration]
+	if !declared {
+		extensionType = &ExtensionType{
+			Location:      checker.Location,
+			Identifier:    declaration.Identifier.Identifier,
+			containerType: containerType,
+		}
+
+		extensionType.ExtendedType = checker.extendedType(declaration.ExtendedType)
+
+		members, origins := checker.extensionMembersAndOrigins(
+			declaration.Members.Functions(),
+			extensionType,
+		)
+
+		extensionType.Members = members
+
+		if checker.positionInfoEnabled {
+			checker.memberOrigins[extensionType] = origins
+		}
+
+		checker.Elaboration.ExtensionDeclarationTypes[declaration] = extensionType
+
+		checker.addExtension(extensionType)
+	}
+
+	variable, err := checker.typeActivations.DeclareType(typeDeclaration{
+		identifier:               declaration.Identifier,
+		ty:                       extensionType,
+		declarationKind:          declaration.DeclarationKind(),
+		access:                   declaration.Access,
+		docString:                declaration.DocString,
+		allowOuterScopeShadowing: false,
+	})
+
+	if !declared {
+		checker.report(err)
+
+		if checker.positionInfoEnabled {
+			checker.recordVariableDeclarationOccurrence(
+				declaration.Identifier.Identifier,
+				variable,
+			)
+		}
+	}
+
+	return extensionType
+}
+
+// extendedType converts the given extended type of an extension declaration.
+// Only structures and resources can be extended.
+//
+// Returns nil if the type is invalid.
+//
+func (checker *Checker) extendedType(t *ast.NominalType) *CompositeType {
+	ty := checker.ConvertType(t)
+
+	if compositeType, ok := ty.(*CompositeType); ok {
+		switch compositeType.Kind {
+		case common.CompositeKindStructure,
+			common.CompositeKindResource:
+
+			return compositeType
+		}
+	}
+
+	if !ty.IsInvalidType() {
+		checker.report(
+			&InvalidExtendedTypeError{
+				Type:  ty,
+				Range: ast.NewRangeFromPositioned(t),
+			},
+		)
+	}
+
+	return nil
+}
+
+func (checker *Checker) extensionMembersAndOrigins(
+	functions []*ast.FunctionDeclaration,
+	extensionType *ExtensionType,
+) (
+	members *StringMemberOrderedMap,
+	origins map[string]*Origin,
+) {
+	members = NewStringMemberOrderedMap()
+	if checker.positionInfoEnabled {
+		origins = make(map[string]*Origin, len(functions))
+	}
+
+	for _, function := range functions {
+		identifier := function.Identifier.Identifier
+
+		functionType := checker.functionType(
+			function.Purity,
+			function.TypeParameterList,
+			function.ParameterList,
+			function.ReturnTypeAnnotation,
+		)
+
+		// The purity inferred for the function body must also apply to the member
+
+		checker.memberFunctionTypes[function] = functionType
+
+		members.Set(
+			identifier,
+			&Member{
+				ContainerType:   extensionType,
+				Access:          function.Access,
+				Identifier:      function.Identifier,
+				DeclarationKind: common.DeclarationKindFunction,
+				TypeAnnotation:  NewTypeAnnotation(functionType),
+				VariableKind:    ast.VariableKindConstant,
+				ArgumentLabels:  function.ParameterList.EffectiveArgumentLabels(),
+				DocString:       function.DocString,
+			},
+		)
+
+		if checker.positionInfoEnabled {
+			origins[identifier] =
+				checker.recordFunctionDeclarationOrigin(function, functionType)
+		}
+	}
+
+	return members, origins
+}
+
+// declareNestedExtensions declares the extensions nested in a composite declaration,
+// and registers them in the container type, so they are available to importers of it.
+//
+// Like nested composite and interface declarations,
+// extensions may only be nested in contracts.
+//
+func (checker *Checker) declareNestedExtensions(
+	declarations []*ast.ExtensionDeclaration,
+	containerType *CompositeType,
+	containerDeclarationKind common.DeclarationKind,
+) {
+	for _, declaration := range declarations {
+
+		_, declared := checker.Elaboration.ExtensionDeclarationTypes[declaration]
+
+		extensionType := checker.declareExtensionDeclaration(declaration, containerType)
+
+		if declared {
+			continue
+		}
+
+		if containerType.Kind != common.CompositeKindContract {
+			checker.report(
+				&InvalidNestedDeclarationError{
+					NestedDeclarationKind:    declaration.DeclarationKind(),
+					ContainerDeclarationKind: containerDeclarationKind,
+					Range:                    ast.NewRangeFromPositioned(declaration.Identifier),
+				},
+			)
+		}
+
+		containerType.extensions = append(containerType.extensions, extensionType)
+	}
+}
+
+// addExtension makes the members of the given extension available
+// on values of the extended type
+//
+func (checker *Checker) addExtension(extensionType *ExtensionType) {
+	for _, extension := range checker.extensions {
+		if extension.Equal(extensionType) {
+			return
+		}
+	}
+
+	checker.extensions = append(checker.extensions, extensionType)
+}
+
+// importExtensions makes the members of the given imported extensions available,
+// including the extensions declared in imported contracts
+//
+func (checker *Checker) importExtensions(ty Type) {
+	switch ty := ty.(type) {
+	case *ExtensionType:
+		checker.addExtension(ty)
+
+	case *CompositeType:
+		for _, extensionType := range ty.GetExtensions() {
+			checker.addExtension(extensionType)
+		}
+	}
+}
+
+// extensionMember returns the member with the given name
+// declared by an extension of the given type, if any.
+//
+// Members of extensions are available on values of the extended type,
+// and on references to them.
+//
+// If multiple extensions declare a member with the given name,
+// the access is ambiguous and an error is reported.
+//
+func (checker *Checker) extensionMember(ty Type, name string, memberRange ast.Range) *Member {
+
+	if referenceType, ok := ty.(*ReferenceType); ok {
+		ty = referenceType.Type
+	}
+
+	compositeType, ok := ty.(*CompositeType)
+	if !ok {
+		return nil
+	}
+
+	var member *Member
+	var extensions []*ExtensionType
+
+	for _, extension := range checker.extensions {
+		if !extension.Extends(compositeType) {
+			continue
+		}
+
+		extensionMember, ok := extension.Members.Get(name)
+		if !ok {
+			continue
+		}
+
+		if member == nil {
+			member = extensionMember
+		}
+
+		extensions = append(extensions, extension)
+	}
+
+	if len(extensions) > 1 {
+		checker.report(
+			&AmbiguousExtensionMemberError{
+				Name:       name,
+				Type:       compositeType,
+				Extensions: extensions,
+				Range:      memberRange,
+			},
+		)
+	}
+
+	return member
+}
@@ -32,6 +32,7 @@ func (checker *Checker) VisitForStatement(statement *ast.ForStatement) ast.Repr
 	valueType := checker.VisitExpression(valueExpression, nil)
 
 	var elementType Type = InvalidType
+	var indexType Type = InvalidType
 
 	if !valueType.IsInvalidType() {
 
@@ -46,35 +47,45 @@ func (checker *Checker) VisitForStatement(statement *ast.ForStatement) ast.Repr
 					Range: ast.NewRangeFromPositioned(valueExpression),
 				},
 			)
-		} else if arrayType, ok := valueType.(ArrayType); ok {
-			elementType = arrayType.ElementType(false)
 		} else {
-			checker.report(
-				&TypeMismatchWithDescriptionError{
-					ExpectedTypeDescription: "array",
-					ActualType:              valueType,
-					Range:                   ast.NewRangeFromPositioned(valueExpression),
-				},
-			)
+			switch valueType := valueType.(type) {
+			case ArrayType:
+				indexType = IntType
+				elementType = valueType.ElementType(false)
+
+			case *RangeType:
+				indexType = IntType
+				elementType = valueType.EffectiveElementType()
+
+			case *DictionaryType:
+				// A single loop variable is bound to the key,
+				// two loop variables are bound to the key and the value
+
+				if statement.Index != nil {
+					indexType = valueType.KeyType
+					elementType = valueType.ValueType
+				} else {
+					elementType = valueType.KeyType
+				}
+
+			default:
+				checker.report(
+					&TypeMismatchWithDescriptionError{
+						ExpectedTypeDescription: "array, dictionary, or range",
+						ActualType:              valueType,
+						Range:                   ast.NewRangeFromPositioned(valueExpression),
+					},
+				)
+			}
 		}
 	}
 
-	identifier := statement.Identifier.Identifier
-
-	variable, err := checker.valueActivations.Declare(variableDeclaration{
-		identifier:               identifier,
-		ty:                       elementType,
-		kind:                     common.DeclarationKindConstant,
-		pos:                      statement.Identifier.Pos,
-		isConstant:               true,
-		argumentLabels:           nil,
-		allowOuterScopeShadowing: false,
-	})
-	checker.report(err)
-	if checker.positionInfoEnabled {
-		checker.recordVariableDeclarationOccurrence(identifier, variable)
+	if statement.Index != nil {
+		checker.declareForVariable(*statement.Index, indexType)
 	}
 
+	checker.declareForVariable(statement.Identifier, elementType)
+
 	// The body of the loop will maybe be evaluated.
 	// That means that resource invalidations and
 	// returns are not definite, but only potential.
@@ -92,3 +103,19 @@ func (checker *Checker) VisitForStatement(statement *ast.ForStatement) ast.Repr
 
 	return nil
 }
+
+func (checker *Checker) declareForVariable(identifier ast.Identifier, ty Type) {
+	variable, err := checker.valueActivations.Declare(variableDeclaration{
+		identifier:               identifier.Identifier,
+		ty:                       ty,
+		kind:                     common.DeclarationKindConstant,
+		pos:                      identifier.Pos,
+		isConstant:               true,
+		argumentLabels:           nil,
+		allowOuterScopeShadowing: false,
+	})
+	checker.report(err)
+	if checker.positionInfoEnabled {
+		checker.recordVariableDeclarationOccurrence(identifier.Identifier, variable)
+	}
+}
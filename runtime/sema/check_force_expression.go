@@ -28,7 +28,9 @@ func (checker *Checker) VisitForceExpression(expression *ast.ForceExpression) as
 	// i.e: if `x!` is `String`, then `x` is expected to be `String?`.
 	expectedType := wrapWithOptionalIfNotNil(checker.expectedType)
 
-	valueType := checker.VisitExpression(expression.Expression, expectedType)
+	valueType := checker.visitExpressionWithoutNarrowing(expression.Expression, expectedType, true)
+
+	checker.Elaboration.ForceExpressionValueTypes[expression] = valueType
 
 	if valueType.IsInvalidType() {
 		return valueType
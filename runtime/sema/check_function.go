@@ -31,6 +31,7 @@ func (checker *Checker) VisitFunctionDeclaration(declaration *ast.FunctionDeclar
 			mustExit:          true,
 			declareFunction:   true,
 			checkResourceLoss: true,
+			inferPurity:       true,
 		},
 	)
 }
@@ -47,6 +48,10 @@ type functionDeclarationOptions struct {
 	// checkResourceLoss if the function should be checked for resource loss.
 	// For example, function declarations in interfaces should not be checked.
 	checkResourceLoss bool
+	// inferPurity specifies if the function should be inferred to be a view function
+	// if its body has no side effects. For example, function declarations in interfaces
+	// are only view functions if they are declared as such, as implementations may have side effects
+	inferPurity bool
 }
 
 func (checker *Checker) visitFunctionDeclaration(
@@ -65,7 +70,12 @@ func (checker *Checker) visitFunctionDeclaration(
 
 	functionType := checker.Elaboration.FunctionDeclarationFunctionTypes[declaration]
 	if functionType == nil {
-		functionType = checker.functionType(declaration.ParameterList, declaration.ReturnTypeAnnotation)
+		functionType = checker.functionType(
+			declaration.Purity,
+			declaration.TypeParameterList,
+			declaration.ParameterList,
+			declaration.ReturnTypeAnnotation,
+		)
 
 		if options.declareFunction {
 			checker.declareFunctionDeclaration(declaration, functionType)
@@ -74,6 +84,26 @@ func (checker *Checker) visitFunctionDeclaration(
 
 	checker.Elaboration.FunctionDeclarationFunctionTypes[declaration] = functionType
 
+	// If the function is generic, declare the type parameters for the function body
+
+	if len(functionType.TypeParameters) > 0 {
+		checker.typeActivations.Enter()
+		defer checker.typeActivations.Leave(declaration.EndPosition)
+
+		checker.declareTypeParameters(
+			functionType.TypeParameters,
+			declaration.TypeParameterList,
+			false,
+		)
+	}
+
+	if options.inferPurity && declaration.FunctionBlock != nil {
+		checker.beginFunctionPurityInference(
+			functionType,
+			checker.memberFunctionTypes[declaration],
+		)
+	}
+
 	checker.checkFunction(
 		declaration.ParameterList,
 		declaration.ReturnTypeAnnotation,
@@ -166,6 +196,8 @@ func (checker *Checker) checkFunction(
 			functionActivation.InitializationInfo = initializationInfo
 
 			if functionBlock != nil {
+				functionActivation.AssignedIdentifiers = assignedIdentifiers(functionBlock)
+
 				checker.visitFunctionBlock(
 					functionBlock,
 					functionType.ReturnTypeAnnotation,
@@ -333,7 +365,13 @@ func (checker *Checker) visitWithPostConditions(postConditions *ast.Conditions,
 
 		checker.Elaboration.PostConditionsRewrite[postConditions] = rewriteResult
 
+		// The extracted `before` expressions are part of the post-conditions,
+		// so they must not have side effects either
+
+		wasInCondition := checker.inCondition
+		checker.inCondition = true
 		checker.visitStatements(rewriteResult.BeforeStatements)
+		checker.inCondition = wasInCondition
 	}
 
 	body()
@@ -416,10 +454,17 @@ func (checker *Checker) declareBefore() {
 func (checker *Checker) VisitFunctionExpression(expression *ast.FunctionExpression) ast.Repr {
 
 	// TODO: infer
-	functionType := checker.functionType(expression.ParameterList, expression.ReturnTypeAnnotation)
+	functionType := checker.functionType(
+		expression.Purity,
+		nil,
+		expression.ParameterList,
+		expression.ReturnTypeAnnotation,
+	)
 
 	checker.Elaboration.FunctionExpressionFunctionType[expression] = functionType
 
+	checker.beginFunctionPurityInference(functionType, nil)
+
 	checker.checkFunction(
 		expression.ParameterList,
 		expression.ReturnTypeAnnotation,
@@ -161,6 +161,8 @@ func (checker *Checker) importResolvedLocation(resolvedLocation ResolvedLocation
 		imp.IsImportableType,
 	)
 
+	checker.importTypeExtensions(resolvedLocation.Identifiers, allTypeElements, imp.IsImportableType)
+
 	// For each identifier, report if the import is invalid due to
 	// restricted access and report an error (i.e. if there is
 	// both a value and type with the same name, only report a single error)
@@ -237,6 +239,37 @@ func (checker *Checker) importResolvedLocation(resolvedLocation ResolvedLocation
 	}
 }
 
+// importTypeExtensions makes the members of the extensions available
+// which are declared by the imported type declarations
+//
+func (checker *Checker) importTypeExtensions(
+	requestedIdentifiers []ast.Identifier,
+	availableElements *StringImportElementOrderedMap,
+	filter func(name string) bool,
+) {
+	if availableElements == nil {
+		return
+	}
+
+	if len(requestedIdentifiers) > 0 {
+		for _, identifier := range requestedIdentifiers {
+			name := identifier.Identifier
+			element, ok := availableElements.Get(name)
+			if !ok || !filter(name) {
+				continue
+			}
+			checker.importExtensions(element.Type)
+		}
+	} else {
+		availableElements.Foreach(func(name string, element ImportElement) {
+			if !filter(name) {
+				return
+			}
+			checker.importExtensions(element.Type)
+		})
+	}
+}
+
 func (checker *Checker) handleMissingImports(missing []ast.Identifier, available []string, importLocation common.Location) {
 	for _, identifier := range missing {
 		checker.report(
@@ -62,10 +62,17 @@ func (checker *Checker) VisitInterfaceDeclaration(declaration *ast.InterfaceDecl
 	checker.typeActivations.Enter()
 	defer checker.typeActivations.Leave(declaration.EndPosition)
 
-	// Declare nested types
+	// Declare nested types and type aliases
 
 	checker.declareInterfaceNestedTypes(declaration)
 
+	checker.declareNestedTypeAliases(
+		declaration.Members.TypeAliases(),
+		interfaceType,
+		interfaceType.CompositeKind,
+		declaration.DeclarationKind(),
+	)
+
 	checker.checkInitializers(
 		declaration.Members.Initializers(),
 		declaration.Members.Fields(),
@@ -120,6 +127,10 @@ func (checker *Checker) VisitInterfaceDeclaration(declaration *ast.InterfaceDecl
 		checker.visitCompositeDeclaration(nestedComposite, kind)
 	}
 
+	for _, typeAlias := range declaration.Members.TypeAliases() {
+		typeAlias.Accept(checker)
+	}
+
 	return nil
 }
 
@@ -215,6 +226,7 @@ func (checker *Checker) declareInterfaceType(declaration *ast.InterfaceDeclarati
 		Identifier:    identifier.Identifier,
 		CompositeKind: declaration.CompositeKind,
 		nestedTypes:   NewStringTypeOrderedMap(),
+		typeAliases:   NewStringTypeOrderedMap(),
 		Members:       NewStringMemberOrderedMap(),
 	}
 
@@ -303,6 +315,28 @@ func (checker *Checker) declareInterfaceMembers(declaration *ast.InterfaceDeclar
 
 	checker.declareInterfaceNestedTypes(declaration)
 
+	// NOTE: declare type aliases after nested types, as type aliases may refer to them,
+	// and before members, as members may refer to type aliases
+
+	checker.declareNestedTypeAliases(
+		declaration.Members.TypeAliases(),
+		interfaceType,
+		interfaceType.CompositeKind,
+		declaration.DeclarationKind(),
+	)
+
+	// Extensions may not be nested in interfaces
+
+	for _, extension := range declaration.Members.Extensions() {
+		checker.report(
+			&InvalidNestedDeclarationError{
+				NestedDeclarationKind:    extension.DeclarationKind(),
+				ContainerDeclarationKind: declaration.DeclarationKind(),
+				Range:                    ast.NewRangeFromPositioned(extension.Identifier),
+			},
+		)
+	}
+
 	// Declare members
 
 	members, fields, origins := checker.defaultMembersAndOrigins(
@@ -131,6 +131,13 @@ func (checker *Checker) checkInvocationExpression(invocationExpression *ast.Invo
 		checkInvocation()
 	}
 
+	// The creation of a resource is an impure operation itself,
+	// see VisitCreateExpression
+
+	if !inCreate {
+		checker.checkInvocationPurity(invocationExpression, functionType)
+	}
+
 	arguments := invocationExpression.Arguments
 
 	if checker.positionInfoEnabled && len(arguments) > 0 {
@@ -257,7 +264,7 @@ func (checker *Checker) checkConstructorInvocationWithResourceResult(
 	// NOTE: not using `isResourceType`,
 	// as only direct resource types can be constructed
 
-	if compositeReturnType, ok := returnType.(*CompositeType); !ok ||
+	if compositeReturnType, ok := compositeOrInstantiatedCompositeType(returnType); !ok ||
 		compositeReturnType.Kind != common.CompositeKindResource {
 
 		return
@@ -537,8 +544,19 @@ func (checker *Checker) checkInvocationRequiredArgument(
 		// param types can be used to infer the types for arguments.
 		argumentType = checker.VisitExpression(argument.Expression, parameterType)
 	} else {
+		// If all type parameters of a user-defined generic function are already bound,
+		// e.g. by explicit type arguments, the resolved parameter type
+		// can be used as the expected type of the argument.
+
+		var expectedType Type
+		if functionType.TypeParameters[0].isUserDefined &&
+			typeParameters.Len() == len(functionType.TypeParameters) {
+
+			expectedType = parameterType.Resolve(typeParameters)
+		}
+
 		// TODO: pass the expected type to support for parameters
-		argumentType = checker.VisitExpression(argument.Expression, nil)
+		argumentType = checker.VisitExpression(argument.Expression, expectedType)
 
 		// Try to unify the parameter type with the argument type.
 		// If unification fails, fall back to the parameter type for now.
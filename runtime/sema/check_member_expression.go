@@ -137,7 +137,11 @@ func (checker *Checker) visitMember(expression *ast.MemberExpression) (accessedT
 			checker.currentMemberExpression = previousMemberExpression
 		}()
 
-		accessedType = checker.VisitExpression(accessedExpression, nil)
+		if expression.Optional {
+			accessedType = checker.visitExpressionWithoutNarrowing(accessedExpression, nil, true)
+		} else {
+			accessedType = checker.VisitExpression(accessedExpression, nil)
+		}
 	}()
 
 	checker.checkUnusedExpressionResourceLoss(accessedType, accessedExpression)
@@ -176,6 +180,17 @@ func (checker *Checker) visitMember(expression *ast.MemberExpression) (accessedT
 	getMemberForType := func(expressionType Type) {
 		resolver, ok := expressionType.GetMembers()[identifier]
 		if !ok {
+			// The type itself has no member with the given name,
+			// but an extension of the type might declare it
+
+			member = checker.extensionMember(
+				expressionType,
+				identifier,
+				ast.Range{
+					StartPos: identifierStartPosition,
+					EndPos:   identifierEndPosition,
+				},
+			)
 			return
 		}
 		targetRange := ast.NewRangeFromPositioned(expression.Expression)
@@ -241,7 +256,11 @@ func (checker *Checker) visitMember(expression *ast.MemberExpression) (accessedT
 	} else {
 
 		if checker.positionInfoEnabled {
-			origins := checker.memberOrigins[accessedType]
+			originsType := accessedType
+			if extensionType, ok := member.ContainerType.(*ExtensionType); ok {
+				originsType = extensionType
+			}
+			origins := checker.memberOrigins[originsType]
 			origin := origins[identifier]
 			checker.Occurrences.Put(
 				identifierStartPosition,
@@ -0,0 +1,79 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// VisitRangeExpression checks a range expression `a..<b` or `a...b`.
+// Both bounds must have the same concrete integer type.
+//
+func (checker *Checker) VisitRangeExpression(expression *ast.RangeExpression) ast.Repr {
+
+	// If the range is contextually expected to have a certain element type,
+	// expect the bounds to have that type.
+
+	var expectedElementType Type
+	if expectedRangeType, ok := UnwrapOptionalType(checker.expectedType).(*RangeType); ok {
+		expectedElementType = expectedRangeType.ElementType
+	}
+
+	// If there is no expected element type, infer the element type from the bounds.
+	//
+	// If only the start is an integer literal, e.g. `0..<n`,
+	// the type of the end determines the element type,
+	// so that e.g. `0..<(10 as UInt8)` has type `Range<UInt8>`.
+
+	var startType, endType Type
+
+	_, startIsLiteral := expression.Start.(*ast.IntegerExpression)
+	_, endIsLiteral := expression.End.(*ast.IntegerExpression)
+
+	if expectedElementType == nil && startIsLiteral && !endIsLiteral {
+		endType = checker.VisitExpression(expression.End, nil)
+		startType = checker.VisitExpression(expression.Start, endType)
+	} else {
+		startType = checker.VisitExpression(expression.Start, expectedElementType)
+		endType = checker.VisitExpression(expression.End, startType)
+	}
+
+	elementType := startType
+
+	if startType.IsInvalidType() || endType.IsInvalidType() {
+		elementType = InvalidType
+	} else if !IsValidRangeElementType(startType) {
+		checker.report(
+			&TypeMismatchWithDescriptionError{
+				ExpectedTypeDescription: "concrete integer type",
+				ActualType:              startType,
+				Range:                   ast.NewRangeFromPositioned(expression.Start),
+			},
+		)
+		elementType = InvalidType
+	}
+
+	rangeType := &RangeType{
+		ElementType: elementType,
+	}
+
+	checker.Elaboration.RangeExpressionTypes[expression] = rangeType
+
+	return rangeType
+}
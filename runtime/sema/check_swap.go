@@ -31,6 +31,9 @@ func (checker *Checker) VisitSwapStatement(swap *ast.SwapStatement) ast.Repr {
 	checker.Elaboration.SwapStatementLeftTypes[swap] = leftType
 	checker.Elaboration.SwapStatementRightTypes[swap] = rightType
 
+	checker.checkAssignmentPurity(swap.Left)
+	checker.checkAssignmentPurity(swap.Right)
+
 	lhsValid := checker.checkSwapStatementExpression(swap.Left, leftType, common.OperandSideLeft)
 	rhsValid := checker.checkSwapStatementExpression(swap.Right, rightType, common.OperandSideRight)
 
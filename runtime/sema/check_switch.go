@@ -20,6 +20,7 @@ package sema
 
 import (
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 )
 
 func (checker *Checker) VisitSwitchStatement(statement *ast.SwitchStatement) ast.Repr {
@@ -49,6 +50,10 @@ func (checker *Checker) VisitSwitchStatement(statement *ast.SwitchStatement) ast
 		checker.visitSwitchCase(switchCase, defaultAllowed, testType, testTypeIsValid)
 	}
 
+	if testTypeIsValid {
+		checker.checkSwitchExhaustiveness(statement, testType)
+	}
+
 	checker.functionActivations.WithSwitch(func() {
 		checker.checkSwitchCasesStatements(statement.Cases)
 	})
@@ -125,6 +130,90 @@ func (checker *Checker) checkSwitchCaseExpression(
 	}
 }
 
+// checkSwitchExhaustiveness checks if a switch over an enum value
+// either has a default case or covers all cases of the enum,
+// and reports a hint listing the missing cases if it does not.
+//
+func (checker *Checker) checkSwitchExhaustiveness(statement *ast.SwitchStatement, testType Type) {
+
+	enumType, ok := testType.(*CompositeType)
+	if !ok || enumType.Kind != common.CompositeKindEnum {
+		return
+	}
+
+	coveredCases := make(map[string]struct{}, len(statement.Cases))
+
+	for _, switchCase := range statement.Cases {
+		if switchCase.Expression == nil {
+			// A default case makes the switch exhaustive
+			return
+		}
+
+		caseName, ok := checker.enumCaseName(switchCase.Expression, enumType)
+		if !ok {
+			continue
+		}
+
+		coveredCases[caseName] = struct{}{}
+	}
+
+	var missingCases []string
+
+	for _, caseName := range enumType.EnumCases {
+		if _, ok := coveredCases[caseName]; ok {
+			continue
+		}
+		missingCases = append(missingCases, caseName)
+	}
+
+	if len(missingCases) == 0 {
+		return
+	}
+
+	checker.hint(
+		&MissingSwitchCasesHint{
+			EnumType:     enumType,
+			MissingCases: missingCases,
+			Statement:    statement,
+			Range: ast.Range{
+				StartPos: statement.StartPos,
+				EndPos:   statement.Expression.EndPosition(),
+			},
+		},
+	)
+}
+
+// enumCaseName returns the name of the enum case the given case expression refers to,
+// if it is a member access of a case on the constructor of the given enum type,
+// e.g. `Color.red`.
+//
+func (checker *Checker) enumCaseName(expression ast.Expression, enumType *CompositeType) (string, bool) {
+
+	memberExpression, ok := expression.(*ast.MemberExpression)
+	if !ok || memberExpression.Optional {
+		return "", false
+	}
+
+	memberInfo, ok := checker.Elaboration.MemberExpressionMemberInfos[memberExpression]
+	if !ok || memberInfo.Member == nil {
+		return "", false
+	}
+
+	constructorType, ok := memberInfo.AccessedType.(*FunctionType)
+	if !ok || !constructorType.IsConstructor {
+		return "", false
+	}
+
+	member := memberInfo.Member
+	if member.DeclarationKind != common.DeclarationKindField ||
+		!member.TypeAnnotation.Type.Equal(enumType) {
+
+		return "", false
+	}
+
+	return member.Identifier.Identifier, true
+}
+
 func (checker *Checker) checkSwitchCasesStatements(cases []*ast.SwitchCase) {
 	caseCount := len(cases)
 	if caseCount == 0 {
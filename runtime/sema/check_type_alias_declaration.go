@@ -0,0 +1,263 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+// VisitTypeAliasDeclaration checks a previously declared type alias declaration.
+//
+// NOTE: This function assumes that the type alias was previously declared
+// using `declareTypeAliasDeclaration`.
+//
+func (checker *Checker) VisitTypeAliasDeclaration(declaration *ast.TypeAliasDeclaration) ast.Repr {
+
+	ty, ok := checker.Elaboration.TypeAliasDeclarationTypes[declaration]
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	checker.checkDeclarationAccessModifier(
+		declaration.Access,
+		declaration.DeclarationKind(),
+		declaration.StartPos,
+		true,
+	)
+
+	typeAnnotation := &TypeAnnotation{
+		IsResource: declaration.TypeAnnotation.IsResource,
+		Type:       ty,
+	}
+
+	checker.checkTypeAnnotation(typeAnnotation, declaration.TypeAnnotation)
+
+	return nil
+}
+
+// declareTypeAliasDeclaration declares the type alias in the current scope,
+// and returns the target type of the type alias.
+//
+// Type aliases are transparent, i.e. the declared type is the target type itself.
+//
+// The target type is only determined the first time the type alias is declared.
+// A nested type alias is declared again when the containing declaration is checked,
+// but errors are only reported once.
+//
+// The target type of a cyclic type alias can never be determined,
+// so the type alias is declared with the invalid type.
+//
+func (checker *Checker) declareTypeAliasDeclaration(declaration *ast.TypeAliasDeclaration, cyclic bool) Type {
+
+	ty, declared := checker.Elaboration.TypeAliasDeclarationTypes[declaration]
+	if !declared {
+		if cyclic {
+			checker.report(
+				&CyclicTypeAliasError{
+					Name:  declaration.Identifier.Identifier,
+					Range: ast.NewRangeFromPositioned(declaration.Identifier),
+				},
+			)
+			ty = InvalidType
+		} else {
+			ty = checker.ConvertType(declaration.TypeAnnotation.Type)
+		}
+		checker.Elaboration.TypeAliasDeclarationTypes[declaration] = ty
+	}
+
+	variable, err := checker.typeActivations.DeclareType(typeDeclaration{
+		identifier:               declaration.Identifier,
+		ty:                       ty,
+		declarationKind:          declaration.DeclarationKind(),
+		access:                   declaration.Access,
+		docString:                declaration.DocString,
+		allowOuterScopeShadowing: false,
+	})
+
+	if !declared {
+		checker.report(err)
+
+		if checker.positionInfoEnabled {
+			checker.recordVariableDeclarationOccurrence(
+				declaration.Identifier.Identifier,
+				variable,
+			)
+		}
+	}
+
+	return ty
+}
+
+// declareNestedTypeAliases declares the type aliases nested in a composite or interface declaration,
+// and registers them in the container type, so they can be referred to in qualified types.
+//
+// Like nested composite and interface declarations,
+// type aliases may only be nested in contracts and contract interfaces.
+//
+func (checker *Checker) declareNestedTypeAliases(
+	declarations []*ast.TypeAliasDeclaration,
+	containerType TypeAliasContainerType,
+	containerCompositeKind common.CompositeKind,
+	containerDeclarationKind common.DeclarationKind,
+) {
+	cyclicDeclarations := cyclicTypeAliases(declarations)
+
+	for _, declaration := range declarations {
+
+		_, declared := checker.Elaboration.TypeAliasDeclarationTypes[declaration]
+
+		_, cyclic := cyclicDeclarations[declaration]
+		ty := checker.declareTypeAliasDeclaration(declaration, cyclic)
+
+		if declared {
+			continue
+		}
+
+		if containerCompositeKind != common.CompositeKindContract {
+			checker.report(
+				&InvalidNestedDeclarationError{
+					NestedDeclarationKind:    declaration.DeclarationKind(),
+					ContainerDeclarationKind: containerDeclarationKind,
+					Range:                    ast.NewRangeFromPositioned(declaration.Identifier),
+				},
+			)
+		}
+
+		containerType.GetTypeAliases().Set(declaration.Identifier.Identifier, ty)
+	}
+}
+
+// cyclicTypeAliases returns the type aliases of the given declarations,
+// which are declared in the same scope, that refer to themselves,
+// directly or through other type aliases of the given declarations,
+// e.g. `typealias A = B` and `typealias B = A`.
+//
+func cyclicTypeAliases(declarations []*ast.TypeAliasDeclaration) map[*ast.TypeAliasDeclaration]struct{} {
+
+	declarationsByIdentifier := map[string]*ast.TypeAliasDeclaration{}
+	for _, declaration := range declarations {
+		identifier := declaration.Identifier.Identifier
+		if _, ok := declarationsByIdentifier[identifier]; ok {
+			continue
+		}
+		declarationsByIdentifier[identifier] = declaration
+	}
+
+	result := map[*ast.TypeAliasDeclaration]struct{}{}
+
+	// visiting are the declarations currently being visited, i.e. the current path,
+	// visited are the declarations which were completely visited
+
+	var path []*ast.TypeAliasDeclaration
+	visiting := map[*ast.TypeAliasDeclaration]bool{}
+	visited := map[*ast.TypeAliasDeclaration]bool{}
+
+	var visit func(declaration *ast.TypeAliasDeclaration)
+	visit = func(declaration *ast.TypeAliasDeclaration) {
+		if visiting[declaration] {
+			// All declarations on the path since the first visit are part of the cycle
+
+			for i := len(path) - 1; i >= 0; i-- {
+				result[path[i]] = struct{}{}
+				if path[i] == declaration {
+					break
+				}
+			}
+			return
+		}
+
+		if visited[declaration] {
+			return
+		}
+
+		visiting[declaration] = true
+		path = append(path, declaration)
+
+		forEachNominalTypeIdentifier(
+			declaration.TypeAnnotation.Type,
+			func(identifier string) {
+				referencedDeclaration, ok := declarationsByIdentifier[identifier]
+				if ok {
+					visit(referencedDeclaration)
+				}
+			},
+		)
+
+		path = path[:len(path)-1]
+		visiting[declaration] = false
+		visited[declaration] = true
+	}
+
+	for _, declaration := range declarations {
+		visit(declaration)
+	}
+
+	return result
+}
+
+// forEachNominalTypeIdentifier calls the given function for the identifier
+// of each nominal type that occurs in the given type.
+// For qualified nominal types, e.g. `C.T`, only the first identifier is reported.
+//
+func forEachNominalTypeIdentifier(t ast.Type, f func(identifier string)) {
+	switch t := t.(type) {
+	case *ast.NominalType:
+		f(t.Identifier.Identifier)
+
+	case *ast.OptionalType:
+		forEachNominalTypeIdentifier(t.Type, f)
+
+	case *ast.VariableSizedType:
+		forEachNominalTypeIdentifier(t.Type, f)
+
+	case *ast.ConstantSizedType:
+		forEachNominalTypeIdentifier(t.Type, f)
+
+	case *ast.DictionaryType:
+		forEachNominalTypeIdentifier(t.KeyType, f)
+		forEachNominalTypeIdentifier(t.ValueType, f)
+
+	case *ast.FunctionType:
+		for _, parameterTypeAnnotation := range t.ParameterTypeAnnotations {
+			forEachNominalTypeIdentifier(parameterTypeAnnotation.Type, f)
+		}
+		if t.ReturnTypeAnnotation != nil {
+			forEachNominalTypeIdentifier(t.ReturnTypeAnnotation.Type, f)
+		}
+
+	case *ast.ReferenceType:
+		forEachNominalTypeIdentifier(t.Type, f)
+
+	case *ast.RestrictedType:
+		if t.Type != nil {
+			forEachNominalTypeIdentifier(t.Type, f)
+		}
+		for _, restriction := range t.Restrictions {
+			forEachNominalTypeIdentifier(restriction, f)
+		}
+
+	case *ast.InstantiationType:
+		forEachNominalTypeIdentifier(t.Type, f)
+		for _, typeArgument := range t.TypeArguments {
+			forEachNominalTypeIdentifier(typeArgument.Type, f)
+		}
+	}
+}
@@ -59,7 +59,12 @@ func (checker *Checker) visitVariableDeclaration(declaration *ast.VariableDeclar
 		}
 	}
 
-	valueType := checker.VisitExpression(declaration.Value, expectedValueType)
+	var valueType Type
+	if isOptionalBinding {
+		valueType = checker.visitExpressionWithoutNarrowing(declaration.Value, expectedValueType, true)
+	} else {
+		valueType = checker.VisitExpression(declaration.Value, expectedValueType)
+	}
 
 	checker.Elaboration.VariableDeclarationValueTypes[declaration] = valueType
 
@@ -49,6 +49,7 @@ var beforeType = func() *FunctionType {
 	)
 
 	return &FunctionType{
+		Purity: ast.FunctionPurityView,
 		TypeParameters: []*TypeParameter{
 			typeParameter,
 		},
@@ -92,6 +93,7 @@ type Checker struct {
 	resources                          *Resources
 	typeActivations                    *VariableActivations
 	containerTypes                     map[Type]bool
+	extensions                         []*ExtensionType
 	functionActivations                *FunctionActivations
 	inCondition                        bool
 	positionInfoEnabled                bool
@@ -105,6 +107,7 @@ type Checker struct {
 	inCreate                           bool
 	inInvocation                       bool
 	inAssignment                       bool
+	ignoreNarrowing                    bool
 	allowSelfResourceFieldInvalidation bool
 	Elaboration                        *Elaboration
 	currentMemberExpression            *ast.MemberExpression
@@ -115,6 +118,9 @@ type Checker struct {
 	checkHandler                       CheckHandlerFunc
 	expectedType                       Type
 	memberAccountAccessHandler         MemberAccountAccessHandlerFunc
+	functionPurityInfos                map[*FunctionType]*functionPurityInfo
+	memberFunctionTypes                map[*ast.FunctionDeclaration]*FunctionType
+	purityObligations                  []purityObligation
 }
 
 type Option func(*Checker) error
@@ -258,6 +264,8 @@ func NewChecker(program *ast.Program, location common.Location, options ...Optio
 		functionActivations: functionActivations,
 		containerTypes:      map[Type]bool{},
 		Elaboration:         NewElaboration(),
+		functionPurityInfos: map[*FunctionType]*functionPurityInfo{},
+		memberFunctionTypes: map[*ast.FunctionDeclaration]*FunctionType{},
 	}
 
 	checker.beforeExtractor = NewBeforeExtractor(checker.report)
@@ -465,6 +473,27 @@ func (checker *Checker) VisitProgram(program *ast.Program) ast.Repr {
 		VisitThisAndNested(compositeType, registerInElaboration)
 	}
 
+	// Declare type aliases.
+	// NOTE: after interface and composite types, as type aliases may refer to them,
+	// and before their members, as the members may refer to type aliases
+
+	typeAliasDeclarations := program.TypeAliasDeclarations()
+	cyclicTypeAliasDeclarations := cyclicTypeAliases(typeAliasDeclarations)
+
+	for _, declaration := range typeAliasDeclarations {
+		_, cyclic := cyclicTypeAliasDeclarations[declaration]
+		checker.declareTypeAliasDeclaration(declaration, cyclic)
+	}
+
+	// Declare extensions.
+	// NOTE: after type aliases, as the extended type and the functions may refer to them.
+	// The members of the extended type are not declared yet,
+	// so conflicts are only checked when the extensions are checked
+
+	for _, declaration := range program.ExtensionDeclarations() {
+		checker.declareExtensionDeclaration(declaration, nil)
+	}
+
 	// Declare interfaces' and composites' members
 
 	for _, declaration := range program.InterfaceDeclarations() {
@@ -502,6 +531,11 @@ func (checker *Checker) VisitProgram(program *ast.Program) ast.Repr {
 		checker.declareGlobalDeclaration(declaration)
 	}
 
+	// Infer the purity of functions,
+	// after all function bodies and conditions have been checked
+
+	checker.inferFunctionPurity()
+
 	return nil
 }
 
@@ -550,7 +584,12 @@ func (checker *Checker) checkTopLevelDeclarationValidity(declarations []ast.Decl
 }
 
 func (checker *Checker) declareGlobalFunctionDeclaration(declaration *ast.FunctionDeclaration) {
-	functionType := checker.functionType(declaration.ParameterList, declaration.ReturnTypeAnnotation)
+	functionType := checker.functionType(
+		declaration.Purity,
+		declaration.TypeParameterList,
+		declaration.ParameterList,
+		declaration.ReturnTypeAnnotation,
+	)
 	checker.Elaboration.FunctionDeclarationFunctionTypes[declaration] = functionType
 	checker.declareFunctionDeclaration(declaration, functionType)
 }
@@ -904,7 +943,9 @@ func (checker *Checker) findAndCheckValueVariable(identifierExpression *ast.Iden
 func (checker *Checker) ConvertType(t ast.Type) Type {
 	switch t := t.(type) {
 	case *ast.NominalType:
-		return checker.convertNominalType(t)
+		ty := checker.convertNominalType(t)
+		checker.checkGenericCompositeTypeInstantiated(ty, t)
+		return ty
 
 	case *ast.VariableSizedType:
 		return checker.convertVariableSizedType(t)
@@ -1292,11 +1333,21 @@ func (checker *Checker) convertNominalType(t *ast.NominalType) Type {
 
 	ty := variable.Type
 
+	if _, ok := ty.(*ExtensionType); ok {
+		checker.report(
+			&InvalidExtensionTypeUseError{
+				Name:  t.Identifier.Identifier,
+				Range: ast.NewRangeFromPositioned(t),
+			},
+		)
+		return InvalidType
+	}
+
 	var resolvedIdentifiers []ast.Identifier
 
 	for _, identifier := range t.NestedIdentifiers {
 		if containerType, ok := ty.(ContainerType); ok && containerType.isContainerType() {
-			ty, _ = containerType.GetNestedTypes().Get(identifier.Identifier)
+			ty, _ = GetNestedTypeOrAlias(containerType, identifier.Identifier)
 		} else {
 			if !ty.IsInvalidType() {
 				checker.report(
@@ -1347,20 +1398,101 @@ func (checker *Checker) ConvertTypeAnnotation(typeAnnotation *ast.TypeAnnotation
 }
 
 func (checker *Checker) functionType(
+	purity ast.FunctionPurity,
+	typeParameterList *ast.TypeParameterList,
 	parameterList *ast.ParameterList,
 	returnTypeAnnotation *ast.TypeAnnotation,
 ) *FunctionType {
+
+	// If the function is generic, declare the type parameters,
+	// so that the parameter types and the return type may refer to them
+
+	var typeParameters []*TypeParameter
+
+	if !typeParameterList.IsEmpty() {
+		typeParameters = checker.typeParameters(typeParameterList)
+
+		checker.typeActivations.Enter()
+		defer checker.typeActivations.Leave(typeParameterList.EndPosition)
+
+		checker.declareTypeParameters(typeParameters, typeParameterList, true)
+	}
+
 	convertedParameters := checker.parameters(parameterList)
 
 	convertedReturnTypeAnnotation :=
 		checker.ConvertTypeAnnotation(returnTypeAnnotation)
 
 	return &FunctionType{
+		Purity:               purity,
+		TypeParameters:       typeParameters,
 		Parameters:           convertedParameters,
 		ReturnTypeAnnotation: convertedReturnTypeAnnotation,
 	}
 }
 
+// typeParameters converts the type parameters of a generic function or composite declaration.
+//
+// A type parameter without a type bound is bounded by `AnyStruct`,
+// so it is known if values of the type parameter's type are resources.
+//
+func (checker *Checker) typeParameters(typeParameterList *ast.TypeParameterList) []*TypeParameter {
+
+	if typeParameterList.IsEmpty() {
+		return nil
+	}
+
+	typeParameters := make([]*TypeParameter, len(typeParameterList.TypeParameters))
+
+	for i, typeParameter := range typeParameterList.TypeParameters {
+
+		var typeBound Type = AnyStructType
+
+		// NOTE: the type bound does not require a resource annotation,
+		// e.g. `T: AnyResource`
+
+		if typeParameter.TypeBound != nil {
+			typeBound = checker.ConvertType(typeParameter.TypeBound.Type)
+		}
+
+		typeParameters[i] = &TypeParameter{
+			Name:          typeParameter.Identifier.Identifier,
+			TypeBound:     typeBound,
+			isUserDefined: true,
+		}
+	}
+
+	return typeParameters
+}
+
+// declareTypeParameters declares the given type parameters in the current type scope.
+// Redeclaration errors are only reported if reportErrors is true,
+// as the type parameters of a declaration are declared multiple times,
+// e.g. once for the function type and once for the function body.
+//
+func (checker *Checker) declareTypeParameters(
+	typeParameters []*TypeParameter,
+	typeParameterList *ast.TypeParameterList,
+	reportErrors bool,
+) {
+	for i, typeParameter := range typeParameters {
+		identifier := typeParameterList.TypeParameters[i].Identifier
+
+		_, err := checker.typeActivations.DeclareType(typeDeclaration{
+			identifier: identifier,
+			ty: &GenericType{
+				TypeParameter: typeParameter,
+			},
+			declarationKind:          common.DeclarationKindTypeParameter,
+			access:                   ast.AccessNotSpecified,
+			allowOuterScopeShadowing: false,
+		})
+		if reportErrors {
+			checker.report(err)
+		}
+	}
+}
+
 func (checker *Checker) parameters(parameterList *ast.ParameterList) []*Parameter {
 
 	parameters := make([]*Parameter, len(parameterList.Parameters))
@@ -2255,9 +2387,41 @@ func (checker *Checker) effectiveCompositeMemberAccess(access ast.Access) ast.Ac
 	}
 }
 
+// checkGenericCompositeTypeInstantiated reports an error
+// if the given type is a generic composite type without type arguments,
+// e.g. `Box` for `struct Box<T>`
+//
+func (checker *Checker) checkGenericCompositeTypeInstantiated(ty Type, pos ast.HasPosition) {
+	compositeType, ok := ty.(*CompositeType)
+	if !ok || len(compositeType.TypeParameters) == 0 {
+		return
+	}
+
+	checker.report(
+		&MissingTypeArgumentsError{
+			Type:  compositeType,
+			Range: ast.NewRangeFromPositioned(pos),
+		},
+	)
+}
+
 func (checker *Checker) convertInstantiationType(t *ast.InstantiationType) Type {
 
-	ty := checker.ConvertType(t.Type)
+	// NOTE: generic composite types must be instantiated,
+	// so do not check the instantiated nominal type to be instantiated
+
+	var ty Type
+	if nominalType, ok := t.Type.(*ast.NominalType); ok {
+		ty = checker.convertNominalType(nominalType)
+	} else {
+		ty = checker.ConvertType(t.Type)
+	}
+
+	if compositeType, ok := ty.(*CompositeType); ok &&
+		len(compositeType.TypeParameters) > 0 {
+
+		ty = NewInstantiatedCompositeType(compositeType, nil)
+	}
 
 	// Always convert (check) the type arguments,
 	// even if the instantiated type
@@ -2330,6 +2494,12 @@ func (checker *Checker) convertInstantiationType(t *ast.InstantiationType) Type
 			},
 		)
 
+		// A generic composite type cannot be used without all type arguments
+
+		if _, ok := ty.(*InstantiatedCompositeType); ok {
+			return InvalidType
+		}
+
 		// Just return the converted instantiated type as-is
 
 		return ty
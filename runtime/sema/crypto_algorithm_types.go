@@ -19,6 +19,7 @@
 package sema
 
 import (
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 )
@@ -43,6 +44,7 @@ var HashAlgorithms = []CryptoAlgorithm{
 var SignatureAlgorithmType = newNativeEnumType(
 	SignatureAlgorithmTypeName,
 	UInt8Type,
+	SignatureAlgorithms,
 	nil,
 )
 
@@ -110,6 +112,7 @@ func (algo SignatureAlgorithm) DocString() string {
 const HashAlgorithmTypeHashFunctionName = "hash"
 
 var HashAlgorithmTypeHashFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Label:          ArgumentLabelNotRequired,
@@ -129,6 +132,7 @@ Returns the hash of the given data
 const HashAlgorithmTypeHashWithTagFunctionName = "hashWithTag"
 
 var HashAlgorithmTypeHashWithTagFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Label:      ArgumentLabelNotRequired,
@@ -154,6 +158,7 @@ Returns the hash of the given data and tag
 var HashAlgorithmType = newNativeEnumType(
 	HashAlgorithmTypeName,
 	UInt8Type,
+	HashAlgorithms,
 	func(enumType *CompositeType) []*Member {
 		return []*Member{
 			NewPublicFunctionMember(
@@ -249,11 +254,19 @@ func (algo HashAlgorithm) DocString() string {
 func newNativeEnumType(
 	identifier string,
 	rawType Type,
+	enumCases []NativeEnumCase,
 	membersConstructor func(enumType *CompositeType) []*Member,
 ) *CompositeType {
+
+	caseNames := make([]string, len(enumCases))
+	for i, enumCase := range enumCases {
+		caseNames[i] = enumCase.Name()
+	}
+
 	ty := &CompositeType{
 		Identifier:  identifier,
 		EnumRawType: rawType,
+		EnumCases:   caseNames,
 		Kind:        common.CompositeKindEnum,
 		importable:  true,
 	}
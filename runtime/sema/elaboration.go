@@ -51,16 +51,23 @@ type Elaboration struct {
 	InvocationExpressionTypeArguments   map[*ast.InvocationExpression]*TypeParameterTypeOrderedMap
 	CastingStaticValueTypes             map[*ast.CastingExpression]Type
 	CastingTargetTypes                  map[*ast.CastingExpression]Type
+	CastingExpectedTypes                map[*ast.CastingExpression]Type
+	ForceExpressionValueTypes           map[*ast.ForceExpression]Type
 	ReturnStatementValueTypes           map[*ast.ReturnStatement]Type
 	ReturnStatementReturnTypes          map[*ast.ReturnStatement]Type
 	BinaryExpressionResultTypes         map[*ast.BinaryExpression]Type
+	BinaryExpressionLeftTypes           map[*ast.BinaryExpression]Type
 	BinaryExpressionRightTypes          map[*ast.BinaryExpression]Type
+	ConditionalExpressionThenTypes      map[*ast.ConditionalExpression]Type
+	ConditionalExpressionElseTypes      map[*ast.ConditionalExpression]Type
+	ConditionalExpressionResultTypes    map[*ast.ConditionalExpression]Type
 	MemberExpressionMemberInfos         map[*ast.MemberExpression]MemberInfo
 	MemberExpressionExpectedTypes       map[*ast.MemberExpression]Type
 	ArrayExpressionArgumentTypes        map[*ast.ArrayExpression][]Type
 	ArrayExpressionArrayType            map[*ast.ArrayExpression]ArrayType
 	DictionaryExpressionType            map[*ast.DictionaryExpression]*DictionaryType
 	DictionaryExpressionEntryTypes      map[*ast.DictionaryExpression][]DictionaryEntryType
+	RangeExpressionTypes                map[*ast.RangeExpression]*RangeType
 	IntegerExpressionType               map[*ast.IntegerExpression]Type
 	FixedPointExpression                map[*ast.FixedPointExpression]Type
 	TransactionDeclarationTypes         map[*ast.TransactionDeclaration]*TransactionType
@@ -69,11 +76,14 @@ type Elaboration struct {
 	IsResourceMoveIndexExpression       map[*ast.IndexExpression]bool
 	CompositeNestedDeclarations         map[*ast.CompositeDeclaration]map[string]ast.Declaration
 	InterfaceNestedDeclarations         map[*ast.InterfaceDeclaration]map[string]ast.Declaration
+	TypeAliasDeclarationTypes           map[*ast.TypeAliasDeclaration]Type
+	ExtensionDeclarationTypes           map[*ast.ExtensionDeclaration]*ExtensionType
 	PostConditionsRewrite               map[*ast.Conditions]PostConditionsRewrite
 	EmitStatementEventTypes             map[*ast.EmitStatement]*CompositeType
 	CompositeTypes                      map[TypeID]*CompositeType
 	InterfaceTypes                      map[TypeID]*InterfaceType
 	IdentifierInInvocationTypes         map[*ast.IdentifierExpression]Type
+	NarrowedIdentifierExpressionTypes   map[*ast.IdentifierExpression]Type
 	ImportDeclarationsResolvedLocations map[*ast.ImportDeclaration][]ResolvedLocation
 	GlobalValues                        *StringVariableOrderedMap
 	GlobalTypes                         *StringVariableOrderedMap
@@ -106,16 +116,23 @@ func NewElaboration() *Elaboration {
 		InvocationExpressionTypeArguments:   map[*ast.InvocationExpression]*TypeParameterTypeOrderedMap{},
 		CastingStaticValueTypes:             map[*ast.CastingExpression]Type{},
 		CastingTargetTypes:                  map[*ast.CastingExpression]Type{},
+		CastingExpectedTypes:                map[*ast.CastingExpression]Type{},
+		ForceExpressionValueTypes:           map[*ast.ForceExpression]Type{},
 		ReturnStatementValueTypes:           map[*ast.ReturnStatement]Type{},
 		ReturnStatementReturnTypes:          map[*ast.ReturnStatement]Type{},
 		BinaryExpressionResultTypes:         map[*ast.BinaryExpression]Type{},
+		BinaryExpressionLeftTypes:           map[*ast.BinaryExpression]Type{},
 		BinaryExpressionRightTypes:          map[*ast.BinaryExpression]Type{},
+		ConditionalExpressionThenTypes:      map[*ast.ConditionalExpression]Type{},
+		ConditionalExpressionElseTypes:      map[*ast.ConditionalExpression]Type{},
+		ConditionalExpressionResultTypes:    map[*ast.ConditionalExpression]Type{},
 		MemberExpressionMemberInfos:         map[*ast.MemberExpression]MemberInfo{},
 		MemberExpressionExpectedTypes:       map[*ast.MemberExpression]Type{},
 		ArrayExpressionArgumentTypes:        map[*ast.ArrayExpression][]Type{},
 		ArrayExpressionArrayType:            map[*ast.ArrayExpression]ArrayType{},
 		DictionaryExpressionType:            map[*ast.DictionaryExpression]*DictionaryType{},
 		DictionaryExpressionEntryTypes:      map[*ast.DictionaryExpression][]DictionaryEntryType{},
+		RangeExpressionTypes:                map[*ast.RangeExpression]*RangeType{},
 		IntegerExpressionType:               map[*ast.IntegerExpression]Type{},
 		FixedPointExpression:                map[*ast.FixedPointExpression]Type{},
 		TransactionDeclarationTypes:         map[*ast.TransactionDeclaration]*TransactionType{},
@@ -124,11 +141,14 @@ func NewElaboration() *Elaboration {
 		IsResourceMoveIndexExpression:       map[*ast.IndexExpression]bool{},
 		CompositeNestedDeclarations:         map[*ast.CompositeDeclaration]map[string]ast.Declaration{},
 		InterfaceNestedDeclarations:         map[*ast.InterfaceDeclaration]map[string]ast.Declaration{},
+		TypeAliasDeclarationTypes:           map[*ast.TypeAliasDeclaration]Type{},
+		ExtensionDeclarationTypes:           map[*ast.ExtensionDeclaration]*ExtensionType{},
 		PostConditionsRewrite:               map[*ast.Conditions]PostConditionsRewrite{},
 		EmitStatementEventTypes:             map[*ast.EmitStatement]*CompositeType{},
 		CompositeTypes:                      map[TypeID]*CompositeType{},
 		InterfaceTypes:                      map[TypeID]*InterfaceType{},
 		IdentifierInInvocationTypes:         map[*ast.IdentifierExpression]Type{},
+		NarrowedIdentifierExpressionTypes:   map[*ast.IdentifierExpression]Type{},
 		ImportDeclarationsResolvedLocations: map[*ast.ImportDeclaration][]ResolvedLocation{},
 		GlobalValues:                        NewStringVariableOrderedMap(),
 		GlobalTypes:                         NewStringVariableOrderedMap(),
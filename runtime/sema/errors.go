@@ -2415,6 +2415,113 @@ func (e *InvalidNestedTypeError) EndPosition() ast.Position {
 	return e.Type.EndPosition()
 }
 
+// InvalidExtendedTypeError
+
+type InvalidExtendedTypeError struct {
+	Type Type
+	ast.Range
+}
+
+func (e *InvalidExtendedTypeError) Error() string {
+	return fmt.Sprintf(
+		"cannot extend type `%s`",
+		e.Type.QualifiedString(),
+	)
+}
+
+func (*InvalidExtendedTypeError) SecondaryError() string {
+	return "only structures and resources can be extended"
+}
+
+func (*InvalidExtendedTypeError) isSemanticError() {}
+
+// InvalidExtensionMemberError
+
+type InvalidExtensionMemberError struct {
+	DeclarationKind common.DeclarationKind
+	ast.Range
+}
+
+func (e *InvalidExtensionMemberError) Error() string {
+	return fmt.Sprintf(
+		"%s declarations are not allowed in extensions",
+		e.DeclarationKind.Name(),
+	)
+}
+
+func (*InvalidExtensionMemberError) SecondaryError() string {
+	return "extensions may only declare functions"
+}
+
+func (*InvalidExtensionMemberError) isSemanticError() {}
+
+// ExtensionMemberConflictError
+
+type ExtensionMemberConflictError struct {
+	Name         string
+	ExtendedType Type
+	ast.Range
+}
+
+func (e *ExtensionMemberConflictError) Error() string {
+	return fmt.Sprintf(
+		"cannot declare `%s` in extension: type `%s` already has a member with the same name",
+		e.Name,
+		e.ExtendedType.QualifiedString(),
+	)
+}
+
+func (*ExtensionMemberConflictError) isSemanticError() {}
+
+// AmbiguousExtensionMemberError
+
+type AmbiguousExtensionMemberError struct {
+	Name       string
+	Type       Type
+	Extensions []*ExtensionType
+	ast.Range
+}
+
+func (e *AmbiguousExtensionMemberError) Error() string {
+	return fmt.Sprintf(
+		"ambiguous member `%s` of type `%s`",
+		e.Name,
+		e.Type.QualifiedString(),
+	)
+}
+
+func (e *AmbiguousExtensionMemberError) SecondaryError() string {
+	var builder strings.Builder
+	builder.WriteString("declared in extensions ")
+	for i, extension := range e.Extensions {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteRune('`')
+		builder.WriteString(extension.QualifiedString())
+		builder.WriteRune('`')
+	}
+	return builder.String()
+}
+
+func (*AmbiguousExtensionMemberError) isSemanticError() {}
+
+// InvalidExtensionTypeUseError
+
+type InvalidExtensionTypeUseError struct {
+	Name string
+	ast.Range
+}
+
+func (e *InvalidExtensionTypeUseError) Error() string {
+	return fmt.Sprintf(
+		"cannot use extension `%s` as a type",
+		e.Name,
+	)
+}
+
+func (*InvalidExtensionTypeUseError) isSemanticError() {}
+
 // InvalidEnumCaseError
 
 type InvalidEnumCaseError struct {
@@ -2745,6 +2852,38 @@ func (e *InvalidTypeArgumentCountError) SecondaryError() string {
 
 func (e *InvalidTypeArgumentCountError) isSemanticError() {}
 
+// MissingTypeArgumentsError
+
+type MissingTypeArgumentsError struct {
+	Type Type
+	ast.Range
+}
+
+func (e *MissingTypeArgumentsError) Error() string {
+	return fmt.Sprintf(
+		"missing type arguments for generic type `%s`",
+		e.Type.QualifiedString(),
+	)
+}
+
+func (*MissingTypeArgumentsError) isSemanticError() {}
+
+// InvalidTypeParametersError
+
+type InvalidTypeParametersError struct {
+	CompositeKind common.CompositeKind
+	ast.Range
+}
+
+func (e *InvalidTypeParametersError) Error() string {
+	return fmt.Sprintf(
+		"%s declarations cannot have type parameters",
+		e.CompositeKind.Name(),
+	)
+}
+
+func (*InvalidTypeParametersError) isSemanticError() {}
+
 // TypeParameterTypeInferenceError
 
 type TypeParameterTypeInferenceError struct {
@@ -2903,6 +3042,19 @@ func (e *CyclicImportsError) Error() string {
 
 func (*CyclicImportsError) isSemanticError() {}
 
+// CyclicTypeAliasError
+
+type CyclicTypeAliasError struct {
+	Name string
+	ast.Range
+}
+
+func (e *CyclicTypeAliasError) Error() string {
+	return fmt.Sprintf("cyclic type alias: `%s` refers to itself", e.Name)
+}
+
+func (*CyclicTypeAliasError) isSemanticError() {}
+
 // SwitchDefaultPositionError
 
 type SwitchDefaultPositionError struct {
@@ -2957,3 +3109,63 @@ func (e *InvalidEntryPointTypeError) Error() string {
 		e.Type.QualifiedString(),
 	)
 }
+
+// InvalidStringInterpolationTypeError
+
+type InvalidStringInterpolationTypeError struct {
+	Type Type
+	ast.Range
+}
+
+func (e *InvalidStringInterpolationTypeError) Error() string {
+	return fmt.Sprintf(
+		"cannot interpolate value which has type: `%s`",
+		e.Type.QualifiedString(),
+	)
+}
+
+func (*InvalidStringInterpolationTypeError) isSemanticError() {}
+
+func (*InvalidStringInterpolationTypeError) SecondaryError() string {
+	return "only strings, characters, booleans, numbers, addresses, and paths can be interpolated"
+}
+
+// ImpureOperationError
+
+type ImpureOperationError struct {
+	Operation string
+	Context   string
+	ast.Range
+}
+
+func (e *ImpureOperationError) Error() string {
+	return fmt.Sprintf(
+		"impure operation in %s: %s",
+		e.Context,
+		e.Operation,
+	)
+}
+
+func (*ImpureOperationError) isSemanticError() {}
+
+func (*ImpureOperationError) SecondaryError() string {
+	return "conditions and view functions must not have side effects"
+}
+
+// ViewFunctionConformanceError
+
+type ViewFunctionConformanceError struct {
+	InterfaceType *InterfaceType
+	FunctionName  string
+	ast.Range
+}
+
+func (e *ViewFunctionConformanceError) Error() string {
+	return fmt.Sprintf(
+		"function `%s` must be a view function, as required by `%s`",
+		e.FunctionName,
+		e.InterfaceType.QualifiedString(),
+	)
+}
+
+func (*ViewFunctionConformanceError) isSemanticError() {}
@@ -19,6 +19,7 @@
 package sema
 
 type FunctionActivation struct {
+	FunctionType         *FunctionType
 	ReturnType           Type
 	Loops                int
 	Switches             int
@@ -26,6 +27,10 @@ type FunctionActivation struct {
 	ReturnInfo           *ReturnInfo
 	ReportedDeadCode     bool
 	InitializationInfo   *InitializationInfo
+	// AssignedIdentifiers are the names of all variables
+	// which are assigned to in the function, including nested functions.
+	// It is nil if the function has no body
+	AssignedIdentifiers map[string]struct{}
 }
 
 func (a FunctionActivation) InLoop() bool {
@@ -53,6 +58,7 @@ func (a *FunctionActivations) IsLocal() bool {
 
 func (a *FunctionActivations) EnterFunction(functionType *FunctionType, valueActivationDepth int) *FunctionActivation {
 	activation := &FunctionActivation{
+		FunctionType:         functionType,
 		ReturnType:           functionType.ReturnTypeAnnotation.Type,
 		ValueActivationDepth: valueActivationDepth,
 		ReturnInfo:           &ReturnInfo{},
@@ -84,6 +90,20 @@ func (a *FunctionActivations) Current() *FunctionActivation {
 	return a.activations[lastIndex]
 }
 
+// DeclaringActivation returns the activation of the function
+// in which the given variable was declared.
+// It returns nil if the variable was not declared in any of the activations.
+//
+func (a *FunctionActivations) DeclaringActivation(variable *Variable) *FunctionActivation {
+	for i := len(a.activations) - 1; i >= 0; i-- {
+		activation := a.activations[i]
+		if activation.ValueActivationDepth < variable.ActivationDepth {
+			return activation
+		}
+	}
+	return nil
+}
+
 func (a *FunctionActivations) WithLoop(f func()) {
 	a.Current().Loops++
 	defer func() {
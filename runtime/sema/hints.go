@@ -20,6 +20,7 @@ package sema
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/onflow/cadence/runtime/ast"
 )
@@ -116,3 +117,54 @@ func (h *UnnecessaryCastHint) Hint() string {
 }
 
 func (*UnnecessaryCastHint) isHint() {}
+
+// MissingSwitchCasesHint
+
+type MissingSwitchCasesHint struct {
+	EnumType     *CompositeType
+	MissingCases []string
+	Statement    *ast.SwitchStatement
+	ast.Range
+}
+
+func (h *MissingSwitchCasesHint) Hint() string {
+	quotedCases := make([]string, len(h.MissingCases))
+	for i, missingCase := range h.MissingCases {
+		quotedCases[i] = fmt.Sprintf("`%s`", missingCase)
+	}
+
+	noun := "case"
+	if len(quotedCases) > 1 {
+		noun = "cases"
+	}
+
+	return fmt.Sprintf(
+		"switch over `%s` is not exhaustive: missing %s %s",
+		h.EnumType.QualifiedString(),
+		noun,
+		strings.Join(quotedCases, ", "),
+	)
+}
+
+func (*MissingSwitchCasesHint) isHint() {}
+
+// NonViewInterfaceFunctionCallHint
+
+type NonViewInterfaceFunctionCallHint struct {
+	FunctionName  string
+	InterfaceType Type
+	Context       string
+	ast.Range
+}
+
+func (h *NonViewInterfaceFunctionCallHint) Hint() string {
+	return fmt.Sprintf(
+		"call of non-view function `%s` of `%s` in %s is checked when executed: "+
+			"consider declaring the function as a view function",
+		h.FunctionName,
+		h.InterfaceType.QualifiedString(),
+		h.Context,
+	)
+}
+
+func (*NonViewInterfaceFunctionCallHint) isHint() {}
@@ -0,0 +1,345 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// InstantiatedCompositeType is the instantiation of a generic composite type
+// with type arguments, e.g. `Box<Int>` for `struct Box<T> { ... }`.
+//
+// The types of the members of the composite type which refer to type parameters
+// are resolved using the type arguments.
+//
+type InstantiatedCompositeType struct {
+	CompositeType       *CompositeType
+	typeArguments       []Type
+	memberResolvers     map[string]MemberResolver
+	memberResolversOnce sync.Once
+}
+
+func NewInstantiatedCompositeType(compositeType *CompositeType, typeArguments []Type) *InstantiatedCompositeType {
+	return &InstantiatedCompositeType{
+		CompositeType: compositeType,
+		typeArguments: typeArguments,
+	}
+}
+
+func (*InstantiatedCompositeType) IsType() {}
+
+func (t *InstantiatedCompositeType) string(
+	baseString string,
+	typeFormatter func(Type) string,
+) string {
+	var builder strings.Builder
+	builder.WriteString(baseString)
+	builder.WriteRune('<')
+	for i, typeArgument := range t.typeArguments {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(typeFormatter(typeArgument))
+	}
+	builder.WriteRune('>')
+	return builder.String()
+}
+
+func (t *InstantiatedCompositeType) String() string {
+	return t.string(
+		t.CompositeType.String(),
+		func(t Type) string {
+			return t.String()
+		},
+	)
+}
+
+func (t *InstantiatedCompositeType) QualifiedString() string {
+	return t.string(
+		t.CompositeType.QualifiedString(),
+		func(t Type) string {
+			return t.QualifiedString()
+		},
+	)
+}
+
+func (t *InstantiatedCompositeType) ID() TypeID {
+	return TypeID(
+		t.string(
+			string(t.CompositeType.ID()),
+			func(t Type) string {
+				return string(t.ID())
+			},
+		),
+	)
+}
+
+func (t *InstantiatedCompositeType) Equal(other Type) bool {
+	otherType, ok := other.(*InstantiatedCompositeType)
+	if !ok {
+		return false
+	}
+
+	if !t.CompositeType.Equal(otherType.CompositeType) ||
+		len(t.typeArguments) != len(otherType.typeArguments) {
+
+		return false
+	}
+
+	for i, typeArgument := range t.typeArguments {
+		if !typeArgument.Equal(otherType.typeArguments[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *InstantiatedCompositeType) GetCompositeKind() common.CompositeKind {
+	return t.CompositeType.Kind
+}
+
+func (t *InstantiatedCompositeType) GetLocation() common.Location {
+	return t.CompositeType.Location
+}
+
+func (t *InstantiatedCompositeType) IsResourceType() bool {
+	return t.CompositeType.IsResourceType()
+}
+
+func (t *InstantiatedCompositeType) IsInvalidType() bool {
+	for _, typeArgument := range t.typeArguments {
+		if typeArgument.IsInvalidType() {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *InstantiatedCompositeType) IsStorable(results map[*Member]bool) bool {
+	if !t.CompositeType.IsStorable(results) {
+		return false
+	}
+
+	for _, typeArgument := range t.typeArguments {
+		if !typeArgument.IsStorable(results) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *InstantiatedCompositeType) IsExternallyReturnable(results map[*Member]bool) bool {
+	if !t.CompositeType.IsExternallyReturnable(results) {
+		return false
+	}
+
+	for _, typeArgument := range t.typeArguments {
+		if !typeArgument.IsExternallyReturnable(results) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *InstantiatedCompositeType) IsImportable(results map[*Member]bool) bool {
+	if !t.CompositeType.IsImportable(results) {
+		return false
+	}
+
+	for _, typeArgument := range t.typeArguments {
+		if !typeArgument.IsImportable(results) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *InstantiatedCompositeType) IsEquatable() bool {
+	return t.CompositeType.IsEquatable()
+}
+
+func (*InstantiatedCompositeType) TypeAnnotationState() TypeAnnotationState {
+	return TypeAnnotationStateValid
+}
+
+func (t *InstantiatedCompositeType) RewriteWithRestrictedTypes() (Type, bool) {
+	return t, false
+}
+
+func (t *InstantiatedCompositeType) Unify(
+	other Type,
+	typeParameters *TypeParameterTypeOrderedMap,
+	report func(err error),
+	outerRange ast.Range,
+) bool {
+	otherType, ok := other.(*InstantiatedCompositeType)
+	if !ok ||
+		!t.CompositeType.Equal(otherType.CompositeType) ||
+		len(t.typeArguments) != len(otherType.typeArguments) {
+
+		return false
+	}
+
+	result := false
+
+	for i, typeArgument := range t.typeArguments {
+		otherTypeArgument := otherType.typeArguments[i]
+		if typeArgument.Unify(otherTypeArgument, typeParameters, report, outerRange) {
+			result = true
+		}
+	}
+
+	return result
+}
+
+func (t *InstantiatedCompositeType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
+	resolvedTypeArguments := make([]Type, len(t.typeArguments))
+
+	for i, typeArgument := range t.typeArguments {
+		resolvedTypeArgument := typeArgument.Resolve(typeArguments)
+		if resolvedTypeArgument == nil {
+			return nil
+		}
+		resolvedTypeArguments[i] = resolvedTypeArgument
+	}
+
+	return NewInstantiatedCompositeType(t.CompositeType, resolvedTypeArguments)
+}
+
+func (t *InstantiatedCompositeType) TypeParameters() []*TypeParameter {
+	return t.CompositeType.TypeParameters
+}
+
+func (t *InstantiatedCompositeType) Instantiate(typeArguments []Type, _ func(err error)) Type {
+	return NewInstantiatedCompositeType(t.CompositeType, typeArguments)
+}
+
+func (t *InstantiatedCompositeType) BaseType() Type {
+	return t.CompositeType
+}
+
+func (t *InstantiatedCompositeType) TypeArguments() []Type {
+	return t.typeArguments
+}
+
+// TypeArgumentsMap returns the type arguments of the instantiation,
+// keyed by the type parameters of the composite type
+//
+func (t *InstantiatedCompositeType) TypeArgumentsMap() *TypeParameterTypeOrderedMap {
+	typeArguments := NewTypeParameterTypeOrderedMap()
+	for i, typeParameter := range t.CompositeType.TypeParameters {
+		if i >= len(t.typeArguments) {
+			break
+		}
+		typeArguments.Set(typeParameter, t.typeArguments[i])
+	}
+	return typeArguments
+}
+
+// isSelfInstantiationOf returns true if this type is the instantiation
+// of the given composite type with its own type parameters,
+// e.g. `Box<T>` for `struct Box<T>`
+//
+func (t *InstantiatedCompositeType) isSelfInstantiationOf(compositeType *CompositeType) bool {
+	if t.CompositeType != compositeType ||
+		len(t.typeArguments) != len(compositeType.TypeParameters) {
+
+		return false
+	}
+
+	for i, typeArgument := range t.typeArguments {
+		genericType, ok := typeArgument.(*GenericType)
+		if !ok || genericType.TypeParameter != compositeType.TypeParameters[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *InstantiatedCompositeType) GetMembers() map[string]MemberResolver {
+	t.initializeMemberResolvers()
+	return t.memberResolvers
+}
+
+func (t *InstantiatedCompositeType) initializeMemberResolvers() {
+	t.memberResolversOnce.Do(func() {
+		typeArguments := t.TypeArgumentsMap()
+
+		compositeMemberResolvers := t.CompositeType.GetMembers()
+
+		members := make(map[string]MemberResolver, len(compositeMemberResolvers))
+
+		for name, loopResolver := range compositeMemberResolvers { //nolint:maprangecheck
+			// NOTE: don't capture loop variable
+			resolver := loopResolver
+			members[name] = MemberResolver{
+				Kind: resolver.Kind,
+				Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+					member := resolver.Resolve(identifier, targetRange, report)
+					return resolveMemberTypeArguments(member, typeArguments)
+				},
+			}
+		}
+
+		t.memberResolvers = members
+	})
+}
+
+// resolveMemberTypeArguments returns the given member,
+// with its type resolved using the given type arguments
+//
+func resolveMemberTypeArguments(member *Member, typeArguments *TypeParameterTypeOrderedMap) *Member {
+	if member == nil {
+		return nil
+	}
+
+	resolvedType := member.TypeAnnotation.Type.Resolve(typeArguments)
+	if resolvedType == nil {
+		return member
+	}
+
+	resolvedMember := *member
+	resolvedMember.TypeAnnotation = &TypeAnnotation{
+		IsResource: member.TypeAnnotation.IsResource,
+		Type:       resolvedType,
+	}
+	return &resolvedMember
+}
+
+// compositeOrInstantiatedCompositeType returns the composite type
+// if the given type is a composite type or an instantiation of a generic composite type
+//
+func compositeOrInstantiatedCompositeType(ty Type) (*CompositeType, bool) {
+	switch ty := ty.(type) {
+	case *CompositeType:
+		return ty, true
+	case *InstantiatedCompositeType:
+		return ty.CompositeType, true
+	}
+	return nil, false
+}
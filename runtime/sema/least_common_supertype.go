@@ -0,0 +1,356 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// numericSuperTypes are the abstract numeric types,
+// ordered from the most specific to the most general.
+//
+var numericSuperTypes = []Type{
+	SignedIntegerType,
+	IntegerType,
+	SignedFixedPointType,
+	FixedPointType,
+	SignedNumberType,
+	NumberType,
+}
+
+// LeastCommonSuperType returns the most specific type
+// which all given types are subtypes of.
+//
+// Nil types are ignored. If any of the types is invalid, the result is the invalid type.
+// If there is no common supertype, e.g. when a resource type and a non-resource type are given,
+// the result is nil.
+//
+func LeastCommonSuperType(types ...Type) Type {
+	var result Type
+
+	for _, ty := range types {
+		if ty == nil {
+			continue
+		}
+
+		if result == nil {
+			result = ty
+			continue
+		}
+
+		result = leastCommonSuperType(result, ty)
+		if result == nil {
+			return nil
+		}
+	}
+
+	return result
+}
+
+func leastCommonSuperType(left, right Type) Type {
+
+	if left.IsInvalidType() || right.IsInvalidType() {
+		return InvalidType
+	}
+
+	if IsSubType(left, right) {
+		return right
+	}
+
+	if IsSubType(right, left) {
+		return left
+	}
+
+	if left.IsResourceType() != right.IsResourceType() {
+		return nil
+	}
+
+	// If either of the types is an optional, the common supertype is an optional
+	// of the common supertype of the inner types. Unwrap one level at a time,
+	// so nested optionals are preserved
+
+	leftOptional, leftIsOptional := left.(*OptionalType)
+	rightOptional, rightIsOptional := right.(*OptionalType)
+
+	if leftIsOptional || rightIsOptional {
+		leftInner := left
+		if leftIsOptional {
+			leftInner = leftOptional.Type
+		}
+
+		rightInner := right
+		if rightIsOptional {
+			rightInner = rightOptional.Type
+		}
+
+		innerType := leastCommonSuperType(leftInner, rightInner)
+		switch innerType {
+		case nil, InvalidType:
+			return innerType
+
+		case AnyStructType, AnyResourceType:
+			// Optionals are already subtypes of `AnyStruct` / `AnyResource`
+			return innerType
+		}
+
+		return &OptionalType{
+			Type: innerType,
+		}
+	}
+
+	var candidate Type
+
+	switch {
+	case IsSubType(left, NumberType) && IsSubType(right, NumberType):
+		candidate = leastCommonNumericSuperType(left, right)
+
+	default:
+		switch left := left.(type) {
+		case *ReferenceType:
+			candidate = leastCommonReferenceSuperType(left, right)
+
+		case *CompositeType, *RestrictedType:
+			candidate = leastCommonRestrictedSuperType(left, right)
+
+		case *VariableSizedType, *ConstantSizedType, *DictionaryType:
+			candidate = leastCommonContainerSuperType(left, right)
+		}
+	}
+
+	if candidate != nil &&
+		IsSubType(left, candidate) &&
+		IsSubType(right, candidate) {
+
+		return candidate
+	}
+
+	if left.IsResourceType() {
+		return AnyResourceType
+	}
+
+	return AnyStructType
+}
+
+func leastCommonNumericSuperType(left, right Type) Type {
+	for _, superType := range numericSuperTypes {
+		if IsSubType(left, superType) && IsSubType(right, superType) {
+			return superType
+		}
+	}
+
+	return nil
+}
+
+// leastCommonReferenceSuperType returns the common supertype of two reference types.
+// The result is only authorized if both references are authorized.
+//
+func leastCommonReferenceSuperType(left *ReferenceType, right Type) Type {
+	rightReference, ok := right.(*ReferenceType)
+	if !ok {
+		return nil
+	}
+
+	innerType := leastCommonSuperType(left.Type, rightReference.Type)
+	if innerType == nil {
+		return nil
+	}
+
+	return &ReferenceType{
+		Authorized: left.Authorized && rightReference.Authorized,
+		Type:       innerType,
+	}
+}
+
+// leastCommonContainerSuperType returns the common supertype of two array types or two dictionary types,
+// which is the array or dictionary type of the common supertypes of the element types,
+// e.g. `[Int?]` for `[Int]` and `[Never?]`.
+//
+func leastCommonContainerSuperType(left, right Type) Type {
+	switch left := left.(type) {
+	case *VariableSizedType:
+		rightArray, ok := right.(*VariableSizedType)
+		if !ok {
+			return nil
+		}
+
+		elementType := leastCommonSuperType(left.Type, rightArray.Type)
+		if elementType == nil {
+			return nil
+		}
+
+		return &VariableSizedType{
+			Type: elementType,
+		}
+
+	case *ConstantSizedType:
+		rightArray, ok := right.(*ConstantSizedType)
+		if !ok || left.Size != rightArray.Size {
+			return nil
+		}
+
+		elementType := leastCommonSuperType(left.Type, rightArray.Type)
+		if elementType == nil {
+			return nil
+		}
+
+		return &ConstantSizedType{
+			Type: elementType,
+			Size: left.Size,
+		}
+
+	case *DictionaryType:
+		rightDictionary, ok := right.(*DictionaryType)
+		if !ok {
+			return nil
+		}
+
+		keyType := leastCommonSuperType(left.KeyType, rightDictionary.KeyType)
+		if keyType == nil || !IsValidDictionaryKeyType(keyType) {
+			return nil
+		}
+
+		valueType := leastCommonSuperType(left.ValueType, rightDictionary.ValueType)
+		if valueType == nil {
+			return nil
+		}
+
+		return &DictionaryType{
+			KeyType:   keyType,
+			ValueType: valueType,
+		}
+	}
+
+	return nil
+}
+
+// leastCommonRestrictedSuperType returns the common supertype of two composite or restricted types.
+//
+// The common supertype of two different composite types
+// is the restricted type `AnyStruct{Is}` / `AnyResource{Is}`,
+// where `Is` are the interfaces both types conform to.
+//
+// The common supertype of two restricted types with the same composite type `T`
+// is the restricted type `T{Is}`, where `Is` are the restrictions of both types.
+//
+func leastCommonRestrictedSuperType(left, right Type) Type {
+
+	leftType, leftInterfaces := restrictedTypeAndInterfaces(left)
+	if leftType == nil {
+		return nil
+	}
+
+	rightType, rightInterfaces := restrictedTypeAndInterfaces(right)
+	if rightType == nil {
+		return nil
+	}
+
+	var restrictions []*InterfaceType
+
+	for _, interfaceType := range leftInterfaces {
+		for _, otherInterfaceType := range rightInterfaces {
+			if interfaceType == otherInterfaceType {
+				restrictions = append(restrictions, interfaceType)
+				break
+			}
+		}
+	}
+
+	var restrictedType Type
+
+	if leftType.Equal(rightType) {
+		restrictedType = leftType
+	} else if leftType.IsResourceType() {
+		restrictedType = AnyResourceType
+	} else {
+		restrictedType = AnyStructType
+	}
+
+	if len(restrictions) == 0 {
+		return restrictedType
+	}
+
+	return &RestrictedType{
+		Type:         restrictedType,
+		Restrictions: restrictions,
+	}
+}
+
+// restrictedTypeAndInterfaces returns the restricted type and the interfaces of a composite or restricted type.
+// For a composite type, the composite type itself and its explicit conformances are returned.
+//
+func restrictedTypeAndInterfaces(ty Type) (Type, []*InterfaceType) {
+	switch ty := ty.(type) {
+	case *CompositeType:
+		switch ty.Kind {
+		case common.CompositeKindStructure, common.CompositeKindResource:
+			return ty, ty.ExplicitInterfaceConformances
+		}
+
+	case *RestrictedType:
+		return ty.Type, ty.Restrictions
+	}
+
+	return nil, nil
+}
+
+// inferCommonSuperType infers the common supertype of the type inferred so far
+// and the type of the given expression.
+//
+// If there is no common supertype, a type mismatch error is reported
+// and the type inferred so far is kept.
+//
+func (checker *Checker) inferCommonSuperType(inferredType, valueType Type, expression ast.Expression) Type {
+	if inferredType == nil {
+		return valueType
+	}
+
+	superType := LeastCommonSuperType(inferredType, valueType)
+	if superType == nil {
+		checker.report(
+			&TypeMismatchError{
+				ExpectedType: inferredType,
+				ActualType:   valueType,
+				Expression:   expression,
+				Range:        expressionRange(expression),
+			},
+		)
+
+		return inferredType
+	}
+
+	return superType
+}
+
+// inferredTypeHint returns the expected type for an element of an array or dictionary literal,
+// given the element type inferred from the preceding elements so far, if any.
+//
+// Only number literals use the inferred type as a hint, so e.g. `[1 as UInt8, 2]` has type `[UInt8]`.
+// All other elements, in particular nested literals, are checked without an expected type,
+// as the inferred type is not necessarily a supertype of the element's type,
+// e.g. `[[1], [nil]]` has type `[[Int?]]`, even though the first element has type `[Int]`.
+//
+func inferredTypeHint(expression ast.Expression, inferredType Type) Type {
+	switch expression.(type) {
+	case *ast.IntegerExpression, *ast.FixedPointExpression:
+		return inferredType
+	default:
+		return nil
+	}
+}
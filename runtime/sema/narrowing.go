@@ -0,0 +1,264 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// narrowings are the narrowed types of variables,
+// e.g. the non-optional type of an optional variable which is known to be non-nil
+//
+type narrowings map[*Variable]Type
+
+// union returns the narrowings which hold if both narrowings hold
+//
+func (n narrowings) union(other narrowings) narrowings {
+	if len(n) == 0 {
+		return other
+	}
+	if len(other) == 0 {
+		return n
+	}
+
+	result := make(narrowings, len(n)+len(other))
+	for variable, narrowedType := range n {
+		result[variable] = narrowedType
+	}
+	for variable, narrowedType := range other {
+		result[variable] = narrowedType
+	}
+	return result
+}
+
+// intersection returns the narrowings which hold if either of the narrowings hold
+//
+func (n narrowings) intersection(other narrowings) narrowings {
+	if len(n) == 0 || len(other) == 0 {
+		return nil
+	}
+
+	var result narrowings
+	for variable, narrowedType := range n {
+		otherNarrowedType, ok := other[variable]
+		if !ok || !otherNarrowedType.Equal(narrowedType) {
+			continue
+		}
+		if result == nil {
+			result = narrowings{}
+		}
+		result[variable] = narrowedType
+	}
+	return result
+}
+
+// conditionNarrowings returns the narrowings which hold
+// if the given (already checked) condition is true,
+// and the narrowings which hold if the condition is false.
+//
+// An optional variable is known to be non-nil if it is compared to `nil`,
+// e.g. `x != nil`, and the comparisons may be combined with `&&`, `||`, and `!`.
+//
+func (checker *Checker) conditionNarrowings(condition ast.Expression) (thenNarrowings, elseNarrowings narrowings) {
+
+	switch condition := condition.(type) {
+	case *ast.BinaryExpression:
+		switch condition.Operation {
+		case ast.OperationEqual, ast.OperationNotEqual:
+			variable, narrowedType := checker.nilComparisonNarrowing(condition)
+			if variable == nil {
+				return nil, nil
+			}
+
+			result := narrowings{variable: narrowedType}
+
+			if condition.Operation == ast.OperationNotEqual {
+				return result, nil
+			}
+			return nil, result
+
+		case ast.OperationAnd:
+			leftThen, leftElse := checker.conditionNarrowings(condition.Left)
+			rightThen, rightElse := checker.conditionNarrowings(condition.Right)
+
+			return leftThen.union(rightThen),
+				leftElse.intersection(rightElse)
+
+		case ast.OperationOr:
+			leftThen, leftElse := checker.conditionNarrowings(condition.Left)
+			rightThen, rightElse := checker.conditionNarrowings(condition.Right)
+
+			return leftThen.intersection(rightThen),
+				leftElse.union(rightElse)
+		}
+
+	case *ast.UnaryExpression:
+		if condition.Operation == ast.OperationNegate {
+			thenNarrowings, elseNarrowings = checker.conditionNarrowings(condition.Expression)
+			return elseNarrowings, thenNarrowings
+		}
+	}
+
+	return nil, nil
+}
+
+// nilComparisonNarrowing returns the variable and its narrowed type
+// if the given binary expression compares a narrowable variable to `nil`.
+//
+func (checker *Checker) nilComparisonNarrowing(expression *ast.BinaryExpression) (*Variable, Type) {
+
+	var identifierExpression *ast.IdentifierExpression
+
+	if _, ok := expression.Right.(*ast.NilExpression); ok {
+		identifierExpression, _ = expression.Left.(*ast.IdentifierExpression)
+	} else if _, ok := expression.Left.(*ast.NilExpression); ok {
+		identifierExpression, _ = expression.Right.(*ast.IdentifierExpression)
+	}
+
+	if identifierExpression == nil {
+		return nil, nil
+	}
+
+	variable := checker.valueActivations.Find(identifierExpression.Identifier.Identifier)
+	if variable == nil || !checker.isNarrowable(variable) {
+		return nil, nil
+	}
+
+	optionalType, ok := variable.Type.(*OptionalType)
+	if !ok {
+		return nil, nil
+	}
+
+	return variable, optionalType.Type
+}
+
+// isNarrowable returns true if the type of the given variable may be narrowed.
+//
+// The value of the variable must not change after it was narrowed,
+// so the variable must either be a constant,
+// or a local variable which is never assigned to after its declaration.
+//
+// Resource variables are not narrowed, as their uses are tracked separately.
+//
+func (checker *Checker) isNarrowable(variable *Variable) bool {
+
+	if variable.IsBaseValue ||
+		variable.Type.IsResourceType() {
+
+		return false
+	}
+
+	switch variable.DeclarationKind {
+	case common.DeclarationKindConstant,
+		common.DeclarationKindParameter:
+
+		return variable.IsConstant
+
+	case common.DeclarationKindVariable:
+		declaringActivation := checker.functionActivations.DeclaringActivation(variable)
+		if declaringActivation == nil || declaringActivation.AssignedIdentifiers == nil {
+			return false
+		}
+
+		_, assigned := declaringActivation.AssignedIdentifiers[variable.Identifier]
+		return !assigned
+
+	default:
+		return false
+	}
+}
+
+// narrow narrows the types of the given variables in the current scope.
+//
+func (checker *Checker) narrow(narrowings narrowings) {
+	for variable, narrowedType := range narrowings {
+		checker.valueActivations.Narrow(variable, narrowedType)
+	}
+}
+
+// checkWithNarrowings checks the given function
+// with the types of the given variables narrowed.
+//
+func (checker *Checker) checkWithNarrowings(narrowings narrowings, check TypeCheckFunc) Type {
+	if len(narrowings) == 0 {
+		return check()
+	}
+
+	checker.enterValueScope()
+	defer checker.leaveValueScope(nil, false)
+
+	checker.narrow(narrowings)
+
+	return check()
+}
+
+// visitExpressionWithoutNarrowing checks the given expression
+// using the declared type of the variable if the expression is an identifier,
+// i.e. without taking narrowings into account.
+//
+// This is used where optionals are expected, e.g. for the operand
+// of a force expression or the left-hand side of a nil-coalescing expression.
+//
+func (checker *Checker) visitExpressionWithoutNarrowing(
+	expression ast.Expression,
+	expectedType Type,
+	forceType bool,
+) Type {
+	if _, ok := expression.(*ast.IdentifierExpression); ok {
+		previousIgnoreNarrowing := checker.ignoreNarrowing
+		checker.ignoreNarrowing = true
+		defer func() {
+			checker.ignoreNarrowing = previousIgnoreNarrowing
+		}()
+	}
+
+	return checker.VisitExpressionWithForceType(expression, expectedType, forceType)
+}
+
+// assignedIdentifiers returns the names of all variables
+// which are assigned to or swapped in the given function block,
+// including nested functions.
+//
+func assignedIdentifiers(functionBlock *ast.FunctionBlock) map[string]struct{} {
+	result := map[string]struct{}{}
+
+	addTarget := func(target ast.Expression) {
+		identifierExpression, ok := target.(*ast.IdentifierExpression)
+		if !ok {
+			return
+		}
+		result[identifierExpression.Identifier.Identifier] = struct{}{}
+	}
+
+	ast.Inspect(functionBlock, func(element ast.Element) bool {
+		switch element := element.(type) {
+		case *ast.AssignmentStatement:
+			addTarget(element.Target)
+
+		case *ast.SwapStatement:
+			addTarget(element.Left)
+			addTarget(element.Right)
+		}
+
+		return true
+	})
+
+	return result
+}
@@ -0,0 +1,430 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// Conditions and view functions must not have side effects.
+//
+// An operation has a side effect (is impure) if it writes to a value
+// which is not local to the function, emits an event, creates or destroys a resource,
+// or calls a function which is not a view function.
+//
+// Functions which are not declared to be view functions are inferred to be view functions
+// if their body has no side effects. As functions may be called before their body is checked,
+// e.g. a function may be called in a condition before it is declared, or may be recursive,
+// the purity of functions is only inferred after all declarations of the program are checked.
+
+// functionPurityInfo is the information about the side effects of a function,
+// gathered while checking its body
+//
+type functionPurityInfo struct {
+	// impure is true if the function body directly performs an impure operation
+	impure bool
+	// callees are the functions which are called in the function body,
+	// and which were not known to be view functions when the call was checked
+	callees []*FunctionType
+}
+
+// purityObligation is the requirement that a function is a view function,
+// which can only be decided once the purity of all functions is inferred
+//
+type purityObligation struct {
+	functionType *FunctionType
+	err          error
+}
+
+// viewContext returns a description of the current context
+// and true if it must not have side effects,
+// i.e. if a condition or the body of a function declared as a view function is checked
+//
+func (checker *Checker) viewContext() (string, bool) {
+	if checker.inCondition {
+		return "condition", true
+	}
+
+	functionActivation := checker.functionActivations.Current()
+	if functionActivation != nil &&
+		functionActivation.FunctionType != nil &&
+		functionActivation.FunctionType.IsView() {
+
+		return "view function", true
+	}
+
+	return "", false
+}
+
+// currentFunctionPurityInfo returns the purity information
+// of the function whose body is currently checked, if any
+//
+func (checker *Checker) currentFunctionPurityInfo() *functionPurityInfo {
+	functionActivation := checker.functionActivations.Current()
+	if functionActivation == nil || functionActivation.FunctionType == nil {
+		return nil
+	}
+
+	return checker.functionPurityInfos[functionActivation.FunctionType]
+}
+
+// beginFunctionPurityInference starts the inference of the purity of the given function,
+// if it is not declared to be a view function.
+//
+// The member function type, if any, is the type of the composite member
+// which is declared for the function. It is a different function type than the one
+// of the function body, but shares the inferred purity
+//
+func (checker *Checker) beginFunctionPurityInference(functionType, memberFunctionType *FunctionType) {
+	if functionType.IsView() {
+		return
+	}
+
+	if _, ok := checker.functionPurityInfos[functionType]; ok {
+		return
+	}
+
+	info := &functionPurityInfo{}
+	checker.functionPurityInfos[functionType] = info
+
+	if memberFunctionType != nil && memberFunctionType != functionType {
+		checker.functionPurityInfos[memberFunctionType] = info
+	}
+}
+
+// recordImpureOperation records that the given operation has side effects.
+//
+// In a view context the operation is reported as an error,
+// otherwise the function whose body is checked is inferred to be impure.
+//
+func (checker *Checker) recordImpureOperation(operation string, positioned ast.HasPosition) {
+	context, inViewContext := checker.viewContext()
+	if inViewContext {
+		checker.report(
+			&ImpureOperationError{
+				Operation: operation,
+				Context:   context,
+				Range:     ast.NewRangeFromPositioned(positioned),
+			},
+		)
+		return
+	}
+
+	info := checker.currentFunctionPurityInfo()
+	if info != nil {
+		info.impure = true
+	}
+}
+
+// checkInvocationPurity checks that the invocation of a function
+// with the given type has no side effects in a view context,
+// and records the call for the purity inference of the current function
+//
+func (checker *Checker) checkInvocationPurity(
+	invocationExpression *ast.InvocationExpression,
+	functionType *FunctionType,
+) {
+	if functionType.IsView() ||
+		checker.isLocalMutatingMemberInvocation(invocationExpression) {
+
+		return
+	}
+
+	// The function type of a bound function is a copy of the member's function type,
+	// so track the purity of the declared function, which is checked for purity
+
+	var member *Member
+
+	if memberExpression, ok := invocationExpression.InvokedExpression.(*ast.MemberExpression); ok {
+		memberInfo, ok := checker.Elaboration.MemberExpressionMemberInfos[memberExpression]
+		if ok && memberInfo.Member != nil {
+			member = memberInfo.Member
+			if memberFunctionType, ok := member.TypeAnnotation.Type.(*FunctionType); ok {
+				functionType = memberFunctionType
+			}
+		}
+	}
+
+	context, inViewContext := checker.viewContext()
+	if inViewContext {
+
+		// Interface requirements which are not declared as view functions
+		// are implemented by existing contracts, which declare no view functions.
+		// Their implementations are inferred to be view functions if they have no side effects,
+		// so calls of them are allowed, and the interpreter rejects calls of impure implementations
+
+		if interfaceType := interfaceRequirementContainerType(member); interfaceType != nil {
+			checker.hint(
+				&NonViewInterfaceFunctionCallHint{
+					FunctionName:  member.Identifier.Identifier,
+					InterfaceType: interfaceType,
+					Context:       context,
+					Range:         ast.NewRangeFromPositioned(invocationExpression),
+				},
+			)
+			return
+		}
+
+		// The function might still be inferred to be a view function,
+		// so record the call and decide when the purity of all functions is known
+
+		checker.purityObligations = append(
+			checker.purityObligations,
+			purityObligation{
+				functionType: functionType,
+				err: &ImpureOperationError{
+					Operation: "call of non-view function",
+					Context:   context,
+					Range:     ast.NewRangeFromPositioned(invocationExpression),
+				},
+			},
+		)
+		return
+	}
+
+	info := checker.currentFunctionPurityInfo()
+	if info != nil {
+		info.callees = append(info.callees, functionType)
+	}
+}
+
+// interfaceRequirementContainerType returns the interface or type requirement
+// which declares the given member, if any
+//
+func interfaceRequirementContainerType(member *Member) Type {
+	if member == nil {
+		return nil
+	}
+
+	switch containerType := member.ContainerType.(type) {
+	case *InterfaceType:
+		return containerType
+
+	case *CompositeType:
+		// Composite types nested in contract interfaces are type requirements
+		if _, ok := containerType.GetContainerType().(*InterfaceType); ok {
+			return containerType
+		}
+	}
+
+	return nil
+}
+
+// isLocalMutatingMemberInvocation returns true if the given invocation
+// is a call of a built-in function of an array or dictionary,
+// which is stored in a local variable of the current function.
+//
+// For example, a view function may append to an array it declared itself.
+// Conditions must not mutate any values, not even local ones.
+//
+func (checker *Checker) isLocalMutatingMemberInvocation(invocationExpression *ast.InvocationExpression) bool {
+	if checker.inCondition {
+		return false
+	}
+
+	memberExpression, ok := invocationExpression.InvokedExpression.(*ast.MemberExpression)
+	if !ok {
+		return false
+	}
+
+	memberInfo, ok := checker.Elaboration.MemberExpressionMemberInfos[memberExpression]
+	if !ok {
+		return false
+	}
+
+	switch memberInfo.AccessedType.(type) {
+	case *VariableSizedType, *ConstantSizedType, *DictionaryType:
+		// The container itself is mutated, not the variable,
+		// so it must not be accessed through a reference
+
+		return checker.isLocalValue(memberExpression.Expression, false)
+	default:
+		return false
+	}
+}
+
+// checkAssignmentPurity checks that an assignment to the given target
+// has no side effects, i.e. that the target is local to the current function.
+//
+// Conditions must not write to any values, not even local ones.
+//
+func (checker *Checker) checkAssignmentPurity(target ast.Expression) {
+	if !checker.inCondition && checker.isLocalValue(target, true) {
+		return
+	}
+
+	checker.recordImpureOperation("write to non-local value", target)
+}
+
+// isLocalValue returns true if the given expression, which is written to,
+// refers to a value owned by a variable which is declared in the current function.
+//
+// isTarget is true if the expression itself is the target of an assignment,
+// and false if the value of the expression is mutated, e.g. an element or member is written.
+//
+// Writes through references and to `self` might modify values outside of the function.
+//
+func (checker *Checker) isLocalValue(expression ast.Expression, isTarget bool) bool {
+	for {
+		switch target := expression.(type) {
+		case *ast.IdentifierExpression:
+			return checker.isLocalVariable(target, isTarget)
+
+		case *ast.MemberExpression:
+			memberInfo, ok := checker.Elaboration.MemberExpressionMemberInfos[target]
+			if ok && isReferenceOrOptionalReference(memberInfo.AccessedType) {
+				return false
+			}
+			expression = target.Expression
+			isTarget = false
+
+		case *ast.IndexExpression:
+			expression = target.TargetExpression
+			isTarget = false
+
+		default:
+			return false
+		}
+	}
+}
+
+// isLocalVariable returns true if the variable referred to by the given identifier expression
+// is declared in the current function, and values may be written through it.
+//
+// If the variable itself is not the written target,
+// but a member or element of its value, it must not be a reference
+//
+func (checker *Checker) isLocalVariable(expression *ast.IdentifierExpression, isTarget bool) bool {
+	identifier := expression.Identifier.Identifier
+	if identifier == SelfIdentifier {
+		return false
+	}
+
+	variable := checker.valueActivations.Find(identifier)
+	if variable == nil {
+		// The variable is undeclared, which is reported separately
+		return true
+	}
+
+	functionActivation := checker.functionActivations.Current()
+	if functionActivation == nil ||
+		variable.ActivationDepth <= functionActivation.ValueActivationDepth {
+
+		return false
+	}
+
+	return isTarget || !isReferenceOrOptionalReference(variable.Type)
+}
+
+func isReferenceOrOptionalReference(ty Type) bool {
+	for {
+		switch typedType := ty.(type) {
+		case *ReferenceType:
+			return true
+		case *OptionalType:
+			ty = typedType.Type
+		default:
+			return false
+		}
+	}
+}
+
+// checkViewFunctionConformance checks that the function which implements
+// a view function requirement of an interface is a view function
+//
+func (checker *Checker) checkViewFunctionConformance(
+	compositeMember *Member,
+	interfaceMember *Member,
+	interfaceType *InterfaceType,
+) {
+	interfaceFunctionType, ok := interfaceMember.TypeAnnotation.Type.(*FunctionType)
+	if !ok || !interfaceFunctionType.IsView() {
+		return
+	}
+
+	compositeFunctionType, ok := compositeMember.TypeAnnotation.Type.(*FunctionType)
+	if !ok || compositeFunctionType.IsView() {
+		return
+	}
+
+	checker.purityObligations = append(
+		checker.purityObligations,
+		purityObligation{
+			functionType: compositeFunctionType,
+			err: &ViewFunctionConformanceError{
+				InterfaceType: interfaceType,
+				FunctionName:  compositeMember.Identifier.Identifier,
+				Range:         ast.NewRangeFromPositioned(compositeMember.Identifier),
+			},
+		},
+	)
+}
+
+// inferFunctionPurity infers which functions of the program are view functions,
+// and reports the calls of non-view functions in view contexts.
+//
+// A function is impure if it directly performs an impure operation,
+// or calls an impure function. All other functions are view functions,
+// including recursive functions which have no side effects otherwise.
+//
+func (checker *Checker) inferFunctionPurity() {
+
+	isImpure := func(functionType *FunctionType) bool {
+		if functionType.IsView() {
+			return false
+		}
+
+		// Functions which were not checked in this program,
+		// e.g. imported or built-in functions, or interface requirements,
+		// have their declared purity
+
+		info, ok := checker.functionPurityInfos[functionType]
+		return !ok || info.impure
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, info := range checker.functionPurityInfos {
+			if info.impure {
+				continue
+			}
+
+			for _, callee := range info.callees {
+				if isImpure(callee) {
+					info.impure = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	for functionType, info := range checker.functionPurityInfos {
+		if !info.impure {
+			functionType.Purity = ast.FunctionPurityView
+		}
+	}
+
+	for _, obligation := range checker.purityObligations {
+		if !obligation.functionType.IsView() {
+			checker.report(obligation.err)
+		}
+	}
+}
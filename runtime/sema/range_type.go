@@ -0,0 +1,311 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// RangeType is the type of ranges, e.g. `0..<10`.
+// The element type is an integer type.
+// A range type without an element type (`Range`) is the supertype of all range types.
+//
+type RangeType struct {
+	ElementType         Type
+	memberResolvers     map[string]MemberResolver
+	memberResolversOnce sync.Once
+}
+
+func (*RangeType) IsType() {}
+
+func (t *RangeType) string(typeFormatter func(Type) string) string {
+	var builder strings.Builder
+	builder.WriteString("Range")
+	if t.ElementType != nil {
+		builder.WriteRune('<')
+		builder.WriteString(typeFormatter(t.ElementType))
+		builder.WriteRune('>')
+	}
+	return builder.String()
+}
+
+func (t *RangeType) String() string {
+	return t.string(func(t Type) string {
+		return t.String()
+	})
+}
+
+func (t *RangeType) QualifiedString() string {
+	return t.string(func(t Type) string {
+		return t.QualifiedString()
+	})
+}
+
+func (t *RangeType) ID() TypeID {
+	return TypeID(t.string(func(t Type) string {
+		return string(t.ID())
+	}))
+}
+
+func (t *RangeType) Equal(other Type) bool {
+	otherRange, ok := other.(*RangeType)
+	if !ok {
+		return false
+	}
+	if otherRange.ElementType == nil {
+		return t.ElementType == nil
+	}
+	return otherRange.ElementType.Equal(t.ElementType)
+}
+
+func (*RangeType) IsResourceType() bool {
+	return false
+}
+
+func (t *RangeType) IsInvalidType() bool {
+	if t.ElementType == nil {
+		return false
+	}
+	return t.ElementType.IsInvalidType()
+}
+
+func (t *RangeType) TypeAnnotationState() TypeAnnotationState {
+	return TypeAnnotationStateValid
+}
+
+func (*RangeType) IsStorable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*RangeType) IsExternallyReturnable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*RangeType) IsImportable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*RangeType) IsEquatable() bool {
+	return false
+}
+
+func (t *RangeType) RewriteWithRestrictedTypes() (Type, bool) {
+	return t, false
+}
+
+func (t *RangeType) Unify(
+	other Type,
+	typeParameters *TypeParameterTypeOrderedMap,
+	report func(err error),
+	outerRange ast.Range,
+) bool {
+	otherRange, ok := other.(*RangeType)
+	if !ok {
+		return false
+	}
+
+	if t.ElementType == nil || otherRange.ElementType == nil {
+		return false
+	}
+
+	return t.ElementType.Unify(otherRange.ElementType, typeParameters, report, outerRange)
+}
+
+func (t *RangeType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
+	var resolvedElementType Type
+	if t.ElementType != nil {
+		resolvedElementType = t.ElementType.Resolve(typeArguments)
+		if resolvedElementType == nil {
+			return nil
+		}
+	}
+
+	return &RangeType{
+		ElementType: resolvedElementType,
+	}
+}
+
+var rangeTypeParameter = &TypeParameter{
+	Name:      "T",
+	TypeBound: IntegerType,
+}
+
+func (t *RangeType) TypeParameters() []*TypeParameter {
+	return []*TypeParameter{
+		rangeTypeParameter,
+	}
+}
+
+func (t *RangeType) Instantiate(typeArguments []Type, _ func(err error)) Type {
+	return &RangeType{
+		ElementType: typeArguments[0],
+	}
+}
+
+func (t *RangeType) BaseType() Type {
+	if t.ElementType == nil {
+		return nil
+	}
+	return &RangeType{}
+}
+
+func (t *RangeType) TypeArguments() []Type {
+	return []Type{
+		t.EffectiveElementType(),
+	}
+}
+
+// EffectiveElementType returns the type of the elements of the range.
+// The elements of a range without an element type are integers.
+//
+func (t *RangeType) EffectiveElementType() Type {
+	if t.ElementType == nil {
+		return IntegerType
+	}
+	return t.ElementType
+}
+
+// IsValidRangeElementType returns true if values of the given type
+// can be the bounds of a range, i.e. if it is a concrete integer type.
+//
+func IsValidRangeElementType(ty Type) bool {
+	switch ty {
+	case NeverType, IntegerType, SignedIntegerType:
+		return false
+	}
+	return IsSubType(ty, IntegerType)
+}
+
+func RangeContainsFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Purity: ast.FunctionPurityView,
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "element",
+				TypeAnnotation: NewTypeAnnotation(elementType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(BoolType),
+	}
+}
+
+func RangeStrideFunctionType(rangeType *RangeType) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Identifier:     "by",
+				TypeAnnotation: NewTypeAnnotation(rangeType.EffectiveElementType()),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(rangeType),
+	}
+}
+
+const rangeTypeStartFieldDocString = `
+The start of the range. The range always contains the start
+`
+
+const rangeTypeEndFieldDocString = `
+The end of the range. The range only contains the end if the range is inclusive
+`
+
+const rangeTypeStepFieldDocString = `
+The step of the range, i.e. the difference between two consecutive elements
+`
+
+const rangeTypeIsInclusiveFieldDocString = `
+Is true if the range contains the end
+`
+
+const rangeTypeContainsFunctionDocString = `
+Returns true if the given element is an element of the range
+`
+
+const rangeTypeStrideFunctionDocString = `
+Returns a new range with the same bounds and the given step. The step must not be zero
+`
+
+func (t *RangeType) GetMembers() map[string]MemberResolver {
+	t.initializeMemberResolvers()
+	return t.memberResolvers
+}
+
+func (t *RangeType) initializeMemberResolvers() {
+	t.memberResolversOnce.Do(func() {
+		elementType := t.EffectiveElementType()
+
+		newElementFieldResolver := func(docString string) MemberResolver {
+			return MemberResolver{
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						elementType,
+						docString,
+					)
+				},
+			}
+		}
+
+		t.memberResolvers = withBuiltinMembers(t, map[string]MemberResolver{
+			"start": newElementFieldResolver(rangeTypeStartFieldDocString),
+			"end":   newElementFieldResolver(rangeTypeEndFieldDocString),
+			"step":  newElementFieldResolver(rangeTypeStepFieldDocString),
+			"isInclusive": {
+				Kind: common.DeclarationKindField,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicConstantFieldMember(
+						t,
+						identifier,
+						BoolType,
+						rangeTypeIsInclusiveFieldDocString,
+					)
+				},
+			},
+			"contains": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						RangeContainsFunctionType(elementType),
+						rangeTypeContainsFunctionDocString,
+					)
+				},
+			},
+			"stride": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						RangeStrideFunctionType(t),
+						rangeTypeStrideFunctionDocString,
+					)
+				},
+			},
+		})
+	})
+}
@@ -0,0 +1,169 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/errors"
+)
+
+var RoundingRules = []NativeEnumCase{
+	RoundingRuleTowardZero,
+	RoundingRuleAwayFromZero,
+	RoundingRuleNearestHalfAway,
+	RoundingRuleNearestHalfEven,
+}
+
+const RoundingRuleTypeName = "RoundingRule"
+
+var RoundingRuleType = newNativeEnumType(
+	RoundingRuleTypeName,
+	UInt8Type,
+	RoundingRules,
+	nil,
+)
+
+// RoundingRule is the rule used to round the result
+// of a fixed-point operation which is not exactly representable
+//
+type RoundingRule uint8
+
+const (
+	RoundingRuleTowardZero RoundingRule = iota
+	RoundingRuleAwayFromZero
+	RoundingRuleNearestHalfAway
+	RoundingRuleNearestHalfEven
+)
+
+func (rule RoundingRule) Name() string {
+	switch rule {
+	case RoundingRuleTowardZero:
+		return "towardZero"
+	case RoundingRuleAwayFromZero:
+		return "awayFromZero"
+	case RoundingRuleNearestHalfAway:
+		return "nearestHalfAway"
+	case RoundingRuleNearestHalfEven:
+		return "nearestHalfEven"
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+func (rule RoundingRule) RawValue() uint8 {
+	// NOTE: only add new rules, do *NOT* change existing items,
+	// reuse raw values for other items, swap the order, etc.
+	//
+	// Existing stored values use these raw values and should not change
+
+	switch rule {
+	case RoundingRuleTowardZero:
+		return 0
+	case RoundingRuleAwayFromZero:
+		return 1
+	case RoundingRuleNearestHalfAway:
+		return 2
+	case RoundingRuleNearestHalfEven:
+		return 3
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+func (rule RoundingRule) DocString() string {
+	switch rule {
+	case RoundingRuleTowardZero:
+		return RoundingRuleDocStringTowardZero
+	case RoundingRuleAwayFromZero:
+		return RoundingRuleDocStringAwayFromZero
+	case RoundingRuleNearestHalfAway:
+		return RoundingRuleDocStringNearestHalfAway
+	case RoundingRuleNearestHalfEven:
+		return RoundingRuleDocStringNearestHalfEven
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+const RoundingRuleDocStringTowardZero = `
+Rounds to the nearest representable value whose magnitude is not greater than the exact result, i.e. truncates
+`
+
+const RoundingRuleDocStringAwayFromZero = `
+Rounds to the nearest representable value whose magnitude is not less than the exact result
+`
+
+const RoundingRuleDocStringNearestHalfAway = `
+Rounds to the nearest representable value, and ties away from zero
+`
+
+const RoundingRuleDocStringNearestHalfEven = `
+Rounds to the nearest representable value, and ties to the even value
+`
+
+const FixedPointTypeMultiplyWithRoundingFunctionName = "multiplyWithRounding"
+const fixedPointTypeMultiplyWithRoundingFunctionDocString = `
+self * other, rounded using the given rounding rule instead of truncated.
+`
+
+const FixedPointTypeDivideWithRoundingFunctionName = "divideWithRounding"
+const fixedPointTypeDivideWithRoundingFunctionDocString = `
+self / other, rounded using the given rounding rule instead of truncated.
+`
+
+const FixedPointTypeRoundingParameterName = "rounding"
+
+func addRoundingArithmeticFunctions(t Type, members map[string]MemberResolver) {
+
+	arithmeticFunctionType := &FunctionType{
+		Purity: ast.FunctionPurityView,
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "other",
+				TypeAnnotation: NewTypeAnnotation(t),
+			},
+			{
+				Identifier:     FixedPointTypeRoundingParameterName,
+				TypeAnnotation: NewTypeAnnotation(RoundingRuleType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(t),
+	}
+
+	addArithmeticFunction := func(name string, docString string) {
+		members[name] = MemberResolver{
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+				return NewPublicFunctionMember(t, name, arithmeticFunctionType, docString)
+			},
+		}
+	}
+
+	addArithmeticFunction(
+		FixedPointTypeMultiplyWithRoundingFunctionName,
+		fixedPointTypeMultiplyWithRoundingFunctionDocString,
+	)
+
+	addArithmeticFunction(
+		FixedPointTypeDivideWithRoundingFunctionName,
+		fixedPointTypeDivideWithRoundingFunctionDocString,
+	)
+}
@@ -28,6 +28,16 @@ const StringTypeEncodeHexFunctionDocString = `
 Returns a hexadecimal string for the given byte array
 `
 
+const StringTypeJoinFunctionName = "join"
+const StringTypeJoinFunctionDocString = `
+Returns a string containing the given strings, separated by the given separator
+`
+
+const StringTypeFromUTF8FunctionName = "fromUTF8"
+const StringTypeFromUTF8FunctionDocString = `
+Returns the string for the given UTF-8 encoded byte array, or nil if the bytes are not valid UTF-8
+`
+
 // StringType represents the string type
 //
 var StringType = &SimpleType{
@@ -119,11 +129,122 @@ func init() {
 					)
 				},
 			},
+			"toUpper": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeToUpperFunctionType,
+						stringTypeToUpperFunctionDocString,
+					)
+				},
+			},
+			"split": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeSplitFunctionType,
+						stringTypeSplitFunctionDocString,
+					)
+				},
+			},
+			"contains": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeContainsFunctionType,
+						stringTypeContainsFunctionDocString,
+					)
+				},
+			},
+			"index": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeIndexFunctionType,
+						stringTypeIndexFunctionDocString,
+					)
+				},
+			},
+			"replaceAll": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeReplaceAllFunctionType,
+						stringTypeReplaceAllFunctionDocString,
+					)
+				},
+			},
+			"trim": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeTrimFunctionType,
+						stringTypeTrimFunctionDocString,
+					)
+				},
+			},
+			"hasPrefix": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeHasPrefixFunctionType,
+						stringTypeHasPrefixFunctionDocString,
+					)
+				},
+			},
+			"hasSuffix": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeHasSuffixFunctionType,
+						stringTypeHasSuffixFunctionDocString,
+					)
+				},
+			},
+			"characters": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeCharactersFunctionType,
+						stringTypeCharactersFunctionDocString,
+					)
+				},
+			},
+			"toUTF8": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(
+						t,
+						identifier,
+						StringTypeToUTF8FunctionType,
+						stringTypeToUTF8FunctionDocString,
+					)
+				},
+			},
 		}
 	}
 }
 
 var StringTypeConcatFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Label:          ArgumentLabelNotRequired,
@@ -141,6 +262,7 @@ Returns a new string which contains the given string concatenated to the end of
 `
 
 var StringTypeSliceFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Identifier:     "from",
@@ -169,6 +291,7 @@ var ByteArrayType = &VariableSizedType{
 }
 
 var StringTypeDecodeHexFunctionType = &FunctionType{
+	Purity:               ast.FunctionPurityView,
 	ReturnTypeAnnotation: NewTypeAnnotation(ByteArrayType),
 }
 
@@ -188,9 +311,172 @@ The byte array of the UTF-8 encoding
 `
 
 var StringTypeToLowerFunctionType = &FunctionType{
+	Purity:               ast.FunctionPurityView,
 	ReturnTypeAnnotation: NewTypeAnnotation(StringType),
 }
 
 const stringTypeToLowerFunctionDocString = `
 Returns the string with upper case letters replaced with lowercase
 `
+
+var StringTypeToUpperFunctionType = &FunctionType{
+	Purity:               ast.FunctionPurityView,
+	ReturnTypeAnnotation: NewTypeAnnotation(StringType),
+}
+
+const stringTypeToUpperFunctionDocString = `
+Returns the string with lower case letters replaced with uppercase
+`
+
+var StringArrayType = &VariableSizedType{
+	Type: StringType,
+}
+
+var StringTypeSplitFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
+	Parameters: []*Parameter{
+		{
+			Identifier:     "separator",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringArrayType,
+	),
+}
+
+const stringTypeSplitFunctionDocString = `
+Returns the substrings of the string which are separated by the given separator.
+
+If the separator is empty, the string is split into its characters
+`
+
+var StringTypeContainsFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "other",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		BoolType,
+	),
+}
+
+const stringTypeContainsFunctionDocString = `
+Returns true if the string contains the given string, and false otherwise
+`
+
+var StringTypeIndexFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
+	Parameters: []*Parameter{
+		{
+			Label:          "of",
+			Identifier:     "other",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: IntType,
+		},
+	),
+}
+
+const stringTypeIndexFunctionDocString = `
+Returns the index of the first character of the first occurrence of the given string, or nil if the string does not contain it
+`
+
+var StringTypeReplaceAllFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
+	Parameters: []*Parameter{
+		{
+			Label:          "of",
+			Identifier:     "original",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+		{
+			Label:          "with",
+			Identifier:     "replacement",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringType,
+	),
+}
+
+const stringTypeReplaceAllFunctionDocString = `
+Returns a new string with all occurrences of ` + "`original`" + ` replaced with ` + "`replacement`" + `.
+
+If ` + "`original`" + ` is empty, the string is returned unchanged
+`
+
+var StringTypeTrimFunctionType = &FunctionType{
+	Purity:               ast.FunctionPurityView,
+	ReturnTypeAnnotation: NewTypeAnnotation(StringType),
+}
+
+const stringTypeTrimFunctionDocString = `
+Returns the string with all leading and trailing whitespace removed
+`
+
+var StringTypeHasPrefixFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "prefix",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		BoolType,
+	),
+}
+
+const stringTypeHasPrefixFunctionDocString = `
+Returns true if the string starts with the given prefix, and false otherwise
+`
+
+var StringTypeHasSuffixFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
+	Parameters: []*Parameter{
+		{
+			Label:          ArgumentLabelNotRequired,
+			Identifier:     "suffix",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		BoolType,
+	),
+}
+
+const stringTypeHasSuffixFunctionDocString = `
+Returns true if the string ends with the given suffix, and false otherwise
+`
+
+var CharacterArrayType = &VariableSizedType{
+	Type: CharacterType,
+}
+
+var StringTypeCharactersFunctionType = &FunctionType{
+	Purity:               ast.FunctionPurityView,
+	ReturnTypeAnnotation: NewTypeAnnotation(CharacterArrayType),
+}
+
+const stringTypeCharactersFunctionDocString = `
+Returns an array containing the characters of the string
+`
+
+var StringTypeToUTF8FunctionType = &FunctionType{
+	Purity:               ast.FunctionPurityView,
+	ReturnTypeAnnotation: NewTypeAnnotation(ByteArrayType),
+}
+
+const stringTypeToUTF8FunctionDocString = `
+Returns the byte array of the UTF-8 encoding
+`
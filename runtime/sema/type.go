@@ -214,6 +214,36 @@ func VisitThisAndNested(t Type, visit func(ty Type)) {
 	})
 }
 
+// TypeAliasContainerType is a container type which may declare type aliases
+//
+type TypeAliasContainerType interface {
+	ContainerType
+	GetTypeAliases() *StringTypeOrderedMap
+}
+
+// GetNestedTypeOrAlias returns the type with the given name nested in the given container type.
+// If there is no nested type with the given name, but a type alias,
+// the target type of the type alias is returned
+//
+func GetNestedTypeOrAlias(containerType ContainerType, name string) (Type, bool) {
+	ty, ok := containerType.GetNestedTypes().Get(name)
+	if ok {
+		return ty, true
+	}
+
+	typeAliasContainerType, ok := containerType.(TypeAliasContainerType)
+	if !ok {
+		return nil, false
+	}
+
+	typeAliases := typeAliasContainerType.GetTypeAliases()
+	if typeAliases == nil {
+		return nil, false
+	}
+
+	return typeAliases.Get(name)
+}
+
 // CompositeKindedType is a type which has a composite kind
 //
 type CompositeKindedType interface {
@@ -308,6 +338,7 @@ func NewTypeAnnotation(ty Type) *TypeAnnotation {
 const IsInstanceFunctionName = "isInstance"
 
 var IsInstanceFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Label:      ArgumentLabelNotRequired,
@@ -331,6 +362,7 @@ Returns true if the object conforms to the given type at runtime
 const GetTypeFunctionName = "getType"
 
 var GetTypeFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
 	ReturnTypeAnnotation: NewTypeAnnotation(
 		MetaType,
 	),
@@ -345,6 +377,7 @@ Returns the type of the value
 const ToStringFunctionName = "toString"
 
 var ToStringFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
 	ReturnTypeAnnotation: NewTypeAnnotation(
 		StringType,
 	),
@@ -359,6 +392,7 @@ A textual representation of this object
 const ToBigEndianBytesFunctionName = "toBigEndianBytes"
 
 var toBigEndianBytesFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
 	ReturnTypeAnnotation: NewTypeAnnotation(
 		ByteArrayType,
 	),
@@ -649,28 +683,40 @@ func (t *GenericType) Equal(other Type) bool {
 	return t.TypeParameter == otherType.TypeParameter
 }
 
-func (*GenericType) IsResourceType() bool {
-	return false
+// IsResourceType returns true if the type parameter is bounded by a resource type,
+// e.g. `T: AnyResource`, as all type arguments must then be resource types.
+//
+func (t *GenericType) IsResourceType() bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsResourceType()
 }
 
 func (*GenericType) IsInvalidType() bool {
 	return false
 }
 
-func (*GenericType) IsStorable(_ map[*Member]bool) bool {
-	return false
+// NOTE: storability, importability, and external returnability
+// of type parameters are determined by their type bound.
+// Type arguments are checked when the generic type is instantiated.
+
+func (t *GenericType) IsStorable(results map[*Member]bool) bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsStorable(results)
 }
 
-func (*GenericType) IsExternallyReturnable(_ map[*Member]bool) bool {
-	return false
+func (t *GenericType) IsExternallyReturnable(results map[*Member]bool) bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsExternallyReturnable(results)
 }
 
-func (t *GenericType) IsImportable(_ map[*Member]bool) bool {
-	return false
+func (t *GenericType) IsImportable(results map[*Member]bool) bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsImportable(results)
 }
 
-func (*GenericType) IsEquatable() bool {
-	return false
+func (t *GenericType) IsEquatable() bool {
+	typeBound := t.TypeParameter.TypeBound
+	return typeBound != nil && typeBound.IsEquatable()
 }
 
 func (*GenericType) TypeAnnotationState() TypeAnnotationState {
@@ -725,12 +771,28 @@ func (t *GenericType) Unify(
 func (t *GenericType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
 	ty, ok := typeArguments.Get(t.TypeParameter)
 	if !ok {
+		// A required type parameter which is not bound by the given type arguments
+		// belongs to an enclosing generic declaration, e.g. the type parameter
+		// of a generic composite used in a generic function of the composite.
+		// It stays unresolved
+
+		if !t.TypeParameter.Optional && t.TypeParameter.isUserDefined {
+			return t
+		}
+
 		return nil
 	}
 	return ty
 }
 
 func (t *GenericType) GetMembers() map[string]MemberResolver {
+	// The members of a value of a type parameter's type are the members of its type bound
+
+	typeBound := t.TypeParameter.TypeBound
+	if typeBound != nil {
+		return typeBound.GetMembers()
+	}
+
 	return withBuiltinMembers(t, nil)
 }
 
@@ -781,6 +843,7 @@ self / other, saturating at the numeric bounds instead of overflowing.
 func addSaturatingArithmeticFunctions(t SaturatingArithmeticType, members map[string]MemberResolver) {
 
 	arithmeticFunctionType := &FunctionType{
+		Purity: ast.FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -829,6 +892,175 @@ func addSaturatingArithmeticFunctions(t SaturatingArithmeticType, members map[st
 	}
 }
 
+// MathFunctionsType is a type that supports native math functions
+//
+type MathFunctionsType interface {
+	Type
+	SupportsPow() bool
+	SupportsSaturatingPow() bool
+	SupportsSqrt() bool
+	SupportsAbs() bool
+	SupportsMinMax() bool
+	SupportsMulDiv() bool
+}
+
+const NumericTypePowFunctionName = "pow"
+const numericTypePowFunctionDocString = `
+self raised to the power of the given exponent.
+`
+
+const NumericTypeSaturatingPowFunctionName = "saturatingPow"
+const numericTypeSaturatingPowFunctionDocString = `
+self raised to the power of the given exponent, saturating at the numeric bounds instead of overflowing.
+`
+
+const NumericTypeSqrtFunctionName = "sqrt"
+const numericTypeSqrtFunctionDocString = `
+The square root of self, rounded toward zero.
+`
+
+const NumericTypeAbsFunctionName = "abs"
+const numericTypeAbsFunctionDocString = `
+The absolute value of self.
+`
+
+const NumericTypeMinFunctionName = "min"
+const numericTypeMinFunctionDocString = `
+The lesser of self and other.
+`
+
+const NumericTypeMaxFunctionName = "max"
+const numericTypeMaxFunctionDocString = `
+The greater of self and other.
+`
+
+const NumericTypeMulDivFunctionName = "mulDiv"
+const numericTypeMulDivFunctionDocString = `
+self * multiplier / divisor, rounded using the given rounding rule.
+The intermediate product is not limited to the numeric bounds.
+`
+
+// PowExponentType is the type of the exponent of the pow functions.
+//
+// The exponent is limited to 8 bits, so the result of an exponentiation
+// of an arbitrary-precision integer stays reasonably small
+//
+var PowExponentType = UInt8Type
+
+func addMathFunctions(t MathFunctionsType, members map[string]MemberResolver) {
+
+	addFunction := func(name string, functionType *FunctionType, docString string) {
+		members[name] = MemberResolver{
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+				return NewPublicFunctionMember(t, name, functionType, docString)
+			},
+		}
+	}
+
+	powFunctionType := &FunctionType{
+		Purity: ast.FunctionPurityView,
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "exponent",
+				TypeAnnotation: NewTypeAnnotation(PowExponentType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(t),
+	}
+
+	if t.SupportsPow() {
+		addFunction(
+			NumericTypePowFunctionName,
+			powFunctionType,
+			numericTypePowFunctionDocString,
+		)
+	}
+
+	if t.SupportsSaturatingPow() {
+		addFunction(
+			NumericTypeSaturatingPowFunctionName,
+			powFunctionType,
+			numericTypeSaturatingPowFunctionDocString,
+		)
+	}
+
+	unaryFunctionType := &FunctionType{
+		Purity:               ast.FunctionPurityView,
+		ReturnTypeAnnotation: NewTypeAnnotation(t),
+	}
+
+	if t.SupportsSqrt() {
+		addFunction(
+			NumericTypeSqrtFunctionName,
+			unaryFunctionType,
+			numericTypeSqrtFunctionDocString,
+		)
+	}
+
+	if t.SupportsAbs() {
+		addFunction(
+			NumericTypeAbsFunctionName,
+			unaryFunctionType,
+			numericTypeAbsFunctionDocString,
+		)
+	}
+
+	if t.SupportsMinMax() {
+		binaryFunctionType := &FunctionType{
+			Purity: ast.FunctionPurityView,
+			Parameters: []*Parameter{
+				{
+					Label:          ArgumentLabelNotRequired,
+					Identifier:     "other",
+					TypeAnnotation: NewTypeAnnotation(t),
+				},
+			},
+			ReturnTypeAnnotation: NewTypeAnnotation(t),
+		}
+
+		addFunction(
+			NumericTypeMinFunctionName,
+			binaryFunctionType,
+			numericTypeMinFunctionDocString,
+		)
+
+		addFunction(
+			NumericTypeMaxFunctionName,
+			binaryFunctionType,
+			numericTypeMaxFunctionDocString,
+		)
+	}
+
+	if t.SupportsMulDiv() {
+		addFunction(
+			NumericTypeMulDivFunctionName,
+			&FunctionType{
+				Purity: ast.FunctionPurityView,
+				Parameters: []*Parameter{
+					{
+						Label:          ArgumentLabelNotRequired,
+						Identifier:     "multiplier",
+						TypeAnnotation: NewTypeAnnotation(t),
+					},
+					{
+						Label:          ArgumentLabelNotRequired,
+						Identifier:     "divisor",
+						TypeAnnotation: NewTypeAnnotation(t),
+					},
+					{
+						Identifier:     FixedPointTypeRoundingParameterName,
+						TypeAnnotation: NewTypeAnnotation(RoundingRuleType),
+					},
+				},
+				ReturnTypeAnnotation: NewTypeAnnotation(t),
+			},
+			numericTypeMulDivFunctionDocString,
+		)
+	}
+}
+
 // NumericType represent all the types in the integer range
 // and non-fractional ranged types.
 //
@@ -840,11 +1072,19 @@ type NumericType struct {
 	supportsSaturatingSubtract bool
 	supportsSaturatingMultiply bool
 	supportsSaturatingDivide   bool
+	supportsPow                bool
+	supportsSaturatingPow      bool
+	supportsSqrt               bool
+	supportsAbs                bool
+	supportsMinMax             bool
+	supportsMulDiv             bool
 	memberResolvers            map[string]MemberResolver
 	memberResolversOnce        sync.Once
 }
 
 var _ IntegerRangedType = &NumericType{}
+var _ SaturatingArithmeticType = &NumericType{}
+var _ MathFunctionsType = &NumericType{}
 
 func NewNumericType(typeName string) *NumericType {
 	return &NumericType{name: typeName}
@@ -892,6 +1132,60 @@ func (t *NumericType) SupportsSaturatingDivide() bool {
 	return t.supportsSaturatingDivide
 }
 
+func (t *NumericType) WithPow() *NumericType {
+	t.supportsPow = true
+	return t
+}
+
+func (t *NumericType) WithSaturatingPow() *NumericType {
+	t.supportsSaturatingPow = true
+	return t
+}
+
+func (t *NumericType) WithSqrt() *NumericType {
+	t.supportsSqrt = true
+	return t
+}
+
+func (t *NumericType) WithAbs() *NumericType {
+	t.supportsAbs = true
+	return t
+}
+
+func (t *NumericType) WithMinMax() *NumericType {
+	t.supportsMinMax = true
+	return t
+}
+
+func (t *NumericType) WithMulDiv() *NumericType {
+	t.supportsMulDiv = true
+	return t
+}
+
+func (t *NumericType) SupportsPow() bool {
+	return t.supportsPow
+}
+
+func (t *NumericType) SupportsSaturatingPow() bool {
+	return t.supportsSaturatingPow
+}
+
+func (t *NumericType) SupportsSqrt() bool {
+	return t.supportsSqrt
+}
+
+func (t *NumericType) SupportsAbs() bool {
+	return t.supportsAbs
+}
+
+func (t *NumericType) SupportsMinMax() bool {
+	return t.supportsMinMax
+}
+
+func (t *NumericType) SupportsMulDiv() bool {
+	return t.supportsMulDiv
+}
+
 func (*NumericType) IsType() {}
 
 func (t *NumericType) String() string {
@@ -975,6 +1269,7 @@ func (t *NumericType) initializeMemberResolvers() {
 		members := map[string]MemberResolver{}
 
 		addSaturatingArithmeticFunctions(t, members)
+		addMathFunctions(t, members)
 
 		t.memberResolvers = withBuiltinMembers(t, members)
 	})
@@ -993,11 +1288,17 @@ type FixedPointNumericType struct {
 	supportsSaturatingSubtract bool
 	supportsSaturatingMultiply bool
 	supportsSaturatingDivide   bool
+	supportsSqrt               bool
+	supportsAbs                bool
+	supportsMinMax             bool
+	supportsMulDiv             bool
 	memberResolvers            map[string]MemberResolver
 	memberResolversOnce        sync.Once
 }
 
 var _ FractionalRangedType = &FixedPointNumericType{}
+var _ SaturatingArithmeticType = &FixedPointNumericType{}
+var _ MathFunctionsType = &FixedPointNumericType{}
 
 func NewFixedPointNumericType(typeName string) *FixedPointNumericType {
 	return &FixedPointNumericType{
@@ -1062,6 +1363,50 @@ func (t *FixedPointNumericType) SupportsSaturatingDivide() bool {
 	return t.supportsSaturatingDivide
 }
 
+func (t *FixedPointNumericType) WithSqrt() *FixedPointNumericType {
+	t.supportsSqrt = true
+	return t
+}
+
+func (t *FixedPointNumericType) WithAbs() *FixedPointNumericType {
+	t.supportsAbs = true
+	return t
+}
+
+func (t *FixedPointNumericType) WithMinMax() *FixedPointNumericType {
+	t.supportsMinMax = true
+	return t
+}
+
+func (t *FixedPointNumericType) WithMulDiv() *FixedPointNumericType {
+	t.supportsMulDiv = true
+	return t
+}
+
+func (*FixedPointNumericType) SupportsPow() bool {
+	return false
+}
+
+func (*FixedPointNumericType) SupportsSaturatingPow() bool {
+	return false
+}
+
+func (t *FixedPointNumericType) SupportsSqrt() bool {
+	return t.supportsSqrt
+}
+
+func (t *FixedPointNumericType) SupportsAbs() bool {
+	return t.supportsAbs
+}
+
+func (t *FixedPointNumericType) SupportsMinMax() bool {
+	return t.supportsMinMax
+}
+
+func (t *FixedPointNumericType) SupportsMulDiv() bool {
+	return t.supportsMulDiv
+}
+
 func (*FixedPointNumericType) IsType() {}
 
 func (t *FixedPointNumericType) String() string {
@@ -1157,6 +1502,8 @@ func (t *FixedPointNumericType) initializeMemberResolvers() {
 		members := map[string]MemberResolver{}
 
 		addSaturatingArithmeticFunctions(t, members)
+		addRoundingArithmeticFunctions(t, members)
+		addMathFunctions(t, members)
 
 		t.memberResolvers = withBuiltinMembers(t, members)
 	})
@@ -1179,7 +1526,12 @@ var (
 	SignedIntegerType = NewNumericType(SignedIntegerTypeName)
 
 	// IntType represents the arbitrary-precision integer type `Int`
-	IntType = NewNumericType(IntTypeName)
+	IntType = NewNumericType(IntTypeName).
+		WithPow().
+		WithSqrt().
+		WithAbs().
+		WithMinMax().
+		WithMulDiv()
 
 	// Int8Type represents the 8-bit signed integer type `Int8`
 	Int8Type = NewNumericType(Int8TypeName).
@@ -1187,7 +1539,12 @@ var (
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
 			WithSaturatingMultiply().
-			WithSaturatingDivide()
+			WithSaturatingDivide().
+			WithPow().
+			WithSaturatingPow().
+			WithSqrt().
+			WithAbs().
+			WithMinMax()
 
 	// Int16Type represents the 16-bit signed integer type `Int16`
 	Int16Type = NewNumericType(Int16TypeName).
@@ -1195,7 +1552,12 @@ var (
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
 			WithSaturatingMultiply().
-			WithSaturatingDivide()
+			WithSaturatingDivide().
+			WithPow().
+			WithSaturatingPow().
+			WithSqrt().
+			WithAbs().
+			WithMinMax()
 
 	// Int32Type represents the 32-bit signed integer type `Int32`
 	Int32Type = NewNumericType(Int32TypeName).
@@ -1203,7 +1565,12 @@ var (
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
 			WithSaturatingMultiply().
-			WithSaturatingDivide()
+			WithSaturatingDivide().
+			WithPow().
+			WithSaturatingPow().
+			WithSqrt().
+			WithAbs().
+			WithMinMax()
 
 	// Int64Type represents the 64-bit signed integer type `Int64`
 	Int64Type = NewNumericType(Int64TypeName).
@@ -1211,7 +1578,12 @@ var (
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
 			WithSaturatingMultiply().
-			WithSaturatingDivide()
+			WithSaturatingDivide().
+			WithPow().
+			WithSaturatingPow().
+			WithSqrt().
+			WithAbs().
+			WithMinMax()
 
 	// Int128Type represents the 128-bit signed integer type `Int128`
 	Int128Type = NewNumericType(Int128TypeName).
@@ -1219,7 +1591,13 @@ var (
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
 			WithSaturatingMultiply().
-			WithSaturatingDivide()
+			WithSaturatingDivide().
+			WithPow().
+			WithSaturatingPow().
+			WithSqrt().
+			WithAbs().
+			WithMinMax().
+			WithMulDiv()
 
 	// Int256Type represents the 256-bit signed integer type `Int256`
 	Int256Type = NewNumericType(Int256TypeName).
@@ -1227,12 +1605,22 @@ var (
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
 			WithSaturatingMultiply().
-			WithSaturatingDivide()
+			WithSaturatingDivide().
+			WithPow().
+			WithSaturatingPow().
+			WithSqrt().
+			WithAbs().
+			WithMinMax().
+			WithMulDiv()
 
 	// UIntType represents the arbitrary-precision unsigned integer type `UInt`
 	UIntType = NewNumericType(UIntTypeName).
 			WithIntRange(UIntTypeMin, nil).
-			WithSaturatingSubtract()
+			WithSaturatingSubtract().
+			WithPow().
+			WithSqrt().
+			WithMinMax().
+			WithMulDiv()
 
 	// UInt8Type represents the 8-bit unsigned integer type `UInt8`
 	// which checks for overflow and underflow
@@ -1240,7 +1628,11 @@ var (
 			WithIntRange(UInt8TypeMinInt, UInt8TypeMaxInt).
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
-			WithSaturatingMultiply()
+			WithSaturatingMultiply().
+			WithPow().
+			WithSaturatingPow().
+			WithSqrt().
+			WithMinMax()
 
 	// UInt16Type represents the 16-bit unsigned integer type `UInt16`
 	// which checks for overflow and underflow
@@ -1248,7 +1640,11 @@ var (
 			WithIntRange(UInt16TypeMinInt, UInt16TypeMaxInt).
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
-			WithSaturatingMultiply()
+			WithSaturatingMultiply().
+			WithPow().
+			WithSaturatingPow().
+			WithSqrt().
+			WithMinMax()
 
 	// UInt32Type represents the 32-bit unsigned integer type `UInt32`
 	// which checks for overflow and underflow
@@ -1256,7 +1652,11 @@ var (
 			WithIntRange(UInt32TypeMinInt, UInt32TypeMaxInt).
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
-			WithSaturatingMultiply()
+			WithSaturatingMultiply().
+			WithPow().
+			WithSaturatingPow().
+			WithSqrt().
+			WithMinMax()
 
 	// UInt64Type represents the 64-bit unsigned integer type `UInt64`
 	// which checks for overflow and underflow
@@ -1264,7 +1664,11 @@ var (
 			WithIntRange(UInt64TypeMinInt, UInt64TypeMaxInt).
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
-			WithSaturatingMultiply()
+			WithSaturatingMultiply().
+			WithPow().
+			WithSaturatingPow().
+			WithSqrt().
+			WithMinMax()
 
 	// UInt128Type represents the 128-bit unsigned integer type `UInt128`
 	// which checks for overflow and underflow
@@ -1272,7 +1676,12 @@ var (
 			WithIntRange(UInt128TypeMinIntBig, UInt128TypeMaxIntBig).
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
-			WithSaturatingMultiply()
+			WithSaturatingMultiply().
+			WithPow().
+			WithSaturatingPow().
+			WithSqrt().
+			WithMinMax().
+			WithMulDiv()
 
 	// UInt256Type represents the 256-bit unsigned integer type `UInt256`
 	// which checks for overflow and underflow
@@ -1280,27 +1689,44 @@ var (
 			WithIntRange(UInt256TypeMinIntBig, UInt256TypeMaxIntBig).
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
-			WithSaturatingMultiply()
+			WithSaturatingMultiply().
+			WithPow().
+			WithSaturatingPow().
+			WithSqrt().
+			WithMinMax().
+			WithMulDiv()
 
 	// Word8Type represents the 8-bit unsigned integer type `Word8`
 	// which does NOT check for overflow and underflow
 	Word8Type = NewNumericType(Word8TypeName).
-			WithIntRange(Word8TypeMinInt, Word8TypeMaxInt)
+			WithIntRange(Word8TypeMinInt, Word8TypeMaxInt).
+			WithPow().
+			WithSqrt().
+			WithMinMax()
 
 	// Word16Type represents the 16-bit unsigned integer type `Word16`
 	// which does NOT check for overflow and underflow
 	Word16Type = NewNumericType(Word16TypeName).
-			WithIntRange(Word16TypeMinInt, Word16TypeMaxInt)
+			WithIntRange(Word16TypeMinInt, Word16TypeMaxInt).
+			WithPow().
+			WithSqrt().
+			WithMinMax()
 
 	// Word32Type represents the 32-bit unsigned integer type `Word32`
 	// which does NOT check for overflow and underflow
 	Word32Type = NewNumericType(Word32TypeName).
-			WithIntRange(Word32TypeMinInt, Word32TypeMaxInt)
+			WithIntRange(Word32TypeMinInt, Word32TypeMaxInt).
+			WithPow().
+			WithSqrt().
+			WithMinMax()
 
 	// Word64Type represents the 64-bit unsigned integer type `Word64`
 	// which does NOT check for overflow and underflow
 	Word64Type = NewNumericType(Word64TypeName).
-			WithIntRange(Word64TypeMinInt, Word64TypeMaxInt)
+			WithIntRange(Word64TypeMinInt, Word64TypeMaxInt).
+			WithPow().
+			WithSqrt().
+			WithMinMax()
 
 	// FixedPointType represents the super-type of all fixed-point types
 	FixedPointType = NewNumericType(FixedPointTypeName)
@@ -1317,7 +1743,11 @@ var (
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
 			WithSaturatingMultiply().
-			WithSaturatingDivide()
+			WithSaturatingDivide().
+			WithSqrt().
+			WithAbs().
+			WithMinMax().
+			WithMulDiv()
 
 	// UFix64Type represents the 64-bit unsigned decimal fixed-point type `UFix64`
 	// which has a scale of 1E9, and checks for overflow and underflow
@@ -1327,7 +1757,38 @@ var (
 			WithScale(Fix64Scale).
 			WithSaturatingAdd().
 			WithSaturatingSubtract().
-			WithSaturatingMultiply()
+			WithSaturatingMultiply().
+			WithSqrt().
+			WithMinMax().
+			WithMulDiv()
+
+	// Fix128Type represents the 128-bit signed decimal fixed-point type `Fix128`
+	// which has a scale of Fix128Scale, and checks for overflow and underflow
+	Fix128Type = NewFixedPointNumericType(Fix128TypeName).
+			WithIntRange(Fix128TypeMinIntBig, Fix128TypeMaxIntBig).
+			WithFractionalRange(Fix128TypeMinFractionalBig, Fix128TypeMaxFractionalBig).
+			WithScale(Fix128Scale).
+			WithSaturatingAdd().
+			WithSaturatingSubtract().
+			WithSaturatingMultiply().
+			WithSaturatingDivide().
+			WithSqrt().
+			WithAbs().
+			WithMinMax().
+			WithMulDiv()
+
+	// UFix128Type represents the 128-bit unsigned decimal fixed-point type `UFix128`
+	// which has a scale of 1E24, and checks for overflow and underflow
+	UFix128Type = NewFixedPointNumericType(UFix128TypeName).
+			WithIntRange(UFix128TypeMinIntBig, UFix128TypeMaxIntBig).
+			WithFractionalRange(UFix128TypeMinFractionalBig, UFix128TypeMaxFractionalBig).
+			WithScale(Fix128Scale).
+			WithSaturatingAdd().
+			WithSaturatingSubtract().
+			WithSaturatingMultiply().
+			WithSqrt().
+			WithMinMax().
+			WithMulDiv()
 )
 
 // Numeric type ranges
@@ -1428,6 +1889,20 @@ var (
 
 	UFix64TypeMinFractionalBig = fixedpoint.UFix64TypeMinFractionalBig
 	UFix64TypeMaxFractionalBig = fixedpoint.UFix64TypeMaxFractionalBig
+
+	Fix128FactorBig = fixedpoint.Fix128FactorBig
+
+	Fix128TypeMinIntBig = fixedpoint.Fix128TypeMinIntBig
+	Fix128TypeMaxIntBig = fixedpoint.Fix128TypeMaxIntBig
+
+	Fix128TypeMinFractionalBig = fixedpoint.Fix128TypeMinFractionalBig
+	Fix128TypeMaxFractionalBig = fixedpoint.Fix128TypeMaxFractionalBig
+
+	UFix128TypeMinIntBig = fixedpoint.UFix128TypeMinIntBig
+	UFix128TypeMaxIntBig = fixedpoint.UFix128TypeMaxIntBig
+
+	UFix128TypeMinFractionalBig = fixedpoint.UFix128TypeMinFractionalBig
+	UFix128TypeMaxFractionalBig = fixedpoint.UFix128TypeMaxFractionalBig
 )
 
 const Fix64Scale = fixedpoint.Fix64Scale
@@ -1445,6 +1920,8 @@ const UFix64TypeMaxInt = fixedpoint.UFix64TypeMaxInt
 const UFix64TypeMinFractional = fixedpoint.UFix64TypeMinFractional
 const UFix64TypeMaxFractional = fixedpoint.UFix64TypeMaxFractional
 
+const Fix128Scale = fixedpoint.Fix128Scale
+
 // ArrayType
 
 type ArrayType interface {
@@ -1502,8 +1979,63 @@ Removes the last element from the array and returns it.
 The array must not be empty. If the array is empty, the program aborts
 `
 
+const arrayTypeMapFunctionDocString = `
+Returns a new array which contains the results of calling the given transform function on each element of the array
+`
+
+const arrayTypeFilterFunctionDocString = `
+Returns a new array which contains only the elements of the array for which the given predicate function returns true
+`
+
+const arrayTypeReduceFunctionDocString = `
+Combines all elements of the array, from first to last, using the given combine function.
+
+The combine function is called with the accumulated value, starting with the given initial value, and the element.
+Returns the final accumulated value
+`
+
+const arrayTypeFirstIndexFunctionDocString = `
+Returns the index of the first element of the array for which the given predicate function returns true,
+or nil if there is no such element
+`
+
+const arrayTypeReverseFunctionDocString = `
+Returns a new array which contains the elements of the array in reverse order
+`
+
+const arrayTypeSliceFunctionDocString = `
+Returns a new variable-sized array which contains the elements of the array
+starting at the given from-index, up to, but not including, the given upTo-index.
+
+The indices must be within the bounds of the array, and the from-index must not be greater than the upTo-index.
+If the indices are invalid, the program aborts
+`
+
+const arrayTypeSortFunctionDocString = `
+Sorts the elements of the array in place, using the given function to compare the elements.
+
+The function must return true if the first given element should be ordered before the second given element.
+The sort is stable, i.e. equal elements keep their relative order
+`
+
 func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 
+	// reportInvalidResourceElementType reports an error
+	// if the member would copy the elements or pass them to a function,
+	// and the elements are resources
+
+	reportInvalidResourceElementType := func(identifier string, targetRange ast.Range, report func(error)) {
+		if arrayType.ElementType(false).IsResourceType() {
+			report(
+				&InvalidResourceArrayMemberError{
+					Name:            identifier,
+					DeclarationKind: common.DeclarationKindFunction,
+					Range:           targetRange,
+				},
+			)
+		}
+	}
+
 	members := map[string]MemberResolver{
 		"contains": {
 			Kind: common.DeclarationKindFunction,
@@ -1554,6 +2086,104 @@ func getArrayMembers(arrayType ArrayType) map[string]MemberResolver {
 				)
 			},
 		},
+		"map": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				reportInvalidResourceElementType(identifier, targetRange, report)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayMapFunctionType(arrayType.ElementType(false)),
+					arrayTypeMapFunctionDocString,
+				)
+			},
+		},
+		"filter": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				reportInvalidResourceElementType(identifier, targetRange, report)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayFilterFunctionType(arrayType.ElementType(false)),
+					arrayTypeFilterFunctionDocString,
+				)
+			},
+		},
+		"reduce": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				reportInvalidResourceElementType(identifier, targetRange, report)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayReduceFunctionType(arrayType.ElementType(false)),
+					arrayTypeReduceFunctionDocString,
+				)
+			},
+		},
+		"firstIndex": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				reportInvalidResourceElementType(identifier, targetRange, report)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayFirstIndexFunctionType(arrayType.ElementType(false)),
+					arrayTypeFirstIndexFunctionDocString,
+				)
+			},
+		},
+		"reverse": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				reportInvalidResourceElementType(identifier, targetRange, report)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArrayReverseFunctionType(arrayType),
+					arrayTypeReverseFunctionDocString,
+				)
+			},
+		},
+		"slice": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				reportInvalidResourceElementType(identifier, targetRange, report)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArraySliceFunctionType(arrayType.ElementType(false)),
+					arrayTypeSliceFunctionDocString,
+				)
+			},
+		},
+		"sort": {
+			Kind: common.DeclarationKindFunction,
+			Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+				reportInvalidResourceElementType(identifier, targetRange, report)
+
+				return NewPublicFunctionMember(
+					arrayType,
+					identifier,
+					ArraySortFunctionType(arrayType.ElementType(false)),
+					arrayTypeSortFunctionDocString,
+				)
+			},
+		},
 	}
 
 	// TODO: maybe still return members but report a helpful error?
@@ -1742,6 +2372,7 @@ func ArrayInsertFunctionType(elementType Type) *FunctionType {
 func ArrayConcatFunctionType(arrayType Type) *FunctionType {
 	typeAnnotation := NewTypeAnnotation(arrayType)
 	return &FunctionType{
+		Purity: ast.FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -1755,6 +2386,7 @@ func ArrayConcatFunctionType(arrayType Type) *FunctionType {
 
 func ArrayContainsFunctionType(elementType Type) *FunctionType {
 	return &FunctionType{
+		Purity: ast.FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -1796,6 +2428,209 @@ func ArrayAppendFunctionType(elementType Type) *FunctionType {
 	}
 }
 
+func ArrayMapFunctionType(elementType Type) *FunctionType {
+	typeParameter := &TypeParameter{
+		Name: "T",
+	}
+
+	resultType := &GenericType{
+		TypeParameter: typeParameter,
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "transform",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "element",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							resultType,
+						),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&VariableSizedType{
+				Type: resultType,
+			},
+		),
+	}
+}
+
+func ArrayFilterFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "predicate",
+				TypeAnnotation: NewTypeAnnotation(elementPredicateFunctionType(elementType)),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&VariableSizedType{
+				Type: elementType,
+			},
+		),
+	}
+}
+
+func ArrayReduceFunctionType(elementType Type) *FunctionType {
+	typeParameter := &TypeParameter{
+		Name: "T",
+	}
+
+	resultType := &GenericType{
+		TypeParameter: typeParameter,
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Identifier:     "initial",
+				TypeAnnotation: NewTypeAnnotation(resultType),
+			},
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "combine",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "accumulator",
+								TypeAnnotation: NewTypeAnnotation(resultType),
+							},
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "element",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							resultType,
+						),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			resultType,
+		),
+	}
+}
+
+func ArrayFirstIndexFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Purity: ast.FunctionPurityView,
+		Parameters: []*Parameter{
+			{
+				Identifier:     "where",
+				TypeAnnotation: NewTypeAnnotation(elementPredicateFunctionType(elementType)),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&OptionalType{
+				Type: IntType,
+			},
+		),
+	}
+}
+
+func ArrayReverseFunctionType(arrayType Type) *FunctionType {
+	return &FunctionType{
+		Purity: ast.FunctionPurityView,
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			arrayType,
+		),
+	}
+}
+
+func ArraySliceFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Purity: ast.FunctionPurityView,
+		Parameters: []*Parameter{
+			{
+				Identifier:     "from",
+				TypeAnnotation: NewTypeAnnotation(IntType),
+			},
+			{
+				Identifier:     "upTo",
+				TypeAnnotation: NewTypeAnnotation(IntType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&VariableSizedType{
+				Type: elementType,
+			},
+		),
+	}
+}
+
+func ArraySortFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Identifier: "by",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "a",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "b",
+								TypeAnnotation: NewTypeAnnotation(elementType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							BoolType,
+						),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			VoidType,
+		),
+	}
+}
+
+// elementPredicateFunctionType returns the type of a function
+// which accepts a single element of the given type, and returns a boolean
+//
+func elementPredicateFunctionType(elementType Type) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:          ArgumentLabelNotRequired,
+				Identifier:     "element",
+				TypeAnnotation: NewTypeAnnotation(elementType),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			BoolType,
+		),
+	}
+}
+
 // VariableSizedType is a variable sized array type
 type VariableSizedType struct {
 	Type                Type
@@ -2121,6 +2956,9 @@ type TypeParameter struct {
 	Name      string
 	TypeBound Type
 	Optional  bool
+	// isUserDefined is true for type parameters of
+	// generic function and composite declarations in programs
+	isUserDefined bool
 }
 
 func (p TypeParameter) string(typeFormatter func(Type) string) string {
@@ -2237,8 +3075,11 @@ func formatFunctionType(
 // FunctionType
 //
 type FunctionType struct {
-	ReceiverType             Type
-	IsConstructor            bool
+	ReceiverType  Type
+	IsConstructor bool
+	// Purity is the declared or inferred purity of the function.
+	// View functions have no side effects, and may be called in conditions
+	Purity                   ast.FunctionPurity
 	TypeParameters           []*TypeParameter
 	Parameters               []*Parameter
 	ReturnTypeAnnotation     *TypeAnnotation
@@ -2247,6 +3088,13 @@ type FunctionType struct {
 	Members                  *StringMemberOrderedMap
 }
 
+// IsView returns true if the function is declared or inferred to be a view function,
+// i.e. it has no side effects
+//
+func (t *FunctionType) IsView() bool {
+	return t.Purity == ast.FunctionPurityView
+}
+
 func RequiredArgumentCount(count int) *int {
 	return &count
 }
@@ -2577,6 +3425,7 @@ func (t *FunctionType) RewriteWithRestrictedTypes() (Type, bool) {
 		}
 
 		return &FunctionType{
+			Purity:                t.Purity,
 			TypeParameters:        rewrittenTypeParameters,
 			Parameters:            rewrittenParameters,
 			ReturnTypeAnnotation:  NewTypeAnnotation(rewrittenReturnType),
@@ -2659,7 +3508,8 @@ func (t *FunctionType) Unify(
 
 func (t *FunctionType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type {
 
-	// TODO: type parameters ?
+	// NOTE: the function's own type parameters are preserved,
+	// e.g. for a generic function of a generic composite type
 
 	// parameters
 
@@ -2688,6 +3538,8 @@ func (t *FunctionType) Resolve(typeArguments *TypeParameterTypeOrderedMap) Type
 	}
 
 	return &FunctionType{
+		Purity:                t.Purity,
+		TypeParameters:        t.TypeParameters,
 		Parameters:            newParameters,
 		ReturnTypeAnnotation:  NewTypeAnnotation(newReturnType),
 		RequiredArgumentCount: t.RequiredArgumentCount,
@@ -2748,12 +3600,14 @@ func init() {
 		PrivatePathType,
 		PublicPathType,
 		&CapabilityType{},
+		&RangeType{},
 		DeployedContractType,
 		BlockType,
 		AccountKeyType,
 		PublicKeyType,
 		SignatureAlgorithmType,
 		HashAlgorithmType,
+		RoundingRuleType,
 	)
 
 	for _, ty := range types {
@@ -2797,10 +3651,12 @@ var BaseValueActivation = NewVariableActivation(nil)
 
 var AllSignedFixedPointTypes = []Type{
 	Fix64Type,
+	Fix128Type,
 }
 
 var AllUnsignedFixedPointTypes = []Type{
 	UFix64Type,
+	UFix128Type,
 }
 
 var AllFixedPointTypes = append(
@@ -2891,6 +3747,7 @@ func init() {
 			}
 
 			functionType := &FunctionType{
+				Purity: ast.FunctionPurityView,
 				Parameters: []*Parameter{
 					{
 						Label:          ArgumentLabelNotRequired,
@@ -3012,6 +3869,7 @@ func init() {
 	}
 
 	functionType := &FunctionType{
+		Purity: ast.FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -3081,6 +3939,7 @@ func init() {
 	}
 
 	functionType := &FunctionType{
+		Purity:               ast.FunctionPurityView,
 		ReturnTypeAnnotation: NewTypeAnnotation(StringType),
 	}
 
@@ -3103,6 +3962,20 @@ func init() {
 		StringTypeEncodeHexFunctionDocString,
 	))
 
+	addMember(NewPublicFunctionMember(
+		functionType,
+		StringTypeJoinFunctionName,
+		StringTypeJoinFunctionType,
+		StringTypeJoinFunctionDocString,
+	))
+
+	addMember(NewPublicFunctionMember(
+		functionType,
+		StringTypeFromUTF8FunctionName,
+		StringTypeFromUTF8FunctionType,
+		StringTypeFromUTF8FunctionDocString,
+	))
+
 	BaseValueActivation.Set(
 		typeName,
 		baseFunctionVariable(
@@ -3114,6 +3987,7 @@ func init() {
 }
 
 var StringTypeEncodeHexFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
 	Parameters: []*Parameter{
 		{
 			Label:      ArgumentLabelNotRequired,
@@ -3128,6 +4002,46 @@ var StringTypeEncodeHexFunctionType = &FunctionType{
 	),
 }
 
+var StringTypeJoinFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
+	Parameters: []*Parameter{
+		{
+			Label:      ArgumentLabelNotRequired,
+			Identifier: "strings",
+			TypeAnnotation: NewTypeAnnotation(
+				StringArrayType,
+			),
+		},
+		{
+			Identifier: "separator",
+			TypeAnnotation: NewTypeAnnotation(
+				StringType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		StringType,
+	),
+}
+
+var StringTypeFromUTF8FunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
+	Parameters: []*Parameter{
+		{
+			Label:      ArgumentLabelNotRequired,
+			Identifier: "bytes",
+			TypeAnnotation: NewTypeAnnotation(
+				ByteArrayType,
+			),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: StringType,
+		},
+	),
+}
+
 func suggestIntegerLiteralConversionReplacement(
 	checker *Checker,
 	argument *ast.IntegerExpression,
@@ -3253,6 +4167,7 @@ func init() {
 		baseFunctionVariable(
 			typeName,
 			&FunctionType{
+				Purity:               ast.FunctionPurityView,
 				TypeParameters:       []*TypeParameter{{Name: "T"}},
 				ReturnTypeAnnotation: NewTypeAnnotation(MetaType),
 			},
@@ -3284,9 +4199,18 @@ type CompositeType struct {
 	// TODO: add support for overloaded initializers
 	ConstructorParameters []*Parameter
 	nestedTypes           *StringTypeOrderedMap
+	typeAliases           *StringTypeOrderedMap
+	extensions            []*ExtensionType
 	containerType         Type
 	EnumRawType           Type
-	hasComputedMembers    bool
+	// EnumCases are the names of the cases of an enum type,
+	// in declaration order
+	EnumCases          []string
+	hasComputedMembers bool
+
+	// TypeParameters are the type parameters of a generic composite type.
+	// The types of values are instantiations, see InstantiatedCompositeType
+	TypeParameters []*TypeParameter
 
 	// Only applicable for native composite types.
 	importable bool
@@ -3536,6 +4460,7 @@ func (t *CompositeType) InterfaceType() *InterfaceType {
 		InitializerParameters: t.ConstructorParameters,
 		containerType:         t.containerType,
 		nestedTypes:           t.nestedTypes,
+		typeAliases:           t.typeAliases,
 	}
 }
 
@@ -3579,6 +4504,19 @@ func (t *CompositeType) GetNestedTypes() *StringTypeOrderedMap {
 	return t.nestedTypes
 }
 
+// GetTypeAliases returns the type aliases declared in the composite type,
+// i.e. the target types of the aliases, by name
+//
+func (t *CompositeType) GetTypeAliases() *StringTypeOrderedMap {
+	return t.typeAliases
+}
+
+// GetExtensions returns the extensions declared in the composite type
+//
+func (t *CompositeType) GetExtensions() []*ExtensionType {
+	return t.extensions
+}
+
 func (t *CompositeType) initializeMemberResolvers() {
 	t.memberResolversOnce.Do(func() {
 		members := make(map[string]MemberResolver, t.Members.Len())
@@ -3754,6 +4692,7 @@ type InterfaceType struct {
 	InitializerParameters []*Parameter
 	containerType         Type
 	nestedTypes           *StringTypeOrderedMap
+	typeAliases           *StringTypeOrderedMap
 	cachedIdentifiers     *struct {
 		TypeID              TypeID
 		QualifiedIdentifier string
@@ -3972,6 +4911,13 @@ func (t *InterfaceType) GetNestedTypes() *StringTypeOrderedMap {
 	return t.nestedTypes
 }
 
+// GetTypeAliases returns the type aliases declared in the interface type,
+// i.e. the target types of the aliases, by name
+//
+func (t *InterfaceType) GetTypeAliases() *StringTypeOrderedMap {
+	return t.typeAliases
+}
+
 // DictionaryType consists of the key and value type
 // for all key-value pairs in the dictionary:
 // All keys have to be a subtype of the key type,
@@ -4106,6 +5052,21 @@ Removes the value for the given key from the dictionary.
 Returns the value as an optional if the dictionary contained the key, or nil if the dictionary did not contain the key
 `
 
+const dictionaryTypeForEachKeyFunctionDocString = `
+Calls the given function for each key of the dictionary, in order.
+
+Iteration stops early if the function returns false
+`
+
+const dictionaryTypeFilterFunctionDocString = `
+Returns a new dictionary which contains only the entries of the dictionary for which the given predicate function returns true
+`
+
+const dictionaryTypeMapValuesFunctionDocString = `
+Returns a new dictionary which contains the keys of the dictionary,
+and the results of calling the given transform function on the corresponding values
+`
+
 func (t *DictionaryType) GetMembers() map[string]MemberResolver {
 	t.initializeMemberResolvers()
 	return t.memberResolvers
@@ -4204,12 +5165,65 @@ func (t *DictionaryType) initializeMemberResolvers() {
 					)
 				},
 			},
+			"forEachKey": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, _ ast.Range, _ func(error)) *Member {
+					return NewPublicFunctionMember(t,
+						identifier,
+						DictionaryForEachKeyFunctionType(t),
+						dictionaryTypeForEachKeyFunctionDocString,
+					)
+				},
+			},
+			"filter": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+					if t.ValueType.IsResourceType() {
+						report(
+							&InvalidResourceDictionaryMemberError{
+								Name:            identifier,
+								DeclarationKind: common.DeclarationKindFunction,
+								Range:           targetRange,
+							},
+						)
+					}
+
+					return NewPublicFunctionMember(t,
+						identifier,
+						DictionaryFilterFunctionType(t),
+						dictionaryTypeFilterFunctionDocString,
+					)
+				},
+			},
+			"mapValues": {
+				Kind: common.DeclarationKindFunction,
+				Resolve: func(identifier string, targetRange ast.Range, report func(error)) *Member {
+
+					if t.ValueType.IsResourceType() {
+						report(
+							&InvalidResourceDictionaryMemberError{
+								Name:            identifier,
+								DeclarationKind: common.DeclarationKindFunction,
+								Range:           targetRange,
+							},
+						)
+					}
+
+					return NewPublicFunctionMember(t,
+						identifier,
+						DictionaryMapValuesFunctionType(t),
+						dictionaryTypeMapValuesFunctionDocString,
+					)
+				},
+			},
 		})
 	})
 }
 
 func DictionaryContainsKeyFunctionType(t *DictionaryType) *FunctionType {
 	return &FunctionType{
+		Purity: ast.FunctionPurityView,
 		Parameters: []*Parameter{
 			{
 				Label:          ArgumentLabelNotRequired,
@@ -4260,6 +5274,109 @@ func DictionaryRemoveFunctionType(t *DictionaryType) *FunctionType {
 	}
 }
 
+func DictionaryForEachKeyFunctionType(t *DictionaryType) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "function",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "key",
+								TypeAnnotation: NewTypeAnnotation(t.KeyType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							BoolType,
+						),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			VoidType,
+		),
+	}
+}
+
+func DictionaryFilterFunctionType(t *DictionaryType) *FunctionType {
+	return &FunctionType{
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "predicate",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "key",
+								TypeAnnotation: NewTypeAnnotation(t.KeyType),
+							},
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "value",
+								TypeAnnotation: NewTypeAnnotation(t.ValueType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							BoolType,
+						),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			t,
+		),
+	}
+}
+
+func DictionaryMapValuesFunctionType(t *DictionaryType) *FunctionType {
+	typeParameter := &TypeParameter{
+		Name: "T",
+	}
+
+	resultType := &GenericType{
+		TypeParameter: typeParameter,
+	}
+
+	return &FunctionType{
+		TypeParameters: []*TypeParameter{
+			typeParameter,
+		},
+		Parameters: []*Parameter{
+			{
+				Label:      ArgumentLabelNotRequired,
+				Identifier: "transform",
+				TypeAnnotation: NewTypeAnnotation(
+					&FunctionType{
+						Parameters: []*Parameter{
+							{
+								Label:          ArgumentLabelNotRequired,
+								Identifier:     "value",
+								TypeAnnotation: NewTypeAnnotation(t.ValueType),
+							},
+						},
+						ReturnTypeAnnotation: NewTypeAnnotation(
+							resultType,
+						),
+					},
+				),
+			},
+		},
+		ReturnTypeAnnotation: NewTypeAnnotation(
+			&DictionaryType{
+				KeyType:   t.KeyType,
+				ValueType: resultType,
+			},
+		),
+	}
+}
+
 func (*DictionaryType) isValueIndexableType() bool {
 	return true
 }
@@ -4531,6 +5648,7 @@ func (t *AddressType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
 const AddressTypeToBytesFunctionName = `toBytes`
 
 var AddressTypeToBytesFunctionType = &FunctionType{
+	Purity: ast.FunctionPurityView,
 	ReturnTypeAnnotation: NewTypeAnnotation(
 		ByteArrayType,
 	),
@@ -4596,6 +5714,21 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 		return true
 	}
 
+	// A type parameter `T: B` is a subtype of `V`
+	// if its type bound `B` is a subtype of `V`,
+	// and it is a subtype of `T?`
+
+	if genericSubType, ok := subType.(*GenericType); ok {
+		if optionalSuperType, ok := superType.(*OptionalType); ok &&
+			IsSubType(subType, optionalSuperType.Type) {
+
+			return true
+		}
+
+		typeBound := genericSubType.TypeParameter.TypeBound
+		return typeBound != nil && IsSubType(typeBound, superType)
+	}
+
 	switch superType {
 	case AnyType:
 		return true
@@ -4654,7 +5787,7 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 	case FixedPointType:
 		switch subType {
 		case FixedPointType, SignedFixedPointType,
-			UFix64Type:
+			UFix64Type, UFix128Type:
 
 			return true
 
@@ -4664,7 +5797,7 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 
 	case SignedFixedPointType:
 		switch subType {
-		case SignedFixedPointType, Fix64Type:
+		case SignedFixedPointType, Fix64Type, Fix128Type:
 			return true
 
 		default:
@@ -5137,6 +6270,19 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 		}
 
 	case ParameterizedType:
+		if instantiatedSuperType, ok := typedSuperType.(*InstantiatedCompositeType); ok {
+
+			// Within a generic composite declaration,
+			// the composite type `C` is the composite type instantiated
+			// with its own type parameters, `C<T...>`
+
+			if compositeSubType, ok := subType.(*CompositeType); ok &&
+				instantiatedSuperType.isSelfInstantiationOf(compositeSubType) {
+
+				return true
+			}
+		}
+
 		if superTypeBaseType := typedSuperType.BaseType(); superTypeBaseType != nil {
 
 			// T<Us> <: V<Ws>
@@ -5348,6 +6494,119 @@ func (t *TransactionType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
 	return t
 }
 
+// ExtensionType is the type of an extension declaration.
+//
+// An extension declares additional functions for an existing composite type,
+// the extended type. The extension is not a type itself:
+// there are no values of it and it may not be used in type annotations.
+//
+// The members of an extension are available on values of the extended type
+// in the program declaring the extension, and in programs importing it,
+// or importing the contract containing it.
+//
+type ExtensionType struct {
+	Location      common.Location
+	Identifier    string
+	ExtendedType  *CompositeType
+	Members       *StringMemberOrderedMap
+	containerType Type
+}
+
+func (*ExtensionType) IsType() {}
+
+func (t *ExtensionType) String() string {
+	return t.Identifier
+}
+
+func (t *ExtensionType) QualifiedString() string {
+	return t.QualifiedIdentifier()
+}
+
+func (t *ExtensionType) QualifiedIdentifier() string {
+	return qualifiedIdentifier(t.Identifier, t.containerType)
+}
+
+func (t *ExtensionType) ID() TypeID {
+	identifier := t.QualifiedIdentifier()
+	if t.Location == nil {
+		return TypeID(identifier)
+	}
+	return t.Location.TypeID(identifier)
+}
+
+func (t *ExtensionType) Equal(other Type) bool {
+	otherExtension, ok := other.(*ExtensionType)
+	if !ok {
+		return false
+	}
+
+	return otherExtension.ID() == t.ID()
+}
+
+func (*ExtensionType) IsResourceType() bool {
+	return false
+}
+
+func (*ExtensionType) IsInvalidType() bool {
+	return false
+}
+
+func (*ExtensionType) IsStorable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*ExtensionType) IsExternallyReturnable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*ExtensionType) IsImportable(_ map[*Member]bool) bool {
+	return false
+}
+
+func (*ExtensionType) IsEquatable() bool {
+	return false
+}
+
+func (*ExtensionType) TypeAnnotationState() TypeAnnotationState {
+	return TypeAnnotationStateValid
+}
+
+func (t *ExtensionType) RewriteWithRestrictedTypes() (Type, bool) {
+	return t, false
+}
+
+func (*ExtensionType) GetMembers() map[string]MemberResolver {
+	// NOTE: the members of the extension are members of the extended type
+	return map[string]MemberResolver{}
+}
+
+func (*ExtensionType) Unify(_ Type, _ *TypeParameterTypeOrderedMap, _ func(err error), _ ast.Range) bool {
+	return false
+}
+
+func (t *ExtensionType) Resolve(_ *TypeParameterTypeOrderedMap) Type {
+	return t
+}
+
+func (t *ExtensionType) GetContainerType() Type {
+	return t.containerType
+}
+
+func (t *ExtensionType) SetContainerType(containerType Type) {
+	t.containerType = containerType
+}
+
+func (t *ExtensionType) GetLocation() common.Location {
+	return t.Location
+}
+
+// Extends returns true if the extension declares members for the given composite type
+//
+func (t *ExtensionType) Extends(compositeType *CompositeType) bool {
+	return t.ExtendedType != nil &&
+		t.ExtendedType.ID() == compositeType.ID()
+}
+
 // RestrictedType
 //
 // No restrictions implies the type is fully restricted,
@@ -5847,6 +7106,7 @@ func init() {
 		PublicKeyType,
 		HashAlgorithmType,
 		SignatureAlgorithmType,
+		RoundingRuleType,
 		AuthAccountType,
 		AuthAccountKeysType,
 		AuthAccountContractsType,
@@ -5987,6 +7247,7 @@ var PublicKeyType = func() *CompositeType {
 }()
 
 var PublicKeyVerifyFunctionType = &FunctionType{
+	Purity:         ast.FunctionPurityView,
 	TypeParameters: []*TypeParameter{},
 	Parameters: []*Parameter{
 		{
@@ -6013,12 +7274,17 @@ var PublicKeyVerifyFunctionType = &FunctionType{
 	ReturnTypeAnnotation: NewTypeAnnotation(BoolType),
 }
 
-type CryptoAlgorithm interface {
+// NativeEnumCase is a case of a native enum type,
+// e.g. a signature algorithm or a rounding rule
+//
+type NativeEnumCase interface {
 	RawValue() uint8
 	Name() string
 	DocString() string
 }
 
+type CryptoAlgorithm = NativeEnumCase
+
 func GetMembersAsMap(members []*Member) *StringMemberOrderedMap {
 	membersMap := NewStringMemberOrderedMap()
 	for _, member := range members {
@@ -49,4 +49,7 @@ const (
 
 	Fix64TypeName  = "Fix64"
 	UFix64TypeName = "UFix64"
+
+	Fix128TypeName  = "Fix128"
+	UFix128TypeName = "UFix128"
 )
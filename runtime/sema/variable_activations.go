@@ -31,6 +31,7 @@ import (
 //
 type VariableActivation struct {
 	entries        *StringVariableOrderedMap
+	narrowedTypes  map[*Variable]Type
 	Depth          int
 	Parent         *VariableActivation
 	LeaveCallbacks []func(getEndPosition func() ast.Position)
@@ -89,10 +90,42 @@ func (a *VariableActivation) Set(name string, variable *Variable) {
 	a.entries.Set(name, variable)
 }
 
+// Narrow sets the narrowed type of the given variable in this activation.
+//
+func (a *VariableActivation) Narrow(variable *Variable, narrowedType Type) {
+	if a.narrowedTypes == nil {
+		a.narrowedTypes = map[*Variable]Type{}
+	}
+
+	a.narrowedTypes[variable] = narrowedType
+}
+
+// NarrowedType returns the narrowed type of the given variable in the activation.
+// It returns false if the type of the variable is not narrowed.
+//
+func (a *VariableActivation) NarrowedType(variable *Variable) (Type, bool) {
+
+	current := a
+
+	for current != nil {
+		if current.narrowedTypes != nil {
+			result, ok := current.narrowedTypes[variable]
+			if ok {
+				return result, true
+			}
+		}
+
+		current = current.Parent
+	}
+
+	return nil, false
+}
+
 // Clear removes all variables from this activation.
 //
 func (a *VariableActivation) Clear() {
 	a.LeaveCallbacks = nil
+	a.narrowedTypes = nil
 
 	if a.entries == nil {
 		return
@@ -224,6 +257,31 @@ func (a *VariableActivations) Find(name string) *Variable {
 	return current.Find(name)
 }
 
+// Narrow sets the narrowed type of the given variable in the current activation.
+//
+func (a *VariableActivations) Narrow(variable *Variable, narrowedType Type) {
+	current := a.Current()
+	// create the first scope if there is no scope
+	if current == nil {
+		current = a.pushNewWithParent(nil)
+	}
+	current.Narrow(variable, narrowedType)
+}
+
+// NarrowedType returns the narrowed type of the given variable in the current activation.
+// It returns false if the type of the variable is not narrowed
+// or if there is no current activation.
+//
+func (a *VariableActivations) NarrowedType(variable *Variable) (Type, bool) {
+
+	current := a.Current()
+	if current == nil {
+		return nil, false
+	}
+
+	return current.NarrowedType(variable)
+}
+
 // Depth returns the depth (size) of the activation stack.
 //
 func (a *VariableActivations) Depth() int {
@@ -21,6 +21,7 @@ package stdlib
 import (
 	"fmt"
 
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
@@ -39,6 +40,7 @@ The message argument is optional.
 var AssertFunction = NewStandardLibraryFunction(
 	"assert",
 	&sema.FunctionType{
+		Purity: ast.FunctionPurityView,
 		Parameters: []*sema.Parameter{
 			{
 				Label:          sema.ArgumentLabelNotRequired,
@@ -92,6 +94,7 @@ Terminates the program unconditionally and reports a message which explains why
 var PanicFunction = NewStandardLibraryFunction(
 	"panic",
 	&sema.FunctionType{
+		Purity: ast.FunctionPurityView,
 		Parameters: []*sema.Parameter{
 			{
 				Label:          sema.ArgumentLabelNotRequired,
@@ -150,6 +153,7 @@ Constructs a new public key
 var CreatePublicKeyFunction = NewStandardLibraryFunction(
 	sema.PublicKeyTypeName,
 	&sema.FunctionType{
+		Purity: ast.FunctionPurityView,
 		Parameters: []*sema.Parameter{
 			{
 				Identifier:     sema.PublicKeyPublicKeyField,
@@ -182,11 +186,11 @@ var CreatePublicKeyFunction = NewStandardLibraryFunction(
 func BuiltinValues() StandardLibraryValues {
 	signatureAlgorithmValue := StandardLibraryValue{
 		Name: sema.SignatureAlgorithmTypeName,
-		Type: cryptoAlgorithmEnumConstructorType(
+		Type: nativeEnumConstructorType(
 			sema.SignatureAlgorithmType,
 			sema.SignatureAlgorithms,
 		),
-		Value: cryptoAlgorithmEnumValue(
+		Value: nativeEnumValue(
 			sema.SignatureAlgorithmType,
 			sema.SignatureAlgorithms,
 			NewSignatureAlgorithmCase,
@@ -196,11 +200,11 @@ func BuiltinValues() StandardLibraryValues {
 
 	hashAlgorithmValue := StandardLibraryValue{
 		Name: sema.HashAlgorithmTypeName,
-		Type: cryptoAlgorithmEnumConstructorType(
+		Type: nativeEnumConstructorType(
 			sema.HashAlgorithmType,
 			sema.HashAlgorithms,
 		),
-		Value: cryptoAlgorithmEnumValue(
+		Value: nativeEnumValue(
 			sema.HashAlgorithmType,
 			sema.HashAlgorithms,
 			NewHashAlgorithmCase,
@@ -208,9 +212,24 @@ func BuiltinValues() StandardLibraryValues {
 		Kind: common.DeclarationKindEnum,
 	}
 
+	roundingRuleValue := StandardLibraryValue{
+		Name: sema.RoundingRuleTypeName,
+		Type: nativeEnumConstructorType(
+			sema.RoundingRuleType,
+			sema.RoundingRules,
+		),
+		Value: nativeEnumValue(
+			sema.RoundingRuleType,
+			sema.RoundingRules,
+			NewRoundingRuleCase,
+		),
+		Kind: common.DeclarationKindEnum,
+	}
+
 	return StandardLibraryValues{
 		signatureAlgorithmValue,
 		hashAlgorithmValue,
+		roundingRuleValue,
 	}
 }
 
@@ -222,6 +241,14 @@ func NewSignatureAlgorithmCase(rawValue uint8) *interpreter.CompositeValue {
 	)
 }
 
+func NewRoundingRuleCase(rawValue uint8) *interpreter.CompositeValue {
+	return interpreter.NewEnumCaseValue(
+		sema.RoundingRuleType,
+		interpreter.UInt8Value(rawValue),
+		nil,
+	)
+}
+
 var hashAlgorithmFunctions = map[string]interpreter.FunctionValue{
 	sema.HashAlgorithmTypeHashFunctionName:        hashAlgorithmHashFunction,
 	sema.HashAlgorithmTypeHashWithTagFunctionName: hashAlgorithmHashWithTagFunction,
@@ -272,9 +299,9 @@ var hashAlgorithmHashWithTagFunction = interpreter.NewHostFunctionValue(
 	sema.HashAlgorithmTypeHashWithTagFunctionType,
 )
 
-func cryptoAlgorithmEnumConstructorType(
+func nativeEnumConstructorType(
 	enumType *sema.CompositeType,
-	enumCases []sema.CryptoAlgorithm,
+	enumCases []sema.NativeEnumCase,
 ) *sema.FunctionType {
 
 	members := make([]*sema.Member, len(enumCases))
@@ -288,6 +315,7 @@ func cryptoAlgorithmEnumConstructorType(
 	}
 
 	constructorType := &sema.FunctionType{
+		Purity:        ast.FunctionPurityView,
 		IsConstructor: true,
 		Parameters: []*sema.Parameter{
 			{
@@ -306,9 +334,9 @@ func cryptoAlgorithmEnumConstructorType(
 	return constructorType
 }
 
-func cryptoAlgorithmEnumValue(
+func nativeEnumValue(
 	enumType *sema.CompositeType,
-	enumCases []sema.CryptoAlgorithm,
+	enumCases []sema.NativeEnumCase,
 	caseConstructor func(rawValue uint8) *interpreter.CompositeValue,
 ) interpreter.Value {
 
@@ -24,6 +24,7 @@ import (
 	"math/rand"
 	"strings"
 
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
@@ -54,6 +55,7 @@ Returns the public account for the given address
 `
 
 var getAccountFunctionType = &sema.FunctionType{
+	Purity: ast.FunctionPurityView,
 	Parameters: []*sema.Parameter{
 		{
 			Label:      sema.ArgumentLabelNotRequired,
@@ -88,6 +90,7 @@ Returns the current block, i.e. the block which contains the currently executed
 `
 
 var getCurrentBlockFunctionType = &sema.FunctionType{
+	Purity: ast.FunctionPurityView,
 	ReturnTypeAnnotation: sema.NewTypeAnnotation(
 		sema.BlockType,
 	),
@@ -98,6 +101,7 @@ Returns the block at the given height. If the given block does not exist the fun
 `
 
 var getBlockFunctionType = &sema.FunctionType{
+	Purity: ast.FunctionPurityView,
 	Parameters: []*sema.Parameter{
 		{
 			Label:      "at",
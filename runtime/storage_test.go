@@ -1992,8 +1992,12 @@ func TestRuntimeStorageSaveCapability(t *testing.T) {
 				}
 
 				var typeArgument string
+				var borrowTypeDescriptor cadence.Type
 				if len(ty) > 0 {
 					typeArgument = fmt.Sprintf("<%s>", ty)
+					borrowTypeDescriptor = cadence.ReferenceType{
+						Type: cadence.IntType{},
+					}.WithID(ty)
 				}
 
 				err := runtime.ExecuteTransaction(
@@ -2026,8 +2030,9 @@ func TestRuntimeStorageSaveCapability(t *testing.T) {
 								Domain:     domain.Identifier(),
 								Identifier: "test",
 							},
-							Address:    cadence.Address(signer),
-							BorrowType: ty,
+							Address:              cadence.Address(signer),
+							BorrowType:           ty,
+							BorrowTypeDescriptor: borrowTypeDescriptor,
 						},
 					},
 					value,
@@ -137,7 +137,26 @@ func TestCheckInvalidDictionaryKeys(t *testing.T) {
 
 	errs := ExpectCheckerErrors(t, err, 1)
 
-	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	assert.IsType(t, &sema.InvalidDictionaryKeyTypeError{}, errs[0])
+}
+
+func TestCheckDictionaryValuesCommonSuperType(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let z = {"a": 1, "b": true}
+	`)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.DictionaryType{
+			KeyType:   sema.StringType,
+			ValueType: sema.AnyStructType,
+		},
+		RequireGlobalValue(t, checker.Elaboration, "z"),
+	)
 }
 
 func TestCheckInvalidDictionaryValues(t *testing.T) {
@@ -145,7 +164,12 @@ func TestCheckInvalidDictionaryValues(t *testing.T) {
 	t.Parallel()
 
 	_, err := ParseAndCheck(t, `
-      let z = {"a": 1, "b": true}
+      resource R {}
+
+      fun test() {
+          let rs <- {"a": <-create R(), "b": true}
+          destroy rs
+      }
 	`)
 
 	errs := ExpectCheckerErrors(t, err, 1)
@@ -950,12 +974,35 @@ func TestCheckInvalidDictionarySubtyping(t *testing.T) {
 	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
 }
 
+func TestCheckArrayElementsCommonSuperType(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let z = [0, true]
+	`)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.VariableSizedType{
+			Type: sema.AnyStructType,
+		},
+		RequireGlobalValue(t, checker.Elaboration, "z"),
+	)
+}
+
 func TestCheckInvalidArrayElements(t *testing.T) {
 
 	t.Parallel()
 
 	_, err := ParseAndCheck(t, `
-      let z = [0, true]
+      resource R {}
+
+      fun test() {
+          let rs <- [<-create R(), true]
+          destroy rs
+      }
 	`)
 
 	errs := ExpectCheckerErrors(t, err, 1)
@@ -1147,3 +1194,116 @@ func TestNilAssignmentToDictionary(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestCheckArrayFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let xs = [3, 1, 2]
+      let mapped = xs.map(fun (x: Int): String {
+          return x.toString()
+      })
+      let filtered = xs.filter(fun (x: Int): Bool {
+          return x > 1
+      })
+      let sum = xs.reduce(initial: 0 as UInt8, fun (acc: UInt8, x: Int): UInt8 {
+          return acc + UInt8(x)
+      })
+      let index = xs.firstIndex(where: fun (x: Int): Bool {
+          return x == 2
+      })
+      let reversed = xs.reverse()
+      let sliced = xs.slice(from: 1, upTo: 2)
+
+      let fixed: [Int; 2] = [1, 2]
+      let fixedReversed = fixed.reverse()
+      let fixedSliced = fixed.slice(from: 0, upTo: 1)
+
+      fun test() {
+          xs.sort(by: fun (a: Int, b: Int): Bool {
+              return a < b
+          })
+      }
+	`)
+
+	require.NoError(t, err)
+
+	for name, expectedType := range map[string]sema.Type{
+		"mapped":        &sema.VariableSizedType{Type: sema.StringType},
+		"filtered":      &sema.VariableSizedType{Type: sema.IntType},
+		"sum":           sema.UInt8Type,
+		"index":         &sema.OptionalType{Type: sema.IntType},
+		"reversed":      &sema.VariableSizedType{Type: sema.IntType},
+		"sliced":        &sema.VariableSizedType{Type: sema.IntType},
+		"fixedReversed": &sema.ConstantSizedType{Type: sema.IntType, Size: 2},
+		"fixedSliced":   &sema.VariableSizedType{Type: sema.IntType},
+	} {
+		assert.Equal(t,
+			expectedType,
+			RequireGlobalValue(t, checker.Elaboration, name),
+			name,
+		)
+	}
+}
+
+func TestCheckInvalidArrayFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      let xs = [1, 2]
+      let filtered = xs.filter(fun (x: String): Bool {
+          return true
+      })
+      let index = xs.firstIndex(fun (x: Int): Bool {
+          return true
+      })
+      let sliced = xs.slice(from: "a", upTo: 1)
+	`)
+
+	errs := ExpectCheckerErrors(t, err, 3)
+
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	assert.IsType(t, &sema.MissingArgumentLabelError{}, errs[1])
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[2])
+}
+
+func TestCheckDictionaryFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let xs = {"a": 1, "b": 2}
+      let filtered = xs.filter(fun (key: String, value: Int): Bool {
+          return value > 1
+      })
+      let mapped = xs.mapValues(fun (value: Int): Bool {
+          return value > 1
+      })
+
+      fun test() {
+          xs.forEachKey(fun (key: String): Bool {
+              return true
+          })
+      }
+	`)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		&sema.DictionaryType{
+			KeyType:   sema.StringType,
+			ValueType: sema.IntType,
+		},
+		RequireGlobalValue(t, checker.Elaboration, "filtered"),
+	)
+
+	assert.Equal(t,
+		&sema.DictionaryType{
+			KeyType:   sema.StringType,
+			ValueType: sema.BoolType,
+		},
+		RequireGlobalValue(t, checker.Elaboration, "mapped"),
+	)
+}
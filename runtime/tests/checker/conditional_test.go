@@ -65,11 +65,22 @@ func TestCheckInvalidConditionalExpressionElse(t *testing.T) {
       }
 	`)
 
-	errs := ExpectCheckerErrors(t, err, 2)
+	errs := ExpectCheckerErrors(t, err, 1)
 
 	assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+}
+
+func TestCheckConditionalExpressionCommonSuperType(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let x = true ? 2 : false
+    `)
 
-	assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	require.NoError(t, err)
+
+	assert.Equal(t, sema.AnyStructType, RequireGlobalValue(t, checker.Elaboration, "x"))
 }
 
 func TestCheckInvalidConditionalExpressionTypes(t *testing.T) {
@@ -77,8 +88,10 @@ func TestCheckInvalidConditionalExpressionTypes(t *testing.T) {
 	t.Parallel()
 
 	_, err := ParseAndCheck(t, `
-      fun test() {
-          let x = true ? 2 : false
+      resource R {}
+
+      fun test(): @AnyResource {
+          return <-(true ? <-create R() : false)
       }
 	`)
 
@@ -87,17 +100,16 @@ func TestCheckInvalidConditionalExpressionTypes(t *testing.T) {
 	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
 }
 
-// TODO: return common super type for conditional
-func TestCheckInvalidAnyConditional(t *testing.T) {
+func TestCheckAnyConditional(t *testing.T) {
 
 	t.Parallel()
 
-	_, err := ParseAndCheck(t, `
+	checker, err := ParseAndCheck(t, `
       let x: AnyStruct = true
       let y = true ? 1 : x
     `)
 
-	errs := ExpectCheckerErrors(t, err, 1)
+	require.NoError(t, err)
 
-	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	assert.Equal(t, sema.AnyStructType, RequireGlobalValue(t, checker.Elaboration, "y"))
 }
@@ -492,7 +492,8 @@ func TestCheckFunctionWithPostConditionAndResourceResult(t *testing.T) {
         }
     `)
 
-	errs := ExpectCheckerErrors(t, err, 1)
+	errs := ExpectCheckerErrors(t, err, 2)
 
 	require.IsType(t, &sema.InvalidMoveOperationError{}, errs[0])
+	require.IsType(t, &sema.ImpureOperationError{}, errs[1])
 }
@@ -0,0 +1,503 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestCheckExtension(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {
+              pub let n: Int
+
+              init(n: Int) {
+                  self.n = n
+              }
+          }
+
+          extension Doubling for S {
+              pub fun double(): Int {
+                  return self.n * 2
+              }
+          }
+
+          let x = S(n: 21).double()
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+
+		assert.IsType(t,
+			&sema.ExtensionType{},
+			RequireGlobalType(t, checker.Elaboration, "Doubling"),
+		)
+	})
+
+	t.Run("resource, reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {
+              pub let id: UInt64
+
+              init(id: UInt64) {
+                  self.id = id
+              }
+          }
+
+          extension Naming for R {
+              pub fun name(): String {
+                  return "R #".concat(self.id.toString())
+              }
+          }
+
+          fun test(ref: &R): String {
+              return ref.name()
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("optional chaining", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {}
+
+          extension E for S {
+              pub fun one(): Int {
+                  return 1
+              }
+          }
+
+          let s: S? = S()
+          let x = s?.one()
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{Type: sema.IntType},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("calls other extension function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          extension E for S {
+              fun one(): Int {
+                  return 1
+              }
+
+              pub fun two(): Int {
+                  return self.one() + self.one()
+              }
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("nested in contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+
+              pub struct S {}
+
+              pub extension E for S {
+                  pub fun one(): Int {
+                      return 1
+                  }
+              }
+          }
+
+          let x: Int = C.S().one()
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("nested in struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              extension E for S {}
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNestedDeclarationError{}, errs[0])
+	})
+
+	t.Run("nested in contract interface", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          contract interface CI {
+              extension E for S {}
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNestedDeclarationError{}, errs[0])
+	})
+
+	t.Run("invalid extended type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {}
+
+          struct interface I {}
+
+          extension E1 for Int {}
+
+          extension E2 for C {}
+
+          extension E3 for I {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 3)
+
+		assert.IsType(t, &sema.InvalidExtendedTypeError{}, errs[0])
+		assert.IsType(t, &sema.InvalidExtendedTypeError{}, errs[1])
+		assert.IsType(t, &sema.InvalidExtendedTypeError{}, errs[2])
+	})
+
+	t.Run("invalid members", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          extension E for S {
+              let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.InvalidExtensionMemberError{}, errs[0])
+		assert.IsType(t, &sema.InvalidExtensionMemberError{}, errs[1])
+	})
+
+	t.Run("conflicting member", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              pub fun foo() {}
+          }
+
+          extension E for S {
+              pub fun foo() {}
+
+              pub fun getType(): Int {
+                  return 1
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.ExtensionMemberConflictError{}, errs[0])
+		assert.IsType(t, &sema.ExtensionMemberConflictError{}, errs[1])
+	})
+
+	t.Run("ambiguous member", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          extension E1 for S {
+              pub fun foo() {}
+          }
+
+          extension E2 for S {
+              pub fun foo() {}
+          }
+
+          fun test() {
+              S().foo()
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.AmbiguousExtensionMemberError{}, errs[0])
+	})
+
+	t.Run("not a type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          extension E for S {}
+
+          fun test(e: E) {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidExtensionTypeUseError{}, errs[0])
+	})
+}
+
+func TestCheckExtensionAccess(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("private field of extended type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              priv let secret: Int
+
+              init() {
+                  self.secret = 42
+              }
+          }
+
+          extension E for S {
+              pub fun reveal(): Int {
+                  return self.secret
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAccessError{}, errs[0])
+	})
+
+	t.Run("assignment to field of extended type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              pub var n: Int
+
+              init() {
+                  self.n = 0
+              }
+          }
+
+          extension E for S {
+              pub fun reset() {
+                  self.n = 0
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAssignmentAccessError{}, errs[0])
+	})
+
+	t.Run("private extension function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          extension E for S {
+              priv fun helper(): Int {
+                  return 1
+              }
+
+              pub fun foo(): Int {
+                  return self.helper()
+              }
+          }
+
+          fun test(): Int {
+              return S().helper()
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAccessError{}, errs[0])
+	})
+
+	t.Run("contract access", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+
+              pub struct S {
+                  access(contract) let n: Int
+
+                  init() {
+                      self.n = 1
+                  }
+              }
+
+              pub extension E for S {
+                  pub fun get(): Int {
+                      return self.n
+                  }
+              }
+          }
+        `)
+		require.NoError(t, err)
+	})
+}
+
+func TestCheckImportedExtension(t *testing.T) {
+
+	t.Parallel()
+
+	importedChecker, err := ParseAndCheckWithOptions(t,
+		`
+          pub struct S {}
+
+          pub extension TopLevel for S {
+              pub fun one(): Int {
+                  return 1
+              }
+          }
+
+          pub contract C {
+
+              pub extension Nested for S {
+                  pub fun two(): Int {
+                      return 2
+                  }
+              }
+          }
+        `,
+		ParseAndCheckOptions{
+			Location: utils.ImportedLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	importHandler := sema.WithImportHandler(
+		func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+			return sema.ElaborationImport{
+				Elaboration: importedChecker.Elaboration,
+			}, nil
+		},
+	)
+
+	t.Run("all", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			`
+              import "imported"
+
+              let x = S().one() + S().two()
+            `,
+			ParseAndCheckOptions{
+				Options: []sema.Option{importHandler},
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("only type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			`
+              import S from "imported"
+
+              let x = S().one()
+            `,
+			ParseAndCheckOptions{
+				Options: []sema.Option{importHandler},
+			},
+		)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+	})
+
+	t.Run("contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			`
+              import S, C from "imported"
+
+              let x = S().two()
+            `,
+			ParseAndCheckOptions{
+				Options: []sema.Option{importHandler},
+			},
+		)
+		require.NoError(t, err)
+	})
+}
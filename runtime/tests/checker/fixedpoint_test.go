@@ -658,13 +658,25 @@ func TestCheckFixedPointLiteralScales(t *testing.T) {
 					),
 				)
 
-				if i <= scale {
+				// The type of the literal without a type annotation
+				// is inferred to be UFix64, which has a scale of Fix64Scale
+
+				expectedErrorCount := 0
+				if i > scale {
+					expectedErrorCount++
+				}
+				if i > sema.Fix64Scale {
+					expectedErrorCount++
+				}
+
+				if expectedErrorCount == 0 {
 					assert.NoError(t, err)
 				} else {
-					errs := ExpectCheckerErrors(t, err, 2)
+					errs := ExpectCheckerErrors(t, err, expectedErrorCount)
 
-					assert.IsType(t, &sema.InvalidFixedPointLiteralScaleError{}, errs[0])
-					assert.IsType(t, &sema.InvalidFixedPointLiteralScaleError{}, errs[1])
+					for _, err := range errs {
+						assert.IsType(t, &sema.InvalidFixedPointLiteralScaleError{}, err)
+					}
 				}
 			}
 		})
@@ -710,3 +722,66 @@ func TestCheckFixedPointMinMax(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckFixedPointRoundingArithmetic(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllFixedPointTypes {
+		// Only test leaf types
+		switch ty {
+		case sema.FixedPointType, sema.SignedFixedPointType:
+			continue
+		}
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			_, err := ParseAndCheck(t,
+				fmt.Sprintf(
+					`
+                      fun test(rule: RoundingRule): [%[1]s] {
+                          let x: %[1]s = 1.5
+                          return [
+                              x.multiplyWithRounding(2.5, rounding: rule),
+                              x.divideWithRounding(2.5, rounding: rule)
+                          ]
+                      }
+                    `,
+					ty,
+				),
+			)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("invalid rounding argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let x: Fix64 = 1.5
+          let y = x.multiplyWithRounding(2.5, rounding: 1)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid operand type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(rule: RoundingRule): Fix64 {
+              let x: Fix64 = 1.5
+              let y: UFix64 = 2.5
+              return x.divideWithRounding(y, rounding: rule)
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
@@ -208,3 +208,148 @@ func TestCheckInvalidForShadowing(t *testing.T) {
 
 	assert.IsType(t, &sema.RedeclarationError{}, errs[0])
 }
+
+func TestCheckForIndex(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs: [String] = ["a", "b"]
+              for i, x in xs {
+                  let index: Int = i
+                  let element: String = x
+              }
+          }
+        `)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("range", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              for i, x in 0..<(10 as UInt8) {
+                  let index: Int = i
+                  let element: UInt8 = x
+              }
+          }
+        `)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid index type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              for i, x in ["a"] {
+                  let index: String = i
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid redeclaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              for x, x in ["a"] {}
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.RedeclarationError{}, errs[0])
+	})
+}
+
+func TestCheckForDictionary(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("keys", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs: {String: Int} = {"a": 1}
+              for key in xs {
+                  let k: String = key
+              }
+          }
+        `)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("keys and values", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs: {String: Int} = {"a": 1}
+              for key, value in xs {
+                  let k: String = key
+                  let v: Int = value
+              }
+          }
+        `)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid value type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let xs: {String: Int} = {"a": 1}
+              for key, value in xs {
+                  let v: String = value
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid, resource values", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun test() {
+              let xs <- {"a": <-create R()}
+              for key, value in xs {}
+              destroy xs
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.UnsupportedResourceForLoopError{}, errs[0])
+	})
+}
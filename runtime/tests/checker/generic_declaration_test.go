@@ -0,0 +1,566 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckGenericFunctionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("inferred type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun first<T>(_ values: [T]): T? {
+              if values.length == 0 {
+                  return nil
+              }
+              return values[0]
+          }
+
+          let x = first([1, 2, 3])
+          let y = first(["a"])
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{Type: sema.IntType},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+
+		assert.Equal(t,
+			&sema.OptionalType{Type: sema.StringType},
+			RequireGlobalValue(t, checker.Elaboration, "y"),
+		)
+	})
+
+	t.Run("explicit type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun identity<T>(_ value: T): T {
+              return value
+          }
+
+          let x = identity<UInt8>(1)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.UInt8Type,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("type argument mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun pair<T>(_ a: T, _ b: T): [T] {
+              return [a, b]
+          }
+
+          let x = pair(1, "2")
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("type bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun double<T: Integer>(_ value: T): [T] {
+              return [value, value]
+          }
+
+          let x = double(1)
+          let y = double("2")
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("resource type bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun identity<T: AnyResource>(_ value: @T): @T {
+              return <-value
+          }
+
+          fun test() {
+              let r <- identity(<-create R())
+              destroy r
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("resource type bound, loss", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun drop<T: AnyResource>(_ value: @T) {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ResourceLossError{}, errs[0])
+	})
+
+	t.Run("unbounded, resource argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun identity<T>(_ value: T): T {
+              return value
+          }
+
+          fun test() {
+              let r <- identity(<-create R())
+              destroy r
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("members of type bound", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface HasID {
+              pub let id: Int
+          }
+
+          fun ids<T: AnyStruct{HasID}>(_ values: [T]): [Int] {
+              let ids: [Int] = []
+              for value in values {
+                  ids.append(value.id)
+              }
+              return ids
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("type parameter not in scope outside", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T>(_ value: T) {}
+
+          let x: T = 1
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("redeclared type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test<T, T>() {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.RedeclarationError{}, errs[0])
+	})
+}
+
+func TestCheckGenericCompositeDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("struct, inferred type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct Box<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+
+              pub fun get(): T {
+                  return self.value
+              }
+          }
+
+          let box = Box(value: 1)
+          let value = box.value
+          let got = box.get()
+        `)
+		require.NoError(t, err)
+
+		boxType := RequireGlobalValue(t, checker.Elaboration, "box")
+		require.IsType(t, &sema.InstantiatedCompositeType{}, boxType)
+		assert.Equal(t, "Box<Int>", boxType.String())
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalValue(t, checker.Elaboration, "value"),
+		)
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalValue(t, checker.Elaboration, "got"),
+		)
+	})
+
+	t.Run("struct, explicit type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct Box<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box: Box<Int8> = Box<Int8>(value: 1)
+          let value = box.value
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.Int8Type,
+			RequireGlobalValue(t, checker.Elaboration, "value"),
+		)
+	})
+
+	t.Run("struct, type annotation mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Box<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box: Box<String> = Box(value: 1)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("struct, covariant type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Box<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box: Box<AnyStruct> = Box(value: 1)
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("missing type arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Box<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box: Box = Box(value: 1)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.MissingTypeArgumentsError{}, errs[0])
+	})
+
+	t.Run("invalid type argument count", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Pair<A, B> {
+              pub let first: A
+              pub let second: B
+
+              init(first: A, second: B) {
+                  self.first = first
+                  self.second = second
+              }
+          }
+
+          let pair: Pair<Int> = Pair(first: 1, second: 2)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.InvalidTypeArgumentCountError{}, errs[0])
+	})
+
+	t.Run("type bound violation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Box<T: Integer> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box: Box<String> = Box<String>(value: "")
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		require.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	})
+
+	t.Run("self is instantiation with own type parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Box<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+
+              pub fun copy(): Box<T> {
+                  return self
+              }
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("generic function in generic struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct Box<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+
+              pub fun map<U>(_ f: ((T): U)): Box<U> {
+                  return Box<U>(value: f(self.value))
+              }
+          }
+
+          let box = Box(value: 1).map(fun (_ value: Int): String {
+              return value.toString()
+          })
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			"Box<String>",
+			RequireGlobalValue(t, checker.Elaboration, "box").String(),
+		)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource Item {}
+
+          resource Holder<T: AnyResource> {
+              pub var item: @T?
+
+              init(item: @T) {
+                  self.item <- item
+              }
+
+              pub fun take(): @T {
+                  let item <- self.item <- nil
+                  return <-item!
+              }
+
+              destroy() {
+                  destroy self.item
+              }
+          }
+
+          fun test() {
+              let holder <- create Holder(item: <-create Item())
+              let item <- holder.take()
+              destroy item
+              destroy holder
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("resource, missing create", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource Holder<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          fun test() {
+              let holder <- Holder(value: 1)
+              destroy holder
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.MissingCreateError{}, errs[0])
+	})
+
+	t.Run("resource field of unbounded type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          struct Box<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          fun test() {
+              let box = Box(value: <-create R())
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid kinds", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C<T> {}
+
+          enum E<T>: UInt8 {}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		require.IsType(t, &sema.InvalidTypeParametersError{}, errs[0])
+		require.IsType(t, &sema.InvalidTypeParametersError{}, errs[1])
+	})
+
+	t.Run("storable", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct Box<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          contract C {
+              let box: Box<Int>
+              let functionBox: Box<((): Void)>
+
+              init() {
+                  self.box = Box(value: 1)
+                  self.functionBox = Box(value: fun () {})
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.FieldTypeNotStorableError{}, errs[0])
+	})
+}
@@ -0,0 +1,193 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckMathFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	isBigInteger := func(ty sema.Type) bool {
+		switch ty {
+		case sema.IntType, sema.UIntType,
+			sema.Int128Type, sema.Int256Type,
+			sema.UInt128Type, sema.UInt256Type:
+			return true
+		}
+		return false
+	}
+
+	type testCase struct {
+		call      string
+		available func(ty sema.Type) bool
+	}
+
+	testCases := map[string]testCase{
+		sema.NumericTypePowFunctionName: {
+			call: "x.pow(2)",
+			available: func(ty sema.Type) bool {
+				return sema.IsSubType(ty, sema.IntegerType)
+			},
+		},
+		sema.NumericTypeSaturatingPowFunctionName: {
+			call: "x.saturatingPow(2)",
+			available: func(ty sema.Type) bool {
+				switch ty {
+				case sema.IntType, sema.UIntType:
+					return false
+				}
+				return sema.IsSubType(ty, sema.IntegerType) &&
+					!strings.HasPrefix(ty.String(), "Word")
+			},
+		},
+		sema.NumericTypeSqrtFunctionName: {
+			call: "x.sqrt()",
+			available: func(_ sema.Type) bool {
+				return true
+			},
+		},
+		sema.NumericTypeAbsFunctionName: {
+			call: "x.abs()",
+			available: func(ty sema.Type) bool {
+				return sema.IsSubType(ty, sema.SignedNumberType)
+			},
+		},
+		sema.NumericTypeMinFunctionName: {
+			call: "x.min(x)",
+			available: func(_ sema.Type) bool {
+				return true
+			},
+		},
+		sema.NumericTypeMaxFunctionName: {
+			call: "x.max(x)",
+			available: func(_ sema.Type) bool {
+				return true
+			},
+		},
+		sema.NumericTypeMulDivFunctionName: {
+			call: "x.mulDiv(x, x, rounding: rule)",
+			available: func(ty sema.Type) bool {
+				return sema.IsSubType(ty, sema.FixedPointType) ||
+					isBigInteger(ty)
+			},
+		},
+	}
+
+	for _, ty := range sema.AllNumberTypes {
+
+		switch ty {
+		case sema.NumberType, sema.SignedNumberType,
+			sema.IntegerType, sema.SignedIntegerType,
+			sema.FixedPointType, sema.SignedFixedPointType:
+			continue
+		}
+
+		for name, testCase := range testCases {
+
+			// NOTE: don't capture loop variables
+			ty := ty
+			testCase := testCase
+
+			t.Run(fmt.Sprintf("%s.%s", ty, name), func(t *testing.T) {
+
+				t.Parallel()
+
+				_, err := ParseAndCheck(t,
+					fmt.Sprintf(
+						`
+                          fun test(x: %[1]s, rule: RoundingRule): %[1]s {
+                              return %[2]s
+                          }
+                        `,
+						ty,
+						testCase.call,
+					),
+				)
+
+				if testCase.available(ty) {
+					require.NoError(t, err)
+				} else {
+					errs := ExpectCheckerErrors(t, err, 1)
+
+					assert.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+				}
+			})
+		}
+	}
+}
+
+func TestCheckInvalidMathFunctionArguments(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("pow, exponent type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(x: UInt64, exponent: UInt64): UInt64 {
+              return x.pow(exponent)
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("min, operand type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(x: UFix64, y: Fix64): UFix64 {
+              return x.min(y)
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("mulDiv, missing rounding", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(x: UFix64): UFix64 {
+              return x.mulDiv(1.0, 2.0)
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ArgumentCountError{}, errs[0])
+	})
+}
@@ -0,0 +1,444 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckOptionalNarrowing(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("not nil, then", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?): Int {
+              if x != nil {
+                  return x + 1
+              }
+              return 0
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("nil on left-hand side", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?): Int {
+              if nil != x {
+                  return x + 1
+              }
+              return 0
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("nil, else", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?): Int {
+              if x == nil {
+                  return 0
+              } else {
+                  return x + 1
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("nil, then", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?): Int {
+              if x == nil {
+                  return x
+              }
+              return 0
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("after if", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?): Int {
+              if x != nil {
+                  let y = x + 1
+              }
+              return x
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("early return", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?): Int {
+              if x == nil {
+                  return 0
+              }
+              return x + 1
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("early panic", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckWithPanic(t, `
+          fun test(_ x: Int?): Int {
+              if x == nil {
+                  panic("x is nil")
+              }
+              return x + 1
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("early return in else", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?): Int {
+              if x != nil {
+                  let y = 1
+              } else {
+                  return 0
+              }
+              return x + 1
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("early return, scope", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?): Int {
+              if true {
+                  if x == nil {
+                      return 0
+                  }
+                  let y = x + 1
+              }
+              return x
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("and", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?, _ y: Int?): Int {
+              if x != nil && y != nil && x > 0 {
+                  return x + y
+              }
+              return 0
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("or, else", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?, _ y: Int?): Int {
+              if x == nil || y == nil || x < 0 {
+                  return 0
+              }
+              return x + y
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("or, then", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?, _ y: Int?): Int {
+              if x != nil || y != nil {
+                  return x
+              }
+              return 0
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("negation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?): Int {
+              if !(x == nil) {
+                  return x + 1
+              }
+              return 0
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("conditional expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ x: Int?): Int {
+              return x != nil ? x + 1 : 0
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("member access", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              let n: Int
+              init() {
+                  self.n = 1
+              }
+          }
+
+          fun test(_ s: S?): Int {
+              if s == nil {
+                  return 0
+              }
+              return s.n
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("let", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): Int {
+              let x: Int? = 1
+              if x == nil {
+                  return 0
+              }
+              return x + 1
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("non-mutated var", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): Int {
+              var x: Int? = 1
+              if x == nil {
+                  return 0
+              }
+              return x + 1
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("mutated var", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): Int {
+              var x: Int? = 1
+              if x == nil {
+                  return 0
+              }
+              x = nil
+              return x
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("var mutated in closure", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(): Int {
+              var x: Int? = 1
+              let reset = fun () {
+                  x = nil
+              }
+              if x == nil {
+                  return 0
+              }
+              reset()
+              return x
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("global var", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          var x: Int? = 1
+
+          fun test(): Int {
+              if x == nil {
+                  return 0
+              }
+              return x
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("optional uses", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct S {
+              let n: Int
+              init() {
+                  self.n = 1
+              }
+          }
+
+          fun test(_ x: Int?, _ s: S?): Int {
+              if x != nil && s != nil {
+                  let a = x!
+                  let b = x ?? 0
+                  let c = s?.n
+                  if let d = x {}
+                  if x == nil {}
+              }
+              return 0
+          }
+        `)
+
+		require.NoError(t, err)
+		assert.Empty(t, checker.Hints())
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {
+              let n: Int
+              init() {
+                  self.n = 1
+              }
+          }
+
+          fun test(_ r: @R?): Int {
+              if r == nil {
+                  destroy r
+                  return 0
+              }
+              let n = r.n
+              destroy r
+              return n
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
+	})
+}
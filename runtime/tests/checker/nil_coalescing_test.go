@@ -155,15 +155,32 @@ func TestCheckInvalidNilCoalescingRightSubtype(t *testing.T) {
 	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
 }
 
-func TestCheckInvalidNilCoalescingNonMatchingTypes(t *testing.T) {
+func TestCheckNilCoalescingNonMatchingTypes(t *testing.T) {
 
 	t.Parallel()
 
-	_, err := ParseAndCheck(t, `
+	checker, err := ParseAndCheck(t, `
       let x: Int? = 1
       let y = x ?? false
    `)
 
+	require.NoError(t, err)
+
+	assert.Equal(t, sema.AnyStructType, RequireGlobalValue(t, checker.Elaboration, "y"))
+}
+
+func TestCheckInvalidNilCoalescingNonMatchingTypes(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      resource R {}
+
+      fun test(r: @R?): @AnyResource {
+          return <-(r ?? false)
+      }
+   `)
+
 	errs := ExpectCheckerErrors(t, err, 1)
 
 	assert.IsType(t, &sema.InvalidBinaryOperandError{}, errs[0])
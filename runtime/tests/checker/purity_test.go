@@ -0,0 +1,553 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckViewFunction(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("no side effects", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun test(_ xs: [Int]): Int {
+              var sum = 0
+              let copy = xs
+              for x in xs {
+                  sum = sum + x
+              }
+              copy.append(sum)
+              return copy.length
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("write to global variable", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          var count = 0
+
+          view fun test() {
+              count = count + 1
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+	})
+
+	t.Run("write to field", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              var count: Int
+
+              init() {
+                  self.count = 0
+              }
+
+              view fun increment() {
+                  self.count = self.count + 1
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+	})
+
+	t.Run("write through reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              var count: Int
+
+              init() {
+                  self.count = 0
+              }
+          }
+
+          view fun test(_ s: &S) {
+              s.count = 1
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+	})
+
+	t.Run("append to global array", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let xs: [Int] = []
+
+          view fun test() {
+              xs.append(1)
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+	})
+
+	t.Run("append to array parameter reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun test(_ xs: &[Int]) {
+              xs.append(1)
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+	})
+
+	t.Run("write to element of array parameter reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun test(_ xs: &[Int]) {
+              xs[0] = 1
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+	})
+
+	t.Run("call of impure function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          var count = 0
+
+          view fun test(): Int {
+              return increment()
+          }
+
+          fun increment(): Int {
+              count = count + 1
+              return count
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+	})
+
+	t.Run("call of inferred view function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun test(): Int {
+              return double(21)
+          }
+
+          fun double(_ x: Int): Int {
+              return x * 2
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("emit", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          event Tested()
+
+          view fun test() {
+              emit Tested()
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+	})
+
+	t.Run("create and destroy", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          view fun test() {
+              let r <- create R()
+              destroy r
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+		require.IsType(t, &sema.ImpureOperationError{}, errs[1])
+	})
+
+	t.Run("storage", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          view fun test(account: AuthAccount) {
+              account.save(1, to: /storage/one)
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+	})
+
+	t.Run("function expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          var count = 0
+
+          let increment = view fun () {
+              count = count + 1
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+	})
+}
+
+func TestCheckConditionPurity(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("built-in view functions", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ xs: [Int], _ name: String) {
+              pre {
+                  xs.contains(1)
+                  name.concat("!").length > 1
+                  Int8(xs[0]) > 0
+                  xs.getType() == Type<[Int]>()
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("call of inferred view function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              let xs: [Int]
+
+              init() {
+                  self.xs = []
+              }
+
+              fun add(_ x: Int) {
+                  pre {
+                      !self.contains(x)
+                  }
+                  self.xs.append(x)
+              }
+
+              fun contains(_ x: Int): Bool {
+                  return self.xs.contains(x)
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("call of recursive view function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ n: Int) {
+              pre {
+                  isEven(n)
+              }
+          }
+
+          fun isEven(_ n: Int): Bool {
+              if n == 0 {
+                  return true
+              }
+              return isOdd(n - 1)
+          }
+
+          fun isOdd(_ n: Int): Bool {
+              if n == 0 {
+                  return false
+              }
+              return isEven(n - 1)
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("call of transitively impure function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          var count = 0
+
+          fun test() {
+              post {
+                  check()
+              }
+          }
+
+          fun check(): Bool {
+              return increment() > 0
+          }
+
+          fun increment(): Int {
+              count = count + 1
+              return count
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		var impureOperationError *sema.ImpureOperationError
+		require.ErrorAs(t, errs[0], &impureOperationError)
+
+		assert.Equal(t, "condition", impureOperationError.Context)
+	})
+
+	t.Run("call of impure function in before", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          var count = 0
+
+          fun test() {
+              post {
+                  before(increment()) > 0
+              }
+          }
+
+          fun increment(): Int {
+              count = count + 1
+              return count
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+	})
+
+	t.Run("mutation of local array", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test(_ xs: [Int]) {
+              pre {
+                  xs.removeFirst() > 0
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+	})
+
+	t.Run("create", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun consume(_ r: @R): Bool {
+              destroy r
+              return true
+          }
+
+          fun test() {
+              pre {
+                  consume(<-create R())
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		require.IsType(t, &sema.ImpureOperationError{}, errs[0])
+		require.IsType(t, &sema.ImpureOperationError{}, errs[1])
+	})
+
+	t.Run("call of interface function", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct interface I {
+              fun get(): Int
+
+              view fun viewGet(): Int
+
+              fun test() {
+                  pre {
+                      self.viewGet() > 0
+                      self.get() > 0
+                  }
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+
+		hints := checker.Hints()
+		require.Len(t, hints, 1)
+
+		require.IsType(t, &sema.NonViewInterfaceFunctionCallHint{}, hints[0])
+		assert.Equal(t, "get", hints[0].(*sema.NonViewInterfaceFunctionCallHint).FunctionName)
+	})
+
+	t.Run("call of type requirement function", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          contract interface CI {
+
+              resource Collection {
+                  fun getIDs(): [UInt64]
+              }
+
+              fun createEmptyCollection(): @Collection {
+                  post {
+                      result.getIDs().length == 0
+                  }
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+
+		hints := checker.Hints()
+		require.Len(t, hints, 1)
+
+		require.IsType(t, &sema.NonViewInterfaceFunctionCallHint{}, hints[0])
+	})
+}
+
+func TestCheckViewFunctionConformance(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("inferred view function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              view fun get(): Int
+          }
+
+          struct S: I {
+              fun get(): Int {
+                  return 1
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("impure function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct interface I {
+              view fun get(): Int
+          }
+
+          struct S: I {
+              var count: Int
+
+              init() {
+                  self.count = 0
+              }
+
+              fun get(): Int {
+                  self.count = self.count + 1
+                  return self.count
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.ViewFunctionConformanceError{}, errs[0])
+	})
+}
@@ -0,0 +1,161 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckRangeValue(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("inferred element type", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let a = 0..<10
+          let b = 0...(10 as UInt8)
+          let n: Int64 = 10
+          let c = n...20
+        `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.RangeType{ElementType: sema.IntType},
+			RequireGlobalValue(t, checker.Elaboration, "a"),
+		)
+		assert.Equal(t,
+			&sema.RangeType{ElementType: sema.UInt8Type},
+			RequireGlobalValue(t, checker.Elaboration, "b"),
+		)
+		assert.Equal(t,
+			&sema.RangeType{ElementType: sema.Int64Type},
+			RequireGlobalValue(t, checker.Elaboration, "c"),
+		)
+	})
+
+	t.Run("expected element type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let a: Range<UInt8> = 0..<10
+          let b: Range = a
+          let c: Range<Integer> = a
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("members", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let r = (0...(10 as Word8)).stride(by: 2)
+          let start: Word8 = r.start
+          let end: Word8 = r.end
+          let step: Word8 = r.step
+          let isInclusive: Bool = r.isInclusive
+          let contains: Bool = r.contains(4)
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid, mismatched bounds", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let a = (0 as UInt8)..<(10 as UInt16)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid, non-integer bounds", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let a = 0.5...1.5
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchWithDescriptionError{}, errs[0])
+	})
+
+	t.Run("invalid, abstract integer bounds", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let a: Integer = 1
+          let b = a..<a
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchWithDescriptionError{}, errs[0])
+	})
+
+	t.Run("invalid, non-integer type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let a: Range<String>? = nil
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("invalid, not storable", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              let range: Range<Int>
+
+              init() {
+                  self.range = 0..<10
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.FieldTypeNotStorableError{}, errs[0])
+	})
+}
@@ -4864,9 +4864,10 @@ func TestCheckInvalidationInPreCondition(t *testing.T) {
       }
     `)
 
-	errs := ExpectCheckerErrors(t, err, 1)
+	errs := ExpectCheckerErrors(t, err, 2)
 
 	assert.IsType(t, &sema.ResourceUseAfterInvalidationError{}, errs[0])
+	assert.IsType(t, &sema.ImpureOperationError{}, errs[1])
 }
 
 func TestCheckInvalidationInPostConditionBefore(t *testing.T) {
@@ -4889,9 +4890,10 @@ func TestCheckInvalidationInPostConditionBefore(t *testing.T) {
       }
     `)
 
-	errs := ExpectCheckerErrors(t, err, 1)
+	errs := ExpectCheckerErrors(t, err, 2)
 
 	assert.IsType(t, &sema.ResourceUseAfterInvalidationError{}, errs[0])
+	assert.IsType(t, &sema.ImpureOperationError{}, errs[1])
 }
 
 func TestCheckInvalidationInPostCondition(t *testing.T) {
@@ -4914,9 +4916,10 @@ func TestCheckInvalidationInPostCondition(t *testing.T) {
       }
     `)
 
-	errs := ExpectCheckerErrors(t, err, 1)
+	errs := ExpectCheckerErrors(t, err, 2)
 
 	assert.IsType(t, &sema.ResourceUseAfterInvalidationError{}, errs[0])
+	assert.IsType(t, &sema.ImpureOperationError{}, errs[1])
 }
 
 func TestCheckFunctionDefinitelyHaltedNoResourceLoss(t *testing.T) {
@@ -5049,3 +5052,96 @@ func TestCheckEmptyResourceCollectionMove(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestCheckInvalidResourceArrayFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(name string) {
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := ParseAndCheck(t, fmt.Sprintf(
+				`
+                  resource X {}
+
+                  fun test() {
+                      let xs: @[X] <- [<-create X()]
+                      let f = xs.%s
+                      destroy xs
+                  }
+                `,
+				name,
+			))
+
+			errs := ExpectCheckerErrors(t, err, 2)
+
+			assert.IsType(t, &sema.InvalidResourceArrayMemberError{}, errs[0])
+			assert.IsType(t, &sema.ResourceMethodBindingError{}, errs[1])
+		})
+	}
+
+	for _, name := range []string{"map", "filter", "firstIndex", "reverse", "slice", "sort"} {
+
+		test(name)
+	}
+}
+
+func TestCheckInvalidResourceDictionaryFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(name string) {
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			_, err := ParseAndCheck(t, fmt.Sprintf(
+				`
+                  resource X {}
+
+                  fun test() {
+                      let xs: @{String: X} <- {"a": <-create X()}
+                      let f = xs.%s
+                      destroy xs
+                  }
+                `,
+				name,
+			))
+
+			errs := ExpectCheckerErrors(t, err, 2)
+
+			assert.IsType(t, &sema.InvalidResourceDictionaryMemberError{}, errs[0])
+			assert.IsType(t, &sema.ResourceMethodBindingError{}, errs[1])
+		})
+	}
+
+	for _, name := range []string{"filter", "mapValues"} {
+
+		test(name)
+	}
+}
+
+func TestCheckResourceDictionaryForEachKey(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      resource X {}
+
+      fun isValid(key: String): Bool {
+          return key.length > 0
+      }
+
+      fun test() {
+          let xs: @{String: X} <- {"a": <-create X()}
+          xs.forEachKey(isValid)
+          destroy xs
+      }
+    `)
+
+	require.NoError(t, err)
+}
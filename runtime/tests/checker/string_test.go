@@ -324,3 +324,170 @@ func TestCheckStringToLower(t *testing.T) {
 		RequireGlobalValue(t, checker.Elaboration, "x"),
 	)
 }
+
+func TestCheckStringFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+        let upper = "abc".toUpper()
+        let parts = "a,b,c".split(separator: ",")
+        let joined = String.join(["a", "b"], separator: ",")
+        let contains = "abc".contains("b")
+        let index = "abc".index(of: "c")
+        let replaced = "abc".replaceAll(of: "b", with: "x")
+        let trimmed = " abc ".trim()
+        let hasPrefix = "abc".hasPrefix("a")
+        let hasSuffix = "abc".hasSuffix("c")
+        let characters = "abc".characters()
+        let bytes = "abc".toUTF8()
+        let decoded = String.fromUTF8([97, 98, 99])
+	`)
+
+	require.NoError(t, err)
+
+	for name, expectedType := range map[string]sema.Type{
+		"upper":      sema.StringType,
+		"parts":      sema.StringArrayType,
+		"joined":     sema.StringType,
+		"contains":   sema.BoolType,
+		"index":      &sema.OptionalType{Type: sema.IntType},
+		"replaced":   sema.StringType,
+		"trimmed":    sema.StringType,
+		"hasPrefix":  sema.BoolType,
+		"hasSuffix":  sema.BoolType,
+		"characters": sema.CharacterArrayType,
+		"bytes":      sema.ByteArrayType,
+		"decoded":    &sema.OptionalType{Type: sema.StringType},
+	} {
+		assert.Equal(t,
+			expectedType,
+			RequireGlobalValue(t, checker.Elaboration, name),
+			name,
+		)
+	}
+}
+
+func TestCheckInvalidStringFunctionArguments(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+        let parts = "a,b,c".split(",")
+        let index = "abc".index("c")
+        let joined = String.join([1], separator: ",")
+	`)
+
+	errs := ExpectCheckerErrors(t, err, 3)
+
+	assert.IsType(t, &sema.MissingArgumentLabelError{}, errs[0])
+	assert.IsType(t, &sema.MissingArgumentLabelError{}, errs[1])
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[2])
+}
+
+func TestCheckCharacterFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+        let c: Character = "a"
+        let string = c.toString()
+        let bytes = c.utf8
+        let lower = c.toLower()
+        let upper = c.toUpper()
+        let isWhitespace = c.isWhitespace
+        let isLetter = c.isLetter
+        let isDigit = c.isDigit
+	`)
+
+	require.NoError(t, err)
+
+	for name, expectedType := range map[string]sema.Type{
+		"string":       sema.StringType,
+		"bytes":        sema.ByteArrayType,
+		"lower":        sema.CharacterType,
+		"upper":        sema.CharacterType,
+		"isWhitespace": sema.BoolType,
+		"isLetter":     sema.BoolType,
+		"isDigit":      sema.BoolType,
+	} {
+		assert.Equal(t,
+			expectedType,
+			RequireGlobalValue(t, checker.Elaboration, name),
+			name,
+		)
+	}
+}
+
+func TestCheckStringTemplate(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+        let balance: UFix64 = 1.5
+        let char: Character = "x"
+        let address: Address = 0x1
+        let string = "balance: \(balance), \(char), \(true), \(address), \(/storage/foo), \("a".concat("b"))"
+	`)
+
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		sema.StringType,
+		RequireGlobalValue(t, checker.Elaboration, "string"),
+	)
+}
+
+func TestCheckInvalidStringTemplate(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("invalid type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+            struct S {}
+
+            let string = "\(S()) \([1]) \(nil)"
+	    `)
+
+		errs := ExpectCheckerErrors(t, err, 3)
+
+		assert.IsType(t, &sema.InvalidStringInterpolationTypeError{}, errs[0])
+		assert.IsType(t, &sema.InvalidStringInterpolationTypeError{}, errs[1])
+		assert.IsType(t, &sema.InvalidStringInterpolationTypeError{}, errs[2])
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+            resource R {}
+
+            fun test() {
+                let r <- create R()
+                let string = "\(r)"
+                destroy r
+            }
+	    `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidStringInterpolationTypeError{}, errs[0])
+	})
+
+	t.Run("undeclared", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+            let string = "\(x)"
+	    `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+}
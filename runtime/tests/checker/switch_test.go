@@ -356,3 +356,137 @@ func TestCheckInvalidSwitchStatementMissingStatements(t *testing.T) {
 
 	assert.IsType(t, &sema.MissingSwitchCaseStatementsError{}, errs[0])
 }
+
+func TestCheckSwitchStatementEnumExhaustiveness(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("all cases", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          enum Color: UInt8 {
+              case red
+              case green
+              case blue
+          }
+
+          fun test(_ color: Color) {
+              switch color {
+              case Color.red:
+                  return
+              case Color.green:
+                  return
+              case Color.blue:
+                  return
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+		require.Empty(t, checker.Hints())
+	})
+
+	t.Run("default case", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          enum Color: UInt8 {
+              case red
+              case green
+              case blue
+          }
+
+          fun test(_ color: Color) {
+              switch color {
+              case Color.red:
+                  return
+              default:
+                  return
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+		require.Empty(t, checker.Hints())
+	})
+
+	t.Run("missing cases", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          enum Color: UInt8 {
+              case red
+              case green
+              case blue
+          }
+
+          fun test(_ color: Color) {
+              switch color {
+              case Color.green:
+                  return
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+
+		hints := checker.Hints()
+		require.Len(t, hints, 1)
+
+		require.IsType(t, &sema.MissingSwitchCasesHint{}, hints[0])
+		missingCasesHint := hints[0].(*sema.MissingSwitchCasesHint)
+
+		assert.Equal(t, []string{"red", "blue"}, missingCasesHint.MissingCases)
+		assert.Equal(t,
+			"switch over `Color` is not exhaustive: missing cases `red`, `blue`",
+			missingCasesHint.Hint(),
+		)
+	})
+
+	t.Run("no cases", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          enum Color: UInt8 {
+              case red
+          }
+
+          fun test(_ color: Color) {
+              switch color {
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+
+		hints := checker.Hints()
+		require.Len(t, hints, 1)
+
+		require.IsType(t, &sema.MissingSwitchCasesHint{}, hints[0])
+		missingCasesHint := hints[0].(*sema.MissingSwitchCasesHint)
+
+		assert.Equal(t, []string{"red"}, missingCasesHint.MissingCases)
+	})
+
+	t.Run("non-enum", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun test(_ x: Int) {
+              switch x {
+              case 1:
+                  return
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+		require.Empty(t, checker.Hints())
+	})
+}
@@ -0,0 +1,423 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestCheckTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("top-level", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          typealias Quantity = Int
+
+          let x: Quantity = 1
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+
+		assert.Equal(t,
+			sema.IntType,
+			RequireGlobalType(t, checker.Elaboration, "Quantity"),
+		)
+	})
+
+	t.Run("restricted reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource interface Receiver {}
+
+          resource interface Balance {}
+
+          resource Vault: Receiver, Balance {}
+
+          typealias VaultReference = &{Receiver, Balance}
+
+          fun test(ref: &Vault): VaultReference {
+              return ref
+          }
+
+          let f: ((&Vault): &{Receiver, Balance}) = test
+        `)
+		require.NoError(t, err)
+
+		vaultReferenceType := RequireGlobalType(t, checker.Elaboration, "VaultReference")
+		require.IsType(t, &sema.ReferenceType{}, vaultReferenceType)
+	})
+
+	t.Run("alias of alias", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias Quantity = Int
+          typealias Quantities = [Quantity]
+
+          let xs: Quantities = [1, 2]
+          let ys: [Int] = xs
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("undeclared target", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias X = Y
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("redeclaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          typealias S = Int
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.RedeclarationError{}, errs[0])
+	})
+
+	t.Run("not a value", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          typealias T = S
+
+          let s = T()
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("resource, annotation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          typealias T = @R
+
+          fun test(r: @T): @R {
+              return <-r
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("resource, missing annotation in declaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          typealias T = R
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingResourceAnnotationError{}, errs[0])
+	})
+
+	t.Run("resource, missing annotation in use", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {}
+
+          typealias T = @R
+
+          fun test(r: T) {
+              destroy r
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.MissingResourceAnnotationError{}, errs[0])
+	})
+
+	t.Run("invalid access modifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          priv typealias T = Int
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidAccessModifierError{}, errs[0])
+	})
+
+	t.Run("cyclic", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias A = B
+          typealias B = [A]
+
+          let a: A = []
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		require.IsType(t, &sema.CyclicTypeAliasError{}, errs[0])
+		assert.Equal(t, "A", errs[0].(*sema.CyclicTypeAliasError).Name)
+
+		require.IsType(t, &sema.CyclicTypeAliasError{}, errs[1])
+		assert.Equal(t, "B", errs[1].(*sema.CyclicTypeAliasError).Name)
+	})
+
+	t.Run("cyclic, self", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias A = {String: A?}
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.CyclicTypeAliasError{}, errs[0])
+	})
+
+	t.Run("cyclic, referenced", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          typealias A = B
+          typealias B = A
+          typealias C = A
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.CyclicTypeAliasError{}, errs[0])
+		assert.IsType(t, &sema.CyclicTypeAliasError{}, errs[1])
+	})
+}
+
+func TestCheckNestedTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          contract C {
+
+              resource interface Receiver {}
+
+              resource Vault: Receiver {}
+
+              typealias ReceiverReference = &{Receiver}
+
+              typealias Vaults = @[Vault]
+
+              struct S {
+                  let receiver: ReceiverReference?
+
+                  init() {
+                      self.receiver = nil
+                  }
+              }
+
+              pub fun createVaults(): @Vaults {
+                  return <-[<-create Vault()]
+              }
+
+              pub fun borrow(vault: &Vault): ReceiverReference {
+                  return vault
+              }
+          }
+
+          let vaults: @[C.Vault] <- C.createVaults()
+          let ref: C.ReceiverReference = &vaults[0] as &C.Vault
+        `)
+		require.NoError(t, err)
+
+		refType := RequireGlobalValue(t, checker.Elaboration, "ref")
+		require.IsType(t, &sema.ReferenceType{}, refType)
+
+		assert.Equal(t,
+			"&AnyResource{C.Receiver}",
+			refType.QualifiedString(),
+		)
+	})
+
+	t.Run("contract interface", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract interface CI {
+
+              resource R {}
+
+              typealias Rs = @[R]
+
+              pub fun createRs(): @Rs
+          }
+
+          fun test(rs: @CI.Rs): @[CI.R] {
+              return <-rs
+          }
+        `)
+		require.NoError(t, err)
+	})
+
+	t.Run("cyclic", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+
+              typealias A = B?
+
+              typealias B = A
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.CyclicTypeAliasError{}, errs[0])
+		assert.IsType(t, &sema.CyclicTypeAliasError{}, errs[1])
+	})
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              typealias T = Int
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNestedDeclarationError{}, errs[0])
+	})
+
+	t.Run("duplicate member", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          contract C {
+              struct S {}
+
+              typealias S = Int
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.RedeclarationError{}, errs[0])
+		assert.IsType(t, &sema.RedeclarationError{}, errs[1])
+	})
+}
+
+func TestCheckImportedTypeAlias(t *testing.T) {
+
+	t.Parallel()
+
+	importedChecker, err := ParseAndCheckWithOptions(t,
+		`
+          pub resource interface Receiver {}
+
+          pub typealias ReceiverReference = &{Receiver}
+
+          pub contract C {
+              pub struct S {}
+
+              pub typealias Ss = [S]
+          }
+        `,
+		ParseAndCheckOptions{
+			Location: utils.ImportedLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = ParseAndCheckWithOptions(t,
+		`
+          import ReceiverReference, C from "imported"
+
+          fun test(ref: ReceiverReference, ss: C.Ss): [C.S] {
+              return ss
+          }
+        `,
+		ParseAndCheckOptions{
+			Options: []sema.Option{
+				sema.WithImportHandler(
+					func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+						return sema.ElaborationImport{
+							Elaboration: importedChecker.Elaboration,
+						}, nil
+					},
+				),
+			},
+		},
+	)
+	require.NoError(t, err)
+}
@@ -771,3 +771,327 @@ func TestCheckInferenceWithCheckerErrors(t *testing.T) {
 		}
 	})
 }
+
+func TestCheckCommonSuperTypeInference(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("optional array elements", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let x = [1, nil]
+          let y = [nil, 1]
+        `)
+		require.NoError(t, err)
+
+		expectedType := &sema.VariableSizedType{
+			Type: &sema.OptionalType{
+				Type: sema.IntType,
+			},
+		}
+
+		assert.Equal(t, expectedType, RequireGlobalValue(t, checker.Elaboration, "x"))
+		assert.Equal(t, expectedType, RequireGlobalValue(t, checker.Elaboration, "y"))
+	})
+
+	t.Run("numeric dictionary values", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let x = {"a": 1, "b": 2.0}
+          let y = {"a": 1, "b": -2.0}
+          let z = {"a": 1 as UInt8, "b": 2 as Int}
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.DictionaryType{
+				KeyType:   sema.StringType,
+				ValueType: sema.NumberType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+
+		assert.Equal(t,
+			&sema.DictionaryType{
+				KeyType:   sema.StringType,
+				ValueType: sema.SignedNumberType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "y"),
+		)
+
+		assert.Equal(t,
+			&sema.DictionaryType{
+				KeyType:   sema.StringType,
+				ValueType: sema.IntegerType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "z"),
+		)
+	})
+
+	t.Run("element hint", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let x = [1 as UInt8, 2]
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: sema.UInt8Type,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("nested arrays", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let x = [[1], [nil]]
+          let y = [[1], ["a"]]
+          let z = [[1 as UInt8], [2]]
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: &sema.VariableSizedType{
+					Type: &sema.OptionalType{
+						Type: sema.IntType,
+					},
+				},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: &sema.VariableSizedType{
+					Type: sema.AnyStructType,
+				},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "y"),
+		)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: &sema.VariableSizedType{
+					Type: sema.IntegerType,
+				},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "z"),
+		)
+	})
+
+	t.Run("nested dictionaries", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let x = {"a": [1], "b": [nil]}
+          let y = {"a": {1: "one"}, "b": {2: nil}}
+          let z = [{"a": 1}, {"b": nil}]
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.DictionaryType{
+				KeyType: sema.StringType,
+				ValueType: &sema.VariableSizedType{
+					Type: &sema.OptionalType{
+						Type: sema.IntType,
+					},
+				},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+
+		assert.Equal(t,
+			&sema.DictionaryType{
+				KeyType: sema.StringType,
+				ValueType: &sema.DictionaryType{
+					KeyType: sema.IntType,
+					ValueType: &sema.OptionalType{
+						Type: sema.StringType,
+					},
+				},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "y"),
+		)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: &sema.DictionaryType{
+					KeyType: sema.StringType,
+					ValueType: &sema.OptionalType{
+						Type: sema.IntType,
+					},
+				},
+			},
+			RequireGlobalValue(t, checker.Elaboration, "z"),
+		)
+	})
+
+	t.Run("conditional", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let x = true ? 1 : nil
+          let y = true ? 1 : 2.0
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: sema.IntType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+
+		assert.Equal(t,
+			sema.NumberType,
+			RequireGlobalValue(t, checker.Elaboration, "y"),
+		)
+	})
+
+	t.Run("nil-coalescing", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let a: Int? = 1
+          let x = a ?? nil
+          let y = a ?? 2.0
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{
+				Type: sema.IntType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+
+		assert.Equal(t,
+			sema.NumberType,
+			RequireGlobalValue(t, checker.Elaboration, "y"),
+		)
+	})
+
+	t.Run("composites with shared interfaces", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct interface I {}
+          struct interface J {}
+
+          struct S1: I, J {}
+          struct S2: J, I {}
+          struct S3: I {}
+          struct S4 {}
+
+          let x = [S1(), S2()]
+          let y = [S1(), S2(), S3()]
+          let z = [S1(), S4()]
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			"[AnyStruct{I, J}]",
+			RequireGlobalValue(t, checker.Elaboration, "x").QualifiedString(),
+		)
+
+		assert.Equal(t,
+			"[AnyStruct{I}]",
+			RequireGlobalValue(t, checker.Elaboration, "y").QualifiedString(),
+		)
+
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: sema.AnyStructType,
+			},
+			RequireGlobalValue(t, checker.Elaboration, "z"),
+		)
+	})
+
+	t.Run("restricted types", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct interface I {}
+          struct interface J {}
+
+          struct S1: I, J {}
+          struct S2: I {}
+
+          let s1: AnyStruct{I, J} = S1()
+
+          let x = true ? s1 : S2()
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			"AnyStruct{I}",
+			RequireGlobalValue(t, checker.Elaboration, "x").QualifiedString(),
+		)
+	})
+
+	t.Run("resources with shared interfaces", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource interface I {}
+
+          resource R1: I {}
+          resource R2: I {}
+
+          let rs <- [<-create R1(), <-create R2()]
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			"[AnyResource{I}]",
+			RequireGlobalValue(t, checker.Elaboration, "rs").QualifiedString(),
+		)
+	})
+
+	t.Run("references", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          struct interface I {}
+
+          struct S1: I {}
+          struct S2: I {}
+
+          let s1 = S1()
+          let s2 = S2()
+
+          let x = [&s1 as &S1, &s2 as &S2]
+          let y = [&s1 as auth &S1, &s2 as &S2]
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			"[&AnyStruct{I}]",
+			RequireGlobalValue(t, checker.Elaboration, "x").QualifiedString(),
+		)
+
+		assert.Equal(t,
+			"[&AnyStruct{I}]",
+			RequireGlobalValue(t, checker.Elaboration, "y").QualifiedString(),
+		)
+	})
+}
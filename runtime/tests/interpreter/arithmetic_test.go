@@ -21,12 +21,14 @@ package interpreter_test
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/fixedpoint"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
 )
@@ -537,6 +539,51 @@ func TestInterpretSaturatedArithmeticFunctions(t *testing.T) {
 				},
 			},
 		},
+		sema.Fix128Type: {
+			add: testCalls{
+				overflow: testCall{
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMaxBig),
+					interpreter.NewFix128ValueWithInteger(big.NewInt(2)),
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMaxBig),
+				},
+				underflow: testCall{
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMinBig),
+					interpreter.NewFix128ValueWithInteger(big.NewInt(-2)),
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMinBig),
+				},
+			},
+			subtract: testCalls{
+				overflow: testCall{
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMaxBig),
+					interpreter.NewFix128ValueWithInteger(big.NewInt(-2)),
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMaxBig),
+				},
+				underflow: testCall{
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMinBig),
+					interpreter.NewFix128ValueWithInteger(big.NewInt(2)),
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMinBig),
+				},
+			},
+			multiply: testCalls{
+				overflow: testCall{
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMaxBig),
+					interpreter.NewFix128ValueWithInteger(big.NewInt(2)),
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMaxBig),
+				},
+				underflow: testCall{
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMinBig),
+					interpreter.NewFix128ValueWithInteger(big.NewInt(2)),
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMinBig),
+				},
+			},
+			divide: testCalls{
+				overflow: testCall{
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMinBig),
+					interpreter.NewFix128ValueWithInteger(big.NewInt(-1)),
+					interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMaxBig),
+				},
+			},
+		},
 		sema.UIntType: {
 			subtract: testCalls{
 				underflow: testCall{
@@ -707,6 +754,29 @@ func TestInterpretSaturatedArithmeticFunctions(t *testing.T) {
 				},
 			},
 		},
+		sema.UFix128Type: {
+			add: testCalls{
+				overflow: testCall{
+					interpreter.NewUFix128ValueFromBigInt(fixedpoint.UFix128TypeMaxBig),
+					interpreter.NewUFix128ValueWithInteger(big.NewInt(2)),
+					interpreter.NewUFix128ValueFromBigInt(fixedpoint.UFix128TypeMaxBig),
+				},
+			},
+			subtract: testCalls{
+				underflow: testCall{
+					interpreter.NewUFix128ValueFromBigInt(fixedpoint.UFix128TypeMinBig),
+					interpreter.NewUFix128ValueWithInteger(big.NewInt(2)),
+					interpreter.NewUFix128ValueFromBigInt(fixedpoint.UFix128TypeMinBig),
+				},
+			},
+			multiply: testCalls{
+				overflow: testCall{
+					interpreter.NewUFix128ValueFromBigInt(fixedpoint.UFix128TypeMaxBig),
+					interpreter.NewUFix128ValueWithInteger(big.NewInt(2)),
+					interpreter.NewUFix128ValueFromBigInt(fixedpoint.UFix128TypeMaxBig),
+				},
+			},
+		},
 	}
 
 	// Verify all test cases exist
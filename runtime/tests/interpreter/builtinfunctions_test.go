@@ -20,6 +20,7 @@ package interpreter_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -73,12 +74,20 @@ func TestInterpretToString(t *testing.T) {
 
 			isSigned := sema.IsSubType(ty, sema.SignedFixedPointType)
 
+			// NOTE: literals of abstract fixed-point types are Fix64 and UFix64 values
+
+			var scale uint = sema.Fix64Scale
+			if fractionalRangedType, ok := ty.(sema.FractionalRangedType); ok {
+				scale = fractionalRangedType.Scale()
+			}
+			fractional := "34" + strings.Repeat("0", int(scale)-2)
+
 			if isSigned {
 				literal = "-12.34"
-				expected = interpreter.NewStringValue("-12.34000000")
+				expected = interpreter.NewStringValue("-12." + fractional)
 			} else {
 				literal = "12.34"
-				expected = interpreter.NewStringValue("12.34000000")
+				expected = interpreter.NewStringValue("12." + fractional)
 			}
 
 			inter := parseCheckAndInterpret(t,
@@ -280,12 +289,23 @@ func TestInterpretToBigEndianBytes(t *testing.T) {
 			"42.24": {0, 0, 0, 0, 251, 197, 32, 0},
 			"-1.0":  {255, 255, 255, 255, 250, 10, 31, 0},
 		},
+		"Fix128": {
+			"0.0":   {0},
+			"42.0":  {34, 189, 216, 143, 237, 158, 252, 106, 0, 0, 0},
+			"42.24": {34, 240, 170, 253, 0, 136, 125, 32, 0, 0, 0},
+			"-1.0":  {255, 44, 61, 228, 49, 51, 18, 95, 0, 0, 0},
+		},
 		// UFix*
 		"UFix64": {
 			"0.0":   {0, 0, 0, 0, 0, 0, 0, 0},
 			"42.0":  {0, 0, 0, 0, 250, 86, 234, 0},
 			"42.24": {0, 0, 0, 0, 251, 197, 32, 0},
 		},
+		"UFix128": {
+			"0.0":   {0},
+			"42.0":  {34, 189, 216, 143, 237, 158, 252, 106, 0, 0, 0},
+			"42.24": {34, 240, 170, 253, 0, 136, 125, 32, 0, 0, 0},
+		},
 	}
 
 	// Ensure the test cases are complete
@@ -1052,6 +1052,7 @@ func TestInterpretFunctionWithPostConditionAndResourceResult(t *testing.T) {
 		{
 			Name: "check",
 			Type: &sema.FunctionType{
+				Purity: ast.FunctionPurityView,
 				Parameters: []*sema.Parameter{
 					{
 						Label:      sema.ArgumentLabelNotRequired,
@@ -0,0 +1,184 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestInterpretExtension(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {
+              pub let n: Int
+
+              init(n: Int) {
+                  self.n = n
+              }
+          }
+
+          extension Doubling for S {
+              pub fun double(): Int {
+                  return self.n * 2
+              }
+
+              pub fun quadruple(): Int {
+                  return self.double() * 2
+              }
+          }
+
+          let result = S(n: 21).quadruple()
+        `)
+
+		assert.Equal(t,
+			interpreter.NewIntValueFromInt64(84),
+			inter.Globals["result"].GetValue(),
+		)
+	})
+
+	t.Run("resource reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {
+              pub let id: UInt64
+
+              init(id: UInt64) {
+                  self.id = id
+              }
+          }
+
+          extension Naming for R {
+              pub fun name(): String {
+                  return "R #".concat(self.id.toString())
+              }
+          }
+
+          fun test(): String {
+              let r <- create R(id: 1)
+              let name = (&r as &R).name()
+              destroy r
+              return name
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			interpreter.NewStringValue("R #1"),
+			value,
+		)
+	})
+
+	t.Run("optional chaining", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct S {}
+
+          extension E for S {
+              pub fun one(): Int {
+                  return 1
+              }
+          }
+
+          let s: S? = S()
+          let none: S? = nil
+
+          let x = s?.one()
+          let y = none?.one()
+        `)
+
+		assert.Equal(t,
+			interpreter.NewSomeValueOwningNonCopying(
+				interpreter.NewIntValueFromInt64(1),
+			),
+			inter.Globals["x"].GetValue(),
+		)
+
+		assert.Equal(t,
+			interpreter.NilValue{},
+			inter.Globals["y"].GetValue(),
+		)
+	})
+
+	t.Run("nested in contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              contract C {
+
+                  pub struct S {
+                      access(contract) let n: Int
+
+                      init() {
+                          self.n = 1
+                      }
+                  }
+
+                  pub let offset: Int
+
+                  pub extension E for S {
+                      pub fun get(): Int {
+                          return self.n + C.offset
+                      }
+                  }
+
+                  init() {
+                      self.offset = 41
+                  }
+              }
+
+              fun test(): Int {
+                  return C.S().get()
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Options: []interpreter.Option{
+					makeContractValueHandler(nil, nil, nil),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			interpreter.NewIntValueFromInt64(42),
+			value,
+		)
+	})
+}
@@ -27,8 +27,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/fixedpoint"
 	"github.com/onflow/cadence/runtime/interpreter"
 	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
 )
 
 func TestInterpretNegativeZeroFixedPoint(t *testing.T) {
@@ -51,9 +53,11 @@ func TestInterpretFixedPointConversionAndAddition(t *testing.T) {
 
 	tests := map[string]interpreter.Value{
 		// Fix*
-		"Fix64": interpreter.Fix64Value(123000000),
+		"Fix64":  interpreter.Fix64Value(123000000),
+		"Fix128": interpreter.NewFix128ValueFromBigInt(mustParseFix128("1.23")),
 		// UFix*
-		"UFix64": interpreter.UFix64Value(123000000),
+		"UFix64":  interpreter.UFix64Value(123000000),
+		"UFix128": interpreter.NewUFix128ValueFromBigInt(mustParseFix128("1.23")),
 	}
 
 	for _, fixedPointType := range sema.AllFixedPointTypes {
@@ -103,8 +107,18 @@ func TestInterpretFixedPointConversionAndAddition(t *testing.T) {
 }
 
 var testFixedPointValues = map[string]interpreter.Value{
-	"Fix64":  interpreter.Fix64Value(50 * sema.Fix64Factor),
-	"UFix64": interpreter.UFix64Value(50 * sema.Fix64Factor),
+	"Fix64":   interpreter.Fix64Value(50 * sema.Fix64Factor),
+	"UFix64":  interpreter.UFix64Value(50 * sema.Fix64Factor),
+	"Fix128":  interpreter.NewFix128ValueWithInteger(big.NewInt(50)),
+	"UFix128": interpreter.NewUFix128ValueWithInteger(big.NewInt(50)),
+}
+
+func mustParseFix128(s string) *big.Int {
+	value, err := fixedpoint.ParseFix128(s)
+	if err != nil {
+		panic(err)
+	}
+	return value
 }
 
 func init() {
@@ -538,6 +552,14 @@ func TestInterpretFixedPointMinMax(t *testing.T) {
 			min: interpreter.UFix64Value(0),
 			max: interpreter.UFix64Value(math.MaxUint64),
 		},
+		sema.Fix128Type: {
+			min: interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMinBig),
+			max: interpreter.NewFix128ValueFromBigInt(fixedpoint.Fix128TypeMaxBig),
+		},
+		sema.UFix128Type: {
+			min: interpreter.NewUFix128ValueFromBigInt(fixedpoint.UFix128TypeMinBig),
+			max: interpreter.NewUFix128ValueFromBigInt(fixedpoint.UFix128TypeMaxBig),
+		},
 	}
 
 	for _, ty := range sema.AllFixedPointTypes {
@@ -559,3 +581,168 @@ func TestInterpretFixedPointMinMax(t *testing.T) {
 		})
 	}
 }
+
+func TestInterpretFixedPointRoundingArithmetic(t *testing.T) {
+
+	t.Parallel()
+
+	valueDeclarations := stdlib.BuiltinValues()
+	semaValueDeclarations := valueDeclarations.ToSemaValueDeclarations()
+	interpreterValueDeclarations := valueDeclarations.ToInterpreterValueDeclarations()
+
+	test := func(t *testing.T, code string, expected interpreter.Value) {
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			code,
+			ParseCheckAndInterpretOptions{
+				CheckerOptions: []sema.Option{
+					sema.WithPredeclaredValues(semaValueDeclarations),
+				},
+				Options: []interpreter.Option{
+					interpreter.WithPredeclaredValues(interpreterValueDeclarations),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			expected,
+			inter.Globals["result"].GetValue(),
+		)
+	}
+
+	t.Run("Fix64 divide, toward zero", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let x: Fix64 = -2.0
+              let result = x.divideWithRounding(3.0, rounding: RoundingRule.towardZero)
+            `,
+			interpreter.Fix64Value(-66666666),
+		)
+	})
+
+	t.Run("Fix64 divide, nearest half away", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let x: Fix64 = -2.0
+              let result = x.divideWithRounding(3.0, rounding: RoundingRule.nearestHalfAway)
+            `,
+			interpreter.Fix64Value(-66666667),
+		)
+	})
+
+	t.Run("UFix64 multiply, away from zero", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let x: UFix64 = 0.00000001
+              let result = x.multiplyWithRounding(0.5, rounding: RoundingRule.awayFromZero)
+            `,
+			interpreter.UFix64Value(1),
+		)
+	})
+
+	t.Run("UFix64 multiply, nearest half even", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let x: UFix64 = 0.00000001
+              let result = x.multiplyWithRounding(0.5, rounding: RoundingRule.nearestHalfEven)
+            `,
+			interpreter.UFix64Value(0),
+		)
+	})
+
+	t.Run("Fix128 divide, nearest half even", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let x: Fix128 = 2.0
+              let result = x.divideWithRounding(3.0, rounding: RoundingRule.nearestHalfEven)
+            `,
+			interpreter.NewFix128ValueFromBigInt(
+				mustParseFix128("0.666666666666666666666667"),
+			),
+		)
+	})
+
+	t.Run("UFix128 multiply, toward zero", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              let x: UFix128 = 0.000000000000000000000001
+              let result = x.multiplyWithRounding(0.9, rounding: RoundingRule.towardZero)
+            `,
+			interpreter.NewUFix128ValueFromBigInt(big.NewInt(0)),
+		)
+	})
+
+	t.Run("division by zero", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              fun test(): Fix64 {
+                  let x: Fix64 = 1.0
+                  return x.divideWithRounding(0.0, rounding: RoundingRule.towardZero)
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				CheckerOptions: []sema.Option{
+					sema.WithPredeclaredValues(semaValueDeclarations),
+				},
+				Options: []interpreter.Option{
+					interpreter.WithPredeclaredValues(interpreterValueDeclarations),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.DivisionByZeroError{})
+	})
+}
+
+func TestInterpretFix128Fix64Conversion(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let x: Fix128 = -1.123456789123456789
+      let y = Fix64(x)
+      let z = Fix128(y)
+      let u = UFix64(UFix128(2.5))
+    `)
+
+	assert.Equal(t,
+		interpreter.Fix64Value(-112345678),
+		inter.Globals["y"].GetValue(),
+	)
+
+	assert.Equal(t,
+		interpreter.NewFix128ValueFromBigInt(
+			mustParseFix128("-1.12345678"),
+		),
+		inter.Globals["z"].GetValue(),
+	)
+
+	assert.Equal(t,
+		interpreter.UFix64Value(250000000),
+		inter.Globals["u"].GetValue(),
+	)
+}
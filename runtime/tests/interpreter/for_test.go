@@ -154,3 +154,115 @@ func TestInterpretForStatementEmpty(t *testing.T) {
 		value,
 	)
 }
+
+func TestInterpretForStatementWithIndex(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+       fun test(): [Int] {
+           var xs: [Int] = []
+           for i, x in [10, 20, 30] {
+               xs.append(i + x)
+           }
+           return xs
+       }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.IsType(t, value, &interpreter.ArrayValue{})
+	arrayValue := value.(*interpreter.ArrayValue)
+
+	assert.Equal(t,
+		[]interpreter.Value{
+			interpreter.NewIntValueFromInt64(10),
+			interpreter.NewIntValueFromInt64(21),
+			interpreter.NewIntValueFromInt64(32),
+		},
+		arrayValue.Elements(),
+	)
+}
+
+func TestInterpretForStatementDictionary(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+       let dict = {"a": 1, "b": 2, "c": 3}
+
+       fun keys(): [String] {
+           var keys: [String] = []
+           for key in dict {
+               keys.append(key)
+           }
+           return keys
+       }
+
+       fun sum(): Int {
+           var sum = 0
+           for key, value in dict {
+               if key == "c" {
+                   break
+               }
+               sum = sum + value
+           }
+           return sum
+       }
+    `)
+
+	value, err := inter.Invoke("keys")
+	require.NoError(t, err)
+
+	require.IsType(t, value, &interpreter.ArrayValue{})
+	arrayValue := value.(*interpreter.ArrayValue)
+
+	assert.Equal(t,
+		[]interpreter.Value{
+			interpreter.NewStringValue("a"),
+			interpreter.NewStringValue("b"),
+			interpreter.NewStringValue("c"),
+		},
+		arrayValue.Elements(),
+	)
+
+	value, err = inter.Invoke("sum")
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		interpreter.NewIntValueFromInt64(3),
+		value,
+	)
+}
+
+func TestInterpretForStatementMetering(t *testing.T) {
+
+	t.Parallel()
+
+	var iterations int
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		`
+          fun test() {
+              for i, x in 0..<4 {}
+              for key, value in {"a": 1, "b": 2} {}
+          }
+        `,
+		ParseCheckAndInterpretOptions{
+			Options: []interpreter.Option{
+				interpreter.WithOnLoopIterationHandler(
+					func(_ *interpreter.Interpreter, _ int) {
+						iterations++
+					},
+				),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, iterations)
+}
@@ -0,0 +1,344 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestInterpretGenericFunctionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("inferred type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun first<T>(_ values: [T]): T? {
+              if values.length == 0 {
+                  return nil
+              }
+              return values[0]
+          }
+
+          let x = first([1, 2, 3])
+          let y = first<String>([])
+        `)
+
+		assert.Equal(t,
+			interpreter.NewSomeValueOwningNonCopying(
+				interpreter.NewIntValueFromInt64(1),
+			),
+			inter.Globals["x"].GetValue(),
+		)
+
+		assert.Equal(t,
+			interpreter.NilValue{},
+			inter.Globals["y"].GetValue(),
+		)
+	})
+
+	t.Run("explicit type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun identity<T>(_ value: T): T {
+              return value
+          }
+
+          let x = identity<UInt8>(1)
+        `)
+
+		assert.Equal(t,
+			interpreter.UInt8Value(1),
+			inter.Globals["x"].GetValue(),
+		)
+	})
+
+	t.Run("type argument in body", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun pair<T>(_ a: T, _ b: T): [T] {
+              let values: [T] = [a, b]
+              return values
+          }
+
+          let values = pair<Int8>(1, 2)
+        `)
+
+		assert.Equal(t,
+			interpreter.NewArrayValueUnownedNonCopying(
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeInt8,
+				},
+				interpreter.Int8Value(1),
+				interpreter.Int8Value(2),
+			),
+			inter.Globals["values"].GetValue(),
+		)
+	})
+
+	t.Run("cast to type parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun cast<T>(_ value: AnyStruct): T? {
+              return value as? T
+          }
+
+          let x = cast<Int>(1)
+          let y = cast<String>(1)
+        `)
+
+		assert.Equal(t,
+			interpreter.NewSomeValueOwningNonCopying(
+				interpreter.NewIntValueFromInt64(1),
+			),
+			inter.Globals["x"].GetValue(),
+		)
+
+		assert.Equal(t,
+			interpreter.NilValue{},
+			inter.Globals["y"].GetValue(),
+		)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource R {
+              let id: Int
+
+              init(id: Int) {
+                  self.id = id
+              }
+          }
+
+          fun identity<T: AnyResource>(_ value: @T): @T {
+              return <-value
+          }
+
+          fun test(): Int {
+              let r <- identity(<-create R(id: 42))
+              let id = r.id
+              destroy r
+              return id
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			interpreter.NewIntValueFromInt64(42),
+			value,
+		)
+	})
+}
+
+func TestInterpretGenericCompositeDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+
+              pub fun get(): T {
+                  return self.value
+              }
+
+              pub fun values(): [T] {
+                  return [self.value]
+              }
+          }
+
+          let box = Box<UInt8>(value: 1)
+          let value = box.get()
+          let values = box.values()
+        `)
+
+		box := inter.Globals["box"].GetValue()
+		require.IsType(t, &interpreter.CompositeValue{}, box)
+
+		assert.Equal(t,
+			interpreter.CompositeStaticType{
+				Location:            common.StringLocation("test"),
+				QualifiedIdentifier: "Box",
+				TypeArguments: []interpreter.StaticType{
+					interpreter.PrimitiveStaticTypeUInt8,
+				},
+			},
+			box.(*interpreter.CompositeValue).StaticType(),
+		)
+
+		assert.Equal(t,
+			interpreter.UInt8Value(1),
+			inter.Globals["value"].GetValue(),
+		)
+
+		assert.Equal(t,
+			interpreter.NewArrayValueUnownedNonCopying(
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.PrimitiveStaticTypeUInt8,
+				},
+				interpreter.UInt8Value(1),
+			),
+			inter.Globals["values"].GetValue(),
+		)
+	})
+
+	t.Run("dynamic casting", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+          }
+
+          let box: AnyStruct = Box(value: 1)
+          let intBox = box as? Box<Int>
+          let stringBox = box as? Box<String>
+        `)
+
+		require.IsType(t,
+			&interpreter.SomeValue{},
+			inter.Globals["intBox"].GetValue(),
+		)
+
+		assert.Equal(t,
+			interpreter.NilValue{},
+			inter.Globals["stringBox"].GetValue(),
+		)
+	})
+
+	t.Run("generic function in generic struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          struct Box<T> {
+              pub let value: T
+
+              init(value: T) {
+                  self.value = value
+              }
+
+              pub fun map<U>(_ f: ((T): U)): Box<U> {
+                  return Box<U>(value: f(self.value))
+              }
+          }
+
+          let box = Box(value: 1).map(fun (_ value: Int): String {
+              return value.toString()
+          })
+          let value = box.value
+        `)
+
+		box := inter.Globals["box"].GetValue()
+		require.IsType(t, &interpreter.CompositeValue{}, box)
+
+		assert.Equal(t,
+			[]interpreter.StaticType{
+				interpreter.PrimitiveStaticTypeString,
+			},
+			box.(*interpreter.CompositeValue).TypeArguments(),
+		)
+
+		assert.Equal(t,
+			interpreter.NewStringValue("1"),
+			inter.Globals["value"].GetValue(),
+		)
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          resource Item {
+              let id: Int
+
+              init(id: Int) {
+                  self.id = id
+              }
+          }
+
+          resource Holder<T: AnyResource> {
+              pub var item: @T?
+
+              init(item: @T) {
+                  self.item <- item
+              }
+
+              pub fun take(): @T {
+                  let item <- self.item <- nil
+                  return <-item!
+              }
+
+              destroy() {
+                  destroy self.item
+              }
+          }
+
+          fun test(): Int {
+              let holder <- create Holder(item: <-create Item(id: 42))
+              let item <- holder.take()
+              let id = item.id
+              destroy item
+              destroy holder
+              return id
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			interpreter.NewIntValueFromInt64(42),
+			value,
+		)
+	})
+}
@@ -1434,6 +1434,109 @@ func TestInterpretConditionalOperator(t *testing.T) {
 	)
 }
 
+func TestInterpretConditionalOperatorCommonSuperType(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+       let x = true ? 1 : nil
+       let y = false ? 1 : nil
+    `)
+
+	assert.Equal(t,
+		interpreter.NewSomeValueOwningNonCopying(
+			interpreter.NewIntValueFromInt64(1),
+		),
+		inter.Globals["x"].GetValue(),
+	)
+
+	assert.Equal(t,
+		interpreter.NilValue{},
+		inter.Globals["y"].GetValue(),
+	)
+}
+
+func TestInterpretArrayCommonSuperType(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+       let xs = [1, nil]
+    `)
+
+	assert.Equal(t,
+		interpreter.NewArrayValueUnownedNonCopying(
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.OptionalStaticType{
+					Type: interpreter.PrimitiveStaticTypeInt,
+				},
+			},
+			interpreter.NewSomeValueOwningNonCopying(
+				interpreter.NewIntValueFromInt64(1),
+			),
+			interpreter.NilValue{},
+		),
+		inter.Globals["xs"].GetValue(),
+	)
+}
+
+func TestInterpretNestedArrayCommonSuperType(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+       let xs = [[1], [nil]]
+       let ys = {"a": [1], "b": [nil]}
+    `)
+
+	assert.Equal(t,
+		interpreter.NewArrayValueUnownedNonCopying(
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.VariableSizedStaticType{
+					Type: interpreter.OptionalStaticType{
+						Type: interpreter.PrimitiveStaticTypeInt,
+					},
+				},
+			},
+			interpreter.NewArrayValueUnownedNonCopying(
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.OptionalStaticType{
+						Type: interpreter.PrimitiveStaticTypeInt,
+					},
+				},
+				interpreter.NewSomeValueOwningNonCopying(
+					interpreter.NewIntValueFromInt64(1),
+				),
+			),
+			interpreter.NewArrayValueUnownedNonCopying(
+				interpreter.VariableSizedStaticType{
+					Type: interpreter.OptionalStaticType{
+						Type: interpreter.PrimitiveStaticTypeInt,
+					},
+				},
+				interpreter.NilValue{},
+			),
+		),
+		inter.Globals["xs"].GetValue(),
+	)
+
+	ys := inter.Globals["ys"].GetValue().(*interpreter.DictionaryValue)
+
+	assert.Equal(t,
+		interpreter.NewArrayValueUnownedNonCopying(
+			interpreter.VariableSizedStaticType{
+				Type: interpreter.OptionalStaticType{
+					Type: interpreter.PrimitiveStaticTypeInt,
+				},
+			},
+			interpreter.NewSomeValueOwningNonCopying(
+				interpreter.NewIntValueFromInt64(1),
+			),
+		),
+		ys.Get(inter, nil, interpreter.NewStringValue("a")).(*interpreter.SomeValue).Value,
+	)
+}
+
 func TestInterpretFunctionBindingInFunction(t *testing.T) {
 
 	t.Parallel()
@@ -2780,6 +2883,23 @@ func TestInterpretNilCoalescingNilLiteralInt(t *testing.T) {
 	)
 }
 
+func TestInterpretNilCoalescingCommonSuperType(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let one: Int? = 1
+      let x = one ?? nil
+    `)
+
+	assert.Equal(t,
+		interpreter.NewSomeValueOwningNonCopying(
+			interpreter.NewIntValueFromInt64(1),
+		),
+		inter.Globals["x"].GetValue(),
+	)
+}
+
 func TestInterpretNilCoalescingShortCircuitLeftSuccess(t *testing.T) {
 
 	t.Parallel()
@@ -6030,11 +6150,23 @@ func TestInterpretEmitEventParameterTypes(t *testing.T) {
 			value: interpreter.Fix64Value(123000000),
 			ty:    sema.Fix64Type,
 		},
+		"Fix128": {
+			value: interpreter.NewFix128ValueFromBigInt(
+				new(big.Int).Mul(big.NewInt(123), new(big.Int).Exp(big.NewInt(10), big.NewInt(22), nil)),
+			),
+			ty: sema.Fix128Type,
+		},
 		// UFix*
 		"UFix64": {
 			value: interpreter.UFix64Value(123000000),
 			ty:    sema.UFix64Type,
 		},
+		"UFix128": {
+			value: interpreter.NewUFix128ValueFromBigInt(
+				new(big.Int).Mul(big.NewInt(123), new(big.Int).Exp(big.NewInt(10), big.NewInt(22), nil)),
+			),
+			ty: sema.UFix128Type,
+		},
 		// TODO:
 		//// Struct
 		//"S": {
@@ -8542,3 +8674,267 @@ func TestHostFunctionStaticType(t *testing.T) {
 		assert.Equal(t, xValue.StaticType(), yValue.StaticType())
 	})
 }
+
+func TestInterpretArrayFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = [3, 1, 2]
+
+      fun mapped(): [String] {
+          return xs.map(fun (x: Int): String {
+              return x.toString()
+          })
+      }
+
+      fun filtered(): [Int] {
+          return xs.filter(fun (x: Int): Bool {
+              return x > 1
+          })
+      }
+
+      fun sum(): Int {
+          return xs.reduce(initial: 10, fun (acc: Int, x: Int): Int {
+              return acc + x
+          })
+      }
+
+      fun index(): Int? {
+          return xs.firstIndex(where: fun (x: Int): Bool {
+              return x < 3
+          })
+      }
+
+      fun missingIndex(): Int? {
+          return xs.firstIndex(where: fun (x: Int): Bool {
+              return x > 3
+          })
+      }
+
+      fun reversed(): [Int] {
+          return xs.reverse()
+      }
+
+      fun sliced(): [Int] {
+          return xs.slice(from: 1, upTo: 3)
+      }
+
+      fun sorted(): [Int] {
+          let ys = [3, 1, 2]
+          ys.sort(by: fun (a: Int, b: Int): Bool {
+              return a < b
+          })
+          return ys
+      }
+
+      fun optionalParameter(): [Bool] {
+          return xs.map(fun (x: Int?): Bool {
+              return x != nil
+          })
+      }
+    `)
+
+	intValues := func(values ...int64) []interpreter.Value {
+		result := make([]interpreter.Value, len(values))
+		for i, value := range values {
+			result[i] = interpreter.NewIntValueFromInt64(value)
+		}
+		return result
+	}
+
+	for name, expected := range map[string][]interpreter.Value{
+		"mapped": {
+			interpreter.NewStringValue("3"),
+			interpreter.NewStringValue("1"),
+			interpreter.NewStringValue("2"),
+		},
+		"filtered": intValues(3, 2),
+		"reversed": intValues(2, 1, 3),
+		"sliced":   intValues(1, 2),
+		"sorted":   intValues(1, 2, 3),
+		"optionalParameter": {
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(true),
+		},
+	} {
+		value, err := inter.Invoke(name)
+		require.NoError(t, err, name)
+
+		assert.Equal(t,
+			expected,
+			value.(*interpreter.ArrayValue).Elements(),
+		)
+	}
+
+	value, err := inter.Invoke("sum")
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		interpreter.NewIntValueFromInt64(16),
+		value,
+	)
+
+	value, err = inter.Invoke("index")
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		interpreter.NewSomeValueOwningNonCopying(
+			interpreter.NewIntValueFromInt64(1),
+		),
+		value,
+	)
+
+	value, err = inter.Invoke("missingIndex")
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		interpreter.NilValue{},
+		value,
+	)
+
+	// The original array is not modified
+
+	assert.Equal(t,
+		intValues(3, 1, 2),
+		inter.Globals["xs"].GetValue().(*interpreter.ArrayValue).Elements(),
+	)
+}
+
+func TestInterpretArraySliceInvalidIndices(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(from: Int, upTo: Int): [Int] {
+          return [1, 2, 3].slice(from: from, upTo: upTo)
+      }
+    `)
+
+	for _, indices := range [][2]int64{{-1, 1}, {2, 1}, {0, 4}} {
+
+		_, err := inter.Invoke(
+			"test",
+			interpreter.NewIntValueFromInt64(indices[0]),
+			interpreter.NewIntValueFromInt64(indices[1]),
+		)
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.ArraySliceIndicesError{})
+	}
+}
+
+func TestInterpretSortIsStable(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): String {
+          let xs = ["bb", "a", "cc", "b", "aa"]
+          xs.sort(by: fun (a: String, b: String): Bool {
+              return a.length < b.length
+          })
+          return String.join(xs, separator: ",")
+      }
+    `)
+
+	value, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		interpreter.NewStringValue("a,b,bb,cc,aa"),
+		value,
+	)
+}
+
+func TestInterpretDictionaryFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let xs = {"a": 1, "b": 2, "c": 3}
+
+      fun filteredKeys(): [String] {
+          return xs.filter(fun (key: String, value: Int): Bool {
+              return key != "a" && value != 3
+          }).keys
+      }
+
+      fun mappedValues(): [Bool] {
+          return xs.mapValues(fun (value: Int): Bool {
+              return value > 1
+          }).values
+      }
+
+      fun visitedKeys(): [String] {
+          let keys: [String] = []
+          xs.forEachKey(fun (key: String): Bool {
+              keys.append(key)
+              return key != "b"
+          })
+          return keys
+      }
+    `)
+
+	for name, expected := range map[string][]interpreter.Value{
+		"filteredKeys": {
+			interpreter.NewStringValue("b"),
+		},
+		"mappedValues": {
+			interpreter.BoolValue(false),
+			interpreter.BoolValue(true),
+			interpreter.BoolValue(true),
+		},
+		"visitedKeys": {
+			interpreter.NewStringValue("a"),
+			interpreter.NewStringValue("b"),
+		},
+	} {
+		value, err := inter.Invoke(name)
+		require.NoError(t, err, name)
+
+		assert.Equal(t,
+			expected,
+			value.(*interpreter.ArrayValue).Elements(),
+		)
+	}
+}
+
+func TestInterpretArrayAndDictionaryFunctionsMetering(t *testing.T) {
+
+	t.Parallel()
+
+	var iterations int
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		`
+          fun test() {
+              let xs = [1, 2, 3]
+              let ys = xs.map(fun (x: Int): Int {
+                  return x * 2
+              })
+
+              let zs = {"a": 1, "b": 2}
+              let filtered = zs.filter(fun (key: String, value: Int): Bool {
+                  return value > 1
+              })
+          }
+        `,
+		ParseCheckAndInterpretOptions{
+			Options: []interpreter.Option{
+				interpreter.WithOnLoopIterationHandler(
+					func(_ *interpreter.Interpreter, _ int) {
+						iterations++
+					},
+				),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, iterations)
+}
@@ -0,0 +1,492 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+func parseCheckAndInterpretWithMath(t *testing.T, code string) *interpreter.Interpreter {
+
+	valueDeclarations := stdlib.BuiltinValues()
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		code,
+		ParseCheckAndInterpretOptions{
+			CheckerOptions: []sema.Option{
+				sema.WithPredeclaredValues(valueDeclarations.ToSemaValueDeclarations()),
+			},
+			Options: []interpreter.Option{
+				interpreter.WithPredeclaredValues(valueDeclarations.ToInterpreterValueDeclarations()),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	return inter
+}
+
+func TestInterpretPow(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllIntegerTypes {
+
+		switch ty {
+		case sema.IntegerType, sema.SignedIntegerType:
+			continue
+		}
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      let x: %s = 3
+                      let a = x.pow(4) == 81
+                      let b = x.pow(1) == 3
+                      let c = x.pow(0) == 1
+                    `,
+					ty,
+				),
+			)
+
+			for _, name := range []string{"a", "b", "c"} {
+				assert.Equal(t,
+					interpreter.BoolValue(true),
+					inter.Globals[name].GetValue(),
+					name,
+				)
+			}
+		})
+	}
+
+	t.Run("Int, large", func(t *testing.T) {
+
+		inter := parseCheckAndInterpret(t, `
+          let x = 10
+          let y = x.pow(40)
+        `)
+
+		assert.Equal(t,
+			"10000000000000000000000000000000000000000",
+			inter.Globals["y"].GetValue().String(),
+		)
+	})
+
+	t.Run("Int, negative base", func(t *testing.T) {
+
+		inter := parseCheckAndInterpret(t, `
+          let x = -2
+          let y = x.pow(3)
+        `)
+
+		assert.Equal(t,
+			interpreter.NewIntValueFromInt64(-8),
+			inter.Globals["y"].GetValue(),
+		)
+	})
+
+	t.Run("Word8, wrap around", func(t *testing.T) {
+
+		inter := parseCheckAndInterpret(t, `
+          let x: Word8 = 3
+          let y = x.pow(6)
+        `)
+
+		// 3^6 = 729 = 2 * 256 + 217
+
+		assert.Equal(t,
+			interpreter.Word8Value(217),
+			inter.Globals["y"].GetValue(),
+		)
+	})
+}
+
+func TestInterpretPowOverflow(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllIntegerTypes {
+
+		switch ty {
+		case sema.IntegerType, sema.SignedIntegerType,
+			sema.IntType, sema.UIntType:
+			continue
+		}
+
+		if strings.HasPrefix(ty.String(), "Word") {
+			continue
+		}
+
+		isSigned := sema.IsSubType(ty, sema.SignedIntegerType)
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      fun overflow(): %[1]s {
+                          return %[1]s.max.pow(2)
+                      }
+
+                      fun underflow(): %[1]s {
+                          return %[1]s.min.pow(3)
+                      }
+
+                      let saturatedMax = %[1]s.max.saturatingPow(2) == %[1]s.max
+                      let saturatedMin = %[1]s.min.saturatingPow(3) == %[1]s.min
+                    `,
+					ty,
+				),
+			)
+
+			_, err := inter.Invoke("overflow")
+			require.ErrorAs(t, err, &interpreter.OverflowError{})
+
+			if isSigned {
+				_, err = inter.Invoke("underflow")
+				require.ErrorAs(t, err, &interpreter.UnderflowError{})
+			}
+
+			assert.Equal(t,
+				interpreter.BoolValue(true),
+				inter.Globals["saturatedMax"].GetValue(),
+			)
+			assert.Equal(t,
+				interpreter.BoolValue(true),
+				inter.Globals["saturatedMin"].GetValue(),
+			)
+		})
+	}
+}
+
+func TestInterpretSqrt(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllIntegerTypes {
+
+		switch ty {
+		case sema.IntegerType, sema.SignedIntegerType:
+			continue
+		}
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      let a = (%[1]s(16)).sqrt() == 4
+                      let b = (%[1]s(17)).sqrt() == 4
+                      let c = (%[1]s(0)).sqrt() == 0
+                    `,
+					ty,
+				),
+			)
+
+			for _, name := range []string{"a", "b", "c"} {
+				assert.Equal(t,
+					interpreter.BoolValue(true),
+					inter.Globals[name].GetValue(),
+					name,
+				)
+			}
+		})
+	}
+
+	t.Run("UFix64", func(t *testing.T) {
+
+		inter := parseCheckAndInterpret(t, `
+          let x: UFix64 = 2.0
+          let y = x.sqrt()
+        `)
+
+		assert.Equal(t,
+			interpreter.UFix64Value(141421356),
+			inter.Globals["y"].GetValue(),
+		)
+	})
+
+	t.Run("Fix64, fraction", func(t *testing.T) {
+
+		inter := parseCheckAndInterpret(t, `
+          let x: Fix64 = 0.25
+          let y = x.sqrt()
+        `)
+
+		assert.Equal(t,
+			interpreter.Fix64Value(50000000),
+			inter.Globals["y"].GetValue(),
+		)
+	})
+
+	t.Run("Fix128", func(t *testing.T) {
+
+		inter := parseCheckAndInterpret(t, `
+          let x: Fix128 = 2.0
+          let y = x.sqrt()
+        `)
+
+		assert.Equal(t,
+			interpreter.NewFix128ValueFromBigInt(
+				mustParseFix128("1.414213562373095048801688"),
+			),
+			inter.Globals["y"].GetValue(),
+		)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): Fix64 {
+              let x: Fix64 = -1.0
+              return x.sqrt()
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.NegativeSquareRootError{})
+	})
+}
+
+func TestInterpretAbs(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllSignedIntegerTypes {
+
+		if ty == sema.SignedIntegerType {
+			continue
+		}
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      let a = (%[1]s(-42)).abs() == 42
+                      let b = (%[1]s(42)).abs() == 42
+                      let c = (%[1]s(0)).abs() == 0
+                    `,
+					ty,
+				),
+			)
+
+			for _, name := range []string{"a", "b", "c"} {
+				assert.Equal(t,
+					interpreter.BoolValue(true),
+					inter.Globals[name].GetValue(),
+					name,
+				)
+			}
+		})
+	}
+
+	t.Run("Fix64", func(t *testing.T) {
+
+		inter := parseCheckAndInterpret(t, `
+          let x: Fix64 = -1.5
+          let y = x.abs()
+        `)
+
+		assert.Equal(t,
+			interpreter.Fix64Value(150000000),
+			inter.Globals["y"].GetValue(),
+		)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): Int8 {
+              return Int8.min.abs()
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.OverflowError{})
+	})
+}
+
+func TestInterpretMinMax(t *testing.T) {
+
+	t.Parallel()
+
+	for _, ty := range sema.AllNumberTypes {
+
+		switch ty {
+		case sema.NumberType, sema.SignedNumberType,
+			sema.IntegerType, sema.SignedIntegerType,
+			sema.FixedPointType, sema.SignedFixedPointType:
+			continue
+		}
+
+		var suffix string
+		if sema.IsSubType(ty, sema.FixedPointType) {
+			suffix = ".0"
+		}
+
+		t.Run(ty.String(), func(t *testing.T) {
+
+			inter := parseCheckAndInterpret(t,
+				fmt.Sprintf(
+					`
+                      let x: %[1]s = 1%[2]s
+                      let y: %[1]s = 2%[2]s
+                      let a = x.min(y) == x
+                      let b = x.max(y) == y
+                      let c = y.min(x) == x
+                      let d = y.max(x) == y
+                      let e = x.min(x) == x
+                    `,
+					ty,
+					suffix,
+				),
+			)
+
+			for _, name := range []string{"a", "b", "c", "d", "e"} {
+				assert.Equal(t,
+					interpreter.BoolValue(true),
+					inter.Globals[name].GetValue(),
+					name,
+				)
+			}
+		})
+	}
+}
+
+func TestInterpretMulDiv(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("UFix64", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithMath(t, `
+          let x: UFix64 = 2.0
+          let truncated = x.mulDiv(1.0, 3.0, rounding: RoundingRule.towardZero)
+          let rounded = x.mulDiv(1.0, 3.0, rounding: RoundingRule.nearestHalfEven)
+        `)
+
+		assert.Equal(t,
+			interpreter.UFix64Value(66666666),
+			inter.Globals["truncated"].GetValue(),
+		)
+		assert.Equal(t,
+			interpreter.UFix64Value(66666667),
+			inter.Globals["rounded"].GetValue(),
+		)
+	})
+
+	t.Run("Fix64, intermediate overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithMath(t, `
+          let x = Fix64.max
+          let y = x.mulDiv(-2.0, 4.0, rounding: RoundingRule.towardZero)
+        `)
+
+		assert.Equal(t,
+			interpreter.Fix64Value(-4611686018427387903),
+			inter.Globals["y"].GetValue(),
+		)
+	})
+
+	t.Run("UInt256, intermediate overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithMath(t, `
+          let x = UInt256.max
+          let y = x.mulDiv(x, x, rounding: RoundingRule.towardZero) == x
+        `)
+
+		assert.Equal(t,
+			interpreter.BoolValue(true),
+			inter.Globals["y"].GetValue(),
+		)
+	})
+
+	t.Run("Int, rounding", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithMath(t, `
+          let x = 7
+          let a = x.mulDiv(1, 2, rounding: RoundingRule.towardZero)
+          let b = x.mulDiv(1, 2, rounding: RoundingRule.awayFromZero)
+          let c = x.mulDiv(-1, 2, rounding: RoundingRule.nearestHalfEven)
+        `)
+
+		assert.Equal(t,
+			interpreter.NewIntValueFromInt64(3),
+			inter.Globals["a"].GetValue(),
+		)
+		assert.Equal(t,
+			interpreter.NewIntValueFromInt64(4),
+			inter.Globals["b"].GetValue(),
+		)
+		assert.Equal(t,
+			interpreter.NewIntValueFromInt64(-4),
+			inter.Globals["c"].GetValue(),
+		)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithMath(t, `
+          fun test(): UFix64 {
+              let x = UFix64.max
+              return x.mulDiv(2.0, 1.0, rounding: RoundingRule.towardZero)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.OverflowError{})
+	})
+
+	t.Run("division by zero", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithMath(t, `
+          fun test(): UInt {
+              let x: UInt = 1
+              return x.mulDiv(1, 0, rounding: RoundingRule.towardZero)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.ErrorAs(t, err, &interpreter.DivisionByZeroError{})
+	})
+}
@@ -0,0 +1,116 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestInterpretOptionalNarrowing(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      struct S {
+          let n: Int
+          init(n: Int) {
+              self.n = n
+          }
+      }
+
+      fun testThen(_ x: Int?): Int {
+          if x != nil && x > 1 {
+              return x + 1
+          }
+          return 0
+      }
+
+      fun testEarlyReturn(_ s: S?): Int {
+          if s == nil {
+              return 0
+          }
+          return s.n
+      }
+
+      fun testOptionalUses(_ x: Int?): Int? {
+          if x != nil {
+              let y: Int? = x
+              if x == y {
+                  return (x ?? 0) + x!
+              }
+          }
+          return nil
+      }
+    `)
+
+	for name, cases := range map[string][]struct {
+		argument interpreter.Value
+		expected interpreter.Value
+	}{
+		"testThen": {
+			{
+				argument: interpreter.NewSomeValueOwningNonCopying(interpreter.NewIntValueFromInt64(2)),
+				expected: interpreter.NewIntValueFromInt64(3),
+			},
+			{
+				argument: interpreter.NilValue{},
+				expected: interpreter.NewIntValueFromInt64(0),
+			},
+		},
+		"testEarlyReturn": {
+			{
+				argument: interpreter.NilValue{},
+				expected: interpreter.NewIntValueFromInt64(0),
+			},
+		},
+		"testOptionalUses": {
+			{
+				argument: interpreter.NewSomeValueOwningNonCopying(interpreter.NewIntValueFromInt64(2)),
+				expected: interpreter.NewSomeValueOwningNonCopying(interpreter.NewIntValueFromInt64(4)),
+			},
+			{
+				argument: interpreter.NilValue{},
+				expected: interpreter.NilValue{},
+			},
+		},
+	} {
+		for _, testCase := range cases {
+			value, err := inter.Invoke(name, testCase.argument)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expected, value)
+		}
+	}
+
+	t.Run("member access", func(t *testing.T) {
+
+		s, err := inter.Invoke("S", interpreter.NewIntValueFromInt64(42))
+		require.NoError(t, err)
+
+		value, err := inter.Invoke("testEarlyReturn", interpreter.NewSomeValueOwningNonCopying(s))
+		require.NoError(t, err)
+
+		assert.Equal(t, interpreter.NewIntValueFromInt64(42), value)
+	})
+}
@@ -0,0 +1,221 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+)
+
+func TestInterpretHostFunctionSideEffectInCondition(t *testing.T) {
+
+	t.Parallel()
+
+	semaValueDeclarations, interpreterValueDeclarations := sideEffectValueDeclarations()
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		`
+          fun preCondition() {
+              pre {
+                  sideEffect()
+              }
+          }
+
+          fun postCondition() {
+              post {
+                  before(sideEffect())
+              }
+          }
+
+          fun callee(): Bool {
+              return sideEffect()
+          }
+
+          fun indirect() {
+              pre {
+                  callee()
+              }
+          }
+
+          var count = 0
+
+          fun body(): Bool {
+              count = count + 1
+              return sideEffect()
+          }
+        `,
+		ParseCheckAndInterpretOptions{
+			CheckerOptions: []sema.Option{
+				sema.WithPredeclaredValues(semaValueDeclarations),
+			},
+			Options: []interpreter.Option{
+				interpreter.WithPredeclaredValues(interpreterValueDeclarations),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	for _, name := range []string{"preCondition", "postCondition", "indirect"} {
+		_, err = inter.Invoke(name)
+		require.Error(t, err)
+		require.ErrorAs(t, err, &interpreter.SideEffectInConditionError{})
+	}
+
+	result, err := inter.Invoke("body")
+	require.NoError(t, err)
+	require.Equal(t, interpreter.BoolValue(true), result)
+}
+
+// sideEffectValueDeclarations returns the declarations of a host function `sideEffect`,
+// which is declared to be a view function, but which has a side effect
+//
+func sideEffectValueDeclarations() ([]sema.ValueDeclaration, []interpreter.ValueDeclaration) {
+
+	valueDeclarations := stdlib.StandardLibraryValues{
+		{
+			Name: "sideEffect",
+			Type: &sema.FunctionType{
+				Purity: ast.FunctionPurityView,
+				ReturnTypeAnnotation: sema.NewTypeAnnotation(
+					sema.BoolType,
+				),
+			},
+			Value: interpreter.NewHostFunctionValue(
+				func(invocation interpreter.Invocation) interpreter.Value {
+					invocation.Interpreter.ExpectNoSideEffects(
+						"side effect",
+						invocation.GetLocationRange,
+					)
+					return interpreter.BoolValue(true)
+				},
+				nil,
+			),
+			Kind: common.DeclarationKindConstant,
+		},
+	}
+
+	return valueDeclarations.ToSemaValueDeclarations(),
+		valueDeclarations.ToInterpreterValueDeclarations()
+}
+
+func TestInterpretHostFunctionSideEffectInViewFunction(t *testing.T) {
+
+	t.Parallel()
+
+	semaValueDeclarations, interpreterValueDeclarations := sideEffectValueDeclarations()
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		`
+          view fun declared(): Bool {
+              return sideEffect()
+          }
+
+          fun inferred(): Bool {
+              return sideEffect()
+          }
+
+          struct S {
+              fun inferred(): Bool {
+                  return sideEffect()
+              }
+          }
+
+          fun member(): Bool {
+              return S().inferred()
+          }
+        `,
+		ParseCheckAndInterpretOptions{
+			CheckerOptions: []sema.Option{
+				sema.WithPredeclaredValues(semaValueDeclarations),
+			},
+			Options: []interpreter.Option{
+				interpreter.WithPredeclaredValues(interpreterValueDeclarations),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	for _, name := range []string{"declared", "inferred", "member"} {
+		_, err = inter.Invoke(name)
+		require.Error(t, err)
+		require.ErrorAs(t, err, &interpreter.SideEffectInViewFunctionError{})
+	}
+}
+
+func TestInterpretInterfaceFunctionSideEffectInCondition(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      struct interface Counter {
+          fun get(): Int
+
+          fun test() {
+              pre {
+                  self.get() >= 0
+              }
+          }
+      }
+
+      struct Pure: Counter {
+          fun get(): Int {
+              return 1
+          }
+
+          fun test() {}
+      }
+
+      struct Impure: Counter {
+          var count: Int
+
+          init() {
+              self.count = 0
+          }
+
+          fun get(): Int {
+              self.count = self.count + 1
+              return self.count
+          }
+
+          fun test() {}
+      }
+
+      fun testPure() {
+          Pure().test()
+      }
+
+      fun testImpure() {
+          Impure().test()
+      }
+    `)
+
+	_, err := inter.Invoke("testPure")
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("testImpure")
+	require.Error(t, err)
+	require.ErrorAs(t, err, &interpreter.SideEffectInConditionError{})
+}
@@ -0,0 +1,223 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+func TestInterpretRange(t *testing.T) {
+
+	t.Parallel()
+
+	collectElements := func(t *testing.T, elementType string, rangeCode string) []interpreter.Value {
+		inter := parseCheckAndInterpret(t, `
+          fun test(): [`+elementType+`] {
+              var xs: [`+elementType+`] = []
+              for x in `+rangeCode+` {
+                  xs.append(x)
+              }
+              return xs
+          }
+        `)
+
+		value, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		require.IsType(t, value, &interpreter.ArrayValue{})
+		return value.(*interpreter.ArrayValue).Elements()
+	}
+
+	t.Run("exclusive", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Equal(t,
+			[]interpreter.Value{
+				interpreter.NewIntValueFromInt64(0),
+				interpreter.NewIntValueFromInt64(1),
+				interpreter.NewIntValueFromInt64(2),
+			},
+			collectElements(t, "Int", "0..<3"),
+		)
+	})
+
+	t.Run("inclusive", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Equal(t,
+			[]interpreter.Value{
+				interpreter.UInt8Value(1),
+				interpreter.UInt8Value(2),
+				interpreter.UInt8Value(3),
+			},
+			collectElements(t, "UInt8", "1...(3 as UInt8)"),
+		)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Empty(t, collectElements(t, "Int", "5..<0"))
+		assert.Empty(t, collectElements(t, "Int", "5..<5"))
+	})
+
+	t.Run("stride", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Equal(t,
+			[]interpreter.Value{
+				interpreter.NewIntValueFromInt64(0),
+				interpreter.NewIntValueFromInt64(3),
+				interpreter.NewIntValueFromInt64(6),
+			},
+			collectElements(t, "Int", "(0...6).stride(by: 3)"),
+		)
+	})
+
+	t.Run("negative stride", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Equal(t,
+			[]interpreter.Value{
+				interpreter.Int8Value(10),
+				interpreter.Int8Value(7),
+				interpreter.Int8Value(4),
+				interpreter.Int8Value(1),
+			},
+			collectElements(t, "Int8", "((10 as Int8)..<0).stride(by: -3)"),
+		)
+	})
+
+	t.Run("up to maximum, no overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Equal(t,
+			[]interpreter.Value{
+				interpreter.UInt8Value(253),
+				interpreter.UInt8Value(254),
+				interpreter.UInt8Value(255),
+			},
+			collectElements(t, "UInt8", "(253 as UInt8)...255"),
+		)
+
+		assert.Equal(t,
+			[]interpreter.Value{
+				interpreter.Int8Value(-127),
+				interpreter.Int8Value(-128),
+			},
+			collectElements(t, "Int8", "((-127 as Int8)...(-128)).stride(by: -1)"),
+		)
+	})
+
+	t.Run("members", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let r = (1..<10).stride(by: 3)
+          let start = r.start
+          let end = r.end
+          let step = r.step
+          let isInclusive = r.isInclusive
+          let contains = [r.contains(1), r.contains(4), r.contains(5), r.contains(10), r.contains(-2)]
+        `)
+
+		assert.Equal(t,
+			interpreter.NewIntValueFromInt64(1),
+			inter.Globals["start"].GetValue(),
+		)
+		assert.Equal(t,
+			interpreter.NewIntValueFromInt64(10),
+			inter.Globals["end"].GetValue(),
+		)
+		assert.Equal(t,
+			interpreter.NewIntValueFromInt64(3),
+			inter.Globals["step"].GetValue(),
+		)
+		assert.Equal(t,
+			interpreter.BoolValue(false),
+			inter.Globals["isInclusive"].GetValue(),
+		)
+
+		require.IsType(t, inter.Globals["contains"].GetValue(), &interpreter.ArrayValue{})
+
+		assert.Equal(t,
+			[]interpreter.Value{
+				interpreter.BoolValue(true),
+				interpreter.BoolValue(true),
+				interpreter.BoolValue(false),
+				interpreter.BoolValue(false),
+				interpreter.BoolValue(false),
+			},
+			inter.Globals["contains"].GetValue().(*interpreter.ArrayValue).Elements(),
+		)
+	})
+
+	t.Run("zero stride", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          fun test(): Range<Int> {
+              return (0..<10).stride(by: 0)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		require.Error(t, err)
+
+		require.ErrorAs(t, err, &interpreter.RangeStepZeroError{})
+	})
+
+	t.Run("dynamic type", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, `
+          let r: AnyStruct = 0..<(10 as UInt16)
+          let isRange = (r as? Range) != nil
+          let isUInt16Range = (r as? Range<UInt16>) != nil
+          let isIntRange = (r as? Range<Int>) != nil
+        `)
+
+		assert.Equal(t,
+			interpreter.BoolValue(true),
+			inter.Globals["isRange"].GetValue(),
+		)
+		assert.Equal(t,
+			interpreter.BoolValue(true),
+			inter.Globals["isUInt16Range"].GetValue(),
+		)
+		assert.Equal(t,
+			interpreter.BoolValue(false),
+			inter.Globals["isIntRange"].GetValue(),
+		)
+	})
+}
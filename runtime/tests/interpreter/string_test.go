@@ -19,9 +19,11 @@
 package interpreter_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -190,3 +192,354 @@ func TestInterpretStringToLower(t *testing.T) {
 		result,
 	)
 }
+
+func TestInterpretStringToUpper(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      fun test(): String {
+          return "Flowers".toUpper()
+      }
+	`)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.Equal(t,
+		interpreter.NewStringValue("FLOWERS"),
+		result,
+	)
+}
+
+func TestInterpretStringSplit(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let parts = "a, b, c".split(separator: ", ")
+      let characters = "ab".split(separator: "")
+      let none = "abc".split(separator: "x")
+      let combining = "e\u{301}ee".split(separator: "e")
+	`)
+
+	newStringArray := func(strs ...string) *interpreter.ArrayValue {
+		values := make([]interpreter.Value, len(strs))
+		for i, str := range strs {
+			values[i] = interpreter.NewStringValue(str)
+		}
+		return interpreter.NewArrayValueUnownedNonCopying(
+			interpreter.StringArrayStaticType,
+			values...,
+		)
+	}
+
+	assert.Equal(t,
+		newStringArray("a", "b", "c"),
+		inter.Globals["parts"].GetValue(),
+	)
+
+	assert.Equal(t,
+		newStringArray("a", "b"),
+		inter.Globals["characters"].GetValue(),
+	)
+
+	assert.Equal(t,
+		newStringArray("abc"),
+		inter.Globals["none"].GetValue(),
+	)
+
+	// The combining character is part of the first character,
+	// so the first "e" is not a separator
+
+	assert.Equal(t,
+		newStringArray("e\u0301", "", ""),
+		inter.Globals["combining"].GetValue(),
+	)
+}
+
+func TestInterpretStringJoin(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let joined = String.join(["a", "b", "c"], separator: ", ")
+      let empty = String.join([], separator: ", ")
+	`)
+
+	assert.Equal(t,
+		interpreter.NewStringValue("a, b, c"),
+		inter.Globals["joined"].GetValue(),
+	)
+
+	assert.Equal(t,
+		interpreter.NewStringValue(""),
+		inter.Globals["empty"].GetValue(),
+	)
+}
+
+func TestInterpretStringSearch(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let contains = "flowers".contains("owe")
+      let notContains = "flowers".contains("x")
+      let containsCombining = "cafe\u{301}".contains("cafe")
+      let index = "\u{1F490}flowers".index(of: "owe")
+      let notFound = "flowers".index(of: "x")
+      let hasPrefix = "flowers".hasPrefix("flow")
+      let hasPrefixCombining = "e\u{301}".hasPrefix("e")
+      let hasSuffix = "flowers".hasSuffix("ers")
+      let notHasSuffix = "flowers".hasSuffix("flow")
+	`)
+
+	for name, expected := range map[string]interpreter.Value{
+		"contains":           interpreter.BoolValue(true),
+		"notContains":        interpreter.BoolValue(false),
+		"containsCombining":  interpreter.BoolValue(false),
+		"index":              interpreter.NewSomeValueOwningNonCopying(interpreter.NewIntValueFromInt64(3)),
+		"notFound":           interpreter.NilValue{},
+		"hasPrefix":          interpreter.BoolValue(true),
+		"hasPrefixCombining": interpreter.BoolValue(false),
+		"hasSuffix":          interpreter.BoolValue(true),
+		"notHasSuffix":       interpreter.BoolValue(false),
+	} {
+		assert.Equal(t,
+			expected,
+			inter.Globals[name].GetValue(),
+			name,
+		)
+	}
+}
+
+func TestInterpretStringReplaceAll(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let replaced = "a-b-c".replaceAll(of: "-", with: "+")
+      let emptyOriginal = "abc".replaceAll(of: "", with: "+")
+      let combining = "e\u{301}e".replaceAll(of: "e", with: "x")
+	`)
+
+	assert.Equal(t,
+		interpreter.NewStringValue("a+b+c"),
+		inter.Globals["replaced"].GetValue(),
+	)
+
+	assert.Equal(t,
+		interpreter.NewStringValue("abc"),
+		inter.Globals["emptyOriginal"].GetValue(),
+	)
+
+	assert.Equal(t,
+		interpreter.NewStringValue("e\u0301x"),
+		inter.Globals["combining"].GetValue(),
+	)
+}
+
+func TestInterpretStringTrim(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let trimmed = " \t flowers \n ".trim()
+      let empty = "   ".trim()
+	`)
+
+	assert.Equal(t,
+		interpreter.NewStringValue("flowers"),
+		inter.Globals["trimmed"].GetValue(),
+	)
+
+	assert.Equal(t,
+		interpreter.NewStringValue(""),
+		inter.Globals["empty"].GetValue(),
+	)
+}
+
+func TestInterpretStringCharacters(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let characters = "a\u{1F1E8}\u{1F1E6}e\u{301}".characters()
+	`)
+
+	assert.Equal(t,
+		interpreter.NewArrayValueUnownedNonCopying(
+			interpreter.CharacterArrayStaticType,
+			interpreter.NewStringValue("a"),
+			interpreter.NewStringValue("\U0001F1E8\U0001F1E6"),
+			interpreter.NewStringValue("e\u0301"),
+		),
+		inter.Globals["characters"].GetValue(),
+	)
+}
+
+func TestInterpretStringUTF8(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let bytes = "abc".toUTF8()
+      let decoded = String.fromUTF8([97, 98, 99])
+      let invalid = String.fromUTF8([0xff])
+	`)
+
+	assert.Equal(t,
+		interpreter.ByteSliceToByteArrayValue([]byte("abc")),
+		inter.Globals["bytes"].GetValue(),
+	)
+
+	assert.Equal(t,
+		interpreter.NewSomeValueOwningNonCopying(
+			interpreter.NewStringValue("abc"),
+		),
+		inter.Globals["decoded"].GetValue(),
+	)
+
+	assert.Equal(t,
+		interpreter.NilValue{},
+		inter.Globals["invalid"].GetValue(),
+	)
+}
+
+func TestInterpretCharacterFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let a: Character = "a"
+      let space: Character = " "
+      let seven: Character = "7"
+
+      let string = a.toString()
+      let upper = a.toUpper()
+      let bytes = a.utf8
+      let isLetter = a.isLetter
+      let isWhitespace = space.isWhitespace
+      let isDigit = seven.isDigit
+      let isNotDigit = a.isDigit
+	`)
+
+	for name, expected := range map[string]interpreter.Value{
+		"string":       interpreter.NewStringValue("a"),
+		"upper":        interpreter.NewStringValue("A"),
+		"bytes":        interpreter.ByteSliceToByteArrayValue([]byte("a")),
+		"isLetter":     interpreter.BoolValue(true),
+		"isWhitespace": interpreter.BoolValue(true),
+		"isDigit":      interpreter.BoolValue(true),
+		"isNotDigit":   interpreter.BoolValue(false),
+	} {
+		assert.Equal(t,
+			expected,
+			inter.Globals[name].GetValue(),
+			name,
+		)
+	}
+}
+
+func TestInterpretStringFunctionsMetering(t *testing.T) {
+
+	t.Parallel()
+
+	// String operations are metered by the size of their input in bytes,
+	// e.g. "Blüten" has 6 characters, but 7 bytes
+
+	tests := map[string]struct {
+		expression string
+		iterations int
+	}{
+		"length":     {`"Blüten".length`, 7},
+		"index":      {`"Blüten"[2]`, 7},
+		"utf8":       {`"Blüten".utf8`, 7},
+		"characters": {`"Blüten".characters()`, 7},
+		"concat":     {`"Blüten".concat("!")`, 8},
+		"slice":      {`"Blüten".slice(from: 1, upTo: 3)`, 7},
+		"decodeHex":  {`"cade".decodeHex()`, 4},
+		"toLower":    {`"Blüten".toLower()`, 7},
+		"toUpper":    {`"Blüten".toUpper()`, 7},
+		"toUTF8":     {`"Blüten".toUTF8()`, 7},
+		"split":      {`"a, b".split(separator: ", ")`, 6},
+		"contains":   {`"flowers".contains("x")`, 8},
+		"index(of:)": {`"flowers".index(of: "ow")`, 9},
+		"replaceAll": {`"flowers".replaceAll(of: "s", with: "")`, 8},
+		"trim":       {`" Blüten ".trim()`, 9},
+		"hasPrefix":  {`"Blüten".hasPrefix("Bl")`, 9},
+		"hasSuffix":  {`"Blüten".hasSuffix("en")`, 9},
+		"join":       {`String.join(["Blüten", "a"], separator: ", ")`, 10},
+		"fromUTF8":   {`String.fromUTF8([97, 98, 99])`, 3},
+		"encodeHex":  {`String.encodeHex([1, 2])`, 2},
+		"template":   {`"Blüten \(1)"`, 9},
+	}
+
+	for name, test := range tests {
+
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			var iterations int
+
+			inter, err := parseCheckAndInterpretWithOptions(t,
+				fmt.Sprintf(
+					`
+                      fun test() {
+                          let result = %s
+                      }
+                    `,
+					test.expression,
+				),
+				ParseCheckAndInterpretOptions{
+					Options: []interpreter.Option{
+						interpreter.WithOnLoopIterationHandler(
+							func(_ *interpreter.Interpreter, _ int) {
+								iterations++
+							},
+						),
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			_, err = inter.Invoke("test")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.iterations, iterations)
+		})
+	}
+}
+
+func TestInterpretStringTemplate(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      struct Vault {
+          let balance: UFix64
+
+          init(balance: UFix64) {
+              self.balance = balance
+          }
+      }
+
+      fun test(): String {
+          let vault = Vault(balance: 1.5)
+          let char: Character = "c"
+          let address: Address = 0x1
+          return "Balance: \(vault.balance), \(char), \(1 + 2), \(true), \(address), \(/public/foo), \("\t\("nested")")\\("
+      }
+	`)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	require.Equal(t,
+		interpreter.NewStringValue("Balance: 1.50000000, c, 3, true, 0x1, /public/foo, \tnested\\("),
+		result,
+	)
+}
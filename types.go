@@ -424,6 +424,26 @@ func (UFix64Type) ID() string {
 	return "UFix64"
 }
 
+// Fix128Type
+
+type Fix128Type struct{}
+
+func (Fix128Type) isType() {}
+
+func (Fix128Type) ID() string {
+	return "Fix128"
+}
+
+// UFix128Type
+
+type UFix128Type struct{}
+
+func (UFix128Type) isType() {}
+
+func (UFix128Type) ID() string {
+	return "UFix128"
+}
+
 type ArrayType interface {
 	Type
 	Element() Type